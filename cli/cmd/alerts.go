@@ -0,0 +1,313 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+)
+
+const (
+	alertFiring   = "firing"
+	alertPending  = "pending"
+	alertInactive = "inactive"
+)
+
+type alertsOptions struct {
+	outputFormat string
+}
+
+func newAlertsOptions() *alertsOptions {
+	return &alertsOptions{
+		outputFormat: tableOutput,
+	}
+}
+
+func (o *alertsOptions) validate() error {
+	if o.outputFormat != tableOutput && o.outputFormat != jsonOutput {
+		return fmt.Errorf("--output supports %s and %s", tableOutput, jsonOutput)
+	}
+	return nil
+}
+
+// alertRule is an alerting rule of the control plane's Prometheus, with the
+// state of its most severe alert.
+type alertRule struct {
+	Name     string `json:"name"`
+	Group    string `json:"group"`
+	Severity string `json:"severity"`
+	State    string `json:"state"`
+	Alerts   int    `json:"alerts"`
+	Summary  string `json:"summary"`
+}
+
+// alertStatus is an active alert of the control plane's Prometheus.
+type alertStatus struct {
+	Name     string            `json:"name"`
+	State    string            `json:"state"`
+	Severity string            `json:"severity"`
+	ActiveAt *time.Time        `json:"activeAt,omitempty"`
+	Labels   map[string]string `json:"labels"`
+	Summary  string            `json:"summary"`
+}
+
+func newCmdAlerts() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "alerts [flags]",
+		Short: "List the alerting rules of the control plane, and their alerts",
+		Long: `List the alerting rules of the control plane, and their alerts.
+
+The Prometheus of the control plane evaluates a set of alerting rules,
+installed by "linkerd install":
+  * LinkerdRouteSuccessRateLow: the success rate of a route has been below 90%
+    for 5 minutes
+  * LinkerdProxyRestarted: a proxy restarted in the last 15 minutes
+  * LinkerdTrustAnchorExpiringSoon: the certificate of the trust anchor expires
+    within 30 days
+  * LinkerdCertificateExpiringSoon: a certificate issued by the CA expires
+    within 7 days
+
+The alerts are read from Prometheus through the Kubernetes API. They are not
+sent anywhere unless Prometheus is configured with an Alertmanager.`,
+	}
+
+	cmd.AddCommand(newCmdAlertsList())
+	cmd.AddCommand(newCmdAlertsStatus())
+
+	return cmd
+}
+
+func newCmdAlertsList() *cobra.Command {
+	options := newAlertsOptions()
+
+	cmd := &cobra.Command{
+		Use:   "list [flags]",
+		Short: "List the alerting rules, and whether they're firing",
+		Example: `  # List the alerting rules of the control plane
+  linkerd alerts list`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(); err != nil {
+				return newCliError(exitCodeInvalidFlags, err)
+			}
+
+			kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup)
+			if err != nil {
+				return err
+			}
+			client, err := kubeAPI.NewClient()
+			if err != nil {
+				return err
+			}
+
+			groups, err := kubeAPI.GetPrometheusRules(client, controlPlaneNamespace)
+			if err != nil {
+				return fmt.Errorf("failed to get the alerting rules: %s", err)
+			}
+			return renderAlertRules(newAlertRules(groups), os.Stdout, options.outputFormat)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, "Output format; one of: \"table\" or \"json\"")
+
+	return cmd
+}
+
+func newCmdAlertsStatus() *cobra.Command {
+	options := newAlertsOptions()
+
+	cmd := &cobra.Command{
+		Use:   "status [flags]",
+		Short: "List the pending and firing alerts",
+		Long: `List the pending and firing alerts.
+
+An alert is pending while its rule holds for less than the rule's duration, and
+firing afterwards.`,
+		Example: `  # List the pending and firing alerts of the control plane
+  linkerd alerts status`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(); err != nil {
+				return newCliError(exitCodeInvalidFlags, err)
+			}
+
+			kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup)
+			if err != nil {
+				return err
+			}
+			client, err := kubeAPI.NewClient()
+			if err != nil {
+				return err
+			}
+
+			alerts, err := kubeAPI.GetPrometheusAlerts(client, controlPlaneNamespace)
+			if err != nil {
+				return fmt.Errorf("failed to get the alerts: %s", err)
+			}
+			return renderAlerts(newAlertStatuses(alerts), os.Stdout, options.outputFormat, time.Now())
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, "Output format; one of: \"table\" or \"json\"")
+
+	return cmd
+}
+
+// newAlertRules returns the alerting rules of the groups, sorted by name.
+func newAlertRules(groups []k8s.PromRuleGroup) []alertRule {
+	rules := []alertRule{}
+	for _, group := range groups {
+		for _, rule := range group.Rules {
+			if rule.Type != "alerting" {
+				continue
+			}
+
+			state := alertInactive
+			for _, alert := range rule.Alerts {
+				if alert.State == alertFiring {
+					state = alertFiring
+				} else if alert.State == alertPending && state == alertInactive {
+					state = alertPending
+				}
+			}
+
+			rules = append(rules, alertRule{
+				Name:     rule.Name,
+				Group:    group.Name,
+				Severity: rule.Labels["severity"],
+				State:    state,
+				Alerts:   len(rule.Alerts),
+				Summary:  rule.Annotations["summary"],
+			})
+		}
+	}
+
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].Name < rules[j].Name
+	})
+	return rules
+}
+
+// newAlertStatuses returns the alerts, firing alerts first, then sorted by
+// name and labels.
+func newAlertStatuses(alerts []k8s.PromAlert) []alertStatus {
+	statuses := []alertStatus{}
+	for _, alert := range alerts {
+		labels := make(map[string]string)
+		for k, v := range alert.Labels {
+			if k != "alertname" && k != "severity" {
+				labels[k] = v
+			}
+		}
+
+		statuses = append(statuses, alertStatus{
+			Name:     alert.Labels["alertname"],
+			State:    alert.State,
+			Severity: alert.Labels["severity"],
+			ActiveAt: alert.ActiveAt,
+			Labels:   labels,
+			Summary:  alert.Annotations["summary"],
+		})
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		if statuses[i].State != statuses[j].State {
+			return statuses[i].State == alertFiring
+		}
+		if statuses[i].Name != statuses[j].Name {
+			return statuses[i].Name < statuses[j].Name
+		}
+		return formatAlertLabels(statuses[i].Labels) < formatAlertLabels(statuses[j].Labels)
+	})
+	return statuses
+}
+
+func renderAlertRules(rules []alertRule, w io.Writer, outputFormat string) error {
+	if outputFormat == jsonOutput {
+		out, err := json.MarshalIndent(rules, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s\n", out)
+		return nil
+	}
+
+	if len(rules) == 0 {
+		fmt.Fprintln(w, "No alerting rules found")
+		return nil
+	}
+
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, padding, ' ', 0)
+	fmt.Fprintln(tw, strings.Join([]string{"NAME", "SEVERITY", colorizeHeader("STATE"), "ALERTS", "SUMMARY"}, "\t"))
+	for _, r := range rules {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n", r.Name, r.Severity, formatAlertState(r.State), r.Alerts, r.Summary)
+	}
+	tw.Flush()
+
+	fmt.Fprint(w, buf.String())
+	return nil
+}
+
+func renderAlerts(alerts []alertStatus, w io.Writer, outputFormat string, now time.Time) error {
+	if outputFormat == jsonOutput {
+		out, err := json.MarshalIndent(alerts, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s\n", out)
+		return nil
+	}
+
+	if len(alerts) == 0 {
+		fmt.Fprintln(w, "No alerts are pending or firing")
+		return nil
+	}
+
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, padding, ' ', 0)
+	fmt.Fprintln(tw, strings.Join([]string{"NAME", colorizeHeader("STATE"), "SEVERITY", "ACTIVE", "LABELS"}, "\t"))
+	for _, a := range alerts {
+		active := "-"
+		if a.ActiveAt != nil {
+			active = now.Sub(*a.ActiveAt).Round(time.Second).String()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", a.Name, formatAlertState(a.State), a.Severity, active, formatAlertLabels(a.Labels))
+	}
+	tw.Flush()
+
+	fmt.Fprint(w, buf.String())
+	return nil
+}
+
+// formatAlertState colorizes the firing states in red and the pending states
+// in yellow. All the states are colorized to keep the column aligned.
+func formatAlertState(state string) string {
+	switch state {
+	case alertFiring:
+		return colorize(colorRed, state)
+	case alertPending:
+		return colorize(colorYellow, state)
+	default:
+		return colorize(colorDefault, state)
+	}
+}
+
+// formatAlertLabels formats the labels of an alert as key=value pairs, sorted
+// by key.
+func formatAlertLabels(labels map[string]string) string {
+	pairs := []string{}
+	for k, v := range labels {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestRenderAlerts(t *testing.T) {
+	now := time.Date(2018, 10, 20, 10, 30, 0, 0, time.UTC)
+	activeAt := now.Add(-25 * time.Minute)
+
+	t.Run("Lists the alerting rules with the state of their alerts", func(t *testing.T) {
+		groups := []k8s.PromRuleGroup{
+			{
+				Name: "linkerd",
+				Rules: []k8s.PromRule{
+					{
+						Type:        "alerting",
+						Name:        "LinkerdProxyRestarted",
+						Labels:      map[string]string{"severity": "warning"},
+						Annotations: map[string]string{"summary": "A proxy restarted in the last 15 minutes"},
+						Alerts:      []k8s.PromAlert{{State: alertPending}, {State: alertFiring}},
+					},
+					{
+						Type:        "alerting",
+						Name:        "LinkerdCertificateExpiringSoon",
+						Labels:      map[string]string{"severity": "critical"},
+						Annotations: map[string]string{"summary": "A certificate issued by the CA expires within 7 days"},
+					},
+					{
+						Type: "recording",
+						Name: "route:success:rate5m",
+					},
+				},
+			},
+		}
+
+		var buf bytes.Buffer
+		if err := renderAlertRules(newAlertRules(groups), &buf, tableOutput); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		diffCompareFile(t, buf.String(), "alerts_list_output.golden")
+	})
+
+	t.Run("Lists the firing alerts first", func(t *testing.T) {
+		alerts := []k8s.PromAlert{
+			{
+				Labels:   map[string]string{"alertname": "LinkerdRouteSuccessRateLow", "severity": "warning", "namespace": "emojivoto", "dst": "web-svc.emojivoto.svc.cluster.local:80", "rt_route": "GET /api/list"},
+				State:    alertPending,
+				ActiveAt: &activeAt,
+			},
+			{
+				Labels:   map[string]string{"alertname": "LinkerdProxyRestarted", "severity": "warning", "namespace": "emojivoto", "pod": "web-6b7f9d5c4-jq2bk"},
+				State:    alertFiring,
+				ActiveAt: &activeAt,
+			},
+		}
+
+		var buf bytes.Buffer
+		if err := renderAlerts(newAlertStatuses(alerts), &buf, tableOutput, now); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		diffCompareFile(t, buf.String(), "alerts_status_output.golden")
+	})
+
+	t.Run("Reports the absence of alerts", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := renderAlerts(newAlertStatuses(nil), &buf, tableOutput, now); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if buf.String() != "No alerts are pending or firing\n" {
+			t.Fatalf("Unexpected output: %s", buf.String())
+		}
+	})
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"google.golang.org/grpc"
+)
+
+var apiCacheTTL time.Duration
+
+// cachingAPIClient wraps a public API client, sharing the responses of the
+// metrics APIs between identical requests for --api-cache-ttl, so that watch
+// modes refreshing faster than the TTL, or commands repeating the same query,
+// don't query the control plane on every tick.
+type cachingAPIClient struct {
+	pb.ApiClient
+	cache *util.ResponseCache
+}
+
+// newCachingAPIClient returns client unchanged when ttl isn't positive.
+func newCachingAPIClient(client pb.ApiClient, ttl time.Duration) pb.ApiClient {
+	if ttl <= 0 {
+		return client
+	}
+
+	return &cachingAPIClient{
+		ApiClient: client,
+		cache:     util.NewResponseCache(ttl, nil),
+	}
+}
+
+// get returns a copy of the cached response of the API to the request, or the
+// response of fetch otherwise. Copies are returned as the callers sort and
+// filter the responses in place.
+func (c *cachingAPIClient) get(ctx context.Context, api string, req proto.Message, fetch func(context.Context) (proto.Message, error)) (proto.Message, error) {
+	rsp, err := c.cache.Get(ctx, api, req, fetch)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Clone(rsp), nil
+}
+
+func (c *cachingAPIClient) StatSummary(ctx context.Context, in *pb.StatSummaryRequest, opts ...grpc.CallOption) (*pb.StatSummaryResponse, error) {
+	rsp, err := c.get(ctx, "StatSummary", in, func(ctx context.Context) (proto.Message, error) {
+		return c.ApiClient.StatSummary(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rsp.(*pb.StatSummaryResponse), nil
+}
+
+func (c *cachingAPIClient) TopRoutes(ctx context.Context, in *pb.TopRoutesRequest, opts ...grpc.CallOption) (*pb.TopRoutesResponse, error) {
+	rsp, err := c.get(ctx, "TopRoutes", in, func(ctx context.Context) (proto.Message, error) {
+		return c.ApiClient.TopRoutes(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rsp.(*pb.TopRoutesResponse), nil
+}
+
+func (c *cachingAPIClient) StreamStats(ctx context.Context, in *pb.StatSummaryRequest, opts ...grpc.CallOption) (*pb.StreamStatsResponse, error) {
+	rsp, err := c.get(ctx, "StreamStats", in, func(ctx context.Context) (proto.Message, error) {
+		return c.ApiClient.StreamStats(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rsp.(*pb.StreamStatsResponse), nil
+}
+
+func (c *cachingAPIClient) RouteSLOs(ctx context.Context, in *pb.RouteSLOsRequest, opts ...grpc.CallOption) (*pb.RouteSLOsResponse, error) {
+	rsp, err := c.get(ctx, "RouteSLOs", in, func(ctx context.Context) (proto.Message, error) {
+		return c.ApiClient.RouteSLOs(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rsp.(*pb.RouteSLOsResponse), nil
+}
+
+func (c *cachingAPIClient) RouteStatsHistory(ctx context.Context, in *pb.RouteStatsHistoryRequest, opts ...grpc.CallOption) (*pb.RouteStatsHistoryResponse, error) {
+	rsp, err := c.get(ctx, "RouteStatsHistory", in, func(ctx context.Context) (proto.Message, error) {
+		return c.ApiClient.RouteStatsHistory(ctx, in, opts...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rsp.(*pb.RouteStatsHistoryResponse), nil
+}
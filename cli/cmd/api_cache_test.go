@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"google.golang.org/grpc"
+)
+
+type countingAPIClient struct {
+	pb.ApiClient
+	calls int
+	err   error
+}
+
+func (c *countingAPIClient) TopRoutes(ctx context.Context, in *pb.TopRoutesRequest, _ ...grpc.CallOption) (*pb.TopRoutesResponse, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &pb.TopRoutesResponse{
+		Response: &pb.TopRoutesResponse_Routes{
+			Routes: &pb.RouteTable{Rows: []*pb.RouteTable_Row{{Route: "/a"}, {Route: "/b"}}},
+		},
+	}, nil
+}
+
+func TestCachingAPIClient(t *testing.T) {
+	newClient := func(counting *countingAPIClient) pb.ApiClient {
+		return newCachingAPIClient(counting, time.Minute)
+	}
+	req := &pb.TopRoutesRequest{Selector: &pb.ResourceSelection{Resource: &pb.Resource{Type: "deployment", Name: "foo"}}}
+
+	t.Run("Reuses copies of the responses of identical requests", func(t *testing.T) {
+		counting := &countingAPIClient{}
+		client := newClient(counting)
+
+		rsp, err := client.TopRoutes(context.Background(), req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		// the callers may modify the responses
+		rsp.GetRoutes().Rows = nil
+
+		rsp, err = client.TopRoutes(context.Background(), &pb.TopRoutesRequest{Selector: &pb.ResourceSelection{Resource: &pb.Resource{Type: "deployment", Name: "foo"}}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if counting.calls != 1 {
+			t.Fatalf("Expected 1 call, got %d", counting.calls)
+		}
+		if len(rsp.GetRoutes().GetRows()) != 2 {
+			t.Fatalf("Expected the cached response to be unchanged, got %v", rsp)
+		}
+
+		client.TopRoutes(context.Background(), &pb.TopRoutesRequest{Selector: &pb.ResourceSelection{Resource: &pb.Resource{Type: "deployment", Name: "bar"}}})
+		if counting.calls != 2 {
+			t.Fatalf("Expected a different request to be sent, got %d calls", counting.calls)
+		}
+	})
+
+	t.Run("Doesn't cache errors", func(t *testing.T) {
+		counting := &countingAPIClient{err: errors.New("unavailable")}
+		client := newClient(counting)
+		for i := 0; i < 2; i++ {
+			if _, err := client.TopRoutes(context.Background(), req); err != counting.err {
+				t.Fatalf("Expected error %s, got %v", counting.err, err)
+			}
+		}
+		if counting.calls != 2 {
+			t.Fatalf("Expected 2 calls, got %d", counting.calls)
+		}
+	})
+
+	t.Run("Is disabled without a TTL", func(t *testing.T) {
+		counting := &countingAPIClient{}
+		if client := newCachingAPIClient(counting, 0); client != counting {
+			t.Fatalf("Expected the client to be returned unchanged, got %T", client)
+		}
+	})
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	healthcheckPb "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"google.golang.org/grpc"
+)
+
+const (
+	defaultAPITimeout = 30 * time.Second
+	defaultAPIRetries = 2
+	apiRetryBackoff   = 500 * time.Millisecond
+
+	// exitCodeInterrupted is the conventional exit code for SIGINT.
+	exitCodeInterrupted = 130
+)
+
+var apiTimeout = defaultAPITimeout
+var apiRetries = defaultAPIRetries
+
+// cliContext is the parent context of every public API call made by the CLI.
+// It's canceled on SIGINT or SIGTERM received while a call is in flight, so
+// that the call returns promptly.
+var cliContext, cancelCliContext = context.WithCancel(context.Background())
+
+// withInterrupts returns a copy of ctx that's canceled, along with cliContext,
+// on SIGINT or SIGTERM. The signals are only handled until stop is called, so
+// that outside of API calls, and in the commands not calling the API, Ctrl-C
+// keeps its default behavior.
+func withInterrupts(ctx context.Context) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-signals:
+			cancelCliContext()
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	return ctx, func() {
+		signal.Stop(signals)
+		cancel()
+	}
+}
+
+// resilientAPIClient wraps a public API client, bounding unary calls by
+// --api-timeout and retrying them with exponential backoff when the control
+// plane is unreachable. Streaming calls are not bounded, as they're expected to
+// run until canceled.
+type resilientAPIClient struct {
+	pb.ApiClient
+	timeout time.Duration
+	retries int
+	backoff time.Duration
+}
+
+func newResilientAPIClient(client pb.ApiClient) pb.ApiClient {
+	return &resilientAPIClient{
+		ApiClient: client,
+		timeout:   apiTimeout,
+		retries:   apiRetries,
+		backoff:   apiRetryBackoff,
+	}
+}
+
+// call invokes fn until it succeeds, fails with an error that isn't worth
+// retrying, or the retries are exhausted.
+func (c *resilientAPIClient) call(ctx context.Context, fn func(ctx context.Context) error) error {
+	ctx, stop := withInterrupts(ctx)
+	defer stop()
+
+	backoff := c.backoff
+	for attempt := 0; ; attempt++ {
+		err := c.attempt(ctx, fn)
+		if err == nil || attempt >= c.retries || ctx.Err() != nil || !isRetryableAPIError(err) {
+			return err
+		}
+
+		select {
+		case <-time.After(backoff):
+			backoff *= 2
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (c *resilientAPIClient) attempt(ctx context.Context, fn func(ctx context.Context) error) error {
+	if c.timeout <= 0 {
+		return fn(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return fn(ctx)
+}
+
+// isRetryableAPIError returns true for errors caused by an unreachable or
+// overloaded control plane.
+func isRetryableAPIError(err error) bool {
+	return err != context.Canceled && classifyError(err).code == exitCodeUnreachable
+}
+
+func (c *resilientAPIClient) StatSummary(ctx context.Context, in *pb.StatSummaryRequest, opts ...grpc.CallOption) (rsp *pb.StatSummaryResponse, err error) {
+	err = c.call(ctx, func(ctx context.Context) (err error) {
+		rsp, err = c.ApiClient.StatSummary(ctx, in, opts...)
+		return
+	})
+	return
+}
+
+func (c *resilientAPIClient) TopRoutes(ctx context.Context, in *pb.TopRoutesRequest, opts ...grpc.CallOption) (rsp *pb.TopRoutesResponse, err error) {
+	err = c.call(ctx, func(ctx context.Context) (err error) {
+		rsp, err = c.ApiClient.TopRoutes(ctx, in, opts...)
+		return
+	})
+	return
+}
+
+func (c *resilientAPIClient) StreamStats(ctx context.Context, in *pb.StatSummaryRequest, opts ...grpc.CallOption) (rsp *pb.StreamStatsResponse, err error) {
+	err = c.call(ctx, func(ctx context.Context) (err error) {
+		rsp, err = c.ApiClient.StreamStats(ctx, in, opts...)
+		return
+	})
+	return
+}
+
+func (c *resilientAPIClient) RouteSLOs(ctx context.Context, in *pb.RouteSLOsRequest, opts ...grpc.CallOption) (rsp *pb.RouteSLOsResponse, err error) {
+	err = c.call(ctx, func(ctx context.Context) (err error) {
+		rsp, err = c.ApiClient.RouteSLOs(ctx, in, opts...)
+		return
+	})
+	return
+}
+
+func (c *resilientAPIClient) RouteStatsHistory(ctx context.Context, in *pb.RouteStatsHistoryRequest, opts ...grpc.CallOption) (rsp *pb.RouteStatsHistoryResponse, err error) {
+	err = c.call(ctx, func(ctx context.Context) (err error) {
+		rsp, err = c.ApiClient.RouteStatsHistory(ctx, in, opts...)
+		return
+	})
+	return
+}
+
+func (c *resilientAPIClient) ListPods(ctx context.Context, in *pb.ListPodsRequest, opts ...grpc.CallOption) (rsp *pb.ListPodsResponse, err error) {
+	err = c.call(ctx, func(ctx context.Context) (err error) {
+		rsp, err = c.ApiClient.ListPods(ctx, in, opts...)
+		return
+	})
+	return
+}
+
+func (c *resilientAPIClient) ListServices(ctx context.Context, in *pb.ListServicesRequest, opts ...grpc.CallOption) (rsp *pb.ListServicesResponse, err error) {
+	err = c.call(ctx, func(ctx context.Context) (err error) {
+		rsp, err = c.ApiClient.ListServices(ctx, in, opts...)
+		return
+	})
+	return
+}
+
+func (c *resilientAPIClient) Version(ctx context.Context, in *pb.Empty, opts ...grpc.CallOption) (rsp *pb.VersionInfo, err error) {
+	err = c.call(ctx, func(ctx context.Context) (err error) {
+		rsp, err = c.ApiClient.Version(ctx, in, opts...)
+		return
+	})
+	return
+}
+
+func (c *resilientAPIClient) SelfCheck(ctx context.Context, in *healthcheckPb.SelfCheckRequest, opts ...grpc.CallOption) (rsp *healthcheckPb.SelfCheckResponse, err error) {
+	err = c.call(ctx, func(ctx context.Context) (err error) {
+		rsp, err = c.ApiClient.SelfCheck(ctx, in, opts...)
+		return
+	})
+	return
+}
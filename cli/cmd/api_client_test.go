@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"google.golang.org/grpc"
+)
+
+type flakyAPIClient struct {
+	pb.ApiClient
+	calls    int
+	failures int
+	err      error
+	delay    time.Duration
+}
+
+func (c *flakyAPIClient) StatSummary(ctx context.Context, in *pb.StatSummaryRequest, _ ...grpc.CallOption) (*pb.StatSummaryResponse, error) {
+	c.calls++
+	if c.delay > 0 {
+		select {
+		case <-time.After(c.delay):
+		case <-ctx.Done():
+			return nil, &url.Error{Op: "Post", URL: "http://linkerd", Err: ctx.Err()}
+		}
+	}
+	if c.calls <= c.failures {
+		return nil, c.err
+	}
+	return &pb.StatSummaryResponse{}, nil
+}
+
+func TestResilientAPIClient(t *testing.T) {
+	newClient := func(flaky *flakyAPIClient, timeout time.Duration, retries int) pb.ApiClient {
+		return &resilientAPIClient{ApiClient: flaky, timeout: timeout, retries: retries, backoff: time.Millisecond}
+	}
+	unreachable := &url.Error{Op: "Post", URL: "http://linkerd", Err: errors.New("connection refused")}
+
+	t.Run("Retries when the control plane is unreachable", func(t *testing.T) {
+		flaky := &flakyAPIClient{failures: 2, err: unreachable}
+		if _, err := newClient(flaky, time.Second, 2).StatSummary(context.Background(), &pb.StatSummaryRequest{}); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if flaky.calls != 3 {
+			t.Fatalf("Expected 3 calls, got %d", flaky.calls)
+		}
+	})
+
+	t.Run("Gives up once the retries are exhausted", func(t *testing.T) {
+		flaky := &flakyAPIClient{failures: 5, err: unreachable}
+		if _, err := newClient(flaky, time.Second, 1).StatSummary(context.Background(), &pb.StatSummaryRequest{}); err != unreachable {
+			t.Fatalf("Expected error %s, got %v", unreachable, err)
+		}
+		if flaky.calls != 2 {
+			t.Fatalf("Expected 2 calls, got %d", flaky.calls)
+		}
+	})
+
+	t.Run("Does not retry other errors", func(t *testing.T) {
+		flaky := &flakyAPIClient{failures: 1, err: errors.New("bad request")}
+		if _, err := newClient(flaky, time.Second, 2).StatSummary(context.Background(), &pb.StatSummaryRequest{}); err == nil {
+			t.Fatalf("Expected error, got nil")
+		}
+		if flaky.calls != 1 {
+			t.Fatalf("Expected 1 call, got %d", flaky.calls)
+		}
+	})
+
+	t.Run("Times out slow requests", func(t *testing.T) {
+		flaky := &flakyAPIClient{delay: time.Second}
+		if _, err := newClient(flaky, 10*time.Millisecond, 0).StatSummary(context.Background(), &pb.StatSummaryRequest{}); err == nil {
+			t.Fatalf("Expected timeout error, got nil")
+		}
+	})
+
+	t.Run("Stops when the context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		flaky := &flakyAPIClient{delay: time.Second}
+		if _, err := newClient(flaky, time.Second, 2).StatSummary(ctx, &pb.StatSummaryRequest{}); err == nil {
+			t.Fatalf("Expected error, got nil")
+		}
+		if flaky.calls != 1 {
+			t.Fatalf("Expected 1 call, got %d", flaky.calls)
+		}
+	})
+}
+
+func TestWithInterrupts(t *testing.T) {
+	parent, cancelParent := cliContext, cancelCliContext
+	cliContext, cancelCliContext = context.WithCancel(context.Background())
+	defer func() { cliContext, cancelCliContext = parent, cancelParent }()
+
+	t.Run("Stops without canceling the CLI context", func(t *testing.T) {
+		ctx, stop := withInterrupts(cliContext)
+		stop()
+		if ctx.Err() == nil {
+			t.Fatalf("Expected the call context to be canceled")
+		}
+		if cliContext.Err() != nil {
+			t.Fatalf("Expected the CLI context not to be canceled")
+		}
+	})
+
+	t.Run("Cancels the CLI context on SIGINT", func(t *testing.T) {
+		ctx, stop := withInterrupts(cliContext)
+		defer stop()
+
+		process, err := os.FindProcess(os.Getpid())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if err := process.Signal(os.Interrupt); err != nil {
+			t.Skipf("Can't interrupt the test process: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Expected the call context to be canceled")
+		}
+		if cliContext.Err() == nil {
+			t.Fatalf("Expected the CLI context to be canceled")
+		}
+	})
+}
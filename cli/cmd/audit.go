@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/version"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// auditRetryInterval is the interval between the checks for the AuditEvent CRD
+// of awaitAuditEvent.
+const auditRetryInterval = 2 * time.Second
+
+type auditHistoryOptions struct {
+	operation    string
+	outputFormat string
+}
+
+func newAuditHistoryOptions() *auditHistoryOptions {
+	return &auditHistoryOptions{
+		operation:    "",
+		outputFormat: tableOutput,
+	}
+}
+
+func auditEventListPath(namespace string) string {
+	return fmt.Sprintf("/apis/linkerd.io/v1alpha1/namespaces/%s/auditevents", namespace)
+}
+
+func newCmdAudit() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "audit [flags]",
+		Short: "Inspect the operations that changed the mesh configuration",
+		Long: `Inspect the operations that changed the mesh configuration.
+
+The CLI records its mutating operations as AuditEvents in the control plane
+namespace: 'linkerd install --audit' creates one once the control plane is
+applied, and 'linkerd prune', 'linkerd repair' and 'linkerd config set' create
+one when they change the cluster. Every event records who ran the operation, when, with which
+CLI version and flags, and the hash of the applied configuration.`,
+	}
+
+	cmd.AddCommand(newCmdAuditHistory())
+
+	return cmd
+}
+
+func newCmdAuditHistory() *cobra.Command {
+	options := newAuditHistoryOptions()
+
+	cmd := &cobra.Command{
+		Use:   "history [flags]",
+		Short: "List the recorded operations, oldest first",
+		Example: `  # List the operations that changed the mesh configuration
+  linkerd audit history
+
+  # List the installs
+  linkerd audit history --operation install`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.outputFormat != tableOutput && options.outputFormat != jsonOutput {
+				return newCliError(exitCodeInvalidFlags, fmt.Errorf("--output must be one of: %s, %s", tableOutput, jsonOutput))
+			}
+
+			kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup)
+			if err != nil {
+				return err
+			}
+			client, err := kubeAPI.NewClient()
+			if err != nil {
+				return err
+			}
+
+			var list sp.AuditEventList
+			if _, err := kubeAPI.GetObject(client, auditEventListPath(controlPlaneNamespace), &list); err != nil {
+				return fmt.Errorf("failed to list the audit events: %s", err)
+			}
+
+			events := auditHistory(list.Items, options.operation)
+			return renderAuditHistory(events, os.Stdout, options.outputFormat)
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&options.operation, "operation", options.operation, "Only list the operations of this kind, e.g. install")
+	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, "Output format; one of: \"table\" or \"json\"")
+
+	return cmd
+}
+
+// newAuditEvent returns the event recording the operation run by cmd with
+// args at now. The operation is named after the command, e.g. "config-set"
+// for 'linkerd config set'.
+func newAuditEvent(cmd *cobra.Command, args []string, configHash string, now time.Time) sp.AuditEvent {
+	user, err := k8s.GetUser(kubeconfigPath, kubeContext, impersonate)
+	if err != nil {
+		log.Debugf("Failed to get the Kubernetes user: %s", err)
+	}
+
+	operation := strings.Join(strings.Fields(cmd.CommandPath())[1:], "-")
+	return sp.AuditEvent{
+		TypeMeta: metaV1.TypeMeta{
+			APIVersion: "linkerd.io/v1alpha1",
+			Kind:       "AuditEvent",
+		},
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", operation, now.UTC().Format("20060102-150405")),
+			Namespace: controlPlaneNamespace,
+		},
+		Spec: sp.AuditEventSpec{
+			Operation:  operation,
+			User:       user,
+			Time:       metaV1.NewTime(now.UTC()),
+			Version:    version.Version,
+			Args:       args,
+			Flags:      auditFlags(cmd.Flags()),
+			ConfigHash: configHash,
+		},
+	}
+}
+
+// auditFlags returns the flags set on the command line, sorted by name.
+func auditFlags(flags *pflag.FlagSet) []string {
+	set := []string{}
+	flags.Visit(func(flag *pflag.Flag) {
+		set = append(set, fmt.Sprintf("--%s=%s", flag.Name, flag.Value))
+	})
+	return set
+}
+
+// manifestHash returns the SHA-256 of the rendered manifests.
+func manifestHash(manifests []byte) string {
+	sum := sha256.Sum256(manifests)
+	return hex.EncodeToString(sum[:])
+}
+
+// recordAuditEvent creates the event in the cluster. The operation already
+// happened, so failing to record it only prints a warning.
+func recordAuditEvent(kubeAPI *k8s.KubernetesAPI, client *http.Client, event sp.AuditEvent) {
+	body, err := json.Marshal(event)
+	if err == nil {
+		err = kubeAPI.CreateObject(client, auditEventListPath(event.Namespace), body)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to record the %s operation in the audit history: %s\n", event.Spec.Operation, err)
+	}
+}
+
+// awaitAuditEvent creates the event once the cluster serves the AuditEvent CRD
+// and the control plane namespace, retrying until timeout, e.g. while
+// 'kubectl apply' creates them from the manifests of 'linkerd install'.
+func awaitAuditEvent(event sp.AuditEvent, timeout time.Duration) {
+	kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to record the %s operation in the audit history: %s\n", event.Spec.Operation, err)
+		return
+	}
+	client, err := kubeAPI.NewClient()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to record the %s operation in the audit history: %s\n", event.Spec.Operation, err)
+		return
+	}
+
+	deadline := time.Now().Add(timeout)
+	for !auditEventServed(kubeAPI, client, event.Namespace) && time.Now().Before(deadline) {
+		time.Sleep(auditRetryInterval)
+	}
+	recordAuditEvent(kubeAPI, client, event)
+}
+
+// auditEventServed returns whether the AuditEvents of the namespace can be
+// created, i.e. both their CRD and the namespace exist.
+func auditEventServed(kubeAPI *k8s.KubernetesAPI, client *http.Client, namespace string) bool {
+	if exists, err := kubeAPI.NamespaceExists(client, namespace); err != nil || !exists {
+		return false
+	}
+	var list sp.AuditEventList
+	served, err := kubeAPI.GetObject(client, auditEventListPath(namespace), &list)
+	return served && err == nil
+}
+
+// auditHistory returns the events of the given operation, or all of them if
+// operation is empty, oldest first.
+func auditHistory(events []sp.AuditEvent, operation string) []sp.AuditEvent {
+	history := []sp.AuditEvent{}
+	for _, event := range events {
+		if operation == "" || event.Spec.Operation == operation {
+			history = append(history, event)
+		}
+	}
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].Spec.Time.Before(&history[j].Spec.Time)
+	})
+	return history
+}
+
+func renderAuditHistory(events []sp.AuditEvent, w io.Writer, outputFormat string) error {
+	if outputFormat == jsonOutput {
+		specs := make([]sp.AuditEventSpec, len(events))
+		for i, event := range events {
+			specs[i] = event.Spec
+		}
+		out, err := json.MarshalIndent(specs, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s\n", out)
+		return nil
+	}
+
+	if len(events) == 0 {
+		fmt.Fprintln(w, "No operations recorded")
+		return nil
+	}
+
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, padding, ' ', 0)
+	fmt.Fprintln(tw, "TIME\tOPERATION\tUSER\tVERSION\tCONFIG HASH\tARGUMENTS")
+	for _, event := range events {
+		spec := event.Spec
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			spec.Time.UTC().Format(time.RFC3339),
+			spec.Operation,
+			orDash(spec.User),
+			spec.Version,
+			orDash(shortHash(spec.ConfigHash)),
+			orDash(strings.Join(append(append([]string{}, spec.Args...), spec.Flags...), " ")),
+		)
+	}
+	tw.Flush()
+	fmt.Fprint(w, buf.String())
+	return nil
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
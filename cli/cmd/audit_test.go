@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	"github.com/spf13/cobra"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewAuditEvent(t *testing.T) {
+	root := &cobra.Command{Use: "linkerd"}
+	config := &cobra.Command{Use: "config"}
+	set := &cobra.Command{Use: "set", Run: func(*cobra.Command, []string) {}}
+	set.Flags().Bool("dry-run", false, "")
+	set.Flags().String("linkerd-namespace", "linkerd", "")
+	root.AddCommand(config)
+	config.AddCommand(set)
+	if err := set.ParseFlags([]string{"--linkerd-namespace=linkerd-test"}); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	now := time.Date(2019, 1, 8, 12, 30, 0, 0, time.UTC)
+	event := newAuditEvent(set, []string{"proxyLogLevel", "debug"}, "abc", now)
+
+	if event.Name != "config-set-20190108-123000" {
+		t.Fatalf("Unexpected event name %s", event.Name)
+	}
+	if event.Spec.Operation != "config-set" {
+		t.Fatalf("Unexpected operation %s", event.Spec.Operation)
+	}
+	if !event.Spec.Time.Equal(&metaV1.Time{Time: now}) {
+		t.Fatalf("Unexpected time %s", event.Spec.Time)
+	}
+	expectedFlags := []string{"--linkerd-namespace=linkerd-test"}
+	if !reflect.DeepEqual(event.Spec.Flags, expectedFlags) {
+		t.Fatalf("Expected flags %v, got %v", expectedFlags, event.Spec.Flags)
+	}
+}
+
+func TestRenderAuditHistory(t *testing.T) {
+	auditEvent := func(operation string, minutes int, args, flags []string, configHash string) sp.AuditEvent {
+		return sp.AuditEvent{
+			Spec: sp.AuditEventSpec{
+				Operation:  operation,
+				User:       "admin",
+				Time:       metaV1.NewTime(time.Date(2019, 1, 8, 12, minutes, 0, 0, time.UTC)),
+				Version:    "stable-2.1.0",
+				Args:       args,
+				Flags:      flags,
+				ConfigHash: configHash,
+			},
+		}
+	}
+
+	events := []sp.AuditEvent{
+		auditEvent("config-set", 30, []string{"proxyLogLevel", "debug"}, nil, "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"),
+		auditEvent("install", 0, nil, []string{"--ha=true"}, "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"),
+		auditEvent("repair", 45, nil, nil, ""),
+	}
+
+	var buf bytes.Buffer
+	if err := renderAuditHistory(auditHistory(events, ""), &buf, tableOutput); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	diffCompareFile(t, buf.String(), "audit_history.golden")
+
+	installs := auditHistory(events, "install")
+	if len(installs) != 1 || installs[0].Spec.Operation != "install" {
+		t.Fatalf("Expected only the install, got %v", installs)
+	}
+}
@@ -0,0 +1,404 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultBenchImage = "buoyantio/slow_cooker:1.1.1"
+
+	// how long to wait for Prometheus to scrape the last requests of the load
+	benchScrapeDelay = 15 * time.Second
+)
+
+type benchOptions struct {
+	statOptionsBase
+	port     uint
+	paths    []string
+	rps      uint
+	duration time.Duration
+	image    string
+}
+
+func newBenchOptions() *benchOptions {
+	return &benchOptions{
+		statOptionsBase: *newStatOptionsBase(),
+		port:            80,
+		paths:           []string{"/"},
+		rps:             10,
+		duration:        30 * time.Second,
+		image:           defaultBenchImage,
+	}
+}
+
+func (o *benchOptions) validate() error {
+	if o.rps == 0 {
+		return fmt.Errorf("--rps must be greater than 0")
+	}
+	if o.duration < 10*time.Second {
+		return fmt.Errorf("--duration must be at least 10s")
+	}
+	for _, path := range o.paths {
+		if !strings.HasPrefix(path, "/") {
+			return fmt.Errorf("paths must start with /, got %s", path)
+		}
+	}
+	return o.validateOutputFormat()
+}
+
+func newCmdBench() *cobra.Command {
+	options := newBenchOptions()
+
+	cmd := &cobra.Command{
+		Use:   "bench [flags] (SERVICE)",
+		Short: "Send load to a service and report the change in its route stats",
+		Long: `Send load to a service and report the change in its route stats.
+
+The bench command runs an ephemeral meshed pod that sends HTTP requests to the
+paths of a service at a fixed rate, then compares the route stats of the
+service during the load with its route stats over the same duration before it.
+The pod is deleted once the load ends.`,
+		Example: `  # Send 20 requests per second to /books and /authors of the webapp service for a minute
+  linkerd bench webapp -n booksapp --port 7000 --path /books --path /authors --rps 20 --duration 1m`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(); err != nil {
+				return newCliError(exitCodeInvalidFlags, err)
+			}
+
+			kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup)
+			if err != nil {
+				return err
+			}
+			client, err := kubeAPI.NewClient()
+			if err != nil {
+				return err
+			}
+			apiClient := validatedPublicAPIClient(time.Time{})
+
+			req, err := buildBenchRoutesRequest(args[0], options)
+			if err != nil {
+				return err
+			}
+			before, err := requestRouteRows(apiClient, req)
+			if err != nil {
+				return err
+			}
+
+			name := fmt.Sprintf("linkerd-bench-%s-%d", args[0], time.Now().Unix())
+			if err := runBenchPod(kubeAPI, client, name, args[0], options); err != nil {
+				return err
+			}
+
+			time.Sleep(benchScrapeDelay)
+			after, err := requestRouteRows(apiClient, req)
+			if err != nil {
+				return err
+			}
+
+			output, err := renderBenchResults(compareBenchRoutes(before, after), options)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Print(output)
+
+			return err
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the service")
+	cmd.PersistentFlags().UintVar(&options.port, "port", options.port, "Port of the service to send requests to")
+	cmd.PersistentFlags().StringArrayVar(&options.paths, "path", options.paths, "Path to send requests to; can be repeated")
+	cmd.PersistentFlags().UintVar(&options.rps, "rps", options.rps, "Requests per second sent to each path")
+	cmd.PersistentFlags().DurationVar(&options.duration, "duration", options.duration, "Duration of the load")
+	cmd.PersistentFlags().StringVar(&options.image, "image", options.image, "Image of the load generator, compatible with slow_cooker")
+	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, outputFormatHelp)
+
+	return cmd
+}
+
+// buildBenchPod returns the pod sending the load, with a slow_cooker container
+// per path.
+func buildBenchPod(name, service string, options *benchOptions) *v1.Pod {
+	total := uint(options.duration.Seconds()) * options.rps
+
+	containers := make([]v1.Container, 0)
+	for i, path := range options.paths {
+		url := fmt.Sprintf("http://%s.%s.svc.cluster.local:%d%s", service, options.namespace, options.port, path)
+		containers = append(containers, v1.Container{
+			Name:  fmt.Sprintf("load-%d", i),
+			Image: options.image,
+			Args: []string{
+				"-qps", fmt.Sprintf("%d", options.rps),
+				"-concurrency", "1",
+				"-totalRequests", fmt.Sprintf("%d", total),
+				url,
+			},
+		})
+	}
+
+	return &v1.Pod{
+		TypeMeta: metaV1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Pod",
+		},
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      name,
+			Namespace: options.namespace,
+			Labels: map[string]string{
+				"app": "linkerd-bench",
+			},
+		},
+		Spec: v1.PodSpec{
+			Containers:    containers,
+			RestartPolicy: v1.RestartPolicyNever,
+		},
+	}
+}
+
+// injectBenchPod returns the JSON of the pod, injected with the proxy.
+func injectBenchPod(pod *v1.Pod) ([]byte, error) {
+	b, err := yaml.Marshal(pod)
+	if err != nil {
+		return nil, err
+	}
+
+	injected, _, err := injectResource(b, nil, newInjectOptions())
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.YAMLToJSON(injected)
+}
+
+// runBenchPod creates the bench pod, waits for its load containers to exit,
+// and deletes it.
+func runBenchPod(kubeAPI *k8s.KubernetesAPI, client *http.Client, name, service string, options *benchOptions) error {
+	pod := buildBenchPod(name, service, options)
+	body, err := injectBenchPod(pod)
+	if err != nil {
+		return err
+	}
+
+	podsPath := fmt.Sprintf("/api/v1/namespaces/%s/pods", options.namespace)
+	if err := kubeAPI.CreateObject(client, podsPath, body); err != nil {
+		return fmt.Errorf("failed to create the bench pod: %s", err)
+	}
+	defer func() {
+		if err := kubeAPI.DeleteObject(client, podsPath+"/"+name); err != nil {
+			log.Errorf("failed to delete the bench pod %s: %s", name, err)
+		}
+	}()
+
+	fmt.Fprintf(os.Stderr, "Sending load to %s for %s...\n", service, options.duration)
+
+	// leave the meshed pod time to start on top of the duration of the load
+	deadline := time.Now().Add(options.duration + 2*time.Minute)
+	for time.Now().Before(deadline) {
+		time.Sleep(2 * time.Second)
+
+		var current v1.Pod
+		found, err := kubeAPI.GetObject(client, podsPath+"/"+name, &current)
+		if err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("the bench pod %s was deleted", name)
+		}
+		if loadFinished(&current) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("timed out waiting for the load of the bench pod %s to end", name)
+}
+
+// loadFinished returns true once all the load containers of the pod exited;
+// the proxy keeps running.
+func loadFinished(pod *v1.Pod) bool {
+	if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+		return true
+	}
+
+	load := 0
+	for _, status := range pod.Status.ContainerStatuses {
+		if !strings.HasPrefix(status.Name, "load-") {
+			continue
+		}
+		if status.State.Terminated == nil {
+			return false
+		}
+		load++
+	}
+	return load > 0
+}
+
+func buildBenchRoutesRequest(service string, options *benchOptions) (*pb.TopRoutesRequest, error) {
+	return util.BuildTopRoutesRequest(util.TopRoutesRequestParams{
+		StatsBaseRequestParams: util.StatsBaseRequestParams{
+			TimeWindow:   options.duration.String(),
+			ResourceName: service,
+			ResourceType: k8s.Service,
+			Namespace:    options.namespace,
+		},
+	})
+}
+
+func requestRouteRows(client pb.ApiClient, req *pb.TopRoutesRequest) ([]*pb.RouteTable_Row, error) {
+	resp, err := client.TopRoutes(cliContext, req)
+	if err != nil {
+		return nil, wrapAPIError("TopRoutes", err)
+	}
+	if e := resp.GetError(); e != nil {
+		return nil, fmt.Errorf("TopRoutes API response error: %v", e.Error)
+	}
+	return resp.GetRoutes().GetRows(), nil
+}
+
+// benchRouteStats are the stats of a route before and during the load.
+type benchRouteStats struct {
+	Route  string          `json:"route"`
+	Before *benchRouteStat `json:"before"`
+	After  *benchRouteStat `json:"after"`
+}
+
+type benchRouteStat struct {
+	Success      float64 `json:"success"`
+	Rps          float64 `json:"rps"`
+	LatencyMSp99 uint64  `json:"latency_ms_p99"`
+}
+
+// compareBenchRoutes pairs the stats of the routes before and during the
+// load, by route name.
+func compareBenchRoutes(before, after []*pb.RouteTable_Row) []*benchRouteStats {
+	byRoute := make(map[string]*benchRouteStats)
+	get := func(route string) *benchRouteStats {
+		if route == "" {
+			route = defaultRoute
+		}
+		if byRoute[route] == nil {
+			byRoute[route] = &benchRouteStats{Route: route}
+		}
+		return byRoute[route]
+	}
+	stat := func(r *pb.RouteTable_Row) *benchRouteStat {
+		return &benchRouteStat{
+			Success:      util.GetSuccessRate(r.Stats),
+			Rps:          util.GetRequestRate(r.Stats, r.TimeWindow),
+			LatencyMSp99: r.Stats.GetLatencyMsP99(),
+		}
+	}
+
+	for _, r := range before {
+		get(r.Route).Before = stat(r)
+	}
+	for _, r := range after {
+		get(r.Route).After = stat(r)
+	}
+
+	routes := make([]*benchRouteStats, 0, len(byRoute))
+	for _, r := range byRoute {
+		routes = append(routes, r)
+	}
+	sort.Slice(routes, func(i, j int) bool { return routes[i].Route < routes[j].Route })
+	return routes
+}
+
+func renderBenchResults(routes []*benchRouteStats, options *benchOptions) (string, error) {
+	var buffer bytes.Buffer
+	w := tabwriter.NewWriter(&buffer, 0, 0, padding, ' ', tabwriter.AlignRight)
+	writeBenchResultsToBuffer(routes, w, options)
+	w.Flush()
+
+	return renderStats(buffer, &options.statOptionsBase)
+}
+
+func writeBenchResultsToBuffer(routes []*benchRouteStats, w *tabwriter.Writer, options *benchOptions) {
+	if isJSONOutput(options.outputFormat) {
+		b, err := json.MarshalIndent(routes, "", "  ")
+		if err != nil {
+			log.Error(err.Error())
+			return
+		}
+		fmt.Fprintf(w, "%s\n", b)
+		return
+	}
+
+	if len(routes) == 0 {
+		fmt.Fprintln(os.Stderr, "No traffic found.  Is the service listening on the --port?")
+		os.Exit(exitCodeNoData)
+	}
+
+	routeLength := len(defaultRoute)
+	for _, r := range routes {
+		if len(r.Route) > routeLength {
+			routeLength = len(r.Route)
+		}
+	}
+	// template for left-aligning the route column
+	routeTemplate := fmt.Sprintf("%%-%ds", routeLength)
+
+	headers := []string{
+		fmt.Sprintf(routeTemplate, "ROUTE"),
+		"RPS_BEFORE",
+		"RPS_DURING",
+		colorizeHeader("SUCCESS_BEFORE"),
+		colorizeHeader("SUCCESS_DURING"),
+		"LATENCY_P99_BEFORE",
+		"LATENCY_P99_DURING\t", // trailing \t is required to format last column
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+
+	for _, r := range routes {
+		values := []string{fmt.Sprintf(routeTemplate, r.Route)}
+		values = append(values, formatBenchStat(r.Before, r.After)...)
+		fmt.Fprintf(w, "%s\t\n", strings.Join(values, "\t"))
+	}
+}
+
+// formatBenchStat returns the RPS, success and p99 latency columns of a route,
+// each before and during the load.
+func formatBenchStat(before, after *benchRouteStat) []string {
+	rps := func(s *benchRouteStat) string {
+		if s == nil {
+			return "-"
+		}
+		return fmt.Sprintf("%.1frps", s.Rps)
+	}
+	success := func(s *benchRouteStat) string {
+		if s == nil {
+			return colorize(colorDefault, "-")
+		}
+		return formatSuccessRate(s.Success)
+	}
+	latency := func(s *benchRouteStat) string {
+		if s == nil {
+			return "-"
+		}
+		return fmt.Sprintf("%dms", s.LatencyMSp99)
+	}
+
+	return []string{
+		rps(before), rps(after),
+		success(before), success(after),
+		latency(before), latency(after),
+	}
+}
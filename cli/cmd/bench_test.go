@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+)
+
+func TestBuildBenchPod(t *testing.T) {
+	options := newBenchOptions()
+	options.namespace = "booksapp"
+	options.port = 7000
+	options.paths = []string{"/books", "/authors"}
+	options.rps = 20
+	options.duration = time.Minute
+
+	pod := buildBenchPod("linkerd-bench-webapp-1", "webapp", options)
+
+	if len(pod.Spec.Containers) != 2 {
+		t.Fatalf("Expected a load container per path, got %d", len(pod.Spec.Containers))
+	}
+	expectedArgs := []string{"-qps", "20", "-concurrency", "1", "-totalRequests", "1200", "http://webapp.booksapp.svc.cluster.local:7000/authors"}
+	if !reflect.DeepEqual(pod.Spec.Containers[1].Args, expectedArgs) {
+		t.Fatalf("Expected args %v, got %v", expectedArgs, pod.Spec.Containers[1].Args)
+	}
+
+	if _, err := injectBenchPod(pod); err != nil {
+		t.Fatalf("Unexpected error injecting the bench pod: %v", err)
+	}
+}
+
+func TestBenchResults(t *testing.T) {
+	before := []*pb.RouteTable_Row{
+		{
+			Route:      "GET /books",
+			TimeWindow: "1m",
+			Stats:      &pb.BasicStats{SuccessCount: 60, LatencyMsP99: 20},
+		},
+	}
+	after := []*pb.RouteTable_Row{
+		{
+			Route:      "GET /books",
+			TimeWindow: "1m",
+			Stats:      &pb.BasicStats{SuccessCount: 1140, FailureCount: 60, LatencyMsP99: 85},
+		},
+		{
+			Route:      "",
+			TimeWindow: "1m",
+			Stats:      &pb.BasicStats{SuccessCount: 1200, LatencyMsP99: 4},
+		},
+	}
+
+	options := newBenchOptions()
+	output, err := renderBenchResults(compareBenchRoutes(before, after), options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	diffCompareFile(t, output, "bench_output.golden")
+}
@@ -1,9 +1,11 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/linkerd/linkerd2/pkg/healthcheck"
@@ -23,16 +25,26 @@ type checkOptions struct {
 	wait            time.Duration
 	namespace       string
 	singleNamespace bool
+	cniEnabled      bool
+	cniNamespace    string
+	openshift       bool
+	proxyUID        int64
+	*multiContextOptions
 }
 
 func newCheckOptions() *checkOptions {
 	return &checkOptions{
-		versionOverride: "",
-		preInstallOnly:  false,
-		dataPlaneOnly:   false,
-		wait:            300 * time.Second,
-		namespace:       "",
-		singleNamespace: false,
+		versionOverride:     "",
+		preInstallOnly:      false,
+		dataPlaneOnly:       false,
+		wait:                300 * time.Second,
+		namespace:           "",
+		singleNamespace:     false,
+		cniEnabled:          false,
+		cniNamespace:        healthcheck.DefaultCNIPluginNamespace,
+		openshift:           false,
+		proxyUID:            newProxyConfigOptions().proxyUID,
+		multiContextOptions: newMultiContextOptions(),
 	}
 }
 
@@ -55,10 +67,33 @@ non-zero exit code.`,
   linkerd check --pre --linkerd-namespace test
 
   # Check that the Linkerd data plane proxies in the "app" namespace are up and running
-  linkerd check --proxy --namespace app`,
+  linkerd check --proxy --namespace app
+
+  # Check that the linkerd-cni plugin is ready before installing the control plane
+  linkerd check --pre --linkerd-cni-enabled
+
+  # Check that the control plane can be installed on OpenShift
+  linkerd check --pre --openshift
+
+  # Check the Linkerd installations of the "east" and "west" kubeconfig contexts
+  linkerd check --contexts east,west`,
 		Args: cobra.NoArgs,
-		Run: func(cmd *cobra.Command, args []string) {
-			configureAndRunChecks(options)
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.multiContextOptions.validate(); err != nil {
+				return newCliError(exitCodeInvalidFlags, err)
+			}
+
+			if !options.multiContextOptions.enabled() {
+				configureAndRunChecks(os.Stdout, kubeContext, options)
+				return nil
+			}
+
+			contexts, err := options.multiContextOptions.resolve()
+			if err != nil {
+				return err
+			}
+			configureAndRunMultiContextChecks(os.Stdout, contexts, options)
+			return nil
 		},
 	}
 
@@ -69,13 +104,66 @@ non-zero exit code.`,
 	cmd.PersistentFlags().DurationVar(&options.wait, "wait", options.wait, "Retry and wait for some checks to succeed if they don't pass the first time")
 	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace to use for --proxy checks (default: all namespaces)")
 	cmd.PersistentFlags().BoolVar(&options.singleNamespace, "single-namespace", options.singleNamespace, "When running pre-installation checks (--pre), only check the permissions required to operate the control plane in a single namespace")
+	cmd.PersistentFlags().BoolVar(&options.cniEnabled, "linkerd-cni-enabled", options.cniEnabled, "Also check that the linkerd-cni plugin is ready on all nodes, and redirects the traffic of a validation pod")
+	cmd.PersistentFlags().StringVar(&options.cniNamespace, "cni-namespace", options.cniNamespace, "Namespace in which the linkerd-cni plugin is installed, for --linkerd-cni-enabled checks")
+	cmd.PersistentFlags().BoolVar(&options.openshift, "openshift", options.openshift, "Also check the SecurityContextConstraints required to run Linkerd on OpenShift")
+	cmd.PersistentFlags().Int64Var(&options.proxyUID, "proxy-uid", options.proxyUID, "The user ID the proxy runs under, for --openshift checks")
+	addMultiContextFlags(cmd, options.multiContextOptions)
 
 	return cmd
 }
 
-func configureAndRunChecks(options *checkOptions) {
+func configureAndRunChecks(w io.Writer, kubeContext string, options *checkOptions) {
+	success := configureAndRunChecksForContext(w, kubeContext, options)
+
+	fmt.Fprintln(w, "")
+
+	if !success {
+		fmt.Fprintf(w, "Status check results are %s\n", colorize(colorRed, failStatus))
+		os.Exit(exitCodeCheckFailed)
+	}
+
+	fmt.Fprintf(w, "Status check results are %s\n", colorize(colorGreen, okStatus))
+}
+
+// configureAndRunMultiContextChecks runs the checks against every context
+// concurrently, and prints the results of each context in turn.
+func configureAndRunMultiContextChecks(w io.Writer, contexts []string, options *checkOptions) {
+	results, _ := fanOutContexts(contexts, func(context string) (interface{}, error) {
+		var buf bytes.Buffer
+		success := configureAndRunChecksForContext(&buf, context, options)
+		return checkContextResult{output: buf.String(), success: success}, nil
+	})
+
+	failed := []string{}
+	for _, context := range contexts {
+		result := results[context].(checkContextResult)
+		fmt.Fprintf(w, "%s: %s\n\n%s\n", clusterHeader, context, result.output)
+		if !result.success {
+			failed = append(failed, context)
+		}
+	}
+
+	if len(failed) > 0 {
+		fmt.Fprintf(w, "Status check results are %s for: %s\n", colorize(colorRed, failStatus), strings.Join(failed, ", "))
+		os.Exit(exitCodeCheckFailed)
+	}
+
+	fmt.Fprintf(w, "Status check results are %s\n", colorize(colorGreen, okStatus))
+}
+
+type checkContextResult struct {
+	output  string
+	success bool
+}
+
+func configureAndRunChecksForContext(w io.Writer, kubeContext string, options *checkOptions) bool {
 	checks := []healthcheck.Checks{healthcheck.KubernetesAPIChecks}
 
+	if options.cniEnabled {
+		checks = append(checks, healthcheck.LinkerdCNIPluginChecks)
+	}
+
 	if options.preInstallOnly {
 		checks = append(checks, healthcheck.LinkerdPreInstallChecks)
 	} else if options.dataPlaneOnly {
@@ -85,60 +173,64 @@ func configureAndRunChecks(options *checkOptions) {
 		checks = append(checks, healthcheck.LinkerdAPIChecks)
 	}
 
+	if options.openshift && !options.preInstallOnly {
+		checks = append(checks, healthcheck.LinkerdOpenShiftChecks)
+	}
+
 	checks = append(checks, healthcheck.LinkerdVersionChecks)
 
 	hc := healthcheck.NewHealthChecker(checks, &healthcheck.HealthCheckOptions{
 		ControlPlaneNamespace:          controlPlaneNamespace,
 		DataPlaneNamespace:             options.namespace,
+		CNIPluginNamespace:             options.cniNamespace,
 		KubeConfig:                     kubeconfigPath,
 		KubeContext:                    kubeContext,
+		Impersonate:                    impersonate,
+		ImpersonateGroup:               impersonateGroup,
 		APIAddr:                        apiAddr,
+		APIVia:                         apiVia,
 		VersionOverride:                options.versionOverride,
 		RetryDeadline:                  time.Now().Add(options.wait),
 		ShouldCheckKubeVersion:         true,
 		ShouldCheckControlPlaneVersion: !(options.preInstallOnly || options.dataPlaneOnly),
 		ShouldCheckDataPlaneVersion:    options.dataPlaneOnly,
 		SingleNamespace:                options.singleNamespace,
+		OpenShift:                      options.openshift,
+		CNIEnabled:                     options.cniEnabled,
+		ProxyUID:                       options.proxyUID,
 	})
 
-	success := runChecks(os.Stdout, hc)
-
-	fmt.Println("")
-
-	if !success {
-		fmt.Printf("Status check results are %s\n", failStatus)
-		os.Exit(2)
-	}
-
-	fmt.Printf("Status check results are %s\n", okStatus)
+	return runChecks(w, hc)
 }
 
 func runChecks(w io.Writer, hc *healthcheck.HealthChecker) bool {
-	prettyPrintResults := func(result *healthcheck.CheckResult) {
-		checkLabel := fmt.Sprintf("%s: %s", result.Category, result.Description)
+	return hc.RunChecks(func(result *healthcheck.CheckResult) {
+		printCheckResult(w, result)
+	})
+}
 
-		filler := ""
-		lineBreak := "\n"
-		for i := 0; i < lineWidth-len(checkLabel)-len(okStatus)-len(lineBreak); i++ {
-			filler = filler + "."
-		}
+func printCheckResult(w io.Writer, result *healthcheck.CheckResult) {
+	checkLabel := fmt.Sprintf("%s: %s", result.Category, result.Description)
 
-		if result.Retry {
-			fmt.Fprintf(w, "%s%s%s -- %s%s", checkLabel, filler, retryStatus, result.Err, lineBreak)
-			return
-		}
+	filler := ""
+	lineBreak := "\n"
+	for i := 0; i < lineWidth-len(checkLabel)-len(okStatus)-len(lineBreak); i++ {
+		filler = filler + "."
+	}
 
-		if result.Err != nil {
-			status := failStatus
-			if result.Warning {
-				status = warningStatus
-			}
-			fmt.Fprintf(w, "%s%s%s -- %s%s", checkLabel, filler, status, result.Err, lineBreak)
-			return
-		}
+	if result.Retry {
+		fmt.Fprintf(w, "%s%s%s -- %s%s", checkLabel, filler, colorize(colorYellow, retryStatus), result.Err, lineBreak)
+		return
+	}
 
-		fmt.Fprintf(w, "%s%s%s%s", checkLabel, filler, okStatus, lineBreak)
+	if result.Err != nil {
+		status := colorize(colorRed, failStatus)
+		if result.Warning {
+			status = colorize(colorYellow, warningStatus)
+		}
+		fmt.Fprintf(w, "%s%s%s -- %s%s", checkLabel, filler, status, result.Err, lineBreak)
+		return
 	}
 
-	return hc.RunChecks(prettyPrintResults)
+	fmt.Fprintf(w, "%s%s%s%s", checkLabel, filler, colorize(colorGreen, okStatus), lineBreak)
 }
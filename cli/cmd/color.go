@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	colorNever  = "never"
+	colorAuto   = "auto"
+	colorAlways = "always"
+
+	// successRateWarnThreshold and successRateFailThreshold are the success
+	// rates under which stats are highlighted as warnings and failures.
+	successRateWarnThreshold = 0.99
+	successRateFailThreshold = 0.9
+)
+
+// termColor is an ANSI foreground color code. All the codes used have the same
+// length, so that tabwriter keeps columns aligned as long as every cell in a
+// column, header included, is colorized: tabwriter counts the escape codes in
+// the width of the cells.
+type termColor int
+
+const (
+	colorDefault termColor = 39
+	colorRed     termColor = 31
+	colorGreen   termColor = 32
+	colorYellow  termColor = 33
+)
+
+var colorMode = colorAuto
+
+func validateColorMode(mode string) error {
+	switch mode {
+	case colorNever, colorAuto, colorAlways:
+		return nil
+	default:
+		return fmt.Errorf("--color must be one of: %s, %s, %s", colorNever, colorAuto, colorAlways)
+	}
+}
+
+// colorEnabled returns true if output should be colorized. In auto mode, colors
+// are used only when stdout is a terminal and $NO_COLOR is not set.
+func colorEnabled() bool {
+	switch colorMode {
+	case colorAlways:
+		return true
+	case colorNever:
+		return false
+	}
+
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+
+	stat, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return stat.Mode()&os.ModeCharDevice != 0
+}
+
+// colorize wraps s in the escape codes for the given color, if colors are
+// enabled.
+func colorize(color termColor, s string) string {
+	if !colorEnabled() {
+		return s
+	}
+	return fmt.Sprintf("\x1b[%dm%s\x1b[0m", color, s)
+}
+
+// colorizeHeader colorizes the header of a column whose cells are colorized,
+// in the default color, to keep the column aligned with its cells.
+func colorizeHeader(s string) string {
+	return colorize(colorDefault, s)
+}
+
+// successRateColor returns the color used to highlight the given success rate.
+func successRateColor(successRate float64) termColor {
+	return successRateColorBelow(successRate, successRateFailThreshold)
+}
+
+// successRateColorBelow returns the color used to highlight the given success
+// rate, highlighting the success rates below failThreshold as failures.
+func successRateColorBelow(successRate, failThreshold float64) termColor {
+	switch {
+	case successRate < failThreshold:
+		return colorRed
+	case successRate < successRateWarnThreshold:
+		return colorYellow
+	default:
+		return colorDefault
+	}
+}
+
+func formatSuccessRate(successRate float64) string {
+	return formatSuccessRateBelow(successRate, successRateFailThreshold)
+}
+
+func formatSuccessRateBelow(successRate, failThreshold float64) string {
+	return colorize(successRateColorBelow(successRate, failThreshold), fmt.Sprintf("%.2f%%", successRate*100))
+}
+
+// latencyColor returns the color used to highlight the given latency in
+// milliseconds, highlighting the latencies above threshold as warnings. A zero
+// threshold highlights none.
+func latencyColor(latencyMs uint64, threshold time.Duration) termColor {
+	if threshold > 0 && time.Duration(latencyMs)*time.Millisecond > threshold {
+		return colorYellow
+	}
+	return colorDefault
+}
+
+func formatLatency(latencyMs uint64, threshold time.Duration) string {
+	return colorize(latencyColor(latencyMs, threshold), fmt.Sprintf("%dms", latencyMs))
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"regexp"
+	"testing"
+	"time"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+)
+
+func TestColorize(t *testing.T) {
+	defer func() { colorMode = colorAuto }()
+
+	t.Run("Colorizes output when color is always enabled", func(t *testing.T) {
+		colorMode = colorAlways
+		expected := "\x1b[31m[FAIL]\x1b[0m"
+		if out := colorize(colorRed, failStatus); out != expected {
+			t.Fatalf("Expected %q, got %q", expected, out)
+		}
+	})
+
+	t.Run("Does not colorize output when color is disabled", func(t *testing.T) {
+		colorMode = colorNever
+		if out := colorize(colorRed, failStatus); out != failStatus {
+			t.Fatalf("Expected %q, got %q", failStatus, out)
+		}
+	})
+
+	t.Run("Respects NO_COLOR in auto mode", func(t *testing.T) {
+		colorMode = colorAuto
+		os.Setenv("NO_COLOR", "1")
+		defer os.Unsetenv("NO_COLOR")
+
+		if out := colorize(colorRed, failStatus); out != failStatus {
+			t.Fatalf("Expected %q, got %q", failStatus, out)
+		}
+	})
+
+	t.Run("Keeps all colorized strings the same length", func(t *testing.T) {
+		colorMode = colorAlways
+		expected := len(colorize(colorDefault, "-"))
+		for _, c := range []termColor{colorRed, colorGreen, colorYellow} {
+			if l := len(colorize(c, "-")); l != expected {
+				t.Fatalf("Expected length %d for color %d, got %d", expected, c, l)
+			}
+		}
+	})
+}
+
+func TestSuccessRateColor(t *testing.T) {
+	expectations := map[float64]termColor{
+		1.0:  colorDefault,
+		0.99: colorDefault,
+		0.95: colorYellow,
+		0.5:  colorRed,
+	}
+
+	for successRate, expected := range expectations {
+		if color := successRateColor(successRate); color != expected {
+			t.Fatalf("Expected color %d for success rate %f, got %d", expected, successRate, color)
+		}
+	}
+}
+
+func TestValidateColorMode(t *testing.T) {
+	for _, mode := range []string{colorNever, colorAuto, colorAlways} {
+		if err := validateColorMode(mode); err != nil {
+			t.Fatalf("Unexpected error for mode %s: %s", mode, err)
+		}
+	}
+
+	if err := validateColorMode("sometimes"); err == nil {
+		t.Fatalf("Expected error for invalid mode")
+	}
+}
+
+func TestLatencyColor(t *testing.T) {
+	if color := latencyColor(300, 250*time.Millisecond); color != colorYellow {
+		t.Fatalf("Expected color %d for a latency above the threshold, got %d", colorYellow, color)
+	}
+	if color := latencyColor(250, 250*time.Millisecond); color != colorDefault {
+		t.Fatalf("Expected color %d for a latency at the threshold, got %d", colorDefault, color)
+	}
+	if color := latencyColor(300, 0); color != colorDefault {
+		t.Fatalf("Expected color %d without a threshold, got %d", colorDefault, color)
+	}
+}
+
+func TestColorizedTablesAlign(t *testing.T) {
+	defer func() { colorMode = colorAuto }()
+	escapeCodes := regexp.MustCompile("\x1b\\[[0-9]+m")
+
+	render := func(mode string) (string, string) {
+		colorMode = mode
+
+		var alerts bytes.Buffer
+		rules := []alertRule{
+			{Name: "ControllerDown", Severity: "critical", State: alertFiring, Alerts: 1, Summary: "controller is down"},
+			{Name: "HighLatency", Severity: "warning", State: "inactive"},
+		}
+		if err := renderAlertRules(rules, &alerts, tableOutput); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		rows := []*pb.RouteSLOTable_Row{
+			{Route: "GET /books", TimeWindow: "30d", SuccessRateObjective: 0.999, Stats: &pb.BasicStats{SuccessCount: 9, FailureCount: 1}},
+			{Route: "GET /authors", TimeWindow: "30d", LatencyMsObjective: 300, Stats: &pb.BasicStats{}},
+		}
+		slos, err := renderRouteSLOs(rows, newSLOOptions())
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		return alerts.String(), slos
+	}
+
+	alerts, slos := render(colorNever)
+	colorAlerts, colorSLOs := render(colorAlways)
+	if colorAlerts == alerts || colorSLOs == slos {
+		t.Fatalf("Expected the tables to be colorized")
+	}
+	if stripped := escapeCodes.ReplaceAllString(colorAlerts, ""); stripped != alerts {
+		t.Fatalf("Expected the colorized alerts to be aligned as:\n%s\ngot:\n%s", alerts, stripped)
+	}
+	if stripped := escapeCodes.ReplaceAllString(colorSLOs, ""); stripped != slos {
+		t.Fatalf("Expected the colorized SLOs to be aligned as:\n%s\ngot:\n%s", slos, stripped)
+	}
+}
@@ -4,10 +4,70 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"sort"
+	"time"
 
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/spf13/cobra"
 )
 
+// completeResourcesCmd is the hidden command invoked by the bash completion
+// script to list the meshed resources of a given type.
+const completeResourcesCmd = "__complete-resources"
+
+// bashCompletionFunc is appended to the generated bash completion script. It
+// completes resource names for the stat, routes, tap and top commands by
+// querying the public API for meshed resources of the type given as the
+// previous argument, in the namespace selected on the command line.
+const bashCompletionFunc = `
+__linkerd_override_flag_list=(--kubeconfig --context --namespace --linkerd-namespace --api-addr -n -l)
+__linkerd_override_flags()
+{
+    local two_word_of of w
+    for w in "${words[@]}"; do
+        if [ -n "${two_word_of}" ]; then
+            echo -n "${two_word_of}=${w} "
+            two_word_of=
+            continue
+        fi
+        for of in "${__linkerd_override_flag_list[@]}"; do
+            case "${w}" in
+                ${of}=*)
+                    echo -n "${w} "
+                    ;;
+                ${of})
+                    two_word_of="${of}"
+                    ;;
+            esac
+        done
+    done
+}
+
+__linkerd_get_resource()
+{
+    if [[ ${#nouns[@]} -ne 1 ]]; then
+        return 1
+    fi
+
+    local linkerd_out
+    if linkerd_out=$(linkerd ` + completeResourcesCmd + ` "${nouns[0]}" $(__linkerd_override_flags) 2>/dev/null); then
+        COMPREPLY=( $( compgen -W "${linkerd_out[*]}" -- "$cur" ) )
+    fi
+}
+
+__custom_func() {
+    case ${last_command} in
+        linkerd_stat | linkerd_routes | linkerd_tap | linkerd_top)
+            __linkerd_get_resource
+            return
+            ;;
+        *)
+            ;;
+    esac
+}
+`
+
 func newCmdCompletion() *cobra.Command {
 	example := `  # bash <= 3.2
   source /dev/stdin <<< "$(linkerd completion bash)"
@@ -69,3 +129,72 @@ func getCompletion(sh string, parent *cobra.Command) (string, error) {
 
 	return buf.String(), nil
 }
+
+type completeResourcesOptions struct {
+	namespace string
+}
+
+func newCompleteResourcesOptions() *completeResourcesOptions {
+	return &completeResourcesOptions{
+		namespace: "default",
+	}
+}
+
+func newCmdCompleteResources() *cobra.Command {
+	options := newCompleteResourcesOptions()
+
+	cmd := &cobra.Command{
+		Use:    completeResourcesCmd + " [TYPE]",
+		Short:  "List meshed resources of a given type, for use in shell completion",
+		Hidden: true,
+		Args:   cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			names, err := getMeshedResourceNames(validatedPublicAPIClient(time.Time{}), args[0], options.namespace)
+			if err != nil {
+				return err
+			}
+
+			for _, name := range names {
+				fmt.Println(name)
+			}
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the resources")
+	markFlagConfigurable(cmd.PersistentFlags(), "namespace", "namespace")
+
+	return cmd
+}
+
+// getMeshedResourceNames returns the sorted names of the resources of the
+// given type in the namespace that have at least one meshed pod.
+func getMeshedResourceNames(client pb.ApiClient, resourceType, namespace string) ([]string, error) {
+	req, err := util.BuildStatSummaryRequest(util.StatsSummaryRequestParams{
+		StatsBaseRequestParams: util.StatsBaseRequestParams{
+			ResourceType: resourceType,
+			Namespace:    namespace,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.StatSummary(cliContext, req)
+	if err != nil {
+		return nil, err
+	}
+	if e := resp.GetError(); e != nil {
+		return nil, errors.New(e.Error)
+	}
+
+	names := make([]string, 0)
+	for _, row := range respToRows(resp) {
+		if row.MeshedPodCount > 0 {
+			names = append(names, row.Resource.Name)
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
@@ -1,8 +1,12 @@
 package cmd
 
 import (
+	"reflect"
 	"strings"
 	"testing"
+
+	"github.com/linkerd/linkerd2/controller/api/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
 )
 
 func TestCompletion(t *testing.T) {
@@ -22,6 +26,10 @@ func TestCompletion(t *testing.T) {
 			t.Fatalf("Unexpected bash output: %+v", bash)
 		}
 
+		if !strings.Contains(bash, "__linkerd_get_resource") {
+			t.Fatalf("Bash output is missing resource completion: %+v", bash)
+		}
+
 		if !strings.Contains(zsh, "#compdef linkerd") {
 			t.Fatalf("Unexpected zsh output: %+v", zsh)
 		}
@@ -34,3 +42,48 @@ func TestCompletion(t *testing.T) {
 		}
 	})
 }
+
+func TestGetMeshedResourceNames(t *testing.T) {
+	t.Run("Returns names of meshed resources", func(t *testing.T) {
+		mockClient := &public.MockApiClient{}
+		response := public.GenStatSummaryResponse("emoji", k8s.Deployment, []string{"emojivoto"}, &public.PodCounts{
+			MeshedPods:  1,
+			RunningPods: 2,
+		})
+		mockClient.StatSummaryResponseToReturn = &response
+
+		names, err := getMeshedResourceNames(mockClient, "deploy", "emojivoto")
+		if err != nil {
+			t.Fatalf("Unexpected error: %+v", err)
+		}
+
+		expected := []string{"emoji"}
+		if !reflect.DeepEqual(names, expected) {
+			t.Fatalf("Expected names %v, got %v", expected, names)
+		}
+	})
+
+	t.Run("Skips resources without meshed pods", func(t *testing.T) {
+		mockClient := &public.MockApiClient{}
+		response := public.GenStatSummaryResponse("emoji", k8s.Deployment, []string{"emojivoto"}, &public.PodCounts{
+			RunningPods: 2,
+		})
+		mockClient.StatSummaryResponseToReturn = &response
+
+		names, err := getMeshedResourceNames(mockClient, "deploy", "emojivoto")
+		if err != nil {
+			t.Fatalf("Unexpected error: %+v", err)
+		}
+
+		if len(names) != 0 {
+			t.Fatalf("Expected no names, got %v", names)
+		}
+	})
+
+	t.Run("Fails with invalid resource type", func(t *testing.T) {
+		_, err := getMeshedResourceNames(&public.MockApiClient{}, "foo", "emojivoto")
+		if err == nil {
+			t.Fatalf("Unexpected success for invalid resource type")
+		}
+	})
+}
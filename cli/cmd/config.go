@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// configKeyAnnotation is the flag annotation used to mark flags whose default
+// value can be provided by the user config file or by an environment variable.
+const configKeyAnnotation = "linkerd_config_key"
+
+// cliConfig holds the CLI defaults read from the user config file, by default
+// located at ~/.config/linkerd/config.yaml.
+type cliConfig struct {
+	Namespace        string `json:"namespace,omitempty"`
+	LinkerdNamespace string `json:"linkerdNamespace,omitempty"`
+	Output           string `json:"output,omitempty"`
+	TimeWindow       string `json:"timeWindow,omitempty"`
+	APIAddr          string `json:"apiAddr,omitempty"`
+}
+
+// configKeys maps each user config key to the environment variable that
+// overrides it.
+var configKeys = map[string]string{
+	"namespace":        "LINKERD_DEFAULT_NAMESPACE",
+	"linkerdNamespace": "LINKERD_NAMESPACE",
+	"output":           "LINKERD_OUTPUT",
+	"timeWindow":       "LINKERD_TIME_WINDOW",
+	"apiAddr":          "LINKERD_API_ADDR",
+}
+
+func (c *cliConfig) get(key string) string {
+	switch key {
+	case "namespace":
+		return c.Namespace
+	case "linkerdNamespace":
+		return c.LinkerdNamespace
+	case "output":
+		return c.Output
+	case "timeWindow":
+		return c.TimeWindow
+	case "apiAddr":
+		return c.APIAddr
+	}
+	return ""
+}
+
+// configFilePath returns the location of the user config file. It can be
+// overridden with $LINKERD_CONFIG; otherwise it's read from
+// $XDG_CONFIG_HOME/linkerd/config.yaml, falling back to ~/.config.
+func configFilePath() string {
+	if path := os.Getenv("LINKERD_CONFIG"); path != "" {
+		return path
+	}
+
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		home := os.Getenv("HOME")
+		if home == "" {
+			return ""
+		}
+		configHome = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(configHome, "linkerd", "config.yaml")
+}
+
+// readConfigFile parses the user config file at path. A missing file is not an
+// error, and results in an empty config.
+func readConfigFile(path string) (*cliConfig, error) {
+	config := &cliConfig{}
+	if path == "" {
+		return config, nil
+	}
+
+	bytes, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return config, nil
+		}
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(bytes, config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %s", path, err)
+	}
+
+	return config, nil
+}
+
+// markFlagConfigurable marks the named flag as taking its default value from
+// the given user config key.
+func markFlagConfigurable(flags *pflag.FlagSet, name, key string) {
+	flags.SetAnnotation(name, configKeyAnnotation, []string{key})
+}
+
+// applyConfigDefaults sets every configurable flag that was not explicitly set
+// on the command line, using the value from its environment variable or, if
+// that is unset, from the user config file. The resulting precedence is flags,
+// then environment, then config file.
+func applyConfigDefaults(cmd *cobra.Command, config *cliConfig) error {
+	var err error
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		keys, ok := flag.Annotations[configKeyAnnotation]
+		if err != nil || !ok || len(keys) == 0 || flag.Changed {
+			return
+		}
+
+		value := os.Getenv(configKeys[keys[0]])
+		if value == "" {
+			value = config.get(keys[0])
+		}
+		if value == "" {
+			return
+		}
+
+		if setErr := flag.Value.Set(value); setErr != nil {
+			err = fmt.Errorf("invalid value %q for --%s: %s", value, flag.Name, setErr)
+		}
+	})
+
+	return err
+}
+
+// markStatFlagsConfigurable marks the flags shared by the stats commands as
+// taking their defaults from the user config file.
+func markStatFlagsConfigurable(flags *pflag.FlagSet) {
+	markFlagConfigurable(flags, "namespace", "namespace")
+	markFlagConfigurable(flags, "time-window", "timeWindow")
+	markFlagConfigurable(flags, "output", "output")
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestReadConfigFile(t *testing.T) {
+	t.Run("Returns an empty config when the file does not exist", func(t *testing.T) {
+		config, err := readConfigFile(filepath.Join("testdata", "missing-config.yaml"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !reflect.DeepEqual(config, &cliConfig{}) {
+			t.Fatalf("Expected empty config, got %+v", config)
+		}
+	})
+
+	t.Run("Parses the config file", func(t *testing.T) {
+		config, err := readConfigFile(filepath.Join("testdata", "config.yaml"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expected := &cliConfig{
+			Namespace:        "emojivoto",
+			LinkerdNamespace: "linkerd-test",
+			Output:           "json",
+			TimeWindow:       "10m",
+			APIAddr:          "localhost:8085",
+		}
+		if !reflect.DeepEqual(config, expected) {
+			t.Fatalf("Expected config %+v, got %+v", expected, config)
+		}
+	})
+
+	t.Run("Returns an error when the file is invalid", func(t *testing.T) {
+		dir, err := ioutil.TempDir("", "linkerd-config")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "config.yaml")
+		if err := ioutil.WriteFile(path, []byte("namespace: [foo"), 0600); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if _, err := readConfigFile(path); err == nil {
+			t.Fatalf("Expected error for invalid config file, got nil")
+		}
+	})
+}
+
+func TestApplyConfigDefaults(t *testing.T) {
+	newTestCmd := func(options *statOptionsBase) *cobra.Command {
+		cmd := &cobra.Command{Use: "test"}
+		cmd.Flags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "")
+		cmd.Flags().StringVarP(&options.timeWindow, "time-window", "t", options.timeWindow, "")
+		cmd.Flags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, "")
+		markStatFlagsConfigurable(cmd.Flags())
+		return cmd
+	}
+
+	config := &cliConfig{
+		Namespace:  "emojivoto",
+		Output:     "json",
+		TimeWindow: "10m",
+	}
+
+	t.Run("Uses the config file when no flags or env are set", func(t *testing.T) {
+		options := newStatOptionsBase()
+		cmd := newTestCmd(options)
+		if err := cmd.ParseFlags([]string{}); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if err := applyConfigDefaults(cmd, config); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expected := &statOptionsBase{namespace: "emojivoto", timeWindow: "10m", outputFormat: "json"}
+		if !reflect.DeepEqual(options, expected) {
+			t.Fatalf("Expected options %+v, got %+v", expected, options)
+		}
+	})
+
+	t.Run("Prefers env over the config file, and flags over env", func(t *testing.T) {
+		os.Setenv("LINKERD_DEFAULT_NAMESPACE", "from-env")
+		os.Setenv("LINKERD_TIME_WINDOW", "1h")
+		defer os.Unsetenv("LINKERD_DEFAULT_NAMESPACE")
+		defer os.Unsetenv("LINKERD_TIME_WINDOW")
+
+		options := newStatOptionsBase()
+		cmd := newTestCmd(options)
+		if err := cmd.ParseFlags([]string{"-n", "from-flag"}); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if err := applyConfigDefaults(cmd, config); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expected := &statOptionsBase{namespace: "from-flag", timeWindow: "1h", outputFormat: "json"}
+		if !reflect.DeepEqual(options, expected) {
+			t.Fatalf("Expected options %+v, got %+v", expected, options)
+		}
+	})
+}
@@ -0,0 +1,526 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	runewidth "github.com/mattn/go-runewidth"
+	termbox "github.com/nsf/termbox-go"
+	"github.com/spf13/cobra"
+)
+
+type consoleOptions struct {
+	namespace       string
+	resourceType    string
+	timeWindow      string
+	refreshInterval time.Duration
+	maxRps          float32
+}
+
+type consoleView int
+
+const (
+	consoleStatView consoleView = iota
+	consoleDetailView
+	consoleTapView
+)
+
+// maxTapLines is the number of tap events kept in the tap view.
+const maxTapLines = 500
+
+type consoleRow struct {
+	name   string
+	meshed string
+	*rowStats
+}
+
+// consoleState holds everything displayed by the console. It's only modified
+// from the main loop in runConsole.
+type consoleState struct {
+	view     consoleView
+	rows     []consoleRow
+	selected int
+	routes   []*rowStats
+	edges    []consoleRow
+	tapLines []string
+	err      error
+	quit     bool
+}
+
+var consoleColumnNames = []string{"NAME", "MESHED", "SUCCESS", "RPS", "LATENCY_P50", "LATENCY_P95", "LATENCY_P99"}
+
+func newConsoleOptions() *consoleOptions {
+	return &consoleOptions{
+		namespace:       "default",
+		resourceType:    "deploy",
+		timeWindow:      "1m",
+		refreshInterval: 5 * time.Second,
+		maxRps:          100.0,
+	}
+}
+
+func newCmdConsole() *cobra.Command {
+	options := newConsoleOptions()
+
+	cmd := &cobra.Command{
+		Use:   "console [flags]",
+		Short: "Interactive terminal dashboard for a namespace",
+		Long: `Interactive terminal dashboard for a namespace.
+
+The console displays live stats for the resources in a namespace. Select a
+resource to drill down into its routes and the resources it sends traffic to,
+or to tap its live requests.
+
+  Keys:
+  * up/down, j/k: select a resource
+  * enter:        show routes and outbound traffic of the selected resource
+  * t:            tap the selected resource
+  * esc:          go back
+  * q:            quit`,
+		Example: `  # display the deployments in the emojivoto namespace
+  linkerd console -n emojivoto
+
+  # display the pods in the default namespace
+  linkerd console --resource pods`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := time.ParseDuration(options.timeWindow); err != nil {
+				return newCliError(exitCodeInvalidFlags, fmt.Errorf("invalid --time-window: %s", err))
+			}
+			if options.refreshInterval <= 0 {
+				return newCliError(exitCodeInvalidFlags, errors.New("--refresh must be positive"))
+			}
+
+			return runConsole(validatedPublicAPIClient(time.Time{}), options)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace to display")
+	cmd.PersistentFlags().StringVar(&options.resourceType, "resource", options.resourceType, "Type of the resources to display")
+	cmd.PersistentFlags().StringVarP(&options.timeWindow, "time-window", "t", options.timeWindow, "Stat window (for example: \"10s\", \"1m\", \"10m\", \"1h\")")
+	cmd.PersistentFlags().DurationVar(&options.refreshInterval, "refresh", options.refreshInterval, "Interval between stat refreshes")
+	cmd.PersistentFlags().Float32Var(&options.maxRps, "max-rps", options.maxRps, "Maximum requests per second to tap")
+	markFlagConfigurable(cmd.PersistentFlags(), "namespace", "namespace")
+	markFlagConfigurable(cmd.PersistentFlags(), "time-window", "timeWindow")
+
+	return cmd
+}
+
+func runConsole(client pb.ApiClient, options *consoleOptions) error {
+	err := termbox.Init()
+	if err != nil {
+		return err
+	}
+	defer termbox.Close()
+
+	events := make(chan termbox.Event)
+	go func() {
+		for {
+			events <- termbox.PollEvent()
+		}
+	}()
+
+	// updates are applied to the state from the main loop, so that fetches can
+	// run in the background without locking
+	updates := make(chan func(*consoleState), 100)
+	state := &consoleState{}
+	var cancelTap context.CancelFunc
+
+	refresh := func() {
+		view := state.view
+		selected := state.selectedName()
+		go func() {
+			updates <- fetchConsoleStats(client, options)
+			if view == consoleDetailView && selected != "" {
+				updates <- fetchConsoleDetail(client, options, selected)
+			}
+		}()
+	}
+	refresh()
+
+	ticker := time.NewTicker(options.refreshInterval)
+	defer ticker.Stop()
+
+	for !state.quit {
+		renderConsole(state, options)
+
+		select {
+		case ev := <-events:
+			if ev.Type != termbox.EventKey {
+				continue
+			}
+			previousView := state.view
+			handleConsoleKey(state, ev)
+
+			if previousView == consoleTapView && state.view != consoleTapView && cancelTap != nil {
+				cancelTap()
+				cancelTap = nil
+			}
+			if previousView != state.view {
+				switch state.view {
+				case consoleDetailView:
+					refresh()
+				case consoleTapView:
+					var ctx context.Context
+					ctx, cancelTap = context.WithCancel(context.Background())
+					go streamConsoleTap(ctx, client, options, state.selectedName(), updates)
+				}
+			}
+		case update := <-updates:
+			update(state)
+		case <-ticker.C:
+			refresh()
+		}
+	}
+
+	if cancelTap != nil {
+		cancelTap()
+	}
+	return nil
+}
+
+func (s *consoleState) selectedName() string {
+	if s.selected < 0 || s.selected >= len(s.rows) {
+		return ""
+	}
+	return s.rows[s.selected].name
+}
+
+// handleConsoleKey updates the state in response to a key press.
+func handleConsoleKey(state *consoleState, ev termbox.Event) {
+	switch {
+	case ev.Ch == 'q' || ev.Key == termbox.KeyCtrlC:
+		state.quit = true
+	case ev.Key == termbox.KeyEsc || ev.Key == termbox.KeyBackspace || ev.Key == termbox.KeyBackspace2:
+		switch state.view {
+		case consoleTapView, consoleDetailView:
+			state.view = consoleStatView
+		}
+	case state.view != consoleStatView:
+		// navigation keys only apply to the stat view
+	case ev.Key == termbox.KeyArrowUp || ev.Ch == 'k':
+		if state.selected > 0 {
+			state.selected--
+		}
+	case ev.Key == termbox.KeyArrowDown || ev.Ch == 'j':
+		if state.selected < len(state.rows)-1 {
+			state.selected++
+		}
+	case ev.Key == termbox.KeyEnter:
+		if state.selectedName() != "" {
+			state.routes = nil
+			state.edges = nil
+			state.view = consoleDetailView
+		}
+	case ev.Ch == 't':
+		if state.selectedName() != "" {
+			state.tapLines = nil
+			state.view = consoleTapView
+		}
+	}
+}
+
+func fetchConsoleStats(client pb.ApiClient, options *consoleOptions) func(*consoleState) {
+	rows, err := requestConsoleRows(client, util.StatsSummaryRequestParams{
+		StatsBaseRequestParams: util.StatsBaseRequestParams{
+			TimeWindow:   options.timeWindow,
+			Namespace:    options.namespace,
+			ResourceType: options.resourceType,
+		},
+	})
+
+	return func(state *consoleState) {
+		state.err = err
+		if err != nil {
+			return
+		}
+
+		// keep the same resource selected across refreshes
+		selected := state.selectedName()
+		state.rows = rows
+		state.selected = 0
+		for i, row := range rows {
+			if row.name == selected {
+				state.selected = i
+			}
+		}
+	}
+}
+
+func fetchConsoleDetail(client pb.ApiClient, options *consoleOptions, name string) func(*consoleState) {
+	edges, err := requestConsoleRows(client, util.StatsSummaryRequestParams{
+		StatsBaseRequestParams: util.StatsBaseRequestParams{
+			TimeWindow:   options.timeWindow,
+			Namespace:    options.namespace,
+			ResourceType: options.resourceType,
+		},
+		FromName: name,
+		FromType: options.resourceType,
+	})
+
+	var routes []*rowStats
+	if err == nil {
+		routes, err = requestConsoleRoutes(client, options, name)
+	}
+
+	return func(state *consoleState) {
+		state.err = err
+		if err != nil || state.selectedName() != name {
+			return
+		}
+		state.edges = edges
+		state.routes = routes
+	}
+}
+
+func requestConsoleRows(client pb.ApiClient, params util.StatsSummaryRequestParams) ([]consoleRow, error) {
+	req, err := util.BuildStatSummaryRequest(params)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.StatSummary(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+	if e := resp.GetError(); e != nil {
+		return nil, errors.New(e.Error)
+	}
+
+	return toConsoleRows(respToRows(resp)), nil
+}
+
+func toConsoleRows(rows []*pb.StatTable_PodGroup_Row) []consoleRow {
+	consoleRows := make([]consoleRow, 0)
+	for _, r := range rows {
+		row := consoleRow{
+			name:   r.Resource.Name,
+			meshed: fmt.Sprintf("%d/%d", r.MeshedPodCount, r.RunningPodCount),
+		}
+		if r.Stats != nil {
+			row.rowStats = &rowStats{
+				requestRate: util.GetRequestRate(r.Stats, r.TimeWindow),
+				successRate: util.GetSuccessRate(r.Stats),
+				tlsPercent:  util.GetPercentTls(r.Stats),
+				latencyP50:  r.Stats.LatencyMsP50,
+				latencyP95:  r.Stats.LatencyMsP95,
+				latencyP99:  r.Stats.LatencyMsP99,
+			}
+		}
+		consoleRows = append(consoleRows, row)
+	}
+
+	sort.Slice(consoleRows, func(i, j int) bool {
+		return consoleRows[i].name < consoleRows[j].name
+	})
+
+	return consoleRows
+}
+
+func requestConsoleRoutes(client pb.ApiClient, options *consoleOptions, name string) ([]*rowStats, error) {
+	req, err := util.BuildTopRoutesRequest(util.TopRoutesRequestParams{
+		StatsBaseRequestParams: util.StatsBaseRequestParams{
+			TimeWindow:   options.timeWindow,
+			Namespace:    options.namespace,
+			ResourceType: options.resourceType,
+			ResourceName: name,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.TopRoutes(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+	if e := resp.GetError(); e != nil {
+		// resources without a service profile have no routes
+		return []*rowStats{}, nil
+	}
+
+	routes := make([]*rowStats, 0)
+	for _, r := range resp.GetRoutes().Rows {
+		if r.Stats == nil {
+			continue
+		}
+		route := r.GetRoute()
+		if route == "" {
+			route = defaultRoute
+		}
+		routes = append(routes, &rowStats{
+			route:       route,
+			dst:         r.GetAuthority(),
+			requestRate: util.GetRequestRate(r.Stats, r.TimeWindow),
+			successRate: util.GetSuccessRate(r.Stats),
+			tlsPercent:  util.GetPercentTls(r.Stats),
+			latencyP50:  r.Stats.LatencyMsP50,
+			latencyP95:  r.Stats.LatencyMsP95,
+			latencyP99:  r.Stats.LatencyMsP99,
+		})
+	}
+
+	sort.Slice(routes, func(i, j int) bool {
+		return routes[i].route+routes[i].dst < routes[j].route+routes[j].dst
+	})
+
+	return routes, nil
+}
+
+func streamConsoleTap(ctx context.Context, client pb.ApiClient, options *consoleOptions, name string, updates chan<- func(*consoleState)) {
+	req, err := util.BuildTapByResourceRequest(util.TapRequestParams{
+		Resource:  options.resourceType + "/" + name,
+		Namespace: options.namespace,
+		MaxRps:    options.maxRps,
+	})
+	if err != nil {
+		updates <- func(state *consoleState) { state.err = err }
+		return
+	}
+
+	rsp, err := client.TapByResource(ctx, req)
+	if err != nil {
+		updates <- func(state *consoleState) { state.err = err }
+		return
+	}
+
+	for {
+		event, err := rsp.Recv()
+		if ctx.Err() != nil {
+			return
+		}
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			updates <- func(state *consoleState) { state.err = err }
+			return
+		}
+
+		line := util.RenderTapEvent(event, "")
+		updates <- func(state *consoleState) {
+			if state.view != consoleTapView {
+				return
+			}
+			state.tapLines = append(state.tapLines, line)
+			if len(state.tapLines) > maxTapLines {
+				state.tapLines = state.tapLines[len(state.tapLines)-maxTapLines:]
+			}
+		}
+	}
+}
+
+func renderConsole(state *consoleState, options *consoleOptions) {
+	termbox.Clear(termbox.ColorDefault, termbox.ColorDefault)
+	_, height := termbox.Size()
+
+	title := fmt.Sprintf("linkerd console: %s in %s", options.resourceType, options.namespace)
+	switch state.view {
+	case consoleStatView:
+		tbprint(0, 0, title+"  (up/down select, enter details, t tap, q quit)")
+		renderConsoleRows(2, state.rows, state.selected)
+	case consoleDetailView:
+		tbprint(0, 0, fmt.Sprintf("%s/%s  (t tap, esc back, q quit)", title, state.selectedName()))
+		tbprintBold(0, 2, "ROUTES")
+		y := renderConsoleRoutes(3, state.routes)
+		tbprintBold(0, y+1, "OUTBOUND")
+		renderConsoleRows(y+2, state.edges, -1)
+	case consoleTapView:
+		tbprint(0, 0, fmt.Sprintf("%s/%s  (esc back, q quit)", title, state.selectedName()))
+		lines := state.tapLines
+		if visible := height - 3; visible > 0 && len(lines) > visible {
+			lines = lines[len(lines)-visible:]
+		}
+		for i, line := range lines {
+			tbprint(0, i+2, line)
+		}
+	}
+
+	if state.err != nil {
+		tbprintAttr(0, height-1, fmt.Sprintf("Error: %s", state.err), termbox.ColorRed, termbox.ColorDefault)
+	}
+
+	termbox.Flush()
+}
+
+func renderConsoleRows(y int, rows []consoleRow, selected int) {
+	nameWidth := len(consoleColumnNames[0])
+	for _, row := range rows {
+		nameWidth = max(nameWidth, runewidth.StringWidth(row.name))
+	}
+
+	tbprintBold(0, y, fmt.Sprintf("%-*s %-8s %-8s %-8s %-12s %-12s %-12s", nameWidth, consoleColumnNames[0],
+		consoleColumnNames[1], consoleColumnNames[2], consoleColumnNames[3], consoleColumnNames[4], consoleColumnNames[5], consoleColumnNames[6]))
+
+	for i, row := range rows {
+		fg, bg := termbox.ColorDefault, termbox.ColorDefault
+		if i == selected {
+			fg = termbox.AttrReverse
+		}
+
+		line := fmt.Sprintf("%-*s %-8s", nameWidth, row.name, row.meshed)
+		stats := fmt.Sprintf(" %-8s %-8s %-12s %-12s %-12s", "-", "-", "-", "-", "-")
+		if row.rowStats != nil {
+			if color := successRateColor(row.successRate); color != colorDefault && i != selected {
+				fg = termboxColor(color)
+			}
+			stats = fmt.Sprintf(" %-8s %-8s %-12s %-12s %-12s",
+				fmt.Sprintf("%.2f%%", row.successRate*100),
+				fmt.Sprintf("%.1frps", row.requestRate),
+				fmt.Sprintf("%dms", row.latencyP50),
+				fmt.Sprintf("%dms", row.latencyP95),
+				fmt.Sprintf("%dms", row.latencyP99))
+		}
+		tbprintAttr(0, y+i+1, line+stats, fg, bg)
+	}
+}
+
+// renderConsoleRoutes prints the routes table starting at line y, and returns
+// the line following it.
+func renderConsoleRoutes(y int, routes []*rowStats) int {
+	routeWidth := len("ROUTE")
+	for _, route := range routes {
+		routeWidth = max(routeWidth, runewidth.StringWidth(route.route))
+	}
+
+	tbprintBold(0, y, fmt.Sprintf("%-*s %-8s %-8s %-12s %-12s %-12s", routeWidth, "ROUTE",
+		"SUCCESS", "RPS", "LATENCY_P50", "LATENCY_P95", "LATENCY_P99"))
+
+	for i, route := range routes {
+		tbprintAttr(0, y+i+1, fmt.Sprintf("%-*s %-8s %-8s %-12s %-12s %-12s", routeWidth, route.route,
+			fmt.Sprintf("%.2f%%", route.successRate*100),
+			fmt.Sprintf("%.1frps", route.requestRate),
+			fmt.Sprintf("%dms", route.latencyP50),
+			fmt.Sprintf("%dms", route.latencyP95),
+			fmt.Sprintf("%dms", route.latencyP99)),
+			termboxColor(successRateColor(route.successRate)), termbox.ColorDefault)
+	}
+
+	return y + len(routes) + 2
+}
+
+func termboxColor(color termColor) termbox.Attribute {
+	switch color {
+	case colorRed:
+		return termbox.ColorRed
+	case colorGreen:
+		return termbox.ColorGreen
+	case colorYellow:
+		return termbox.ColorYellow
+	default:
+		return termbox.ColorDefault
+	}
+}
+
+func tbprintAttr(x, y int, msg string, fg, bg termbox.Attribute) {
+	for _, c := range msg {
+		termbox.SetCell(x, y, c, fg, bg)
+		x += runewidth.RuneWidth(c)
+	}
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/linkerd/linkerd2/controller/api/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	termbox "github.com/nsf/termbox-go"
+)
+
+func TestHandleConsoleKey(t *testing.T) {
+	newState := func() *consoleState {
+		return &consoleState{
+			rows: []consoleRow{{name: "emoji"}, {name: "voting"}, {name: "web"}},
+		}
+	}
+
+	t.Run("Moves the selection within bounds", func(t *testing.T) {
+		state := newState()
+		handleConsoleKey(state, termbox.Event{Key: termbox.KeyArrowUp})
+		if state.selected != 0 {
+			t.Fatalf("Expected selection to stay at 0, got %d", state.selected)
+		}
+
+		for i := 0; i < 5; i++ {
+			handleConsoleKey(state, termbox.Event{Ch: 'j'})
+		}
+		if state.selectedName() != "web" {
+			t.Fatalf("Expected [web] to be selected, got [%s]", state.selectedName())
+		}
+	})
+
+	t.Run("Drills down into details and tap, and back", func(t *testing.T) {
+		state := newState()
+		handleConsoleKey(state, termbox.Event{Key: termbox.KeyEnter})
+		if state.view != consoleDetailView {
+			t.Fatalf("Expected detail view, got %d", state.view)
+		}
+
+		handleConsoleKey(state, termbox.Event{Key: termbox.KeyArrowDown})
+		if state.selected != 0 {
+			t.Fatalf("Expected selection not to change outside the stat view, got %d", state.selected)
+		}
+
+		handleConsoleKey(state, termbox.Event{Key: termbox.KeyEsc})
+		if state.view != consoleStatView {
+			t.Fatalf("Expected stat view, got %d", state.view)
+		}
+
+		handleConsoleKey(state, termbox.Event{Ch: 't'})
+		if state.view != consoleTapView {
+			t.Fatalf("Expected tap view, got %d", state.view)
+		}
+	})
+
+	t.Run("Does not drill down without resources", func(t *testing.T) {
+		state := &consoleState{}
+		handleConsoleKey(state, termbox.Event{Key: termbox.KeyEnter})
+		if state.view != consoleStatView {
+			t.Fatalf("Expected stat view, got %d", state.view)
+		}
+	})
+
+	t.Run("Quits", func(t *testing.T) {
+		state := newState()
+		handleConsoleKey(state, termbox.Event{Ch: 'q'})
+		if !state.quit {
+			t.Fatalf("Expected console to quit")
+		}
+	})
+}
+
+func TestToConsoleRows(t *testing.T) {
+	resp := public.GenStatSummaryResponse("emoji", k8s.Deployment, []string{"emojivoto"}, &public.PodCounts{
+		MeshedPods:  1,
+		RunningPods: 2,
+	})
+
+	rows := toConsoleRows(respToRows(&resp))
+	if len(rows) != 1 {
+		t.Fatalf("Expected 1 row, got %d", len(rows))
+	}
+	if rows[0].name != "emoji" || rows[0].meshed != "1/2" {
+		t.Fatalf("Unexpected row: %+v", rows[0])
+	}
+	if rows[0].rowStats == nil || rows[0].successRate != 1.0 || rows[0].latencyP50 != 123 {
+		t.Fatalf("Unexpected row stats: %+v", rows[0].rowStats)
+	}
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+)
+
+// clusterHeader is the header of the column identifying the kubeconfig
+// context that each row was read from.
+const clusterHeader = "CLUSTER"
+
+// multiContextOptions configures commands that can run against several
+// kubeconfig contexts at once.
+type multiContextOptions struct {
+	contexts    []string
+	allContexts bool
+}
+
+func newMultiContextOptions() *multiContextOptions {
+	return &multiContextOptions{
+		contexts:    []string{},
+		allContexts: false,
+	}
+}
+
+func addMultiContextFlags(cmd *cobra.Command, options *multiContextOptions) {
+	cmd.PersistentFlags().StringSliceVar(&options.contexts, "contexts", options.contexts, "Comma-separated list of kubeconfig contexts to run against concurrently, instead of --context")
+	cmd.PersistentFlags().BoolVar(&options.allContexts, "all-contexts", options.allContexts, "Run against every context in the kubeconfig file")
+}
+
+// enabled returns true if the command should run against multiple contexts.
+func (o *multiContextOptions) enabled() bool {
+	return o.allContexts || len(o.contexts) > 0
+}
+
+func (o *multiContextOptions) validate() error {
+	if o.allContexts && len(o.contexts) > 0 {
+		return fmt.Errorf("--contexts and --all-contexts flags are mutually exclusive")
+	}
+	if o.enabled() && kubeContext != "" {
+		return fmt.Errorf("--context flag is incompatible with --contexts and --all-contexts")
+	}
+	if o.enabled() && apiAddr != "" {
+		return fmt.Errorf("--api-addr flag is incompatible with --contexts and --all-contexts")
+	}
+	return nil
+}
+
+// resolve returns the sorted list of contexts to run against.
+func (o *multiContextOptions) resolve() ([]string, error) {
+	if o.allContexts {
+		return k8s.GetContexts(kubeconfigPath)
+	}
+
+	seen := map[string]bool{}
+	contexts := []string{}
+	for _, context := range o.contexts {
+		if !seen[context] {
+			seen[context] = true
+			contexts = append(contexts, context)
+		}
+	}
+	sort.Strings(contexts)
+	return contexts, nil
+}
+
+type contextResult struct {
+	context string
+	value   interface{}
+	err     error
+}
+
+// fanOutContexts runs fn concurrently for each context, and returns the
+// results indexed by context. It fails with the first error, naming the
+// context that returned it.
+func fanOutContexts(contexts []string, fn func(context string) (interface{}, error)) (map[string]interface{}, error) {
+	c := make(chan contextResult, len(contexts))
+	for _, context := range contexts {
+		go func(context string) {
+			value, err := fn(context)
+			c <- contextResult{context, value, err}
+		}(context)
+	}
+
+	results := map[string]interface{}{}
+	var firstErr error
+	for range contexts {
+		res := <-c
+		if res.err != nil {
+			if firstErr == nil {
+				firstErr = newCliError(classifyError(res.err).code, fmt.Errorf("context %s: %s", res.context, res.err))
+			}
+			continue
+		}
+		results[res.context] = res.value
+	}
+
+	return results, firstErr
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMultiContextOptions(t *testing.T) {
+	t.Run("Resolves a sorted list of unique contexts", func(t *testing.T) {
+		options := newMultiContextOptions()
+		options.contexts = []string{"west", "east", "west"}
+
+		contexts, err := options.resolve()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expected := []string{"east", "west"}
+		if !reflect.DeepEqual(contexts, expected) {
+			t.Fatalf("Expected contexts %v, got %v", expected, contexts)
+		}
+	})
+
+	t.Run("Rejects --contexts with --all-contexts", func(t *testing.T) {
+		options := newMultiContextOptions()
+		options.contexts = []string{"east"}
+		options.allContexts = true
+
+		if err := options.validate(); err == nil {
+			t.Fatalf("Expected error, got nil")
+		}
+	})
+}
+
+func TestFanOutContexts(t *testing.T) {
+	t.Run("Returns the result of each context", func(t *testing.T) {
+		results, err := fanOutContexts([]string{"east", "west"}, func(context string) (interface{}, error) {
+			return "stats from " + context, nil
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expected := map[string]interface{}{"east": "stats from east", "west": "stats from west"}
+		if !reflect.DeepEqual(results, expected) {
+			t.Fatalf("Expected results %v, got %v", expected, results)
+		}
+	})
+
+	t.Run("Names the context that failed", func(t *testing.T) {
+		_, err := fanOutContexts([]string{"east", "west"}, func(context string) (interface{}, error) {
+			if context == "west" {
+				return nil, errors.New("connection refused")
+			}
+			return nil, nil
+		})
+
+		expected := "context west: connection refused"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error [%s], got [%v]", expected, err)
+		}
+	})
+}
@@ -0,0 +1,530 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ghodss/yaml"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+	"k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	yamlDecoder "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+const (
+	// webUUIDArg prefixes the UUID of the control plane in the arguments of
+	// the linkerd-web container.
+	webUUIDArg = "-uuid="
+
+	driftMissing       = "Missing"
+	driftChanged       = "Changed"
+	driftExtra         = "Extra"
+	driftOutdatedProxy = "OutdatedProxy"
+)
+
+type diffOptions struct {
+	outputFormat string
+	workloads    bool
+	*installOptions
+}
+
+func newDiffOptions() *diffOptions {
+	return &diffOptions{
+		outputFormat:   tableOutput,
+		workloads:      true,
+		installOptions: newInstallOptions(),
+	}
+}
+
+// the API paths listing the rendered kinds that are not prunable
+var diffKindPaths = map[string]func(namespace string) string{
+	"Namespace":                  func(string) string { return "/api/v1/namespaces" },
+	"CustomResourceDefinition":   func(string) string { return "/apis/apiextensions.k8s.io/v1beta1/customresourcedefinitions" },
+	"DaemonSet":                  func(ns string) string { return "/apis/apps/v1/namespaces/" + ns + "/daemonsets" },
+	"SecurityContextConstraints": func(string) string { return "/apis/security.openshift.io/v1/securitycontextconstraints" },
+}
+
+// the API paths listing the workloads of all namespaces that can be injected
+var diffWorkloadPaths = map[string]string{
+	"DaemonSet":   "/apis/apps/v1/daemonsets",
+	"Deployment":  "/apis/apps/v1/deployments",
+	"StatefulSet": "/apis/apps/v1/statefulsets",
+}
+
+// renderedObject is an object of the rendered control plane.
+type renderedObject struct {
+	kind      string
+	namespace string
+	name      string
+	object    map[string]interface{}
+}
+
+func (o renderedObject) key() string {
+	return objectKey(o.kind, o.namespace, o.name)
+}
+
+// path returns the API path of the object, or false if its kind is unknown.
+func (o renderedObject) path() (string, bool) {
+	for _, kind := range prunableKinds {
+		if kind.kind == o.kind {
+			return kind.path(o.namespace) + "/" + o.name, true
+		}
+	}
+	if path, ok := diffKindPaths[o.kind]; ok {
+		return path(o.namespace) + "/" + o.name, true
+	}
+	return "", false
+}
+
+// driftedObject is an object in the cluster that doesn't match the rendered
+// configuration.
+type driftedObject struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	Detail    string `json:"detail"`
+}
+
+type driftReport struct {
+	InSync  bool            `json:"inSync"`
+	Checked int             `json:"checked"`
+	Drifted []driftedObject `json:"drifted"`
+}
+
+type workloadList struct {
+	Items []struct {
+		Metadata metaV1.ObjectMeta `json:"metadata"`
+		Spec     struct {
+			Template v1.PodTemplateSpec `json:"template"`
+		} `json:"spec"`
+	} `json:"items"`
+}
+
+func newCmdDiff() *cobra.Command {
+	options := newDiffOptions()
+
+	cmd := &cobra.Command{
+		Use:   "diff [flags]",
+		Short: "Report the drift between the configuration and the cluster",
+		Long: `Report the drift between the configuration and the cluster.
+
+The diff command renders the control plane with the given install flags and
+compares it to the objects in the cluster. It reports:
+  * rendered objects that are not in the cluster (Missing)
+  * objects that differ from the rendered ones (Changed)
+  * control plane objects that are no longer rendered (Extra)
+  * injected workloads running a different proxy (OutdatedProxy)
+
+Objects installed with 'linkerd install --config-hash' are compared by the hash
+of their configuration; the others by the fields set in the rendered objects.
+Pass the same flags that were used with 'linkerd install'. The UUID of the
+installed control plane is rendered in place of a new one.
+
+The command exits with a non-zero exit code if any drift is reported, and its
+JSON output can be used as a health check by GitOps tools.`,
+		Example: `  # Report the drift of the control plane and the injected workloads
+  linkerd diff
+
+  # Report the drift of an HA control plane as JSON
+  linkerd diff --ha -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.outputFormat != tableOutput && options.outputFormat != jsonOutput {
+				return newCliError(exitCodeInvalidFlags, fmt.Errorf("--output supports %s and %s", tableOutput, jsonOutput))
+			}
+			config, err := validateAndBuildConfig(options.installOptions)
+			if err != nil {
+				return newCliError(exitCodeInvalidFlags, err)
+			}
+
+			kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup)
+			if err != nil {
+				return err
+			}
+			client, err := kubeAPI.NewClient()
+			if err != nil {
+				return err
+			}
+
+			// every render generates a new UUID, so keep the installed one
+			uuid, err := installedUUID(kubeAPI, client, controlPlaneNamespace)
+			if err != nil {
+				return fmt.Errorf("failed to get the UUID of the control plane: %s", err)
+			}
+			if uuid != "" {
+				config.UUID = uuid
+			}
+
+			var buf bytes.Buffer
+			if err := render(*config, &buf, options.installOptions); err != nil {
+				return err
+			}
+			rendered, err := parseRenderedObjects(&buf)
+			if err != nil {
+				return err
+			}
+
+			live := map[string]map[string]interface{}{}
+			for _, object := range rendered {
+				path, ok := object.path()
+				if !ok {
+					continue
+				}
+				var liveObject map[string]interface{}
+				found, err := kubeAPI.GetObject(client, path, &liveObject)
+				if err != nil {
+					return fmt.Errorf("failed to get %s %s: %s", object.kind, object.name, err)
+				}
+				if found {
+					live[object.key()] = liveObject
+				}
+			}
+
+			inCluster := map[string][]metaV1.ObjectMeta{}
+			for _, kind := range prunableKinds {
+				objects, err := kubeAPI.ListObjectMeta(client, kind.path(controlPlaneNamespace))
+				if err != nil {
+					return fmt.Errorf("failed to list %s objects: %s", kind.kind, err)
+				}
+				inCluster[kind.kind] = objects
+			}
+
+			report := &driftReport{Checked: len(rendered)}
+			drifted, err := controlPlaneDrift(rendered, live, inCluster, controlPlaneNamespace)
+			if err != nil {
+				return err
+			}
+			report.Drifted = drifted
+
+			if options.workloads {
+				for _, kind := range sortedWorkloadKinds() {
+					var list workloadList
+					if _, err := kubeAPI.GetObject(client, diffWorkloadPaths[kind], &list); err != nil {
+						return fmt.Errorf("failed to list %s objects: %s", kind, err)
+					}
+					for _, item := range list.Items {
+						if item.Metadata.Namespace == controlPlaneNamespace || item.Spec.Template.Labels[k8s.ControllerNSLabel] != controlPlaneNamespace {
+							continue
+						}
+						report.Checked++
+						if detail := workloadDrift(item.Spec.Template, options.proxyConfigOptions); detail != "" {
+							report.Drifted = append(report.Drifted, driftedObject{
+								Kind:      kind,
+								Namespace: item.Metadata.Namespace,
+								Name:      item.Metadata.Name,
+								Status:    driftOutdatedProxy,
+								Detail:    detail,
+							})
+						}
+					}
+				}
+			}
+
+			report.InSync = len(report.Drifted) == 0
+			if err := renderDriftReport(report, os.Stdout, options.outputFormat); err != nil {
+				return err
+			}
+
+			if !report.InSync {
+				os.Exit(exitCodeCheckFailed)
+			}
+			return nil
+		},
+	}
+
+	addInstallFlags(cmd, options.installOptions)
+	cmd.PersistentFlags().MarkHidden("ignore-cluster")
+	cmd.PersistentFlags().BoolVar(&options.workloads, "workloads", options.workloads, "Also report the injected workloads running a different proxy than the configured one")
+	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, "Output format; one of: \"table\" or \"json\"")
+
+	return cmd
+}
+
+// installedUUID returns the UUID of the installed control plane, read from the
+// arguments of its linkerd-web deployment, or an empty string if there is
+// none.
+func installedUUID(kubeAPI *k8s.KubernetesAPI, client *http.Client, namespace string) (string, error) {
+	var deployment struct {
+		Spec struct {
+			Template v1.PodTemplateSpec `json:"template"`
+		} `json:"spec"`
+	}
+	path := fmt.Sprintf("/apis/apps/v1/namespaces/%s/deployments/linkerd-web", namespace)
+	found, err := kubeAPI.GetObject(client, path, &deployment)
+	if err != nil || !found {
+		return "", err
+	}
+	return uuidArg(deployment.Spec.Template.Spec.Containers), nil
+}
+
+// uuidArg returns the UUID in the arguments of the containers, or an empty
+// string if there is none.
+func uuidArg(containers []v1.Container) string {
+	for _, container := range containers {
+		for _, arg := range container.Args {
+			if strings.HasPrefix(arg, webUUIDArg) {
+				return strings.TrimPrefix(arg, webUUIDArg)
+			}
+		}
+	}
+	return ""
+}
+
+func sortedWorkloadKinds() []string {
+	kinds := make([]string, 0, len(diffWorkloadPaths))
+	for kind := range diffWorkloadPaths {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// parseRenderedObjects decodes the objects of the rendered manifests.
+func parseRenderedObjects(manifests io.Reader) ([]renderedObject, error) {
+	reader := yamlDecoder.NewYAMLReader(bufio.NewReaderSize(manifests, 4096))
+	objects := []renderedObject{}
+
+	for {
+		bytes, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var object map[string]interface{}
+		if err := yaml.Unmarshal(bytes, &object); err != nil {
+			return nil, err
+		}
+		kind, _ := object["kind"].(string)
+		if kind == "" {
+			continue
+		}
+
+		var meta objMeta
+		if err := yaml.Unmarshal(bytes, &meta); err != nil {
+			return nil, err
+		}
+		objects = append(objects, renderedObject{
+			kind:      kind,
+			namespace: meta.Namespace,
+			name:      meta.Name,
+			object:    object,
+		})
+	}
+
+	return objects, nil
+}
+
+// controlPlaneDrift compares the rendered objects to the live ones, keyed by
+// objectKey, and the control plane objects in the cluster to the rendered
+// ones.
+func controlPlaneDrift(rendered []renderedObject, live map[string]map[string]interface{}, inCluster map[string][]metaV1.ObjectMeta, namespace string) ([]driftedObject, error) {
+	drifted := []driftedObject{}
+	renderedKeys := map[string]bool{}
+
+	for _, object := range rendered {
+		renderedKeys[object.key()] = true
+		if _, ok := object.path(); !ok {
+			continue
+		}
+
+		drift := driftedObject{Kind: object.kind, Namespace: object.namespace, Name: object.name}
+		liveObject, ok := live[object.key()]
+		if !ok {
+			drift.Status = driftMissing
+			drift.Detail = "not found in the cluster"
+			drifted = append(drifted, drift)
+			continue
+		}
+
+		detail, err := objectDrift(object.object, liveObject)
+		if err != nil {
+			return nil, err
+		}
+		if detail != "" {
+			drift.Status = driftChanged
+			drift.Detail = detail
+			drifted = append(drifted, drift)
+		}
+	}
+
+	for _, object := range staleObjects(renderedKeys, inCluster, namespace) {
+		drifted = append(drifted, driftedObject{
+			Kind:      object.kind.kind,
+			Namespace: object.namespace,
+			Name:      object.name,
+			Status:    driftExtra,
+			Detail:    "no longer part of the control plane",
+		})
+	}
+
+	return drifted, nil
+}
+
+// objectDrift describes how the live object differs from the rendered one, or
+// returns an empty string if it doesn't. Objects carrying a config hash are
+// compared by hash; the others by the fields set in the rendered object.
+func objectDrift(rendered, live map[string]interface{}) (string, error) {
+	metadata, _ := live["metadata"].(map[string]interface{})
+	annotations, _ := metadata["annotations"].(map[string]interface{})
+	if liveHash, ok := annotations[k8s.ConfigHashAnnotation].(string); ok {
+		hash, err := objectConfigHash(rendered)
+		if err != nil {
+			return "", err
+		}
+		if liveHash != hash {
+			return fmt.Sprintf("config hash %s, expected %s", shortHash(liveHash), shortHash(hash)), nil
+		}
+		return "", nil
+	}
+
+	keys := make([]string, 0, len(rendered))
+	for key := range rendered {
+		// objects are read at the preferred version of their group, which
+		// may not be the rendered one
+		if key != "apiVersion" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if path := fieldDrift(rendered[key], live[key], key); path != "" {
+			return fmt.Sprintf("%s differs", path), nil
+		}
+	}
+	return "", nil
+}
+
+// fieldDrift returns the path of the first field set in rendered whose value
+// differs in live, or an empty string if live matches all of them. Fields
+// that are only set in live, such as defaults, are ignored.
+func fieldDrift(rendered, live interface{}, path string) string {
+	switch r := rendered.(type) {
+	case nil:
+		return ""
+	case map[string]interface{}:
+		if len(r) == 0 {
+			return ""
+		}
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return path
+		}
+		keys := make([]string, 0, len(r))
+		for key := range r {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if p := fieldDrift(r[key], l[key], path+"."+key); p != "" {
+				return p
+			}
+		}
+		return ""
+	case []interface{}:
+		if len(r) == 0 {
+			return ""
+		}
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(r) {
+			return path
+		}
+		for i := range r {
+			if p := fieldDrift(r[i], l[i], fmt.Sprintf("%s[%d]", path, i)); p != "" {
+				return p
+			}
+		}
+		return ""
+	default:
+		// compare the printed values, so that ports rendered as strings match
+		// their numeric values
+		if fmt.Sprint(r) != fmt.Sprint(live) {
+			return path
+		}
+		return ""
+	}
+}
+
+// workloadDrift describes how the proxy injected in the pod template differs
+// from the configured one, or returns an empty string if it doesn't.
+func workloadDrift(template v1.PodTemplateSpec, options *proxyConfigOptions) string {
+	var proxy, init *v1.Container
+	for i, container := range template.Spec.Containers {
+		if container.Name == k8s.ProxyContainerName {
+			proxy = &template.Spec.Containers[i]
+		}
+	}
+	for i, container := range template.Spec.InitContainers {
+		if container.Name == k8s.InitContainerName {
+			init = &template.Spec.InitContainers[i]
+		}
+	}
+
+	if proxy == nil {
+		return "proxy container not found"
+	}
+	if proxy.Image != options.taggedProxyImage() {
+		return fmt.Sprintf("proxy image %s, expected %s", proxy.Image, options.taggedProxyImage())
+	}
+	if !options.noInitContainer {
+		if init == nil {
+			return "proxy init container not found"
+		}
+		if init.Image != options.taggedProxyInitImage() {
+			return fmt.Sprintf("proxy init image %s, expected %s", init.Image, options.taggedProxyInitImage())
+		}
+	}
+	return ""
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 12 {
+		return hash[:12]
+	}
+	return hash
+}
+
+func renderDriftReport(report *driftReport, w io.Writer, outputFormat string) error {
+	if outputFormat == jsonOutput {
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s\n", out)
+		return nil
+	}
+
+	if report.InSync {
+		fmt.Fprintf(w, "All %d objects match the configuration\n", report.Checked)
+		return nil
+	}
+
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, padding, ' ', 0)
+	fmt.Fprintln(tw, strings.Join([]string{"KIND", "NAMESPACE", "NAME", "STATUS", "DETAIL"}, "\t"))
+	for _, object := range report.Drifted {
+		namespace := object.Namespace
+		if namespace == "" {
+			namespace = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", object.Kind, namespace, object.Name, object.Status, object.Detail)
+	}
+	tw.Flush()
+
+	fmt.Fprint(w, buf.String())
+	fmt.Fprintf(w, "\n%d of %d objects drifted\n", len(report.Drifted), report.Checked)
+	return nil
+}
@@ -0,0 +1,182 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const diffManifests = `### Service Account Controller ###
+---
+kind: ServiceAccount
+apiVersion: v1
+metadata:
+  name: linkerd-controller
+  namespace: linkerd
+---
+kind: Service
+apiVersion: v1
+metadata:
+  name: linkerd-controller-api
+  namespace: linkerd
+  labels:
+    linkerd.io/control-plane-component: controller
+spec:
+  type: ClusterIP
+  ports:
+  - name: http
+    port: 8085
+    targetPort: 8085
+---
+kind: Deployment
+apiVersion: extensions/v1beta1
+metadata:
+  name: controller
+  namespace: linkerd
+spec:
+  replicas: 1
+`
+
+func TestControlPlaneDrift(t *testing.T) {
+	rendered, err := parseRenderedObjects(strings.NewReader(diffManifests))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	liveObject := func(manifest string) map[string]interface{} {
+		var object map[string]interface{}
+		if err := yaml.Unmarshal([]byte(manifest), &object); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		return object
+	}
+
+	// the live service has defaulted fields, and the live deployment was
+	// scaled up
+	live := map[string]map[string]interface{}{
+		objectKey("Service", "linkerd", "linkerd-controller-api"): liveObject(`
+kind: Service
+apiVersion: v1
+metadata:
+  name: linkerd-controller-api
+  namespace: linkerd
+  uid: 1234
+  labels:
+    linkerd.io/control-plane-component: controller
+spec:
+  type: ClusterIP
+  clusterIP: 10.0.0.1
+  ports:
+  - name: http
+    port: 8085
+    protocol: TCP
+    targetPort: 8085
+`),
+		objectKey("Deployment", "linkerd", "controller"): liveObject(`
+kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: controller
+  namespace: linkerd
+spec:
+  replicas: 3
+`),
+	}
+
+	component := map[string]string{k8s.ControllerComponentLabel: "ca"}
+	inCluster := map[string][]metaV1.ObjectMeta{
+		"Deployment": {{Name: "controller"}, {Name: "ca", Labels: component}},
+	}
+
+	drifted, err := controlPlaneDrift(rendered, live, inCluster, "linkerd")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	report := &driftReport{Checked: len(rendered), Drifted: drifted}
+	var buf bytes.Buffer
+	if err := renderDriftReport(report, &buf, tableOutput); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	diffCompareFile(t, buf.String(), "diff_control_plane.golden")
+}
+
+func TestObjectDriftConfigHash(t *testing.T) {
+	var buf bytes.Buffer
+	if err := annotateConfigHash(strings.NewReader(diffManifests), &buf); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	annotated, err := parseRenderedObjects(&buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	rendered, err := parseRenderedObjects(strings.NewReader(diffManifests))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	for i := range rendered {
+		detail, err := objectDrift(rendered[i].object, annotated[i].object)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if detail != "" {
+			t.Fatalf("Expected %s to match its annotated object, got: %s", rendered[i].key(), detail)
+		}
+	}
+
+	// the hash is compared even if the rendered fields match
+	rendered[2].object["spec"] = map[string]interface{}{"replicas": 2}
+	detail, err := objectDrift(rendered[2].object, annotated[2].object)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if !strings.HasPrefix(detail, "config hash ") {
+		t.Fatalf("Expected a config hash mismatch, got: %s", detail)
+	}
+}
+
+func TestWorkloadDrift(t *testing.T) {
+	options := newProxyConfigOptions()
+	options.linkerdVersion = "stable-2.1.0"
+
+	template := v1.PodTemplateSpec{
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{Name: "app", Image: "app"},
+				{Name: k8s.ProxyContainerName, Image: options.taggedProxyImage()},
+			},
+			InitContainers: []v1.Container{
+				{Name: k8s.InitContainerName, Image: options.taggedProxyInitImage()},
+			},
+		},
+	}
+	if detail := workloadDrift(template, options); detail != "" {
+		t.Fatalf("Expected no drift, got: %s", detail)
+	}
+
+	template.Spec.Containers[1].Image = "gcr.io/linkerd-io/proxy:stable-2.0.0"
+	expected := "proxy image gcr.io/linkerd-io/proxy:stable-2.0.0, expected gcr.io/linkerd-io/proxy:stable-2.1.0"
+	if detail := workloadDrift(template, options); detail != expected {
+		t.Fatalf("Expected [%s], got [%s]", expected, detail)
+	}
+}
+
+func TestUUIDArg(t *testing.T) {
+	containers := []v1.Container{
+		{Name: "web", Args: []string{"-api-addr=linkerd-controller-api.linkerd.svc.cluster.local:8085", "-uuid=7a3c1e04-6a5e-4b4d-9b1f-8e1f4b2f3c5d"}},
+		{Name: k8s.ProxyContainerName},
+	}
+	if uuid := uuidArg(containers); uuid != "7a3c1e04-6a5e-4b4d-9b1f-8e1f4b2f3c5d" {
+		t.Fatalf("Expected the UUID of the web container, got [%s]", uuid)
+	}
+
+	if uuid := uuidArg(containers[1:]); uuid != "" {
+		t.Fatalf("Expected no UUID, got [%s]", uuid)
+	}
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/linkerd/linkerd2/controller/api/public"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// Exit codes returned by the CLI, so that automation can branch on the cause
+// of a failure.
+const (
+	exitCodeError             = 1
+	exitCodeCheckFailed       = 2
+	exitCodeInvalidFlags      = 3
+	exitCodeUnreachable       = 4
+	exitCodeForbidden         = 5
+	exitCodeNoData            = 6
+	exitCodeThresholdViolated = 7
+)
+
+// errorReasons are the machine-readable names of each exit code, included in
+// JSON error output.
+var errorReasons = map[int]string{
+	exitCodeError:             "Error",
+	exitCodeCheckFailed:       "CheckFailed",
+	exitCodeInvalidFlags:      "InvalidFlags",
+	exitCodeUnreachable:       "ControlPlaneUnreachable",
+	exitCodeForbidden:         "Forbidden",
+	exitCodeNoData:            "NoData",
+	exitCodeThresholdViolated: "ThresholdViolated",
+}
+
+// jsonErrors is set when the command being run was asked for JSON output, in
+// which case errors are also reported as JSON.
+var jsonErrors bool
+
+// cliError is an error annotated with the exit code for its class of failure.
+type cliError struct {
+	code int
+	err  error
+}
+
+func (e *cliError) Error() string {
+	return e.err.Error()
+}
+
+func newCliError(code int, err error) *cliError {
+	return &cliError{code: code, err: err}
+}
+
+type jsonError struct {
+	Reason   string `json:"reason"`
+	Message  string `json:"message"`
+	ExitCode int    `json:"exitCode"`
+}
+
+// classifyError returns err annotated with an exit code. Errors that were not
+// explicitly classified are inspected for well-known gRPC, HTTP and Kubernetes
+// failures.
+func classifyError(err error) *cliError {
+	if e, ok := err.(*cliError); ok {
+		return e
+	}
+
+	if s, ok := status.FromError(err); ok {
+		switch s.Code() {
+		case codes.PermissionDenied, codes.Unauthenticated:
+			return newCliError(exitCodeForbidden, err)
+		case codes.Unavailable:
+			return newCliError(exitCodeUnreachable, err)
+		}
+	}
+
+	if k8sErrors.IsForbidden(err) || k8sErrors.IsUnauthorized(err) {
+		return newCliError(exitCodeForbidden, err)
+	}
+
+	switch e := err.(type) {
+	case public.UnexpectedResponseError:
+		switch e.StatusCode {
+		case http.StatusForbidden, http.StatusUnauthorized:
+			return newCliError(exitCodeForbidden, err)
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return newCliError(exitCodeUnreachable, err)
+		}
+	case *url.Error, net.Error:
+		return newCliError(exitCodeUnreachable, err)
+	}
+
+	return newCliError(exitCodeError, err)
+}
+
+// wrapAPIError prefixes the message of err, returned by the named public API
+// call, while keeping the exit code for its class of failure.
+func wrapAPIError(api string, err error) error {
+	return newCliError(classifyError(err).code, fmt.Errorf("%s API error: %v", api, err))
+}
+
+// printError writes err to w, as JSON if JSON output was requested.
+func printError(w io.Writer, err *cliError) {
+	if !jsonErrors {
+		fmt.Fprintf(w, "Error: %s\n", err)
+		return
+	}
+
+	out, marshalErr := json.MarshalIndent(map[string]jsonError{
+		"error": {
+			Reason:   errorReasons[err.code],
+			Message:  err.Error(),
+			ExitCode: err.code,
+		},
+	}, "", "  ")
+	if marshalErr != nil {
+		fmt.Fprintf(w, "Error: %s\n", err)
+		return
+	}
+	fmt.Fprintf(w, "%s\n", out)
+}
+
+// exitWithError reports err and exits with the exit code for its class.
+func exitWithError(err error) {
+	cliErr := classifyError(err)
+	printError(os.Stderr, cliErr)
+	os.Exit(cliErr.code)
+}
+
+// Execute runs the root command, and returns the exit code for the error it
+// failed with, if any.
+func Execute() int {
+	RootCmd.SilenceErrors = true
+	RootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return newCliError(exitCodeInvalidFlags, err)
+	})
+
+	if err := RootCmd.Execute(); err != nil {
+		// commands interrupted with Ctrl-C fail with context.Canceled
+		if cliContext.Err() != nil {
+			return exitCodeInterrupted
+		}
+
+		cliErr := classifyError(err)
+		printError(os.Stderr, cliErr)
+		return cliErr.code
+	}
+
+	return 0
+}
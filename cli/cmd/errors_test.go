@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/linkerd/linkerd2/controller/api/public"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestClassifyError(t *testing.T) {
+	expectations := []struct {
+		err  error
+		code int
+	}{
+		{errors.New("generic error"), exitCodeError},
+		{newCliError(exitCodeInvalidFlags, errors.New("bad flag")), exitCodeInvalidFlags},
+		{status.Error(codes.PermissionDenied, "denied"), exitCodeForbidden},
+		{status.Error(codes.Unavailable, "unavailable"), exitCodeUnreachable},
+		{wrapAPIError("StatSummary", status.Error(codes.Unavailable, "unavailable")), exitCodeUnreachable},
+		{k8sErrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "web", errors.New("denied")), exitCodeForbidden},
+		{public.UnexpectedResponseError{StatusCode: http.StatusForbidden, Status: "403 Forbidden"}, exitCodeForbidden},
+		{public.UnexpectedResponseError{StatusCode: http.StatusServiceUnavailable, Status: "503 Service Unavailable"}, exitCodeUnreachable},
+	}
+
+	for i, exp := range expectations {
+		if code := classifyError(exp.err).code; code != exp.code {
+			t.Fatalf("Test %d: expected exit code %d for error [%s], got %d", i, exp.code, exp.err, code)
+		}
+	}
+}
+
+func TestWrapAPIError(t *testing.T) {
+	client := &public.MockApiClient{ErrorToReturn: status.Error(codes.PermissionDenied, "denied")}
+
+	_, routesErr := requestRouteRows(client, &pb.TopRoutesRequest{})
+	_, slosErr := requestRouteSLOsFromAPI(client, &pb.RouteSLOsRequest{}, newSLOOptions())
+	_, streamErr := requestStreamStatsFromAPI(client, []*pb.StatSummaryRequest{{}})
+
+	for _, err := range []error{routesErr, slosErr, streamErr} {
+		if code := classifyError(err).code; code != exitCodeForbidden {
+			t.Fatalf("Expected exit code %d for error [%s], got %d", exitCodeForbidden, err, code)
+		}
+	}
+}
+
+func TestPrintError(t *testing.T) {
+	defer func() { jsonErrors = false }()
+	err := newCliError(exitCodeForbidden, errors.New("tap is forbidden"))
+
+	t.Run("Prints plain errors", func(t *testing.T) {
+		jsonErrors = false
+		var buf bytes.Buffer
+		printError(&buf, err)
+
+		expected := "Error: tap is forbidden\n"
+		if buf.String() != expected {
+			t.Fatalf("Expected %q, got %q", expected, buf.String())
+		}
+	})
+
+	t.Run("Prints JSON errors", func(t *testing.T) {
+		jsonErrors = true
+		var buf bytes.Buffer
+		printError(&buf, err)
+
+		expected := `{
+  "error": {
+    "reason": "Forbidden",
+    "message": "tap is forbidden",
+    "exitCode": 5
+  }
+}
+`
+		if buf.String() != expected {
+			t.Fatalf("Expected %q, got %q", expected, buf.String())
+		}
+	})
+}
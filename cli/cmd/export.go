@@ -0,0 +1,294 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/spf13/cobra"
+)
+
+const exportCSVFormat = "csv"
+
+type exportOptions struct {
+	namespace  string
+	since      string
+	until      string
+	step       time.Duration
+	chunk      time.Duration
+	routes     bool
+	format     string
+	outputDir  string
+	toResource string
+}
+
+func newExportOptions() *exportOptions {
+	return &exportOptions{
+		namespace:  "default",
+		since:      "24h",
+		until:      "",
+		step:       time.Hour,
+		chunk:      24 * time.Hour,
+		routes:     false,
+		format:     exportCSVFormat,
+		outputDir:  ".",
+		toResource: "",
+	}
+}
+
+func (o *exportOptions) validate() error {
+	if o.format != exportCSVFormat {
+		return fmt.Errorf("--format currently only supports csv")
+	}
+	if o.step < time.Minute {
+		return fmt.Errorf("--step must be at least 1m")
+	}
+	if o.chunk < o.step || o.chunk%o.step != 0 {
+		return fmt.Errorf("--chunk must be a multiple of --step")
+	}
+	if o.toResource != "" && !o.routes {
+		return fmt.Errorf("--to is only supported with --routes")
+	}
+	return nil
+}
+
+// timeRange resolves --since and --until against now.
+func (o *exportOptions) timeRange(now time.Time) (time.Time, time.Time, error) {
+	since, err := parseExportTime(o.since, now)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid --since: %s", err)
+	}
+	until := now
+	if o.until != "" {
+		until, err = parseExportTime(o.until, now)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --until: %s", err)
+		}
+	}
+	if !since.Before(until) {
+		return time.Time{}, time.Time{}, fmt.Errorf("--since must be before --until")
+	}
+	return since, until, nil
+}
+
+func newCmdExport() *cobra.Command {
+	options := newExportOptions()
+
+	cmd := &cobra.Command{
+		Use:   "export [flags] (RESOURCE)",
+		Short: "Export stat or route metrics over a time range to files",
+		Long: `Export stat or route metrics over a time range to files.
+
+The export command writes a data point every --step between --since and
+--until, each with the stats of the --step window ending at its timestamp. The
+data points are written to a file per --chunk in --output-dir. Existing chunk
+files are skipped, so an interrupted export resumes where it stopped when run
+again with the same flags.
+
+--since and --until are either RFC3339 timestamps or durations before now.`,
+		Example: `  # Export the hourly stats of the deployments in the emojivoto namespace over the last week
+  linkerd export deploy -n emojivoto --since 168h --output-dir ./emojivoto
+
+  # Export the routes of the webapp service every 10 minutes of a day
+  linkerd export svc/webapp -n booksapp --routes --since 2019-01-07T00:00:00Z --until 2019-01-08T00:00:00Z --step 10m`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: util.ValidTargets,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(); err != nil {
+				return newCliError(exitCodeInvalidFlags, err)
+			}
+			since, until, err := options.timeRange(time.Now())
+			if err != nil {
+				return newCliError(exitCodeInvalidFlags, err)
+			}
+
+			return runExport(validatedPublicAPIClient(time.Time{}), args[0], since, until, options, os.Stderr)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the specified resource")
+	cmd.PersistentFlags().StringVar(&options.since, "since", options.since, "Start of the exported time range")
+	cmd.PersistentFlags().StringVar(&options.until, "until", options.until, "End of the exported time range (default now)")
+	cmd.PersistentFlags().DurationVar(&options.step, "step", options.step, "Interval between two data points, and time window of each")
+	cmd.PersistentFlags().DurationVar(&options.chunk, "chunk", options.chunk, "Time range of each file; a multiple of --step")
+	cmd.PersistentFlags().BoolVar(&options.routes, "routes", options.routes, "Export route stats instead of resource stats")
+	cmd.PersistentFlags().StringVar(&options.toResource, "to", options.toResource, "With --routes, export the outbound route stats to the specified resource")
+	cmd.PersistentFlags().StringVar(&options.format, "format", options.format, "Format of the files; one of: csv")
+	cmd.PersistentFlags().StringVar(&options.outputDir, "output-dir", options.outputDir, "Directory to write the files to")
+
+	return cmd
+}
+
+// parseExportTime parses an RFC3339 timestamp, or a duration before now.
+func parseExportTime(s string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// runExport writes the chunks of the time range that don't exist yet.
+func runExport(client pb.ApiClient, resource string, since, until time.Time, options *exportOptions, w io.Writer) error {
+	if err := os.MkdirAll(options.outputDir, 0755); err != nil {
+		return err
+	}
+
+	prefix := exportFilePrefix(resource, options)
+	for start := since; start.Before(until); start = start.Add(options.chunk) {
+		end := start.Add(options.chunk)
+		if end.After(until) {
+			end = until
+		}
+
+		path := filepath.Join(options.outputDir, fmt.Sprintf("%s-%d-%d.%s", prefix, start.Unix(), end.Unix(), options.format))
+		if _, err := os.Stat(path); err == nil {
+			fmt.Fprintf(w, "Skipping %s, already exported\n", path)
+			continue
+		}
+
+		if err := exportChunk(client, resource, start, end, path, options); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "Exported %s\n", path)
+	}
+
+	return nil
+}
+
+func exportFilePrefix(resource string, options *exportOptions) string {
+	kind := "stat"
+	if options.routes {
+		kind = "routes"
+	}
+	return strings.Join([]string{kind, options.namespace, strings.Replace(resource, "/", "-", -1)}, "-")
+}
+
+// exportChunk writes the data points ending in (start, end] to path. The file
+// is written under a temporary name and renamed once complete, so that a
+// partial chunk is exported again on resume.
+func exportChunk(client pb.ApiClient, resource string, start, end time.Time, path string, options *exportOptions) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+
+	writer := csv.NewWriter(f)
+	if options.routes {
+		err = writer.Write([]string{"timestamp", "route", "authority", "success_count", "failure_count", "success_rate", "rps", "latency_ms_p50", "latency_ms_p95", "latency_ms_p99", "tls_request_count"})
+	} else {
+		err = writer.Write([]string{"timestamp", "namespace", "type", "name", "success_count", "failure_count", "success_rate", "rps", "latency_ms_p50", "latency_ms_p95", "latency_ms_p99", "tls_request_count", "meshed_pod_count", "running_pod_count"})
+	}
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	for ts := start.Add(options.step); !ts.After(end); ts = ts.Add(options.step) {
+		records, err := exportRecords(client, resource, ts, options)
+		if err != nil {
+			f.Close()
+			return err
+		}
+		if err := writer.WriteAll(records); err != nil {
+			f.Close()
+			return err
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// exportRecords returns the CSV records of the data point ending at ts.
+func exportRecords(client pb.ApiClient, resource string, ts time.Time, options *exportOptions) ([][]string, error) {
+	res, err := util.BuildResource(options.namespace, resource)
+	if err != nil {
+		return nil, err
+	}
+	params := util.StatsBaseRequestParams{
+		TimeWindow:   options.step.String(),
+		Namespace:    options.namespace,
+		ResourceType: res.Type,
+		ResourceName: res.Name,
+		EndTime:      ts,
+	}
+	timestamp := ts.UTC().Format(time.RFC3339)
+
+	records := make([][]string, 0)
+
+	if options.routes {
+		req, err := util.BuildTopRoutesRequest(util.TopRoutesRequestParams{
+			StatsBaseRequestParams: params,
+			To:                     options.toResource,
+		})
+		if err != nil {
+			return nil, err
+		}
+		rows, err := requestRouteRows(client, req)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			route := row.Route
+			if route == "" {
+				route = defaultRoute
+			}
+			record := []string{timestamp, route, row.Authority}
+			records = append(records, append(record, exportStats(row.Stats, row.TimeWindow)...))
+		}
+		return records, nil
+	}
+
+	req, err := util.BuildStatSummaryRequest(util.StatsSummaryRequestParams{StatsBaseRequestParams: params})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.StatSummary(cliContext, req)
+	if err != nil {
+		return nil, wrapAPIError("StatSummary", err)
+	}
+	if e := resp.GetError(); e != nil {
+		return nil, fmt.Errorf("StatSummary API response error: %v", e.Error)
+	}
+	for _, table := range resp.GetOk().GetStatTables() {
+		for _, row := range table.GetPodGroup().GetRows() {
+			record := []string{timestamp, row.Resource.Namespace, row.Resource.Type, row.Resource.Name}
+			record = append(record, exportStats(row.Stats, row.TimeWindow)...)
+			record = append(record, strconv.FormatUint(row.MeshedPodCount, 10), strconv.FormatUint(row.RunningPodCount, 10))
+			records = append(records, record)
+		}
+	}
+	return records, nil
+}
+
+func exportStats(stats *pb.BasicStats, timeWindow string) []string {
+	if stats == nil {
+		return []string{"", "", "", "", "", "", "", ""}
+	}
+	return []string{
+		strconv.FormatUint(stats.SuccessCount, 10),
+		strconv.FormatUint(stats.FailureCount, 10),
+		strconv.FormatFloat(util.GetSuccessRate(stats), 'f', 4, 64),
+		strconv.FormatFloat(util.GetRequestRate(stats, timeWindow), 'f', 4, 64),
+		strconv.FormatUint(stats.LatencyMsP50, 10),
+		strconv.FormatUint(stats.LatencyMsP95, 10),
+		strconv.FormatUint(stats.LatencyMsP99, 10),
+		strconv.FormatUint(stats.TlsRequestCount, 10),
+	}
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/public"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+)
+
+func TestExportTimeRange(t *testing.T) {
+	now := time.Date(2019, 1, 8, 12, 0, 0, 0, time.UTC)
+
+	options := newExportOptions()
+	options.since = "36h"
+	options.until = "2019-01-08T00:00:00Z"
+	since, until, err := options.timeRange(now)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !since.Equal(time.Date(2019, 1, 7, 0, 0, 0, 0, time.UTC)) || !until.Equal(time.Date(2019, 1, 8, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Unexpected time range [%s, %s]", since, until)
+	}
+
+	options.since = "1h"
+	if _, _, err := options.timeRange(now); err == nil {
+		t.Fatalf("Expected an error for a range ending before it starts")
+	}
+}
+
+func TestRunExport(t *testing.T) {
+	dir, err := ioutil.TempDir("", "linkerd-export")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	response := public.GenStatSummaryResponse("emoji", "deployment", []string{"emojivoto"}, &public.PodCounts{
+		MeshedPods:  1,
+		RunningPods: 2,
+		FailedPods:  0,
+	})
+	client := &public.MockApiClient{StatSummaryResponseToReturn: &response}
+
+	options := newExportOptions()
+	options.namespace = "emojivoto"
+	options.step = 30 * time.Minute
+	options.chunk = time.Hour
+	options.outputDir = dir
+
+	since := time.Date(2019, 1, 7, 0, 0, 0, 0, time.UTC)
+	until := since.Add(90 * time.Minute)
+
+	var log bytes.Buffer
+	if err := runExport(client, "deploy", since, until, options, &log); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	first := filepath.Join(dir, "stat-emojivoto-deploy-1546819200-1546822800.csv")
+	second := filepath.Join(dir, "stat-emojivoto-deploy-1546822800-1546824600.csv")
+	b, err := ioutil.ReadFile(first)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	diffCompareFile(t, string(b), "export_stat.golden")
+	if _, err := os.Stat(second); err != nil {
+		t.Fatalf("Expected the last, partial chunk to be exported: %v", err)
+	}
+
+	// resuming skips the exported chunks
+	os.Remove(second)
+	client.StatSummaryResponseToReturn = &pb.StatSummaryResponse{}
+	log.Reset()
+	if err := runExport(client, "deploy", since, until, options, &log); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "Skipping " + first + ", already exported\nExported " + second + "\n"
+	if log.String() != expected {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expected, log.String())
+	}
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"errors"
 	"fmt"
 	"os"
@@ -76,6 +75,7 @@ Only pod resources (aka pods, po) are supported.`,
 
 	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of pods")
 	cmd.PersistentFlags().BoolVar(&options.allNamespaces, "all-namespaces", options.allNamespaces, "If present, returns pods across all namespaces, ignoring the \"--namespace\" flag")
+	markFlagConfigurable(cmd.PersistentFlags(), "namespace", "namespace")
 	return cmd
 }
 
@@ -85,7 +85,7 @@ func getPods(apiClient pb.ApiClient, options *getOptions) ([]string, error) {
 		req.Namespace = options.namespace
 	}
 
-	resp, err := apiClient.ListPods(context.Background(), req)
+	resp, err := apiClient.ListPods(cliContext, req)
 	if err != nil {
 		return nil, err
 	}
@@ -3,9 +3,11 @@ package cmd
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -16,10 +18,10 @@ import (
 	"github.com/spf13/cobra"
 	appsV1 "k8s.io/api/apps/v1"
 	batchV1 "k8s.io/api/batch/v1"
+	batchV1beta1 "k8s.io/api/batch/v1beta1"
 	"k8s.io/api/core/v1"
 	"k8s.io/api/extensions/v1beta1"
 	k8sMeta "k8s.io/apimachinery/pkg/api/meta"
-	k8sResource "k8s.io/apimachinery/pkg/api/resource"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -43,11 +45,17 @@ const (
 )
 
 type injectOptions struct {
+	fromHelm            string
+	fromKustomize       string
+	defaultInjectPolicy string
+	report              bool
 	*proxyConfigOptions
 }
 
 type injectReport struct {
 	name                string
+	disabled            bool   // true if the inject policy leaves the pods without a proxy
+	policy              string // why the inject policy enabled or disabled the injection
 	hostNetwork         bool
 	sidecar             bool
 	udp                 bool // true if any port in any container has `protocol: UDP`
@@ -61,10 +69,21 @@ type objMeta struct {
 
 func newInjectOptions() *injectOptions {
 	return &injectOptions{
-		proxyConfigOptions: newProxyConfigOptions(),
+		fromHelm:            "",
+		fromKustomize:       "",
+		defaultInjectPolicy: k8s.ProxyInjectEnabled,
+		report:              false,
+		proxyConfigOptions:  newProxyConfigOptions(),
 	}
 }
 
+func (options *injectOptions) validate() error {
+	if err := options.proxyConfigOptions.validate(); err != nil {
+		return err
+	}
+	return k8s.ValidateInjectPolicy(options.defaultInjectPolicy)
+}
+
 func newCmdInject() *cobra.Command {
 	options := newInjectOptions()
 
@@ -75,20 +94,38 @@ func newCmdInject() *cobra.Command {
 
 You can use a config file from stdin by using the '-' argument
 with 'linkerd inject'. e.g. curl http://url.to/yml | linkerd inject -
+The '-' argument may be left out when the config is piped.
 Also works with a folder containing resource files and other
 sub-folder. e.g. linkerd inject <folder> | kubectl apply -f -
-	`,
-		RunE: func(cmd *cobra.Command, args []string) error {
 
-			if len(args) < 1 {
-				return fmt.Errorf("please specify a kubernetes resource file")
-			}
+The --from-helm and --from-kustomize flags inject the output of
+'helm template' and 'kustomize build', which must be in the PATH.
+e.g. linkerd inject --from-helm ./charts/web | kubectl apply -f -
+
+The config may have any number of YAML documents, including Lists,
+and a summary table reports whether each resource was injected.
 
+The linkerd.io/inject annotation, "enabled" or "disabled", opts the
+workloads in or out of the injection. It's read from the pod template
+of each workload, then from its Namespace, when the config has it, and
+falls back to --default-inject-policy. The proxy injector honors it as
+well. --report prints the report alone, explaining why each resource
+was or wasn't injected.
+	`,
+		RunE: func(cmd *cobra.Command, args []string) error {
 			if err := options.validate(); err != nil {
 				return err
 			}
 
-			in, err := read(args[0])
+			if options.verifyImagesKey != "" {
+				verified, err := verifyImages(options.verifyImagesKey, options.proxyImages())
+				if err != nil {
+					return err
+				}
+				options.verifiedImages = verified
+			}
+
+			in, err := injectInputs(args, options, os.Stdin)
 			if err != nil {
 				return err
 			}
@@ -100,9 +137,57 @@ sub-folder. e.g. linkerd inject <folder> | kubectl apply -f -
 	}
 
 	addProxyConfigFlags(cmd, options.proxyConfigOptions)
+	addVerifyImagesFlag(cmd, options.proxyConfigOptions)
+	cmd.Flags().StringVar(&options.fromHelm, "from-helm", options.fromHelm, "Inject the output of 'helm template' for the given chart instead of a config file")
+	cmd.Flags().StringVar(&options.fromKustomize, "from-kustomize", options.fromKustomize, "Inject the output of 'kustomize build' for the given directory instead of a config file")
+	cmd.Flags().StringVar(&options.defaultInjectPolicy, "default-inject-policy", options.defaultInjectPolicy, "Inject policy of the workloads without a "+k8s.ProxyInjectAnnotation+" annotation on their pods or namespace: enabled or disabled")
+	cmd.Flags().BoolVar(&options.report, "report", options.report, "Print the report on stdout instead of the injected config, explaining why each resource was or wasn't injected")
 	return cmd
 }
 
+// injectInputs returns the readers of the resources to inject: the files
+// found in the CONFIG-FILE argument, the output of helm or kustomize, or stdin
+// when neither is given and it is piped.
+func injectInputs(args []string, options *injectOptions, stdin *os.File) ([]io.Reader, error) {
+	sources := len(args)
+	if options.fromHelm != "" {
+		sources++
+	}
+	if options.fromKustomize != "" {
+		sources++
+	}
+	if sources > 1 {
+		return nil, errors.New("please specify only one of a kubernetes resource file, --from-helm or --from-kustomize")
+	}
+
+	switch {
+	case len(args) == 1:
+		return read(args[0])
+	case options.fromHelm != "":
+		return renderConfigs("helm", "template", options.fromHelm)
+	case options.fromKustomize != "":
+		return renderConfigs("kustomize", "build", options.fromKustomize)
+	}
+
+	if stat, err := stdin.Stat(); err == nil && stat.Mode()&os.ModeCharDevice == 0 {
+		return []io.Reader{stdin}, nil
+	}
+	return nil, errors.New("please specify a kubernetes resource file")
+}
+
+// renderConfigs runs a command rendering Kubernetes configs, e.g. helm
+// template, and returns a reader of its output.
+func renderConfigs(command string, args ...string) ([]io.Reader, error) {
+	out, err := exec.Command(command, args...).Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			err = errors.New(strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("failed to run %s %s: %s", command, strings.Join(args, " "), err)
+	}
+	return []io.Reader{bytes.NewReader(out)}, nil
+}
+
 // Read all the resource files found in path into a slice of readers.
 // path can be either a file, directory or stdin.
 func read(path string) ([]io.Reader, error) {
@@ -133,6 +218,12 @@ func runInjectCmd(inputs []io.Reader, errWriter, outWriter io.Writer, options *i
 			fmt.Fprintf(errWriter, "Error injecting linkerd proxy: %v\n", err)
 			return 1
 		}
+		if options.report {
+			// only the report is printed, without the injected config
+			postInjectBuf.Reset()
+			io.Copy(outWriter, reportBuf)
+			continue
+		}
 		_, err = io.Copy(outWriter, postInjectBuf)
 
 		// print error report after yaml output, for better visibility
@@ -167,9 +258,10 @@ func injectObjectMeta(t *metaV1.ObjectMeta, k8sLabels map[string]string, options
 
 /* Given a PodSpec, update the PodSpec in place with the sidecar
  * and init-container injected. If the pod is unsuitable for having them
- * injected, return false.
+ * injected, return false. The pod annotations can override some of the proxy
+ * configuration.
  */
-func injectPodSpec(t *v1.PodSpec, identity k8s.TLSIdentity, controlPlaneDNSNameOverride string, options *injectOptions, report *injectReport) bool {
+func injectPodSpec(t *v1.PodSpec, annotations map[string]string, identity k8s.TLSIdentity, controlPlaneDNSNameOverride string, options *injectOptions, report *injectReport) (bool, error) {
 	report.hostNetwork = t.HostNetwork
 	report.sidecar = healthcheck.HasExistingSidecars(t)
 	report.udp = checkUDPPorts(t)
@@ -180,7 +272,17 @@ func injectPodSpec(t *v1.PodSpec, identity k8s.TLSIdentity, controlPlaneDNSNameO
 	// OR
 	// 2) Known sidecars already present.
 	if report.hostNetwork || report.sidecar {
-		return false
+		return false, nil
+	}
+
+	opaquePorts, err := k8s.OpaquePorts(options.opaquePorts, nil, annotations)
+	if err != nil {
+		return false, err
+	}
+
+	outboundSkipCIDRs, err := k8s.SkipOutboundCIDRs(annotations, options.ignoreOutboundCIDRs)
+	if err != nil {
+		return false, err
 	}
 
 	f := false
@@ -211,6 +313,11 @@ func injectPodSpec(t *v1.PodSpec, identity k8s.TLSIdentity, controlPlaneDNSNameO
 		initArgs = append(initArgs, strings.Join(outboundSkipPortsStr, ","))
 	}
 
+	if len(outboundSkipCIDRs) > 0 {
+		initArgs = append(initArgs, "--outbound-cidrs-to-ignore")
+		initArgs = append(initArgs, strings.Join(outboundSkipCIDRs, ","))
+	}
+
 	initContainer := v1.Container{
 		Name:                     k8s.InitContainerName,
 		Image:                    options.taggedProxyInitImage(),
@@ -243,16 +350,9 @@ func injectPodSpec(t *v1.PodSpec, identity k8s.TLSIdentity, controlPlaneDNSNameO
 		InitialDelaySeconds: 10,
 	}
 
-	resources := v1.ResourceRequirements{
-		Requests: v1.ResourceList{},
-	}
-
-	if options.proxyCpuRequest != "" {
-		resources.Requests["cpu"] = k8sResource.MustParse(options.proxyCpuRequest)
-	}
-
-	if options.proxyMemoryRequest != "" {
-		resources.Requests["memory"] = k8sResource.MustParse(options.proxyMemoryRequest)
+	resources, err := options.proxyResources(annotations)
+	if err != nil {
+		return false, err
 	}
 
 	profileSuffixes := "."
@@ -298,6 +398,9 @@ func injectPodSpec(t *v1.PodSpec, identity k8s.TLSIdentity, controlPlaneDNSNameO
 		LivenessProbe:  &proxyProbe,
 		ReadinessProbe: &proxyProbe,
 	}
+	if opaquePorts != "" {
+		sidecar.Env = append(sidecar.Env, v1.EnvVar{Name: k8s.ProxyOpaquePortsEnvVar, Value: opaquePorts})
+	}
 
 	// Special case if the caller specifies that
 	// LINKERD2_PROXY_OUTBOUND_ROUTER_CAPACITY be set on the pod.
@@ -364,9 +467,11 @@ func injectPodSpec(t *v1.PodSpec, identity k8s.TLSIdentity, controlPlaneDNSNameO
 	}
 
 	t.Containers = append(t.Containers, sidecar)
-	t.InitContainers = append(t.InitContainers, initContainer)
+	if !options.noInitContainer {
+		t.InitContainers = append(t.InitContainers, initContainer)
+	}
 
-	return true
+	return true, nil
 }
 
 // InjectYAML takes an input stream of YAML, outputting injected YAML to out.
@@ -375,7 +480,10 @@ func InjectYAML(in io.Reader, out io.Writer, report io.Writer, options *injectOp
 
 	injectReports := []injectReport{}
 
-	// Iterate over all YAML objects in the input
+	// Read all the YAML objects in the input first, so that the inject policy
+	// of the namespaces it has applies to their workloads wherever they are
+	docs := [][]byte{}
+	namespaces := map[string]map[string]string{}
 	for {
 		// Read a single YAML object
 		bytes, err := reader.Read()
@@ -386,8 +494,20 @@ func InjectYAML(in io.Reader, out io.Writer, report io.Writer, options *injectOp
 			return err
 		}
 
-		ir := injectReport{}
-		result, err := injectResource(bytes, options, &ir)
+		// skip the documents without any object, e.g. the templates of a helm
+		// chart rendering nothing but their "# Source" comment
+		if isEmptyDocument(bytes) {
+			continue
+		}
+
+		if err := addNamespaceAnnotations(bytes, namespaces); err != nil {
+			return err
+		}
+		docs = append(docs, bytes)
+	}
+
+	for _, bytes := range docs {
+		result, reports, err := injectResource(bytes, namespaces, options)
 		if err != nil {
 			return err
 		}
@@ -395,44 +515,88 @@ func InjectYAML(in io.Reader, out io.Writer, report io.Writer, options *injectOp
 		out.Write(result)
 		out.Write([]byte("---\n"))
 
-		injectReports = append(injectReports, ir)
+		injectReports = append(injectReports, reports...)
 	}
 
-	generateReport(injectReports, report)
+	generateReport(injectReports, report, options.report)
+
+	return nil
+}
+
+func isEmptyDocument(b []byte) bool {
+	var obj map[string]interface{}
+	return yaml.Unmarshal(b, &obj) == nil && len(obj) == 0
+}
+
+// addNamespaceAnnotations records the annotations of the Namespace objects of
+// a YAML document, which may be a List, by namespace name.
+func addNamespaceAnnotations(b []byte, namespaces map[string]map[string]string) error {
+	var meta metaV1.TypeMeta
+	if err := yaml.Unmarshal(b, &meta); err != nil {
+		return err
+	}
 
+	switch meta.Kind {
+	case "Namespace":
+		var om objMeta
+		if err := yaml.Unmarshal(b, &om); err != nil {
+			return err
+		}
+		namespaces[om.Name] = om.Annotations
+	case "List":
+		var list v1.List
+		if err := yaml.Unmarshal(b, &list); err != nil {
+			return err
+		}
+		for _, item := range list.Items {
+			if err := addNamespaceAnnotations(item.Raw, namespaces); err != nil {
+				return err
+			}
+		}
+	}
 	return nil
 }
 
-func injectList(b []byte, options *injectOptions, report *injectReport) ([]byte, error) {
+func injectList(b []byte, namespaces map[string]map[string]string, options *injectOptions) ([]byte, []injectReport, error) {
 	var sourceList v1.List
 	if err := yaml.Unmarshal(b, &sourceList); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	items := []runtime.RawExtension{}
+	reports := []injectReport{}
 
 	for _, item := range sourceList.Items {
-		result, err := injectResource(item.Raw, options, report)
+		result, itemReports, err := injectResource(item.Raw, namespaces, options)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
+		reports = append(reports, itemReports...)
 
 		// At this point, we have yaml. The kubernetes internal representation is
 		// json. Because we're building a list from RawExtensions, the yaml needs
 		// to be converted to json.
 		injected, err := yaml.YAMLToJSON(result)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		items = append(items, runtime.RawExtension{Raw: injected})
 	}
 
 	sourceList.Items = items
-	return yaml.Marshal(sourceList)
+	result, err := yaml.Marshal(sourceList)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, reports, nil
 }
 
-func injectResource(bytes []byte, options *injectOptions, report *injectReport) ([]byte, error) {
+// injectResource returns the injected serialization of a resource, along with
+// the report of each resource it has: one for most resources, and one for
+// each item of a List. namespaces holds the annotations of the known
+// namespaces, by name, whose inject policy applies to their workloads.
+func injectResource(bytes []byte, namespaces map[string]map[string]string, options *injectOptions) ([]byte, []injectReport, error) {
 	// The Kubernetes API is versioned and each version has an API modeled
 	// with its own distinct Go types. If we tell `yaml.Unmarshal()` which
 	// version we support then it will provide a representation of that
@@ -447,15 +611,22 @@ func injectResource(bytes []byte, options *injectOptions, report *injectReport)
 	// Unmarshal the object enough to read the Kind field
 	var meta metaV1.TypeMeta
 	if err := yaml.Unmarshal(bytes, &meta); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	// Lists are a little different than the other types. There's no immediate
+	// pod template. Because of this, we do a recursive call for each element
+	// in the list (instead of just marshaling the injected pod template).
+	if meta.Kind == "List" {
+		return injectList(bytes, namespaces, options)
 	}
 
 	// retrieve the `metadata/name` field for reporting later
 	var om objMeta
 	if err := yaml.Unmarshal(bytes, &om); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	report.name = fmt.Sprintf("%s/%s", strings.ToLower(meta.Kind), om.Name)
+	report := &injectReport{name: fmt.Sprintf("%s/%s", strings.ToLower(meta.Kind), om.Name)}
 
 	// obj and podTemplateSpec will reference zero or one the following
 	// objects, depending on the type.
@@ -481,7 +652,7 @@ func injectResource(bytes []byte, options *injectOptions, report *injectReport)
 	case "Deployment":
 		var deployment v1beta1.Deployment
 		if err := yaml.Unmarshal(bytes, &deployment); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		if deployment.Name == ControlPlanePodName && deployment.Namespace == controlPlaneNamespace {
@@ -496,7 +667,7 @@ func injectResource(bytes []byte, options *injectOptions, report *injectReport)
 	case "ReplicationController":
 		var rc v1.ReplicationController
 		if err := yaml.Unmarshal(bytes, &rc); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		obj = &rc
@@ -507,7 +678,7 @@ func injectResource(bytes []byte, options *injectOptions, report *injectReport)
 	case "ReplicaSet":
 		var rs v1beta1.ReplicaSet
 		if err := yaml.Unmarshal(bytes, &rs); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		obj = &rs
@@ -518,7 +689,7 @@ func injectResource(bytes []byte, options *injectOptions, report *injectReport)
 	case "Job":
 		var job batchV1.Job
 		if err := yaml.Unmarshal(bytes, &job); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		obj = &job
@@ -526,10 +697,21 @@ func injectResource(bytes []byte, options *injectOptions, report *injectReport)
 		podSpec = &job.Spec.Template.Spec
 		objectMeta = &job.Spec.Template.ObjectMeta
 
+	case "CronJob":
+		var cronJob batchV1beta1.CronJob
+		if err := yaml.Unmarshal(bytes, &cronJob); err != nil {
+			return nil, nil, err
+		}
+
+		obj = &cronJob
+		k8sLabels[k8s.ProxyCronJobLabel] = cronJob.Name
+		podSpec = &cronJob.Spec.JobTemplate.Spec.Template.Spec
+		objectMeta = &cronJob.Spec.JobTemplate.Spec.Template.ObjectMeta
+
 	case "DaemonSet":
 		var ds v1beta1.DaemonSet
 		if err := yaml.Unmarshal(bytes, &ds); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		obj = &ds
@@ -540,7 +722,7 @@ func injectResource(bytes []byte, options *injectOptions, report *injectReport)
 	case "StatefulSet":
 		var statefulset appsV1.StatefulSet
 		if err := yaml.Unmarshal(bytes, &statefulset); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		obj = &statefulset
@@ -551,21 +733,12 @@ func injectResource(bytes []byte, options *injectOptions, report *injectReport)
 	case "Pod":
 		var pod v1.Pod
 		if err := yaml.Unmarshal(bytes, &pod); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		obj = &pod
 		podSpec = &pod.Spec
 		objectMeta = &pod.ObjectMeta
-
-	case "List":
-		// Lists are a little different than the other types. There's no immediate
-		// pod template. Because of this, we do a recursive call for each element
-		// in the list (instead of just marshaling the injected pod template).
-
-		// TODO: generate an injectReport per list item
-		return injectList(bytes, options, report)
-
 	}
 
 	// If we don't inject anything into the pod template then output the
@@ -575,7 +748,7 @@ func injectResource(bytes []byte, options *injectOptions, report *injectReport)
 	if podSpec != nil {
 		metaAccessor, err := k8sMeta.Accessor(obj)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		// The namespace isn't necessarily in the input so it has to be substituted
@@ -588,19 +761,32 @@ func injectResource(bytes []byte, options *injectOptions, report *injectReport)
 			ControllerNamespace: controlPlaneNamespace,
 		}
 
-		if injectPodSpec(podSpec, identity, DNSNameOverride, options, report) {
+		enabled, policy, err := k8s.InjectPolicy(objectMeta.Annotations, namespaces[om.Namespace], options.defaultInjectPolicy)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s %s: %s", meta.Kind, metaAccessor.GetName(), err)
+		}
+		report.policy = policy
+		if !enabled {
+			report.disabled = true
+			return output, []injectReport{*report}, nil
+		}
+
+		injected, err := injectPodSpec(podSpec, objectMeta.Annotations, identity, DNSNameOverride, options, report)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s %s: %s", meta.Kind, metaAccessor.GetName(), err)
+		}
+		if injected {
 			injectObjectMeta(objectMeta, k8sLabels, options)
-			var err error
 			output, err = yaml.Marshal(obj)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 		}
 	} else {
 		report.unsupportedResource = true
 	}
 
-	return output, nil
+	return output, []injectReport{*report}, nil
 }
 
 // walk walks the file tree rooted at path. path may be a file or a directory.
@@ -646,7 +832,9 @@ func walk(path string) ([]io.Reader, error) {
 	return in, nil
 }
 
-func generateReport(injectReports []injectReport, output io.Writer) {
+// generateReport writes the checks and the summary table of the injection.
+// With explain, the table tells why the injected resources were injected too.
+func generateReport(injectReports []injectReport, output io.Writer, explain bool) {
 
 	injected := []string{}
 	hostNetwork := []string{}
@@ -654,7 +842,7 @@ func generateReport(injectReports []injectReport, output io.Writer) {
 	udp := []string{}
 
 	for _, r := range injectReports {
-		if !r.hostNetwork && !r.sidecar && !r.unsupportedResource {
+		if r.injected() {
 			injected = append(injected, r.name)
 		}
 
@@ -714,17 +902,66 @@ func generateReport(injectReports []injectReport, output io.Writer) {
 	// Summary
 	//
 
-	summary := fmt.Sprintf("Summary: %d of %d YAML document(s) injected", len(injected), len(injectReports))
+	summary := fmt.Sprintf("Summary: %d of %d resource(s) injected", len(injected), len(injectReports))
 	output.Write([]byte(fmt.Sprintf("\n%s\n", summary)))
 
-	for _, i := range injected {
-		output.Write([]byte(fmt.Sprintf("  %s\n", i)))
+	if len(injectReports) > 0 {
+		output.Write([]byte("\n"))
+		writeInjectSummaryTable(injectReports, output, explain)
 	}
 
 	// trailing newline to separate from kubectl output if piping
 	output.Write([]byte("\n"))
 }
 
+// writeInjectSummaryTable writes a row for each resource, telling whether it
+// was injected, or why not. With explain, it also tells which inject policy
+// the injected resources were injected by.
+func writeInjectSummaryTable(injectReports []injectReport, output io.Writer, explain bool) {
+	nameWidth := len("RESOURCE")
+	for _, r := range injectReports {
+		if len(r.name) > nameWidth {
+			nameWidth = len(r.name)
+		}
+	}
+
+	row := func(name, injected, reason string) {
+		line := fmt.Sprintf("  %-*s  %-8s  %s", nameWidth, name, injected, reason)
+		output.Write([]byte(strings.TrimRight(line, " ") + "\n"))
+	}
+	row("RESOURCE", "INJECTED", "REASON")
+	for _, r := range injectReports {
+		if r.injected() {
+			reason := ""
+			if explain {
+				reason = r.policy
+			}
+			row(r.name, "yes", reason)
+		} else {
+			row(r.name, "no", r.skipReason())
+		}
+	}
+}
+
+func (r injectReport) injected() bool {
+	return !r.disabled && !r.hostNetwork && !r.sidecar && !r.unsupportedResource
+}
+
+// skipReason returns why the resource wasn't injected.
+func (r injectReport) skipReason() string {
+	switch {
+	case r.unsupportedResource:
+		return "unsupported resource kind"
+	case r.disabled:
+		return r.policy
+	case r.hostNetwork:
+		return "pods use host networking"
+	case r.sidecar:
+		return "pods already have a proxy or known sidecar"
+	}
+	return ""
+}
+
 func getFiller(text string) string {
 	filler := ""
 	for i := 0; i < lineWidth-len(text)-len(okStatus)-len("\n"); i++ {
@@ -19,6 +19,15 @@ func TestInjectYAML(t *testing.T) {
 	tlsOptions.linkerdVersion = "testinjectversion"
 	tlsOptions.tls = "optional"
 
+	proxyLimitOptions := newInjectOptions()
+	proxyLimitOptions.linkerdVersion = "testinjectversion"
+	proxyLimitOptions.proxyCpuRequest = "10m"
+	proxyLimitOptions.proxyCpuLimit = "1"
+
+	disabledOptions := newInjectOptions()
+	disabledOptions.linkerdVersion = "testinjectversion"
+	disabledOptions.defaultInjectPolicy = "disabled"
+
 	proxyRequestOptions := newInjectOptions()
 	proxyRequestOptions.linkerdVersion = "testinjectversion"
 	proxyRequestOptions.proxyCpuRequest = "110m"
@@ -114,6 +123,30 @@ func TestInjectYAML(t *testing.T) {
 			reportFileName:    "inject_contour.report",
 			testInjectOptions: defaultOptions,
 		},
+		{
+			inputFileName:     "inject_helm_output.input.yml",
+			goldenFileName:    "inject_helm_output.golden.yml",
+			reportFileName:    "inject_helm_output.report",
+			testInjectOptions: defaultOptions,
+		},
+		{
+			inputFileName:     "inject_emojivoto_deployment_resources.input.yml",
+			goldenFileName:    "inject_emojivoto_deployment_resources.golden.yml",
+			reportFileName:    "inject_emojivoto_deployment.report",
+			testInjectOptions: proxyLimitOptions,
+		},
+		{
+			inputFileName:     "inject_namespace_policy.input.yml",
+			goldenFileName:    "inject_namespace_policy.golden.yml",
+			reportFileName:    "inject_namespace_policy.report",
+			testInjectOptions: defaultOptions,
+		},
+		{
+			inputFileName:     "inject_emojivoto_deployment.input.yml",
+			goldenFileName:    "inject_emojivoto_deployment_disabled.golden.yml",
+			reportFileName:    "inject_emojivoto_deployment_disabled.report",
+			testInjectOptions: disabledOptions,
+		},
 	}
 
 	for i, tc := range testCases {
@@ -200,6 +233,31 @@ func TestRunInjectCmd(t *testing.T) {
 	}
 }
 
+func TestRunInjectCmdReport(t *testing.T) {
+	options := newInjectOptions()
+	options.linkerdVersion = "testinjectversion"
+	options.report = true
+
+	in, err := os.Open("testdata/inject_namespace_policy.input.yml")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	errBuffer := &bytes.Buffer{}
+	outBuffer := &bytes.Buffer{}
+	if exitCode := runInjectCmd([]io.Reader{in}, errBuffer, outBuffer, options); exitCode != 0 {
+		t.Fatalf("Expected exit code to be 0 but got: %d", exitCode)
+	}
+
+	expectedStdOutResult := readOptionalTestFile(t, "inject_namespace_policy.explain.report")
+	if expectedStdOutResult != outBuffer.String() {
+		t.Errorf("Result mismatch.\nExpected: %s\nActual: %s", expectedStdOutResult, outBuffer.String())
+	}
+	if errBuffer.Len() != 0 {
+		t.Errorf("Expected no stderr output, got: %s", errBuffer.String())
+	}
+}
+
 func TestInjectFilePath(t *testing.T) {
 	var (
 		resourceFolder = filepath.Join("testdata", "inject-filepath", "resources")
@@ -317,3 +375,99 @@ func TestWalk(t *testing.T) {
 		}
 	}
 }
+
+func TestInjectInputs(t *testing.T) {
+	// a fake helm, outputting a config or failing depending on the chart
+	binDir, err := ioutil.TempDir("", "linkerd-inject")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	defer os.RemoveAll(binDir)
+	helm := `#!/bin/sh
+if [ "$2" = "./books" ]; then
+  cat testdata/inject_helm_output.input.yml
+else
+  echo "Error: chart $2 not found" >&2
+  exit 1
+fi
+`
+	if err := ioutil.WriteFile(filepath.Join(binDir, "helm"), []byte(helm), 0755); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	defer os.Setenv("PATH", os.Getenv("PATH"))
+	os.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	t.Run("Injects the output of helm template", func(t *testing.T) {
+		options := newInjectOptions()
+		options.fromHelm = "./books"
+
+		in, err := injectInputs(nil, options, nil)
+		if err != nil {
+			t.Fatal("Unexpected error: ", err)
+		}
+		if len(in) != 1 {
+			t.Fatalf("Expected 1 input, got %d", len(in))
+		}
+		actual, err := ioutil.ReadAll(in[0])
+		if err != nil {
+			t.Fatal("Unexpected error: ", err)
+		}
+		expected := readOptionalTestFile(t, "inject_helm_output.input.yml")
+		if string(actual) != expected {
+			t.Errorf("Result mismatch.\nExpected: %s\nActual: %s", expected, actual)
+		}
+	})
+
+	t.Run("Returns the errors of helm template", func(t *testing.T) {
+		options := newInjectOptions()
+		options.fromHelm = "./missing"
+
+		_, err := injectInputs(nil, options, nil)
+		expected := "failed to run helm template ./missing: Error: chart ./missing not found"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got %v", expected, err)
+		}
+	})
+
+	t.Run("Rejects more than one input", func(t *testing.T) {
+		options := newInjectOptions()
+		options.fromHelm = "./books"
+		options.fromKustomize = "./overlays/prod"
+
+		_, err := injectInputs(nil, options, nil)
+		expected := "please specify only one of a kubernetes resource file, --from-helm or --from-kustomize"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got %v", expected, err)
+		}
+	})
+
+	t.Run("Reads stdin when it is piped", func(t *testing.T) {
+		stdin, err := os.Open(filepath.Join("testdata", "inject_helm_output.input.yml"))
+		if err != nil {
+			t.Fatal("Unexpected error: ", err)
+		}
+		defer stdin.Close()
+
+		in, err := injectInputs(nil, newInjectOptions(), stdin)
+		if err != nil {
+			t.Fatal("Unexpected error: ", err)
+		}
+		if len(in) != 1 || in[0] != stdin {
+			t.Fatalf("Expected stdin to be the input, got %v", in)
+		}
+	})
+
+	t.Run("Requires an input when stdin is a terminal", func(t *testing.T) {
+		stdin, err := os.Open(os.DevNull)
+		if err != nil {
+			t.Fatal("Unexpected error: ", err)
+		}
+		defer stdin.Close()
+
+		_, err = injectInputs(nil, newInjectOptions(), stdin)
+		expected := "please specify a kubernetes resource file"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error %q, got %v", expected, err)
+		}
+	})
+}
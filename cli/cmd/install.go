@@ -1,19 +1,28 @@
 package cmd
 
 import (
+	"bufio"
 	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"strings"
 	"text/template"
+	"time"
 
+	"github.com/ghodss/yaml"
 	"github.com/linkerd/linkerd2/cli/install"
+	"github.com/linkerd/linkerd2/controller/api/public"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	uuid "github.com/satori/go.uuid"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	yamlDecoder "k8s.io/apimachinery/pkg/util/yaml"
 )
 
 type installConfig struct {
@@ -42,6 +51,7 @@ type installConfig struct {
 	OutboundPort                     uint
 	IgnoreInboundPorts               string
 	IgnoreOutboundPorts              string
+	IgnoreOutboundCIDRs              string
 	ProxyAutoInjectEnabled           bool
 	ProxyAutoInjectLabel             string
 	ProxyUID                         int64
@@ -54,11 +64,19 @@ type installConfig struct {
 	ProxyImage                       string
 	ProxyResourceRequestCPU          string
 	ProxyResourceRequestMemory       string
+	ProxyResourceLimitCPU            string
+	ProxyResourceLimitMemory         string
 	ProxyBindTimeout                 string
+	OpaquePorts                      string
 	SingleNamespace                  bool
 	EnableHA                         bool
 	ProfileSuffixes                  string
 	EnableH2Upgrade                  bool
+	OpenShift                        bool
+	NoInitContainer                  bool
+	AlertsEnabled                    bool
+	ProfileOperatorEnabled           bool
+	TapAccessPolicy                  string
 }
 
 type installOptions struct {
@@ -68,6 +86,12 @@ type installOptions struct {
 	singleNamespace    bool
 	highAvailability   bool
 	disableH2Upgrade   bool
+	openshift          bool
+	alerts             bool
+	profileOperator    bool
+	tapAccessPolicy    string
+	configHash         bool
+	audit              bool
 	*proxyConfigOptions
 }
 
@@ -75,6 +99,7 @@ const (
 	prometheusProxyOutboundCapacity = 10000
 	defaultControllerReplicas       = 1
 	defaultHAControllerReplicas     = 3
+	installAuditTimeout             = 2 * time.Minute
 )
 
 func newInstallOptions() *installOptions {
@@ -85,6 +110,12 @@ func newInstallOptions() *installOptions {
 		singleNamespace:    false,
 		highAvailability:   false,
 		disableH2Upgrade:   false,
+		openshift:          false,
+		alerts:             false,
+		profileOperator:    false,
+		tapAccessPolicy:    "",
+		configHash:         false,
+		audit:              false,
 		proxyConfigOptions: newProxyConfigOptions(),
 	}
 }
@@ -102,10 +133,52 @@ func newCmdInstall() *cobra.Command {
 				return err
 			}
 
-			return render(*config, os.Stdout, options)
+			if options.verifyImagesKey != "" {
+				verified, err := verifyImages(options.verifyImagesKey, config.linkerdImages())
+				if err != nil {
+					return err
+				}
+				// the proxies injected in the control plane are pinned as well
+				options.verifiedImages = verified
+				config.pinImages(verified)
+			}
+
+			var buf bytes.Buffer
+			if err := render(*config, &buf, options); err != nil {
+				return err
+			}
+			configHash := manifestHash(buf.Bytes())
+
+			if options.configHash {
+				if err := annotateConfigHash(&buf, os.Stdout); err != nil {
+					return err
+				}
+			} else if _, err := buf.WriteTo(os.Stdout); err != nil {
+				return err
+			}
+
+			if !options.audit {
+				return nil
+			}
+			// The event can only be created once the manifests, which hold its
+			// CRD, are applied: closing stdout lets a piped 'kubectl apply'
+			// proceed while the event waits for them.
+			os.Stdout.Close()
+			awaitAuditEvent(newAuditEvent(cmd, args, configHash, time.Now()), installAuditTimeout)
+			return nil
 		},
 	}
 
+	addInstallFlags(cmd, options)
+	cmd.PersistentFlags().BoolVar(&options.configHash, "config-hash", options.configHash, "Annotate the rendered objects with the hash of their configuration, for drift detection with 'linkerd diff'")
+	addVerifyImagesFlag(cmd, options.proxyConfigOptions)
+	cmd.PersistentFlags().BoolVar(&options.audit, "audit", options.audit, "Record the install, once the manifests are applied, in the audit history listed by 'linkerd audit history'")
+	return cmd
+}
+
+// addInstallFlags adds the flags that configure the rendered control plane,
+// shared by the commands that render it.
+func addInstallFlags(cmd *cobra.Command, options *installOptions) {
 	addProxyConfigFlags(cmd, options.proxyConfigOptions)
 	cmd.PersistentFlags().UintVar(&options.controllerReplicas, "controller-replicas", options.controllerReplicas, "Replicas of the controller to deploy")
 	cmd.PersistentFlags().StringVar(&options.controllerLogLevel, "controller-log-level", options.controllerLogLevel, "Log level for the controller and web components")
@@ -113,7 +186,10 @@ func newCmdInstall() *cobra.Command {
 	cmd.PersistentFlags().BoolVar(&options.singleNamespace, "single-namespace", options.singleNamespace, "Experimental: Configure the control plane to only operate in the installed namespace (default false)")
 	cmd.PersistentFlags().BoolVar(&options.highAvailability, "ha", options.highAvailability, "Experimental: Enable HA deployment config for the control plane")
 	cmd.PersistentFlags().BoolVar(&options.disableH2Upgrade, "disable-h2-upgrade", options.disableH2Upgrade, "Prevents the controller from instructing proxies to perform transparent HTTP/2 ugprading")
-	return cmd
+	cmd.PersistentFlags().BoolVar(&options.openshift, "openshift", options.openshift, "Experimental: Render the SecurityContextConstraints required to run the control plane and the data plane on OpenShift")
+	cmd.PersistentFlags().BoolVar(&options.alerts, "alerts", options.alerts, "Experimental: Deploy the alerting controller, configured by the linkerd-alerts-config ConfigMap")
+	cmd.PersistentFlags().BoolVar(&options.profileOperator, "profile-operator", options.profileOperator, "Experimental: Deploy the profile-operator, generating service profiles from the traffic of the services without one")
+	cmd.PersistentFlags().StringVar(&options.tapAccessPolicy, "tap-access-policy", options.tapAccessPolicy, "Experimental: Enable the tap WebSocket endpoint of the dashboard for the consumers of this YAML tap access policy")
 }
 
 func validateAndBuildConfig(options *installOptions) (*installConfig, error) {
@@ -145,12 +221,24 @@ func validateAndBuildConfig(options *installOptions) (*installConfig, error) {
 		options.proxyMemoryRequest = "20Mi"
 	}
 
+	tapAccessPolicy := ""
+	if options.tapAccessPolicy != "" {
+		if _, err := public.LoadTapAccessPolicy(options.tapAccessPolicy); err != nil {
+			return nil, err
+		}
+		policy, err := ioutil.ReadFile(options.tapAccessPolicy)
+		if err != nil {
+			return nil, err
+		}
+		tapAccessPolicy = base64.StdEncoding.EncodeToString(policy)
+	}
+
 	profileSuffixes := "."
 	if options.proxyConfigOptions.disableExternalProfiles {
 		profileSuffixes = "svc.cluster.local."
 	}
 
-	return &installConfig{
+	config := &installConfig{
 		Namespace:                        controlPlaneNamespace,
 		ControllerImage:                  fmt.Sprintf("%s/controller:%s", options.dockerRegistry, options.linkerdVersion),
 		WebImage:                         fmt.Sprintf("%s/web:%s", options.dockerRegistry, options.linkerdVersion),
@@ -176,6 +264,7 @@ func validateAndBuildConfig(options *installOptions) (*installConfig, error) {
 		OutboundPort:                     options.outboundPort,
 		IgnoreInboundPorts:               strings.Join(ignoreInboundPorts, ","),
 		IgnoreOutboundPorts:              strings.Join(ignoreOutboundPorts, ","),
+		IgnoreOutboundCIDRs:              strings.Join(options.ignoreOutboundCIDRs, ","),
 		ProxyAutoInjectEnabled:           options.proxyAutoInject,
 		ProxyAutoInjectLabel:             k8s.ProxyAutoInjectLabel,
 		ProxyUID:                         options.proxyUID,
@@ -188,12 +277,52 @@ func validateAndBuildConfig(options *installOptions) (*installConfig, error) {
 		ProxyImage:                       options.taggedProxyImage(),
 		ProxyResourceRequestCPU:          options.proxyCpuRequest,
 		ProxyResourceRequestMemory:       options.proxyMemoryRequest,
+		ProxyResourceLimitCPU:            options.proxyCpuLimit,
+		ProxyResourceLimitMemory:         options.proxyMemoryLimit,
 		ProxyBindTimeout:                 "1m",
+		OpaquePorts:                      options.opaquePorts,
 		SingleNamespace:                  options.singleNamespace,
 		EnableHA:                         options.highAvailability,
 		ProfileSuffixes:                  profileSuffixes,
 		EnableH2Upgrade:                  !options.disableH2Upgrade,
-	}, nil
+		OpenShift:                        options.openshift,
+		NoInitContainer:                  options.noInitContainer,
+		AlertsEnabled:                    options.alerts,
+		ProfileOperatorEnabled:           options.profileOperator,
+		TapAccessPolicy:                  tapAccessPolicy,
+	}
+
+	if options.ignoreCluster {
+		config.UUID = deterministicUUID(*config)
+	}
+
+	return config, nil
+}
+
+// linkerdImages returns the Linkerd images of the control plane and of its
+// proxies.
+func (config installConfig) linkerdImages() []string {
+	images := []string{config.ControllerImage, config.WebImage, config.GrafanaImage, config.ProxyImage}
+	if !config.NoInitContainer {
+		images = append(images, config.ProxyInitImage)
+	}
+	return images
+}
+
+// pinImages replaces the Linkerd images of the config with the pinned ones.
+func (config *installConfig) pinImages(pinned map[string]string) {
+	for _, image := range []*string{&config.ControllerImage, &config.WebImage, &config.GrafanaImage, &config.ProxyImage, &config.ProxyInitImage} {
+		if p, ok := pinned[*image]; ok {
+			*image = p
+		}
+	}
+}
+
+// deterministicUUID derives the install UUID from the rest of the config, so
+// that offline renders with the same flags produce identical manifests.
+func deterministicUUID(config installConfig) string {
+	config.UUID = ""
+	return uuid.NewV5(uuid.NamespaceOID, fmt.Sprintf("linkerd-install:%+v", config)).String()
 }
 
 func render(config installConfig, w io.Writer, options *installOptions) error {
@@ -229,6 +358,39 @@ func render(config installConfig, w io.Writer, options *installOptions) error {
 		}
 	}
 
+	if config.AlertsEnabled {
+		alertsTemplate, err := template.New("linkerd").Parse(install.AlertsTemplate)
+		if err != nil {
+			return err
+		}
+		err = alertsTemplate.Execute(buf, config)
+		if err != nil {
+			return err
+		}
+	}
+
+	if config.ProfileOperatorEnabled {
+		profileOperatorTemplate, err := template.New("linkerd").Parse(install.ProfileOperatorTemplate)
+		if err != nil {
+			return err
+		}
+		err = profileOperatorTemplate.Execute(buf, config)
+		if err != nil {
+			return err
+		}
+	}
+
+	if config.OpenShift {
+		openShiftTemplate, err := template.New("linkerd").Parse(install.OpenShiftTemplate)
+		if err != nil {
+			return err
+		}
+		err = openShiftTemplate.Execute(buf, config)
+		if err != nil {
+			return err
+		}
+	}
+
 	injectOptions := newInjectOptions()
 	injectOptions.proxyConfigOptions = options.proxyConfigOptions
 
@@ -238,6 +400,81 @@ func render(config installConfig, w io.Writer, options *installOptions) error {
 	return InjectYAML(buf, w, ioutil.Discard, injectOptions)
 }
 
+// annotateConfigHash copies the rendered manifests to w, annotating every
+// object with the hash of its configuration.
+func annotateConfigHash(manifests io.Reader, w io.Writer) error {
+	reader := yamlDecoder.NewYAMLReader(bufio.NewReaderSize(manifests, 4096))
+
+	for {
+		bytes, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var object map[string]interface{}
+		if err := yaml.Unmarshal(bytes, &object); err != nil {
+			return err
+		}
+		if object["kind"] == nil {
+			continue
+		}
+
+		hash, err := objectConfigHash(object)
+		if err != nil {
+			return err
+		}
+		metadata, _ := object["metadata"].(map[string]interface{})
+		if metadata == nil {
+			metadata = map[string]interface{}{}
+			object["metadata"] = metadata
+		}
+		annotations, _ := metadata["annotations"].(map[string]interface{})
+		if annotations == nil {
+			annotations = map[string]interface{}{}
+			metadata["annotations"] = annotations
+		}
+		annotations[k8s.ConfigHashAnnotation] = hash
+
+		out, err := yaml.Marshal(object)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "---\n%s", out); err != nil {
+			return err
+		}
+	}
+}
+
+// objectConfigHash returns the SHA-256 of the JSON encoding of the object,
+// leaving out its config hash annotation.
+func objectConfigHash(object map[string]interface{}) (string, error) {
+	b, err := json.Marshal(object)
+	if err != nil {
+		return "", err
+	}
+	var clean map[string]interface{}
+	if err := json.Unmarshal(b, &clean); err != nil {
+		return "", err
+	}
+	if metadata, ok := clean["metadata"].(map[string]interface{}); ok {
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			delete(annotations, k8s.ConfigHashAnnotation)
+			if len(annotations) == 0 {
+				delete(metadata, "annotations")
+			}
+		}
+	}
+	if b, err = json.Marshal(clean); err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
+
 func (options *installOptions) validate() error {
 	if _, err := log.ParseLevel(options.controllerLogLevel); err != nil {
 		return fmt.Errorf("--controller-log-level must be one of: panic, fatal, error, warn, info, debug")
@@ -247,5 +484,21 @@ func (options *installOptions) validate() error {
 		return fmt.Errorf("The --proxy-auto-inject and --single-namespace flags cannot both be specified together")
 	}
 
+	if options.openshift && options.singleNamespace {
+		return fmt.Errorf("The --openshift and --single-namespace flags cannot both be specified together")
+	}
+
+	if options.alerts && options.singleNamespace {
+		return fmt.Errorf("The --alerts and --single-namespace flags cannot both be specified together")
+	}
+
+	if options.profileOperator && options.singleNamespace {
+		return fmt.Errorf("The --profile-operator and --single-namespace flags cannot both be specified together")
+	}
+
+	if options.audit && options.ignoreCluster {
+		return fmt.Errorf("The --audit and --ignore-cluster flags cannot both be specified together")
+	}
+
 	return options.proxyConfigOptions.validate()
 }
@@ -0,0 +1,303 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/linkerd/linkerd2/cli/install"
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"k8s.io/api/core/v1"
+)
+
+const (
+	defaultCNINetDir = "/etc/cni/net.d"
+	defaultCNIBinDir = "/opt/cni/bin"
+)
+
+type installCNIPluginConfig struct {
+	Namespace                string
+	ControllerComponentLabel string
+	CreatedByAnnotation      string
+	CliVersion               string
+	CNIPluginImage           string
+	ImagePullPolicy          string
+	LogLevel                 string
+	InboundPort              uint
+	OutboundPort             uint
+	IgnoreInboundPorts       string
+	IgnoreOutboundPorts      string
+	IgnoreOutboundCIDRs      string
+	ProxyUID                 int64
+	DestCNINetDir            string
+	DestCNIBinDir            string
+}
+
+type cniPluginOptions struct {
+	namespace           string
+	linkerdVersion      string
+	dockerRegistry      string
+	imagePullPolicy     string
+	proxyControlPort    uint
+	proxyMetricsPort    uint
+	inboundPort         uint
+	outboundPort        uint
+	ignoreInboundPorts  []uint
+	ignoreOutboundPorts []uint
+	ignoreOutboundCIDRs []string
+	proxyUID            int64
+	cniPluginImage      string
+	logLevel            string
+	destCNINetDir       string
+	destCNIBinDir       string
+	ignoreCluster       bool
+}
+
+func newCNIPluginOptions() *cniPluginOptions {
+	defaults := newProxyConfigOptions()
+	return &cniPluginOptions{
+		namespace:           healthcheck.DefaultCNIPluginNamespace,
+		linkerdVersion:      defaults.linkerdVersion,
+		dockerRegistry:      defaultDockerRegistry,
+		imagePullPolicy:     defaults.imagePullPolicy,
+		proxyControlPort:    defaults.proxyControlPort,
+		proxyMetricsPort:    defaults.proxyMetricsPort,
+		inboundPort:         defaults.inboundPort,
+		outboundPort:        defaults.outboundPort,
+		ignoreInboundPorts:  nil,
+		ignoreOutboundPorts: nil,
+		ignoreOutboundCIDRs: nil,
+		proxyUID:            defaults.proxyUID,
+		cniPluginImage:      defaultDockerRegistry + "/cni-plugin",
+		logLevel:            "info",
+		destCNINetDir:       defaultCNINetDir,
+		destCNIBinDir:       defaultCNIBinDir,
+		ignoreCluster:       false,
+	}
+}
+
+// hostCNI describes the CNI setup of the cluster nodes, which the linkerd-cni
+// plugin chains onto.
+type hostCNI struct {
+	flavor string
+	netDir string
+	binDir string
+}
+
+// cniFlavors maps the prefix of the kube-system pods run by the common CNI
+// plugins to the name of the plugin.
+var cniFlavors = []struct {
+	podPrefix string
+	flavor    string
+}{
+	{"aws-node-", "aws-vpc-cni"},
+	{"calico-node-", "calico"},
+	{"canal-", "canal"},
+	{"cilium-", "cilium"},
+	{"kube-flannel-", "flannel"},
+	{"weave-net-", "weave"},
+}
+
+func newCmdInstallCNIPlugin() *cobra.Command {
+	options := newCNIPluginOptions()
+
+	cmd := &cobra.Command{
+		Use:   "install-cni [flags]",
+		Short: "Output Kubernetes configs to install Linkerd CNI",
+		Long: `Output Kubernetes configs to install Linkerd CNI.
+
+The linkerd-cni plugin configures the iptables rules redirecting the traffic of
+meshed pods to their proxy, chained after the cluster's CNI plugin, so that
+pods don't need the privileged proxy-init container. Install it before the
+control plane, and pass --linkerd-cni-enabled to 'linkerd install' and
+'linkerd inject'.
+
+Unless the CNI directories are given, or --ignore-cluster is set, the host CNI
+plugin is detected from the pods running in the kube-system namespace.`,
+		Example: `  # Install the CNI plugin, then check that it's ready on all nodes
+  linkerd install-cni | kubectl apply -f -
+  linkerd check --pre --linkerd-cni-enabled`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !options.ignoreCluster && !cmd.Flags().Changed("dest-cni-net-dir") && !cmd.Flags().Changed("dest-cni-bin-dir") {
+				host, err := detectHostCNI()
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Could not detect the host CNI plugin, using the default CNI directories: %s\n", err)
+				} else {
+					fmt.Fprintf(os.Stderr, "Detected the %s CNI plugin\n", host.flavor)
+					options.destCNINetDir = host.netDir
+					options.destCNIBinDir = host.binDir
+				}
+			}
+
+			config, err := validateAndBuildCNIConfig(options)
+			if err != nil {
+				return newCliError(exitCodeInvalidFlags, err)
+			}
+
+			return renderCNIPlugin(os.Stdout, config)
+		},
+	}
+
+	cmd.PersistentFlags().StringVar(&options.namespace, "cni-namespace", options.namespace, "Namespace in which the CNI plugin is installed")
+	cmd.PersistentFlags().StringVarP(&options.linkerdVersion, "linkerd-version", "v", options.linkerdVersion, "Tag to be used for Linkerd images")
+	cmd.PersistentFlags().StringVar(&options.dockerRegistry, "registry", options.dockerRegistry, "Docker registry to pull images from")
+	cmd.PersistentFlags().StringVar(&options.imagePullPolicy, "image-pull-policy", options.imagePullPolicy, "Docker image pull policy")
+	cmd.PersistentFlags().StringVar(&options.cniPluginImage, "cni-image", options.cniPluginImage, "Image for the CNI plugin")
+	cmd.PersistentFlags().StringVar(&options.logLevel, "cni-log-level", options.logLevel, "Log level for the CNI plugin")
+	cmd.PersistentFlags().Int64Var(&options.proxyUID, "proxy-uid", options.proxyUID, "Run the proxy under this user ID")
+	cmd.PersistentFlags().UintVar(&options.inboundPort, "inbound-port", options.inboundPort, "Proxy port to use for inbound traffic")
+	cmd.PersistentFlags().UintVar(&options.outboundPort, "outbound-port", options.outboundPort, "Proxy port to use for outbound traffic")
+	cmd.PersistentFlags().UintVar(&options.proxyControlPort, "control-port", options.proxyControlPort, "Proxy port to use for control")
+	cmd.PersistentFlags().UintVar(&options.proxyMetricsPort, "metrics-port", options.proxyMetricsPort, "Proxy port to serve metrics on")
+	cmd.PersistentFlags().UintSliceVar(&options.ignoreInboundPorts, "skip-inbound-ports", options.ignoreInboundPorts, "Ports that should skip the proxy and send directly to the application")
+	cmd.PersistentFlags().UintSliceVar(&options.ignoreOutboundPorts, "skip-outbound-ports", options.ignoreOutboundPorts, "Outbound ports that should skip the proxy")
+	cmd.PersistentFlags().StringSliceVar(&options.ignoreOutboundCIDRs, "skip-outbound-cidrs", options.ignoreOutboundCIDRs, "Outbound destination networks that should skip the proxy, e.g. 169.254.169.254/32")
+	cmd.PersistentFlags().StringVar(&options.destCNINetDir, "dest-cni-net-dir", options.destCNINetDir, "Directory on the host where the CNI configuration will be placed")
+	cmd.PersistentFlags().StringVar(&options.destCNIBinDir, "dest-cni-bin-dir", options.destCNIBinDir, "Directory on the host where the CNI plugin binaries reside")
+	cmd.PersistentFlags().BoolVar(&options.ignoreCluster, "ignore-cluster", options.ignoreCluster, "Render offline: don't detect the host CNI plugin, and ignore the user config file and environment defaults")
+
+	return cmd
+}
+
+func (options *cniPluginOptions) validate() error {
+	if !alphaNumDashDot.MatchString(options.linkerdVersion) {
+		return fmt.Errorf("%s is not a valid version", options.linkerdVersion)
+	}
+
+	if !alphaNumDashDotSlashColon.MatchString(options.dockerRegistry) {
+		return fmt.Errorf("%s is not a valid Docker registry. The url can contain only letters, numbers, dash, dot, slash and colon", options.dockerRegistry)
+	}
+
+	if !alphaNumDash.MatchString(options.namespace) {
+		return fmt.Errorf("%s is not a valid namespace", options.namespace)
+	}
+
+	if options.imagePullPolicy != "Always" && options.imagePullPolicy != "IfNotPresent" && options.imagePullPolicy != "Never" {
+		return fmt.Errorf("--image-pull-policy must be one of: Always, IfNotPresent, Never")
+	}
+
+	if err := k8s.ValidateCIDRs(options.ignoreOutboundCIDRs); err != nil {
+		return fmt.Errorf("%s for --skip-outbound-cidrs flag", err)
+	}
+
+	if _, err := log.ParseLevel(options.logLevel); err != nil {
+		return fmt.Errorf("--cni-log-level must be one of: panic, fatal, error, warn, info, debug")
+	}
+
+	for _, dir := range []string{options.destCNINetDir, options.destCNIBinDir} {
+		if !strings.HasPrefix(dir, "/") {
+			return fmt.Errorf("%s is not an absolute path", dir)
+		}
+	}
+
+	return nil
+}
+
+func (options *cniPluginOptions) taggedCNIPluginImage() string {
+	image := strings.Replace(options.cniPluginImage, defaultDockerRegistry, options.dockerRegistry, 1)
+	return fmt.Sprintf("%s:%s", image, options.linkerdVersion)
+}
+
+func validateAndBuildCNIConfig(options *cniPluginOptions) (*installCNIPluginConfig, error) {
+	if err := options.validate(); err != nil {
+		return nil, err
+	}
+
+	ignoreInboundPorts := []string{
+		fmt.Sprintf("%d", options.proxyControlPort),
+		fmt.Sprintf("%d", options.proxyMetricsPort),
+	}
+	for _, p := range options.ignoreInboundPorts {
+		ignoreInboundPorts = append(ignoreInboundPorts, fmt.Sprintf("%d", p))
+	}
+	ignoreOutboundPorts := []string{}
+	for _, p := range options.ignoreOutboundPorts {
+		ignoreOutboundPorts = append(ignoreOutboundPorts, fmt.Sprintf("%d", p))
+	}
+	ignoreOutboundCIDRs := []string{}
+	for _, cidr := range options.ignoreOutboundCIDRs {
+		ignoreOutboundCIDRs = append(ignoreOutboundCIDRs, fmt.Sprintf("%q", cidr))
+	}
+
+	return &installCNIPluginConfig{
+		Namespace:                options.namespace,
+		ControllerComponentLabel: k8s.ControllerComponentLabel,
+		CreatedByAnnotation:      k8s.CreatedByAnnotation,
+		CliVersion:               k8s.CreatedByAnnotationValue(),
+		CNIPluginImage:           options.taggedCNIPluginImage(),
+		ImagePullPolicy:          options.imagePullPolicy,
+		LogLevel:                 options.logLevel,
+		InboundPort:              options.inboundPort,
+		OutboundPort:             options.outboundPort,
+		IgnoreInboundPorts:       strings.Join(ignoreInboundPorts, ","),
+		IgnoreOutboundPorts:      strings.Join(ignoreOutboundPorts, ","),
+		IgnoreOutboundCIDRs:      strings.Join(ignoreOutboundCIDRs, ","),
+		ProxyUID:                 options.proxyUID,
+		DestCNINetDir:            options.destCNINetDir,
+		DestCNIBinDir:            options.destCNIBinDir,
+	}, nil
+}
+
+func renderCNIPlugin(w io.Writer, config *installCNIPluginConfig) error {
+	template, err := template.New("linkerd-cni").Parse(install.CNITemplate)
+	if err != nil {
+		return err
+	}
+
+	buf := &bytes.Buffer{}
+	if err := template.Execute(buf, config); err != nil {
+		return err
+	}
+
+	_, err = w.Write(buf.Bytes())
+	return err
+}
+
+func detectHostCNI() (*hostCNI, error) {
+	kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup)
+	if err != nil {
+		return nil, err
+	}
+	client, err := kubeAPI.NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := kubeAPI.GetPodsByNamespace(client, "kube-system")
+	if err != nil {
+		return nil, err
+	}
+
+	return hostCNIFor(pods), nil
+}
+
+// hostCNIFor returns the host CNI setup of the cluster running the given
+// kube-system pods. GKE nodes keep the CNI binaries outside of the default
+// directory.
+func hostCNIFor(kubeSystemPods []v1.Pod) *hostCNI {
+	host := &hostCNI{flavor: "default", netDir: defaultCNINetDir, binDir: defaultCNIBinDir}
+
+	for _, pod := range kubeSystemPods {
+		for _, f := range cniFlavors {
+			if strings.HasPrefix(pod.Name, f.podPrefix) {
+				host.flavor = f.flavor
+			}
+		}
+		if strings.HasPrefix(pod.Spec.NodeName, "gke-") {
+			host.binDir = "/home/kubernetes/bin"
+		}
+	}
+
+	if host.binDir != defaultCNIBinDir && host.flavor == "default" {
+		host.flavor = "gke"
+	}
+
+	return host
+}
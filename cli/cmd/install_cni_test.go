@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestRenderCNIPlugin(t *testing.T) {
+	metaConfig := installCNIPluginConfig{
+		Namespace:                "Namespace",
+		ControllerComponentLabel: "ControllerComponentLabel",
+		CreatedByAnnotation:      "CreatedByAnnotation",
+		CliVersion:               "CliVersion",
+		CNIPluginImage:           "CNIPluginImage",
+		ImagePullPolicy:          "ImagePullPolicy",
+		LogLevel:                 "LogLevel",
+		InboundPort:              1234,
+		OutboundPort:             5678,
+		IgnoreInboundPorts:       "4190,4191",
+		IgnoreOutboundPorts:      "3306",
+		IgnoreOutboundCIDRs:      `"169.254.169.254/32"`,
+		ProxyUID:                 2102,
+		DestCNINetDir:            "/DestCNINetDir",
+		DestCNIBinDir:            "/DestCNIBinDir",
+	}
+
+	var buf bytes.Buffer
+	if err := renderCNIPlugin(&buf, &metaConfig); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	diffCompareFile(t, buf.String(), "install_cni_output.golden")
+}
+
+func TestValidateAndBuildCNIConfig(t *testing.T) {
+	t.Run("Accepts the default options as valid", func(t *testing.T) {
+		options := newCNIPluginOptions()
+		options.ignoreOutboundPorts = []uint{3306}
+		options.ignoreOutboundCIDRs = []string{"169.254.169.254/32", "10.0.0.0/8"}
+
+		config, err := validateAndBuildCNIConfig(options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if config.IgnoreInboundPorts != "4190,4191" {
+			t.Fatalf("Expected inbound ports to ignore [4190,4191], got [%s]", config.IgnoreInboundPorts)
+		}
+		if config.IgnoreOutboundPorts != "3306" {
+			t.Fatalf("Expected outbound ports to ignore [3306], got [%s]", config.IgnoreOutboundPorts)
+		}
+		if config.IgnoreOutboundCIDRs != `"169.254.169.254/32","10.0.0.0/8"` {
+			t.Fatalf("Expected outbound CIDRs to ignore [\"169.254.169.254/32\",\"10.0.0.0/8\"], got [%s]", config.IgnoreOutboundCIDRs)
+		}
+	})
+
+	t.Run("Rejects relative CNI directories", func(t *testing.T) {
+		options := newCNIPluginOptions()
+		options.destCNIBinDir = "opt/cni/bin"
+		expected := "opt/cni/bin is not an absolute path"
+
+		_, err := validateAndBuildCNIConfig(options)
+		if err == nil {
+			t.Fatalf("Expected error, got nothing")
+		}
+		if err.Error() != expected {
+			t.Fatalf("Expected error string\"%s\", got \"%s\"", expected, err)
+		}
+	})
+}
+
+func TestHostCNIFor(t *testing.T) {
+	pod := func(name, nodeName string) v1.Pod {
+		return v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{Name: name, Namespace: "kube-system"},
+			Spec:       v1.PodSpec{NodeName: nodeName},
+		}
+	}
+
+	testCases := []struct {
+		pods     []v1.Pod
+		expected hostCNI
+	}{
+		{
+			nil,
+			hostCNI{flavor: "default", netDir: defaultCNINetDir, binDir: defaultCNIBinDir},
+		},
+		{
+			[]v1.Pod{pod("kube-proxy-x2vbl", "node-1"), pod("calico-node-7f9kx", "node-1")},
+			hostCNI{flavor: "calico", netDir: defaultCNINetDir, binDir: defaultCNIBinDir},
+		},
+		{
+			[]v1.Pod{pod("kube-proxy-gke-default-pool-1", "gke-default-pool-1")},
+			hostCNI{flavor: "gke", netDir: defaultCNINetDir, binDir: "/home/kubernetes/bin"},
+		},
+		{
+			[]v1.Pod{pod("calico-node-hx8tq", "gke-default-pool-1")},
+			hostCNI{flavor: "calico", netDir: defaultCNINetDir, binDir: "/home/kubernetes/bin"},
+		},
+	}
+
+	for _, tc := range testCases {
+		host := hostCNIFor(tc.pods)
+		if *host != tc.expected {
+			t.Fatalf("Expected %+v, got %+v", tc.expected, *host)
+		}
+	}
+}
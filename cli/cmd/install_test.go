@@ -2,8 +2,12 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/base64"
 	"fmt"
 	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -173,4 +177,205 @@ func TestValidate(t *testing.T) {
 			t.Fatalf("Expected error string\"%s\", got \"%s\"", expected, err)
 		}
 	})
+
+	t.Run("Rejects single namespace install with the alerting controller", func(t *testing.T) {
+		options := newInstallOptions()
+		options.alerts = true
+		options.singleNamespace = true
+		expected := "The --alerts and --single-namespace flags cannot both be specified together"
+
+		err := options.validate()
+		if err == nil {
+			t.Fatalf("Expected error, got nothing")
+		}
+		if err.Error() != expected {
+			t.Fatalf("Expected error string\"%s\", got \"%s\"", expected, err)
+		}
+	})
+
+	t.Run("Rejects single namespace install with the profile-operator", func(t *testing.T) {
+		options := newInstallOptions()
+		options.profileOperator = true
+		options.singleNamespace = true
+		expected := "The --profile-operator and --single-namespace flags cannot both be specified together"
+
+		err := options.validate()
+		if err == nil {
+			t.Fatalf("Expected error, got nothing")
+		}
+		if err.Error() != expected {
+			t.Fatalf("Expected error string\"%s\", got \"%s\"", expected, err)
+		}
+	})
+
+	t.Run("Rejects single namespace install on OpenShift", func(t *testing.T) {
+		options := newInstallOptions()
+		options.openshift = true
+		options.singleNamespace = true
+		expected := "The --openshift and --single-namespace flags cannot both be specified together"
+
+		err := options.validate()
+		if err == nil {
+			t.Fatalf("Expected error, got nothing")
+		}
+		if err.Error() != expected {
+			t.Fatalf("Expected error string\"%s\", got \"%s\"", expected, err)
+		}
+	})
+}
+
+func TestRenderAlertsController(t *testing.T) {
+	options := newInstallOptions()
+	options.alerts = true
+	config, err := validateAndBuildConfig(options)
+	if err != nil {
+		t.Fatalf("Unexpected error from validateAndBuildConfig(): %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := render(*config, &buf, options); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		"name: linkerd-linkerd-alerts",
+		"name: linkerd-alerts-config",
+		"-config=/var/run/linkerd/alerts/config.yml",
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Fatalf("Expected the alerting controller to be rendered with [%s]", expected)
+		}
+	}
+
+	defaultOptions := newInstallOptions()
+	defaultConfig, err := validateAndBuildConfig(defaultOptions)
+	if err != nil {
+		t.Fatalf("Unexpected error from validateAndBuildConfig(): %v", err)
+	}
+	buf.Reset()
+	if err := render(*defaultConfig, &buf, defaultOptions); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Contains(buf.String(), "linkerd-alerts") {
+		t.Fatalf("Expected the alerting controller to be opt-in")
+	}
+}
+
+func TestRenderProfileOperator(t *testing.T) {
+	options := newInstallOptions()
+	options.profileOperator = true
+	config, err := validateAndBuildConfig(options)
+	if err != nil {
+		t.Fatalf("Unexpected error from validateAndBuildConfig(): %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := render(*config, &buf, options); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		"name: linkerd-linkerd-profile-operator",
+		"kind: RoleBinding",
+		"-api-addr=linkerd-controller-api.linkerd.svc.cluster.local:8085",
+	} {
+		if !strings.Contains(buf.String(), expected) {
+			t.Fatalf("Expected the profile-operator to be rendered with [%s]", expected)
+		}
+	}
+}
+
+func TestRenderTapAccessPolicy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tap-access-policy")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	writePolicy := func(policy string) string {
+		path := filepath.Join(dir, "policy.yml")
+		if err := ioutil.WriteFile(path, []byte(policy), 0600); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return path
+	}
+
+	t.Run("Mounts the policy in the web deployment", func(t *testing.T) {
+		policy := "consumers:\n- name: dashboard\n  token: secret\n  namespaces: [emojivoto]\n"
+		options := newInstallOptions()
+		options.tapAccessPolicy = writePolicy(policy)
+		config, err := validateAndBuildConfig(options)
+		if err != nil {
+			t.Fatalf("Unexpected error from validateAndBuildConfig(): %v", err)
+		}
+
+		var buf bytes.Buffer
+		if err := render(*config, &buf, options); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for _, expected := range []string{
+			"policy.yml: " + base64.StdEncoding.EncodeToString([]byte(policy)),
+			"-tap-access-policy=/var/run/linkerd/tap-access-policy/policy.yml",
+			"secretName: linkerd-web-tap-access-policy",
+		} {
+			if !strings.Contains(buf.String(), expected) {
+				t.Fatalf("Expected the tap access policy to be rendered with [%s]", expected)
+			}
+		}
+	})
+
+	t.Run("Rejects an invalid policy", func(t *testing.T) {
+		options := newInstallOptions()
+		options.tapAccessPolicy = writePolicy("consumers:\n- name: dashboard\n  token: secret\n")
+		if _, err := validateAndBuildConfig(options); err == nil {
+			t.Fatalf("Expected error, got nothing")
+		}
+	})
+}
+
+func TestIgnoreClusterDeterministicUUID(t *testing.T) {
+	options := newInstallOptions()
+	options.ignoreCluster = true
+
+	config1, err := validateAndBuildConfig(options)
+	if err != nil {
+		t.Fatalf("Unexpected error from validateAndBuildConfig(): %v", err)
+	}
+	config2, err := validateAndBuildConfig(options)
+	if err != nil {
+		t.Fatalf("Unexpected error from validateAndBuildConfig(): %v", err)
+	}
+
+	if config1.UUID != config2.UUID {
+		t.Fatalf("Expected identical UUIDs for the same options, got %s and %s", config1.UUID, config2.UUID)
+	}
+
+	options.controllerReplicas = 3
+	config3, err := validateAndBuildConfig(options)
+	if err != nil {
+		t.Fatalf("Unexpected error from validateAndBuildConfig(): %v", err)
+	}
+
+	if config1.UUID == config3.UUID {
+		t.Fatalf("Expected different UUIDs for different options, got %s for both", config1.UUID)
+	}
+}
+
+func TestRenderWithoutInitContainer(t *testing.T) {
+	options := newInstallOptions()
+	options.ignoreCluster = true
+	options.proxyAutoInject = true
+	options.tls = optionalTLS
+	options.noInitContainer = true
+
+	config, err := validateAndBuildConfig(options)
+	if err != nil {
+		t.Fatalf("Unexpected error from validateAndBuildConfig(): %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := render(*config, &buf, options); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "- -no-init-container\n") {
+		t.Fatalf("Expected the proxy injector to omit the proxy-init container")
+	}
 }
@@ -0,0 +1,279 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var proxyLogLevelRegex = regexp.MustCompile("^[a-zA-Z0-9_:=,.]+$")
+
+// meshConfigFields lists the fields of the mesh config, in the order they're
+// printed.
+var meshConfigFields = []string{"proxyLogLevel", "opaquePorts", "skipOutboundCIDRs", "defaultInjectPolicy"}
+
+// meshConfigValidArgs returns a copy of meshConfigFields, as the completion
+// of the arguments sorts them in place.
+func meshConfigValidArgs() []string {
+	return append([]string{}, meshConfigFields...)
+}
+
+func meshConfigListPath(namespace string) string {
+	return fmt.Sprintf("/apis/linkerd.io/v1alpha1/namespaces/%s/meshconfigs", namespace)
+}
+
+func meshConfigPath(namespace string) string {
+	return meshConfigListPath(namespace) + "/" + k8s.MeshConfigName
+}
+
+func newCmdMeshConfig() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config [flags]",
+		Short: "Read and update the mesh-wide configuration",
+		Long: `Read and update the mesh-wide configuration.
+
+The mesh-wide configuration is held by the MeshConfig named linkerd in the
+control plane namespace. The control plane watches it, so that changes apply to
+the pods injected afterwards without re-rendering the control plane. Pods that
+are already running keep their configuration until they're restarted.
+
+Unset fields keep the values of the install flags.
+
+Fields:
+  proxyLogLevel        log level of the proxies, e.g. warn,linkerd2_proxy=info
+  opaquePorts          inbound ports proxied as TCP, e.g. 3306,5432
+  skipOutboundCIDRs    networks whose outbound traffic bypasses the proxies, e.g. 169.254.169.254/32
+  defaultInjectPolicy  inject policy of the workloads without a linkerd.io/inject annotation, enabled or disabled`,
+	}
+
+	cmd.AddCommand(newCmdMeshConfigGet())
+	cmd.AddCommand(newCmdMeshConfigSet())
+
+	return cmd
+}
+
+func newCmdMeshConfigGet() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get [flags] [FIELD]",
+		Short: "Print the mesh-wide configuration, or one of its fields",
+		Example: `  # Print the mesh-wide configuration
+  linkerd config get
+
+  # Print the log level of the proxies
+  linkerd config get proxyLogLevel`,
+		Args:      cobra.MaximumNArgs(1),
+		ValidArgs: meshConfigValidArgs(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup)
+			if err != nil {
+				return err
+			}
+			client, err := kubeAPI.NewClient()
+			if err != nil {
+				return err
+			}
+
+			var meshConfig sp.MeshConfig
+			if _, err := kubeAPI.GetObject(client, meshConfigPath(controlPlaneNamespace), &meshConfig); err != nil {
+				return fmt.Errorf("failed to get the mesh config: %s", err)
+			}
+
+			if len(args) == 0 {
+				renderMeshConfig(&meshConfig.Spec, os.Stdout)
+				return nil
+			}
+
+			value, err := meshConfigField(&meshConfig.Spec, args[0])
+			if err != nil {
+				return newCliError(exitCodeInvalidFlags, err)
+			}
+			fmt.Println(value)
+			return nil
+		},
+	}
+}
+
+func newCmdMeshConfigSet() *cobra.Command {
+	return &cobra.Command{
+		Use:   "set [flags] FIELD VALUE",
+		Short: "Update a field of the mesh-wide configuration",
+		Long: `Update a field of the mesh-wide configuration.
+
+The value is validated before the update, and the update fails if the mesh
+config was changed concurrently. An empty value unsets the field.`,
+		Example: `  # Raise the log level of the proxies injected from now on
+  linkerd config set proxyLogLevel warn,linkerd2_proxy=debug
+
+  # Stop proxying the traffic to the metadata server
+  linkerd config set skipOutboundCIDRs 169.254.169.254/32
+
+  # Go back to the install-time log level
+  linkerd config set proxyLogLevel ""`,
+		Args:      cobra.ExactArgs(2),
+		ValidArgs: meshConfigValidArgs(),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup)
+			if err != nil {
+				return err
+			}
+			client, err := kubeAPI.NewClient()
+			if err != nil {
+				return err
+			}
+
+			var meshConfig sp.MeshConfig
+			found, err := kubeAPI.GetObject(client, meshConfigPath(controlPlaneNamespace), &meshConfig)
+			if err != nil {
+				return fmt.Errorf("failed to get the mesh config: %s", err)
+			}
+
+			if !found {
+				meshConfig = newMeshConfig(controlPlaneNamespace)
+				if err := setMeshConfigField(&meshConfig.Spec, args[0], args[1]); err != nil {
+					return newCliError(exitCodeInvalidFlags, err)
+				}
+				body, err := json.Marshal(meshConfig)
+				if err != nil {
+					return err
+				}
+				if err := kubeAPI.CreateObject(client, meshConfigListPath(controlPlaneNamespace), body); err != nil {
+					return fmt.Errorf("failed to create the mesh config: %s", err)
+				}
+				fmt.Printf("%s set\n", args[0])
+				recordAuditEvent(kubeAPI, client, newAuditEvent(cmd, args, meshConfigHash(&meshConfig.Spec), time.Now()))
+				return nil
+			}
+
+			patch, err := meshConfigPatch(&meshConfig, args[0], args[1])
+			if err != nil {
+				return newCliError(exitCodeInvalidFlags, err)
+			}
+			if err := kubeAPI.PatchObject(client, meshConfigPath(controlPlaneNamespace), patch); err != nil {
+				return fmt.Errorf("failed to update the mesh config: %s", err)
+			}
+			fmt.Printf("%s set\n", args[0])
+
+			// the value was validated by meshConfigPatch
+			setMeshConfigField(&meshConfig.Spec, args[0], args[1])
+			recordAuditEvent(kubeAPI, client, newAuditEvent(cmd, args, meshConfigHash(&meshConfig.Spec), time.Now()))
+			return nil
+		},
+	}
+}
+
+func newMeshConfig(namespace string) sp.MeshConfig {
+	return sp.MeshConfig{
+		TypeMeta: metaV1.TypeMeta{
+			APIVersion: "linkerd.io/v1alpha1",
+			Kind:       "MeshConfig",
+		},
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      k8s.MeshConfigName,
+			Namespace: namespace,
+		},
+	}
+}
+
+// meshConfigPatch returns the JSON patch setting the field of the mesh config
+// to value. The patch only applies to the version of the mesh config it was
+// computed from, so that concurrent updates aren't lost.
+func meshConfigPatch(meshConfig *sp.MeshConfig, field, value string) ([]byte, error) {
+	spec := meshConfig.Spec.DeepCopy()
+	if err := setMeshConfigField(spec, field, value); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal([]map[string]interface{}{
+		{"op": "test", "path": "/metadata/resourceVersion", "value": meshConfig.ResourceVersion},
+		{"op": "add", "path": "/spec", "value": spec},
+	})
+}
+
+// meshConfigHash returns the SHA-256 of the JSON encoding of the mesh config.
+func meshConfigHash(spec *sp.MeshConfigSpec) string {
+	b, _ := json.Marshal(spec)
+	return manifestHash(b)
+}
+
+// meshConfigField returns the value of the field of the mesh config, as
+// accepted by setMeshConfigField.
+func meshConfigField(spec *sp.MeshConfigSpec, field string) (string, error) {
+	switch field {
+	case "proxyLogLevel":
+		return spec.ProxyLogLevel, nil
+	case "opaquePorts":
+		return spec.OpaquePorts, nil
+	case "skipOutboundCIDRs":
+		return strings.Join(spec.SkipOutboundCIDRs, ","), nil
+	case "defaultInjectPolicy":
+		return spec.DefaultInjectPolicy, nil
+	}
+	return "", fmt.Errorf("unknown field %s; must be one of: %s", field, strings.Join(meshConfigFields, ", "))
+}
+
+// setMeshConfigField validates value and sets the field of the mesh config to
+// it. An empty value unsets the field.
+func setMeshConfigField(spec *sp.MeshConfigSpec, field, value string) error {
+	switch field {
+	case "proxyLogLevel":
+		if value != "" && !proxyLogLevelRegex.MatchString(value) {
+			return fmt.Errorf("%s is not a valid proxy log level", value)
+		}
+		spec.ProxyLogLevel = value
+	case "opaquePorts":
+		if err := k8s.ValidateOpaquePorts(value); err != nil {
+			return err
+		}
+		spec.OpaquePorts = value
+	case "skipOutboundCIDRs":
+		cidrs := []string{}
+		for _, cidr := range strings.Split(value, ",") {
+			if cidr = strings.TrimSpace(cidr); cidr != "" {
+				cidrs = append(cidrs, cidr)
+			}
+		}
+		if err := k8s.ValidateCIDRs(cidrs); err != nil {
+			return err
+		}
+		if len(cidrs) == 0 {
+			cidrs = nil
+		}
+		spec.SkipOutboundCIDRs = cidrs
+	case "defaultInjectPolicy":
+		if value != "" {
+			if err := k8s.ValidateInjectPolicy(value); err != nil {
+				return err
+			}
+		}
+		spec.DefaultInjectPolicy = value
+	default:
+		return fmt.Errorf("unknown field %s; must be one of: %s", field, strings.Join(meshConfigFields, ", "))
+	}
+	return nil
+}
+
+func renderMeshConfig(spec *sp.MeshConfigSpec, w io.Writer) {
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, padding, ' ', 0)
+	fmt.Fprintln(tw, "FIELD\tVALUE")
+	for _, field := range meshConfigFields {
+		value, _ := meshConfigField(spec, field)
+		if value == "" {
+			value = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\n", field, value)
+	}
+	tw.Flush()
+	fmt.Fprint(w, buf.String())
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+)
+
+func TestSetMeshConfigField(t *testing.T) {
+	testCases := []struct {
+		field       string
+		value       string
+		expected    string
+		expectedErr string
+	}{
+		{"proxyLogLevel", "warn,linkerd2_proxy=debug", "warn,linkerd2_proxy=debug", ""},
+		{"proxyLogLevel", "debug; rm", "", "debug; rm is not a valid proxy log level"},
+		{"opaquePorts", "3306, 5432", "3306, 5432", ""},
+		{"skipOutboundCIDRs", "169.254.169.254/32, 10.0.0.0/8", "169.254.169.254/32,10.0.0.0/8", ""},
+		{"skipOutboundCIDRs", "169.254.169.254", "", "Invalid CIDR '169.254.169.254'"},
+		{"defaultInjectPolicy", "disabled", "disabled", ""},
+		{"defaultInjectPolicy", "off", "", "Invalid inject policy 'off'"},
+		{"clusterDomain", "cluster.local", "", "unknown field clusterDomain; must be one of: proxyLogLevel, opaquePorts, skipOutboundCIDRs, defaultInjectPolicy"},
+	}
+
+	for _, tc := range testCases {
+		spec := &sp.MeshConfigSpec{}
+		err := setMeshConfigField(spec, tc.field, tc.value)
+		if tc.expectedErr != "" {
+			if err == nil || err.Error() != tc.expectedErr {
+				t.Fatalf("Expected error [%s] setting %s, got [%v]", tc.expectedErr, tc.field, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error setting %s: %s", tc.field, err)
+		}
+		if value, _ := meshConfigField(spec, tc.field); value != tc.expected {
+			t.Fatalf("Expected %s to be [%s], got [%s]", tc.field, tc.expected, value)
+		}
+	}
+}
+
+func TestMeshConfigPatch(t *testing.T) {
+	meshConfig := newMeshConfig("linkerd")
+	meshConfig.ResourceVersion = "42"
+	meshConfig.Spec.ProxyLogLevel = "debug"
+	meshConfig.Spec.OpaquePorts = "3306"
+
+	patch, err := meshConfigPatch(&meshConfig, "proxyLogLevel", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	expected := `[{"op":"test","path":"/metadata/resourceVersion","value":"42"},{"op":"add","path":"/spec","value":{"opaquePorts":"3306"}}]`
+	if string(patch) != expected {
+		t.Fatalf("Expected patch:\n%s\nGot:\n%s", expected, patch)
+	}
+	if meshConfig.Spec.ProxyLogLevel != "debug" {
+		t.Fatalf("Expected the mesh config to be left unchanged")
+	}
+
+	var buf bytes.Buffer
+	renderMeshConfig(&meshConfig.Spec, &buf)
+	expectedOutput := `FIELD                 VALUE
+proxyLogLevel         debug
+opaquePorts           3306
+skipOutboundCIDRs     -
+defaultInjectPolicy   -
+`
+	if buf.String() != expectedOutput {
+		t.Fatalf("Expected:\n%s\nGot:\n%s", expectedOutput, buf.String())
+	}
+}
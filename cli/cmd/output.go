@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+const (
+	tableOutput      = "table"
+	jsonOutput       = "json"
+	jsonlOutput      = "jsonl"
+	jsonPathOutput   = "jsonpath"
+	goTemplateOutput = "go-template"
+	wideOutput       = "wide"
+	csvOutput        = "csv"
+	prometheusOutput = "prometheus"
+	yamlOutput       = "yaml"
+)
+
+// outputFormatHelp describes the output formats supported by the commands
+// that use formatOutput.
+const outputFormatHelp = "Output format. One of: table (default), json, yaml, jsonpath=TEMPLATE, go-template=TEMPLATE"
+
+// statOutputFormatHelp describes the output formats supported by stat.
+const statOutputFormatHelp = "Output format. One of: table (default), json, yaml, csv, prometheus, jsonpath=TEMPLATE, go-template=TEMPLATE"
+
+// parseOutputFormat splits an --output value into its kind and, for the
+// template-based formats, the template. For example "jsonpath={.name}"
+// returns "jsonpath" and "{.name}".
+func parseOutputFormat(format string) (string, string) {
+	if format == "" {
+		return tableOutput, ""
+	}
+
+	parts := strings.SplitN(format, "=", 2)
+	switch parts[0] {
+	case jsonPathOutput, goTemplateOutput:
+		if len(parts) == 2 {
+			return parts[0], parts[1]
+		}
+		return parts[0], ""
+	}
+
+	return format, ""
+}
+
+// isJSONOutput returns true if the format is rendered from JSON, i.e. json,
+// yaml or one of the template-based formats.
+func isJSONOutput(format string) bool {
+	switch kind, _ := parseOutputFormat(format); kind {
+	case jsonOutput, yamlOutput, jsonPathOutput, goTemplateOutput:
+		return true
+	}
+	return false
+}
+
+// validateTemplateOutput checks that template-based formats come with a
+// template that can be parsed.
+func validateTemplateOutput(format string) error {
+	kind, tmpl := parseOutputFormat(format)
+	switch kind {
+	case jsonPathOutput, goTemplateOutput:
+		if tmpl == "" {
+			return fmt.Errorf("--output %s requires a template, for example: %s='{...}'", kind, kind)
+		}
+		_, err := newOutputTemplate(kind, tmpl)
+		return err
+	}
+	return nil
+}
+
+// outputTemplate is implemented by both jsonpath and text/template templates.
+type outputTemplate interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+func newOutputTemplate(kind, tmpl string) (outputTemplate, error) {
+	switch kind {
+	case jsonPathOutput:
+		j := jsonpath.New("output")
+		if err := j.Parse(tmpl); err != nil {
+			return nil, fmt.Errorf("error parsing jsonpath %s: %s", tmpl, err)
+		}
+		return j, nil
+	case goTemplateOutput:
+		t, err := template.New("output").Parse(tmpl)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing go-template %s: %s", tmpl, err)
+		}
+		return t, nil
+	}
+	return nil, fmt.Errorf("unsupported output format: %s", kind)
+}
+
+// formatOutput renders JSON output according to the output format. JSON is
+// returned as is, YAML is converted from it, and template-based formats are
+// executed against the decoded JSON, so YAML and templates use the same field
+// names as -o json.
+func formatOutput(jsonBytes []byte, format string) (string, error) {
+	kind, tmpl := parseOutputFormat(format)
+	if kind == jsonOutput {
+		return string(jsonBytes), nil
+	}
+	if kind == yamlOutput {
+		out, err := yaml.JSONToYAML(jsonBytes)
+		if err != nil {
+			return "", err
+		}
+		return string(out), nil
+	}
+
+	t, err := newOutputTemplate(kind, tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(jsonBytes, &data); err != nil {
+		return "", err
+	}
+
+	var out bytes.Buffer
+	if err := t.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("error executing %s template: %s", kind, err)
+	}
+
+	return out.String(), nil
+}
+
+// writeCSV writes the records as an RFC 4180 CSV, after a header row. The
+// fields are named after the fields of the JSON output.
+func writeCSV(w io.Writer, header []string, records [][]string) error {
+	cw := csv.NewWriter(w)
+	cw.UseCRLF = true
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	return cw.WriteAll(records)
+}
+
+// csvFloat formats a float with as many digits as needed to represent it,
+// like the JSON output.
+func csvFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// promMetric is a gauge of the Prometheus exposition output.
+type promMetric struct {
+	name    string
+	help    string
+	samples []promSample
+}
+
+type promSample struct {
+	labels []promLabel
+	value  float64
+}
+
+type promLabel struct {
+	name  string
+	value string
+}
+
+// add adds a sample to the gauge.
+func (m *promMetric) add(labels []promLabel, value float64) {
+	m.samples = append(m.samples, promSample{labels, value})
+}
+
+// writePrometheus writes the gauges in the Prometheus text exposition format,
+// so that the stats can be pushed to a Pushgateway. The gauges without
+// samples are left out.
+func writePrometheus(w io.Writer, metrics []*promMetric) error {
+	for _, m := range metrics {
+		if len(m.samples) == 0 {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", m.name, m.help, m.name); err != nil {
+			return err
+		}
+		for _, sample := range m.samples {
+			labels := make([]string, len(sample.labels))
+			for i, label := range sample.labels {
+				labels[i] = fmt.Sprintf("%s=\"%s\"", label.name, promLabelValueEscaper.Replace(label.value))
+			}
+			if _, err := fmt.Fprintf(w, "%s{%s} %s\n", m.name, strings.Join(labels, ","), promFloat(sample.value)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+var promLabelValueEscaper = strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n")
+
+// promFloat formats a sample value as Prometheus does.
+func promFloat(f float64) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
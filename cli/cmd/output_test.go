@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"testing"
+)
+
+func TestParseOutputFormat(t *testing.T) {
+	expectations := []struct {
+		format string
+		kind   string
+		tmpl   string
+	}{
+		{"", tableOutput, ""},
+		{"table", tableOutput, ""},
+		{"json", jsonOutput, ""},
+		{"jsonpath={.name}", jsonPathOutput, "{.name}"},
+		{"go-template={{.name}}={{.meshed}}", goTemplateOutput, "{{.name}}={{.meshed}}"},
+		{"jsonpath", jsonPathOutput, ""},
+	}
+
+	for _, exp := range expectations {
+		kind, tmpl := parseOutputFormat(exp.format)
+		if kind != exp.kind || tmpl != exp.tmpl {
+			t.Fatalf("Expected [%s] to parse as (%s, %s), got (%s, %s)", exp.format, exp.kind, exp.tmpl, kind, tmpl)
+		}
+	}
+}
+
+func TestFormatOutput(t *testing.T) {
+	jsonBytes := []byte(`[{"name": "web", "success": 0.5}]`)
+
+	t.Run("Returns json as is", func(t *testing.T) {
+		out, err := formatOutput(jsonBytes, "json")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if out != string(jsonBytes) {
+			t.Fatalf("Expected [%s], got [%s]", jsonBytes, out)
+		}
+	})
+
+	t.Run("Executes jsonpath templates", func(t *testing.T) {
+		out, err := formatOutput(jsonBytes, "jsonpath={[0].success}")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if out != "0.5" {
+			t.Fatalf("Expected [0.5], got [%s]", out)
+		}
+	})
+
+	t.Run("Executes go templates", func(t *testing.T) {
+		out, err := formatOutput(jsonBytes, "go-template={{range .}}{{.name}}{{end}}")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if out != "web" {
+			t.Fatalf("Expected [web], got [%s]", out)
+		}
+	})
+
+	t.Run("Fails on missing templates", func(t *testing.T) {
+		if err := validateTemplateOutput("jsonpath"); err == nil {
+			t.Fatalf("Expected error for jsonpath without a template")
+		}
+	})
+}
@@ -7,8 +7,9 @@ import (
 	"io/ioutil"
 	"net/http"
 	"os"
-	"regexp"
 	"sort"
+	"strings"
+	"time"
 
 	"github.com/ghodss/yaml"
 	"github.com/go-openapi/spec"
@@ -26,21 +27,44 @@ type templateConfig struct {
 	ClusterZone           string
 }
 
-var pathParamRegex = regexp.MustCompile(`\\{[^\}]*\\}`)
+// serviceProfileOutput is a ServiceProfile as written by writeServiceProfile.
+// Its metadata only has the fields set by the command, as marshaling the
+// ObjectMeta of a ServiceProfile prints its zero creationTimestamp as null with
+// some versions of apimachinery.
+type serviceProfileOutput struct {
+	meta_v1.TypeMeta `json:",inline"`
+	Metadata         serviceProfileMetadata `json:"metadata"`
+	Spec             sp.ServiceProfileSpec  `json:"spec"`
+}
+
+type serviceProfileMetadata struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
 
 type profileOptions struct {
-	name      string
-	namespace string
-	template  bool
-	openAPI   string
+	name              string
+	namespace         string
+	external          string
+	template          bool
+	openAPI           string
+	tap               string
+	tapDuration       time.Duration
+	tapRouteLimit     uint
+	tapParamThreshold uint
 }
 
 func newProfileOptions() *profileOptions {
 	return &profileOptions{
-		name:      "",
-		namespace: "default",
-		template:  false,
-		openAPI:   "",
+		name:              "",
+		namespace:         "default",
+		external:          "",
+		template:          false,
+		openAPI:           "",
+		tap:               "",
+		tapDuration:       5 * time.Second,
+		tapRouteLimit:     20,
+		tapParamThreshold: 10,
 	}
 }
 
@@ -52,8 +76,31 @@ func (options *profileOptions) validate() error {
 	if options.openAPI != "" {
 		outputs++
 	}
+	if options.tap != "" {
+		outputs++
+	}
 	if outputs != 1 {
-		return errors.New("You must specify exactly one of --template or --open-api")
+		return errors.New("You must specify exactly one of --template, --open-api or --tap")
+	}
+
+	if options.tap != "" && options.tapDuration <= 0 {
+		return errors.New("--tap-duration must be positive")
+	}
+
+	return options.validateService()
+}
+
+// validateService checks the name and namespace of the service of the
+// profile, or its external authority.
+func (options *profileOptions) validateService() error {
+	if options.external != "" {
+		if options.name != "" {
+			return errors.New("You must specify either a service or --external, not both")
+		}
+		// the authority may be given as a fully qualified name, ending with a
+		// dot, e.g. api.stripe.com.
+		options.external = strings.ToLower(strings.TrimSuffix(options.external, "."))
+		return profiles.ValidateExternalAuthority(options.external)
 	}
 
 	// a DNS-1035 label must consist of lower case alphanumeric characters or '-',
@@ -76,7 +123,7 @@ func newCmdProfile() *cobra.Command {
 	options := newProfileOptions()
 
 	cmd := &cobra.Command{
-		Use:   "profile [flags] (--template | --open-api file) (SERVICE)",
+		Use:   "profile [flags] (--template | --open-api file | --tap resource) (SERVICE | --external authority)",
 		Short: "Output service profile config for Kubernetes",
 		Long: `Output service profile config for Kubernetes.
 
@@ -92,23 +139,57 @@ Example:
   kubectl apply -f web-svc-profile.yaml
 
 If the --open-api flag is specified, it reads the given OpenAPI
-specification file and outputs a corresponding service profile.
+specification file, either Swagger 2.0 or OpenAPI 3.x, and outputs a
+corresponding service profile.
+
+Example:
+  linkerd profile -n emojivoto --open-api web-svc.swagger web-svc | kubectl apply -f -
+
+If the --tap flag is specified, it taps the given resource for --tap-duration
+and outputs a service profile with a route for each of the most requested
+paths. The path segments which look like identifiers, e.g. numbers or UUIDs,
+or which take at least --tap-param-threshold values under the same parent
+path, are collapsed into parameters: e.g. /users/123 and /users/456 are both
+matched by the route of /users/{id}. The responses observed for each route
+become its response classes.
+
+Example:
+  linkerd profile -n emojivoto --tap deploy/web --tap-duration 10s web-svc > web-svc-profile.yaml
+
+If the --external flag is specified, it outputs a service profile for the given
+DNS name outside of the cluster instead of a service, e.g. a third-party API.
+The proxies look up the profiles of these authorities like the profiles of the
+services, unless they were injected with --disable-external-profiles, so that
+the stats of the outbound requests to the authority are reported by route, e.g.
+with "linkerd routes deploy/web --to authority/api.stripe.com". Without
+--open-api or --tap, the template is output; with --tap, the outbound requests
+of the given resource to the authority are tapped.
 
 Example:
-  linkerd profile -n emojivoto --open-api web-svc.swagger web-svc | kubectl apply -f -`,
-		Args: cobra.ExactArgs(1),
+  linkerd profile --external api.stripe.com > stripe-profile.yaml
+  linkerd profile --external api.stripe.com --tap deploy/web --tap-duration 30s > stripe-profile.yaml`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			options.name = args[0]
+			if len(args) == 1 {
+				options.name = args[0]
+			}
+			if options.external != "" && options.openAPI == "" && options.tap == "" {
+				options.template = true
+			}
 
 			err := options.validate()
 			if err != nil {
 				return err
 			}
 
-			if options.template {
+			if options.template && options.external != "" {
+				return profiles.RenderExternalProfileTemplate(options.external, controlPlaneNamespace, os.Stdout)
+			} else if options.template {
 				return profiles.RenderProfileTemplate(options.namespace, options.name, controlPlaneNamespace, os.Stdout)
 			} else if options.openAPI != "" {
 				return renderOpenAPI(options, os.Stdout)
+			} else if options.tap != "" {
+				return renderTapOutputProfile(validatedPublicAPIClient(time.Time{}), options, os.Stdout)
 			}
 
 			// we should never get here
@@ -118,7 +199,15 @@ Example:
 
 	cmd.PersistentFlags().BoolVar(&options.template, "template", options.template, "Output a service profile template")
 	cmd.PersistentFlags().StringVar(&options.openAPI, "open-api", options.openAPI, "Output a service profile based on the given OpenAPI spec file")
+	cmd.PersistentFlags().StringVar(&options.tap, "tap", options.tap, "Output a service profile based on the traffic of the given resource, e.g. deploy/web")
+	cmd.PersistentFlags().DurationVar(&options.tapDuration, "tap-duration", options.tapDuration, "Duration of the tap of the --tap resource")
+	cmd.PersistentFlags().UintVar(&options.tapRouteLimit, "tap-route-limit", options.tapRouteLimit, "Maximum number of routes of the profile generated with --tap, keeping the most requested ones")
+	cmd.PersistentFlags().UintVar(&options.tapParamThreshold, "tap-param-threshold", options.tapParamThreshold, "Number of values of a path segment under the same parent path from which --tap collapses it into a parameter; 0 only collapses the segments which look like identifiers")
+	cmd.PersistentFlags().StringVar(&options.external, "external", options.external, "Output a service profile for the given DNS name outside of the cluster, e.g. api.stripe.com, instead of a service")
 	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the service")
+	markFlagConfigurable(cmd.PersistentFlags(), "namespace", "namespace")
+
+	cmd.AddCommand(newCmdProfileDiff(options))
 
 	return cmd
 }
@@ -139,26 +228,58 @@ func renderOpenAPI(options *profileOptions, w io.Writer) error {
 	if err != nil {
 		return fmt.Errorf("Error reading file: %s", err)
 	}
-	json, err := yaml.YAMLToJSON(bytes)
+	specJSON, err := yaml.YAMLToJSON(bytes)
 	if err != nil {
 		return fmt.Errorf("Error parsing yaml: %s", err)
 	}
 
-	swagger := spec.Swagger{}
-	err = swagger.UnmarshalJSON(json)
+	var routes []*sp.RouteSpec
+	if isOpenAPI3(specJSON) {
+		routes, err = openAPI3Routes(specJSON)
+	} else {
+		routes, err = swaggerRoutes(specJSON)
+	}
 	if err != nil {
 		return fmt.Errorf("Error parsing OpenAPI spec: %s", err)
 	}
 
-	profile := sp.ServiceProfile{
-		ObjectMeta: meta_v1.ObjectMeta{
-			Name:      fmt.Sprintf("%s.%s.svc.cluster.local", options.name, options.namespace),
-			Namespace: controlPlaneNamespace,
-		},
+	return writeServiceProfile(options, routes, w)
+}
+
+// writeServiceProfile writes the service profile of the service of the
+// options, with the given routes.
+func writeServiceProfile(options *profileOptions, routes []*sp.RouteSpec, w io.Writer) error {
+	name := fmt.Sprintf("%s.%s.svc.cluster.local", options.name, options.namespace)
+	if options.external != "" {
+		name = options.external
+	}
+	profile := serviceProfileOutput{
 		TypeMeta: meta_v1.TypeMeta{
 			APIVersion: "linkerd.io/v1alpha1",
 			Kind:       "ServiceProfile",
 		},
+		Metadata: serviceProfileMetadata{
+			Name:      name,
+			Namespace: controlPlaneNamespace,
+		},
+	}
+
+	profile.Spec.Routes = routes
+	output, err := yaml.Marshal(profile)
+	if err != nil {
+		return fmt.Errorf("Error writing Service Profile: %s", err)
+	}
+	w.Write(output)
+
+	return nil
+}
+
+// swaggerRoutes returns the routes of a Swagger 2.0 specification.
+func swaggerRoutes(specJSON []byte) ([]*sp.RouteSpec, error) {
+	swagger := spec.Swagger{}
+	err := swagger.UnmarshalJSON(specJSON)
+	if err != nil {
+		return nil, err
 	}
 
 	routes := make([]*sp.RouteSpec, 0)
@@ -171,7 +292,7 @@ func renderOpenAPI(options *profileOptions, w io.Writer) error {
 
 	for _, path := range paths {
 		item := swagger.Paths.Paths[path]
-		pathRegex := pathToRegex(path)
+		pathRegex := profiles.PathToRegex(path)
 		if item.Delete != nil {
 			spec := mkRouteSpec(path, pathRegex, http.MethodDelete, item.Delete.Responses)
 			routes = append(routes, spec)
@@ -202,14 +323,7 @@ func renderOpenAPI(options *profileOptions, w io.Writer) error {
 		}
 	}
 
-	profile.Spec.Routes = routes
-	output, err := yaml.Marshal(profile)
-	if err != nil {
-		return fmt.Errorf("Error writing Service Profile: %s", err)
-	}
-	w.Write(output)
-
-	return nil
+	return routes, nil
 }
 
 func mkRouteSpec(path, pathRegex string, method string, responses *spec.Responses) *sp.RouteSpec {
@@ -220,11 +334,6 @@ func mkRouteSpec(path, pathRegex string, method string, responses *spec.Response
 	}
 }
 
-func pathToRegex(path string) string {
-	escaped := regexp.QuoteMeta(path)
-	return pathParamRegex.ReplaceAllLiteralString(escaped, "[^/]*")
-}
-
 func toReqMatch(path string, method string) *sp.RequestMatch {
 	return &sp.RequestMatch{
 		PathRegex: path,
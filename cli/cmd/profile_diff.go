@@ -0,0 +1,324 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/util"
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/profiles"
+	"github.com/spf13/cobra"
+)
+
+type profileDiffOptions struct {
+	*profileOptions
+	timeWindow string
+}
+
+func newCmdProfileDiff(profileOptions *profileOptions) *cobra.Command {
+	options := &profileDiffOptions{
+		profileOptions: profileOptions,
+		timeWindow:     "1h",
+	}
+
+	cmd := &cobra.Command{
+		Use:   "diff [flags] (SERVICE)",
+		Short: "Compare the service profile of a service with its traffic",
+		Long: `Compare the service profile of a service with its traffic.
+
+The routes of the service profile, including the routes inherited from its base
+profiles, are compared with the route stats of the service during
+"--time-window", to report the routes which weren't requested, and the share of
+the requests falling into the default route, i.e. matching no route.
+
+If the --tap flag is specified, the inbound requests of the given resource are
+also tapped for --tap-duration, to report the paths of the requests matching no
+route, with their path segments collapsed into parameters as with
+"linkerd profile --tap".`,
+		Example: `  # Report the routes of the web-svc service which weren't requested during
+  # the last day.
+  linkerd profile diff -n emojivoto -t 24h web-svc
+
+  # Also report the paths of the requests to the web deployment falling into the
+  # default route.
+  linkerd profile diff -n emojivoto --tap deploy/web --tap-duration 10s web-svc`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			options.name = args[0]
+
+			err := options.validate()
+			if err != nil {
+				return err
+			}
+
+			profile, err := getServiceProfile(options.name, options.namespace)
+			if err != nil {
+				return err
+			}
+
+			return renderProfileDiff(validatedPublicAPIClient(time.Time{}), profile, options, os.Stdout)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.timeWindow, "time-window", "t", options.timeWindow, "Stat window of the route stats (for example: \"10m\", \"1h\", \"24h\")")
+
+	return cmd
+}
+
+func (options *profileDiffOptions) validate() error {
+	if options.template || options.openAPI != "" || options.external != "" {
+		return errors.New("--template, --open-api and --external can't be used with profile diff")
+	}
+	if options.tap != "" && options.tapDuration <= 0 {
+		return errors.New("--tap-duration must be positive")
+	}
+
+	return options.validateService()
+}
+
+func serviceProfilePath(name string) string {
+	return fmt.Sprintf("/apis/linkerd.io/v1alpha1/namespaces/%s/serviceprofiles/%s", controlPlaneNamespace, name)
+}
+
+// getServiceProfile returns the service profile of the service, merged with
+// its base profiles.
+func getServiceProfile(service, namespace string) (*sp.ServiceProfile, error) {
+	kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup)
+	if err != nil {
+		return nil, err
+	}
+	client, err := kubeAPI.NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	getProfile := func(name string) (*sp.ServiceProfile, error) {
+		var profile sp.ServiceProfile
+		found, err := kubeAPI.GetObject(client, serviceProfilePath(name), &profile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ServiceProfile %s: %s", name, err)
+		}
+		if !found {
+			return nil, fmt.Errorf("no ServiceProfile %s found", name)
+		}
+		return &profile, nil
+	}
+
+	profile, err := getProfile(fmt.Sprintf("%s.%s.svc.cluster.local", service, namespace))
+	if err != nil {
+		return nil, err
+	}
+	merged, _, err := profiles.MergeBaseProfiles(profile, getProfile)
+	return merged, err
+}
+
+// renderProfileDiff writes the routes of the profile which weren't requested
+// during the time window, the share of the requests falling into the default
+// route and, with --tap, the paths of the tapped requests falling into the
+// default route.
+func renderProfileDiff(client pb.ApiClient, profile *sp.ServiceProfile, options *profileDiffOptions, w io.Writer) error {
+	req, err := util.BuildTopRoutesRequest(util.TopRoutesRequestParams{
+		StatsBaseRequestParams: util.StatsBaseRequestParams{
+			TimeWindow:   options.timeWindow,
+			Namespace:    options.namespace,
+			ResourceType: k8s.Service,
+			ResourceName: options.name,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	rows, err := requestRouteRows(client, req)
+	if err != nil {
+		return err
+	}
+
+	// the requests of each route, the default route being unnamed
+	requests := make(map[string]uint64)
+	total := uint64(0)
+	for _, row := range rows {
+		count := row.GetStats().GetSuccessCount() + row.GetStats().GetFailureCount()
+		requests[row.GetRoute()] += count
+		total += count
+	}
+
+	var defaultPaths []*profiles.TapRoute
+	if options.tap != "" {
+		defaultPaths, err = tapDefaultRoutePaths(client, profile.Spec.Routes, options)
+		if err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(w, "ServiceProfile %s, over the last %s:\n", profile.Name, options.timeWindow)
+
+	unused := make([]string, 0)
+	for _, route := range profile.Spec.Routes {
+		if requests[route.Name] == 0 {
+			unused = append(unused, route.Name)
+		}
+	}
+	fmt.Fprintln(w, "")
+	if len(unused) == 0 {
+		fmt.Fprintln(w, "All the routes were requested")
+	} else {
+		fmt.Fprintf(w, "Routes not requested (%d of %d):\n", len(unused), len(profile.Spec.Routes))
+		for _, route := range unused {
+			fmt.Fprintf(w, "  %s\n", route)
+		}
+	}
+
+	fmt.Fprintln(w, "")
+	if total == 0 {
+		fmt.Fprintln(w, "No requests observed")
+	} else {
+		fmt.Fprintf(w, "Requests falling into the default route: %d of %d (%.2f%%)\n",
+			requests[""], total, 100*float64(requests[""])/float64(total))
+	}
+
+	if options.tap == "" {
+		return nil
+	}
+	fmt.Fprintln(w, "")
+	if len(defaultPaths) == 0 {
+		fmt.Fprintf(w, "No tapped request of %s fell into the default route\n", options.tap)
+		return nil
+	}
+	fmt.Fprintf(w, "Paths of the tapped requests of %s falling into the default route:\n", options.tap)
+	tw := tabwriter.NewWriter(w, 0, 0, padding, ' ', 0)
+	for _, route := range defaultPaths {
+		fmt.Fprintf(tw, "  %s %s\t%d\n", route.Method, route.Path(), route.Requests)
+	}
+	tw.Flush()
+	return nil
+}
+
+// tapDefaultRoutePaths taps the inbound requests of the --tap resource, and
+// returns the paths of the requests matching none of the routes, collapsed
+// into parameterized paths, most requested first.
+func tapDefaultRoutePaths(client pb.ApiClient, routes []*sp.RouteSpec, options *profileDiffOptions) ([]*profiles.TapRoute, error) {
+	req, err := util.BuildTapByResourceRequest(util.TapRequestParams{
+		Resource:  options.tap,
+		Namespace: options.namespace,
+		MaxRps:    100,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(cliContext, options.tapDuration)
+	defer cancel()
+
+	rsp, err := client.TapByResource(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	tapped, err := profiles.CollectTapRoutes(&inboundTapClient{Api_TapByResourceClient: rsp})
+	if err != nil && ctx.Err() == nil {
+		return nil, err
+	}
+
+	matcher := newRouteMatcher(routes)
+	unmatched := make([]*profiles.TapRoute, 0)
+	for _, route := range tapped {
+		if !matcher.matchesAny(route.Method, route.Path()) {
+			unmatched = append(unmatched, route)
+		}
+	}
+
+	names, merged := profiles.MergeTapRoutes(unmatched, options.tapParamThreshold, options.tapRouteLimit)
+	paths := make([]*profiles.TapRoute, 0, len(names))
+	for _, name := range names {
+		paths = append(paths, merged[name])
+	}
+	return paths, nil
+}
+
+// inboundTapClient wraps a tap stream, dropping the events of the outbound
+// requests.
+type inboundTapClient struct {
+	pb.Api_TapByResourceClient
+}
+
+func (c *inboundTapClient) Recv() (*pb.TapEvent, error) {
+	for {
+		event, err := c.Api_TapByResourceClient.Recv()
+		if err != nil || event.GetProxyDirection() == pb.TapEvent_INBOUND {
+			return event, err
+		}
+	}
+}
+
+// routeMatcher matches requests with the conditions of routes, as the proxies
+// do: the path regexes match whole paths.
+type routeMatcher struct {
+	routes  []*sp.RouteSpec
+	regexes map[string]*regexp.Regexp
+}
+
+func newRouteMatcher(routes []*sp.RouteSpec) *routeMatcher {
+	return &routeMatcher{
+		routes:  routes,
+		regexes: make(map[string]*regexp.Regexp),
+	}
+}
+
+// matchesAny returns whether a request matches one of the routes.
+func (m *routeMatcher) matchesAny(method, path string) bool {
+	for _, route := range m.routes {
+		if m.matches(route.Condition, method, path) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *routeMatcher) matches(match *sp.RequestMatch, method, path string) bool {
+	if match == nil {
+		return false
+	}
+	if match.Method != "" && !strings.EqualFold(match.Method, method) {
+		return false
+	}
+	if match.PathRegex != "" {
+		regex, ok := m.regexes[match.PathRegex]
+		if !ok {
+			// invalid regexes match no path
+			regex, _ = regexp.Compile("^(?:" + match.PathRegex + ")$")
+			m.regexes[match.PathRegex] = regex
+		}
+		if regex == nil || !regex.MatchString(path) {
+			return false
+		}
+	}
+	if match.Not != nil && m.matches(match.Not, method, path) {
+		return false
+	}
+	for _, child := range match.All {
+		if !m.matches(child, method, path) {
+			return false
+		}
+	}
+	if len(match.Any) > 0 {
+		any := false
+		for _, child := range match.Any {
+			if m.matches(child, method, path) {
+				any = true
+				break
+			}
+		}
+		if !any {
+			return false
+		}
+	}
+	return true
+}
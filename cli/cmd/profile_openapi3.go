@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+)
+
+var (
+	// the path parameters of an OpenAPI 3 path template, e.g. {id}
+	openAPI3PathParamRegex = regexp.MustCompile(`\{([^}]*)\}`)
+	// the ranges of statuses of the responses of an OpenAPI 3 operation,
+	// e.g. 5XX
+	openAPI3StatusRangeRegex = regexp.MustCompile(`^[1-5][xX][xX]$`)
+)
+
+// openAPI3Document is the subset of an OpenAPI 3.x document describing the
+// routes of a service.
+type openAPI3Document struct {
+	OpenAPI    string                      `json:"openapi"`
+	Servers    []openAPI3Server            `json:"servers"`
+	Paths      map[string]openAPI3PathItem `json:"paths"`
+	Components struct {
+		Parameters map[string]openAPI3Parameter `json:"parameters"`
+	} `json:"components"`
+}
+
+type openAPI3Server struct {
+	URL       string `json:"url"`
+	Variables map[string]struct {
+		Default string `json:"default"`
+	} `json:"variables"`
+}
+
+type openAPI3PathItem struct {
+	Servers    []openAPI3Server    `json:"servers"`
+	Parameters []openAPI3Parameter `json:"parameters"`
+	Delete     *openAPI3Operation  `json:"delete"`
+	Get        *openAPI3Operation  `json:"get"`
+	Head       *openAPI3Operation  `json:"head"`
+	Options    *openAPI3Operation  `json:"options"`
+	Patch      *openAPI3Operation  `json:"patch"`
+	Post       *openAPI3Operation  `json:"post"`
+	Put        *openAPI3Operation  `json:"put"`
+	Trace      *openAPI3Operation  `json:"trace"`
+}
+
+type openAPI3Operation struct {
+	Servers    []openAPI3Server           `json:"servers"`
+	Parameters []openAPI3Parameter        `json:"parameters"`
+	Responses  map[string]json.RawMessage `json:"responses"`
+}
+
+type openAPI3Parameter struct {
+	Ref   string `json:"$ref"`
+	Name  string `json:"name"`
+	In    string `json:"in"`
+	Style string `json:"style"`
+}
+
+// isOpenAPI3 returns true if the specification is an OpenAPI 3.x document,
+// rather than a Swagger 2.0 one.
+func isOpenAPI3(specJSON []byte) bool {
+	var version struct {
+		OpenAPI string `json:"openapi"`
+	}
+	if err := json.Unmarshal(specJSON, &version); err != nil {
+		return false
+	}
+	return strings.HasPrefix(version.OpenAPI, "3.")
+}
+
+// openAPI3Routes returns the routes of an OpenAPI 3.x document. The paths of
+// the routes are prefixed with the path of the first server of their
+// operation, e.g. /v1 for https://api.example.com/v1.
+func openAPI3Routes(specJSON []byte) ([]*sp.RouteSpec, error) {
+	doc := openAPI3Document{}
+	err := json.Unmarshal(specJSON, &doc)
+	if err != nil {
+		return nil, err
+	}
+
+	routes := make([]*sp.RouteSpec, 0)
+
+	paths := make([]string, 0)
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		item := doc.Paths[path]
+		operations := []struct {
+			method    string
+			operation *openAPI3Operation
+		}{
+			{http.MethodDelete, item.Delete},
+			{http.MethodGet, item.Get},
+			{http.MethodHead, item.Head},
+			{http.MethodOptions, item.Options},
+			{http.MethodPatch, item.Patch},
+			{http.MethodPost, item.Post},
+			{http.MethodPut, item.Put},
+			{http.MethodTrace, item.Trace},
+		}
+		for _, op := range operations {
+			if op.operation == nil {
+				continue
+			}
+
+			servers := op.operation.Servers
+			if len(servers) == 0 {
+				servers = item.Servers
+			}
+			if len(servers) == 0 {
+				servers = doc.Servers
+			}
+			basePath, err := openAPI3BasePath(servers)
+			if err != nil {
+				return nil, err
+			}
+
+			params, err := doc.pathParameters(item.Parameters, op.operation.Parameters)
+			if err != nil {
+				return nil, err
+			}
+			pathRegex := regexp.QuoteMeta(basePath) + openAPI3PathToRegex(path, params)
+
+			routes = append(routes, &sp.RouteSpec{
+				Name:            fmt.Sprintf("%s %s", op.method, basePath+path),
+				Condition:       toReqMatch(pathRegex, op.method),
+				ResponseClasses: toOpenAPI3RspClasses(op.operation.Responses),
+			})
+		}
+	}
+
+	return routes, nil
+}
+
+// openAPI3BasePath returns the path of the URL of the first server, after
+// substituting its variables with their default values, or an empty string
+// if it's the root path.
+func openAPI3BasePath(servers []openAPI3Server) (string, error) {
+	if len(servers) == 0 {
+		return "", nil
+	}
+
+	server := servers[0]
+	rawURL := openAPI3PathParamRegex.ReplaceAllStringFunc(server.URL, func(variable string) string {
+		return server.Variables[variable[1:len(variable)-1]].Default
+	})
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid server URL [%s]: %s", server.URL, err)
+	}
+	return strings.TrimSuffix(u.Path, "/"), nil
+}
+
+// pathParameters returns the path parameters of an operation by name. The
+// parameters of the operation override the ones of its path.
+func (doc *openAPI3Document) pathParameters(pathParams, operationParams []openAPI3Parameter) (map[string]openAPI3Parameter, error) {
+	params := make(map[string]openAPI3Parameter)
+	all := make([]openAPI3Parameter, 0, len(pathParams)+len(operationParams))
+	all = append(all, pathParams...)
+	all = append(all, operationParams...)
+	for _, param := range all {
+		if param.Ref != "" {
+			name := strings.TrimPrefix(param.Ref, "#/components/parameters/")
+			ref, ok := doc.Components.Parameters[name]
+			if !ok || name == param.Ref {
+				return nil, fmt.Errorf("unresolved parameter reference [%s]", param.Ref)
+			}
+			param = ref
+		}
+		if param.In == "path" {
+			params[param.Name] = param
+		}
+	}
+	return params, nil
+}
+
+// openAPI3PathToRegex returns the regex of the requests to a path template,
+// depending on the style of its parameters: e.g. /users/{id} matches
+// /users/5 with the default simple style, /users/.5 with the label style and
+// /users/;id=5 with the matrix style.
+func openAPI3PathToRegex(path string, params map[string]openAPI3Parameter) string {
+	var regex strings.Builder
+	last := 0
+	for _, match := range openAPI3PathParamRegex.FindAllStringSubmatchIndex(path, -1) {
+		regex.WriteString(regexp.QuoteMeta(path[last:match[0]]))
+		name := path[match[2]:match[3]]
+		switch params[name].Style {
+		case "label":
+			regex.WriteString(`\.[^/]*`)
+		case "matrix":
+			regex.WriteString(";" + regexp.QuoteMeta(name) + "=[^/]*")
+		default:
+			regex.WriteString("[^/]*")
+		}
+		last = match[1]
+	}
+	regex.WriteString(regexp.QuoteMeta(path[last:]))
+	return regex.String()
+}
+
+// toOpenAPI3RspClasses returns the response classes of the statuses of the
+// responses of an operation, e.g. 404, or of their ranges, e.g. 5XX. The
+// default response doesn't describe any status.
+func toOpenAPI3RspClasses(responses map[string]json.RawMessage) []*sp.ResponseClass {
+	if responses == nil {
+		return nil
+	}
+	classes := make([]*sp.ResponseClass, 0)
+
+	ranges := make([]*sp.Range, 0)
+	for status := range responses {
+		if openAPI3StatusRangeRegex.MatchString(status) {
+			class := uint32(status[0]-'0') * 100
+			ranges = append(ranges, &sp.Range{Min: class, Max: class + 99})
+		} else if code, err := strconv.ParseUint(status, 10, 32); err == nil {
+			ranges = append(ranges, &sp.Range{Min: uint32(code), Max: uint32(code)})
+		}
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		if ranges[i].Min != ranges[j].Min {
+			return ranges[i].Min < ranges[j].Min
+		}
+		return ranges[i].Max < ranges[j].Max
+	})
+
+	for _, r := range ranges {
+		classes = append(classes, &sp.ResponseClass{
+			Condition: &sp.ResponseMatch{Status: r},
+			IsFailure: r.Min >= 500,
+		})
+	}
+	return classes
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/profiles"
+)
+
+func renderTapOutputProfile(client pb.ApiClient, options *profileOptions, w io.Writer) error {
+	req, err := util.BuildTapByResourceRequest(util.TapRequestParams{
+		Resource:  options.tap,
+		Namespace: options.namespace,
+		MaxRps:    100,
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(cliContext, options.tapDuration)
+	defer cancel()
+
+	rsp, err := client.TapByResource(ctx, req)
+	if err != nil {
+		return err
+	}
+	var tapClient pb.Api_TapByResourceClient = rsp
+	if options.external != "" {
+		tapClient = &outboundTapClient{Api_TapByResourceClient: rsp, host: options.external}
+	}
+	routes, err := profiles.CollectTapRoutes(tapClient)
+	if err != nil && ctx.Err() == nil {
+		return err
+	}
+
+	return writeServiceProfile(options, profiles.TapRouteSpecs(routes, options.tapParamThreshold, options.tapRouteLimit), w)
+}
+
+// outboundTapClient wraps a tap stream, only keeping the events of the
+// outbound requests to the host, on any port.
+type outboundTapClient struct {
+	pb.Api_TapByResourceClient
+	host string
+}
+
+func (c *outboundTapClient) Recv() (*pb.TapEvent, error) {
+	for {
+		event, err := c.Api_TapByResourceClient.Recv()
+		if err != nil {
+			return event, err
+		}
+		if event.GetProxyDirection() != pb.TapEvent_OUTBOUND {
+			continue
+		}
+		init := event.GetHttp().GetRequestInit()
+		if init != nil && !strings.EqualFold(strings.Split(init.GetAuthority(), ":")[0], c.host) {
+			continue
+		}
+		return event, nil
+	}
+}
@@ -7,7 +7,9 @@ import (
 	"testing"
 
 	"github.com/ghodss/yaml"
+	"github.com/linkerd/linkerd2/controller/api/public"
 	"github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/profiles"
 )
 
@@ -33,9 +35,32 @@ func TestParseProfile(t *testing.T) {
 	}
 }
 
+func TestParseExternalProfile(t *testing.T) {
+	var buf bytes.Buffer
+
+	err := profiles.RenderExternalProfileTemplate("api.stripe.com", "linkerd", &buf)
+	if err != nil {
+		t.Fatalf("Error rendering service profile template: %v", err)
+	}
+
+	var serviceProfile v1alpha1.ServiceProfile
+	err = yaml.Unmarshal(buf.Bytes(), &serviceProfile)
+	if err != nil {
+		t.Fatalf("Error parsing service profile: %v", err)
+	}
+
+	expectedServiceProfile := profiles.GenServiceProfile("mysvc", "myns", "linkerd")
+	expectedServiceProfile.Name = "api.stripe.com"
+
+	err = profiles.ServiceProfileYamlEquals(serviceProfile, expectedServiceProfile)
+	if err != nil {
+		t.Fatalf("ServiceProfiles are not equal: %v", err)
+	}
+}
+
 func TestValidateOptions(t *testing.T) {
 	options := newProfileOptions()
-	exp := errors.New("You must specify exactly one of --template or --open-api")
+	exp := errors.New("You must specify exactly one of --template, --open-api or --tap")
 	err := options.validate()
 	if err == nil || err.Error() != exp.Error() {
 		t.Fatalf("validateOptions returned unexpected error: %s (expected: %s) for options: %+v", err, exp, options)
@@ -44,7 +69,7 @@ func TestValidateOptions(t *testing.T) {
 	options = newProfileOptions()
 	options.template = true
 	options.openAPI = "openAPI"
-	exp = errors.New("You must specify exactly one of --template or --open-api")
+	exp = errors.New("You must specify exactly one of --template, --open-api or --tap")
 	err = options.validate()
 	if err == nil || err.Error() != exp.Error() {
 		t.Fatalf("validateOptions returned unexpected error: %s (expected: %s) for options: %+v", err, exp, options)
@@ -139,3 +164,230 @@ func TestValidateOptions(t *testing.T) {
 		t.Fatalf("validateOptions returned unexpected error: %s (expected: %s) for options: %+v", err, exp, options)
 	}
 }
+
+func TestValidateExternalOptions(t *testing.T) {
+	options := newProfileOptions()
+	options.template = true
+	options.external = "API.stripe.com."
+	err := options.validate()
+	if err != nil || options.external != "api.stripe.com" {
+		t.Fatalf("validateOptions returned unexpected error (%s) for options: %+v", err, options)
+	}
+
+	options = newProfileOptions()
+	options.template = true
+	options.name = "service-name"
+	options.external = "api.stripe.com"
+	exp := errors.New("You must specify either a service or --external, not both")
+	err = options.validate()
+	if err == nil || err.Error() != exp.Error() {
+		t.Fatalf("validateOptions returned unexpected error: %s (expected: %s) for options: %+v", err, exp, options)
+	}
+
+	options = newProfileOptions()
+	options.template = true
+	options.external = "web-svc.emojivoto.svc.cluster.local"
+	exp = errors.New("invalid external authority \"web-svc.emojivoto.svc.cluster.local\": the services of the cluster are named \"<service>.<namespace>.svc.cluster.local\"")
+	err = options.validate()
+	if err == nil || err.Error() != exp.Error() {
+		t.Fatalf("validateOptions returned unexpected error: %s (expected: %s) for options: %+v", err, exp, options)
+	}
+
+	options = newProfileOptions()
+	options.template = true
+	options.external = "localhost"
+	exp = errors.New("invalid external authority \"localhost\": must be a fully qualified DNS name, e.g. api.example.com")
+	err = options.validate()
+	if err == nil || err.Error() != exp.Error() {
+		t.Fatalf("validateOptions returned unexpected error: %s (expected: %s) for options: %+v", err, exp, options)
+	}
+}
+
+func TestRenderOpenAPI(t *testing.T) {
+	testCases := []struct {
+		spec   string
+		golden string
+	}{
+		{"testdata/profile_swagger.yaml", "profile_swagger_output.golden"},
+		{"testdata/profile_openapi3.yaml", "profile_openapi3_output.golden"},
+	}
+
+	for i, tc := range testCases {
+		tc := tc // pin
+		t.Run(fmt.Sprintf("%d: %s", i, tc.spec), func(t *testing.T) {
+			options := newProfileOptions()
+			options.name = "books"
+			options.namespace = "library"
+			options.openAPI = tc.spec
+
+			var buf bytes.Buffer
+			if err := renderOpenAPI(options, &buf); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			diffCompareFile(t, buf.String(), tc.golden)
+		})
+	}
+}
+
+func TestRenderTapOutputProfile(t *testing.T) {
+	requests := []struct {
+		method string
+		path   string
+		status uint32
+	}{
+		{"GET", "/users/123", 200},
+		{"GET", "/users/456?fields=name", 404},
+		{"GET", "/users/8a3c9c4e-6f7d-4a2b-9d1e-2f3b4c5d6e7f", 200},
+		{"DELETE", "/users/123", 503},
+		{"GET", "/books/alice/reviews", 200},
+		{"GET", "/books/bob/reviews", 200},
+		{"GET", "/books/carol/reviews", 200},
+		{"GET", "/api/list", 200},
+		{"GET", "/api/list", 200},
+		{"GET", "/api/vote", 500},
+		{"GET", "/api/vote", 200},
+		{"GET", "/healthz", 200},
+		{"GET", "/metrics", 200},
+		{"GET", "/ready", 200},
+	}
+
+	events := make([]pb.TapEvent, 0)
+	for i, r := range requests {
+		id := &pb.TapEvent_Http_StreamId{Base: 1, Stream: uint64(i)}
+		method := &pb.HttpMethod{Type: &pb.HttpMethod_Registered_{Registered: pb.HttpMethod_GET}}
+		if r.method == "DELETE" {
+			method.Type = &pb.HttpMethod_Registered_{Registered: pb.HttpMethod_DELETE}
+		}
+		events = append(events,
+			createEvent(&pb.TapEvent_Http{Event: &pb.TapEvent_Http_RequestInit_{RequestInit: &pb.TapEvent_Http_RequestInit{
+				Id:     id,
+				Method: method,
+				Path:   r.path,
+			}}}, map[string]string{}),
+			createEvent(&pb.TapEvent_Http{Event: &pb.TapEvent_Http_ResponseInit_{ResponseInit: &pb.TapEvent_Http_ResponseInit{
+				Id:         id,
+				HttpStatus: r.status,
+			}}}, map[string]string{}),
+		)
+	}
+
+	options := newProfileOptions()
+	options.name = "web-svc"
+	options.namespace = "emojivoto"
+	options.tap = "deploy/web"
+	options.tapParamThreshold = 3
+	options.tapRouteLimit = 4
+
+	client := &public.MockApiClient{
+		Api_TapByResourceClientToReturn: &public.MockApi_TapByResourceClient{TapEventsToReturn: events},
+	}
+	var buf bytes.Buffer
+	if err := renderTapOutputProfile(client, options, &buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	diffCompareFile(t, buf.String(), "profile_tap_output.golden")
+}
+
+func TestRenderTapOutputExternalProfile(t *testing.T) {
+	requests := []struct {
+		direction pb.TapEvent_ProxyDirection
+		authority string
+		path      string
+		status    uint32
+	}{
+		{pb.TapEvent_OUTBOUND, "api.stripe.com", "/v1/charges", 200},
+		{pb.TapEvent_OUTBOUND, "api.stripe.com:80", "/v1/customers/123", 200},
+		{pb.TapEvent_OUTBOUND, "API.Stripe.com", "/v1/customers/456", 404},
+		// the requests to other authorities, and the inbound requests, are
+		// left out
+		{pb.TapEvent_OUTBOUND, "emoji-svc.emojivoto.svc.cluster.local:8080", "/emojis", 200},
+		{pb.TapEvent_INBOUND, "api.stripe.com", "/api/list", 200},
+	}
+
+	events := make([]pb.TapEvent, 0)
+	for i, r := range requests {
+		id := &pb.TapEvent_Http_StreamId{Base: 1, Stream: uint64(i)}
+		for _, http := range []*pb.TapEvent_Http{
+			{Event: &pb.TapEvent_Http_RequestInit_{RequestInit: &pb.TapEvent_Http_RequestInit{
+				Id:        id,
+				Method:    &pb.HttpMethod{Type: &pb.HttpMethod_Registered_{Registered: pb.HttpMethod_GET}},
+				Authority: r.authority,
+				Path:      r.path,
+			}}},
+			{Event: &pb.TapEvent_Http_ResponseInit_{ResponseInit: &pb.TapEvent_Http_ResponseInit{
+				Id:         id,
+				HttpStatus: r.status,
+			}}},
+		} {
+			event := createEvent(http, map[string]string{})
+			event.ProxyDirection = r.direction
+			events = append(events, event)
+		}
+	}
+
+	options := newProfileOptions()
+	options.external = "api.stripe.com"
+	options.tap = "deploy/web"
+
+	client := &public.MockApiClient{
+		Api_TapByResourceClientToReturn: &public.MockApi_TapByResourceClient{TapEventsToReturn: events},
+	}
+	var buf bytes.Buffer
+	if err := renderTapOutputProfile(client, options, &buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	diffCompareFile(t, buf.String(), "profile_tap_external_output.golden")
+}
+
+func TestRenderProfileDiff(t *testing.T) {
+	profile := &v1alpha1.ServiceProfile{
+		Spec: v1alpha1.ServiceProfileSpec{
+			Routes: []*v1alpha1.RouteSpec{
+				{Name: "GET /api/list", Condition: &v1alpha1.RequestMatch{Method: "GET", PathRegex: "/api/list"}},
+				{Name: "GET /api/vote", Condition: &v1alpha1.RequestMatch{Method: "GET", PathRegex: "/api/vote"}},
+				{Name: "GET /api/leaderboard", Condition: &v1alpha1.RequestMatch{Method: "GET", PathRegex: "/api/leaderboard"}},
+			},
+		},
+	}
+	profile.Name = "web-svc.emojivoto.svc.cluster.local"
+
+	requests := []struct {
+		direction pb.TapEvent_ProxyDirection
+		path      string
+	}{
+		{pb.TapEvent_INBOUND, "/api/list"},
+		{pb.TapEvent_INBOUND, "/api/list?page=2"},
+		{pb.TapEvent_INBOUND, "/books/123"},
+		{pb.TapEvent_INBOUND, "/books/456"},
+		{pb.TapEvent_INBOUND, "/healthz"},
+		{pb.TapEvent_INBOUND, "/api/vote/extra"},
+		{pb.TapEvent_OUTBOUND, "/emoji"},
+	}
+	events := make([]pb.TapEvent, 0)
+	for i, r := range requests {
+		event := createEvent(&pb.TapEvent_Http{Event: &pb.TapEvent_Http_RequestInit_{RequestInit: &pb.TapEvent_Http_RequestInit{
+			Id:     &pb.TapEvent_Http_StreamId{Base: 1, Stream: uint64(i)},
+			Method: &pb.HttpMethod{Type: &pb.HttpMethod_Registered_{Registered: pb.HttpMethod_GET}},
+			Path:   r.path,
+		}}}, map[string]string{})
+		event.ProxyDirection = r.direction
+		events = append(events, event)
+	}
+
+	rows := public.GenTopRoutesResponse([]string{"GET /api/list", "GET /api/vote", ""}, []uint64{90, 60, 30})
+	client := &public.MockApiClient{
+		TopRoutesResponseToReturn:       &rows,
+		Api_TapByResourceClientToReturn: &public.MockApi_TapByResourceClient{TapEventsToReturn: events},
+	}
+
+	options := &profileDiffOptions{profileOptions: newProfileOptions(), timeWindow: "1h"}
+	options.name = "web-svc"
+	options.namespace = "emojivoto"
+	options.tap = "deploy/web"
+
+	var buf bytes.Buffer
+	if err := renderProfileDiff(client, profile, options, &buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	diffCompareFile(t, buf.String(), "profile_diff_output.golden")
+}
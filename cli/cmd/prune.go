@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	yamlDecoder "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+type pruneOptions struct {
+	yes bool
+	*installOptions
+}
+
+func newPruneOptions() *pruneOptions {
+	return &pruneOptions{
+		yes:            false,
+		installOptions: newInstallOptions(),
+	}
+}
+
+// prunableKind describes a kind of object that `linkerd install` renders, and
+// how to find the instances of it that belong to the control plane.
+type prunableKind struct {
+	kind string
+	// path returns the API path listing the objects of this kind in the
+	// control plane namespace, or cluster-wide for cluster-scoped kinds.
+	path       func(namespace string) string
+	namespaced bool
+}
+
+var prunableKinds = []prunableKind{
+	{kind: "Deployment", path: func(ns string) string { return "/apis/apps/v1/namespaces/" + ns + "/deployments" }, namespaced: true},
+	{kind: "Service", path: func(ns string) string { return "/api/v1/namespaces/" + ns + "/services" }, namespaced: true},
+	{kind: "ConfigMap", path: func(ns string) string { return "/api/v1/namespaces/" + ns + "/configmaps" }, namespaced: true},
+	{kind: "ServiceAccount", path: func(ns string) string { return "/api/v1/namespaces/" + ns + "/serviceaccounts" }, namespaced: true},
+	{kind: "Role", path: func(ns string) string { return "/apis/rbac.authorization.k8s.io/v1/namespaces/" + ns + "/roles" }, namespaced: true},
+	{kind: "RoleBinding", path: func(ns string) string { return "/apis/rbac.authorization.k8s.io/v1/namespaces/" + ns + "/rolebindings" }, namespaced: true},
+	{kind: "ClusterRole", path: func(string) string { return "/apis/rbac.authorization.k8s.io/v1/clusterroles" }},
+	{kind: "ClusterRoleBinding", path: func(string) string { return "/apis/rbac.authorization.k8s.io/v1/clusterrolebindings" }},
+}
+
+// prunedObject is an object in the cluster that is no longer rendered by
+// `linkerd install`.
+type prunedObject struct {
+	kind      prunableKind
+	namespace string
+	name      string
+}
+
+func (o prunedObject) String() string {
+	if o.namespace == "" {
+		return fmt.Sprintf("%s/%s", strings.ToLower(o.kind.kind), o.name)
+	}
+	return fmt.Sprintf("%s/%s (namespace %s)", strings.ToLower(o.kind.kind), o.name, o.namespace)
+}
+
+func (o prunedObject) path() string {
+	return fmt.Sprintf("%s/%s?propagationPolicy=Background", o.kind.path(o.namespace), o.name)
+}
+
+func newCmdPrune() *cobra.Command {
+	options := newPruneOptions()
+
+	cmd := &cobra.Command{
+		Use:   "prune [flags]",
+		Short: "Delete control plane resources left over from previous installs",
+		Long: `Delete control plane resources left over from previous installs.
+
+The prune command renders the control plane with the given install flags, and
+deletes the objects of the control plane in the cluster that are no longer part
+of it, such as renamed RBAC resources and removed components. Pass the same
+flags that were used with 'linkerd install'.
+
+Only the objects created by 'linkerd install' are considered, that is the ones
+carrying the control plane component label or the linkerd.io/created-by
+annotation. The resources are only listed unless --yes is passed.`,
+		Example: `  # List the resources that would be deleted
+  linkerd prune
+
+  # Delete the resources left over from a previous version
+  linkerd prune --yes`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config, err := validateAndBuildConfig(options.installOptions)
+			if err != nil {
+				return newCliError(exitCodeInvalidFlags, err)
+			}
+
+			var buf bytes.Buffer
+			if err := render(*config, &buf, options.installOptions); err != nil {
+				return err
+			}
+			manifests := buf.Bytes()
+			rendered, err := renderedObjects(bytes.NewReader(manifests))
+			if err != nil {
+				return err
+			}
+
+			kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup)
+			if err != nil {
+				return err
+			}
+			client, err := kubeAPI.NewClient()
+			if err != nil {
+				return err
+			}
+
+			inCluster := map[string][]metaV1.ObjectMeta{}
+			for _, kind := range prunableKinds {
+				objects, err := kubeAPI.ListObjectMeta(client, kind.path(controlPlaneNamespace))
+				if err != nil {
+					return fmt.Errorf("failed to list %s objects: %s", kind.kind, err)
+				}
+				inCluster[kind.kind] = objects
+			}
+
+			stale := staleObjects(rendered, inCluster, controlPlaneNamespace)
+			if len(stale) == 0 {
+				fmt.Println("No resources to prune")
+				return nil
+			}
+
+			if !options.yes {
+				for _, object := range stale {
+					fmt.Printf("%s would be deleted\n", object)
+				}
+				fmt.Println("\nRun 'linkerd prune' with --yes to delete these resources")
+				return nil
+			}
+
+			for _, object := range stale {
+				if err := kubeAPI.DeleteObject(client, object.path()); err != nil {
+					return fmt.Errorf("failed to delete %s: %s", object, err)
+				}
+				fmt.Printf("%s deleted\n", object)
+			}
+
+			event := newAuditEvent(cmd, args, manifestHash(manifests), time.Now())
+			recordAuditEvent(kubeAPI, client, event)
+			return nil
+		},
+	}
+
+	addInstallFlags(cmd, options.installOptions)
+	cmd.PersistentFlags().MarkHidden("ignore-cluster")
+	cmd.PersistentFlags().BoolVarP(&options.yes, "yes", "y", options.yes, "Delete the listed resources instead of only printing them")
+
+	return cmd
+}
+
+// renderedObjects returns the set of objects in the rendered manifests, keyed
+// by kind, namespace and name.
+func renderedObjects(manifests io.Reader) (map[string]bool, error) {
+	reader := yamlDecoder.NewYAMLReader(bufio.NewReaderSize(manifests, 4096))
+	objects := map[string]bool{}
+
+	for {
+		bytes, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var object struct {
+			Kind string `json:"kind"`
+			objMeta
+		}
+		if err := yaml.Unmarshal(bytes, &object); err != nil {
+			return nil, err
+		}
+		if object.Kind == "" {
+			continue
+		}
+		objects[objectKey(object.Kind, object.Namespace, object.Name)] = true
+	}
+
+	return objects, nil
+}
+
+func objectKey(kind, namespace, name string) string {
+	return strings.Join([]string{kind, namespace, name}, "/")
+}
+
+// staleObjects returns the control plane objects in the cluster that are not
+// in the rendered manifests. Objects are considered part of the control plane
+// if they carry the control plane component label or the created-by
+// annotation; cluster-scoped objects must also be named after the control
+// plane namespace.
+func staleObjects(rendered map[string]bool, inCluster map[string][]metaV1.ObjectMeta, namespace string) []prunedObject {
+	stale := []prunedObject{}
+
+	for _, kind := range prunableKinds {
+		for _, object := range inCluster[kind.kind] {
+			objectNamespace := ""
+			if kind.namespaced {
+				objectNamespace = namespace
+			}
+
+			if rendered[objectKey(kind.kind, objectNamespace, object.Name)] {
+				continue
+			}
+
+			if !createdByInstall(object) {
+				continue
+			}
+			if !kind.namespaced && !strings.HasPrefix(object.Name, fmt.Sprintf("linkerd-%s-", namespace)) {
+				continue
+			}
+
+			stale = append(stale, prunedObject{kind: kind, namespace: objectNamespace, name: object.Name})
+		}
+	}
+
+	return stale
+}
+
+// createdByInstall returns true if the object was rendered by `linkerd install`.
+// The objects of `linkerd install-cni` carry the same metadata, and are skipped
+// in case the plugin was installed in the control plane namespace.
+func createdByInstall(object metaV1.ObjectMeta) bool {
+	component, labeled := object.Labels[k8s.ControllerComponentLabel]
+	_, annotated := object.Annotations[k8s.CreatedByAnnotation]
+	return (labeled || annotated) && component != "cni"
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestStaleObjects(t *testing.T) {
+	manifests := `### Service Account Controller ###
+---
+kind: ServiceAccount
+apiVersion: v1
+metadata:
+  name: linkerd-controller
+  namespace: linkerd
+---
+kind: ClusterRole
+apiVersion: rbac.authorization.k8s.io/v1beta1
+metadata:
+  name: linkerd-linkerd-controller
+---
+kind: Deployment
+apiVersion: extensions/v1beta1
+metadata:
+  name: controller
+  namespace: linkerd
+`
+	rendered, err := renderedObjects(strings.NewReader(manifests))
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	component := map[string]string{k8s.ControllerComponentLabel: "controller"}
+	createdBy := map[string]string{k8s.CreatedByAnnotation: "linkerd/cli stable-2.2.1"}
+	inCluster := map[string][]metaV1.ObjectMeta{
+		"Deployment": {
+			{Name: "controller", Labels: component},
+			{Name: "ca", Labels: component},
+			{Name: "unrelated"},
+		},
+		"ConfigMap": {
+			{Name: k8s.TLSTrustAnchorConfigMapName},
+			{Name: "linkerd-cni-config", Labels: map[string]string{k8s.ControllerComponentLabel: "cni"}, Annotations: createdBy},
+		},
+		"ServiceAccount": {
+			{Name: "default"},
+			{Name: "linkerd-controller", Annotations: createdBy},
+			{Name: "linkerd-ca", Annotations: createdBy},
+			{Name: "ci-deployer"},
+		},
+		"RoleBinding": {
+			{Name: "ci-deployer"},
+		},
+		"ClusterRole": {
+			{Name: "linkerd-linkerd-controller", Annotations: createdBy},
+			{Name: "linkerd-linkerd-ca", Annotations: createdBy},
+			{Name: "linkerd-linkerd-custom"},
+			{Name: "linkerd-other-controller", Annotations: createdBy},
+			{Name: "cluster-admin"},
+		},
+	}
+
+	stale := staleObjects(rendered, inCluster, "linkerd")
+
+	expected := []string{
+		"deployment/ca (namespace linkerd)",
+		"serviceaccount/linkerd-ca (namespace linkerd)",
+		"clusterrole/linkerd-linkerd-ca",
+	}
+	if len(stale) != len(expected) {
+		t.Fatalf("Expected %d stale objects, got %d: %v", len(expected), len(stale), stale)
+	}
+	for i, object := range stale {
+		if object.String() != expected[i] {
+			t.Fatalf("Expected stale object %s, got %s", expected[i], object)
+		}
+	}
+
+	expectedPath := "/apis/apps/v1/namespaces/linkerd/deployments/ca?propagationPolicy=Background"
+	if stale[0].path() != expectedPath {
+		t.Fatalf("Expected path %s, got %s", expectedPath, stale[0].path())
+	}
+}
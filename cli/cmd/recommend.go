@@ -0,0 +1,314 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+)
+
+const (
+	patchOutput = "patch"
+
+	// proxyRequestHeadroom is the headroom of the recommended proxy requests
+	// over the usage of the busiest pod of a workload.
+	proxyRequestHeadroom = 1.2
+
+	// The recommended requests are never smaller than the requests of the
+	// proxies of a high availability control plane.
+	minProxyCPURequestMillis = 10
+	minProxyMemoryRequestMi  = 20
+
+	// The recommended limits leave room for traffic bursts, which the average
+	// CPU usage over the window doesn't reflect.
+	proxyCPULimitFactor    = 4
+	proxyMemoryLimitFactor = 2
+)
+
+// workloadLabels are the Prometheus labels of the proxy metrics that identify
+// the workload of a pod, by workload kind. Prometheus relabels the job label
+// to k8s_job, so as not to clash with its own.
+var workloadLabels = []struct {
+	kind  string
+	label string
+}{
+	{"deployment", "deployment"},
+	{"statefulset", "statefulset"},
+	{"daemonset", "daemonset"},
+	{"replicationcontroller", "replicationcontroller"},
+	{"cronjob", "cronjob"},
+	{"job", "k8s_job"},
+}
+
+type recommendOptions struct {
+	namespace    string
+	window       string
+	outputFormat string
+}
+
+func newRecommendOptions() *recommendOptions {
+	return &recommendOptions{
+		namespace:    "",
+		window:       "1h",
+		outputFormat: tableOutput,
+	}
+}
+
+// proxySizing is the observed proxy usage of a workload, and the proxy
+// resources recommended for it.
+type proxySizing struct {
+	Namespace   string            `json:"namespace"`
+	Workload    string            `json:"workload"`
+	Pods        int               `json:"pods"`
+	RequestRate float64           `json:"requestRate"`
+	CPU         float64           `json:"cpu"`
+	MemoryBytes float64           `json:"memoryBytes"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+func newCmdRecommend() *cobra.Command {
+	options := newRecommendOptions()
+
+	cmd := &cobra.Command{
+		Use:   "recommend [flags]",
+		Short: "Recommend proxy resource requests and limits for meshed workloads",
+		Long: `Recommend proxy resource requests and limits for meshed workloads.
+
+The recommend command reads the CPU and memory usage and the request rate of
+the proxies of each meshed workload from Prometheus, over the --window, and
+recommends the proxy resources of the workload, from the usage of its busiest
+pod:
+  * the requests leave 20% headroom over the observed usage
+  * the CPU limit is 4 times the CPU request, to absorb traffic bursts
+  * the memory limit is twice the memory request
+
+The recommendations are the values of the config.linkerd.io/proxy-* annotations
+to set on the pod templates of the workloads; -o patch prints the kubectl
+commands that set them.`,
+		Example: `  # Recommend proxy resources for the workloads of the "emojivoto" namespace
+  linkerd recommend -n emojivoto
+
+  # Recommend proxy resources from the usage of the last day, as kubectl commands
+  linkerd recommend --window 24h -o patch`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.outputFormat != tableOutput && options.outputFormat != jsonOutput && options.outputFormat != patchOutput {
+				return newCliError(exitCodeInvalidFlags, fmt.Errorf("--output supports %s, %s and %s", tableOutput, jsonOutput, patchOutput))
+			}
+			window, err := time.ParseDuration(options.window)
+			if err != nil || window < time.Minute {
+				return newCliError(exitCodeInvalidFlags, fmt.Errorf("--window must be a duration of at least 1m"))
+			}
+
+			kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup)
+			if err != nil {
+				return err
+			}
+			client, err := kubeAPI.NewClient()
+			if err != nil {
+				return err
+			}
+
+			cpuQuery, memoryQuery, requestRateQuery := proxyUsageQueries(options.namespace, window)
+			responses := []*k8s.PromQueryResponse{}
+			for _, query := range []string{cpuQuery, memoryQuery, requestRateQuery} {
+				rsp, err := kubeAPI.QueryPrometheus(client, controlPlaneNamespace, query)
+				if err != nil {
+					return fmt.Errorf("failed to query the proxy usage: %s", err)
+				}
+				responses = append(responses, rsp)
+			}
+
+			sizings := newProxySizings(responses[0], responses[1], responses[2])
+			return renderProxySizings(sizings, os.Stdout, options.outputFormat)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the workloads; all namespaces by default")
+	cmd.PersistentFlags().StringVar(&options.window, "window", options.window, "Time window of the observed proxy usage, e.g. 1h or 24h")
+	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, "Output format; one of: \"table\", \"json\" or \"patch\", which prints the kubectl commands that annotate the workloads")
+
+	return cmd
+}
+
+// proxyUsageQueries returns the Prometheus queries of the average CPU usage,
+// the peak memory usage and the request rate of every proxy over the window.
+func proxyUsageQueries(namespace string, window time.Duration) (string, string, string) {
+	selector := `job="linkerd-proxy"`
+	if namespace != "" {
+		selector += fmt.Sprintf(`, namespace="%s"`, namespace)
+	}
+	labels := []string{"namespace", "pod"}
+	for _, w := range workloadLabels {
+		labels = append(labels, w.label)
+	}
+	by := strings.Join(labels, ", ")
+	rangeSelector := fmt.Sprintf("%.0fs", window.Seconds())
+
+	return fmt.Sprintf("sum(rate(process_cpu_seconds_total{%s}[%s])) by (%s)", selector, rangeSelector, by),
+		fmt.Sprintf("max(max_over_time(process_resident_memory_bytes{%s}[%s])) by (%s)", selector, rangeSelector, by),
+		fmt.Sprintf("sum(rate(request_total{%s}[%s])) by (%s)", selector, rangeSelector, by)
+}
+
+// newProxySizings groups the per-pod proxy usage by workload, and recommends
+// the proxy resources of each workload.
+func newProxySizings(cpu, memory, requestRate *k8s.PromQueryResponse) []proxySizing {
+	type podUsage struct {
+		cpu, memory, requestRate float64
+	}
+	workloads := map[string]map[string]*podUsage{}
+	namespaces := map[string]string{}
+
+	add := func(rsp *k8s.PromQueryResponse, set func(*podUsage, float64)) {
+		for _, sample := range rsp.Data.Result {
+			v, ok := sample.Float()
+			if !ok || math.IsNaN(v) {
+				continue
+			}
+			namespace := sample.Metric["namespace"]
+			key := namespace + "/" + sampleWorkload(sample.Metric)
+			if workloads[key] == nil {
+				workloads[key] = map[string]*podUsage{}
+				namespaces[key] = namespace
+			}
+			pod := sample.Metric["pod"]
+			if workloads[key][pod] == nil {
+				workloads[key][pod] = &podUsage{}
+			}
+			set(workloads[key][pod], v)
+		}
+	}
+	add(cpu, func(u *podUsage, v float64) { u.cpu = v })
+	add(memory, func(u *podUsage, v float64) { u.memory = v })
+	add(requestRate, func(u *podUsage, v float64) { u.requestRate = v })
+
+	sizings := []proxySizing{}
+	for key, pods := range workloads {
+		namespace := namespaces[key]
+		sizing := proxySizing{
+			Namespace: namespace,
+			Workload:  strings.TrimPrefix(key, namespace+"/"),
+			Pods:      len(pods),
+		}
+		for _, usage := range pods {
+			sizing.RequestRate += usage.requestRate
+			sizing.CPU = math.Max(sizing.CPU, usage.cpu)
+			sizing.MemoryBytes = math.Max(sizing.MemoryBytes, usage.memory)
+		}
+		sizing.Annotations = recommendProxyResources(sizing.CPU, sizing.MemoryBytes)
+		sizings = append(sizings, sizing)
+	}
+
+	sort.Slice(sizings, func(i, j int) bool {
+		if sizings[i].Namespace != sizings[j].Namespace {
+			return sizings[i].Namespace < sizings[j].Namespace
+		}
+		return sizings[i].Workload < sizings[j].Workload
+	})
+	return sizings
+}
+
+// sampleWorkload returns the workload of the pod of a proxy metric, e.g.
+// deployment/web, or the pod itself if it has no workload.
+func sampleWorkload(metric map[string]string) string {
+	for _, w := range workloadLabels {
+		if name := metric[w.label]; name != "" {
+			return w.kind + "/" + name
+		}
+	}
+	return "pod/" + metric["pod"]
+}
+
+// recommendProxyResources returns the proxy resource annotations recommended
+// for the given CPU usage, in cores, and memory usage, in bytes.
+func recommendProxyResources(cpu, memoryBytes float64) map[string]string {
+	cpuRequest := int64(math.Ceil(cpu * 1000 * proxyRequestHeadroom))
+	if cpuRequest < minProxyCPURequestMillis {
+		cpuRequest = minProxyCPURequestMillis
+	}
+	memoryRequest := int64(math.Ceil(memoryBytes * proxyRequestHeadroom / (1 << 20)))
+	if memoryRequest < minProxyMemoryRequestMi {
+		memoryRequest = minProxyMemoryRequestMi
+	}
+
+	return map[string]string{
+		k8s.ProxyCPURequestAnnotation:    fmt.Sprintf("%dm", cpuRequest),
+		k8s.ProxyMemoryRequestAnnotation: fmt.Sprintf("%dMi", memoryRequest),
+		k8s.ProxyCPULimitAnnotation:      fmt.Sprintf("%dm", cpuRequest*proxyCPULimitFactor),
+		k8s.ProxyMemoryLimitAnnotation:   fmt.Sprintf("%dMi", memoryRequest*proxyMemoryLimitFactor),
+	}
+}
+
+func renderProxySizings(sizings []proxySizing, w io.Writer, outputFormat string) error {
+	switch outputFormat {
+	case jsonOutput:
+		out, err := json.MarshalIndent(sizings, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s\n", out)
+		return nil
+
+	case patchOutput:
+		for _, sizing := range sizings {
+			fmt.Fprintln(w, proxySizingPatch(sizing))
+		}
+		return nil
+	}
+
+	if len(sizings) == 0 {
+		fmt.Fprintln(w, "No proxy metrics found")
+		return nil
+	}
+
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, padding, ' ', 0)
+	fmt.Fprintln(tw, strings.Join([]string{"NAMESPACE", "WORKLOAD", "PODS", "RPS", "CPU", "MEMORY", "CPU_REQUEST", "MEMORY_REQUEST", "CPU_LIMIT", "MEMORY_LIMIT"}, "\t"))
+	for _, s := range sizings {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%.1f\t%.0fm\t%.0fMi\t%s\t%s\t%s\t%s\n",
+			s.Namespace, s.Workload, s.Pods, s.RequestRate, s.CPU*1000, s.MemoryBytes/(1<<20),
+			s.Annotations[k8s.ProxyCPURequestAnnotation], s.Annotations[k8s.ProxyMemoryRequestAnnotation],
+			s.Annotations[k8s.ProxyCPULimitAnnotation], s.Annotations[k8s.ProxyMemoryLimitAnnotation])
+	}
+	tw.Flush()
+
+	fmt.Fprint(w, buf.String())
+	return nil
+}
+
+// proxySizingPatch returns the kubectl command that sets the recommended
+// annotations on the pod template of the workload. Pods and jobs can't be
+// patched, so a comment lists the annotations to set instead.
+func proxySizingPatch(sizing proxySizing) string {
+	annotations := map[string]interface{}{"annotations": sizing.Annotations}
+	template := map[string]interface{}{"template": map[string]interface{}{"metadata": annotations}}
+
+	var patch map[string]interface{}
+	parts := strings.SplitN(sizing.Workload, "/", 2)
+	switch parts[0] {
+	case "deployment", "statefulset", "daemonset", "replicationcontroller":
+		patch = map[string]interface{}{"spec": template}
+	case "cronjob":
+		patch = map[string]interface{}{"spec": map[string]interface{}{"jobTemplate": map[string]interface{}{"spec": template}}}
+	default:
+		pairs := []string{}
+		for k, v := range sizing.Annotations {
+			pairs = append(pairs, k+"="+v)
+		}
+		sort.Strings(pairs)
+		return fmt.Sprintf("# %s in namespace %s: annotate its pod template with %s", sizing.Workload, sizing.Namespace, strings.Join(pairs, " "))
+	}
+
+	// json.Marshal sorts the map keys, which keeps the output stable
+	out, _ := json.Marshal(patch)
+	return fmt.Sprintf("kubectl -n %s patch %s %s -p '%s'", sizing.Namespace, parts[0], parts[1], out)
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestProxyUsageQueries(t *testing.T) {
+	cpu, memory, requestRate := proxyUsageQueries("emojivoto", 90*time.Minute)
+
+	by := "by (namespace, pod, deployment, statefulset, daemonset, replicationcontroller, cronjob, k8s_job)"
+	expected := []string{
+		`sum(rate(process_cpu_seconds_total{job="linkerd-proxy", namespace="emojivoto"}[5400s])) ` + by,
+		`max(max_over_time(process_resident_memory_bytes{job="linkerd-proxy", namespace="emojivoto"}[5400s])) ` + by,
+		`sum(rate(request_total{job="linkerd-proxy", namespace="emojivoto"}[5400s])) ` + by,
+	}
+	for i, query := range []string{cpu, memory, requestRate} {
+		if query != expected[i] {
+			t.Fatalf("Expected query [%s], got [%s]", expected[i], query)
+		}
+	}
+}
+
+func TestRenderProxySizings(t *testing.T) {
+	parse := func(samples string) *k8s.PromQueryResponse {
+		rsp := &k8s.PromQueryResponse{}
+		if err := json.Unmarshal([]byte(`{"status": "success", "data": {"resultType": "vector", "result": [`+samples+`]}}`), rsp); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		return rsp
+	}
+
+	cpu := parse(`
+		{"metric": {"namespace": "emojivoto", "pod": "web-6b7f9d5c4-jq2bk", "deployment": "web"}, "value": [1540000000, "0.0021"]},
+		{"metric": {"namespace": "emojivoto", "pod": "web-6b7f9d5c4-x8r2m", "deployment": "web"}, "value": [1540000000, "0.0035"]},
+		{"metric": {"namespace": "emojivoto", "pod": "voting-0", "statefulset": "voting"}, "value": [1540000000, "0.25"]},
+		{"metric": {"namespace": "batch", "pod": "report-1540000000-8z5xq", "k8s_job": "report-1540000000"}, "value": [1540000000, "0.001"]}`)
+	memory := parse(`
+		{"metric": {"namespace": "emojivoto", "pod": "web-6b7f9d5c4-jq2bk", "deployment": "web"}, "value": [1540000000, "9437184"]},
+		{"metric": {"namespace": "emojivoto", "pod": "web-6b7f9d5c4-x8r2m", "deployment": "web"}, "value": [1540000000, "12582912"]},
+		{"metric": {"namespace": "emojivoto", "pod": "voting-0", "statefulset": "voting"}, "value": [1540000000, "104857600"]},
+		{"metric": {"namespace": "batch", "pod": "report-1540000000-8z5xq", "k8s_job": "report-1540000000"}, "value": [1540000000, "NaN"]}`)
+	requestRate := parse(`
+		{"metric": {"namespace": "emojivoto", "pod": "web-6b7f9d5c4-jq2bk", "deployment": "web"}, "value": [1540000000, "3.5"]},
+		{"metric": {"namespace": "emojivoto", "pod": "web-6b7f9d5c4-x8r2m", "deployment": "web"}, "value": [1540000000, "4.25"]},
+		{"metric": {"namespace": "emojivoto", "pod": "voting-0", "statefulset": "voting"}, "value": [1540000000, "812"]}`)
+
+	sizings := newProxySizings(cpu, memory, requestRate)
+
+	t.Run("Renders the recommendations as a table", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := renderProxySizings(sizings, &buf, tableOutput); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		diffCompareFile(t, buf.String(), "recommend_output.golden")
+	})
+
+	t.Run("Renders the recommendations as kubectl commands", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := renderProxySizings(sizings, &buf, patchOutput); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		diffCompareFile(t, buf.String(), "recommend_patch_output.golden")
+	})
+
+	t.Run("Reports the absence of proxy metrics", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := renderProxySizings(newProxySizings(parse(""), parse(""), parse("")), &buf, tableOutput); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !strings.Contains(buf.String(), "No proxy metrics found") {
+			t.Fatalf("Expected no proxy metrics to be reported, got [%s]", buf.String())
+		}
+	})
+}
@@ -0,0 +1,308 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+	arV1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	"k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	serviceProfileCRDName    = "serviceprofiles.linkerd.io"
+	serviceProfileCRDVersion = "v1alpha1"
+	crdPath                  = "/apis/apiextensions.k8s.io/v1beta1/customresourcedefinitions"
+	mwcPath                  = "/apis/admissionregistration.k8s.io/v1beta1/mutatingwebhookconfigurations"
+)
+
+type repairOptions struct {
+	dryRun bool
+}
+
+func newRepairOptions() *repairOptions {
+	return &repairOptions{
+		dryRun: false,
+	}
+}
+
+// drift is an inconsistency in the control plane state, usually left behind by
+// an interrupted install or upgrade.
+type drift struct {
+	description string
+	// repair fixes the drift; it's nil when the drift can't be fixed
+	// automatically, in which case hint describes how to fix it.
+	repair func() error
+	hint   string
+}
+
+// crdVersions is the subset of a CustomResourceDefinition that determines the
+// versions it serves.
+type crdVersions struct {
+	Spec struct {
+		Version  string `json:"version"`
+		Versions []struct {
+			Name   string `json:"name"`
+			Served bool   `json:"served"`
+		} `json:"versions"`
+	} `json:"spec"`
+}
+
+func newCmdRepair() *cobra.Command {
+	options := newRepairOptions()
+
+	cmd := &cobra.Command{
+		Use:   "repair [flags]",
+		Short: "Detect and fix inconsistent control plane state",
+		Long: `Detect and fix inconsistent control plane state.
+
+The repair command looks for drift left behind by interrupted installs and
+upgrades, such as control plane components running different versions, a
+proxy injector webhook whose CA bundle doesn't match the trust anchors, or a
+missing ServiceProfile CRD version. It fixes the drift it can, and explains how
+to fix the rest.`,
+		Example: `  # List the drift that would be repaired
+  linkerd repair --dry-run
+
+  # Repair the control plane in the linkerd-test namespace
+  linkerd repair --linkerd-namespace linkerd-test`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup)
+			if err != nil {
+				return err
+			}
+			client, err := kubeAPI.NewClient()
+			if err != nil {
+				return err
+			}
+
+			drifts, err := detectDrift(kubeAPI, client, controlPlaneNamespace)
+			if err != nil {
+				return err
+			}
+			if len(drifts) == 0 {
+				fmt.Println("No drift found")
+				return nil
+			}
+
+			unrepaired, repaired := 0, 0
+			for _, d := range drifts {
+				switch {
+				case d.repair == nil:
+					unrepaired++
+					fmt.Printf("%s: %s\n", d.description, d.hint)
+				case options.dryRun:
+					fmt.Printf("%s: would be repaired (dry run)\n", d.description)
+				default:
+					if err := d.repair(); err != nil {
+						return fmt.Errorf("failed to repair drift: %s: %s", d.description, err)
+					}
+					fmt.Printf("%s: repaired\n", d.description)
+					repaired++
+				}
+			}
+
+			if repaired > 0 {
+				recordAuditEvent(kubeAPI, client, newAuditEvent(cmd, args, "", time.Now()))
+			}
+
+			if unrepaired > 0 {
+				return newCliError(exitCodeCheckFailed, fmt.Errorf("%d drift(s) must be repaired manually", unrepaired))
+			}
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().BoolVar(&options.dryRun, "dry-run", options.dryRun, "Only print the drift that would be repaired")
+
+	return cmd
+}
+
+// detectDrift reads the control plane state and returns the drift found in it.
+func detectDrift(kubeAPI *k8s.KubernetesAPI, client *http.Client, namespace string) ([]drift, error) {
+	drifts := []drift{}
+
+	deployments, err := kubeAPI.ListObjectMeta(client, "/apis/apps/v1/namespaces/"+namespace+"/deployments")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %s", err)
+	}
+	if d := versionDrift(deployments); d != nil {
+		drifts = append(drifts, *d)
+	}
+
+	var trustAnchors v1.ConfigMap
+	configMapPath := fmt.Sprintf("/api/v1/namespaces/%s/configmaps/%s", namespace, k8s.TLSTrustAnchorConfigMapName)
+	hasTrustAnchors, err := kubeAPI.GetObject(client, configMapPath, &trustAnchors)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the trust anchors: %s", err)
+	}
+
+	var mwc arV1beta1.MutatingWebhookConfiguration
+	webhookPath := mwcPath + "/" + k8s.ProxyInjectorWebhookConfig
+	hasWebhook, err := kubeAPI.GetObject(client, webhookPath, &mwc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the proxy injector webhook: %s", err)
+	}
+
+	if hasTrustAnchors && hasWebhook {
+		trustAnchor := trustAnchors.Data[k8s.TLSTrustAnchorFileName]
+		if patch := caBundlePatch(&mwc, trustAnchor); patch != nil {
+			drifts = append(drifts, drift{
+				description: fmt.Sprintf("the CA bundle of the %s webhook doesn't match the trust anchors", k8s.ProxyInjectorWebhookConfig),
+				repair:      func() error { return kubeAPI.PatchObject(client, webhookPath, patch) },
+			})
+		}
+	}
+
+	var crd crdVersions
+	hasCRD, err := kubeAPI.GetObject(client, crdPath+"/"+serviceProfileCRDName, &crd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the ServiceProfile CRD: %s", err)
+	}
+	if d := crdDrift(kubeAPI, client, hasCRD, &crd); d != nil {
+		drifts = append(drifts, *d)
+	}
+
+	return drifts, nil
+}
+
+// versionDrift returns the drift between the versions of the control plane
+// deployments, as recorded in their created-by annotation.
+func versionDrift(deployments []metaV1.ObjectMeta) *drift {
+	byVersion := map[string][]string{}
+	for _, deploy := range deployments {
+		if deploy.Labels[k8s.ControllerComponentLabel] == "" {
+			continue
+		}
+		createdBy := deploy.Annotations[k8s.CreatedByAnnotation]
+		if createdBy == "" {
+			createdBy = "unknown version"
+		}
+		byVersion[createdBy] = append(byVersion[createdBy], deploy.Name)
+	}
+	if len(byVersion) < 2 {
+		return nil
+	}
+
+	versions := []string{}
+	for version, names := range byVersion {
+		sort.Strings(names)
+		versions = append(versions, fmt.Sprintf("%s (%s)", version, strings.Join(names, ", ")))
+	}
+	sort.Strings(versions)
+
+	return &drift{
+		description: fmt.Sprintf("control plane components are on different versions: %s", strings.Join(versions, "; ")),
+		hint:        "upgrade all of them with 'linkerd install | kubectl apply -f -', passing the flags used to install",
+	}
+}
+
+// caBundlePatch returns the JSON patch setting the CA bundle of the webhooks
+// that don't match the trust anchor, or nil if all of them match.
+func caBundlePatch(mwc *arV1beta1.MutatingWebhookConfiguration, trustAnchor string) []byte {
+	type operation struct {
+		Op    string `json:"op"`
+		Path  string `json:"path"`
+		Value string `json:"value"`
+	}
+
+	ops := []operation{}
+	for i, webhook := range mwc.Webhooks {
+		if string(webhook.ClientConfig.CABundle) == trustAnchor {
+			continue
+		}
+		ops = append(ops, operation{
+			Op:    "replace",
+			Path:  fmt.Sprintf("/webhooks/%d/clientConfig/caBundle", i),
+			Value: base64.StdEncoding.EncodeToString([]byte(trustAnchor)),
+		})
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+
+	patch, _ := json.Marshal(ops)
+	return patch
+}
+
+// crdDrift returns the drift of the ServiceProfile CRD, which must exist and
+// serve the version used by this release.
+func crdDrift(kubeAPI *k8s.KubernetesAPI, client *http.Client, exists bool, crd *crdVersions) *drift {
+	if !exists {
+		return &drift{
+			description: fmt.Sprintf("the %s CRD is missing", serviceProfileCRDName),
+			repair:      func() error { return createServiceProfileCRD(kubeAPI, client) },
+		}
+	}
+
+	if servesVersion(crd, serviceProfileCRDVersion) {
+		return nil
+	}
+
+	d := &drift{
+		description: fmt.Sprintf("the %s CRD doesn't serve version %s", serviceProfileCRDName, serviceProfileCRDVersion),
+		hint:        "re-apply it with 'linkerd install | kubectl apply -f -', passing the flags used to install",
+	}
+	if len(crd.Spec.Versions) > 0 {
+		patch := []byte(fmt.Sprintf(`[{"op":"add","path":"/spec/versions/-","value":{"name":"%s","served":true,"storage":false}}]`, serviceProfileCRDVersion))
+		d.repair = func() error { return kubeAPI.PatchObject(client, crdPath+"/"+serviceProfileCRDName, patch) }
+	}
+	return d
+}
+
+func servesVersion(crd *crdVersions, version string) bool {
+	if len(crd.Spec.Versions) == 0 {
+		return crd.Spec.Version == version
+	}
+	for _, v := range crd.Spec.Versions {
+		if v.Name == version && v.Served {
+			return true
+		}
+	}
+	return false
+}
+
+// createServiceProfileCRD creates the ServiceProfile CRD as rendered by
+// `linkerd install`.
+func createServiceProfileCRD(kubeAPI *k8s.KubernetesAPI, client *http.Client) error {
+	options := newInstallOptions()
+	config, err := validateAndBuildConfig(options)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := render(*config, &buf, options); err != nil {
+		return err
+	}
+
+	for _, doc := range strings.Split(buf.String(), "\n---\n") {
+		var object struct {
+			Kind string `json:"kind"`
+		}
+		if err := yaml.Unmarshal([]byte(doc), &object); err != nil {
+			return err
+		}
+		if object.Kind != "CustomResourceDefinition" {
+			continue
+		}
+
+		crd, err := yaml.YAMLToJSON([]byte(doc))
+		if err != nil {
+			return err
+		}
+		return kubeAPI.CreateObject(client, crdPath, crd)
+	}
+
+	return fmt.Errorf("the %s CRD isn't rendered by 'linkerd install'", serviceProfileCRDName)
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	arV1beta1 "k8s.io/api/admissionregistration/v1beta1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestVersionDrift(t *testing.T) {
+	deploy := func(name, createdBy string) metaV1.ObjectMeta {
+		return metaV1.ObjectMeta{
+			Name:        name,
+			Labels:      map[string]string{k8s.ControllerComponentLabel: name},
+			Annotations: map[string]string{k8s.CreatedByAnnotation: createdBy},
+		}
+	}
+
+	t.Run("Returns nil if all components are on the same version", func(t *testing.T) {
+		deployments := []metaV1.ObjectMeta{
+			deploy("controller", "linkerd/cli edge-19.1.2"),
+			deploy("web", "linkerd/cli edge-19.1.2"),
+			{Name: "unrelated"},
+		}
+		if d := versionDrift(deployments); d != nil {
+			t.Fatalf("Unexpected drift: %s", d.description)
+		}
+	})
+
+	t.Run("Reports the components on each version", func(t *testing.T) {
+		deployments := []metaV1.ObjectMeta{
+			deploy("web", "linkerd/cli edge-19.1.2"),
+			deploy("controller", "linkerd/cli edge-19.1.1"),
+			deploy("prometheus", "linkerd/cli edge-19.1.2"),
+		}
+		d := versionDrift(deployments)
+		if d == nil {
+			t.Fatalf("Expected drift, got nil")
+		}
+
+		expected := "control plane components are on different versions: linkerd/cli edge-19.1.1 (controller); linkerd/cli edge-19.1.2 (prometheus, web)"
+		if d.description != expected {
+			t.Fatalf("Expected [%s], got [%s]", expected, d.description)
+		}
+		if d.repair != nil {
+			t.Fatalf("Expected version drift not to be repaired automatically")
+		}
+	})
+}
+
+func TestCABundlePatch(t *testing.T) {
+	trustAnchor := "-----BEGIN CERTIFICATE-----\nnew\n-----END CERTIFICATE-----\n"
+	mwc := &arV1beta1.MutatingWebhookConfiguration{
+		Webhooks: []arV1beta1.Webhook{
+			{ClientConfig: arV1beta1.WebhookClientConfig{CABundle: []byte(trustAnchor)}},
+			{ClientConfig: arV1beta1.WebhookClientConfig{CABundle: []byte("stale")}},
+		},
+	}
+
+	expected := fmt.Sprintf(`[{"op":"replace","path":"/webhooks/1/clientConfig/caBundle","value":"%s"}]`, base64.StdEncoding.EncodeToString([]byte(trustAnchor)))
+	if patch := caBundlePatch(mwc, trustAnchor); string(patch) != expected {
+		t.Fatalf("Expected patch %s, got %s", expected, patch)
+	}
+
+	mwc.Webhooks[1].ClientConfig.CABundle = []byte(trustAnchor)
+	if patch := caBundlePatch(mwc, trustAnchor); patch != nil {
+		t.Fatalf("Expected no patch, got %s", patch)
+	}
+}
+
+func TestServesVersion(t *testing.T) {
+	var crd crdVersions
+	crd.Spec.Version = "v1alpha1"
+	if !servesVersion(&crd, "v1alpha1") {
+		t.Fatalf("Expected the CRD to serve v1alpha1")
+	}
+
+	crd.Spec.Versions = append(crd.Spec.Versions, struct {
+		Name   string `json:"name"`
+		Served bool   `json:"served"`
+	}{Name: "v1alpha2", Served: true})
+	if servesVersion(&crd, "v1alpha1") {
+		t.Fatalf("Expected the CRD not to serve v1alpha1")
+	}
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os"
 	"regexp"
@@ -10,9 +11,12 @@ import (
 
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/version"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"k8s.io/api/core/v1"
 	k8sResource "k8s.io/apimachinery/pkg/api/resource"
 )
 
@@ -26,8 +30,11 @@ const (
 
 var controlPlaneNamespace string
 var apiAddr string // An empty value means "use the Kubernetes configuration"
+var apiVia = healthcheck.ViaAuto
 var kubeconfigPath string
 var kubeContext string
+var impersonate string
+var impersonateGroup []string
 var verbose bool
 
 var (
@@ -42,6 +49,8 @@ var RootCmd = &cobra.Command{
 	Use:   "linkerd",
 	Short: "linkerd manages the Linkerd service mesh",
 	Long:  `linkerd manages the Linkerd service mesh.`,
+
+	BashCompletionFunction: bashCompletionFunc,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
 		// enable / disable logging
 		if verbose {
@@ -50,13 +59,43 @@ var RootCmd = &cobra.Command{
 			log.SetLevel(log.PanicLevel)
 		}
 
-		controlPlaneNamespaceFromEnv := os.Getenv("LINKERD_NAMESPACE")
-		if controlPlaneNamespace == defaultNamespace && controlPlaneNamespaceFromEnv != "" {
-			controlPlaneNamespace = controlPlaneNamespaceFromEnv
+		// offline renders only depend on their flags, so that they're
+		// reproducible on any machine
+		if !ignoresCluster(cmd) {
+			config, err := readConfigFile(configFilePath())
+			if err != nil {
+				return err
+			}
+
+			if err := applyConfigDefaults(cmd, config); err != nil {
+				return newCliError(exitCodeInvalidFlags, err)
+			}
+		}
+
+		// report errors as JSON when JSON output is requested, without the usage
+		if output := cmd.Flags().Lookup("output"); output != nil && isJSONOutput(output.Value.String()) {
+			jsonErrors = true
+			cmd.SilenceUsage = true
+		}
+
+		if err := validateColorMode(colorMode); err != nil {
+			return newCliError(exitCodeInvalidFlags, err)
+		}
+
+		if err := validateAPIVia(apiVia); err != nil {
+			return newCliError(exitCodeInvalidFlags, err)
+		}
+
+		if err := validateImpersonation(impersonate, impersonateGroup); err != nil {
+			return newCliError(exitCodeInvalidFlags, err)
+		}
+
+		if apiTimeout < 0 || apiRetries < 0 || apiCacheTTL < 0 {
+			return newCliError(exitCodeInvalidFlags, errors.New("--api-timeout, --api-retries and --api-cache-ttl must not be negative"))
 		}
 
 		if !alphaNumDash.MatchString(controlPlaneNamespace) {
-			return fmt.Errorf("%s is not a valid namespace", controlPlaneNamespace)
+			return newCliError(exitCodeInvalidFlags, fmt.Errorf("%s is not a valid namespace", controlPlaneNamespace))
 		}
 
 		return nil
@@ -67,23 +106,74 @@ func init() {
 	RootCmd.PersistentFlags().StringVarP(&controlPlaneNamespace, "linkerd-namespace", "l", defaultNamespace, "Namespace in which Linkerd is installed [$LINKERD_NAMESPACE]")
 	RootCmd.PersistentFlags().StringVar(&kubeconfigPath, "kubeconfig", "", "Path to the kubeconfig file to use for CLI requests")
 	RootCmd.PersistentFlags().StringVar(&kubeContext, "context", "", "Name of the kubeconfig context to use")
-	RootCmd.PersistentFlags().StringVar(&apiAddr, "api-addr", "", "Override kubeconfig and communicate directly with the control plane at host:port (mostly for testing)")
+	RootCmd.PersistentFlags().StringVar(&impersonate, "as", "", "Username to impersonate for Kubernetes operations")
+	RootCmd.PersistentFlags().StringArrayVar(&impersonateGroup, "as-group", []string{}, "Group to impersonate for Kubernetes operations; this flag can be repeated to specify multiple groups")
+	RootCmd.PersistentFlags().StringVar(&apiAddr, "api-addr", "", "Override kubeconfig and communicate directly with the control plane at host:port (mostly for testing) [$LINKERD_API_ADDR]")
+	RootCmd.PersistentFlags().StringVar(&apiVia, "via", apiVia, "How to reach the Linkerd API: portforward, direct, or auto, which falls back to a port-forward to the controller pod when the API is unreachable through the Kubernetes API")
+	RootCmd.PersistentFlags().DurationVar(&apiTimeout, "api-timeout", apiTimeout, "Timeout for each request to the Linkerd API; 0 disables the timeout")
+	RootCmd.PersistentFlags().IntVar(&apiRetries, "api-retries", apiRetries, "Number of times to retry requests to the Linkerd API when the control plane is unreachable")
+	RootCmd.PersistentFlags().DurationVar(&apiCacheTTL, "api-cache-ttl", apiCacheTTL, "Reuse the responses of identical metrics requests to the Linkerd API for this long, for example to refresh the watch modes faster than the API is queried; 0 disables the cache")
 	RootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Turn on debug logging")
+	RootCmd.PersistentFlags().StringVar(&colorMode, "color", colorMode, "When to colorize output: never, auto or always; auto disables colors when $NO_COLOR is set or stdout is not a terminal")
+	markFlagConfigurable(RootCmd.PersistentFlags(), "linkerd-namespace", "linkerdNamespace")
+	markFlagConfigurable(RootCmd.PersistentFlags(), "api-addr", "apiAddr")
 
+	RootCmd.AddCommand(newCmdAlerts())
+	RootCmd.AddCommand(newCmdAudit())
+	RootCmd.AddCommand(newCmdBench())
 	RootCmd.AddCommand(newCmdCheck())
 	RootCmd.AddCommand(newCmdCompletion())
+	RootCmd.AddCommand(newCmdCompleteResources())
+	RootCmd.AddCommand(newCmdMeshConfig())
+	RootCmd.AddCommand(newCmdConsole())
 	RootCmd.AddCommand(newCmdDashboard())
+	RootCmd.AddCommand(newCmdDiff())
+	RootCmd.AddCommand(newCmdExport())
 	RootCmd.AddCommand(newCmdGet())
 	RootCmd.AddCommand(newCmdInject())
 	RootCmd.AddCommand(newCmdInstall())
+	RootCmd.AddCommand(newCmdInstallCNIPlugin())
 	RootCmd.AddCommand(newCmdProfile())
+	RootCmd.AddCommand(newCmdPrune())
+	RootCmd.AddCommand(newCmdRecommend())
+	RootCmd.AddCommand(newCmdRepair())
 	RootCmd.AddCommand(newCmdRoutes())
+	RootCmd.AddCommand(newCmdSLO())
+	RootCmd.AddCommand(newCmdSmoke())
 	RootCmd.AddCommand(newCmdStat())
 	RootCmd.AddCommand(newCmdTap())
 	RootCmd.AddCommand(newCmdTop())
+	RootCmd.AddCommand(newCmdVerifyInjection())
 	RootCmd.AddCommand(newCmdVersion())
 }
 
+func validateAPIVia(via string) error {
+	switch via {
+	case healthcheck.ViaAuto, healthcheck.ViaDirect, healthcheck.ViaPortForward:
+	default:
+		return fmt.Errorf("--via must be one of: %s, %s, %s", healthcheck.ViaAuto, healthcheck.ViaDirect, healthcheck.ViaPortForward)
+	}
+	if via == healthcheck.ViaPortForward && apiAddr != "" {
+		return errors.New("--via portforward is incompatible with --api-addr")
+	}
+	return nil
+}
+
+// validateImpersonation rejects --as-group without --as, like kubectl, as the
+// groups are only impersonated along with a user.
+func validateImpersonation(user string, groups []string) error {
+	if user == "" && len(groups) > 0 {
+		return errors.New("--as-group requires --as to be set")
+	}
+	return nil
+}
+
+// ignoresCluster returns true if the command was run with --ignore-cluster.
+func ignoresCluster(cmd *cobra.Command) bool {
+	flag := cmd.Flags().Lookup("ignore-cluster")
+	return flag != nil && flag.Value.String() == "true"
+}
+
 // validatedPublicAPIClient builds a new public API client and executes status
 // checks to determine if the client can successfully connect to the API. If the
 // checks fail, then CLI will print an error and exit. If the shouldRetry param
@@ -98,7 +188,10 @@ func validatedPublicAPIClient(retryDeadline time.Time) pb.ApiClient {
 		ControlPlaneNamespace: controlPlaneNamespace,
 		KubeConfig:            kubeconfigPath,
 		KubeContext:           kubeContext,
+		Impersonate:           impersonate,
+		ImpersonateGroup:      impersonateGroup,
 		APIAddr:               apiAddr,
+		APIVia:                apiVia,
 		RetryDeadline:         retryDeadline,
 	})
 
@@ -116,6 +209,18 @@ func validatedPublicAPIClient(retryDeadline time.Time) pb.ApiClient {
 			case healthcheck.LinkerdAPICategory:
 				msg = "Cannot connect to Linkerd"
 			}
+
+			// failures that aren't explicitly RBAC related mean that the control
+			// plane is unreachable
+			err := classifyError(result.Err)
+			if err.code == exitCodeError {
+				err.code = exitCodeUnreachable
+			}
+
+			if jsonErrors {
+				exitWithError(newCliError(err.code, fmt.Errorf("%s: %s", msg, result.Err)))
+			}
+
 			fmt.Fprintf(os.Stderr, "%s: %s\n", msg, result.Err)
 
 			checkCmd := "linkerd check"
@@ -124,18 +229,53 @@ func validatedPublicAPIClient(retryDeadline time.Time) pb.ApiClient {
 			}
 			fmt.Fprintf(os.Stderr, "Validate the install with: %s\n", checkCmd)
 
-			os.Exit(1)
+			os.Exit(err.code)
 		}
 	}
 
 	hc.RunChecks(exitOnError)
-	return hc.PublicAPIClient()
+	return newCachingAPIClient(newResilientAPIClient(hc.PublicAPIClient()), apiCacheTTL)
+}
+
+// publicAPIClientForContext builds a new public API client for the given
+// kubeconfig context. Unlike validatedPublicAPIClient, it returns an error
+// instead of exiting when the checks fail, so that commands reading from
+// several clusters can report which one failed.
+func publicAPIClientForContext(kubeContext string) (pb.ApiClient, error) {
+	checks := []healthcheck.Checks{
+		healthcheck.KubernetesAPIChecks,
+		healthcheck.LinkerdAPIChecks,
+	}
+
+	hc := healthcheck.NewHealthChecker(checks, &healthcheck.HealthCheckOptions{
+		ControlPlaneNamespace: controlPlaneNamespace,
+		KubeConfig:            kubeconfigPath,
+		KubeContext:           kubeContext,
+		Impersonate:           impersonate,
+		ImpersonateGroup:      impersonateGroup,
+		APIVia:                apiVia,
+	})
+
+	var err error
+	hc.RunChecks(func(result *healthcheck.CheckResult) {
+		if err == nil && result.Err != nil && !result.Warning && !result.Retry {
+			err = fmt.Errorf("%s: %s", result.Description, result.Err)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return newCachingAPIClient(newResilientAPIClient(hc.PublicAPIClient()), apiCacheTTL), nil
 }
 
 type statOptionsBase struct {
 	namespace    string
 	timeWindow   string
 	outputFormat string
+	// the past time the stats are queried at, with --at or --offset
+	at     string
+	offset time.Duration
 }
 
 func newStatOptionsBase() *statOptionsBase {
@@ -143,30 +283,69 @@ func newStatOptionsBase() *statOptionsBase {
 		namespace:    "default",
 		timeWindow:   "1m",
 		outputFormat: "",
+		at:           "",
+		offset:       0,
+	}
+}
+
+// endTime returns the end of the time window of the stats, set with --at or
+// --offset, or the zero time for the stats ending now.
+func (o *statOptionsBase) endTime(now time.Time) (time.Time, error) {
+	if o.at != "" && o.offset != 0 {
+		return time.Time{}, errors.New("--at and --offset flags are mutually exclusive")
+	}
+	if o.offset < 0 {
+		return time.Time{}, errors.New("--offset must not be negative")
+	}
+	if o.offset > 0 {
+		return now.Add(-o.offset), nil
+	}
+	if o.at == "" {
+		return time.Time{}, nil
 	}
+
+	// "2h-ago" reads better than "2h" on the command line
+	end, err := parseExportTime(strings.TrimSuffix(o.at, "-ago"), now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --at, expected an RFC3339 timestamp or a duration such as 2h-ago: %s", o.at)
+	}
+	if end.After(now) {
+		return time.Time{}, errors.New("--at must not be in the future")
+	}
+	return end, nil
+}
+
+// addEndTimeFlags adds the --at and --offset flags, querying the stats of a
+// past time window.
+func addEndTimeFlags(flags *pflag.FlagSet, o *statOptionsBase) {
+	flags.StringVar(&o.at, "at", o.at, "If present, queries the stats of the time window ending at this time, as an RFC3339 timestamp or a duration before now (for example: \"2h-ago\", \"2019-01-01T10:00:00Z\")")
+	flags.DurationVar(&o.offset, "offset", o.offset, "If present, queries the stats of the time window ending this long before now (for example: \"2h\")")
 }
 
 func (o *statOptionsBase) validateOutputFormat() error {
-	switch o.outputFormat {
-	case "table", "json", "":
+	switch kind, _ := parseOutputFormat(o.outputFormat); kind {
+	case tableOutput, jsonOutput, yamlOutput:
 		return nil
+	case jsonPathOutput, goTemplateOutput:
+		return validateTemplateOutput(o.outputFormat)
 	default:
-		return fmt.Errorf("--output currently only supports table and json")
+		return fmt.Errorf("--output currently only supports table, json, yaml, jsonpath and go-template")
 	}
 }
 
-func renderStats(buffer bytes.Buffer, options *statOptionsBase) string {
-	var out string
-	switch options.outputFormat {
-	case "table", "":
-		// strip left padding on the first column
-		out = string(buffer.Bytes()[padding:])
-		out = strings.Replace(out, "\n"+strings.Repeat(" ", padding), "\n", -1)
-	case "json":
-		out = string(buffer.Bytes())
+func renderStats(buffer bytes.Buffer, options *statOptionsBase) (string, error) {
+	if isJSONOutput(options.outputFormat) {
+		return formatOutput(buffer.Bytes(), options.outputFormat)
 	}
+	if options.outputFormat == csvOutput || options.outputFormat == prometheusOutput {
+		return buffer.String(), nil
+	}
+
+	// strip left padding on the first column
+	out := string(buffer.Bytes()[padding:])
+	out = strings.Replace(out, "\n"+strings.Repeat(" ", padding), "\n", -1)
 
-	return out
+	return out, nil
 }
 
 type proxyConfigOptions struct {
@@ -179,6 +358,7 @@ type proxyConfigOptions struct {
 	outboundPort            uint
 	ignoreInboundPorts      []uint
 	ignoreOutboundPorts     []uint
+	ignoreOutboundCIDRs     []string
 	proxyUID                int64
 	proxyLogLevel           string
 	proxyBindTimeout        string
@@ -187,9 +367,18 @@ type proxyConfigOptions struct {
 	proxyMetricsPort        uint
 	proxyCpuRequest         string
 	proxyMemoryRequest      string
+	proxyCpuLimit           string
+	proxyMemoryLimit        string
 	proxyOutboundCapacity   map[string]uint
 	tls                     string
 	disableExternalProfiles bool
+	ignoreCluster           bool
+	noInitContainer         bool
+	opaquePorts             string
+	verifyImagesKey         string
+	// verifiedImages maps the images whose signature was verified to the
+	// digest they were verified at, e.g. repo@sha256:...
+	verifiedImages map[string]string
 }
 
 const (
@@ -199,25 +388,29 @@ const (
 
 func newProxyConfigOptions() *proxyConfigOptions {
 	return &proxyConfigOptions{
-		linkerdVersion:        version.Version,
-		proxyImage:            defaultDockerRegistry + "/proxy",
-		initImage:             defaultDockerRegistry + "/proxy-init",
-		dockerRegistry:        defaultDockerRegistry,
-		imagePullPolicy:       "IfNotPresent",
-		inboundPort:           4143,
-		outboundPort:          4140,
-		ignoreInboundPorts:    nil,
-		ignoreOutboundPorts:   nil,
-		proxyUID:              2102,
-		proxyLogLevel:         "warn,linkerd2_proxy=info",
-		proxyBindTimeout:      "10s",
-		proxyAPIPort:          8086,
-		proxyControlPort:      4190,
-		proxyMetricsPort:      4191,
-		proxyOutboundCapacity: map[string]uint{},
-		proxyCpuRequest:       "",
-		proxyMemoryRequest:    "",
-		tls:                   "",
+		linkerdVersion:          version.Version,
+		proxyImage:              defaultDockerRegistry + "/proxy",
+		initImage:               defaultDockerRegistry + "/proxy-init",
+		dockerRegistry:          defaultDockerRegistry,
+		imagePullPolicy:         "IfNotPresent",
+		inboundPort:             4143,
+		outboundPort:            4140,
+		ignoreInboundPorts:      nil,
+		ignoreOutboundPorts:     nil,
+		ignoreOutboundCIDRs:     nil,
+		proxyUID:                2102,
+		proxyLogLevel:           "warn,linkerd2_proxy=info",
+		proxyBindTimeout:        "10s",
+		proxyAPIPort:            8086,
+		proxyControlPort:        4190,
+		proxyMetricsPort:        4191,
+		proxyOutboundCapacity:   map[string]uint{},
+		proxyCpuRequest:         "",
+		proxyMemoryRequest:      "",
+		proxyCpuLimit:           "",
+		proxyMemoryLimit:        "",
+		tls:                     "",
+		opaquePorts:             "",
 		disableExternalProfiles: false,
 	}
 }
@@ -251,25 +444,64 @@ func (options *proxyConfigOptions) validate() error {
 		}
 	}
 
+	if options.proxyCpuLimit != "" {
+		if _, err := k8sResource.ParseQuantity(options.proxyCpuLimit); err != nil {
+			return fmt.Errorf("Invalid cpu limit '%s' for --proxy-cpu-limit flag", options.proxyCpuLimit)
+		}
+	}
+
+	if options.proxyMemoryLimit != "" {
+		if _, err := k8sResource.ParseQuantity(options.proxyMemoryLimit); err != nil {
+			return fmt.Errorf("Invalid memory limit '%s' for --proxy-memory-limit flag", options.proxyMemoryLimit)
+		}
+	}
+
+	if _, err := options.proxyResources(nil); err != nil {
+		return err
+	}
+
 	if options.tls != "" && options.tls != optionalTLS {
 		return fmt.Errorf("--tls must be blank or set to \"%s\"", optionalTLS)
 	}
 
+	if err := k8s.ValidateCIDRs(options.ignoreOutboundCIDRs); err != nil {
+		return fmt.Errorf("%s for --skip-outbound-cidrs flag", err)
+	}
+
+	if err := k8s.ValidateOpaquePorts(options.opaquePorts); err != nil {
+		return fmt.Errorf("%s for --opaque-ports flag", err)
+	}
+
 	return nil
 }
 
+// proxyResources returns the resource requirements of the proxy container,
+// the annotations overriding those of the flags.
+func (options *proxyConfigOptions) proxyResources(annotations map[string]string) (v1.ResourceRequirements, error) {
+	return k8s.ProxyResources(annotations, options.proxyCpuRequest, options.proxyMemoryRequest, options.proxyCpuLimit, options.proxyMemoryLimit)
+}
+
 func (options *proxyConfigOptions) enableTLS() bool {
 	return options.tls == optionalTLS
 }
 
 func (options *proxyConfigOptions) taggedProxyImage() string {
 	image := strings.Replace(options.proxyImage, defaultDockerRegistry, options.dockerRegistry, 1)
-	return fmt.Sprintf("%s:%s", image, options.linkerdVersion)
+	return options.pinnedImage(fmt.Sprintf("%s:%s", image, options.linkerdVersion))
 }
 
 func (options *proxyConfigOptions) taggedProxyInitImage() string {
 	image := strings.Replace(options.initImage, defaultDockerRegistry, options.dockerRegistry, 1)
-	return fmt.Sprintf("%s:%s", image, options.linkerdVersion)
+	return options.pinnedImage(fmt.Sprintf("%s:%s", image, options.linkerdVersion))
+}
+
+// pinnedImage returns the image pinned to the digest its signature was
+// verified at, if it was verified.
+func (options *proxyConfigOptions) pinnedImage(image string) string {
+	if pinned, ok := options.verifiedImages[image]; ok {
+		return pinned
+	}
+	return image
 }
 
 func addProxyConfigFlags(cmd *cobra.Command, options *proxyConfigOptions) {
@@ -281,15 +513,21 @@ func addProxyConfigFlags(cmd *cobra.Command, options *proxyConfigOptions) {
 	cmd.PersistentFlags().Int64Var(&options.proxyUID, "proxy-uid", options.proxyUID, "Run the proxy under this user ID")
 	cmd.PersistentFlags().StringVar(&options.proxyLogLevel, "proxy-log-level", options.proxyLogLevel, "Log level for the proxy")
 	cmd.PersistentFlags().StringVar(&options.proxyBindTimeout, "proxy-bind-timeout", options.proxyBindTimeout, "Timeout the proxy will use")
+	cmd.PersistentFlags().StringVar(&options.opaquePorts, "opaque-ports", options.opaquePorts, "Inbound ports whose traffic the proxy forwards as opaque TCP without protocol detection, e.g. 3306,5432; merged with the ports of the "+k8s.ProxyOpaquePortsAnnotation+" annotation")
 	cmd.PersistentFlags().UintVar(&options.inboundPort, "inbound-port", options.inboundPort, "Proxy port to use for inbound traffic")
 	cmd.PersistentFlags().UintVar(&options.outboundPort, "outbound-port", options.outboundPort, "Proxy port to use for outbound traffic")
 	cmd.PersistentFlags().UintVar(&options.proxyAPIPort, "api-port", options.proxyAPIPort, "Port where the Linkerd controller is running")
 	cmd.PersistentFlags().UintVar(&options.proxyControlPort, "control-port", options.proxyControlPort, "Proxy port to use for control")
 	cmd.PersistentFlags().UintVar(&options.proxyMetricsPort, "metrics-port", options.proxyMetricsPort, "Proxy port to serve metrics on")
 	cmd.PersistentFlags().StringVar(&options.tls, "tls", options.tls, "Enable TLS; valid settings: \"optional\"")
-	cmd.PersistentFlags().StringVar(&options.proxyCpuRequest, "proxy-cpu", options.proxyCpuRequest, "Amount of CPU units that the proxy sidecar requests")
-	cmd.PersistentFlags().StringVar(&options.proxyMemoryRequest, "proxy-memory", options.proxyMemoryRequest, "Amount of Memory that the proxy sidecar requests")
+	cmd.PersistentFlags().StringVar(&options.proxyCpuRequest, "proxy-cpu", options.proxyCpuRequest, "Amount of CPU units that the proxy sidecar requests; overridden by the "+k8s.ProxyCPURequestAnnotation+" annotation")
+	cmd.PersistentFlags().StringVar(&options.proxyMemoryRequest, "proxy-memory", options.proxyMemoryRequest, "Amount of Memory that the proxy sidecar requests; overridden by the "+k8s.ProxyMemoryRequestAnnotation+" annotation")
+	cmd.PersistentFlags().StringVar(&options.proxyCpuLimit, "proxy-cpu-limit", options.proxyCpuLimit, "Maximum amount of CPU units that the proxy sidecar can use; overridden by the "+k8s.ProxyCPULimitAnnotation+" annotation")
+	cmd.PersistentFlags().StringVar(&options.proxyMemoryLimit, "proxy-memory-limit", options.proxyMemoryLimit, "Maximum amount of Memory that the proxy sidecar can use; overridden by the "+k8s.ProxyMemoryLimitAnnotation+" annotation")
 	cmd.PersistentFlags().UintSliceVar(&options.ignoreInboundPorts, "skip-inbound-ports", options.ignoreInboundPorts, "Ports that should skip the proxy and send directly to the application")
 	cmd.PersistentFlags().UintSliceVar(&options.ignoreOutboundPorts, "skip-outbound-ports", options.ignoreOutboundPorts, "Outbound ports that should skip the proxy")
+	cmd.PersistentFlags().StringSliceVar(&options.ignoreOutboundCIDRs, "skip-outbound-cidrs", options.ignoreOutboundCIDRs, "Outbound destination networks that should skip the proxy, e.g. 169.254.169.254/32; overridden by the "+k8s.ProxySkipOutboundCIDRsAnnotation+" annotation")
 	cmd.PersistentFlags().BoolVar(&options.disableExternalProfiles, "disable-external-profiles", options.disableExternalProfiles, "Disables service profiles for non-Kubernetes services")
+	cmd.PersistentFlags().BoolVar(&options.noInitContainer, "linkerd-cni-enabled", options.noInitContainer, "Experimental: Omit the proxy-init container when injecting the proxy; requires the linkerd-cni plugin to be installed (see 'linkerd install-cni')")
+	cmd.PersistentFlags().BoolVar(&options.ignoreCluster, "ignore-cluster", options.ignoreCluster, "Render offline: ignore the user config file and environment defaults, and produce deterministic output for the same flags")
 }
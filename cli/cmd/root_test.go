@@ -0,0 +1,25 @@
+package cmd
+
+import "testing"
+
+func TestValidateImpersonation(t *testing.T) {
+	testCases := []struct {
+		user   string
+		groups []string
+		valid  bool
+	}{
+		{"", nil, true},
+		{"jane", nil, true},
+		{"jane", []string{"dev", "ops"}, true},
+		{"", []string{"dev"}, false},
+	}
+	for _, tc := range testCases {
+		err := validateImpersonation(tc.user, tc.groups)
+		if tc.valid && err != nil {
+			t.Fatalf("Unexpected error for --as=%q --as-group=%v: %s", tc.user, tc.groups, err)
+		}
+		if !tc.valid && err == nil {
+			t.Fatalf("Expected error for --as=%q --as-group=%v, got nothing", tc.user, tc.groups)
+		}
+	}
+}
@@ -2,16 +2,19 @@ package cmd
 
 import (
 	"bytes"
-	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"os"
+	"regexp"
 	"sort"
 	"strings"
 	"text/tabwriter"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/linkerd/linkerd2/controller/api/util"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/k8s"
@@ -21,18 +24,89 @@ import (
 
 type routesOptions struct {
 	statOptionsBase
-	toResource   string
-	toNamespace  string
-	dstIsService bool
+	toResources   []string
+	toNamespace   string
+	allNamespaces bool
+	dstIsService  bool
+	watch         bool
+	watchInterval time.Duration
+	sortBy        string
+	reverse       bool
+	history       bool
+	historyPoints int
+	route         string
+	routeRegex    *regexp.Regexp
+	showHistogram bool
+
+	failIfSuccessBelow float64
+	failIfP99Above     time.Duration
+}
+
+const (
+	defaultRoute = "[UNKNOWN]"
+
+	routesOutputFormatHelp = "Output format. One of: table (default), wide, json, yaml, csv, prometheus, jsonpath=TEMPLATE, go-template=TEMPLATE"
+
+	// clearScreen moves the cursor to the top left corner of the terminal and
+	// clears it.
+	clearScreen = "\x1b[H\x1b[2J"
+)
+
+// routeSortKeys are the columns the routes can be sorted by, with the function
+// comparing two routes by the column.
+var routeSortKeys = map[string]func(a, b *rowStats) bool{
+	"route":   func(a, b *rowStats) bool { return a.route+a.dst < b.route+b.dst },
+	"rps":     func(a, b *rowStats) bool { return a.requestRate < b.requestRate },
+	"success": func(a, b *rowStats) bool { return a.successRate < b.successRate },
+	"p50":     func(a, b *rowStats) bool { return a.latencyP50 < b.latencyP50 },
+	"p95":     func(a, b *rowStats) bool { return a.latencyP95 < b.latencyP95 },
+	"p99":     func(a, b *rowStats) bool { return a.latencyP99 < b.latencyP99 },
+	"tls":     func(a, b *rowStats) bool { return a.tlsPercent < b.tlsPercent },
+}
+
+var routeSortKeyNames = []string{"route", "rps", "success", "p50", "p95", "p99", "tls"}
+
+// histogramColumns are the latency ranges of the "--show-histogram" columns,
+// by upper bound in milliseconds; the buckets of the proxies are merged into
+// the first range they fit in, and the last range has no upper bound.
+var histogramColumns = []struct {
+	header string
+	maxMs  uint64
+}{
+	{"<=10MS", 10},
+	{"<=100MS", 100},
+	{"<=1S", 1000},
+	{"<=10S", 10000},
+	{">10S", 0},
 }
 
-const defaultRoute = "[UNKNOWN]"
+// sparklineBlocks are the bars of the success rate sparklines, from the
+// lowest to the highest.
+var sparklineBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// minHistoryStep is the shortest sub-window of --history, as the rates of
+// shorter windows can't be computed from the 10s Prometheus scrapes.
+const minHistoryStep = 20 * time.Second
+
+var errNoRouteTraffic = errors.New("No traffic found.  Does the service have a service profile?  You can create one with the `linkerd profile` command.")
 
 func newRoutesOptions() *routesOptions {
 	return &routesOptions{
 		statOptionsBase: *newStatOptionsBase(),
-		toResource:      "",
+		toResources:     []string{},
 		toNamespace:     "",
+		allNamespaces:   false,
+		watch:           false,
+		watchInterval:   2 * time.Second,
+		sortBy:          "route",
+		reverse:         false,
+		history:         false,
+		historyPoints:   8,
+		route:           "",
+		showHistogram:   false,
+
+		failIfSuccessBelow: 0,
+		failIfP99Above:     0,
 	}
 }
 
@@ -40,150 +114,685 @@ func newCmdRoutes() *cobra.Command {
 	options := newRoutesOptions()
 
 	cmd := &cobra.Command{
-		Use:   "routes [flags] (RESOURCES)",
+		Use:   "routes [flags] (RESOURCE)",
 		Short: "Display route stats",
 		Long: `Display route stats.
 
-This command will only display traffic which is sent to a service that has a Service Profile defined.`,
+This command will only display traffic which is sent to a service that has a Service Profile defined.
+
+The RESOURCE argument can be a resource type without a name, such as deploy, to
+display the routes of every resource of that type, in the "--namespace"
+namespace or, with "--all-namespaces", in every namespace.`,
 		Example: `  # Routes for the webapp service in the test namespace.
   linkerd routes service/webapp -n test
 
+  # Routes of every deployment, grouped by namespace.
+  linkerd routes deploy -A
+
   # Routes for calls from from the traffic deployment to the webapp service in the test namespace.
-  linkerd routes deploy/traffic -n test --to svc/webapp`,
+  linkerd routes deploy/traffic -n test --to svc/webapp
+
+  # Also show the number of requests of the routes of the webapp service.
+  linkerd routes service/webapp -n test -o wide
+
+  # Compare the routes of the calls from the traffic deployment to the webapp and books services.
+  linkerd routes deploy/traffic -n test --to svc/webapp,svc/books
+
+  # Routes for calls from the traffic deployment to the pods of the books statefulset.
+  linkerd routes deploy/traffic -n test --to sts/books
+
+  # Show the routes of the webapp service under /api/books.
+  linkerd routes service/webapp -n test --route '^GET /api/books'
+
+  # Show the slowest routes of the webapp service first.
+  linkerd routes service/webapp -n test --sort-by p99 --reverse
+
+  # Show the share of the responses of the webapp service in each latency range,
+  # e.g. to tell apart the routes with a bimodal latency.
+  linkerd routes service/webapp -n test --show-histogram
+
+  # Show whether the success rate of the routes of the webapp service improved or
+  # degraded during the last 10 minutes.
+  linkerd routes service/webapp -n test -t 10m --history
+
+  # Fail if a route of the webapp service has a success rate below 99.5% or a
+  # p99 latency above 250ms, e.g. to gate a rollout in CI.
+  linkerd routes service/webapp -n test --fail-if-success-below 99.5 --fail-if-p99-above 250ms
+
+  # Routes for the webapp service during the 10 minutes ending at 10:00 UTC, e.g.
+  # to look back at an incident.
+  linkerd routes service/webapp -n test -t 10m --at 2019-01-01T10:00:00Z
+
+  # Redraw the routes of the webapp service every 5 seconds, until interrupted.
+  linkerd routes service/webapp -n test -w --watch-interval 5s`,
 		Args:      cobra.ExactArgs(1),
 		ValidArgs: util.ValidTargets,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			req, err := buildTopRoutesRequest(args[0], options)
+			reqs, err := buildTopRoutesRequests(args[0], options)
 			if err != nil {
-				return fmt.Errorf("error creating metrics request while making routes request: %v", err)
+				return newCliError(exitCodeInvalidFlags, fmt.Errorf("error creating metrics request while making routes request: %v", err))
 			}
 
-			output, err := requestRouteStatsFromAPI(validatedPublicAPIClient(time.Time{}), req, options)
-			if err != nil {
-				return err
+			client := validatedPublicAPIClient(time.Time{})
+			if options.watch {
+				return watchRouteStats(client, reqs, options, os.Stdout)
 			}
 
-			_, err = fmt.Print(output)
+			output, err := requestRouteStatsFromAPI(client, reqs, options)
+			if err == errNoRouteTraffic {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(exitCodeNoData)
+			}
+
+			// the stats are printed even if they violate the thresholds
+			fmt.Print(output)
 
 			return err
 		},
 	}
 
-	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the specified resource")
-	cmd.PersistentFlags().StringVarP(&options.timeWindow, "time-window", "t", options.timeWindow, "Stat window (for example: \"10s\", \"1m\", \"10m\", \"1h\")")
-	cmd.PersistentFlags().StringVar(&options.toResource, "to", options.toResource, "If present, shows outbound stats to the specified resource")
-	cmd.PersistentFlags().StringVar(&options.toNamespace, "to-namespace", options.toNamespace, "Sets the namespace used to lookup the \"--to\" resource; by default the current \"--namespace\" is used")
-	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, "Output format; currently only \"table\" (default) and \"json\" are supported")
+	cmd.Flags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the specified resource")
+	cmd.Flags().StringVarP(&options.timeWindow, "time-window", "t", options.timeWindow, "Stat window (for example: \"10s\", \"1m\", \"10m\", \"1h\")")
+	cmd.Flags().BoolVarP(&options.allNamespaces, "all-namespaces", "A", options.allNamespaces, "If present, shows the routes of the resources of every namespace, ignoring the \"--namespace\" flag; the resource can't be named")
+	cmd.Flags().StringSliceVar(&options.toResources, "to", options.toResources, "If present, shows outbound stats to the specified resources; repeat the flag or separate the resources with commas to compare several destinations")
+	cmd.Flags().StringVar(&options.toNamespace, "to-namespace", options.toNamespace, "Sets the namespace used to lookup the \"--to\" resources; by default the current \"--namespace\" is used")
+	cmd.Flags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, routesOutputFormatHelp)
+	cmd.Flags().StringVar(&options.route, "route", options.route, "If present, only shows the routes whose name matches this regular expression")
+	cmd.Flags().StringVar(&options.sortBy, "sort-by", options.sortBy, fmt.Sprintf("Column to sort the routes by, in ascending order; one of: %s", strings.Join(routeSortKeyNames, ", ")))
+	cmd.Flags().BoolVar(&options.reverse, "reverse", options.reverse, "Sort the routes in descending order")
+	cmd.Flags().BoolVar(&options.showHistogram, "show-histogram", options.showHistogram, "Also query the latency histogram of the routes, displayed as the share of the responses in each latency range, or as the count of the responses in each bucket with the json and yaml output formats")
+	cmd.Flags().BoolVar(&options.history, "history", options.history, "Also query the stats of sub-windows of the time window, and display the trend of the success rate of each route")
+	cmd.Flags().IntVar(&options.historyPoints, "history-points", options.historyPoints, "Number of sub-windows the time window is split into by \"--history\"")
+	cmd.Flags().Float64Var(&options.failIfSuccessBelow, "fail-if-success-below", options.failIfSuccessBelow, "Exit with status 7 if the success rate of a route is below this percentage, e.g. 99.5")
+	cmd.Flags().DurationVar(&options.failIfP99Above, "fail-if-p99-above", options.failIfP99Above, "Exit with status 7 if the p99 latency of a route is above this duration, e.g. 250ms")
+	cmd.Flags().BoolVarP(&options.watch, "watch", "w", options.watch, "After printing the route stats, keep querying them and redraw the table in place")
+	cmd.Flags().DurationVar(&options.watchInterval, "watch-interval", options.watchInterval, "Interval between the queries of \"--watch\"")
+	addEndTimeFlags(cmd.Flags(), &options.statOptionsBase)
+	markStatFlagsConfigurable(cmd.Flags())
+
+	cmd.AddCommand(newCmdRoutesDiff())
 
 	return cmd
 }
 
-func requestRouteStatsFromAPI(client pb.ApiClient, req *pb.TopRoutesRequest, options *routesOptions) (string, error) {
-	resp, err := client.TopRoutes(context.Background(), req)
+// requestRouteStatsFromAPI returns the rendered route stats. If the stats
+// violate the "--fail-if" thresholds, they're returned along with the error
+// listing the violations, so that they can still be printed.
+func requestRouteStatsFromAPI(client pb.ApiClient, reqs []*pb.TopRoutesRequest, options *routesOptions) (string, error) {
+	rows, err := requestRoutesFromAPI(client, reqs)
+	if err != nil {
+		return "", err
+	}
+
+	var history map[routeKey][]float64
+	if options.history {
+		// the sub-windows end at the end of the time window, which is in the
+		// past with --at and --offset
+		end := time.Now()
+		if endTime := reqs[0].GetEndTime(); endTime != 0 {
+			end = time.Unix(endTime, 0)
+		}
+		history, err = requestRouteHistory(client, reqs, options, end)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	output, err := renderRouteStats(rows, history, options)
 	if err != nil {
-		return "", fmt.Errorf("TopRoutes API error: %v", err)
+		return "", err
+	}
+	return output, checkRouteThresholds(rows, options)
+}
+
+// checkRouteThresholds returns an error listing the routes whose success rate
+// or p99 latency violate the "--fail-if" thresholds. The routes without
+// requests don't violate any threshold.
+func checkRouteThresholds(rows []*pb.RouteTable_Row, options *routesOptions) error {
+	if options.failIfSuccessBelow <= 0 && options.failIfP99Above <= 0 {
+		return nil
+	}
+
+	violations := []string{}
+	for _, row := range rows {
+		stats := row.GetStats()
+		route := routeName(row)
+		if stats.GetSuccessCount()+stats.GetFailureCount() == 0 || !options.matchesRoute(route) {
+			continue
+		}
+
+		name := fmt.Sprintf("%s to %s", route, row.GetAuthority())
+		if row.GetResource() != nil {
+			name = fmt.Sprintf("%s of %s/%s", name, row.GetResource().GetNamespace(), row.GetResource().GetName())
+		}
+		if successRate := util.GetSuccessRate(stats) * 100; options.failIfSuccessBelow > 0 && successRate < options.failIfSuccessBelow {
+			violations = append(violations, fmt.Sprintf("%s: success rate %.2f%% is below %.2f%%", name, successRate, options.failIfSuccessBelow))
+		}
+		if p99 := time.Duration(stats.GetLatencyMsP99()) * time.Millisecond; options.failIfP99Above > 0 && p99 > options.failIfP99Above {
+			violations = append(violations, fmt.Sprintf("%s: p99 latency %s is above %s", name, p99, options.failIfP99Above))
+		}
 	}
-	if e := resp.GetError(); e != nil {
-		return "", fmt.Errorf("TopRoutes API response error: %v", e.Error)
+	if len(violations) == 0 {
+		return nil
 	}
 
-	return renderRouteStats(resp, options), nil
+	sort.Strings(violations)
+	return newCliError(exitCodeThresholdViolated, fmt.Errorf("the route stats violate the thresholds:\n  %s", strings.Join(violations, "\n  ")))
 }
 
-func renderRouteStats(resp *pb.TopRoutesResponse, options *routesOptions) string {
+// routeName returns the name of the route of row, or defaultRoute for the
+// requests that didn't match any route.
+func routeName(row *pb.RouteTable_Row) string {
+	if row.GetRoute() == "" {
+		return defaultRoute
+	}
+	return row.GetRoute()
+}
+
+// matchesRoute returns whether the route is displayed, per "--route".
+func (o *routesOptions) matchesRoute(route string) bool {
+	return o.routeRegex == nil || o.routeRegex.MatchString(route)
+}
+
+// requestRoutesFromAPI runs the requests concurrently, and returns the rows of
+// all the responses. The rows are labeled with their destination authority, so
+// the rows of different destinations don't need to be told apart.
+func requestRoutesFromAPI(client pb.ApiClient, reqs []*pb.TopRoutesRequest) ([]*pb.RouteTable_Row, error) {
+	type routesResult struct {
+		rows []*pb.RouteTable_Row
+		err  error
+	}
+
+	c := make(chan routesResult, len(reqs))
+	for _, req := range reqs {
+		go func(req *pb.TopRoutesRequest) {
+			rows, err := requestRouteRows(client, req)
+			c <- routesResult{rows, err}
+		}(req)
+	}
+
+	rows := make([]*pb.RouteTable_Row, 0)
+	for range reqs {
+		res := <-c
+		if res.err != nil {
+			return nil, res.err
+		}
+		rows = append(rows, res.rows...)
+	}
+
+	return rows, nil
+}
+
+// routeKey identifies the stats of a route to a destination, of a resource
+// when the routes of several resources are displayed.
+type routeKey struct {
+	namespace string
+	name      string
+	route     string
+	dst       string
+}
+
+func routeKeyOf(row *pb.RouteTable_Row) routeKey {
+	return routeKey{
+		namespace: row.GetResource().GetNamespace(),
+		name:      row.GetResource().GetName(),
+		route:     row.GetRoute(),
+		dst:       row.GetAuthority(),
+	}
+}
+
+// requestRouteHistory splits the time window of the requests into
+// historyPoints sub-windows ending at now, and returns the success rate of
+// every route in each of them, oldest first. The success rate is NaN in the
+// sub-windows without traffic.
+func requestRouteHistory(client pb.ApiClient, reqs []*pb.TopRoutesRequest, options *routesOptions, now time.Time) (map[routeKey][]float64, error) {
+	window, err := time.ParseDuration(options.timeWindow)
+	if err != nil {
+		return nil, err
+	}
+	step := window / time.Duration(options.historyPoints)
+
+	type pointResult struct {
+		point int
+		rows  []*pb.RouteTable_Row
+		err   error
+	}
+
+	c := make(chan pointResult, options.historyPoints)
+	for point := 0; point < options.historyPoints; point++ {
+		end := now.Add(-time.Duration(options.historyPoints-1-point) * step)
+		pointReqs := make([]*pb.TopRoutesRequest, len(reqs))
+		for i, req := range reqs {
+			pointReq := proto.Clone(req).(*pb.TopRoutesRequest)
+			pointReq.TimeWindow = fmt.Sprintf("%ds", int64(step.Seconds()))
+			pointReq.EndTime = end.Unix()
+			pointReqs[i] = pointReq
+		}
+
+		go func(point int, pointReqs []*pb.TopRoutesRequest) {
+			rows, err := requestRoutesFromAPI(client, pointReqs)
+			c <- pointResult{point, rows, err}
+		}(point, pointReqs)
+	}
+
+	history := make(map[routeKey][]float64)
+	for i := 0; i < options.historyPoints; i++ {
+		res := <-c
+		if res.err != nil {
+			return nil, res.err
+		}
+		for _, row := range res.rows {
+			key := routeKeyOf(row)
+			if history[key] == nil {
+				history[key] = make([]float64, options.historyPoints)
+				for point := range history[key] {
+					history[key][point] = math.NaN()
+				}
+			}
+			if stats := row.GetStats(); stats.GetSuccessCount()+stats.GetFailureCount() > 0 {
+				history[key][res.point] = util.GetSuccessRate(stats)
+			}
+		}
+	}
+
+	return history, nil
+}
+
+// successTrend renders the success rates as a sparkline scaled between their
+// lowest and highest values, followed by an arrow telling whether the last
+// success rate is higher or lower than the first one. The sub-windows without
+// traffic are left blank.
+func successTrend(history []float64) string {
+	low, high := math.Inf(1), math.Inf(-1)
+	first, last := math.NaN(), math.NaN()
+	for _, rate := range history {
+		if math.IsNaN(rate) {
+			continue
+		}
+		low, high = math.Min(low, rate), math.Max(high, rate)
+		if math.IsNaN(first) {
+			first = rate
+		}
+		last = rate
+	}
+	if math.IsNaN(first) {
+		return "-"
+	}
+
+	sparkline := make([]rune, len(history))
+	for i, rate := range history {
+		switch {
+		case math.IsNaN(rate):
+			sparkline[i] = ' '
+		case high == low:
+			sparkline[i] = sparklineBlocks[len(sparklineBlocks)/2]
+		default:
+			sparkline[i] = sparklineBlocks[int((rate-low)/(high-low)*float64(len(sparklineBlocks)-1)+0.5)]
+		}
+	}
+
+	arrow := "→"
+	if last-first > 0.0005 {
+		arrow = "↑"
+	} else if first-last > 0.0005 {
+		arrow = "↓"
+	}
+	return string(sparkline) + " " + arrow
+}
+
+// addColumn adds a string column, such as SUCCESS_TREND, to the end of the
+// headers and row template of a route table.
+func addColumn(headers []string, templateString string, header string) ([]string, string) {
+	headers[len(headers)-1] = strings.TrimSuffix(headers[len(headers)-1], "\t")
+	return append(headers, header+"\t"), strings.TrimSuffix(templateString, "\n") + "%s\t\n"
+}
+
+// histogramShares returns the shares of the responses of the histogram in
+// each of the histogramColumns, or "-" for the routes without responses.
+func histogramShares(histogram []*pb.RouteTable_LatencyBucket) []interface{} {
+	counts := make([]uint64, len(histogramColumns))
+	total := uint64(0)
+	for _, bucket := range histogram {
+		for i, column := range histogramColumns {
+			if column.maxMs == 0 || bucket.GetMaxMs() != 0 && bucket.GetMaxMs() <= column.maxMs {
+				counts[i] += bucket.GetCount()
+				break
+			}
+		}
+		total += bucket.GetCount()
+	}
+
+	shares := make([]interface{}, len(histogramColumns))
+	for i, count := range counts {
+		shares[i] = "-"
+		if total > 0 {
+			shares[i] = fmt.Sprintf("%.f%%", float64(count)/float64(total)*100)
+		}
+	}
+	return shares
+}
+
+// addHistogramColumns adds the "--show-histogram" columns to the end of the
+// headers and row template of a route table.
+func addHistogramColumns(headers []string, templateString string) ([]string, string) {
+	for _, column := range histogramColumns {
+		headers, templateString = addColumn(headers, templateString, column.header)
+	}
+	return headers, templateString
+}
+
+// hasGrpcStatus returns whether a route has gRPC responses, in which case the
+// route tables break the responses down by gRPC status.
+func hasGrpcStatus(stats []*rowStats) bool {
+	for _, row := range stats {
+		if len(row.grpcStatus) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// watchRouteStats redraws the route stats every watchInterval, until the
+// command is interrupted.
+func watchRouteStats(client pb.ApiClient, reqs []*pb.TopRoutesRequest, options *routesOptions, w io.Writer) error {
+	ticker := time.NewTicker(options.watchInterval)
+	defer ticker.Stop()
+
+	for {
+		output, err := requestRouteStatsFromAPI(client, reqs, options)
+		if e, ok := err.(*cliError); err == errNoRouteTraffic || ok && e.code == exitCodeNoData {
+			output, err = fmt.Sprintln(err), nil
+		}
+		if err != nil {
+			if cliContext.Err() != nil {
+				return nil
+			}
+			return err
+		}
+
+		fmt.Fprintf(w, "%sEvery %s, last updated %s\n\n%s", clearScreen, options.watchInterval, time.Now().Format("15:04:05"), output)
+
+		select {
+		case <-ticker.C:
+		case <-cliContext.Done():
+			return nil
+		}
+	}
+}
+
+func renderRouteStats(rows []*pb.RouteTable_Row, history map[routeKey][]float64, options *routesOptions) (string, error) {
 	var buffer bytes.Buffer
 	w := tabwriter.NewWriter(&buffer, 0, 0, padding, ' ', tabwriter.AlignRight)
-	writeRouteStatsToBuffer(resp, w, options)
+	if err := writeRouteStatsToBuffer(rows, history, w, options); err != nil {
+		return "", err
+	}
 	w.Flush()
 
 	return renderStats(buffer, &options.statOptionsBase)
 }
 
-func writeRouteStatsToBuffer(resp *pb.TopRoutesResponse, w *tabwriter.Writer, options *routesOptions) {
+// writeRouteStatsToBuffer writes the route stats, along with the trends of
+// their success rates if history isn't nil.
+func writeRouteStatsToBuffer(rows []*pb.RouteTable_Row, history map[routeKey][]float64, w *tabwriter.Writer, options *routesOptions) error {
 	table := make([]*rowStats, 0)
 
-	for _, r := range resp.GetRoutes().Rows {
+	for _, r := range rows {
 		if r.Stats != nil {
-			route := r.GetRoute()
-			if route == "" {
-				route = defaultRoute
+			route := routeName(r)
+			if !options.matchesRoute(route) {
+				continue
 			}
 			table = append(table, &rowStats{
-				route:       route,
-				dst:         r.GetAuthority(),
-				requestRate: util.GetRequestRate(r.Stats, r.TimeWindow),
-				successRate: util.GetSuccessRate(r.Stats),
-				tlsPercent:  util.GetPercentTls(r.Stats),
-				latencyP50:  r.Stats.LatencyMsP50,
-				latencyP95:  r.Stats.LatencyMsP95,
-				latencyP99:  r.Stats.LatencyMsP99,
+				route:            route,
+				dst:              r.GetAuthority(),
+				requestRate:      util.GetRequestRate(r.Stats, r.TimeWindow),
+				successRate:      util.GetSuccessRate(r.Stats),
+				tlsPercent:       util.GetPercentTls(r.Stats),
+				latencyP50:       r.Stats.LatencyMsP50,
+				latencyP95:       r.Stats.LatencyMsP95,
+				latencyP99:       r.Stats.LatencyMsP99,
+				requests:         r.Stats.SuccessCount + r.Stats.FailureCount,
+				successHistory:   history[routeKeyOf(r)],
+				namespace:        r.GetResource().GetNamespace(),
+				name:             r.GetResource().GetName(),
+				grpcStatus:       grpcStatusCounts(r.GetResponsesByGrpcStatus()),
+				latencyHistogram: r.GetLatencyHistogram(),
 			})
 		}
 	}
 
-	sort.Slice(table, func(i, j int) bool {
-		return table[i].route+table[i].dst < table[j].route+table[j].dst
-	})
+	sortRoutes(table, options.sortBy, options.reverse)
+	grouped := len(table) > 0 && table[0].name != ""
+	if grouped {
+		sort.SliceStable(table, func(i, j int) bool {
+			if table[i].namespace != table[j].namespace {
+				return table[i].namespace < table[j].namespace
+			}
+			return table[i].name < table[j].name
+		})
+	}
 
-	switch options.outputFormat {
-	case "table", "":
-		if len(table) == 0 {
-			fmt.Fprintln(os.Stderr, "No traffic found.  Does the service have a service profile?  You can create one with the `linkerd profile` command.")
-			os.Exit(0)
-		}
-		printRouteTable(table, w, options)
-	case "json":
+	if isJSONOutput(options.outputFormat) {
 		printRouteJson(table, w)
+		return nil
+	}
+	if options.outputFormat == csvOutput {
+		printRouteCSV(table, w)
+		return nil
+	}
+	if options.outputFormat == prometheusOutput {
+		printRoutePrometheus(table, w)
+		return nil
+	}
+
+	if len(table) == 0 {
+		if options.routeRegex != nil && len(rows) > 0 {
+			return newCliError(exitCodeNoData, fmt.Errorf("No route matches %s", options.route))
+		}
+		return errNoRouteTraffic
+	}
+
+	printTable := printRouteTable
+	if options.outputFormat == wideOutput {
+		printTable = printWideRouteTable
+	}
+	if !grouped || !options.allNamespaces {
+		printTable(table, w, history != nil, options)
+		return nil
 	}
+
+	// print a table per namespace, as the rows are sorted by namespace
+	for start := 0; start < len(table); {
+		end := start
+		for end < len(table) && table[end].namespace == table[start].namespace {
+			end++
+		}
+		if start > 0 {
+			fmt.Fprintln(w, "")
+		}
+		// the left padding of the lines is stripped by renderStats
+		fmt.Fprintf(w, "%snamespace/%s\n", strings.Repeat(" ", padding), table[start].namespace)
+		printTable(table[start:end], w, history != nil, options)
+		start = end
+	}
+	return nil
 }
 
-func printRouteTable(stats []*rowStats, w *tabwriter.Writer, options *routesOptions) {
-	// template for left-aligning the route column
-	routeTemplate := fmt.Sprintf("%%-%ds", routeWidth(stats))
+// addResourceColumn adds the NAME column of the resources of the routes in
+// front of the headers and row template of a route table, when the routes of
+// several resources are displayed.
+func addResourceColumn(stats []*rowStats, headers []string, templateString string) ([]string, string) {
+	width := len(nameHeader)
+	for _, row := range stats {
+		if len(row.name) > width {
+			width = len(row.name)
+		}
+	}
+	nameTemplate := fmt.Sprintf("%%-%ds", width)
+	return append([]string{fmt.Sprintf(nameTemplate, nameHeader)}, headers...), nameTemplate + "\t" + templateString
+}
 
-	authorityColumn := "AUTHORITY"
+// authorityColumn returns the header of the column of the destinations, and
+// the value of the column for row.
+func authorityColumn(row *rowStats, options *routesOptions) (string, string) {
 	if options.dstIsService {
-		authorityColumn = "SERVICE"
+		return "SERVICE", strings.Split(row.dst, ".")[0]
 	}
+	return "AUTHORITY", row.dst
+}
+
+// sortRoutes sorts the routes by the given column, and then by route and
+// destination, so that the order is stable across refreshes.
+func sortRoutes(table []*rowStats, sortBy string, reverse bool) {
+	less := routeSortKeys[sortBy]
+	byRoute := routeSortKeys["route"]
+	sort.Slice(table, func(i, j int) bool {
+		a, b := table[i], table[j]
+		if reverse {
+			a, b = b, a
+		}
+		if less(a, b) {
+			return true
+		}
+		if less(b, a) {
+			return false
+		}
+		return byRoute(table[i], table[j])
+	})
+}
+
+func printRouteTable(stats []*rowStats, w *tabwriter.Writer, trend bool, options *routesOptions) {
+	// template for left-aligning the route column
+	routeTemplate := fmt.Sprintf("%%-%ds", routeWidth(stats))
+
+	authorityHeader, _ := authorityColumn(&rowStats{}, options)
 
 	headers := []string{
 		fmt.Sprintf(routeTemplate, "ROUTE"),
-		authorityColumn,
-		"SUCCESS",
+		authorityHeader,
+		colorizeHeader("SUCCESS"),
 		"RPS",
 		"LATENCY_P50",
 		"LATENCY_P95",
 		"LATENCY_P99",
 		"TLS\t", // trailing \t is required to format last column
 	}
+	templateString := routeTemplate + "\t%s\t%s\t%.1frps\t%dms\t%dms\t%dms\t%.f%%\t\n"
+	grpcStatus := hasGrpcStatus(stats)
+	if grpcStatus {
+		headers, templateString = addColumn(headers, templateString, "GRPC_STATUS")
+	}
+	if options.showHistogram {
+		headers, templateString = addHistogramColumns(headers, templateString)
+	}
+	if trend {
+		headers, templateString = addColumn(headers, templateString, "SUCCESS_TREND")
+	}
+	grouped := len(stats) > 0 && stats[0].name != ""
+	if grouped {
+		headers, templateString = addResourceColumn(stats, headers, templateString)
+	}
 
 	fmt.Fprintln(w, strings.Join(headers, "\t"))
 
-	templateString := routeTemplate + "\t%s\t%.2f%%\t%.1frps\t%dms\t%dms\t%dms\t%.f%%\t\n"
-
 	for _, row := range stats {
+		_, authorityValue := authorityColumn(row, options)
 
-		authorityValue := row.dst
-		if options.dstIsService {
-			segments := strings.Split(row.dst, ".")
-			authorityValue = segments[0]
+		values := []interface{}{
+			row.route,
+			authorityValue,
+			formatSuccessRate(row.successRate),
+			row.requestRate,
+			row.latencyP50,
+			row.latencyP95,
+			row.latencyP99,
+			row.tlsPercent * 100,
+		}
+		if grpcStatus {
+			values = append(values, formatCodeCounts(row.grpcStatus))
+		}
+		if options.showHistogram {
+			values = append(values, histogramShares(row.latencyHistogram)...)
 		}
+		if trend {
+			values = append(values, successTrend(row.successHistory))
+		}
+		if grouped {
+			values = append([]interface{}{row.name}, values...)
+		}
+		fmt.Fprintf(w, templateString, values...)
+	}
+}
+
+// printWideRouteTable prints the route stats along with the number of
+// requests of each route during the time window.
+func printWideRouteTable(stats []*rowStats, w *tabwriter.Writer, trend bool, options *routesOptions) {
+	routeTemplate := fmt.Sprintf("%%-%ds", routeWidth(stats))
+	authorityHeader, _ := authorityColumn(&rowStats{}, options)
+
+	headers := []string{
+		fmt.Sprintf(routeTemplate, "ROUTE"),
+		authorityHeader,
+		colorizeHeader("SUCCESS"),
+		"RPS",
+		"REQUESTS",
+		"LATENCY_P50",
+		"LATENCY_P95",
+		"LATENCY_P99",
+		"TLS\t", // trailing \t is required to format last column
+	}
+	templateString := routeTemplate + "\t%s\t%s\t%.1frps\t%d\t%dms\t%dms\t%dms\t%.f%%\t\n"
+	grpcStatus := hasGrpcStatus(stats)
+	if grpcStatus {
+		headers, templateString = addColumn(headers, templateString, "GRPC_STATUS")
+	}
+	if options.showHistogram {
+		headers, templateString = addHistogramColumns(headers, templateString)
+	}
+	if trend {
+		headers, templateString = addColumn(headers, templateString, "SUCCESS_TREND")
+	}
+	grouped := len(stats) > 0 && stats[0].name != ""
+	if grouped {
+		headers, templateString = addResourceColumn(stats, headers, templateString)
+	}
 
-		fmt.Fprintf(w, templateString,
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+
+	for _, row := range stats {
+		_, authorityValue := authorityColumn(row, options)
+
+		values := []interface{}{
 			row.route,
 			authorityValue,
-			row.successRate*100,
+			formatSuccessRate(row.successRate),
 			row.requestRate,
+			row.requests,
 			row.latencyP50,
 			row.latencyP95,
 			row.latencyP99,
-			row.tlsPercent*100,
-		)
+			row.tlsPercent * 100,
+		}
+		if grpcStatus {
+			values = append(values, formatCodeCounts(row.grpcStatus))
+		}
+		if options.showHistogram {
+			values = append(values, histogramShares(row.latencyHistogram)...)
+		}
+		if trend {
+			values = append(values, successTrend(row.successHistory))
+		}
+		if grouped {
+			values = append([]interface{}{row.name}, values...)
+		}
+		fmt.Fprintf(w, templateString, values...)
 	}
 }
 
 // Using pointers there where the value is NA and the corresponding json is null
 type jsonRouteStats struct {
+	Namespace    string   `json:"namespace,omitempty"`
+	Name         string   `json:"name,omitempty"`
 	Route        string   `json:"route"`
 	Authority    string   `json:"authority"`
 	Success      *float64 `json:"success"`
@@ -192,6 +801,17 @@ type jsonRouteStats struct {
 	LatencyMSp95 *uint64  `json:"latency_ms_p95"`
 	LatencyMSp99 *uint64  `json:"latency_ms_p99"`
 	Tls          *float64 `json:"tls"`
+	// GrpcStatus is only set for the routes of gRPC destinations
+	GrpcStatus map[string]uint64 `json:"grpc_status,omitempty"`
+	// LatencyHistogram is only set with --show-histogram
+	LatencyHistogram []*jsonLatencyBucket `json:"latency_histogram,omitempty"`
+}
+
+// jsonLatencyBucket is a bucket of the latency histogram of a route; MaxMs is
+// null for the last bucket, which has no upper bound.
+type jsonLatencyBucket struct {
+	MaxMs *uint64 `json:"max_ms"`
+	Count uint64  `json:"count"`
 }
 
 func printRouteJson(stats []*rowStats, w *tabwriter.Writer) {
@@ -200,7 +820,9 @@ func printRouteJson(stats []*rowStats, w *tabwriter.Writer) {
 	for _, row := range stats {
 		route := row.route
 		entry := &jsonRouteStats{
-			Route: route,
+			Namespace: row.namespace,
+			Name:      row.name,
+			Route:     route,
 		}
 
 		entry.Authority = row.dst
@@ -210,6 +832,16 @@ func printRouteJson(stats []*rowStats, w *tabwriter.Writer) {
 		entry.LatencyMSp95 = &row.latencyP95
 		entry.LatencyMSp99 = &row.latencyP99
 		entry.Tls = &row.tlsPercent
+		if len(row.grpcStatus) > 0 {
+			entry.GrpcStatus = row.grpcStatus
+		}
+		for _, bucket := range row.latencyHistogram {
+			jsonBucket := &jsonLatencyBucket{Count: bucket.GetCount()}
+			if maxMs := bucket.GetMaxMs(); maxMs != 0 {
+				jsonBucket.MaxMs = &maxMs
+			}
+			entry.LatencyHistogram = append(entry.LatencyHistogram, jsonBucket)
+		}
 
 		entries = append(entries, entry)
 	}
@@ -221,11 +853,161 @@ func printRouteJson(stats []*rowStats, w *tabwriter.Writer) {
 	fmt.Fprintf(w, "%s\n", b)
 }
 
-func buildTopRoutesRequest(resource string, options *routesOptions) (*pb.TopRoutesRequest, error) {
+// validateOutputFormat accepts the wide and csv formats on top of the formats
+// of the other stat commands, only the table formats with --watch, and no csv
+// or prometheus format with --show-histogram.
+func (o *routesOptions) validateOutputFormat() error {
+	if o.watch {
+		if kind, _ := parseOutputFormat(o.outputFormat); kind != tableOutput && kind != wideOutput {
+			return errors.New("--watch only supports the table and wide output formats")
+		}
+		if o.watchInterval <= 0 {
+			return errors.New("--watch-interval must be positive")
+		}
+	}
+	if kind, _ := parseOutputFormat(o.outputFormat); o.showHistogram && (kind == csvOutput || kind == prometheusOutput) {
+		return errors.New("--show-histogram doesn't support the csv and prometheus output formats")
+	}
+
+	switch kind, _ := parseOutputFormat(o.outputFormat); kind {
+	case tableOutput, wideOutput, jsonOutput, yamlOutput, csvOutput, prometheusOutput:
+		return nil
+	case jsonPathOutput, goTemplateOutput:
+		return validateTemplateOutput(o.outputFormat)
+	default:
+		return errors.New("--output currently only supports table, wide, json, yaml, csv, prometheus, jsonpath and go-template")
+	}
+}
+
+// printRouteCSV prints a CSV row per route, with the fields of the JSON
+// output.
+func printRouteCSV(stats []*rowStats, w *tabwriter.Writer) {
+	header := []string{"route", "authority", "success", "rps", "latency_ms_p50", "latency_ms_p95", "latency_ms_p99", "tls"}
+	grouped := len(stats) > 0 && stats[0].name != ""
+	if grouped {
+		header = append([]string{"namespace", "name"}, header...)
+	}
+	records := [][]string{}
+	for _, row := range stats {
+		record := []string{
+			row.route,
+			row.dst,
+			csvFloat(row.successRate),
+			csvFloat(row.requestRate),
+			fmt.Sprintf("%d", row.latencyP50),
+			fmt.Sprintf("%d", row.latencyP95),
+			fmt.Sprintf("%d", row.latencyP99),
+			csvFloat(row.tlsPercent),
+		}
+		if grouped {
+			record = append([]string{row.namespace, row.name}, record...)
+		}
+		records = append(records, record)
+	}
+
+	if err := writeCSV(w, header, records); err != nil {
+		log.Error(err.Error())
+	}
+}
+
+// validateThresholds checks the "--fail-if" thresholds, which can't apply to
+// the stats of "--watch", as it never exits.
+func (o *routesOptions) validateThresholds() error {
+	if o.failIfSuccessBelow < 0 || o.failIfSuccessBelow > 100 {
+		return errors.New("--fail-if-success-below must be a percentage between 0 and 100")
+	}
+	if o.failIfP99Above < 0 {
+		return errors.New("--fail-if-p99-above must be positive")
+	}
+	if o.watch && (o.failIfSuccessBelow > 0 || o.failIfP99Above > 0) {
+		return errors.New("the --fail-if flags can't be combined with --watch")
+	}
+	return nil
+}
+
+// validateHistory checks that the sub-windows of --history are long enough
+// for their stats to be computed.
+func (o *routesOptions) validateHistory() error {
+	if !o.history {
+		return nil
+	}
+	if kind, _ := parseOutputFormat(o.outputFormat); kind != tableOutput && kind != wideOutput {
+		return errors.New("--history only supports the table and wide output formats")
+	}
+	if o.historyPoints < 2 {
+		return errors.New("--history-points must be at least 2")
+	}
+	window, err := time.ParseDuration(o.timeWindow)
+	if err != nil {
+		return err
+	}
+	if window/time.Duration(o.historyPoints) < minHistoryStep {
+		return fmt.Errorf("--history needs a --time-window of at least %s to split it into %d sub-windows", minHistoryStep*time.Duration(o.historyPoints), o.historyPoints)
+	}
+	return nil
+}
+
+// printRoutePrometheus prints the route stats as gauges labeled with the route
+// and its authority, and with the resource of the route when the routes of
+// several resources are displayed.
+func printRoutePrometheus(stats []*rowStats, w *tabwriter.Writer) {
+	successRate := &promMetric{name: "linkerd_route_success_ratio", help: "Ratio of the successful requests of the route."}
+	requestRate := &promMetric{name: "linkerd_route_requests_per_second", help: "Rate of the requests of the route."}
+	latency := &promMetric{name: "linkerd_route_latency_milliseconds", help: "Latency quantiles of the requests of the route."}
+	tlsRate := &promMetric{name: "linkerd_route_tls_ratio", help: "Ratio of the requests of the route sent over TLS."}
+
+	for _, row := range stats {
+		labels := []promLabel{{"route", row.route}, {"authority", row.dst}}
+		if row.name != "" {
+			labels = append([]promLabel{{"namespace", row.namespace}, {"name", row.name}}, labels...)
+		}
+		quantile := func(q string) []promLabel {
+			return append(append([]promLabel{}, labels...), promLabel{"quantile", q})
+		}
+
+		successRate.add(labels, row.successRate)
+		requestRate.add(labels, row.requestRate)
+		latency.add(quantile("0.5"), float64(row.latencyP50))
+		latency.add(quantile("0.95"), float64(row.latencyP95))
+		latency.add(quantile("0.99"), float64(row.latencyP99))
+		tlsRate.add(labels, row.tlsPercent)
+	}
+
+	metrics := []*promMetric{successRate, requestRate, latency, tlsRate}
+	if err := writePrometheus(w, metrics); err != nil {
+		log.Error(err.Error())
+	}
+}
+
+// buildTopRoutesRequests returns a request per "--to" destination, or a
+// single request without destination.
+func buildTopRoutesRequests(resource string, options *routesOptions) ([]*pb.TopRoutesRequest, error) {
 	err := options.validateOutputFormat()
 	if err != nil {
 		return nil, err
 	}
+	if _, ok := routeSortKeys[options.sortBy]; !ok {
+		return nil, fmt.Errorf("--sort-by must be one of: %s", strings.Join(routeSortKeyNames, ", "))
+	}
+	if err := options.validateHistory(); err != nil {
+		return nil, err
+	}
+	if err := options.validateThresholds(); err != nil {
+		return nil, err
+	}
+	endTime, err := options.endTime(time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if options.watch && !endTime.IsZero() {
+		return nil, errors.New("--watch can't be combined with the --at and --offset flags")
+	}
+	if options.route != "" {
+		options.routeRegex, err = regexp.Compile(options.route)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --route regular expression: %s", err)
+		}
+	}
 
 	target, err := util.BuildResource(options.namespace, resource)
 	if err != nil {
@@ -234,52 +1016,89 @@ func buildTopRoutesRequest(resource string, options *routesOptions) (*pb.TopRout
 
 	requestParams := util.TopRoutesRequestParams{
 		StatsBaseRequestParams: util.StatsBaseRequestParams{
-			TimeWindow:   options.timeWindow,
-			ResourceName: target.Name,
-			ResourceType: target.Type,
-			Namespace:    options.namespace,
+			TimeWindow:    options.timeWindow,
+			ResourceName:  target.Name,
+			ResourceType:  target.Type,
+			Namespace:     options.namespace,
+			AllNamespaces: options.allNamespaces,
+			EndTime:       endTime,
 		},
+		IncludeLatencyHistogram: options.showHistogram,
 	}
 
 	options.dstIsService = target.GetType() == k8s.Service
 
-	if options.toResource != "" {
-		if target.GetType() == k8s.Service {
-			return nil, errors.New("Cannot use \"--to\" when the target resource is a service")
-		}
-		if options.toNamespace == "" {
-			options.toNamespace = options.namespace
-		}
-		toRes, err := util.BuildResource(options.toNamespace, options.toResource)
+	if len(options.toResources) == 0 {
+		req, err := util.BuildTopRoutesRequest(requestParams)
 		if err != nil {
 			return nil, err
 		}
+		return []*pb.TopRoutesRequest{req}, nil
+	}
 
-		options.dstIsService = toRes.GetType() == k8s.Service
+	if target.GetType() == k8s.Service {
+		return nil, errors.New("Cannot use \"--to\" when the target resource is a service")
+	}
+	if options.toNamespace == "" {
+		options.toNamespace = options.namespace
+	}
 
-		toDNS, err := buildTopRoutesTo(toRes)
+	reqs := make([]*pb.TopRoutesRequest, 0)
+	destinations := make(map[string]bool)
+	// the destination column shows the names of the services only if all the
+	// destinations are services
+	allServices := true
+	for _, toResource := range options.toResources {
+		toRes, err := util.BuildResource(options.toNamespace, toResource)
 		if err != nil {
 			return nil, err
 		}
+		allServices = allServices && toRes.GetType() == k8s.Service
 
-		if toDNS == "" && toRes.GetType() == k8s.Authority {
-			requestParams.ToAll = true
-		} else {
-			requestParams.To = toDNS
+		params, destination := buildTopRoutesTo(requestParams, toRes)
+		if destinations[destination] {
+			continue
 		}
+		destinations[destination] = true
+
+		req, err := util.BuildTopRoutesRequest(params)
+		if err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, req)
+	}
+
+	if destinations[""] && len(reqs) > 1 {
+		return nil, errors.New("\"--to authority\" already includes all the destinations, and can't be combined with other destinations")
 	}
+	options.dstIsService = allServices
 
-	return util.BuildTopRoutesRequest(requestParams)
+	return reqs, nil
 }
 
-func buildTopRoutesTo(toResource pb.Resource) (string, error) {
-	if toResource.GetType() == k8s.Service {
-		return fmt.Sprintf("%s.%s.svc.cluster.local", toResource.GetName(), toResource.GetNamespace()), nil
-	}
-	if toResource.GetType() == k8s.Authority {
-		return toResource.GetName(), nil
+// buildTopRoutesTo returns the request parameters with the "--to" resource as
+// their destination, along with a key identifying the destination, which is
+// empty for the "--to authority" destination including all the others.
+// Services and authorities are selected by authority, and the other types by
+// resource.
+func buildTopRoutesTo(params util.TopRoutesRequestParams, toResource pb.Resource) (util.TopRoutesRequestParams, string) {
+	switch toResource.GetType() {
+	case k8s.Service:
+		params.To = fmt.Sprintf("%s.%s.svc.cluster.local", toResource.GetName(), toResource.GetNamespace())
+		return params, params.To
+	case k8s.Authority:
+		if toResource.GetName() == "" {
+			params.ToAll = true
+		} else {
+			params.To = toResource.GetName()
+		}
+		return params, toResource.GetName()
+	default:
+		params.ToNamespace = toResource.GetNamespace()
+		params.ToType = toResource.GetType()
+		params.ToName = toResource.GetName()
+		return params, fmt.Sprintf("%s/%s/%s", toResource.GetType(), toResource.GetNamespace(), toResource.GetName())
 	}
-	return "", errors.New("The \"--to\" resource must be an authority or service")
 }
 
 // returns the length of the longest route name
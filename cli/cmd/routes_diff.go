@@ -0,0 +1,392 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/spf13/cobra"
+)
+
+type routesDiffOptions struct {
+	*routesOptions
+	baselineFile   string
+	baselineOffset time.Duration
+}
+
+// routeDiffStats are the stats of a route in a snapshot, or the deltas of
+// the stats between two snapshots.
+type routeDiffStats struct {
+	Success      float64 `json:"success"`
+	Rps          float64 `json:"rps"`
+	LatencyMSp50 int64   `json:"latency_ms_p50"`
+	LatencyMSp95 int64   `json:"latency_ms_p95"`
+	LatencyMSp99 int64   `json:"latency_ms_p99"`
+}
+
+// routeDiff compares the stats of a route in the baseline snapshot with its
+// current stats. Before is nil for the new routes, and After is nil for the
+// routes without requests anymore, in which case Delta is nil.
+type routeDiff struct {
+	Namespace string          `json:"namespace,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Route     string          `json:"route"`
+	Authority string          `json:"authority"`
+	Before    *routeDiffStats `json:"before"`
+	After     *routeDiffStats `json:"after"`
+	Delta     *routeDiffStats `json:"delta"`
+}
+
+func newRoutesDiffOptions() *routesDiffOptions {
+	return &routesDiffOptions{
+		routesOptions:  newRoutesOptions(),
+		baselineFile:   "",
+		baselineOffset: 0,
+	}
+}
+
+func newCmdRoutesDiff() *cobra.Command {
+	options := newRoutesDiffOptions()
+
+	cmd := &cobra.Command{
+		Use:   "diff [flags] (RESOURCE)",
+		Short: "Compare route stats with a baseline",
+		Long: `Compare route stats with a baseline.
+
+The current route stats are compared with the stats of a baseline, which is by
+default the previous time window, e.g. the 1m window ending 1m ago for a 1m
+time window. The baseline can also end "--baseline-offset" ago, or be read from
+"--baseline-file", a snapshot previously saved with "linkerd routes -o json".
+
+The success rate, RPS and latencies of every route are printed along with their
+change since the baseline.`,
+		Example: `  # Compare the routes of the webapp service during the last 5 minutes with
+  # the 5 minutes before.
+  linkerd routes diff service/webapp -n test -t 5m
+
+  # Compare the routes of the webapp service with the same time of the day
+  # before.
+  linkerd routes diff service/webapp -n test -t 10m --baseline-offset 24h
+
+  # Save the routes of the webapp service before a deploy, and compare them
+  # with the routes after the deploy.
+  linkerd routes service/webapp -n test -o json > before.json
+  kubectl -n test apply -f webapp.yml
+  linkerd routes diff service/webapp -n test --baseline-file before.json -o json`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: util.ValidTargets,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reqs, err := buildRoutesDiffRequests(args[0], options)
+			if err != nil {
+				return newCliError(exitCodeInvalidFlags, fmt.Errorf("error creating metrics request while making routes request: %v", err))
+			}
+
+			output, err := requestRoutesDiffFromAPI(validatedPublicAPIClient(time.Time{}), reqs, options, time.Now())
+			if err == errNoRouteTraffic {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(exitCodeNoData)
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Print(output)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the specified resource")
+	cmd.Flags().StringVarP(&options.timeWindow, "time-window", "t", options.timeWindow, "Stat window of the current and baseline stats (for example: \"10s\", \"1m\", \"10m\", \"1h\")")
+	cmd.Flags().StringSliceVar(&options.toResources, "to", options.toResources, "If present, compares the outbound stats to the specified resources")
+	cmd.Flags().StringVar(&options.toNamespace, "to-namespace", options.toNamespace, "Sets the namespace used to lookup the \"--to\" resources; by default the current \"--namespace\" is used")
+	cmd.Flags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, outputFormatHelp)
+	cmd.Flags().StringVar(&options.route, "route", options.route, "If present, only compares the routes whose name matches this regular expression")
+	cmd.Flags().StringVar(&options.baselineFile, "baseline-file", options.baselineFile, "Compare with the route stats of this file, as printed by \"linkerd routes -o json\"")
+	cmd.Flags().DurationVar(&options.baselineOffset, "baseline-offset", options.baselineOffset, "How long before now the baseline time window ends; by default the baseline is the time window before the current one")
+	markStatFlagsConfigurable(cmd.Flags())
+
+	return cmd
+}
+
+func buildRoutesDiffRequests(resource string, options *routesDiffOptions) ([]*pb.TopRoutesRequest, error) {
+	if err := options.statOptionsBase.validateOutputFormat(); err != nil {
+		return nil, err
+	}
+	if options.baselineOffset < 0 {
+		return nil, errors.New("--baseline-offset must not be negative")
+	}
+	if options.baselineFile != "" && options.baselineOffset > 0 {
+		return nil, errors.New("--baseline-file and --baseline-offset are mutually exclusive")
+	}
+	return buildTopRoutesRequests(resource, options.routesOptions)
+}
+
+// requestRoutesDiffFromAPI returns the rendered comparison of the current
+// route stats with the baseline.
+func requestRoutesDiffFromAPI(client pb.ApiClient, reqs []*pb.TopRoutesRequest, options *routesDiffOptions, now time.Time) (string, error) {
+	var baseline map[routeKey]*routeDiffStats
+	var err error
+	if options.baselineFile != "" {
+		baseline, err = readRouteBaseline(options.baselineFile)
+	} else {
+		baseline, err = requestRouteBaseline(client, reqs, options, now)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	rows, err := requestRoutesFromAPI(client, reqs)
+	if err != nil {
+		return "", err
+	}
+
+	diffs := diffRoutes(baseline, routeSnapshot(rows), options.routesOptions)
+	if len(diffs) == 0 && !isJSONOutput(options.outputFormat) {
+		return "", errNoRouteTraffic
+	}
+	return renderRoutesDiff(diffs, options)
+}
+
+// requestRouteBaseline returns the stats of the time window ending
+// baselineOffset before now, or the time window before the current one by
+// default.
+func requestRouteBaseline(client pb.ApiClient, reqs []*pb.TopRoutesRequest, options *routesDiffOptions, now time.Time) (map[routeKey]*routeDiffStats, error) {
+	offset := options.baselineOffset
+	if offset == 0 {
+		window, err := time.ParseDuration(reqs[0].GetTimeWindow())
+		if err != nil {
+			return nil, err
+		}
+		offset = window
+	}
+
+	baselineReqs := make([]*pb.TopRoutesRequest, len(reqs))
+	for i, req := range reqs {
+		baselineReqs[i] = proto.Clone(req).(*pb.TopRoutesRequest)
+		baselineReqs[i].EndTime = now.Add(-offset).Unix()
+	}
+
+	rows, err := requestRoutesFromAPI(client, baselineReqs)
+	if err != nil {
+		return nil, err
+	}
+	return routeSnapshot(rows), nil
+}
+
+// readRouteBaseline reads the route stats printed by "linkerd routes -o json".
+func readRouteBaseline(path string) (map[routeKey]*routeDiffStats, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []*jsonRouteStats
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, fmt.Errorf("invalid baseline file %s: %s", path, err)
+	}
+
+	baseline := make(map[routeKey]*routeDiffStats)
+	for _, entry := range entries {
+		stats := &routeDiffStats{}
+		if entry.Success != nil {
+			stats.Success = *entry.Success
+		}
+		if entry.Rps != nil {
+			stats.Rps = *entry.Rps
+		}
+		if entry.LatencyMSp50 != nil {
+			stats.LatencyMSp50 = int64(*entry.LatencyMSp50)
+		}
+		if entry.LatencyMSp95 != nil {
+			stats.LatencyMSp95 = int64(*entry.LatencyMSp95)
+		}
+		if entry.LatencyMSp99 != nil {
+			stats.LatencyMSp99 = int64(*entry.LatencyMSp99)
+		}
+		// the JSON output names the requests without a route
+		route := entry.Route
+		if route == defaultRoute {
+			route = ""
+		}
+		baseline[routeKey{namespace: entry.Namespace, name: entry.Name, route: route, dst: entry.Authority}] = stats
+	}
+	return baseline, nil
+}
+
+// routeSnapshot returns the stats of the routes of a TopRoutes response.
+func routeSnapshot(rows []*pb.RouteTable_Row) map[routeKey]*routeDiffStats {
+	snapshot := make(map[routeKey]*routeDiffStats)
+	for _, row := range rows {
+		if row.Stats == nil {
+			continue
+		}
+		snapshot[routeKeyOf(row)] = &routeDiffStats{
+			Success:      util.GetSuccessRate(row.Stats),
+			Rps:          util.GetRequestRate(row.Stats, row.TimeWindow),
+			LatencyMSp50: int64(row.Stats.LatencyMsP50),
+			LatencyMSp95: int64(row.Stats.LatencyMsP95),
+			LatencyMSp99: int64(row.Stats.LatencyMsP99),
+		}
+	}
+	return snapshot
+}
+
+// diffRoutes pairs the baseline and current stats of the routes matching
+// "--route", sorted by resource, route and authority.
+func diffRoutes(baseline, current map[routeKey]*routeDiffStats, options *routesOptions) []*routeDiff {
+	keys := make(map[routeKey]bool)
+	for key := range baseline {
+		keys[key] = true
+	}
+	for key := range current {
+		keys[key] = true
+	}
+
+	diffs := make([]*routeDiff, 0)
+	for key := range keys {
+		route := key.route
+		if route == "" {
+			route = defaultRoute
+		}
+		if !options.matchesRoute(route) {
+			continue
+		}
+
+		diff := &routeDiff{
+			Namespace: key.namespace,
+			Name:      key.name,
+			Route:     route,
+			Authority: key.dst,
+			Before:    baseline[key],
+			After:     current[key],
+		}
+		if diff.Before != nil && diff.After != nil {
+			diff.Delta = &routeDiffStats{
+				Success:      diff.After.Success - diff.Before.Success,
+				Rps:          diff.After.Rps - diff.Before.Rps,
+				LatencyMSp50: diff.After.LatencyMSp50 - diff.Before.LatencyMSp50,
+				LatencyMSp95: diff.After.LatencyMSp95 - diff.Before.LatencyMSp95,
+				LatencyMSp99: diff.After.LatencyMSp99 - diff.Before.LatencyMSp99,
+			}
+		}
+		diffs = append(diffs, diff)
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		a, b := diffs[i], diffs[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		if a.Route != b.Route {
+			return a.Route < b.Route
+		}
+		return a.Authority < b.Authority
+	})
+	return diffs
+}
+
+func renderRoutesDiff(diffs []*routeDiff, options *routesDiffOptions) (string, error) {
+	var buffer bytes.Buffer
+	w := tabwriter.NewWriter(&buffer, 0, 0, padding, ' ', tabwriter.AlignRight)
+	if isJSONOutput(options.outputFormat) {
+		b, err := json.MarshalIndent(diffs, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(w, "%s\n", b)
+	} else {
+		printRoutesDiffTable(diffs, w, options.routesOptions)
+	}
+	w.Flush()
+
+	return renderStats(buffer, &options.statOptionsBase)
+}
+
+// printRoutesDiffTable prints the current stats of every route, followed by
+// their change since the baseline, or by "new" for the routes that weren't in
+// the baseline.
+func printRoutesDiffTable(diffs []*routeDiff, w *tabwriter.Writer, options *routesOptions) {
+	routeLength := len(defaultRoute)
+	nameLength := len(nameHeader)
+	grouped := false
+	for _, diff := range diffs {
+		if len(diff.Route) > routeLength {
+			routeLength = len(diff.Route)
+		}
+		if len(diff.Name) > nameLength {
+			nameLength = len(diff.Name)
+		}
+		grouped = grouped || diff.Name != ""
+	}
+	routeTemplate := fmt.Sprintf("%%-%ds", routeLength)
+	nameTemplate := fmt.Sprintf("%%-%ds", nameLength)
+
+	authorityHeader, _ := authorityColumn(&rowStats{}, options)
+	headers := []string{
+		fmt.Sprintf(routeTemplate, "ROUTE"),
+		authorityHeader,
+		colorizeHeader("SUCCESS"),
+		"RPS",
+		"LATENCY_P50",
+		"LATENCY_P95",
+		"LATENCY_P99\t", // trailing \t is required to format last column
+	}
+	if grouped {
+		headers = append([]string{fmt.Sprintf(nameTemplate, nameHeader)}, headers...)
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+
+	for _, diff := range diffs {
+		_, authority := authorityColumn(&rowStats{dst: diff.Authority}, options)
+		values := []string{fmt.Sprintf(routeTemplate, diff.Route), authority}
+		values = append(values, formatRouteDiff(diff)...)
+		if grouped {
+			values = append([]string{fmt.Sprintf(nameTemplate, diff.Name)}, values...)
+		}
+		fmt.Fprintf(w, "%s\t\n", strings.Join(values, "\t"))
+	}
+}
+
+// formatRouteDiff returns the success rate, RPS and latency columns of a
+// route.
+func formatRouteDiff(diff *routeDiff) []string {
+	if diff.After == nil {
+		return []string{colorize(colorDefault, "- (removed)"), "-", "-", "-", "-"}
+	}
+
+	after, delta := diff.After, diff.Delta
+	columns := []string{
+		formatSuccessRate(after.Success),
+		fmt.Sprintf("%.1frps", after.Rps),
+		fmt.Sprintf("%dms", after.LatencyMSp50),
+		fmt.Sprintf("%dms", after.LatencyMSp95),
+		fmt.Sprintf("%dms", after.LatencyMSp99),
+	}
+	if delta == nil {
+		columns[0] += " (new)"
+		return columns
+	}
+
+	changes := []string{
+		fmt.Sprintf("%+.2f%%", delta.Success*100),
+		fmt.Sprintf("%+.1frps", delta.Rps),
+		fmt.Sprintf("%+dms", delta.LatencyMSp50),
+		fmt.Sprintf("%+dms", delta.LatencyMSp95),
+		fmt.Sprintf("%+dms", delta.LatencyMSp99),
+	}
+	for i := range columns {
+		columns[i] = fmt.Sprintf("%s (%s)", columns[i], changes[i])
+	}
+	return columns
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/public"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"google.golang.org/grpc"
+)
+
+// baselineAPIClient returns the baseline route stats for the requests with an
+// end time, and the current route stats for the others.
+type baselineAPIClient struct {
+	public.MockApiClient
+	baseline *pb.TopRoutesResponse
+	current  *pb.TopRoutesResponse
+	endTimes []int64
+}
+
+func (c *baselineAPIClient) TopRoutes(ctx context.Context, in *pb.TopRoutesRequest, opts ...grpc.CallOption) (*pb.TopRoutesResponse, error) {
+	if in.GetEndTime() != 0 {
+		c.endTimes = append(c.endTimes, in.GetEndTime())
+		return c.baseline, nil
+	}
+	return c.current, nil
+}
+
+func newBaselineAPIClient() *baselineAPIClient {
+	baseline := public.GenTopRoutesResponse([]string{"/a", "/b", ""}, []uint64{90, 60, 30})
+	current := public.GenTopRoutesResponse([]string{"/a", "/c", ""}, []uint64{120, 30, 30})
+	current.GetRoutes().Rows[0].Stats.FailureCount = 30
+	current.GetRoutes().Rows[0].Stats.LatencyMsP99 = 250
+	return &baselineAPIClient{baseline: &baseline, current: &current}
+}
+
+func TestRoutesDiff(t *testing.T) {
+	now := time.Unix(1546300800, 0)
+
+	t.Run("Compares the route stats with the previous time window", func(t *testing.T) {
+		client := newBaselineAPIClient()
+		options := newRoutesDiffOptions()
+		reqs, err := buildRoutesDiffRequests("deploy/foobar", options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		output, err := requestRoutesDiffFromAPI(client, reqs, options, now)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(client.endTimes) != 1 || client.endTimes[0] != now.Add(-time.Minute).Unix() {
+			t.Fatalf("Expected the baseline window to end a minute ago, got %v", client.endTimes)
+		}
+		diffCompareFile(t, output, "routes_diff_output.golden")
+	})
+
+	t.Run("Compares the route stats with a baseline file", func(t *testing.T) {
+		client := newBaselineAPIClient()
+
+		jsonOptions := newRoutesOptions()
+		jsonOptions.outputFormat = jsonOutput
+		baseline, err := renderRouteStats(client.baseline.GetRoutes().GetRows(), nil, jsonOptions)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		dir, err := ioutil.TempDir("", "routes-diff")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		defer os.RemoveAll(dir)
+		path := filepath.Join(dir, "baseline.json")
+		if err := ioutil.WriteFile(path, []byte(baseline), 0600); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		options := newRoutesDiffOptions()
+		options.baselineFile = path
+		options.outputFormat = jsonOutput
+		reqs, err := buildRoutesDiffRequests("deploy/foobar", options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		output, err := requestRoutesDiffFromAPI(client, reqs, options, now)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(client.endTimes) != 0 {
+			t.Fatalf("Expected the baseline to be read from the file, got requests ending at %v", client.endTimes)
+		}
+		diffCompareFile(t, output, "routes_diff_output_json.golden")
+	})
+
+	t.Run("Executes a go-template against the comparison", func(t *testing.T) {
+		options := newRoutesDiffOptions()
+		options.outputFormat = `go-template={{range .}}{{.route}}{{with .delta}} {{.latency_ms_p99}}{{end}}{{"\n"}}{{end}}`
+		reqs, err := buildRoutesDiffRequests("deploy/foobar", options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		output, err := requestRoutesDiffFromAPI(newBaselineAPIClient(), reqs, options, now)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		diffCompareFile(t, output, "routes_diff_output_go_template.golden")
+	})
+
+	t.Run("Rejects a baseline file with a baseline offset", func(t *testing.T) {
+		options := newRoutesDiffOptions()
+		options.baselineFile = "baseline.json"
+		options.baselineOffset = time.Hour
+		if _, err := buildRoutesDiffRequests("deploy/foobar", options); err == nil {
+			t.Fatal("Expected an error")
+		}
+	})
+}
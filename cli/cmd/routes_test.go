@@ -1,9 +1,18 @@
 package cmd
 
 import (
+	"bytes"
+	"context"
+	"math"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/linkerd/linkerd2/controller/api/public"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
 )
 
 type routesParamsExp struct {
@@ -11,6 +20,14 @@ type routesParamsExp struct {
 	routes  []string
 	counts  []uint64
 	file    string
+	// the target of the command, deploy/foobar by default
+	target string
+	// the resources of the routes, if the target has no name
+	resources []*pb.Resource
+	// the gRPC responses of the routes by status code, for gRPC destinations
+	grpcStatus []map[uint32]uint64
+	// the latency histograms of the routes
+	histograms [][]*pb.RouteTable_LatencyBucket
 }
 
 func TestRoutes(t *testing.T) {
@@ -33,6 +50,409 @@ func TestRoutes(t *testing.T) {
 			file:    "routes_one_output_json.golden",
 		}, t)
 	})
+
+	options.outputFormat = yamlOutput
+	t.Run("Returns route stats (yaml)", func(t *testing.T) {
+		testRoutesCall(routesParamsExp{
+			routes:  []string{"/a", "/b", "/c", ""},
+			counts:  []uint64{90, 60, 0, 30},
+			options: options,
+			file:    "routes_one_output_yaml.golden",
+		}, t)
+	})
+
+	options.outputFormat = csvOutput
+	t.Run("Returns route stats (csv)", func(t *testing.T) {
+		testRoutesCall(routesParamsExp{
+			routes:  []string{"/a", "/b", "/c", ""},
+			counts:  []uint64{90, 60, 0, 30},
+			options: options,
+			file:    "routes_one_output_csv.golden",
+		}, t)
+	})
+
+	options.outputFormat = prometheusOutput
+	t.Run("Returns route stats (prometheus)", func(t *testing.T) {
+		testRoutesCall(routesParamsExp{
+			routes:  []string{"/a", "/b", "/c", ""},
+			counts:  []uint64{90, 60, 0, 30},
+			options: options,
+			file:    "routes_one_output_prometheus.golden",
+		}, t)
+	})
+
+	options = newRoutesOptions()
+	options.outputFormat = wideOutput
+	t.Run("Returns route stats (wide)", func(t *testing.T) {
+		testRoutesCall(routesParamsExp{
+			routes:  []string{"/a", "/b", "/c", ""},
+			counts:  []uint64{90, 60, 0, 30},
+			options: options,
+			file:    "routes_wide_output.golden",
+		}, t)
+	})
+
+	options = newRoutesOptions()
+	t.Run("Returns route stats with their gRPC status codes", func(t *testing.T) {
+		testRoutesCall(routesParamsExp{
+			routes:  []string{"/a", "/b", "/c", ""},
+			counts:  []uint64{90, 60, 0, 30},
+			options: options,
+			file:    "routes_grpc_output.golden",
+			grpcStatus: []map[uint32]uint64{
+				{0: 85, 4: 3, 14: 2},
+				{0: 60},
+				nil,
+				{12: 30},
+			},
+		}, t)
+	})
+
+	histograms := [][]*pb.RouteTable_LatencyBucket{
+		{{MaxMs: 5, Count: 40}, {MaxMs: 10, Count: 5}, {MaxMs: 500, Count: 0}, {MaxMs: 1000, Count: 45}, {MaxMs: 0, Count: 0}},
+		{{MaxMs: 5, Count: 0}, {MaxMs: 10, Count: 0}, {MaxMs: 500, Count: 60}, {MaxMs: 1000, Count: 0}, {MaxMs: 0, Count: 0}},
+		nil,
+		{{MaxMs: 5, Count: 0}, {MaxMs: 10, Count: 0}, {MaxMs: 500, Count: 0}, {MaxMs: 1000, Count: 0}, {MaxMs: 0, Count: 30}},
+	}
+
+	options = newRoutesOptions()
+	options.showHistogram = true
+	t.Run("Returns route stats with their latency histogram", func(t *testing.T) {
+		testRoutesCall(routesParamsExp{
+			routes:     []string{"/a", "/b", "/c", ""},
+			counts:     []uint64{90, 60, 0, 30},
+			options:    options,
+			file:       "routes_histogram_output.golden",
+			histograms: histograms,
+		}, t)
+	})
+
+	options = newRoutesOptions()
+	options.showHistogram = true
+	options.outputFormat = jsonOutput
+	t.Run("Returns route stats with their latency histogram in json", func(t *testing.T) {
+		testRoutesCall(routesParamsExp{
+			routes:     []string{"/a", "/b", "/c", ""},
+			counts:     []uint64{90, 60, 0, 30},
+			options:    options,
+			file:       "routes_histogram_output_json.golden",
+			histograms: histograms,
+		}, t)
+	})
+
+	options = newRoutesOptions()
+	options.history = true
+	options.timeWindow = "10m"
+	t.Run("Returns route stats with their history", func(t *testing.T) {
+		testRoutesCall(routesParamsExp{
+			routes:  []string{"/a", "/b", "/c", ""},
+			counts:  []uint64{90, 60, 0, 30},
+			options: options,
+			file:    "routes_history_output.golden",
+		}, t)
+	})
+
+	options = newRoutesOptions()
+	options.route = "^/[ab]$"
+	t.Run("Returns the stats of the routes matching --route", func(t *testing.T) {
+		testRoutesCall(routesParamsExp{
+			routes:  []string{"/a", "/b", "/c", ""},
+			counts:  []uint64{90, 60, 0, 30},
+			options: options,
+			file:    "routes_filtered_output.golden",
+		}, t)
+	})
+
+	options = newRoutesOptions()
+	options.allNamespaces = true
+	t.Run("Returns the route stats of all the deployments, grouped by namespace", func(t *testing.T) {
+		testRoutesCall(routesParamsExp{
+			routes:  []string{"/a", "/b", "/a", "/c"},
+			counts:  []uint64{90, 60, 30, 0},
+			options: options,
+			target:  "deploy",
+			resources: []*pb.Resource{
+				{Namespace: "emojivoto", Type: "deployment", Name: "web"},
+				{Namespace: "books", Type: "deployment", Name: "webapp"},
+				{Namespace: "books", Type: "deployment", Name: "traffic"},
+				{Namespace: "books", Type: "deployment", Name: "webapp"},
+			},
+			file: "routes_all_namespaces_output.golden",
+		}, t)
+	})
+
+	t.Run("Rejects named resources with --all-namespaces", func(t *testing.T) {
+		options := newRoutesOptions()
+		options.allNamespaces = true
+		if _, err := buildTopRoutesRequests("deploy/foobar", options); err == nil {
+			t.Fatal("Expected an error for a named resource across all namespaces")
+		}
+	})
+
+	t.Run("Rejects invalid --route regular expressions", func(t *testing.T) {
+		options := newRoutesOptions()
+		options.route = "/a("
+		if _, err := buildTopRoutesRequests("deploy/foobar", options); err == nil {
+			t.Fatal("Expected an error for an invalid regular expression")
+		}
+	})
+
+	t.Run("Rejects unknown output formats", func(t *testing.T) {
+		options := newRoutesOptions()
+		options.outputFormat = "xml"
+		if _, err := buildTopRoutesRequests("deploy/foobar", options); err == nil {
+			t.Fatal("Expected an error for the xml output format")
+		}
+	})
+
+	t.Run("Rejects --show-histogram with the csv output format", func(t *testing.T) {
+		options := newRoutesOptions()
+		options.showHistogram = true
+		options.outputFormat = csvOutput
+		if _, err := buildTopRoutesRequests("deploy/foobar", options); err == nil {
+			t.Fatal("Expected an error for --show-histogram with the csv output format")
+		}
+	})
+}
+
+func TestBuildTopRoutesRequests(t *testing.T) {
+	t.Run("Builds a request per destination", func(t *testing.T) {
+		options := newRoutesOptions()
+		options.toResources = []string{"svc/webapp", "svc/books", "svc/webapp"}
+
+		reqs, err := buildTopRoutesRequests("deploy/traffic", options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		destinations := []string{}
+		for _, req := range reqs {
+			destinations = append(destinations, req.GetToAuthority())
+		}
+		expected := []string{"webapp.default.svc.cluster.local", "books.default.svc.cluster.local"}
+		if !reflect.DeepEqual(destinations, expected) {
+			t.Fatalf("Expected destinations %v, got %v", expected, destinations)
+		}
+		if !options.dstIsService {
+			t.Fatal("Expected the destinations to be shown as services")
+		}
+	})
+
+	t.Run("Shows the authorities of mixed destinations", func(t *testing.T) {
+		options := newRoutesOptions()
+		options.toResources = []string{"svc/webapp", "au/books.example.com"}
+
+		reqs, err := buildTopRoutesRequests("deploy/traffic", options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(reqs) != 2 || options.dstIsService {
+			t.Fatalf("Expected 2 requests to authorities, got %v", reqs)
+		}
+	})
+
+	t.Run("Selects the destinations other than services by resource", func(t *testing.T) {
+		options := newRoutesOptions()
+		options.toNamespace = "books"
+		options.toResources = []string{"sts/books", "svc/webapp"}
+
+		reqs, err := buildTopRoutesRequests("deploy/traffic", options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := &pb.Resource{Namespace: "books", Type: k8s.StatefulSet, Name: "books"}
+		if len(reqs) != 2 || !proto.Equal(reqs[0].GetToResource(), expected) {
+			t.Fatalf("Expected a request to %v, got %v", expected, reqs)
+		}
+		if reqs[1].GetToAuthority() != "webapp.books.svc.cluster.local" {
+			t.Fatalf("Expected a request to the webapp service, got %v", reqs[1])
+		}
+		if options.dstIsService {
+			t.Fatal("Expected the destinations to be shown as authorities")
+		}
+	})
+
+	t.Run("Rejects all the authorities with other destinations", func(t *testing.T) {
+		options := newRoutesOptions()
+		options.toResources = []string{"au", "svc/webapp"}
+
+		if _, err := buildTopRoutesRequests("deploy/traffic", options); err == nil {
+			t.Fatal("Expected an error")
+		}
+	})
+}
+
+func TestSortRoutes(t *testing.T) {
+	newTable := func() []*rowStats {
+		return []*rowStats{
+			{route: "/a", dst: "web", latencyP99: 20, successRate: 0.5},
+			{route: "/b", dst: "web", latencyP99: 90, successRate: 1},
+			{route: "/c", dst: "web", latencyP99: 20, successRate: 0.9},
+		}
+	}
+	routes := func(table []*rowStats) []string {
+		names := []string{}
+		for _, row := range table {
+			names = append(names, row.route)
+		}
+		return names
+	}
+
+	expectations := []struct {
+		sortBy   string
+		reverse  bool
+		expected []string
+	}{
+		{"route", false, []string{"/a", "/b", "/c"}},
+		{"route", true, []string{"/c", "/b", "/a"}},
+		{"success", false, []string{"/a", "/c", "/b"}},
+		// routes with the same latency stay sorted by route
+		{"p99", true, []string{"/b", "/a", "/c"}},
+	}
+
+	for _, exp := range expectations {
+		table := newTable()
+		sortRoutes(table, exp.sortBy, exp.reverse)
+		if !reflect.DeepEqual(routes(table), exp.expected) {
+			t.Fatalf("Expected --sort-by %s (reverse: %t) to order the routes as %v, got %v", exp.sortBy, exp.reverse, exp.expected, routes(table))
+		}
+	}
+
+	options := newRoutesOptions()
+	options.sortBy = "latency"
+	if _, err := buildTopRoutesRequests("deploy/foobar", options); err == nil {
+		t.Fatal("Expected an error for an unknown --sort-by column")
+	}
+}
+
+func TestCheckRouteThresholds(t *testing.T) {
+	newRows := func() []*pb.RouteTable_Row {
+		response := public.GenTopRoutesResponse([]string{"/a", "/b", "/c", ""}, []uint64{90, 60, 0, 30})
+		rows := response.GetRoutes().Rows
+		// 90% success for /b
+		rows[1].Stats.FailureCount = 60 * 9
+		rows[1].Stats.SuccessCount = 60 * 81
+		return rows
+	}
+
+	t.Run("Passes without thresholds", func(t *testing.T) {
+		if err := checkRouteThresholds(newRows(), newRoutesOptions()); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Reports the routes below the success rate threshold", func(t *testing.T) {
+		options := newRoutesOptions()
+		options.failIfSuccessBelow = 99.5
+		err := checkRouteThresholds(newRows(), options)
+		expected := "the route stats violate the thresholds:\n  /b to foo.default.svc.cluster.local: success rate 90.00% is below 99.50%"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error [%s], got [%v]", expected, err)
+		}
+		if e, ok := err.(*cliError); !ok || e.code != exitCodeThresholdViolated {
+			t.Fatalf("Expected exit code %d, got %v", exitCodeThresholdViolated, err)
+		}
+	})
+
+	t.Run("Reports the routes above the p99 threshold, skipping the routes without traffic", func(t *testing.T) {
+		options := newRoutesOptions()
+		options.failIfP99Above = 100 * time.Millisecond
+		err := checkRouteThresholds(newRows(), options)
+		expected := `the route stats violate the thresholds:
+  /a to foo.default.svc.cluster.local: p99 latency 123ms is above 100ms
+  /b to foo.default.svc.cluster.local: p99 latency 123ms is above 100ms
+  [UNKNOWN] to foo.default.svc.cluster.local: p99 latency 123ms is above 100ms`
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error [%s], got [%v]", expected, err)
+		}
+	})
+
+	t.Run("Only checks the routes matching --route", func(t *testing.T) {
+		options := newRoutesOptions()
+		options.route = "^/a$"
+		options.failIfSuccessBelow = 99.5
+		if _, err := buildTopRoutesRequests("deploy/foobar", options); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if err := checkRouteThresholds(newRows(), options); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Rejects invalid thresholds", func(t *testing.T) {
+		options := newRoutesOptions()
+		options.failIfSuccessBelow = 150
+		if _, err := buildTopRoutesRequests("deploy/foobar", options); err == nil {
+			t.Fatal("Expected an error for a success rate above 100%")
+		}
+
+		options = newRoutesOptions()
+		options.failIfP99Above = time.Second
+		options.watch = true
+		if _, err := buildTopRoutesRequests("deploy/foobar", options); err == nil {
+			t.Fatal("Expected an error for a threshold with --watch")
+		}
+	})
+}
+
+func TestSuccessTrend(t *testing.T) {
+	nan := math.NaN()
+	expectations := map[string][]float64{
+		"▁▄▆█ ↑":  {0.9, 0.95, 0.97, 1},
+		"█ ▁▁ ↓":  {1, nan, 0.5, 0.5},
+		"▅▅▅▅ →":  {1, 1, 1, 1},
+		"-":       {nan, nan, nan, nan},
+		"  ▅ ▅ →": {nan, nan, 0.99, nan, 0.99},
+	}
+
+	for expected, history := range expectations {
+		if trend := successTrend(history); trend != expected {
+			t.Fatalf("Expected the trend of %v to be %q, got %q", history, expected, trend)
+		}
+	}
+
+	options := newRoutesOptions()
+	options.history = true
+	if _, err := buildTopRoutesRequests("deploy/foobar", options); err == nil {
+		t.Fatal("Expected an error for --history with a 1m time window")
+	}
+}
+
+func TestWatchRoutes(t *testing.T) {
+	options := newRoutesOptions()
+	options.watch = true
+	options.watchInterval = time.Hour
+
+	mockClient := &public.MockApiClient{}
+	response := public.GenTopRoutesResponse([]string{"/a"}, []uint64{90})
+	mockClient.TopRoutesResponseToReturn = &response
+
+	reqs, err := buildTopRoutesRequests("deploy/foobar", options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// an interrupted watch stops after drawing the first table
+	ctx, cancel := cliContext, cancelCliContext
+	cliContext, cancelCliContext = context.WithCancel(context.Background())
+	cancelCliContext()
+	defer func() { cliContext, cancelCliContext = ctx, cancel }()
+
+	var buf bytes.Buffer
+	if err := watchRouteStats(mockClient, reqs, options, &buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	output := buf.String()
+	if !strings.HasPrefix(output, clearScreen+"Every 1h0m0s, last updated ") {
+		t.Fatalf("Expected the table to be redrawn, got: %q", output)
+	}
+	if strings.Count(output, "/a ") != 1 {
+		t.Fatalf("Expected a single table, got: %q", output)
+	}
+
+	options.outputFormat = jsonOutput
+	if _, err := buildTopRoutesRequests("deploy/foobar", options); err == nil {
+		t.Fatal("Expected an error for --watch with the json output format")
+	}
 }
 
 func testRoutesCall(exp routesParamsExp, t *testing.T) {
@@ -40,14 +460,30 @@ func testRoutesCall(exp routesParamsExp, t *testing.T) {
 
 	response := public.GenTopRoutesResponse(exp.routes, exp.counts)
 
+	for i, resource := range exp.resources {
+		response.GetRoutes().Rows[i].Resource = resource
+	}
+
+	for i, byStatus := range exp.grpcStatus {
+		response.GetRoutes().Rows[i].ResponsesByGrpcStatus = byStatus
+	}
+
+	for i, histogram := range exp.histograms {
+		response.GetRoutes().Rows[i].LatencyHistogram = histogram
+	}
+
 	mockClient.TopRoutesResponseToReturn = &response
 
-	req, err := buildTopRoutesRequest("deploy/foobar", exp.options)
+	target := exp.target
+	if target == "" {
+		target = "deploy/foobar"
+	}
+	reqs, err := buildTopRoutesRequests(target, exp.options)
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 
-	output, err := requestRouteStatsFromAPI(mockClient, req, exp.options)
+	output, err := requestRouteStatsFromAPI(mockClient, reqs, exp.options)
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
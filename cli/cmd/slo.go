@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+type sloOptions struct {
+	statOptionsBase
+}
+
+func newSLOOptions() *sloOptions {
+	return &sloOptions{
+		statOptionsBase: *newStatOptionsBase(),
+	}
+}
+
+func newCmdSLO() *cobra.Command {
+	options := newSLOOptions()
+
+	cmd := &cobra.Command{
+		Use:   "slo [flags] (SERVICE)",
+		Short: "Display the compliance of a service's route SLOs",
+		Long: `Display the compliance of a service's route SLOs.
+
+The SLOs are declared on the routes of the service's Service Profile, with a
+success rate objective, a p99 latency objective, or both, over a rolling window.
+The remaining error budget is the ratio of the failures the success rate
+objective allows over the window that haven't happened yet.`,
+		Example: `  # SLOs of the webapp service in the test namespace.
+  linkerd slo webapp -n test`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req, err := buildRouteSLOsRequest(args[0], options)
+			if err != nil {
+				return newCliError(exitCodeInvalidFlags, fmt.Errorf("error creating SLO request: %v", err))
+			}
+
+			output, err := requestRouteSLOsFromAPI(validatedPublicAPIClient(time.Time{}), req, options)
+			if err != nil {
+				return err
+			}
+
+			_, err = fmt.Print(output)
+
+			return err
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the specified service")
+	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, outputFormatHelp)
+
+	return cmd
+}
+
+func buildRouteSLOsRequest(service string, options *sloOptions) (*pb.RouteSLOsRequest, error) {
+	err := options.validateOutputFormat()
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.RouteSLOsRequest{
+		Selector: &pb.ResourceSelection{
+			Resource: &pb.Resource{
+				Namespace: options.namespace,
+				Type:      k8s.Service,
+				Name:      service,
+			},
+		},
+	}, nil
+}
+
+func requestRouteSLOsFromAPI(client pb.ApiClient, req *pb.RouteSLOsRequest, options *sloOptions) (string, error) {
+	resp, err := client.RouteSLOs(cliContext, req)
+	if err != nil {
+		return "", wrapAPIError("RouteSLOs", err)
+	}
+	if e := resp.GetError(); e != nil {
+		return "", fmt.Errorf("RouteSLOs API response error: %v", e.Error)
+	}
+
+	return renderRouteSLOs(resp.GetOk().GetRows(), options)
+}
+
+func renderRouteSLOs(rows []*pb.RouteSLOTable_Row, options *sloOptions) (string, error) {
+	var buffer bytes.Buffer
+	w := tabwriter.NewWriter(&buffer, 0, 0, padding, ' ', tabwriter.AlignRight)
+	writeRouteSLOsToBuffer(rows, w, options)
+	w.Flush()
+
+	return renderStats(buffer, &options.statOptionsBase)
+}
+
+func writeRouteSLOsToBuffer(rows []*pb.RouteSLOTable_Row, w *tabwriter.Writer, options *sloOptions) {
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].Route < rows[j].Route
+	})
+
+	if isJSONOutput(options.outputFormat) {
+		printRouteSLOsJSON(rows, w)
+		return
+	}
+
+	if len(rows) == 0 {
+		fmt.Fprintln(os.Stderr, "No SLOs found.  You can declare them on the routes of the service's service profile.")
+		os.Exit(exitCodeNoData)
+	}
+
+	routeLength := len("ROUTE")
+	for _, r := range rows {
+		if len(r.Route) > routeLength {
+			routeLength = len(r.Route)
+		}
+	}
+	// template for left-aligning the route column
+	routeTemplate := fmt.Sprintf("%%-%ds", routeLength)
+
+	headers := []string{
+		fmt.Sprintf(routeTemplate, "ROUTE"),
+		"WINDOW",
+		colorizeHeader("SUCCESS"),
+		"SUCCESS_SLO",
+		"ERROR_BUDGET",
+		"LATENCY_P99",
+		"LATENCY_SLO",
+		"STATUS\t", // trailing \t is required to format last column
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+
+	for _, r := range rows {
+		success, successSLO, errorBudget, latencySLO := colorize(colorDefault, "-"), "-", "-", "-"
+		if r.Stats.GetSuccessCount()+r.Stats.GetFailureCount() > 0 {
+			success = formatSuccessRate(util.GetSuccessRate(r.Stats))
+		}
+		if r.SuccessRateObjective > 0 {
+			successSLO = fmt.Sprintf("%.2f%%", r.SuccessRateObjective*100)
+			errorBudget = fmt.Sprintf("%.1f%%", r.ErrorBudgetRemaining*100)
+		}
+		if r.LatencyMsObjective > 0 {
+			latencySLO = fmt.Sprintf("%dms", r.LatencyMsObjective)
+		}
+		status := "OK"
+		if !r.Compliant {
+			status = "VIOLATED"
+		}
+
+		fmt.Fprintf(w, routeTemplate+"\t%s\t%s\t%s\t%s\t%dms\t%s\t%s\t\n",
+			r.Route,
+			r.TimeWindow,
+			success,
+			successSLO,
+			errorBudget,
+			r.Stats.GetLatencyMsP99(),
+			latencySLO,
+			status,
+		)
+	}
+}
+
+// Using pointers there where the value is NA and the corresponding json is null
+type jsonRouteSLO struct {
+	Route                string   `json:"route"`
+	Window               string   `json:"window"`
+	Success              *float64 `json:"success"`
+	SuccessObjective     *float64 `json:"success_objective"`
+	ErrorBudgetRemaining *float64 `json:"error_budget_remaining"`
+	LatencyMSp99         uint64   `json:"latency_ms_p99"`
+	LatencyMSObjective   *uint64  `json:"latency_ms_objective"`
+	Compliant            bool     `json:"compliant"`
+}
+
+func printRouteSLOsJSON(rows []*pb.RouteSLOTable_Row, w *tabwriter.Writer) {
+	// avoid nil initialization so that if there are not stats it gets marshalled as an empty array vs null
+	entries := []*jsonRouteSLO{}
+	for _, r := range rows {
+		entry := &jsonRouteSLO{
+			Route:        r.Route,
+			Window:       r.TimeWindow,
+			LatencyMSp99: r.Stats.GetLatencyMsP99(),
+			Compliant:    r.Compliant,
+		}
+		if r.Stats.GetSuccessCount()+r.Stats.GetFailureCount() > 0 {
+			success := util.GetSuccessRate(r.Stats)
+			entry.Success = &success
+		}
+		if r.SuccessRateObjective > 0 {
+			entry.SuccessObjective = &r.SuccessRateObjective
+			entry.ErrorBudgetRemaining = &r.ErrorBudgetRemaining
+		}
+		if r.LatencyMsObjective > 0 {
+			entry.LatencyMSObjective = &r.LatencyMsObjective
+		}
+
+		entries = append(entries, entry)
+	}
+	b, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		log.Error(err.Error())
+		return
+	}
+	fmt.Fprintf(w, "%s\n", b)
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/linkerd/linkerd2/controller/api/public"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+)
+
+func TestSLO(t *testing.T) {
+	mockClient := &public.MockApiClient{
+		RouteSLOsResponseToReturn: &pb.RouteSLOsResponse{
+			Response: &pb.RouteSLOsResponse_Ok{
+				Ok: &pb.RouteSLOTable{
+					Rows: []*pb.RouteSLOTable_Row{
+						{
+							Route:                "GET /books",
+							TimeWindow:           "30d",
+							SuccessRateObjective: 0.999,
+							LatencyMsObjective:   300,
+							Stats:                &pb.BasicStats{SuccessCount: 99950, FailureCount: 50, LatencyMsP99: 120},
+							ErrorBudgetRemaining: 0.5,
+							Compliant:            true,
+						},
+						{
+							Route:                "POST /books",
+							TimeWindow:           "7d",
+							SuccessRateObjective: 0.99,
+							Stats:                &pb.BasicStats{SuccessCount: 970, FailureCount: 30, LatencyMsP99: 450},
+							ErrorBudgetRemaining: -2,
+							Compliant:            false,
+						},
+						{
+							Route:              "DELETE /books/{id}",
+							TimeWindow:         "1d",
+							LatencyMsObjective: 100,
+							Stats:              &pb.BasicStats{},
+							Compliant:          true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	t.Run("Renders the SLOs as a table", func(t *testing.T) {
+		options := newSLOOptions()
+		req, err := buildRouteSLOsRequest("webapp", options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		output, err := requestRouteSLOsFromAPI(mockClient, req, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		diffCompareFile(t, output, "slo_output.golden")
+	})
+
+	t.Run("Renders the SLOs as JSON", func(t *testing.T) {
+		options := newSLOOptions()
+		options.outputFormat = jsonOutput
+		req, err := buildRouteSLOsRequest("webapp", options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		output, err := requestRouteSLOsFromAPI(mockClient, req, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		diffCompareFile(t, output, "slo_output_json.golden")
+	})
+}
@@ -0,0 +1,574 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+	appsV1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	smokeCategory = "linkerd-smoke"
+
+	defaultSmokeNamespace = "linkerd-smoke"
+	smokeAppImage         = "buoyantio/bb:v0.0.1"
+	smokeTerminus         = "smoke-test-terminus"
+	smokeGateway          = "smoke-test-gateway"
+	smokeTraffic          = "smoke-test-traffic"
+	smokeOpaque           = "smoke-test-opaque"
+	smokeOpaqueTraffic    = "smoke-test-opaque-traffic"
+	smokeGatewayPort      = 8080
+	smokeTerminusPort     = 9090
+	smokeOpaquePort       = 7070
+)
+
+// how long to wait between two attempts of a step that is retried
+var smokeRetryWindow = 5 * time.Second
+
+type smokeOptions struct {
+	*injectOptions
+	namespace    string
+	trafficImage string
+	wait         time.Duration
+	keep         bool
+}
+
+func newSmokeOptions() *smokeOptions {
+	return &smokeOptions{
+		injectOptions: newInjectOptions(),
+		namespace:     defaultSmokeNamespace,
+		trafficImage:  defaultBenchImage,
+		wait:          300 * time.Second,
+		keep:          false,
+	}
+}
+
+func (o *smokeOptions) validate() error {
+	if !alphaNumDash.MatchString(o.namespace) {
+		return fmt.Errorf("%s is not a valid namespace", o.namespace)
+	}
+	return o.injectOptions.validate()
+}
+
+// clientNamespace is the namespace of the traffic sent to the test
+// application, so that every request crosses namespaces.
+func (o *smokeOptions) clientNamespace() string {
+	return o.namespace + "-client"
+}
+
+func newCmdSmoke() *cobra.Command {
+	options := newSmokeOptions()
+
+	cmd := &cobra.Command{
+		Use:   "smoke [flags]",
+		Short: "Deploy a test application to verify that the mesh works end to end",
+		Long: `Deploy a test application to verify that the mesh works end to end.
+
+The smoke command deploys a small injected application in two test namespaces:
+an HTTP gateway calling a gRPC backend, and a client sending HTTP requests to
+the gateway from the other namespace. It then checks that the requests on both
+hops succeed, that their metrics reach the control plane, and that they are
+secured with mTLS when the application is injected with --tls=optional. A
+second client sends requests to a server whose port is marked opaque, to check
+that its TCP connections are recorded. The test namespaces are deleted once the
+checks end, unless --keep is set.`,
+		Example: `  # Verify a freshly installed control plane
+  linkerd smoke
+
+  # Verify that the mesh secures the requests of the test application with mTLS
+  linkerd smoke --tls optional`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := options.validate(); err != nil {
+				return newCliError(exitCodeInvalidFlags, err)
+			}
+
+			kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup)
+			if err != nil {
+				return err
+			}
+			client, err := kubeAPI.NewClient()
+			if err != nil {
+				return err
+			}
+			apiClient := validatedPublicAPIClient(time.Time{})
+
+			success := runSmokeSteps(os.Stdout, smokeSteps(kubeAPI, client, apiClient, options), time.Now().Add(options.wait))
+			if !options.keep {
+				result := &healthcheck.CheckResult{
+					Category:    smokeCategory,
+					Description: "can delete the test namespaces",
+					Err:         deleteSmokeNamespaces(kubeAPI, client, options),
+				}
+				printCheckResult(os.Stdout, result)
+				success = success && result.Err == nil
+			}
+
+			fmt.Println("")
+			if !success {
+				fmt.Printf("Smoke test results are %s\n", colorize(colorRed, failStatus))
+				os.Exit(exitCodeCheckFailed)
+			}
+			fmt.Printf("Smoke test results are %s\n", colorize(colorGreen, okStatus))
+
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the test application; the client runs in the namespace with the \"-client\" suffix")
+	cmd.PersistentFlags().StringVar(&options.trafficImage, "traffic-image", options.trafficImage, "Image of the client of the test application, compatible with slow_cooker")
+	cmd.PersistentFlags().DurationVar(&options.wait, "wait", options.wait, "Wait for the test application to become ready and serve traffic")
+	cmd.PersistentFlags().BoolVar(&options.keep, "keep", options.keep, "Do not delete the test namespaces once the checks end")
+	addProxyConfigFlags(cmd, options.proxyConfigOptions)
+
+	return cmd
+}
+
+// smokeStep is a step of the smoke test. A failed step skips the remaining
+// ones, unless it is a warning.
+type smokeStep struct {
+	description string
+
+	// retry the step until the deadline of the smoke test
+	retry bool
+
+	// report the failure of the step without failing the smoke test
+	warning bool
+
+	run func() error
+}
+
+// runSmokeSteps runs the steps in order and prints their results in the
+// format of the check command. It returns false if a step failed.
+func runSmokeSteps(w io.Writer, steps []smokeStep, deadline time.Time) bool {
+	for _, step := range steps {
+		for {
+			err := step.run()
+			result := &healthcheck.CheckResult{
+				Category:    smokeCategory,
+				Description: step.description,
+				Warning:     step.warning,
+				Err:         err,
+			}
+
+			if err != nil && step.retry && time.Now().Before(deadline) {
+				result.Retry = true
+				printCheckResult(w, result)
+				time.Sleep(smokeRetryWindow)
+				continue
+			}
+
+			printCheckResult(w, result)
+			if err != nil && !step.warning {
+				return false
+			}
+			break
+		}
+	}
+
+	return true
+}
+
+func smokeSteps(kubeAPI *k8s.KubernetesAPI, client *http.Client, apiClient pb.ApiClient, options *smokeOptions) []smokeStep {
+	return []smokeStep{
+		{
+			description: "can create the test namespaces",
+			run: func() error {
+				return createSmokeNamespaces(kubeAPI, client, options)
+			},
+		},
+		{
+			description: "can deploy the injected test application",
+			run: func() error {
+				return deploySmokeApp(kubeAPI, client, options)
+			},
+		},
+		{
+			description: "test application pods are ready",
+			retry:       true,
+			run: func() error {
+				pods, err := kubeAPI.GetPodsByNamespace(client, options.namespace)
+				if err != nil {
+					return err
+				}
+				clientPods, err := kubeAPI.GetPodsByNamespace(client, options.clientNamespace())
+				if err != nil {
+					return err
+				}
+				return checkSmokePods(append(pods, clientPods...), 5)
+			},
+		},
+		{
+			description: "HTTP requests across namespaces succeed",
+			retry:       true,
+			run: func() error {
+				return checkSmokeEdge(apiClient, options.clientNamespace(), smokeTraffic, options.namespace, smokeGateway, false)
+			},
+		},
+		{
+			description: "gRPC requests succeed",
+			retry:       true,
+			run: func() error {
+				return checkSmokeEdge(apiClient, options.namespace, smokeGateway, options.namespace, smokeTerminus, false)
+			},
+		},
+		{
+			description: "TCP connections to opaque ports are recorded",
+			retry:       true,
+			run: func() error {
+				return checkSmokeTCP(apiClient, options.namespace, smokeOpaque)
+			},
+		},
+		{
+			description: "requests are secured with mTLS",
+			warning:     !options.enableTLS(),
+			run: func() error {
+				if !options.enableTLS() {
+					return fmt.Errorf("the test application is injected without --tls=optional")
+				}
+				if err := checkSmokeEdge(apiClient, options.clientNamespace(), smokeTraffic, options.namespace, smokeGateway, true); err != nil {
+					return err
+				}
+				return checkSmokeEdge(apiClient, options.namespace, smokeGateway, options.namespace, smokeTerminus, true)
+			},
+		},
+	}
+}
+
+func createSmokeNamespaces(kubeAPI *k8s.KubernetesAPI, client *http.Client, options *smokeOptions) error {
+	for _, name := range []string{options.namespace, options.clientNamespace()} {
+		exists, err := kubeAPI.NamespaceExists(client, name)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("the namespace %s already exists; delete it or pick another --namespace", name)
+		}
+
+		ns := &v1.Namespace{
+			TypeMeta:   metaV1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+			ObjectMeta: metaV1.ObjectMeta{Name: name},
+		}
+		body, err := yaml.Marshal(ns)
+		if err != nil {
+			return err
+		}
+		body, err = yaml.YAMLToJSON(body)
+		if err != nil {
+			return err
+		}
+		if err := kubeAPI.CreateObject(client, "/api/v1/namespaces", body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func deleteSmokeNamespaces(kubeAPI *k8s.KubernetesAPI, client *http.Client, options *smokeOptions) error {
+	for _, name := range []string{options.namespace, options.clientNamespace()} {
+		if err := kubeAPI.DeleteObject(client, "/api/v1/namespaces/"+name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// smokeObject is an object of the test application and the path of the list
+// it is created in.
+type smokeObject struct {
+	path string
+	obj  interface{}
+}
+
+// buildSmokeApp returns the objects of the test application: a gRPC
+// terminus, an HTTP gateway calling it, and a client sending requests to the
+// gateway from the client namespace. A second client sends requests to an
+// HTTP terminus whose port is marked opaque, so that the proxy forwards them
+// as plain TCP.
+func buildSmokeApp(options *smokeOptions) []smokeObject {
+	ns := options.namespace
+	clientNs := options.clientNamespace()
+
+	opaque := buildSmokeDeployment(ns, smokeOpaque, v1.Container{
+		Name:  "terminus",
+		Image: smokeAppImage,
+		Args:  []string{"terminus", "--h1-server-port", fmt.Sprintf("%d", smokeOpaquePort), "--response-text", "BANANA"},
+		Ports: []v1.ContainerPort{{ContainerPort: smokeOpaquePort}},
+	})
+	opaque.Spec.Template.Annotations = map[string]string{
+		k8s.ProxyOpaquePortsAnnotation: fmt.Sprintf("%d", smokeOpaquePort),
+	}
+
+	return []smokeObject{
+		{
+			path: fmt.Sprintf("/apis/apps/v1/namespaces/%s/deployments", ns),
+			obj: buildSmokeDeployment(ns, smokeTerminus, v1.Container{
+				Name:  "terminus",
+				Image: smokeAppImage,
+				Args:  []string{"terminus", "--grpc-server-port", fmt.Sprintf("%d", smokeTerminusPort), "--response-text", "BANANA"},
+				Ports: []v1.ContainerPort{{ContainerPort: smokeTerminusPort}},
+			}),
+		},
+		{
+			path: fmt.Sprintf("/api/v1/namespaces/%s/services", ns),
+			obj:  buildSmokeService(ns, smokeTerminus, "grpc", smokeTerminusPort),
+		},
+		{
+			path: fmt.Sprintf("/apis/apps/v1/namespaces/%s/deployments", ns),
+			obj: buildSmokeDeployment(ns, smokeGateway, v1.Container{
+				Name:  "gateway",
+				Image: smokeAppImage,
+				Args: []string{
+					"point-to-point-channel",
+					"--grpc-downstream-server", fmt.Sprintf("%s:%d", smokeTerminus, smokeTerminusPort),
+					"--h1-server-port", fmt.Sprintf("%d", smokeGatewayPort),
+				},
+				Ports: []v1.ContainerPort{{ContainerPort: smokeGatewayPort}},
+			}),
+		},
+		{
+			path: fmt.Sprintf("/api/v1/namespaces/%s/services", ns),
+			obj:  buildSmokeService(ns, smokeGateway, "http", smokeGatewayPort),
+		},
+		{
+			path: fmt.Sprintf("/apis/apps/v1/namespaces/%s/deployments", clientNs),
+			obj: buildSmokeDeployment(clientNs, smokeTraffic, v1.Container{
+				Name:  "traffic",
+				Image: options.trafficImage,
+				Args: []string{
+					"-qps", "5",
+					"-concurrency", "1",
+					fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", smokeGateway, ns, smokeGatewayPort),
+				},
+			}),
+		},
+		{
+			path: fmt.Sprintf("/apis/apps/v1/namespaces/%s/deployments", ns),
+			obj:  opaque,
+		},
+		{
+			path: fmt.Sprintf("/api/v1/namespaces/%s/services", ns),
+			obj:  buildSmokeService(ns, smokeOpaque, "tcp", smokeOpaquePort),
+		},
+		{
+			path: fmt.Sprintf("/apis/apps/v1/namespaces/%s/deployments", clientNs),
+			obj: buildSmokeDeployment(clientNs, smokeOpaqueTraffic, v1.Container{
+				Name:  "traffic",
+				Image: options.trafficImage,
+				Args: []string{
+					"-qps", "5",
+					"-concurrency", "1",
+					fmt.Sprintf("http://%s.%s.svc.cluster.local:%d", smokeOpaque, ns, smokeOpaquePort),
+				},
+			}),
+		},
+	}
+}
+
+func buildSmokeDeployment(namespace, name string, container v1.Container) *appsV1.Deployment {
+	labels := map[string]string{"app": name}
+	replicas := int32(1)
+
+	return &appsV1.Deployment{
+		TypeMeta: metaV1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		Spec: appsV1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metaV1.LabelSelector{MatchLabels: labels},
+			Template: v1.PodTemplateSpec{
+				ObjectMeta: metaV1.ObjectMeta{Labels: labels},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{container},
+				},
+			},
+		},
+	}
+}
+
+func buildSmokeService(namespace, name, portName string, port int32) *v1.Service {
+	return &v1.Service{
+		TypeMeta: metaV1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metaV1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: v1.ServiceSpec{
+			Selector: map[string]string{"app": name},
+			Ports: []v1.ServicePort{
+				{Name: portName, Port: port, TargetPort: intstr.FromInt(int(port))},
+			},
+		},
+	}
+}
+
+// deploySmokeApp creates the objects of the test application, injecting the
+// proxy into its deployments.
+func deploySmokeApp(kubeAPI *k8s.KubernetesAPI, client *http.Client, options *smokeOptions) error {
+	for _, o := range buildSmokeApp(options) {
+		body, err := yaml.Marshal(o.obj)
+		if err != nil {
+			return err
+		}
+		if _, ok := o.obj.(*appsV1.Deployment); ok {
+			body, _, err = injectResource(body, nil, options.injectOptions)
+			if err != nil {
+				return err
+			}
+		}
+		body, err = yaml.YAMLToJSON(body)
+		if err != nil {
+			return err
+		}
+		if err := kubeAPI.CreateObject(client, o.path, body); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkSmokePods returns an error unless the expected number of pods are
+// running with all their containers, including the proxy, ready.
+func checkSmokePods(pods []v1.Pod, expected int) error {
+	ready := 0
+	for _, pod := range pods {
+		if pod.Status.Phase != v1.PodRunning {
+			continue
+		}
+
+		proxy := false
+		allReady := true
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.Name == k8s.ProxyContainerName {
+				proxy = true
+			}
+			allReady = allReady && status.Ready
+		}
+		if !proxy {
+			return fmt.Errorf("the pod %s/%s is not injected with the proxy", pod.Namespace, pod.Name)
+		}
+		if allReady {
+			ready++
+		}
+	}
+
+	if ready < expected {
+		return fmt.Errorf("%d of %d pods are ready", ready, expected)
+	}
+	return nil
+}
+
+// checkSmokeEdge returns an error unless the requests from a deployment to
+// another were recorded and succeeded, and, if tls is set, were all secured
+// with mTLS.
+func checkSmokeEdge(apiClient pb.ApiClient, fromNamespace, from, toNamespace, to string, tls bool) error {
+	req, err := util.BuildStatSummaryRequest(util.StatsSummaryRequestParams{
+		StatsBaseRequestParams: util.StatsBaseRequestParams{
+			TimeWindow:   "1m",
+			ResourceName: to,
+			ResourceType: k8s.Deployment,
+			Namespace:    toNamespace,
+		},
+		FromNamespace: fromNamespace,
+		FromType:      k8s.Deployment,
+		FromName:      from,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := apiClient.StatSummary(cliContext, req)
+	if err != nil {
+		return err
+	}
+
+	return validateSmokeEdge(resp, from, to, tls)
+}
+
+func validateSmokeEdge(resp *pb.StatSummaryResponse, from, to string, tls bool) error {
+	if e := resp.GetError(); e != nil {
+		return fmt.Errorf("StatSummary API response error: %v", e.Error)
+	}
+
+	stats := &pb.BasicStats{}
+	for _, table := range resp.GetOk().GetStatTables() {
+		for _, row := range table.GetPodGroup().GetRows() {
+			stats.SuccessCount += row.GetStats().GetSuccessCount()
+			stats.FailureCount += row.GetStats().GetFailureCount()
+			stats.TlsRequestCount += row.GetStats().GetTlsRequestCount()
+		}
+	}
+
+	total := stats.SuccessCount + stats.FailureCount
+	if total == 0 {
+		return fmt.Errorf("no requests from %s to %s were recorded", from, to)
+	}
+	if stats.FailureCount > 0 {
+		return fmt.Errorf("%d of %d requests from %s to %s failed", stats.FailureCount, total, from, to)
+	}
+	if tls && stats.TlsRequestCount < total {
+		return fmt.Errorf("%d of %d requests from %s to %s were secured with mTLS", stats.TlsRequestCount, total, from, to)
+	}
+	return nil
+}
+
+// checkSmokeTCP returns an error unless the TCP connections accepted by a
+// deployment were recorded, which is the only metric of its opaque ports.
+func checkSmokeTCP(apiClient pb.ApiClient, namespace, name string) error {
+	req, err := util.BuildStatSummaryRequest(util.StatsSummaryRequestParams{
+		StatsBaseRequestParams: util.StatsBaseRequestParams{
+			TimeWindow:   "1m",
+			ResourceName: name,
+			ResourceType: k8s.Deployment,
+			Namespace:    namespace,
+		},
+		TCPStats: true,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := apiClient.StatSummary(cliContext, req)
+	if err != nil {
+		return err
+	}
+
+	return validateSmokeTCP(resp, name)
+}
+
+func validateSmokeTCP(resp *pb.StatSummaryResponse, name string) error {
+	if e := resp.GetError(); e != nil {
+		return fmt.Errorf("StatSummary API response error: %v", e.Error)
+	}
+
+	stats := &pb.TcpStats{}
+	for _, table := range resp.GetOk().GetStatTables() {
+		for _, row := range table.GetPodGroup().GetRows() {
+			stats.ReadBytes += row.GetTcpStats().GetReadBytes()
+			stats.WriteBytes += row.GetTcpStats().GetWriteBytes()
+		}
+	}
+
+	if stats.ReadBytes == 0 {
+		return fmt.Errorf("no TCP traffic to %s was recorded", name)
+	}
+	if stats.WriteBytes == 0 {
+		return fmt.Errorf("no TCP traffic from %s was recorded", name)
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	appsV1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+)
+
+func TestRunSmokeSteps(t *testing.T) {
+	defer func(window time.Duration) { smokeRetryWindow = window }(smokeRetryWindow)
+	smokeRetryWindow = 0
+
+	attempts := 0
+	steps := []smokeStep{
+		{
+			description: "first step",
+			run:         func() error { return nil },
+		},
+		{
+			description: "retried step",
+			retry:       true,
+			run: func() error {
+				attempts++
+				if attempts < 2 {
+					return errors.New("not yet")
+				}
+				return nil
+			},
+		},
+		{
+			description: "warning step",
+			warning:     true,
+			run:         func() error { return errors.New("reported") },
+		},
+		{
+			description: "failed step",
+			run:         func() error { return errors.New("failed") },
+		},
+		{
+			description: "skipped step",
+			run:         func() error { return nil },
+		},
+	}
+
+	var output bytes.Buffer
+	success := runSmokeSteps(&output, steps, time.Now().Add(time.Minute))
+	if success {
+		t.Fatalf("Expected the smoke test to fail")
+	}
+
+	diffCompareFile(t, output.String(), "smoke_steps.golden")
+}
+
+func TestBuildSmokeApp(t *testing.T) {
+	options := newSmokeOptions()
+	options.namespace = "smoke"
+
+	objects := buildSmokeApp(options)
+	if len(objects) != 8 {
+		t.Fatalf("Expected 8 objects, got %d", len(objects))
+	}
+
+	traffic, ok := objects[4].obj.(*appsV1.Deployment)
+	if !ok {
+		t.Fatalf("Expected the client to be a deployment, got %T", objects[4].obj)
+	}
+	if traffic.Namespace != "smoke-client" {
+		t.Fatalf("Expected the client in the smoke-client namespace, got %s", traffic.Namespace)
+	}
+	target := traffic.Spec.Template.Spec.Containers[0].Args[4]
+	if target != "http://smoke-test-gateway.smoke.svc.cluster.local:8080" {
+		t.Fatalf("Unexpected target of the client: %s", target)
+	}
+
+	opaque, ok := objects[5].obj.(*appsV1.Deployment)
+	if !ok {
+		t.Fatalf("Expected the opaque server to be a deployment, got %T", objects[5].obj)
+	}
+	if ports := opaque.Spec.Template.Annotations[k8s.ProxyOpaquePortsAnnotation]; ports != "7070" {
+		t.Fatalf("Expected the port 7070 of the server to be opaque, got [%s]", ports)
+	}
+}
+
+func TestCheckSmokePods(t *testing.T) {
+	pod := func(name string, injected, ready bool) v1.Pod {
+		statuses := []v1.ContainerStatus{{Name: "app", Ready: ready}}
+		if injected {
+			statuses = append(statuses, v1.ContainerStatus{Name: k8s.ProxyContainerName, Ready: ready})
+		}
+		p := v1.Pod{Status: v1.PodStatus{Phase: v1.PodRunning, ContainerStatuses: statuses}}
+		p.Name = name
+		return p
+	}
+
+	testCases := []struct {
+		pods        []v1.Pod
+		expectedErr string
+	}{
+		{[]v1.Pod{pod("a", true, true), pod("b", true, true)}, ""},
+		{[]v1.Pod{pod("a", true, true), pod("b", true, false)}, "1 of 2 pods are ready"},
+		{[]v1.Pod{pod("a", true, true), pod("b", false, true)}, "the pod /b is not injected with the proxy"},
+	}
+
+	for _, tc := range testCases {
+		err := checkSmokePods(tc.pods, 2)
+		if tc.expectedErr == "" && err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if tc.expectedErr != "" && (err == nil || err.Error() != tc.expectedErr) {
+			t.Fatalf("Expected error [%s], got [%v]", tc.expectedErr, err)
+		}
+	}
+}
+
+func TestValidateSmokeEdge(t *testing.T) {
+	response := func(stats *pb.BasicStats) *pb.StatSummaryResponse {
+		return &pb.StatSummaryResponse{
+			Response: &pb.StatSummaryResponse_Ok_{
+				Ok: &pb.StatSummaryResponse_Ok{
+					StatTables: []*pb.StatTable{
+						{
+							Table: &pb.StatTable_PodGroup_{
+								PodGroup: &pb.StatTable_PodGroup{
+									Rows: []*pb.StatTable_PodGroup_Row{{Stats: stats}},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		stats       *pb.BasicStats
+		tls         bool
+		expectedErr string
+	}{
+		{&pb.BasicStats{}, false, "no requests from client to server were recorded"},
+		{&pb.BasicStats{SuccessCount: 9, FailureCount: 1}, false, "1 of 10 requests from client to server failed"},
+		{&pb.BasicStats{SuccessCount: 10, TlsRequestCount: 5}, false, ""},
+		{&pb.BasicStats{SuccessCount: 10, TlsRequestCount: 5}, true, "5 of 10 requests from client to server were secured with mTLS"},
+		{&pb.BasicStats{SuccessCount: 10, TlsRequestCount: 10}, true, ""},
+	}
+
+	for _, tc := range testCases {
+		err := validateSmokeEdge(response(tc.stats), "client", "server", tc.tls)
+		if tc.expectedErr == "" && err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if tc.expectedErr != "" && (err == nil || err.Error() != tc.expectedErr) {
+			t.Fatalf("Expected error [%s], got [%v]", tc.expectedErr, err)
+		}
+	}
+}
+
+func TestValidateSmokeTCP(t *testing.T) {
+	response := func(stats *pb.TcpStats) *pb.StatSummaryResponse {
+		return &pb.StatSummaryResponse{
+			Response: &pb.StatSummaryResponse_Ok_{
+				Ok: &pb.StatSummaryResponse_Ok{
+					StatTables: []*pb.StatTable{
+						{
+							Table: &pb.StatTable_PodGroup_{
+								PodGroup: &pb.StatTable_PodGroup{
+									Rows: []*pb.StatTable_PodGroup_Row{{TcpStats: stats}},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	testCases := []struct {
+		stats       *pb.TcpStats
+		expectedErr string
+	}{
+		{nil, "no TCP traffic to server was recorded"},
+		{&pb.TcpStats{ReadBytes: 100}, "no TCP traffic from server was recorded"},
+		{&pb.TcpStats{OpenConnections: 1, ReadBytes: 100, WriteBytes: 200}, ""},
+	}
+
+	for _, tc := range testCases {
+		err := validateSmokeTCP(response(tc.stats), "server")
+		if tc.expectedErr == "" && err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if tc.expectedErr != "" && (err == nil || err.Error() != tc.expectedErr) {
+			t.Fatalf("Expected error [%s], got [%v]", tc.expectedErr, err)
+		}
+	}
+}
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"bytes"
-	"context"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -25,6 +24,18 @@ type statOptions struct {
 	fromNamespace string
 	fromResource  string
 	allNamespaces bool
+	labelSelector string
+	tcp           bool
+	unmeshed      bool
+	pods          bool
+	tree          bool
+	grpc          bool
+	byLabel       string
+	// the success rate percentage under which, and the latency over which,
+	// the table output highlights the stats
+	successThreshold float64
+	latencyThreshold time.Duration
+	*multiContextOptions
 }
 
 type indexedResults struct {
@@ -35,12 +46,22 @@ type indexedResults struct {
 
 func newStatOptions() *statOptions {
 	return &statOptions{
-		statOptionsBase: *newStatOptionsBase(),
-		toNamespace:     "",
-		toResource:      "",
-		fromNamespace:   "",
-		fromResource:    "",
-		allNamespaces:   false,
+		statOptionsBase:     *newStatOptionsBase(),
+		toNamespace:         "",
+		toResource:          "",
+		fromNamespace:       "",
+		fromResource:        "",
+		allNamespaces:       false,
+		labelSelector:       "",
+		tcp:                 false,
+		unmeshed:            false,
+		pods:                false,
+		tree:                false,
+		grpc:                false,
+		byLabel:             "",
+		successThreshold:    successRateFailThreshold * 100,
+		latencyThreshold:    0,
+		multiContextOptions: newMultiContextOptions(),
 	}
 }
 
@@ -76,7 +97,8 @@ func newCmdStat() *cobra.Command {
   * replicationcontrollers
   * authorities (not supported in --from)
   * services (only supported if a --from is also specified, or as a --to)
-  * jobs (only supported as a --from or --to)
+  * jobs
+  * cronjobs (aggregates the pods of all the jobs created by the cronjob)
   * all (all resource types, not supported in --from or --to)
 
 This command will hide resources that have completed, such as pods that are in the Succeeded or Failed phases.
@@ -90,6 +112,9 @@ If no resource name is specified, displays stats about all resources of the spec
   # Get all namespaces.
   linkerd stat namespaces
 
+  # Get the nightly-backup cronjob in the test namespace, across all of its runs.
+  linkerd stat cronjobs nightly-backup -n test
+
   # Get all inbound stats to the web deployment.
   linkerd stat deploy/web
 
@@ -111,41 +136,78 @@ If no resource name is specified, displays stats about all resources of the spec
   # Get all namespaces that receive traffic from the default namespace.
   linkerd stat namespaces --from ns/default
 
+  # Get all deployments in the test namespace, restricted to the traffic they receive from the frontend namespace.
+  linkerd stat deployments -n test --from-namespace frontend
+
+  # Get all pods in the test namespace, restricted to the traffic they send to the linkerd namespace.
+  linkerd stat pods -n test --to-namespace linkerd
+
   # Get all inbound stats to the test namespace.
-  linkerd stat ns/test`,
+  linkerd stat ns/test
+
+  # Get all inbound stats to the deployments labeled app=web and tier=frontend.
+  linkerd stat deploy --selector app=web,tier=frontend
+
+  # Get the open TCP connections of the redis deployment, and their byte rates.
+  linkerd stat deploy/redis --tcp
+
+  # Get the rate of the requests the deployments of the test namespace receive from unmeshed clients.
+  linkerd stat deploy -n test --unmeshed
+
+  # Get the stats of each pod of the web deployment, to spot a misbehaving replica.
+  linkerd stat deploy/web --pods
+
+  # Get the stats of all the namespaces, with the deployments of each namespace beneath it.
+  linkerd stat ns --tree
+
+  # Get the stats of the deployments in the test namespace during the minute ending 2 hours ago.
+  linkerd stat deploy -n test --at 2h-ago
+
+  # Highlight the success rates under 99.5% in red, and the latencies over 250ms in yellow.
+  linkerd stat deploy -n test --success-threshold 99.5 --latency-threshold 250ms --color always
+
+  # Get the gRPC status codes of the responses of the emoji deployment.
+  linkerd stat deploy/emoji --grpc
+
+  # Compare the canary and stable pods of the emoji deployment, by their version label.
+  linkerd stat deploy/emoji --by-label version`,
 		Args:      cobra.MinimumNArgs(1),
 		ValidArgs: util.ValidTargets,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			reqs, err := buildStatSummaryRequests(args, options)
 			if err != nil {
-				return fmt.Errorf("error creating metrics request while making stats request: %v", err)
+				return newCliError(exitCodeInvalidFlags, fmt.Errorf("error creating metrics request while making stats request: %v", err))
 			}
 
-			// The gRPC client is concurrency-safe, so we can reuse it in all the following goroutines
-			// https://github.com/grpc/grpc-go/issues/682
-			client := validatedPublicAPIClient(time.Time{})
-			c := make(chan indexedResults, len(reqs))
-			for num, req := range reqs {
-				go func(num int, req *pb.StatSummaryRequest) {
-					resp, err := requestStatsFromAPI(client, req, options)
-					rows := respToRows(resp)
-					c <- indexedResults{num, rows, err}
-				}(num, req)
+			if err := options.multiContextOptions.validate(); err != nil {
+				return newCliError(exitCodeInvalidFlags, err)
 			}
 
-			totalRows := make([]*pb.StatTable_PodGroup_Row, 0)
-			i := 0
-			for res := range c {
-				if res.err != nil {
-					return res.err
+			var output string
+			if options.grpc {
+				if options.multiContextOptions.enabled() {
+					return newCliError(exitCodeInvalidFlags, fmt.Errorf("--grpc is not supported across multiple contexts"))
+				}
+				var rows []*pb.StreamStatsTable_Row
+				rows, err = requestStreamStatsFromAPI(validatedPublicAPIClient(time.Time{}), reqs)
+				if err != nil {
+					return err
 				}
-				totalRows = append(totalRows, res.rows...)
-				if i++; i == len(reqs) {
-					close(c)
+				output, err = renderStreamStats(rows, options)
+			} else if options.multiContextOptions.enabled() {
+				output, err = requestMultiContextStats(reqs, options)
+			} else {
+				var rows []*pb.StatTable_PodGroup_Row
+				rows, err = requestAllStatsFromAPI(validatedPublicAPIClient(time.Time{}), reqs, options)
+				if err != nil {
+					return err
 				}
+				output, err = renderStatStats(rows, options)
+			}
+			if err != nil {
+				return err
 			}
 
-			output := renderStatStats(totalRows, options)
 			_, err = fmt.Print(output)
 
 			return err
@@ -155,15 +217,83 @@ If no resource name is specified, displays stats about all resources of the spec
 	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the specified resource")
 	cmd.PersistentFlags().StringVarP(&options.timeWindow, "time-window", "t", options.timeWindow, "Stat window (for example: \"10s\", \"1m\", \"10m\", \"1h\")")
 	cmd.PersistentFlags().StringVar(&options.toResource, "to", options.toResource, "If present, restricts outbound stats to the specified resource name")
-	cmd.PersistentFlags().StringVar(&options.toNamespace, "to-namespace", options.toNamespace, "Sets the namespace used to lookup the \"--to\" resource; by default the current \"--namespace\" is used")
+	cmd.PersistentFlags().StringVar(&options.toNamespace, "to-namespace", options.toNamespace, "Sets the namespace used to lookup the \"--to\" resource; by default the current \"--namespace\" is used. Without \"--to\", restricts outbound stats to the resources of this namespace")
 	cmd.PersistentFlags().StringVar(&options.fromResource, "from", options.fromResource, "If present, restricts outbound stats from the specified resource name")
-	cmd.PersistentFlags().StringVar(&options.fromNamespace, "from-namespace", options.fromNamespace, "Sets the namespace used from lookup the \"--from\" resource; by default the current \"--namespace\" is used")
+	cmd.PersistentFlags().StringVar(&options.fromNamespace, "from-namespace", options.fromNamespace, "Sets the namespace used from lookup the \"--from\" resource; by default the current \"--namespace\" is used. Without \"--from\", restricts outbound stats from the resources of this namespace")
 	cmd.PersistentFlags().BoolVar(&options.allNamespaces, "all-namespaces", options.allNamespaces, "If present, returns stats across all namespaces, ignoring the \"--namespace\" flag")
-	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, "Output format; currently only \"table\" (default) and \"json\" are supported")
+	cmd.PersistentFlags().StringVar(&options.labelSelector, "selector", options.labelSelector, "Selector (label query) to filter the resources on, supports '=', '==', and '!=' (for example: --selector key1=value1,key2=value2)")
+	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, statOutputFormatHelp)
+	cmd.PersistentFlags().BoolVar(&options.tcp, "tcp", options.tcp, "If present, also displays the open TCP connections of the resources, and the rates of the bytes read and written over them")
+	cmd.PersistentFlags().BoolVar(&options.unmeshed, "unmeshed", options.unmeshed, "If present, also displays the rate of the requests the resources receive from unmeshed clients, and the share of their traffic coming from meshed clients")
+	cmd.PersistentFlags().BoolVar(&options.pods, "pods", options.pods, "If present, also displays the stats of each pod of the resources")
+	cmd.PersistentFlags().BoolVar(&options.tree, "tree", options.tree, "If present with the namespace resource type, displays the stats of the deployments of each namespace beneath it")
+	cmd.PersistentFlags().BoolVar(&options.grpc, "grpc", options.grpc, "If present, displays the gRPC responses of the resources by status code")
+	cmd.PersistentFlags().Float64Var(&options.successThreshold, "success-threshold", options.successThreshold, "Highlight the success rates below this percentage in red when the output is colorized, e.g. 99.5")
+	cmd.PersistentFlags().DurationVar(&options.latencyThreshold, "latency-threshold", options.latencyThreshold, "Highlight the latencies above this duration in yellow when the output is colorized, e.g. 250ms; 0 highlights none")
+	cmd.PersistentFlags().StringVar(&options.byLabel, "by-label", options.byLabel, "If present, groups the stats of the pods of the resources by namespace and by the value of this pod label, rather than by resource")
+	addEndTimeFlags(cmd.PersistentFlags(), &options.statOptionsBase)
+	addMultiContextFlags(cmd, options.multiContextOptions)
+	markStatFlagsConfigurable(cmd.PersistentFlags())
 
 	return cmd
 }
 
+// requestAllStatsFromAPI runs the requests concurrently, and returns the rows
+// of all the responses.
+func requestAllStatsFromAPI(client pb.ApiClient, reqs []*pb.StatSummaryRequest, options *statOptions) ([]*pb.StatTable_PodGroup_Row, error) {
+	// The gRPC client is concurrency-safe, so we can reuse it in all the following goroutines
+	// https://github.com/grpc/grpc-go/issues/682
+	c := make(chan indexedResults, len(reqs))
+	for num, req := range reqs {
+		go func(num int, req *pb.StatSummaryRequest) {
+			resp, err := requestStatsFromAPI(client, req, options)
+			rows := respToRows(resp)
+			c <- indexedResults{num, rows, err}
+		}(num, req)
+	}
+
+	totalRows := make([]*pb.StatTable_PodGroup_Row, 0)
+	for range reqs {
+		res := <-c
+		if res.err != nil {
+			return nil, res.err
+		}
+		totalRows = append(totalRows, res.rows...)
+	}
+
+	return totalRows, nil
+}
+
+// requestMultiContextStats runs the requests against every context, and
+// renders the stats of all the clusters in a single table.
+func requestMultiContextStats(reqs []*pb.StatSummaryRequest, options *statOptions) (string, error) {
+	contexts, err := options.multiContextOptions.resolve()
+	if err != nil {
+		return "", err
+	}
+
+	results, err := fanOutContexts(contexts, func(context string) (interface{}, error) {
+		client, err := publicAPIClientForContext(context)
+		if err != nil {
+			return nil, err
+		}
+		return requestAllStatsFromAPI(client, reqs, options)
+	})
+	if err != nil {
+		return "", err
+	}
+
+	clusterRows := make([]clusterStatRows, 0, len(contexts))
+	for _, context := range contexts {
+		clusterRows = append(clusterRows, clusterStatRows{
+			cluster: context,
+			rows:    results[context].([]*pb.StatTable_PodGroup_Row),
+		})
+	}
+
+	return renderClusterStatStats(clusterRows, options)
+}
+
 func respToRows(resp *pb.StatSummaryResponse) []*pb.StatTable_PodGroup_Row {
 	rows := make([]*pb.StatTable_PodGroup_Row, 0)
 	if resp != nil {
@@ -174,10 +304,12 @@ func respToRows(resp *pb.StatSummaryResponse) []*pb.StatTable_PodGroup_Row {
 	return rows
 }
 
+// requestStatsFromAPI requests the stats page by page, and returns the rows of
+// all the pages in a single response.
 func requestStatsFromAPI(client pb.ApiClient, req *pb.StatSummaryRequest, options *statOptions) (*pb.StatSummaryResponse, error) {
-	resp, err := client.StatSummary(context.Background(), req)
+	resp, err := util.GetStatSummary(cliContext, client, req)
 	if err != nil {
-		return nil, fmt.Errorf("StatSummary API error: %v", err)
+		return nil, wrapAPIError("StatSummary", err)
 	}
 	if e := resp.GetError(); e != nil {
 		return nil, fmt.Errorf("StatSummary API response error: %v", e.Error)
@@ -186,10 +318,21 @@ func requestStatsFromAPI(client pb.ApiClient, req *pb.StatSummaryRequest, option
 	return resp, nil
 }
 
-func renderStatStats(rows []*pb.StatTable_PodGroup_Row, options *statOptions) string {
+// clusterStatRows are the stat rows read from a single cluster. The cluster
+// is empty unless stats are read from multiple kubeconfig contexts.
+type clusterStatRows struct {
+	cluster string
+	rows    []*pb.StatTable_PodGroup_Row
+}
+
+func renderStatStats(rows []*pb.StatTable_PodGroup_Row, options *statOptions) (string, error) {
+	return renderClusterStatStats([]clusterStatRows{{rows: rows}}, options)
+}
+
+func renderClusterStatStats(clusterRows []clusterStatRows, options *statOptions) (string, error) {
 	var buffer bytes.Buffer
 	w := tabwriter.NewWriter(&buffer, 0, 0, padding, ' ', tabwriter.AlignRight)
-	writeStatsToBuffer(rows, w, options)
+	writeStatsToBuffer(clusterRows, w, options)
 	w.Flush()
 
 	return renderStats(buffer, &options.statOptionsBase)
@@ -206,11 +349,43 @@ type rowStats struct {
 	latencyP50  uint64
 	latencyP95  uint64
 	latencyP99  uint64
+	// the rate of the requests received from unmeshed clients, and the share
+	// of the requests received from meshed clients, reported by stat with
+	// --unmeshed
+	unmeshedRequestRate float64
+	meshedPercent       float64
+
+	// the number of requests of a route, reported by the wide output of routes
+	requests uint64
+	// the success rates of the sub-windows of the time window, oldest first,
+	// reported by the --history option of routes
+	successHistory []float64
+	// the namespace and name of the resource of a route, reported by routes
+	// when the target is a resource type without a name
+	namespace string
+	name      string
+	// the gRPC response counts by status code name, reported by routes for
+	// the routes of gRPC destinations
+	grpcStatus map[string]uint64
+	// the latency buckets of a route, reported by routes with --show-histogram
+	latencyHistogram []*pb.RouteTable_LatencyBucket
+}
+
+// tcpRowStats are the TCP connection stats of a row, with the byte counts
+// turned into rates over the time window.
+type tcpRowStats struct {
+	openConnections uint64
+	readByteRate    float64
+	writeByteRate   float64
 }
 
 type row struct {
 	meshed string
+	// the pod counts, unless the resource is an authority
+	meshedPods  *uint64
+	runningPods *uint64
 	*rowStats
+	tcp *tcpRowStats
 }
 
 var (
@@ -218,78 +393,174 @@ var (
 	namespaceHeader = "NAMESPACE"
 )
 
-func writeStatsToBuffer(rows []*pb.StatTable_PodGroup_Row, w *tabwriter.Writer, options *statOptions) {
-	maxNameLength := len(nameHeader)
+// writeStatsToBuffer writes the stats of all the clusters. The stat tables are
+// keyed by cluster, namespace and name; the cluster column is only displayed
+// when the rows were read from multiple clusters, in which case
+// maxClusterLength is non-zero.
+func writeStatsToBuffer(clusterRows []clusterStatRows, w *tabwriter.Writer, options *statOptions) {
+	maxNameLength := len(options.nameHeader())
 	maxNamespaceLength := len(namespaceHeader)
+	maxClusterLength := 0
 	statTables := make(map[string]map[string]*row)
 
+	// the rows of the pods of the resources, requested with --pods, are
+	// displayed in a table of their own
+	expanded := make([]clusterStatRows, 0, len(clusterRows))
+	for _, cr := range clusterRows {
+		expanded = append(expanded, clusterStatRows{cluster: cr.cluster, rows: withPodRows(cr.rows)})
+	}
+	clusterRows = expanded
+
 	prefixTypes := make(map[string]bool)
-	for _, r := range rows {
-		prefixTypes[r.Resource.Type] = true
+	for _, cr := range clusterRows {
+		for _, r := range cr.rows {
+			prefixTypes[r.Resource.Type] = true
+		}
+		if cr.cluster != "" && maxClusterLength < len(clusterHeader) {
+			maxClusterLength = len(clusterHeader)
+		}
+		if len(cr.cluster) > maxClusterLength {
+			maxClusterLength = len(cr.cluster)
+		}
 	}
 	usePrefix := false
-	if len(prefixTypes) > 1 {
+	if len(prefixTypes) > 1 && options.byLabel == "" {
 		usePrefix = true
 	}
 
-	for _, r := range rows {
-		name := r.Resource.Name
-		nameWithPrefix := name
-		if usePrefix {
-			nameWithPrefix = getNamePrefix(r.Resource.Type) + nameWithPrefix
-		}
+	for _, cr := range clusterRows {
+		for _, r := range cr.rows {
+			name := r.Resource.Name
+			if options.byLabel != "" {
+				name = labelValueName(r.LabelValue)
+			}
+			nameWithPrefix := name
+			if usePrefix {
+				nameWithPrefix = getNamePrefix(r.Resource.Type) + nameWithPrefix
+			}
 
-		namespace := r.Resource.Namespace
-		key := fmt.Sprintf("%s/%s", namespace, name)
-		resourceKey := r.Resource.Type
+			namespace := r.Resource.Namespace
+			key := fmt.Sprintf("%s/%s/%s", cr.cluster, namespace, name)
+			resourceKey := r.Resource.Type
 
-		if _, ok := statTables[resourceKey]; !ok {
-			statTables[resourceKey] = make(map[string]*row)
-		}
+			if _, ok := statTables[resourceKey]; !ok {
+				statTables[resourceKey] = make(map[string]*row)
+			}
 
-		if len(nameWithPrefix) > maxNameLength {
-			maxNameLength = len(nameWithPrefix)
-		}
+			if len(nameWithPrefix) > maxNameLength {
+				maxNameLength = len(nameWithPrefix)
+			}
 
-		if len(namespace) > maxNamespaceLength {
-			maxNamespaceLength = len(namespace)
-		}
+			if len(namespace) > maxNamespaceLength {
+				maxNamespaceLength = len(namespace)
+			}
 
-		meshedCount := fmt.Sprintf("%d/%d", r.MeshedPodCount, r.RunningPodCount)
-		if resourceKey == k8s.Authority {
-			meshedCount = "-"
-		}
-		statTables[resourceKey][key] = &row{
-			meshed: meshedCount,
-		}
+			meshedCount := fmt.Sprintf("%d/%d", r.MeshedPodCount, r.RunningPodCount)
+			meshedPods, runningPods := &r.MeshedPodCount, &r.RunningPodCount
+			if resourceKey == k8s.Authority {
+				meshedCount = "-"
+				meshedPods, runningPods = nil, nil
+			}
+			statTables[resourceKey][key] = &row{
+				meshed:      meshedCount,
+				meshedPods:  meshedPods,
+				runningPods: runningPods,
+			}
+
+			if r.Stats != nil {
+				statTables[resourceKey][key].rowStats = &rowStats{
+					requestRate: util.GetRequestRate(r.Stats, r.TimeWindow),
+					successRate: util.GetSuccessRate(r.Stats),
+					tlsPercent:  util.GetPercentTls(r.Stats),
+					latencyP50:  r.Stats.LatencyMsP50,
+					latencyP95:  r.Stats.LatencyMsP95,
+					latencyP99:  r.Stats.LatencyMsP99,
+
+					unmeshedRequestRate: util.GetUnmeshedRequestRate(r.Stats, r.TimeWindow),
+					meshedPercent:       util.GetPercentMeshed(r.Stats),
+				}
+			}
 
-		if r.Stats != nil {
-			statTables[resourceKey][key].rowStats = &rowStats{
-				requestRate: util.GetRequestRate(r.Stats, r.TimeWindow),
-				successRate: util.GetSuccessRate(r.Stats),
-				tlsPercent:  util.GetPercentTls(r.Stats),
-				latencyP50:  r.Stats.LatencyMsP50,
-				latencyP95:  r.Stats.LatencyMsP95,
-				latencyP99:  r.Stats.LatencyMsP99,
+			if r.TcpStats != nil {
+				statTables[resourceKey][key].tcp = newTcpRowStats(r.TcpStats, r.TimeWindow)
 			}
 		}
 	}
 
-	switch options.outputFormat {
-	case "table", "":
-		if len(statTables) == 0 {
-			fmt.Fprintln(os.Stderr, "No traffic found.")
-			os.Exit(0)
-		}
-		printStatTables(statTables, w, maxNameLength, maxNamespaceLength, options)
-	case "json":
-		printStatJson(statTables, w)
+	if isJSONOutput(options.outputFormat) {
+		printStatJson(statTables, w, options)
+		return
+	}
+	if options.outputFormat == csvOutput {
+		printStatCSV(statTables, w, maxClusterLength > 0, options)
+		return
+	}
+	if options.outputFormat == prometheusOutput {
+		printStatPrometheus(statTables, w, maxClusterLength > 0, options)
+		return
+	}
+
+	if len(statTables) == 0 {
+		fmt.Fprintln(os.Stderr, "No traffic found.")
+		os.Exit(exitCodeNoData)
+	}
+	printStatTables(statTables, w, maxNameLength, maxNamespaceLength, maxClusterLength, options)
+}
+
+// withPodRows returns the rows, followed by the rows of their pods.
+func withPodRows(rows []*pb.StatTable_PodGroup_Row) []*pb.StatTable_PodGroup_Row {
+	podRows := make([]*pb.StatTable_PodGroup_Row, 0)
+	for _, r := range rows {
+		podRows = append(podRows, r.PodRows...)
+	}
+	if len(podRows) == 0 {
+		return rows
+	}
+	return append(append([]*pb.StatTable_PodGroup_Row{}, rows...), podRows...)
+}
+
+// nameHeader is the header of the NAME column, or the name of the label the
+// stats are grouped by with --by-label.
+func (o *statOptions) nameHeader() string {
+	if o.byLabel != "" {
+		return strings.ToUpper(o.byLabel)
+	}
+	return nameHeader
+}
+
+// labelValueName is the name of the row of the pods with the label value,
+// which is empty for the pods without the label.
+func labelValueName(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
+
+func newTcpRowStats(stats *pb.TcpStats, timeWindow string) *tcpRowStats {
+	tcp := &tcpRowStats{
+		openConnections: stats.OpenConnections,
 	}
+
+	windowLength, err := time.ParseDuration(timeWindow)
+	if err != nil {
+		log.Error(err.Error())
+		return tcp
+	}
+	tcp.readByteRate = float64(stats.ReadBytes) / windowLength.Seconds()
+	tcp.writeByteRate = float64(stats.WriteBytes) / windowLength.Seconds()
+
+	return tcp
 }
 
-func printStatTables(statTables map[string]map[string]*row, w *tabwriter.Writer, maxNameLength int, maxNamespaceLength int, options *statOptions) {
+func printStatTables(statTables map[string]map[string]*row, w *tabwriter.Writer, maxNameLength int, maxNamespaceLength int, maxClusterLength int, options *statOptions) {
+	if options.tree {
+		printStatTree(statTables, w, maxNameLength, maxNamespaceLength, maxClusterLength, options)
+		return
+	}
+
 	usePrefix := false
-	if len(statTables) > 1 {
+	if len(statTables) > 1 && options.byLabel == "" {
 		usePrefix = true
 	}
 
@@ -304,79 +575,206 @@ func printStatTables(statTables map[string]map[string]*row, w *tabwriter.Writer,
 			if !usePrefix {
 				resourceTypeLabel = ""
 			}
-			printSingleStatTable(stats, resourceTypeLabel, w, maxNameLength, maxNamespaceLength, options)
+			printSingleStatTable(stats, resourceTypeLabel, w, maxNameLength, maxNamespaceLength, maxClusterLength, options)
+		}
+	}
+}
+
+func printSingleStatTable(stats map[string]*row, resourceType string, w *tabwriter.Writer, maxNameLength int, maxNamespaceLength int, maxClusterLength int, options *statOptions) {
+	printStatHeaders(w, maxNameLength, maxNamespaceLength, maxClusterLength, options)
+
+	for _, key := range sortStatsKeys(stats) {
+		cluster, namespace, name := clusterNamespaceName(resourceType, key)
+		printStatRow(w, stats[key], cluster, namespace, name, maxNameLength, maxNamespaceLength, maxClusterLength, options)
+	}
+}
+
+// treeIndent indents the deployments beneath their namespace with --tree.
+const treeIndent = "  "
+
+// printStatTree prints the namespaces in a single table, each followed by its
+// deployments, indented beneath it.
+func printStatTree(statTables map[string]map[string]*row, w *tabwriter.Writer, maxNameLength int, maxNamespaceLength int, maxClusterLength int, options *statOptions) {
+	maxNameLength += len(treeIndent)
+	printStatHeaders(w, maxNameLength, maxNamespaceLength, maxClusterLength, options)
+
+	namespaces := statTables[k8s.Namespace]
+	deployments := statTables[k8s.Deployment]
+	deploymentKeys := sortStatsKeys(deployments)
+	for _, nsKey := range sortStatsKeys(namespaces) {
+		cluster, _, nsName := clusterNamespaceName("", nsKey)
+		printStatRow(w, namespaces[nsKey], cluster, nsName, getNamePrefix(k8s.Namespace)+nsName, maxNameLength, maxNamespaceLength, maxClusterLength, options)
+
+		for _, key := range deploymentKeys {
+			deployCluster, namespace, name := clusterNamespaceName(k8s.Deployment, key)
+			if deployCluster != cluster || namespace != nsName {
+				continue
+			}
+			printStatRow(w, deployments[key], cluster, namespace, treeIndent+name, maxNameLength, maxNamespaceLength, maxClusterLength, options)
 		}
 	}
 }
 
-func printSingleStatTable(stats map[string]*row, resourceType string, w *tabwriter.Writer, maxNameLength int, maxNamespaceLength int, options *statOptions) {
+// printStatHeaders prints the header line of the stat tables.
+func printStatHeaders(w *tabwriter.Writer, maxNameLength int, maxNamespaceLength int, maxClusterLength int, options *statOptions) {
 	headers := make([]string, 0)
+	if maxClusterLength > 0 {
+		headers = append(headers,
+			clusterHeader+strings.Repeat(" ", maxClusterLength-len(clusterHeader)))
+	}
 	if options.allNamespaces {
 		headers = append(headers,
 			namespaceHeader+strings.Repeat(" ", maxNamespaceLength-len(namespaceHeader)))
 	}
+	nameColumn := options.nameHeader()
 	headers = append(headers, []string{
-		nameHeader + strings.Repeat(" ", maxNameLength-len(nameHeader)),
+		nameColumn + strings.Repeat(" ", maxNameLength-len(nameColumn)),
 		"MESHED",
-		"SUCCESS",
+		colorizeHeader("SUCCESS"),
 		"RPS",
-		"LATENCY_P50",
-		"LATENCY_P95",
-		"LATENCY_P99",
+		colorizeHeader("LATENCY_P50"),
+		colorizeHeader("LATENCY_P95"),
+		colorizeHeader("LATENCY_P99"),
 		"TLS\t", // trailing \t is required to format last column
 	}...)
+	if options.unmeshed {
+		last := len(headers) - 1
+		headers = append(headers[:last], []string{
+			strings.TrimSuffix(headers[last], "\t"),
+			"UNMESHED_RPS",
+			"MESHED_TRAFFIC\t",
+		}...)
+	}
+	if options.tcp {
+		last := len(headers) - 1
+		headers = append(headers[:last], []string{
+			strings.TrimSuffix(headers[last], "\t"),
+			"TCP_CONN",
+			"READ_BYTES/SEC",
+			"WRITE_BYTES/SEC\t",
+		}...)
+	}
 
 	fmt.Fprintln(w, strings.Join(headers, "\t"))
+}
 
-	sortedKeys := sortStatsKeys(stats)
-	for _, key := range sortedKeys {
-		namespace, name := namespaceName(resourceType, key)
-		values := make([]interface{}, 0)
-		templateString := "%s\t%s\t%.2f%%\t%.1frps\t%dms\t%dms\t%dms\t%.f%%\t\n"
-		templateStringEmpty := "%s\t%s\t-\t-\t-\t-\t-\t-\t\n"
-
-		if options.allNamespaces {
-			values = append(values,
-				namespace+strings.Repeat(" ", maxNamespaceLength-len(namespace)))
-			templateString = "%s\t" + templateString
-			templateStringEmpty = "%s\t" + templateStringEmpty
-		}
-		padding := 0
-		if maxNameLength > len(name) {
-			padding = maxNameLength - len(name)
-		}
+// printStatRow prints the stats of a resource.
+func printStatRow(w *tabwriter.Writer, r *row, cluster, namespace, name string, maxNameLength int, maxNamespaceLength int, maxClusterLength int, options *statOptions) {
+	values := make([]interface{}, 0)
+	templateString := "%s\t%s\t%s\t%.1frps\t%s\t%s\t%s\t%.f%%\t\n"
+	templateStringEmpty := "%s\t%s\t%s\t-\t%s\t%s\t%s\t-\t\n"
+	if options.unmeshed {
+		unmeshedTemplate := "%s\t%s\t\n"
+		templateString = strings.TrimSuffix(templateString, "\n") + unmeshedTemplate
+		templateStringEmpty = strings.TrimSuffix(templateStringEmpty, "\n") + unmeshedTemplate
+	}
+	if options.tcp {
+		tcpTemplate := "%s\t%s\t%s\t\n"
+		templateString = strings.TrimSuffix(templateString, "\n") + tcpTemplate
+		templateStringEmpty = strings.TrimSuffix(templateStringEmpty, "\n") + tcpTemplate
+	}
+
+	if options.allNamespaces {
+		values = append(values,
+			namespace+strings.Repeat(" ", maxNamespaceLength-len(namespace)))
+		templateString = "%s\t" + templateString
+		templateStringEmpty = "%s\t" + templateStringEmpty
+	}
+	if maxClusterLength > 0 {
+		values = append([]interface{}{
+			cluster + strings.Repeat(" ", maxClusterLength-len(cluster))}, values...)
+		templateString = "%s\t" + templateString
+		templateStringEmpty = "%s\t" + templateStringEmpty
+	}
+	padding := 0
+	if maxNameLength > len(name) {
+		padding = maxNameLength - len(name)
+	}
+	values = append(values, []interface{}{
+		name + strings.Repeat(" ", padding),
+		r.meshed,
+	}...)
+
+	if r.rowStats != nil {
 		values = append(values, []interface{}{
-			name + strings.Repeat(" ", padding),
-			stats[key].meshed,
+			formatSuccessRateBelow(r.successRate, options.successThreshold/100),
+			r.requestRate,
+			formatLatency(r.latencyP50, options.latencyThreshold),
+			formatLatency(r.latencyP95, options.latencyThreshold),
+			formatLatency(r.latencyP99, options.latencyThreshold),
+			r.tlsPercent * 100,
 		}...)
+		if options.unmeshed {
+			values = append(values, unmeshedColumns(r.rowStats)...)
+		}
+		if options.tcp {
+			values = append(values, tcpColumns(r.tcp)...)
+		}
+
+		fmt.Fprintf(w, templateString, values...)
+	} else {
+		// colorize the empty success rate and latencies to keep the columns
+		// aligned
+		empty := colorize(colorDefault, "-")
+		values = append(values, empty, empty, empty, empty)
+		if options.unmeshed {
+			values = append(values, unmeshedColumns(r.rowStats)...)
+		}
+		if options.tcp {
+			values = append(values, tcpColumns(r.tcp)...)
+		}
+		fmt.Fprintf(w, templateStringEmpty, values...)
+	}
+}
+
+// unmeshedColumns returns the values of the unmeshed traffic columns of a row.
+func unmeshedColumns(rs *rowStats) []interface{} {
+	if rs == nil {
+		return []interface{}{"-", "-"}
+	}
+	return []interface{}{
+		fmt.Sprintf("%.1frps", rs.unmeshedRequestRate),
+		fmt.Sprintf("%.f%%", rs.meshedPercent*100),
+	}
+}
 
-		if stats[key].rowStats != nil {
-			values = append(values, []interface{}{
-				stats[key].successRate * 100,
-				stats[key].requestRate,
-				stats[key].latencyP50,
-				stats[key].latencyP95,
-				stats[key].latencyP99,
-				stats[key].tlsPercent * 100,
-			}...)
-
-			fmt.Fprintf(w, templateString, values...)
-		} else {
-			fmt.Fprintf(w, templateStringEmpty, values...)
+// tcpColumns returns the values of the TCP columns of a row.
+func tcpColumns(tcp *tcpRowStats) []interface{} {
+	if tcp == nil {
+		return []interface{}{"-", "-", "-"}
+	}
+	return []interface{}{
+		fmt.Sprintf("%d", tcp.openConnections),
+		formatByteRate(tcp.readByteRate),
+		formatByteRate(tcp.writeByteRate),
+	}
+}
+
+func formatByteRate(rate float64) string {
+	units := []string{"B/s", "KiB/s", "MiB/s"}
+	unit := "GiB/s"
+	for _, u := range units {
+		if rate < 1024 {
+			unit = u
+			break
 		}
+		rate /= 1024
 	}
+	return fmt.Sprintf("%.1f%s", rate, unit)
 }
 
-func namespaceName(resourceType string, key string) (string, string) {
+func clusterNamespaceName(resourceType string, key string) (string, string, string) {
 	parts := strings.Split(key, "/")
-	namespace := parts[0]
+	cluster := parts[0]
+	namespace := parts[1]
 	namePrefix := getNamePrefix(resourceType)
-	name := namePrefix + parts[1]
-	return namespace, name
+	name := namePrefix + parts[2]
+	return cluster, namespace, name
 }
 
 // Using pointers there where the value is NA and the corresponding json is null
 type jsonStats struct {
+	Cluster      string   `json:"cluster,omitempty"`
 	Namespace    string   `json:"namespace"`
 	Kind         string   `json:"kind"`
 	Name         string   `json:"name"`
@@ -387,21 +785,37 @@ type jsonStats struct {
 	LatencyMSp95 *uint64  `json:"latency_ms_p95"`
 	LatencyMSp99 *uint64  `json:"latency_ms_p99"`
 	Tls          *float64 `json:"tls"`
+	// Label is the label the stats are grouped by with --by-label, in which
+	// case Name is its value
+	Label string `json:"label,omitempty"`
+	// the unmeshed traffic of the resource, only reported with --unmeshed
+	UnmeshedRps   *float64 `json:"unmeshed_rps,omitempty"`
+	MeshedTraffic *float64 `json:"meshed_traffic,omitempty"`
+
+	Tcp *jsonTcpStats `json:"tcp,omitempty"`
 }
 
-func printStatJson(statTables map[string]map[string]*row, w *tabwriter.Writer) {
+type jsonTcpStats struct {
+	OpenConnections uint64  `json:"open_connections"`
+	ReadBps         float64 `json:"read_bps"`
+	WriteBps        float64 `json:"write_bps"`
+}
+
+func printStatJson(statTables map[string]map[string]*row, w *tabwriter.Writer, options *statOptions) {
 	// avoid nil initialization so that if there are not stats it gets marshalled as an empty array vs null
 	entries := []*jsonStats{}
 	for _, resourceType := range k8s.AllResources {
 		if stats, ok := statTables[resourceType]; ok {
 			sortedKeys := sortStatsKeys(stats)
 			for _, key := range sortedKeys {
-				namespace, name := namespaceName("", key)
+				cluster, namespace, name := clusterNamespaceName("", key)
 				entry := &jsonStats{
+					Cluster:   cluster,
 					Namespace: namespace,
 					Kind:      resourceType,
 					Name:      name,
 					Meshed:    stats[key].meshed,
+					Label:     options.byLabel,
 				}
 				if stats[key].rowStats != nil {
 					entry.Success = &stats[key].successRate
@@ -410,6 +824,17 @@ func printStatJson(statTables map[string]map[string]*row, w *tabwriter.Writer) {
 					entry.LatencyMSp95 = &stats[key].latencyP95
 					entry.LatencyMSp99 = &stats[key].latencyP99
 					entry.Tls = &stats[key].tlsPercent
+					if options.unmeshed {
+						entry.UnmeshedRps = &stats[key].unmeshedRequestRate
+						entry.MeshedTraffic = &stats[key].meshedPercent
+					}
+				}
+				if tcp := stats[key].tcp; tcp != nil {
+					entry.Tcp = &jsonTcpStats{
+						OpenConnections: tcp.openConnections,
+						ReadBps:         tcp.readByteRate,
+						WriteBps:        tcp.writeByteRate,
+					}
 				}
 
 				entries = append(entries, entry)
@@ -424,6 +849,141 @@ func printStatJson(statTables map[string]map[string]*row, w *tabwriter.Writer) {
 	fmt.Fprintf(w, "%s\n", b)
 }
 
+// printStatCSV prints a CSV row per resource, with the fields of the JSON
+// output. Stats that aren't available are left empty.
+func printStatCSV(statTables map[string]map[string]*row, w *tabwriter.Writer, multiCluster bool, options *statOptions) {
+	header := []string{"namespace", "kind", "name", "meshed", "success", "rps", "latency_ms_p50", "latency_ms_p95", "latency_ms_p99", "tls"}
+	if options.unmeshed {
+		header = append(header, "unmeshed_rps", "meshed_traffic")
+	}
+	if options.tcp {
+		header = append(header, "tcp_open_connections", "tcp_read_bps", "tcp_write_bps")
+	}
+	if multiCluster {
+		header = append([]string{"cluster"}, header...)
+	}
+
+	records := [][]string{}
+	for _, resourceType := range k8s.AllResources {
+		stats, ok := statTables[resourceType]
+		if !ok {
+			continue
+		}
+		for _, key := range sortStatsKeys(stats) {
+			cluster, namespace, name := clusterNamespaceName("", key)
+			record := []string{namespace, resourceType, name, stats[key].meshed}
+			if rs := stats[key].rowStats; rs != nil {
+				record = append(record,
+					csvFloat(rs.successRate),
+					csvFloat(rs.requestRate),
+					fmt.Sprintf("%d", rs.latencyP50),
+					fmt.Sprintf("%d", rs.latencyP95),
+					fmt.Sprintf("%d", rs.latencyP99),
+					csvFloat(rs.tlsPercent),
+				)
+			} else {
+				record = append(record, "", "", "", "", "", "")
+			}
+			if options.unmeshed {
+				if rs := stats[key].rowStats; rs != nil {
+					record = append(record, csvFloat(rs.unmeshedRequestRate), csvFloat(rs.meshedPercent))
+				} else {
+					record = append(record, "", "")
+				}
+			}
+			if options.tcp {
+				if tcp := stats[key].tcp; tcp != nil {
+					record = append(record,
+						fmt.Sprintf("%d", tcp.openConnections),
+						csvFloat(tcp.readByteRate),
+						csvFloat(tcp.writeByteRate),
+					)
+				} else {
+					record = append(record, "", "", "")
+				}
+			}
+			if multiCluster {
+				record = append([]string{cluster}, record...)
+			}
+			records = append(records, record)
+		}
+	}
+
+	if err := writeCSV(w, header, records); err != nil {
+		log.Error(err.Error())
+	}
+}
+
+// printStatPrometheus prints the stats as gauges labeled with the namespace,
+// kind and name of their resource. The rates are per second and the latencies
+// in milliseconds.
+func printStatPrometheus(statTables map[string]map[string]*row, w *tabwriter.Writer, multiCluster bool, options *statOptions) {
+	meshedPods := &promMetric{name: "linkerd_stat_meshed_pods", help: "Number of meshed pods of the resource."}
+	runningPods := &promMetric{name: "linkerd_stat_running_pods", help: "Number of running pods of the resource."}
+	successRate := &promMetric{name: "linkerd_stat_success_ratio", help: "Ratio of the successful requests of the resource."}
+	requestRate := &promMetric{name: "linkerd_stat_requests_per_second", help: "Rate of the requests of the resource."}
+	latency := &promMetric{name: "linkerd_stat_latency_milliseconds", help: "Latency quantiles of the requests of the resource."}
+	tlsRate := &promMetric{name: "linkerd_stat_tls_ratio", help: "Ratio of the requests of the resource sent over TLS."}
+	metrics := []*promMetric{meshedPods, runningPods, successRate, requestRate, latency, tlsRate}
+
+	unmeshedRate := &promMetric{name: "linkerd_stat_unmeshed_requests_per_second", help: "Rate of the requests the resource received from unmeshed clients."}
+	meshedRate := &promMetric{name: "linkerd_stat_meshed_traffic_ratio", help: "Ratio of the requests the resource received from meshed clients."}
+	if options.unmeshed {
+		metrics = append(metrics, unmeshedRate, meshedRate)
+	}
+
+	tcpConnections := &promMetric{name: "linkerd_stat_tcp_open_connections", help: "Number of open TCP connections of the resource."}
+	tcpReadRate := &promMetric{name: "linkerd_stat_tcp_read_bytes_per_second", help: "Rate of the TCP bytes read by the resource."}
+	tcpWriteRate := &promMetric{name: "linkerd_stat_tcp_write_bytes_per_second", help: "Rate of the TCP bytes written by the resource."}
+	if options.tcp {
+		metrics = append(metrics, tcpConnections, tcpReadRate, tcpWriteRate)
+	}
+
+	for _, resourceType := range k8s.AllResources {
+		stats, ok := statTables[resourceType]
+		if !ok {
+			continue
+		}
+		for _, key := range sortStatsKeys(stats) {
+			cluster, namespace, name := clusterNamespaceName("", key)
+			labels := []promLabel{{"namespace", namespace}, {"kind", resourceType}, {"name", name}}
+			if multiCluster {
+				labels = append([]promLabel{{"cluster", cluster}}, labels...)
+			}
+			quantile := func(q string) []promLabel {
+				return append(append([]promLabel{}, labels...), promLabel{"quantile", q})
+			}
+
+			r := stats[key]
+			if r.meshedPods != nil {
+				meshedPods.add(labels, float64(*r.meshedPods))
+				runningPods.add(labels, float64(*r.runningPods))
+			}
+			if rs := r.rowStats; rs != nil {
+				successRate.add(labels, rs.successRate)
+				requestRate.add(labels, rs.requestRate)
+				latency.add(quantile("0.5"), float64(rs.latencyP50))
+				latency.add(quantile("0.95"), float64(rs.latencyP95))
+				latency.add(quantile("0.99"), float64(rs.latencyP99))
+				tlsRate.add(labels, rs.tlsPercent)
+				if options.unmeshed {
+					unmeshedRate.add(labels, rs.unmeshedRequestRate)
+					meshedRate.add(labels, rs.meshedPercent)
+				}
+			}
+			if tcp := r.tcp; options.tcp && tcp != nil {
+				tcpConnections.add(labels, float64(tcp.openConnections))
+				tcpReadRate.add(labels, tcp.readByteRate)
+				tcpWriteRate.add(labels, tcp.writeByteRate)
+			}
+		}
+	}
+
+	if err := writePrometheus(w, metrics); err != nil {
+		log.Error(err.Error())
+	}
+}
+
 func getNamePrefix(resourceType string) string {
 	if resourceType == "" {
 		return ""
@@ -453,6 +1013,11 @@ func buildStatSummaryRequests(resources []string, options *statOptions) ([]*pb.S
 		}
 	}
 
+	endTime, err := options.endTime(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
 	requests := make([]*pb.StatSummaryRequest, 0)
 	for _, target := range targets {
 		err = options.validate(target.Type)
@@ -467,6 +1032,7 @@ func buildStatSummaryRequests(resources []string, options *statOptions) ([]*pb.S
 				ResourceType:  target.Type,
 				Namespace:     options.namespace,
 				AllNamespaces: options.allNamespaces,
+				EndTime:       endTime,
 			},
 			ToName:        toRes.Name,
 			ToType:        toRes.Type,
@@ -474,6 +1040,10 @@ func buildStatSummaryRequests(resources []string, options *statOptions) ([]*pb.S
 			FromName:      fromRes.Name,
 			FromType:      fromRes.Type,
 			FromNamespace: options.fromNamespace,
+			TCPStats:      options.tcp,
+			LabelSelector: options.labelSelector,
+			PodStats:      options.pods,
+			GroupByLabel:  options.byLabel,
 		}
 
 		req, err := util.BuildStatSummaryRequest(requestParams)
@@ -481,6 +1051,22 @@ func buildStatSummaryRequests(resources []string, options *statOptions) ([]*pb.S
 			return nil, err
 		}
 		requests = append(requests, req)
+
+		// with --tree, the deployments of the namespaces are displayed beneath
+		// them; the label selector only selects the namespaces
+		if options.tree {
+			requestParams.ResourceType = k8s.Deployment
+			requestParams.ResourceName = ""
+			requestParams.Namespace = target.Name
+			requestParams.AllNamespaces = target.Name == ""
+			requestParams.LabelSelector = ""
+
+			req, err := util.BuildStatSummaryRequest(requestParams)
+			if err != nil {
+				return nil, err
+			}
+			requests = append(requests, req)
+		}
 	}
 	return requests, nil
 }
@@ -502,6 +1088,14 @@ func (o *statOptions) validate(resourceType string) error {
 		return err
 	}
 
+	if o.successThreshold < 0 || o.successThreshold > 100 {
+		return fmt.Errorf("--success-threshold must be a percentage between 0 and 100")
+	}
+
+	if o.latencyThreshold < 0 {
+		return fmt.Errorf("--latency-threshold must not be negative")
+	}
+
 	if resourceType == k8s.Namespace {
 		err := o.validateNamespaceFlags()
 		if err != nil {
@@ -509,9 +1103,38 @@ func (o *statOptions) validate(resourceType string) error {
 		}
 	}
 
+	if o.byLabel != "" && (resourceType == k8s.All || resourceType == k8s.Authority) {
+		return fmt.Errorf("--by-label is incompatible with %s resource type", resourceType)
+	}
+
+	if o.pods && (resourceType == k8s.All || resourceType == k8s.Pod || resourceType == k8s.Authority) {
+		return fmt.Errorf("--pods is incompatible with %s resource type", resourceType)
+	}
+
+	if o.tree && resourceType != k8s.Namespace {
+		return fmt.Errorf("--tree is incompatible with %s resource type", resourceType)
+	}
+
+	if o.labelSelector != "" && resourceType == k8s.Authority {
+		return fmt.Errorf("--selector is incompatible with %s resource type", resourceType)
+	}
+
 	return o.validateOutputFormat()
 }
 
+// validateOutputFormat accepts the csv and prometheus formats on top of the
+// formats of the other stat commands.
+func (o *statOptions) validateOutputFormat() error {
+	switch kind, _ := parseOutputFormat(o.outputFormat); kind {
+	case tableOutput, jsonOutput, yamlOutput, csvOutput, prometheusOutput:
+		return nil
+	case jsonPathOutput, goTemplateOutput:
+		return validateTemplateOutput(o.outputFormat)
+	default:
+		return fmt.Errorf("--output currently only supports table, json, yaml, csv, prometheus, jsonpath and go-template")
+	}
+}
+
 // validateConflictingFlags validates that the options do not contain mutually
 // exclusive flags.
 func (o *statOptions) validateConflictingFlags() error {
@@ -523,6 +1146,46 @@ func (o *statOptions) validateConflictingFlags() error {
 		return fmt.Errorf("--to-namespace and --from-namespace flags are mutually exclusive")
 	}
 
+	if o.grpc && o.tcp {
+		return fmt.Errorf("--grpc and --tcp flags are mutually exclusive")
+	}
+
+	if o.grpc && o.unmeshed {
+		return fmt.Errorf("--grpc and --unmeshed flags are mutually exclusive")
+	}
+
+	if o.unmeshed && (o.toResource != "" || o.fromResource != "" || o.toNamespace != "" || o.fromNamespace != "") {
+		return fmt.Errorf("--unmeshed only applies to inbound stats, and can't be combined with the --to, --from, --to-namespace and --from-namespace flags")
+	}
+
+	if o.grpc && o.labelSelector != "" {
+		return fmt.Errorf("--grpc and --selector flags are mutually exclusive")
+	}
+
+	if o.grpc && o.pods {
+		return fmt.Errorf("--grpc and --pods flags are mutually exclusive")
+	}
+
+	if o.byLabel != "" && o.pods {
+		return fmt.Errorf("--by-label and --pods flags are mutually exclusive")
+	}
+
+	if o.tree && (o.grpc || o.pods || o.byLabel != "") {
+		return fmt.Errorf("--tree can't be combined with the --grpc, --pods and --by-label flags")
+	}
+
+	if o.tree && o.outputFormat != "" && o.outputFormat != tableOutput {
+		return fmt.Errorf("--tree only supports the table output format")
+	}
+
+	if o.grpc && (o.outputFormat == csvOutput || o.outputFormat == prometheusOutput) {
+		return fmt.Errorf("--grpc doesn't support the %s output format", o.outputFormat)
+	}
+
+	if o.byLabel != "" && (o.fromResource != "" || o.grpc || o.tcp) {
+		return fmt.Errorf("--by-label can't be combined with the --from, --grpc and --tcp flags")
+	}
+
 	return nil
 }
 
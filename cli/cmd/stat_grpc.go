@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+)
+
+// requestStreamStatsFromAPI runs the requests against the StreamStats API, and
+// returns the rows of all the responses.
+func requestStreamStatsFromAPI(client pb.ApiClient, reqs []*pb.StatSummaryRequest) ([]*pb.StreamStatsTable_Row, error) {
+	rows := make([]*pb.StreamStatsTable_Row, 0)
+	for _, req := range reqs {
+		resp, err := client.StreamStats(cliContext, req)
+		if err != nil {
+			return nil, wrapAPIError("StreamStats", err)
+		}
+		if e := resp.GetError(); e != nil {
+			return nil, fmt.Errorf("StreamStats API response error: %v", e.Error)
+		}
+		rows = append(rows, resp.GetOk().GetRows()...)
+	}
+
+	return rows, nil
+}
+
+// codeCount is the number of responses that ended with a code.
+type codeCount struct {
+	code  string
+	count uint64
+}
+
+// sortCodeCounts returns the counts from the most to the least frequent code.
+func sortCodeCounts(counts map[string]uint64) []codeCount {
+	sorted := make([]codeCount, 0, len(counts))
+	for code, count := range counts {
+		sorted = append(sorted, codeCount{code, count})
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].count != sorted[j].count {
+			return sorted[i].count > sorted[j].count
+		}
+		return sorted[i].code < sorted[j].code
+	})
+	return sorted
+}
+
+// grpcStatusCounts keys the gRPC response counts by status code name, e.g.
+// "Unavailable" for status 14.
+func grpcStatusCounts(byStatus map[uint32]uint64) map[string]uint64 {
+	counts := make(map[string]uint64)
+	for status, count := range byStatus {
+		counts[codes.Code(status).String()] += count
+	}
+	return counts
+}
+
+func formatCodeCounts(counts map[string]uint64) string {
+	if len(counts) == 0 {
+		return "-"
+	}
+	parts := make([]string, 0, len(counts))
+	for _, c := range sortCodeCounts(counts) {
+		parts = append(parts, fmt.Sprintf("%s:%d", c.code, c.count))
+	}
+	return strings.Join(parts, ",")
+}
+
+func renderStreamStats(rows []*pb.StreamStatsTable_Row, options *statOptions) (string, error) {
+	var buffer bytes.Buffer
+	w := tabwriter.NewWriter(&buffer, 0, 0, padding, ' ', tabwriter.AlignRight)
+	writeStreamStatsToBuffer(rows, w, options)
+	w.Flush()
+
+	return renderStats(buffer, &options.statOptionsBase)
+}
+
+func writeStreamStatsToBuffer(rows []*pb.StreamStatsTable_Row, w *tabwriter.Writer, options *statOptions) {
+	sort.Slice(rows, func(i, j int) bool {
+		a, b := rows[i].GetResource(), rows[j].GetResource()
+		if a.GetType() != b.GetType() {
+			return a.GetType() < b.GetType()
+		}
+		if a.GetNamespace() != b.GetNamespace() {
+			return a.GetNamespace() < b.GetNamespace()
+		}
+		return a.GetName() < b.GetName()
+	})
+
+	if isJSONOutput(options.outputFormat) {
+		printStreamStatsJSON(rows, w)
+		return
+	}
+
+	if len(rows) == 0 {
+		fmt.Fprintln(os.Stderr, "No traffic found.")
+		os.Exit(exitCodeNoData)
+	}
+
+	maxNameLength := len(nameHeader)
+	maxNamespaceLength := len(namespaceHeader)
+	for _, r := range rows {
+		if len(r.Resource.Name) > maxNameLength {
+			maxNameLength = len(r.Resource.Name)
+		}
+		if len(r.Resource.Namespace) > maxNamespaceLength {
+			maxNamespaceLength = len(r.Resource.Namespace)
+		}
+	}
+
+	headers := make([]string, 0)
+	if options.allNamespaces {
+		headers = append(headers,
+			namespaceHeader+strings.Repeat(" ", maxNamespaceLength-len(namespaceHeader)))
+	}
+	headers = append(headers, []string{
+		nameHeader + strings.Repeat(" ", maxNameLength-len(nameHeader)),
+		"GRPC_STATUS\t", // trailing \t is required to format last column
+	}...)
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+
+	for _, r := range rows {
+		values := make([]string, 0)
+		if options.allNamespaces {
+			values = append(values,
+				r.Resource.Namespace+strings.Repeat(" ", maxNamespaceLength-len(r.Resource.Namespace)))
+		}
+		values = append(values,
+			r.Resource.Name+strings.Repeat(" ", maxNameLength-len(r.Resource.Name)),
+			formatCodeCounts(grpcStatusCounts(r.Stats.GetResponsesByGrpcStatus())),
+		)
+		fmt.Fprintf(w, "%s\t\n", strings.Join(values, "\t"))
+	}
+}
+
+type jsonStreamStats struct {
+	Namespace  string            `json:"namespace"`
+	Kind       string            `json:"kind"`
+	Name       string            `json:"name"`
+	GrpcStatus map[string]uint64 `json:"grpc_status"`
+}
+
+func printStreamStatsJSON(rows []*pb.StreamStatsTable_Row, w *tabwriter.Writer) {
+	// avoid nil initialization so that if there are not stats it gets marshalled as an empty array vs null
+	entries := []*jsonStreamStats{}
+	for _, r := range rows {
+		entries = append(entries, &jsonStreamStats{
+			Namespace:  r.Resource.Namespace,
+			Kind:       r.Resource.Type,
+			Name:       r.Resource.Name,
+			GrpcStatus: grpcStatusCounts(r.Stats.GetResponsesByGrpcStatus()),
+		})
+	}
+	b, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		log.Error(err.Error())
+		return
+	}
+	fmt.Fprintf(w, "%s\n", b)
+}
@@ -2,8 +2,12 @@ package cmd
 
 import (
 	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
 
 	"github.com/linkerd/linkerd2/controller/api/public"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 )
 
@@ -72,6 +76,87 @@ func TestStat(t *testing.T) {
 		}, t)
 	})
 
+	options.outputFormat = yamlOutput
+	t.Run("Returns all namespace stats (yaml)", func(t *testing.T) {
+		testStatCall(paramsExp{
+			counts: &public.PodCounts{
+				MeshedPods:  1,
+				RunningPods: 2,
+				FailedPods:  0,
+			},
+			options: options,
+			resNs:   []string{"emojivoto1", "emojivoto2"},
+			file:    "stat_all_output_yaml.golden",
+		}, t)
+	})
+
+	options.outputFormat = csvOutput
+	t.Run("Returns all namespace stats (csv)", func(t *testing.T) {
+		testStatCall(paramsExp{
+			counts: &public.PodCounts{
+				MeshedPods:  1,
+				RunningPods: 2,
+				FailedPods:  0,
+			},
+			options: options,
+			resNs:   []string{"emojivoto1", "emojivoto2"},
+			file:    "stat_all_output_csv.golden",
+		}, t)
+	})
+
+	options.outputFormat = prometheusOutput
+	t.Run("Returns all namespace stats (prometheus)", func(t *testing.T) {
+		testStatCall(paramsExp{
+			counts: &public.PodCounts{
+				MeshedPods:  1,
+				RunningPods: 2,
+				FailedPods:  0,
+			},
+			options: options,
+			resNs:   []string{"emojivoto1", "emojivoto2"},
+			file:    "stat_all_output_prometheus.golden",
+		}, t)
+	})
+
+	options.outputFormat = "jsonpath={range [*]}{.namespace}{\" \"}{.success}{\"\\n\"}{end}"
+	t.Run("Returns all namespace stats (jsonpath)", func(t *testing.T) {
+		testStatCall(paramsExp{
+			counts: &public.PodCounts{
+				MeshedPods:  1,
+				RunningPods: 2,
+				FailedPods:  0,
+			},
+			options: options,
+			resNs:   []string{"emojivoto1", "emojivoto2"},
+			file:    "stat_all_output_jsonpath.golden",
+		}, t)
+	})
+
+	options.outputFormat = "go-template={{range .}}{{.namespace}}/{{.name}} {{.meshed}}\n{{end}}"
+	t.Run("Returns all namespace stats (go-template)", func(t *testing.T) {
+		testStatCall(paramsExp{
+			counts: &public.PodCounts{
+				MeshedPods:  1,
+				RunningPods: 2,
+				FailedPods:  0,
+			},
+			options: options,
+			resNs:   []string{"emojivoto1", "emojivoto2"},
+			file:    "stat_all_output_go_template.golden",
+		}, t)
+	})
+
+	t.Run("Rejects invalid output templates", func(t *testing.T) {
+		options := newStatOptions()
+		options.outputFormat = "jsonpath={.name"
+		args := []string{"ns"}
+
+		_, err := buildStatSummaryRequests(args, options)
+		if err == nil {
+			t.Fatalf("Expected error for invalid jsonpath template")
+		}
+	})
+
 	t.Run("Returns an error for named resource queries with the --all-namespaces flag", func(t *testing.T) {
 		options := newStatOptions()
 		options.allNamespaces = true
@@ -135,6 +220,276 @@ func TestStat(t *testing.T) {
 	})
 }
 
+func TestStatNamespaceFilters(t *testing.T) {
+	t.Run("Filters the traffic from a namespace", func(t *testing.T) {
+		options := newStatOptions()
+		options.namespace = "emojivoto"
+		options.fromNamespace = "vote"
+
+		reqs, err := buildStatSummaryRequests([]string{"svc"}, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := &pb.Resource{Namespace: "vote", Type: k8s.Namespace, Name: "vote"}
+		if from := reqs[0].GetFromResource(); !proto.Equal(from, expected) {
+			t.Fatalf("Expected the traffic from %v, got %v", expected, from)
+		}
+	})
+
+	t.Run("Filters the traffic to a namespace", func(t *testing.T) {
+		options := newStatOptions()
+		options.namespace = "emojivoto"
+		options.toNamespace = "linkerd"
+
+		reqs, err := buildStatSummaryRequests([]string{"deploy"}, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := &pb.Resource{Namespace: "linkerd", Type: k8s.Namespace, Name: "linkerd"}
+		if to := reqs[0].GetToResource(); !proto.Equal(to, expected) {
+			t.Fatalf("Expected the traffic to %v, got %v", expected, to)
+		}
+	})
+
+	t.Run("Looks up the --to resource in the --to-namespace", func(t *testing.T) {
+		options := newStatOptions()
+		options.namespace = "emojivoto"
+		options.toResource = "deploy/controller"
+		options.toNamespace = "linkerd"
+
+		reqs, err := buildStatSummaryRequests([]string{"deploy"}, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := &pb.Resource{Namespace: "linkerd", Type: k8s.Deployment, Name: "controller"}
+		if to := reqs[0].GetToResource(); !proto.Equal(to, expected) {
+			t.Fatalf("Expected the traffic to %v, got %v", expected, to)
+		}
+	})
+}
+
+func TestStatSelector(t *testing.T) {
+	t.Run("Sends the label selector with the requests", func(t *testing.T) {
+		options := newStatOptions()
+		options.labelSelector = "app=web,tier=frontend"
+
+		reqs, err := buildStatSummaryRequests([]string{"deploy"}, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if selector := reqs[0].GetSelector().GetLabelSelector(); selector != options.labelSelector {
+			t.Fatalf("Expected the label selector %s, got %s", options.labelSelector, selector)
+		}
+	})
+
+	t.Run("Rejects a label selector for authorities", func(t *testing.T) {
+		options := newStatOptions()
+		options.labelSelector = "app=web"
+
+		if _, err := buildStatSummaryRequests([]string{"au"}, options); err == nil {
+			t.Fatal("Expected an error")
+		}
+	})
+}
+
+func TestStatMultiContext(t *testing.T) {
+	options := newStatOptions()
+	options.contexts = []string{"west-cluster", "east"}
+
+	counts := &public.PodCounts{MeshedPods: 1, RunningPods: 2}
+	response := public.GenStatSummaryResponse("emoji", k8s.Namespace, []string{"emojivoto1"}, counts)
+
+	output, err := renderClusterStatStats([]clusterStatRows{
+		{cluster: "east", rows: respToRows(&response)},
+		{cluster: "west-cluster", rows: respToRows(&response)},
+	}, options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	diffCompareFile(t, output, "stat_multi_context_output.golden")
+}
+
+func TestStatTcp(t *testing.T) {
+	options := newStatOptions()
+	options.allNamespaces = true
+	options.tcp = true
+
+	counts := &public.PodCounts{MeshedPods: 1, RunningPods: 2}
+	response := public.GenStatSummaryResponse("emoji", k8s.Namespace, []string{"emojivoto1", "emojivoto2"}, counts)
+	rows := respToRows(&response)
+	rows[0].TcpStats = &pb.TcpStats{
+		OpenConnections: 18,
+		ReadBytes:       3145728,
+		WriteBytes:      61440,
+	}
+
+	t.Run("Renders the TCP columns", func(t *testing.T) {
+		output, err := renderStatStats(rows, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		diffCompareFile(t, output, "stat_tcp_output.golden")
+	})
+
+	t.Run("Requests the TCP stats", func(t *testing.T) {
+		reqs, err := buildStatSummaryRequests([]string{"deploy"}, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reqs[0].TcpStats {
+			t.Fatalf("Expected the request to ask for TCP stats")
+		}
+	})
+
+	t.Run("Rejects --grpc with --tcp", func(t *testing.T) {
+		options := newStatOptions()
+		options.grpc = true
+		options.tcp = true
+
+		_, err := buildStatSummaryRequests([]string{"deploy"}, options)
+		expected := "--grpc and --tcp flags are mutually exclusive"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error [%s], got [%v]", expected, err)
+		}
+	})
+}
+
+func TestStatUnmeshed(t *testing.T) {
+	options := newStatOptions()
+	options.allNamespaces = true
+	options.unmeshed = true
+
+	counts := &public.PodCounts{MeshedPods: 1, RunningPods: 2}
+	response := public.GenStatSummaryResponse("emoji", k8s.Namespace, []string{"emojivoto1", "emojivoto2"}, counts)
+	rows := respToRows(&response)
+	rows[0].Stats.TlsRequestCount = 93
+	rows[0].Stats.UnmeshedRequestCount = 30
+
+	t.Run("Renders the unmeshed traffic columns", func(t *testing.T) {
+		output, err := renderStatStats(rows, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		diffCompareFile(t, output, "stat_unmeshed_output.golden")
+	})
+
+	t.Run("Rejects --unmeshed with --to", func(t *testing.T) {
+		options := newStatOptions()
+		options.unmeshed = true
+		options.toResource = "deploy/web"
+
+		_, err := buildStatSummaryRequests([]string{"deploy"}, options)
+		if err == nil {
+			t.Fatal("Expected an error")
+		}
+	})
+}
+
+func TestStatGrpc(t *testing.T) {
+	options := newStatOptions()
+	options.allNamespaces = true
+	options.grpc = true
+
+	mockClient := &public.MockApiClient{
+		StreamStatsResponseToReturn: &pb.StreamStatsResponse{
+			Response: &pb.StreamStatsResponse_Ok{
+				Ok: &pb.StreamStatsTable{
+					Rows: []*pb.StreamStatsTable_Row{
+						{
+							Resource:   &pb.Resource{Namespace: "emojivoto", Type: k8s.Deployment, Name: "voting"},
+							TimeWindow: "1m",
+							Stats:      &pb.StreamStats{},
+						},
+						{
+							Resource:   &pb.Resource{Namespace: "emojivoto", Type: k8s.Deployment, Name: "emoji"},
+							TimeWindow: "1m",
+							Stats: &pb.StreamStats{
+								ResponsesByGrpcStatus: map[uint32]uint64{0: 1200, 4: 2, 14: 12},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	reqs, err := buildStatSummaryRequests([]string{"deploy"}, options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	rows, err := requestStreamStatsFromAPI(mockClient, reqs)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	t.Run("Renders the gRPC status codes", func(t *testing.T) {
+		output, err := renderStreamStats(rows, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		diffCompareFile(t, output, "stat_grpc_output.golden")
+	})
+
+	t.Run("Rejects --grpc with --tcp", func(t *testing.T) {
+		options := newStatOptions()
+		options.grpc = true
+		options.tcp = true
+
+		_, err := buildStatSummaryRequests([]string{"deploy"}, options)
+		expected := "--grpc and --tcp flags are mutually exclusive"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error [%s], got [%v]", expected, err)
+		}
+	})
+}
+
+func TestStatByLabel(t *testing.T) {
+	options := newStatOptions()
+	options.byLabel = "version"
+
+	counts := &public.PodCounts{MeshedPods: 2, RunningPods: 2}
+	response := public.GenStatSummaryResponse("emoji", k8s.Deployment, []string{"emojivoto", "emojivoto"}, counts)
+	rows := respToRows(&response)
+	for i, value := range []string{"canary", ""} {
+		rows[i].Resource.Name = ""
+		rows[i].LabelValue = value
+	}
+
+	t.Run("Renders a row per label value", func(t *testing.T) {
+		output, err := renderStatStats(rows, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		diffCompareFile(t, output, "stat_by_label_output.golden")
+	})
+
+	t.Run("Requests the stats grouped by label", func(t *testing.T) {
+		reqs, err := buildStatSummaryRequests([]string{"deploy/emoji"}, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if reqs[0].GroupByLabel != "version" {
+			t.Fatalf("Expected the request to group the stats by version, got [%s]", reqs[0].GroupByLabel)
+		}
+	})
+
+	t.Run("Rejects --by-label with --from", func(t *testing.T) {
+		options := newStatOptions()
+		options.byLabel = "version"
+		options.fromResource = "deploy/web"
+
+		if _, err := buildStatSummaryRequests([]string{"deploy"}, options); err == nil {
+			t.Fatal("Expected an error")
+		}
+	})
+}
+
 func testStatCall(exp paramsExp, t *testing.T) {
 	mockClient := &public.MockApiClient{}
 
@@ -154,7 +509,195 @@ func testStatCall(exp paramsExp, t *testing.T) {
 	}
 
 	rows := respToRows(resp)
-	output := renderStatStats(rows, exp.options)
+	output, err := renderStatStats(rows, exp.options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
 
 	diffCompareFile(t, output, exp.file)
 }
+
+func TestStatPods(t *testing.T) {
+	options := newStatOptions()
+	options.pods = true
+
+	counts := &public.PodCounts{MeshedPods: 2, RunningPods: 2}
+	response := public.GenStatSummaryResponse("web", k8s.Deployment, []string{"emojivoto"}, counts)
+	rows := respToRows(&response)
+	for i, name := range []string{"web-6fd5b6f8d7-4xzq2", "web-6fd5b6f8d7-m9kld"} {
+		podResponse := public.GenStatSummaryResponse(name, k8s.Pod, []string{"emojivoto"}, &public.PodCounts{MeshedPods: 1, RunningPods: 1})
+		podRow := respToRows(&podResponse)[0]
+		podRow.Stats.FailureCount = uint64(i * 60)
+		rows[0].PodRows = append(rows[0].PodRows, podRow)
+	}
+
+	t.Run("Renders a row for each pod of the resources", func(t *testing.T) {
+		output, err := renderStatStats(rows, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		diffCompareFile(t, output, "stat_pods_output.golden")
+	})
+
+	t.Run("Requests the stats of the pods", func(t *testing.T) {
+		reqs, err := buildStatSummaryRequests([]string{"deploy/web"}, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !reqs[0].GetPodStats() {
+			t.Fatalf("Expected the pod stats to be requested, got %v", reqs[0])
+		}
+	})
+
+	t.Run("Rejects --pods for pods", func(t *testing.T) {
+		if _, err := buildStatSummaryRequests([]string{"po"}, options); err == nil {
+			t.Fatal("Expected an error")
+		}
+	})
+}
+
+func TestStatThresholds(t *testing.T) {
+	defer func() { colorMode = colorAuto }()
+	colorMode = colorAlways
+
+	options := newStatOptions()
+	options.successThreshold = 99.5
+	options.latencyThreshold = 100 * time.Millisecond
+
+	response := public.GenStatSummaryResponse("emoji", k8s.Deployment, []string{"emojivoto"}, &public.PodCounts{MeshedPods: 1, RunningPods: 1})
+	rows := respToRows(&response)
+	rows[0].Stats.FailureCount = 1
+
+	t.Run("Highlights the stats over the thresholds", func(t *testing.T) {
+		output, err := renderStatStats(rows, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		diffCompareFile(t, output, "stat_thresholds_output.golden")
+	})
+
+	t.Run("Rejects a success threshold over 100%", func(t *testing.T) {
+		options := newStatOptions()
+		options.successThreshold = 150
+
+		if _, err := buildStatSummaryRequests([]string{"deploy"}, options); err == nil {
+			t.Fatal("Expected an error")
+		}
+	})
+}
+
+func TestStatTree(t *testing.T) {
+	options := newStatOptions()
+	options.tree = true
+
+	rows := make([]*pb.StatTable_PodGroup_Row, 0)
+	for _, ns := range []string{"emojivoto", "books"} {
+		response := public.GenStatSummaryResponse(ns, k8s.Namespace, []string{""}, &public.PodCounts{MeshedPods: 3, RunningPods: 3})
+		rows = append(rows, respToRows(&response)...)
+	}
+	for _, deploy := range []string{"web", "emoji", "voting"} {
+		response := public.GenStatSummaryResponse(deploy, k8s.Deployment, []string{"emojivoto"}, &public.PodCounts{MeshedPods: 1, RunningPods: 1})
+		rows = append(rows, respToRows(&response)...)
+	}
+	response := public.GenStatSummaryResponse("webapp", k8s.Deployment, []string{"books"}, &public.PodCounts{MeshedPods: 3, RunningPods: 3})
+	rows = append(rows, respToRows(&response)...)
+
+	t.Run("Renders the deployments beneath their namespace", func(t *testing.T) {
+		output, err := renderStatStats(rows, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		diffCompareFile(t, output, "stat_tree_output.golden")
+	})
+
+	t.Run("Requests the deployments of the namespaces", func(t *testing.T) {
+		reqs, err := buildStatSummaryRequests([]string{"ns/emojivoto"}, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(reqs) != 2 {
+			t.Fatalf("Expected 2 requests, got %d", len(reqs))
+		}
+		resource := reqs[1].GetSelector().GetResource()
+		if resource.GetType() != k8s.Deployment || resource.GetNamespace() != "emojivoto" {
+			t.Fatalf("Expected the deployments of the emojivoto namespace to be requested, got %v", reqs[1])
+		}
+	})
+
+	t.Run("Rejects --tree for deployments", func(t *testing.T) {
+		if _, err := buildStatSummaryRequests([]string{"deploy"}, options); err == nil {
+			t.Fatal("Expected an error")
+		}
+	})
+}
+
+func TestStatEndTime(t *testing.T) {
+	now := time.Date(2019, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Parses the end of the time window", func(t *testing.T) {
+		testCases := []struct {
+			at       string
+			offset   time.Duration
+			expected time.Time
+		}{
+			{"", 0, time.Time{}},
+			{"2h-ago", 0, now.Add(-2 * time.Hour)},
+			{"90m", 0, now.Add(-90 * time.Minute)},
+			{"2019-01-01T10:00:00Z", 0, time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)},
+			{"", 30 * time.Minute, now.Add(-30 * time.Minute)},
+		}
+
+		for _, tc := range testCases {
+			options := newStatOptionsBase()
+			options.at = tc.at
+			options.offset = tc.offset
+
+			end, err := options.endTime(now)
+			if err != nil {
+				t.Fatalf("Unexpected error for --at %q --offset %s: %s", tc.at, tc.offset, err)
+			}
+			if !end.Equal(tc.expected) {
+				t.Fatalf("Expected %s for --at %q --offset %s, got %s", tc.expected, tc.at, tc.offset, end)
+			}
+		}
+	})
+
+	t.Run("Rejects invalid end times", func(t *testing.T) {
+		testCases := []struct {
+			at     string
+			offset time.Duration
+		}{
+			{"yesterday", 0},
+			{"2019-01-02T10:00:00Z", 0},
+			{"", -time.Hour},
+			{"2h-ago", time.Hour},
+		}
+
+		for _, tc := range testCases {
+			options := newStatOptionsBase()
+			options.at = tc.at
+			options.offset = tc.offset
+
+			if _, err := options.endTime(now); err == nil {
+				t.Fatalf("Expected an error for --at %q --offset %s", tc.at, tc.offset)
+			}
+		}
+	})
+
+	t.Run("Requests the stats ending at the end time", func(t *testing.T) {
+		options := newStatOptions()
+		options.at = "2019-01-01T10:00:00Z"
+
+		reqs, err := buildStatSummaryRequests([]string{"deploy"}, options)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		expected := time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC).Unix()
+		if reqs[0].GetEndTime() != expected {
+			t.Fatalf("Expected the end time %d, got %d", expected, reqs[0].GetEndTime())
+		}
+	})
+}
@@ -1,7 +1,7 @@
 package cmd
 
 import (
-	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -9,35 +9,50 @@ import (
 	"text/tabwriter"
 	"time"
 
+	"github.com/golang/protobuf/ptypes/duration"
 	"github.com/linkerd/linkerd2/controller/api/util"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/addr"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"google.golang.org/grpc/codes"
 )
 
 type tapOptions struct {
-	namespace   string
-	toResource  string
-	toNamespace string
-	maxRps      float32
-	scheme      string
-	method      string
-	authority   string
-	path        string
-	output      string
+	namespace    string
+	toResource   string
+	toNamespace  string
+	maxRps       float32
+	scheme       string
+	method       string
+	authority    string
+	path         string
+	output       string
+	status       []string
+	minLatency   time.Duration
+	rpcMethod    string
+	record       string
+	summary      bool
+	showIdentity bool
 }
 
 func newTapOptions() *tapOptions {
 	return &tapOptions{
-		namespace:   "default",
-		toResource:  "",
-		toNamespace: "",
-		maxRps:      100.0,
-		scheme:      "",
-		method:      "",
-		authority:   "",
-		path:        "",
-		output:      "",
+		namespace:    "default",
+		toResource:   "",
+		toNamespace:  "",
+		maxRps:       100.0,
+		scheme:       "",
+		method:       "",
+		authority:    "",
+		path:         "",
+		output:       "",
+		status:       []string{},
+		minLatency:   0,
+		rpcMethod:    "",
+		record:       "",
+		summary:      true,
+		showIdentity: false,
 	}
 }
 
@@ -72,20 +87,41 @@ func newCmdTap() *cobra.Command {
   linkerd tap pod/web-dlbvj
 
   # tap the test namespace, filter by request to prod namespace
-  linkerd tap ns/test --to ns/prod`,
+  linkerd tap ns/test --to ns/prod
+
+  # tap the requests of the web deployment failing with a 5xx status
+  linkerd tap deploy/web --status 5xx
+
+  # tap the requests of the web deployment taking at least 200ms to respond
+  linkerd tap deploy/web --min-latency 200ms
+
+  # tap the calls to a gRPC method of the voting deployment
+  linkerd tap deploy/voting --rpc-method /emojivoto.v1.VotingService/VoteDoughnut
+
+  # print a JSON object per event, e.g. to process the events with jq
+  linkerd tap deploy/web -o jsonl | jq 'select(.type == "response")'
+
+  # show the mTLS identities of the clients and servers of the requests
+  linkerd tap deploy/web --show-identity
+
+  # record the events to a file, to display them later with "linkerd tap replay"
+  linkerd tap deploy/web --record web.tap`,
 		Args:      cobra.RangeArgs(1, 2),
 		ValidArgs: util.ValidTargets,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			requestParams := util.TapRequestParams{
-				Resource:    strings.Join(args, "/"),
-				Namespace:   options.namespace,
-				ToResource:  options.toResource,
-				ToNamespace: options.toNamespace,
-				MaxRps:      options.maxRps,
-				Scheme:      options.scheme,
-				Method:      options.method,
-				Authority:   options.authority,
-				Path:        options.path,
+				Resource:      strings.Join(args, "/"),
+				Namespace:     options.namespace,
+				ToResource:    options.toResource,
+				ToNamespace:   options.toNamespace,
+				MaxRps:        options.maxRps,
+				Scheme:        options.scheme,
+				Method:        options.method,
+				Authority:     options.authority,
+				Path:          options.path,
+				StatusClasses: options.status,
+				MinLatency:    options.minLatency,
+				RPCMethod:     options.rpcMethod,
 			}
 
 			req, err := util.BuildTapByResourceRequest(requestParams)
@@ -93,21 +129,49 @@ func newCmdTap() *cobra.Command {
 				return err
 			}
 
-			wide := false
-			switch options.output {
-			// TODO: support more output formats?
-			case "":
-				// default output format.
-			case "wide":
-				wide = true
+			switch kind, _ := parseOutputFormat(options.output); kind {
+			case tableOutput, wideOutput, jsonlOutput:
+			case jsonPathOutput, goTemplateOutput:
+				if err := validateTemplateOutput(options.output); err != nil {
+					return err
+				}
 			default:
 				return fmt.Errorf("output format \"%s\" not recognized", options.output)
 			}
 
-			return requestTapByResourceFromAPI(os.Stdout, validatedPublicAPIClient(time.Time{}), req, wide)
+			client := validatedPublicAPIClient(time.Time{})
+			if options.record != "" {
+				f, err := os.Create(options.record)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+
+				client, err = newRecordingAPIClient(client, f)
+				if err != nil {
+					return err
+				}
+			}
+
+			// the summary is written to stderr, to keep the output of the events
+			// usable by other tools
+			summary := newTapSummary()
+			if options.summary {
+				client = &summarizingAPIClient{ApiClient: client, summary: summary}
+			}
+
+			if err := requestTapByResourceFromAPI(os.Stdout, client, req, options.output, options.showIdentity); err != nil {
+				return err
+			}
+			if options.summary {
+				summary.render(os.Stderr)
+			}
+			return nil
 		},
 	}
 
+	cmd.AddCommand(newCmdTapReplay())
+
 	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace,
 		"Namespace of the specified resource")
 	cmd.PersistentFlags().StringVar(&options.toResource, "to", options.toResource,
@@ -125,27 +189,46 @@ func newCmdTap() *cobra.Command {
 	cmd.PersistentFlags().StringVar(&options.path, "path", options.path,
 		"Display requests with paths that start with this prefix")
 	cmd.PersistentFlags().StringVarP(&options.output, "output", "o", options.output,
-		"Output format. One of: wide")
+		"Output format. One of: wide, jsonl, jsonpath=TEMPLATE, go-template=TEMPLATE")
+	cmd.PersistentFlags().StringSliceVar(&options.status, "status", options.status,
+		"Display requests whose response status is in these classes, e.g. 5xx; repeat the flag or separate the classes with commas")
+	cmd.PersistentFlags().DurationVar(&options.minLatency, "min-latency", options.minLatency,
+		"Display requests whose response took at least this long, e.g. 200ms")
+	cmd.PersistentFlags().StringVar(&options.rpcMethod, "rpc-method", options.rpcMethod,
+		"Display requests to this gRPC method, e.g. /package.Service/Method")
+	cmd.PersistentFlags().StringVar(&options.record, "record", options.record,
+		"Also write the events to this file, to display them later with \"linkerd tap replay\"")
+	cmd.PersistentFlags().BoolVar(&options.showIdentity, "show-identity", options.showIdentity,
+		"Display the mTLS identities of the client and the server of each request, if the proxies report them")
+	cmd.Flags().BoolVar(&options.summary, "summary", options.summary,
+		"Print a summary of the requests, their statuses, latencies and paths when the tap ends")
 
+	markFlagConfigurable(cmd.PersistentFlags(), "namespace", "namespace")
 	return cmd
 }
 
-func requestTapByResourceFromAPI(w io.Writer, client pb.ApiClient, req *pb.TapByResourceRequest, wide bool) error {
+func requestTapByResourceFromAPI(w io.Writer, client pb.ApiClient, req *pb.TapByResourceRequest, output string, showIdentity bool) error {
 	var resource string
-	if wide {
+	if output == wideOutput {
 		resource = req.Target.Resource.GetType()
 	}
 
-	rsp, err := client.TapByResource(context.Background(), req)
+	ctx, stop := withInterrupts(cliContext)
+	defer stop()
+
+	rsp, err := client.TapByResource(ctx, req)
 	if err != nil {
 		return err
 	}
-	return renderTap(w, rsp, resource)
+	if isTapJSONOutput(output) {
+		return renderTapJSONL(w, rsp, output)
+	}
+	return renderTap(w, rsp, resource, showIdentity)
 }
 
-func renderTap(w io.Writer, tapClient pb.Api_TapByResourceClient, resource string) error {
+func renderTap(w io.Writer, tapClient pb.Api_TapByResourceClient, resource string, showIdentity bool) error {
 	tableWriter := tabwriter.NewWriter(w, 0, 0, 0, ' ', tabwriter.AlignRight)
-	err := writeTapEventsToBuffer(tapClient, tableWriter, resource)
+	err := writeTapEventsToBuffer(tapClient, tableWriter, resource, showIdentity)
 	if err != nil {
 		return err
 	}
@@ -154,7 +237,13 @@ func renderTap(w io.Writer, tapClient pb.Api_TapByResourceClient, resource strin
 	return nil
 }
 
-func writeTapEventsToBuffer(tapClient pb.Api_TapByResourceClient, w *tabwriter.Writer, resource string) error {
+func writeTapEventsToBuffer(tapClient pb.Api_TapByResourceClient, w *tabwriter.Writer, resource string, showIdentity bool) error {
+	render := util.RenderTapEvent
+	if showIdentity {
+		render = util.RenderTapEventWithIdentity
+	}
+	warnedIdentity := false
+
 	for {
 		log.Debug("Waiting for data...")
 		event, err := tapClient.Recv()
@@ -162,14 +251,205 @@ func writeTapEventsToBuffer(tapClient pb.Api_TapByResourceClient, w *tabwriter.W
 			break
 		}
 		if err != nil {
-			fmt.Fprintln(os.Stderr, err)
+			// the stream is closed on purpose when the CLI is interrupted
+			if cliContext.Err() == nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+			break
+		}
+		if showIdentity && !warnedIdentity && util.MissingIdentity(event) {
+			fmt.Fprintln(os.Stderr, "The tapped proxies don't report the mTLS identities of their peers; \"--show-identity\" needs proxies setting the client_id and server_id labels")
+			warnedIdentity = true
+		}
+		_, err = fmt.Fprintln(w, render(event, resource))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// tapEventJSON is a tap event as printed by "-o jsonl". The events of a
+// stream share its id, and the response events repeat the request of their
+// stream, so that each line can be processed on its own.
+type tapEventJSON struct {
+	Type            string            `json:"type"`
+	ID              string            `json:"id"`
+	ProxyDirection  string            `json:"proxyDirection"`
+	Source          string            `json:"source"`
+	SourceMeta      map[string]string `json:"sourceMeta,omitempty"`
+	Destination     string            `json:"destination"`
+	DestinationMeta map[string]string `json:"destinationMeta,omitempty"`
+	RouteMeta       map[string]string `json:"routeMeta,omitempty"`
+	Request         *tapRequestJSON   `json:"request,omitempty"`
+	// Status and LatencyMicros are set on the response and end events
+	Status        uint32 `json:"status,omitempty"`
+	LatencyMicros int64  `json:"latencyMicros,omitempty"`
+	// the fields of the end events
+	DurationMicros   int64   `json:"durationMicros,omitempty"`
+	ResponseBytes    uint64  `json:"responseBytes,omitempty"`
+	GrpcStatus       *string `json:"grpcStatus,omitempty"`
+	RequestMessages  uint64  `json:"requestMessages,omitempty"`
+	ResponseMessages uint64  `json:"responseMessages,omitempty"`
+	ResetErrorCode   *uint32 `json:"resetErrorCode,omitempty"`
+}
+
+type tapRequestJSON struct {
+	Method    string `json:"method"`
+	Scheme    string `json:"scheme,omitempty"`
+	Authority string `json:"authority"`
+	Path      string `json:"path"`
+}
+
+// isTapJSONOutput returns true if the tap events are rendered from their JSON
+// objects, i.e. with -o jsonl or one of the template-based formats.
+func isTapJSONOutput(output string) bool {
+	switch kind, _ := parseOutputFormat(output); kind {
+	case jsonlOutput, jsonPathOutput, goTemplateOutput:
+		return true
+	}
+	return false
+}
+
+// renderTapJSONL writes a JSON object per tap event, on its own line. With
+// the template-based formats, the template is executed against the JSON
+// object of each event instead, and its output is written on its own line.
+func renderTapJSONL(w io.Writer, tapClient pb.Api_TapByResourceClient, output string) error {
+	streams := newTapStreams()
+	encoder := json.NewEncoder(w)
+	kind, _ := parseOutputFormat(output)
+	for {
+		event, err := tapClient.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			// the stream is closed on purpose when the CLI is interrupted
+			if cliContext.Err() == nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
 			break
 		}
-		_, err = fmt.Fprintln(w, util.RenderTapEvent(event, resource))
+		if kind == jsonlOutput {
+			if err := encoder.Encode(streams.toJSON(event)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		b, err := json.Marshal(streams.toJSON(event))
+		if err != nil {
+			return err
+		}
+		out, err := formatOutput(b, output)
 		if err != nil {
 			return err
 		}
+		if _, err := fmt.Fprintln(w, out); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
+
+// tapStreams keeps the request and response status of the streams being
+// tapped, until their response ends, to correlate their events.
+type tapStreams struct {
+	requests map[string]*tapRequestJSON
+	statuses map[string]uint32
+}
+
+func newTapStreams() *tapStreams {
+	return &tapStreams{
+		requests: make(map[string]*tapRequestJSON),
+		statuses: make(map[string]uint32),
+	}
+}
+
+func (s *tapStreams) toJSON(event *pb.TapEvent) *tapEventJSON {
+	j := &tapEventJSON{
+		ProxyDirection:  strings.ToLower(event.GetProxyDirection().String()),
+		Source:          addr.PublicAddressToString(event.GetSource()),
+		SourceMeta:      event.GetSourceMeta().GetLabels(),
+		Destination:     addr.PublicAddressToString(event.GetDestination()),
+		DestinationMeta: event.GetDestinationMeta().GetLabels(),
+		RouteMeta:       event.GetRouteMeta().GetLabels(),
+	}
+
+	// stream ids are only unique within a proxy
+	streamKey := func(id *pb.TapEvent_Http_StreamId) string {
+		j.ID = fmt.Sprintf("%d:%d", id.GetBase(), id.GetStream())
+		return strings.Join([]string{j.ID, j.ProxyDirection, j.Source, j.Destination}, " ")
+	}
+
+	switch ev := event.GetHttp().GetEvent().(type) {
+	case *pb.TapEvent_Http_RequestInit_:
+		j.Type = "request"
+		j.Request = &tapRequestJSON{
+			Method:    formatTapMethod(ev.RequestInit.GetMethod()),
+			Scheme:    formatTapScheme(ev.RequestInit.GetScheme()),
+			Authority: ev.RequestInit.GetAuthority(),
+			Path:      ev.RequestInit.GetPath(),
+		}
+		s.requests[streamKey(ev.RequestInit.GetId())] = j.Request
+
+	case *pb.TapEvent_Http_ResponseInit_:
+		key := streamKey(ev.ResponseInit.GetId())
+		j.Type = "response"
+		j.Request = s.requests[key]
+		j.Status = ev.ResponseInit.GetHttpStatus()
+		j.LatencyMicros = durationMicros(ev.ResponseInit.GetSinceRequestInit())
+		s.statuses[key] = j.Status
+
+	case *pb.TapEvent_Http_ResponseEnd_:
+		key := streamKey(ev.ResponseEnd.GetId())
+		j.Type = "end"
+		j.Request = s.requests[key]
+		j.Status = s.statuses[key]
+		j.LatencyMicros = durationMicros(ev.ResponseEnd.GetSinceRequestInit())
+		j.DurationMicros = durationMicros(ev.ResponseEnd.GetSinceResponseInit())
+		j.ResponseBytes = ev.ResponseEnd.GetResponseBytes()
+		switch eos := ev.ResponseEnd.GetEos().GetEnd().(type) {
+		case *pb.Eos_GrpcStatusCode:
+			grpcStatus := codes.Code(eos.GrpcStatusCode).String()
+			j.GrpcStatus = &grpcStatus
+			j.RequestMessages = ev.ResponseEnd.GetRequestMessages()
+			j.ResponseMessages = ev.ResponseEnd.GetResponseMessages()
+		case *pb.Eos_ResetErrorCode:
+			j.ResetErrorCode = &eos.ResetErrorCode
+		}
+		delete(s.requests, key)
+		delete(s.statuses, key)
+
+	default:
+		j.Type = "unknown"
+	}
+
+	return j
+}
+
+func formatTapMethod(method *pb.HttpMethod) string {
+	if unregistered := method.GetUnregistered(); unregistered != "" {
+		return unregistered
+	}
+	return method.GetRegistered().String()
+}
+
+func formatTapScheme(scheme *pb.Scheme) string {
+	if scheme == nil {
+		return ""
+	}
+	if unregistered := scheme.GetUnregistered(); unregistered != "" {
+		return unregistered
+	}
+	return strings.ToLower(scheme.GetRegistered().String())
+}
+
+func durationMicros(d *duration.Duration) int64 {
+	if d == nil {
+		return 0
+	}
+	return d.GetSeconds()*1000000 + int64(d.GetNanos()/1000)
+}
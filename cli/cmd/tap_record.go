@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+// tapFileHeader starts the files recorded by "linkerd tap --record", followed
+// by the version of the format. The events follow, each as a protobuf
+// TapEvent prefixed with its length as a varint.
+var tapFileHeader = []byte("linkerd-tap\x00")
+
+const tapFileVersion = 1
+
+// maxTapFileEventBytes caps the length read for an event, so that a corrupted
+// file doesn't allocate an arbitrarily large buffer.
+const maxTapFileEventBytes = 1 << 20
+
+type tapReplayOptions struct {
+	output     string
+	status     []string
+	minLatency time.Duration
+	rpcMethod  string
+}
+
+func newTapReplayOptions() *tapReplayOptions {
+	return &tapReplayOptions{
+		output:     "",
+		status:     []string{},
+		minLatency: 0,
+		rpcMethod:  "",
+	}
+}
+
+func newCmdTapReplay() *cobra.Command {
+	options := newTapReplayOptions()
+
+	cmd := &cobra.Command{
+		Use:   "replay [flags] (FILE)",
+		Short: "Display the events of a tap recorded with --record",
+		Long: `Display the events of a tap recorded with --record.
+
+The events can be filtered again by the status and latency of their responses,
+or by gRPC method, e.g. to analyze a capture from production offline.`,
+		Example: `  # record the requests of the web deployment, then display the failed ones
+  linkerd tap deploy/web --record web.tap
+  linkerd tap replay web.tap --status 5xx
+
+  # print the recorded events as JSON objects
+  linkerd tap replay web.tap -o jsonl`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			switch kind, _ := parseOutputFormat(options.output); kind {
+			case tableOutput, jsonlOutput:
+			case jsonPathOutput, goTemplateOutput:
+				if err := validateTemplateOutput(options.output); err != nil {
+					return err
+				}
+			default:
+				return fmt.Errorf("output format \"%s\" not recognized", options.output)
+			}
+
+			filter, err := util.BuildTapFilter(util.TapRequestParams{
+				StatusClasses: options.status,
+				MinLatency:    options.minLatency,
+				RPCMethod:     options.rpcMethod,
+			})
+			if err != nil {
+				return err
+			}
+
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			return replayTap(os.Stdout, f, filter, options.output)
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.output, "output", "o", options.output,
+		"Output format. One of: jsonl, jsonpath=TEMPLATE, go-template=TEMPLATE")
+	cmd.PersistentFlags().StringSliceVar(&options.status, "status", options.status,
+		"Display requests whose response status is in these classes, e.g. 5xx; repeat the flag or separate the classes with commas")
+	cmd.PersistentFlags().DurationVar(&options.minLatency, "min-latency", options.minLatency,
+		"Display requests whose response took at least this long, e.g. 200ms")
+	cmd.PersistentFlags().StringVar(&options.rpcMethod, "rpc-method", options.rpcMethod,
+		"Display requests to this gRPC method, e.g. /package.Service/Method")
+
+	return cmd
+}
+
+// replayTap renders the events recorded in r that match the filter.
+func replayTap(w io.Writer, r io.Reader, filter *pb.TapByResourceRequest_Filter, output string) error {
+	events, err := newTapFileReader(r)
+	if err != nil {
+		return err
+	}
+
+	tapFilter, err := util.NewTapFilter(filter)
+	if err != nil {
+		return err
+	}
+	tapClient := &filteredTapClient{Api_TapByResourceClient: events, filter: tapFilter}
+
+	if isTapJSONOutput(output) {
+		err = renderTapJSONL(w, tapClient, output)
+	} else {
+		err = renderTap(w, tapClient, "", false)
+	}
+	if err != nil {
+		return err
+	}
+	return events.err
+}
+
+// recordingAPIClient wraps a public API client, writing the events of its
+// taps to a file as they're received.
+type recordingAPIClient struct {
+	pb.ApiClient
+	w io.Writer
+}
+
+// newRecordingAPIClient writes the header of the file to w.
+func newRecordingAPIClient(client pb.ApiClient, w io.Writer) (pb.ApiClient, error) {
+	header := append(append([]byte{}, tapFileHeader...), tapFileVersion)
+	if _, err := w.Write(header); err != nil {
+		return nil, err
+	}
+	return &recordingAPIClient{ApiClient: client, w: w}, nil
+}
+
+func (c *recordingAPIClient) TapByResource(ctx context.Context, in *pb.TapByResourceRequest, opts ...grpc.CallOption) (pb.Api_TapByResourceClient, error) {
+	rsp, err := c.ApiClient.TapByResource(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &recordingTapClient{Api_TapByResourceClient: rsp, w: c.w}, nil
+}
+
+type recordingTapClient struct {
+	pb.Api_TapByResourceClient
+	w io.Writer
+}
+
+func (c *recordingTapClient) Recv() (*pb.TapEvent, error) {
+	event, err := c.Api_TapByResourceClient.Recv()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := proto.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	length := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(length, uint64(len(data)))
+	// a single write per event, so that an interrupted tap leaves whole events
+	if _, err := c.w.Write(append(length[:n], data...)); err != nil {
+		return nil, fmt.Errorf("failed to record the tap event: %s", err)
+	}
+
+	return event, nil
+}
+
+// tapFileReader reads the events of a recorded tap, as a tap stream ending at
+// the end of the file. The stream also ends when the file can't be read, with
+// the error kept in err, as the renderers only report the errors of live taps.
+type tapFileReader struct {
+	grpc.ClientStream
+	r   *bufio.Reader
+	err error
+}
+
+func newTapFileReader(r io.Reader) (*tapFileReader, error) {
+	reader := bufio.NewReader(r)
+	header := make([]byte, len(tapFileHeader)+1)
+	if _, err := io.ReadFull(reader, header); err != nil || !bytes.Equal(header[:len(tapFileHeader)], tapFileHeader) {
+		return nil, errors.New("not a file recorded by linkerd tap --record")
+	}
+	if version := header[len(tapFileHeader)]; version != tapFileVersion {
+		return nil, fmt.Errorf("unsupported tap file version %d", version)
+	}
+	return &tapFileReader{r: reader}, nil
+}
+
+func (t *tapFileReader) Recv() (*pb.TapEvent, error) {
+	event, err := t.read()
+	if err != nil {
+		if err != io.EOF {
+			t.err = err
+		}
+		return nil, io.EOF
+	}
+	return event, nil
+}
+
+func (t *tapFileReader) read() (*pb.TapEvent, error) {
+	length, err := binary.ReadUvarint(t.r)
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the tap event: %s", err)
+	}
+	if length > maxTapFileEventBytes {
+		return nil, fmt.Errorf("invalid tap event length %d", length)
+	}
+
+	data := make([]byte, length)
+	if _, err := io.ReadFull(t.r, data); err != nil {
+		return nil, fmt.Errorf("failed to read the tap event: %s", err)
+	}
+	var event pb.TapEvent
+	if err := proto.Unmarshal(data, &event); err != nil {
+		return nil, fmt.Errorf("failed to read the tap event: %s", err)
+	}
+	return &event, nil
+}
+
+// filteredTapClient only returns the events of a tap stream matching the
+// filter.
+type filteredTapClient struct {
+	pb.Api_TapByResourceClient
+	filter  *util.TapFilter
+	pending []*pb.TapEvent
+}
+
+func (c *filteredTapClient) Recv() (*pb.TapEvent, error) {
+	for len(c.pending) == 0 {
+		event, err := c.Api_TapByResourceClient.Recv()
+		if err != nil {
+			return nil, err
+		}
+		c.pending = c.filter.Apply(event)
+	}
+
+	event := c.pending[0]
+	c.pending = c.pending[1:]
+	return event, nil
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/linkerd/linkerd2/controller/api/public"
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+)
+
+func TestTapRecordAndReplay(t *testing.T) {
+	newStream := func(base uint32, status uint32) []pb.TapEvent {
+		id := &pb.TapEvent_Http_StreamId{Base: base}
+		return []pb.TapEvent{
+			createEvent(&pb.TapEvent_Http{
+				Event: &pb.TapEvent_Http_RequestInit_{
+					RequestInit: &pb.TapEvent_Http_RequestInit{Id: id, Authority: "web-svc", Path: "/api/list"},
+				},
+			}, map[string]string{}),
+			createEvent(&pb.TapEvent_Http{
+				Event: &pb.TapEvent_Http_ResponseInit_{
+					ResponseInit: &pb.TapEvent_Http_ResponseInit{
+						Id:               id,
+						HttpStatus:       status,
+						SinceRequestInit: &duration.Duration{Nanos: 1000000},
+					},
+				},
+			}, map[string]string{}),
+			createEvent(&pb.TapEvent_Http{
+				Event: &pb.TapEvent_Http_ResponseEnd_{
+					ResponseEnd: &pb.TapEvent_Http_ResponseEnd{
+						Id:                id,
+						Eos:               &pb.Eos{End: &pb.Eos_GrpcStatusCode{GrpcStatusCode: 0}},
+						SinceRequestInit:  &duration.Duration{Nanos: 2000000},
+						SinceResponseInit: &duration.Duration{Nanos: 1000000},
+						ResponseBytes:     42,
+					},
+				},
+			}, map[string]string{}),
+		}
+	}
+
+	req, err := util.BuildTapByResourceRequest(util.TapRequestParams{Resource: "deploy/web"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var recording bytes.Buffer
+	mockApiClient := &public.MockApiClient{}
+	mockApiClient.Api_TapByResourceClientToReturn = &public.MockApi_TapByResourceClient{
+		TapEventsToReturn: append(newStream(1, 200), newStream(2, 503)...),
+	}
+	client, err := newRecordingAPIClient(mockApiClient, &recording)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	var tapped bytes.Buffer
+	if err := requestTapByResourceFromAPI(&tapped, client, req, "", false); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	t.Run("Replays the recorded events", func(t *testing.T) {
+		var replayed bytes.Buffer
+		if err := replayTap(&replayed, bytes.NewReader(recording.Bytes()), nil, ""); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if replayed.String() != tapped.String() {
+			t.Fatalf("Expected the replay to render:\n%s\nbut got:\n%s", tapped.String(), replayed.String())
+		}
+	})
+
+	t.Run("Filters the recorded events", func(t *testing.T) {
+		filter, err := util.BuildTapFilter(util.TapRequestParams{StatusClasses: []string{"5xx"}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		var replayed bytes.Buffer
+		if err := replayTap(&replayed, bytes.NewReader(recording.Bytes()), filter, jsonlOutput); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		diffCompareFile(t, replayed.String(), "tap_replay_filtered_output.golden")
+	})
+
+	t.Run("Rejects the files not recorded by tap", func(t *testing.T) {
+		err := replayTap(&bytes.Buffer{}, strings.NewReader("not a tap"), nil, "")
+		if err == nil || err.Error() != "not a file recorded by linkerd tap --record" {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Reports the truncated files", func(t *testing.T) {
+		truncated := recording.Bytes()[:recording.Len()-1]
+		err := replayTap(&bytes.Buffer{}, bytes.NewReader(truncated), nil, "")
+		if err == nil || !strings.HasPrefix(err.Error(), "failed to read the tap event") {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"google.golang.org/grpc"
+)
+
+// maxSummaryPaths is the number of paths listed by the summary of a tap.
+const maxSummaryPaths = 5
+
+// tapSummary aggregates the events of a tap, to be reported once the tap
+// ends.
+type tapSummary struct {
+	requests  int
+	responses int
+	failures  int
+	statuses  map[uint32]int
+	latencies []time.Duration
+	paths     map[string]int
+}
+
+func newTapSummary() *tapSummary {
+	return &tapSummary{
+		statuses: make(map[uint32]int),
+		paths:    make(map[string]int),
+	}
+}
+
+func (s *tapSummary) add(event *pb.TapEvent) {
+	switch ev := event.GetHttp().GetEvent().(type) {
+	case *pb.TapEvent_Http_RequestInit_:
+		s.requests++
+		s.paths[ev.RequestInit.GetPath()]++
+
+	case *pb.TapEvent_Http_ResponseInit_:
+		status := ev.ResponseInit.GetHttpStatus()
+		s.statuses[status]++
+		if status >= 500 {
+			s.failures++
+		}
+
+	case *pb.TapEvent_Http_ResponseEnd_:
+		s.responses++
+		if latency, err := ptypes.Duration(ev.ResponseEnd.GetSinceRequestInit()); err == nil {
+			s.latencies = append(s.latencies, latency)
+		}
+		// gRPC errors are reported with a 200 status, and only known once the
+		// response ends
+		switch eos := ev.ResponseEnd.GetEos().GetEnd().(type) {
+		case *pb.Eos_GrpcStatusCode:
+			if eos.GrpcStatusCode != 0 {
+				s.failures++
+			}
+		case *pb.Eos_ResetErrorCode:
+			s.failures++
+		}
+	}
+}
+
+// render writes the summary. The success rate counts the 5xx responses, the
+// gRPC errors and the reset streams as failures.
+func (s *tapSummary) render(w io.Writer) {
+	fmt.Fprintln(w, "")
+	fmt.Fprintln(w, "Summary:")
+	if s.requests == 0 {
+		fmt.Fprintln(w, "  No requests observed")
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, padding, ' ', 0)
+	fmt.Fprintf(tw, "  Requests:\t%d\n", s.requests)
+	if s.responses > 0 {
+		successes := s.responses - s.failures
+		if successes < 0 {
+			successes = 0
+		}
+		fmt.Fprintf(tw, "  Success rate:\t%.2f%%\n", 100*float64(successes)/float64(s.responses))
+	}
+	if len(s.latencies) > 0 {
+		sort.Slice(s.latencies, func(i, j int) bool { return s.latencies[i] < s.latencies[j] })
+		fmt.Fprintf(tw, "  Latency:\tp50=%s p95=%s p99=%s max=%s\n",
+			formatDuration(latencyPercentile(s.latencies, 50)),
+			formatDuration(latencyPercentile(s.latencies, 95)),
+			formatDuration(latencyPercentile(s.latencies, 99)),
+			formatDuration(s.latencies[len(s.latencies)-1]),
+		)
+	}
+	tw.Flush()
+
+	if len(s.statuses) > 0 {
+		statuses := make([]uint32, 0, len(s.statuses))
+		for status := range s.statuses {
+			statuses = append(statuses, status)
+		}
+		sort.Slice(statuses, func(i, j int) bool { return statuses[i] < statuses[j] })
+
+		fmt.Fprintln(w, "  Status codes:")
+		for _, status := range statuses {
+			fmt.Fprintf(tw, "    %d\t%d\n", status, s.statuses[status])
+		}
+		tw.Flush()
+	}
+
+	paths := make([]string, 0, len(s.paths))
+	for path := range s.paths {
+		paths = append(paths, path)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if s.paths[paths[i]] != s.paths[paths[j]] {
+			return s.paths[paths[i]] > s.paths[paths[j]]
+		}
+		return paths[i] < paths[j]
+	})
+	if len(paths) > maxSummaryPaths {
+		paths = paths[:maxSummaryPaths]
+	}
+	fmt.Fprintln(w, "  Top paths:")
+	for _, path := range paths {
+		fmt.Fprintf(tw, "    %s\t%d\n", path, s.paths[path])
+	}
+	tw.Flush()
+}
+
+// latencyPercentile returns the nearest-rank percentile of the sorted
+// latencies.
+func latencyPercentile(sorted []time.Duration, percentile float64) time.Duration {
+	rank := int(math.Ceil(percentile / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// summarizingAPIClient wraps a public API client, adding the events of its
+// taps to a summary as they're received.
+type summarizingAPIClient struct {
+	pb.ApiClient
+	summary *tapSummary
+}
+
+func (c *summarizingAPIClient) TapByResource(ctx context.Context, in *pb.TapByResourceRequest, opts ...grpc.CallOption) (pb.Api_TapByResourceClient, error) {
+	rsp, err := c.ApiClient.TapByResource(ctx, in, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &summarizingTapClient{Api_TapByResourceClient: rsp, summary: c.summary}, nil
+}
+
+type summarizingTapClient struct {
+	pb.Api_TapByResourceClient
+	summary *tapSummary
+}
+
+func (c *summarizingTapClient) Recv() (*pb.TapEvent, error) {
+	event, err := c.Api_TapByResourceClient.Recv()
+	if err != nil {
+		return nil, err
+	}
+	c.summary.add(event)
+	return event, nil
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/linkerd/linkerd2/controller/api/public"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+)
+
+func summaryRequest(base uint32, path string, status uint32, latencyMs int32, eos *pb.Eos) []pb.TapEvent {
+	id := &pb.TapEvent_Http_StreamId{Base: base}
+	return []pb.TapEvent{
+		createEvent(&pb.TapEvent_Http{Event: &pb.TapEvent_Http_RequestInit_{RequestInit: &pb.TapEvent_Http_RequestInit{
+			Id:   id,
+			Path: path,
+		}}}, map[string]string{}),
+		createEvent(&pb.TapEvent_Http{Event: &pb.TapEvent_Http_ResponseInit_{ResponseInit: &pb.TapEvent_Http_ResponseInit{
+			Id:         id,
+			HttpStatus: status,
+		}}}, map[string]string{}),
+		createEvent(&pb.TapEvent_Http{Event: &pb.TapEvent_Http_ResponseEnd_{ResponseEnd: &pb.TapEvent_Http_ResponseEnd{
+			Id:               id,
+			SinceRequestInit: &duration.Duration{Nanos: latencyMs * 1000000},
+			Eos:              eos,
+		}}}, map[string]string{}),
+	}
+}
+
+func TestTapSummary(t *testing.T) {
+	t.Run("Summarizes the tapped requests", func(t *testing.T) {
+		var events []pb.TapEvent
+		for i := uint32(0); i < 6; i++ {
+			events = append(events, summaryRequest(i, "/api/list", 200, int32(i+1), nil)...)
+		}
+		events = append(events, summaryRequest(6, "/api/vote", 503, 30, nil)...)
+		events = append(events, summaryRequest(7, "/api/vote", 200, 40, &pb.Eos{End: &pb.Eos_GrpcStatusCode{GrpcStatusCode: 14}})...)
+		for i, path := range []string{"/a", "/b", "/c", "/d"} {
+			events = append(events, summaryRequest(uint32(8+i), path, 404, 1, nil)...)
+		}
+
+		summary := newTapSummary()
+		client := &summarizingAPIClient{
+			ApiClient: &public.MockApiClient{
+				Api_TapByResourceClientToReturn: &public.MockApi_TapByResourceClient{TapEventsToReturn: events},
+			},
+			summary: summary,
+		}
+		rsp, err := client.TapByResource(cliContext, &pb.TapByResourceRequest{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for range events {
+			if _, err := rsp.Recv(); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+
+		var buf bytes.Buffer
+		summary.render(&buf)
+		diffCompareFile(t, buf.String(), "tap_summary_output.golden")
+	})
+
+	t.Run("Reports taps without requests", func(t *testing.T) {
+		var buf bytes.Buffer
+		newTapSummary().render(&buf)
+		expected := "\nSummary:\n  No requests observed\n"
+		if buf.String() != expected {
+			t.Fatalf("Expected summary %q, got %q", expected, buf.String())
+		}
+	})
+}
@@ -16,7 +16,9 @@ import (
 	"google.golang.org/grpc/codes"
 )
 
-func busyTest(t *testing.T, wide bool) {
+const tapJSONPathOutput = "jsonpath={.type} {.id} {.request.path}"
+
+func busyTest(t *testing.T, output string) {
 	resourceType := k8s.Pod
 	targetName := "pod-666"
 	params := util.TapRequestParams{
@@ -77,15 +79,20 @@ func busyTest(t *testing.T, wide bool) {
 	}
 
 	writer := bytes.NewBufferString("")
-	err = requestTapByResourceFromAPI(writer, mockApiClient, req, wide)
+	err = requestTapByResourceFromAPI(writer, mockApiClient, req, output, false)
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 
 	var goldenFilePath string
-	if wide {
+	switch output {
+	case wideOutput:
 		goldenFilePath = "testdata/tap_busy_output_wide.golden"
-	} else {
+	case jsonlOutput:
+		goldenFilePath = "testdata/tap_busy_output_jsonl.golden"
+	case tapJSONPathOutput:
+		goldenFilePath = "testdata/tap_busy_output_jsonpath.golden"
+	default:
 		goldenFilePath = "testdata/tap_busy_output.golden"
 	}
 
@@ -94,19 +101,27 @@ func busyTest(t *testing.T, wide bool) {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 	expectedContent := string(goldenFileBytes)
-	output := writer.String()
-	if expectedContent != output {
-		t.Fatalf("Expected function to render:\n%s\bbut got:\n%s", expectedContent, output)
+	actual := writer.String()
+	if expectedContent != actual {
+		t.Fatalf("Expected function to render:\n%s\bbut got:\n%s", expectedContent, actual)
 	}
 }
 
 func TestRequestTapByResourceFromAPI(t *testing.T) {
 	t.Run("Should render busy response if everything went well", func(t *testing.T) {
-		busyTest(t, false)
+		busyTest(t, "")
 	})
 
 	t.Run("Should render wide busy response if everything went well", func(t *testing.T) {
-		busyTest(t, true)
+		busyTest(t, wideOutput)
+	})
+
+	t.Run("Should render a JSON object per event with -o jsonl", func(t *testing.T) {
+		busyTest(t, jsonlOutput)
+	})
+
+	t.Run("Should execute the template against each event with -o jsonpath", func(t *testing.T) {
+		busyTest(t, tapJSONPathOutput)
 	})
 
 	t.Run("Should render empty response if no events returned", func(t *testing.T) {
@@ -131,7 +146,7 @@ func TestRequestTapByResourceFromAPI(t *testing.T) {
 		}
 
 		writer := bytes.NewBufferString("")
-		err = requestTapByResourceFromAPI(writer, mockApiClient, req, false)
+		err = requestTapByResourceFromAPI(writer, mockApiClient, req, "", false)
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -170,7 +185,7 @@ func TestRequestTapByResourceFromAPI(t *testing.T) {
 		}
 
 		writer := bytes.NewBufferString("")
-		err = requestTapByResourceFromAPI(writer, mockApiClient, req, false)
+		err = requestTapByResourceFromAPI(writer, mockApiClient, req, "", false)
 		if err == nil {
 			t.Fatalf("Expecting error, got nothing but output [%s]", writer.String())
 		}
@@ -272,6 +287,29 @@ func TestEventToString(t *testing.T) {
 		}
 	})
 
+	t.Run("Converts gRPC response end event with message counts to string", func(t *testing.T) {
+		event := toTapEvent(&pb.TapEvent_Http{
+			Event: &pb.TapEvent_Http_ResponseEnd_{
+				ResponseEnd: &pb.TapEvent_Http_ResponseEnd{
+					SinceRequestInit:  &duration.Duration{Nanos: 999000},
+					SinceResponseInit: &duration.Duration{Nanos: 888000},
+					ResponseBytes:     111,
+					Eos: &pb.Eos{
+						End: &pb.Eos_GrpcStatusCode{GrpcStatusCode: uint32(codes.Unavailable)},
+					},
+					RequestMessages:  1,
+					ResponseMessages: 3,
+				},
+			},
+		})
+
+		expectedOutput := "end id=7:8 proxy=out src=1.2.3.4:5555 dst=2.3.4.5:6666 tls= grpc-status=Unavailable duration=888µs response-length=111B request-messages=1 response-messages=3"
+		output := util.RenderTapEvent(event, "")
+		if output != expectedOutput {
+			t.Fatalf("Expecting command output to be [%s], got [%s]", expectedOutput, output)
+		}
+	})
+
 	t.Run("Converts HTTP response end event with reset error code to string", func(t *testing.T) {
 		event := toTapEvent(&pb.TapEvent_Http{
 			Event: &pb.TapEvent_Http_ResponseEnd_{
@@ -330,6 +368,62 @@ func TestEventToString(t *testing.T) {
 		}
 	})
 
+	t.Run("Converts the identities of the peers to string", func(t *testing.T) {
+		event := toTapEvent(&pb.TapEvent_Http{
+			Event: &pb.TapEvent_Http_ResponseInit_{
+				ResponseInit: &pb.TapEvent_Http_ResponseInit{
+					SinceRequestInit: &duration.Duration{Nanos: 999000},
+					HttpStatus:       http.StatusOK,
+				},
+			},
+		})
+		event.SourceMeta = &pb.TapEvent_EndpointMeta{Labels: map[string]string{
+			util.ClientIdentityLabel: "web.emojivoto.serviceaccount.identity.linkerd.cluster.local",
+		}}
+		event.DestinationMeta = &pb.TapEvent_EndpointMeta{Labels: map[string]string{
+			"tls":                    "true",
+			util.ServerIdentityLabel: "emoji.emojivoto.serviceaccount.identity.linkerd.cluster.local",
+		}}
+
+		expectedOutput := "rsp id=7:8 proxy=out src=1.2.3.4:5555 dst=2.3.4.5:6666 tls=true client_id=web.emojivoto.serviceaccount.identity.linkerd.cluster.local server_id=emoji.emojivoto.serviceaccount.identity.linkerd.cluster.local :status=200 latency=999µs"
+		output := util.RenderTapEventWithIdentity(event, "")
+		if output != expectedOutput {
+			t.Fatalf("Expecting command output to be [%s], got [%s]", expectedOutput, output)
+		}
+
+		expectedOutput = "rsp id=7:8 proxy=out src=1.2.3.4:5555 dst=2.3.4.5:6666 tls=true :status=200 latency=999µs"
+		output = util.RenderTapEvent(event, "")
+		if output != expectedOutput {
+			t.Fatalf("Expecting command output to be [%s], got [%s]", expectedOutput, output)
+		}
+	})
+
+	t.Run("Omits the identities the proxies don't report", func(t *testing.T) {
+		event := toTapEvent(&pb.TapEvent_Http{
+			Event: &pb.TapEvent_Http_ResponseInit_{
+				ResponseInit: &pb.TapEvent_Http_ResponseInit{
+					SinceRequestInit: &duration.Duration{Nanos: 999000},
+					HttpStatus:       http.StatusOK,
+				},
+			},
+		})
+		event.DestinationMeta = &pb.TapEvent_EndpointMeta{Labels: map[string]string{"tls": "true"}}
+
+		expectedOutput := "rsp id=7:8 proxy=out src=1.2.3.4:5555 dst=2.3.4.5:6666 tls=true :status=200 latency=999µs"
+		output := util.RenderTapEventWithIdentity(event, "")
+		if output != expectedOutput {
+			t.Fatalf("Expecting command output to be [%s], got [%s]", expectedOutput, output)
+		}
+		if !util.MissingIdentity(event) {
+			t.Fatal("Expected the identity of the server to be missing")
+		}
+
+		event.DestinationMeta.Labels["tls"] = "no_identity"
+		if util.MissingIdentity(event) {
+			t.Fatal("Expected no identity without mTLS")
+		}
+	})
+
 	t.Run("Handles unknown event types", func(t *testing.T) {
 		event := toTapEvent(&pb.TapEvent_Http{})
 
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
 	"io"
 	"os"
@@ -30,6 +29,7 @@ type topOptions struct {
 	authority   string
 	path        string
 	hideSources bool
+	groupBy     string
 }
 
 type topRequest struct {
@@ -49,6 +49,9 @@ func (id topRequestID) String() string {
 	return fmt.Sprintf("%s->%s(%d)", id.src, id.dst, id.stream)
 }
 
+// tableRow aggregates the requests sharing the same value of the --group-by
+// flag. With the default path grouping, the rows are also keyed by the method
+// and the destination of the requests, and by their source unless hidden.
 type tableRow struct {
 	by          string
 	method      string
@@ -62,13 +65,61 @@ type tableRow struct {
 	failures    int
 }
 
-const headerHeight = 3
+const (
+	headerHeight = 3
+
+	groupBySource      = "source"
+	groupByDestination = "destination"
+	groupByPath        = "path"
+	groupByAuthority   = "authority"
+)
+
+var groupByValues = []string{groupBySource, groupByDestination, groupByPath, groupByAuthority}
+
+// topColumn is a column of the table, at least width wide.
+type topColumn struct {
+	name  string
+	width int
+	value func(row tableRow) string
+}
 
 var (
-	columnNames  = []string{"Source", "Destination", "Method", "Path", "Count", "Best", "Worst", "Last", "Success Rate"}
-	columnWidths = []int{23, 23, 10, 37, 6, 6, 6, 6, 3}
+	topSourceColumn      = topColumn{"Source", 23, func(row tableRow) string { return row.source }}
+	topDestinationColumn = topColumn{"Destination", 23, func(row tableRow) string { return row.destination }}
+	topMethodColumn      = topColumn{"Method", 10, func(row tableRow) string { return row.method }}
+	topPathColumn        = topColumn{"Path", 37, func(row tableRow) string { return row.by }}
+	topAuthorityColumn   = topColumn{"Authority", 37, func(row tableRow) string { return row.by }}
+
+	topStatColumns = []topColumn{
+		{"Count", 6, func(row tableRow) string { return strconv.Itoa(row.count) }},
+		{"Best", 6, func(row tableRow) string { return formatDuration(row.best) }},
+		{"Worst", 6, func(row tableRow) string { return formatDuration(row.worst) }},
+		{"Last", 6, func(row tableRow) string { return formatDuration(row.last) }},
+		{"Success Rate", 3, func(row tableRow) string {
+			return fmt.Sprintf("%.2f%%", 100.0*float32(row.successes)/float32(row.successes+row.failures))
+		}},
+	}
 )
 
+// topColumns returns the columns of the table for the grouping of the rows.
+func topColumns(groupBy string, withSource bool) []topColumn {
+	columns := []topColumn{}
+	switch groupBy {
+	case groupBySource:
+		columns = append(columns, topSourceColumn)
+	case groupByDestination:
+		columns = append(columns, topDestinationColumn)
+	case groupByAuthority:
+		columns = append(columns, topAuthorityColumn)
+	default:
+		if withSource {
+			columns = append(columns, topSourceColumn)
+		}
+		columns = append(columns, topDestinationColumn, topMethodColumn, topPathColumn)
+	}
+	return append(columns, topStatColumns...)
+}
+
 func newTopOptions() *topOptions {
 	return &topOptions{
 		namespace:   "default",
@@ -80,6 +131,7 @@ func newTopOptions() *topOptions {
 		authority:   "",
 		path:        "",
 		hideSources: false,
+		groupBy:     groupByPath,
 	}
 }
 
@@ -111,10 +163,25 @@ func newCmdTop() *cobra.Command {
   linkerd top deploy/web
 
   # display traffic for the web-dlbvj pod in the default namespace
-  linkerd top pod/web-dlbvj`,
+  linkerd top pod/web-dlbvj
+
+  # display the callers sending the most requests to the web deployment
+  linkerd top deploy/web --group-by source
+
+  # display the live route stats of the web service in the default namespace
+  linkerd top routes svc/web`,
 		Args:      cobra.RangeArgs(1, 2),
 		ValidArgs: util.ValidTargets,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			switch options.groupBy {
+			case groupBySource, groupByDestination, groupByPath, groupByAuthority:
+			default:
+				return fmt.Errorf("--group-by must be one of: %s", strings.Join(groupByValues, ", "))
+			}
+			if options.hideSources && options.groupBy == groupBySource {
+				return fmt.Errorf("--hide-sources can't be combined with --group-by %s", groupBySource)
+			}
+
 			requestParams := util.TapRequestParams{
 				Resource:    strings.Join(args, "/"),
 				Namespace:   options.namespace,
@@ -136,29 +203,34 @@ func newCmdTop() *cobra.Command {
 		},
 	}
 
-	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace,
+	cmd.Flags().StringVarP(&options.namespace, "namespace", "n", options.namespace,
 		"Namespace of the specified resource")
-	cmd.PersistentFlags().StringVar(&options.toResource, "to", options.toResource,
+	cmd.Flags().StringVar(&options.toResource, "to", options.toResource,
 		"Display requests to this resource")
-	cmd.PersistentFlags().StringVar(&options.toNamespace, "to-namespace", options.toNamespace,
+	cmd.Flags().StringVar(&options.toNamespace, "to-namespace", options.toNamespace,
 		"Sets the namespace used to lookup the \"--to\" resource; by default the current \"--namespace\" is used")
-	cmd.PersistentFlags().Float32Var(&options.maxRps, "max-rps", options.maxRps,
+	cmd.Flags().Float32Var(&options.maxRps, "max-rps", options.maxRps,
 		"Maximum requests per second to tap.")
-	cmd.PersistentFlags().StringVar(&options.scheme, "scheme", options.scheme,
+	cmd.Flags().StringVar(&options.scheme, "scheme", options.scheme,
 		"Display requests with this scheme")
-	cmd.PersistentFlags().StringVar(&options.method, "method", options.method,
+	cmd.Flags().StringVar(&options.method, "method", options.method,
 		"Display requests with this HTTP method")
-	cmd.PersistentFlags().StringVar(&options.authority, "authority", options.authority,
+	cmd.Flags().StringVar(&options.authority, "authority", options.authority,
 		"Display requests with this :authority")
-	cmd.PersistentFlags().StringVar(&options.path, "path", options.path,
+	cmd.Flags().StringVar(&options.path, "path", options.path,
 		"Display requests with paths that start with this prefix")
-	cmd.PersistentFlags().BoolVar(&options.hideSources, "hide-sources", options.hideSources, "Hide the source column")
+	cmd.Flags().BoolVar(&options.hideSources, "hide-sources", options.hideSources, "Hide the source column")
+	cmd.Flags().StringVar(&options.groupBy, "group-by", options.groupBy,
+		fmt.Sprintf("Aggregate the requests by this attribute; one of: %s", strings.Join(groupByValues, ", ")))
 
+	markFlagConfigurable(cmd.Flags(), "namespace", "namespace")
+
+	cmd.AddCommand(newCmdTopRoutes())
 	return cmd
 }
 
 func getTrafficByResourceFromAPI(w io.Writer, client pb.ApiClient, req *pb.TapByResourceRequest, options *topOptions) error {
-	rsp, err := client.TapByResource(context.Background(), req)
+	rsp, err := client.TapByResource(cliContext, req)
 	if err != nil {
 		return err
 	}
@@ -175,7 +247,7 @@ func getTrafficByResourceFromAPI(w io.Writer, client pb.ApiClient, req *pb.TapBy
 	go recvEvents(rsp, requestCh, done)
 	go pollInput(done)
 
-	renderTable(requestCh, done, !options.hideSources)
+	renderTable(requestCh, done, options.groupBy, !options.hideSources)
 
 	return nil
 }
@@ -239,8 +311,9 @@ func pollInput(done chan<- struct{}) {
 	}
 }
 
-func renderTable(requestCh <-chan topRequest, done <-chan struct{}, withSource bool) {
+func renderTable(requestCh <-chan topRequest, done <-chan struct{}, groupBy string, withSource bool) {
 	ticker := time.NewTicker(100 * time.Millisecond)
+	columns := topColumns(groupBy, withSource)
 	var table []tableRow
 
 	for {
@@ -248,28 +321,45 @@ func renderTable(requestCh <-chan topRequest, done <-chan struct{}, withSource b
 		case <-done:
 			return
 		case req := <-requestCh:
-			tableInsert(&table, req, withSource)
+			tableInsert(&table, req, groupBy, withSource)
 		case <-ticker.C:
 			termbox.Clear(termbox.ColorDefault, termbox.ColorDefault)
-			renderHeaders(withSource)
-			renderTableBody(&table, withSource)
+			adjustColumnWidths(columns, table)
+			renderHeaders(columns)
+			renderTableBody(&table, columns)
 			termbox.Flush()
 		}
 	}
 }
 
-func tableInsert(table *[]tableRow, req topRequest, withSource bool) {
-	by := req.reqInit.GetPath()
-	method := req.reqInit.GetMethod().GetRegistered().String()
+func tableInsert(table *[]tableRow, req topRequest, groupBy string, withSource bool) {
 	source := stripPort(addr.PublicAddressToString(req.event.GetSource()))
-	if pod := req.event.SourceMeta.Labels["pod"]; pod != "" {
+	if pod := req.event.GetSourceMeta().GetLabels()["pod"]; pod != "" {
 		source = pod
 	}
 	destination := stripPort(addr.PublicAddressToString(req.event.GetDestination()))
-	if pod := req.event.DestinationMeta.Labels["pod"]; pod != "" {
+	if pod := req.event.GetDestinationMeta().GetLabels()["pod"]; pod != "" {
 		destination = pod
 	}
 
+	// the fields not displayed are left empty, so that they don't split the rows
+	var by, method string
+	switch groupBy {
+	case groupBySource:
+		destination = ""
+	case groupByDestination:
+		source = ""
+	case groupByAuthority:
+		by = req.reqInit.GetAuthority()
+		source, destination = "", ""
+	default:
+		by = req.reqInit.GetPath()
+		method = req.reqInit.GetMethod().GetRegistered().String()
+		if !withSource {
+			source = ""
+		}
+	}
+
 	latency, err := ptypes.Duration(req.rspEnd.GetSinceRequestInit())
 	if err != nil {
 		log.Errorf("error parsing duration %v: %s", req.rspEnd.GetSinceRequestInit(), err)
@@ -288,7 +378,7 @@ func tableInsert(table *[]tableRow, req topRequest, withSource bool) {
 
 	found := false
 	for i, row := range *table {
-		if row.by == by && row.method == method && row.destination == destination && (row.source == source || !withSource) {
+		if row.by == by && row.method == method && row.destination == destination && row.source == source {
 			(*table)[i].count++
 			if latency.Nanoseconds() < row.best.Nanoseconds() {
 				(*table)[i].best = latency
@@ -334,17 +424,13 @@ func stripPort(address string) string {
 	return strings.Split(address, ":")[0]
 }
 
-func renderHeaders(withSource bool) {
+func renderHeaders(columns []topColumn) {
 	tbprint(0, 0, "(press q to quit)")
 	x := 0
-	for i, header := range columnNames {
-		if i == 0 && !withSource {
-			continue
-		}
-		width := columnWidths[i]
-		padded := fmt.Sprintf("%-"+strconv.Itoa(width)+"s ", header)
+	for _, column := range columns {
+		padded := fmt.Sprintf("%-"+strconv.Itoa(column.width)+"s ", column.name)
 		tbprintBold(x, 2, padded)
-		x += width + 1
+		x += column.width + 1
 	}
 }
 
@@ -355,39 +441,25 @@ func max(i, j int) int {
 	return j
 }
 
-func renderTableBody(table *[]tableRow, withSource bool) {
+func renderTableBody(table *[]tableRow, columns []topColumn) {
 	sort.SliceStable(*table, func(i, j int) bool {
 		return (*table)[i].count > (*table)[j].count
 	})
-	adjustedColumnWidths := columnWidths
-	for _, row := range *table {
-		adjustedColumnWidths[0] = max(adjustedColumnWidths[0], runewidth.StringWidth(row.source))
-		adjustedColumnWidths[1] = max(adjustedColumnWidths[1], runewidth.StringWidth(row.destination))
-		adjustedColumnWidths[3] = max(adjustedColumnWidths[3], runewidth.StringWidth(row.by))
-
-	}
 	for i, row := range *table {
 		x := 0
-		if withSource {
-			tbprint(x, i+headerHeight, row.source)
-			x += adjustedColumnWidths[0] + 1
+		for _, column := range columns {
+			tbprint(x, i+headerHeight, column.value(row))
+			x += column.width + 1
+		}
+	}
+}
+
+// adjustColumnWidths widens the columns to fit the values of the rows.
+func adjustColumnWidths(columns []topColumn, table []tableRow) {
+	for i := range columns {
+		for _, row := range table {
+			columns[i].width = max(columns[i].width, runewidth.StringWidth(columns[i].value(row)))
 		}
-		tbprint(x, i+headerHeight, row.destination)
-		x += adjustedColumnWidths[1] + 1
-		tbprint(x, i+headerHeight, row.method)
-		x += adjustedColumnWidths[2] + 1
-		tbprint(x, i+headerHeight, row.by)
-		x += adjustedColumnWidths[3] + 1
-		tbprint(x, i+headerHeight, strconv.Itoa(row.count))
-		x += adjustedColumnWidths[4] + 1
-		tbprint(x, i+headerHeight, formatDuration(row.best))
-		x += adjustedColumnWidths[5] + 1
-		tbprint(x, i+headerHeight, formatDuration(row.worst))
-		x += adjustedColumnWidths[6] + 1
-		tbprint(x, i+headerHeight, formatDuration(row.last))
-		x += adjustedColumnWidths[7] + 1
-		successRate := fmt.Sprintf("%.2f%%", 100.0*float32(row.successes)/float32(row.successes+row.failures))
-		tbprint(x, i+headerHeight, successRate)
 	}
 }
 
@@ -0,0 +1,288 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	termbox "github.com/nsf/termbox-go"
+	"github.com/spf13/cobra"
+)
+
+// topRoutesView is the state of the top routes view: the last route stats
+// received, and the sort column and route filter picked with the keyboard.
+type topRoutesView struct {
+	options *routesOptions
+	rows    []*pb.RouteTable_Row
+	err     error
+	updated time.Time
+
+	// editing is true while the route filter is being typed, in filter; the
+	// filter is applied as it's typed, and restored to previousFilter if the
+	// edit is canceled
+	editing        bool
+	filter         string
+	previousFilter string
+	filterErr      error
+}
+
+// topRoutesLine is a line of the top routes view.
+type topRoutesLine struct {
+	text string
+	bold bool
+}
+
+type topRoutesResult struct {
+	rows []*pb.RouteTable_Row
+	err  error
+}
+
+const topRoutesHelp = "(press q to quit, s to change the sort column, r to reverse the order, / to filter the routes)"
+
+func newCmdTopRoutes() *cobra.Command {
+	options := newRoutesOptions()
+
+	cmd := &cobra.Command{
+		Use:   "routes [flags] (RESOURCE)",
+		Short: "Display live route stats",
+		Long: `Display live route stats.
+
+The route stats of "linkerd routes" are redrawn every "--interval" in an
+interactive view, where the routes can be sorted by any column and filtered by
+name:
+
+  * s, →  sort by the next column
+  * ←     sort by the previous column
+  * r     reverse the order
+  * /     type a regular expression the routes must match; press enter to
+          apply it, or esc to cancel
+  * esc   clear the filter
+  * q     quit
+
+This command will only display traffic which is sent to a service that has a Service Profile defined.`,
+		Example: `  # Live routes of the webapp service in the test namespace.
+  linkerd top routes service/webapp -n test
+
+  # Live routes of the calls from the traffic deployment to the webapp service,
+  # slowest first.
+  linkerd top routes deploy/traffic -n test --to svc/webapp --sort-by p99 --reverse`,
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: util.ValidTargets,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reqs, err := buildTopRoutesRequests(args[0], options)
+			if err != nil {
+				return newCliError(exitCodeInvalidFlags, fmt.Errorf("error creating metrics request while making routes request: %v", err))
+			}
+			if options.watchInterval <= 0 {
+				return newCliError(exitCodeInvalidFlags, errors.New("--interval must be positive"))
+			}
+
+			return topRoutes(validatedPublicAPIClient(time.Time{}), reqs, options)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace of the specified resource")
+	cmd.Flags().StringVarP(&options.timeWindow, "time-window", "t", options.timeWindow, "Stat window (for example: \"10s\", \"1m\", \"10m\", \"1h\")")
+	cmd.Flags().BoolVarP(&options.allNamespaces, "all-namespaces", "A", options.allNamespaces, "If present, shows the routes of the resources of every namespace, ignoring the \"--namespace\" flag; the resource can't be named")
+	cmd.Flags().StringSliceVar(&options.toResources, "to", options.toResources, "If present, shows outbound stats to the specified resources; repeat the flag or separate the resources with commas to compare several destinations")
+	cmd.Flags().StringVar(&options.toNamespace, "to-namespace", options.toNamespace, "Sets the namespace used to lookup the \"--to\" resources; by default the current \"--namespace\" is used")
+	cmd.Flags().StringVar(&options.route, "route", options.route, "Initial filter of the routes, a regular expression their name must match")
+	cmd.Flags().StringVar(&options.sortBy, "sort-by", options.sortBy, fmt.Sprintf("Initial column to sort the routes by; one of: %s", strings.Join(routeSortKeyNames, ", ")))
+	cmd.Flags().BoolVar(&options.reverse, "reverse", options.reverse, "Initially sort the routes in descending order")
+	cmd.Flags().DurationVar(&options.watchInterval, "interval", options.watchInterval, "Interval between the queries of the route stats")
+
+	markFlagConfigurable(cmd.Flags(), "namespace", "namespace")
+	markFlagConfigurable(cmd.Flags(), "time-window", "timeWindow")
+	return cmd
+}
+
+// topRoutes runs the top routes view until the user quits.
+func topRoutes(client pb.ApiClient, reqs []*pb.TopRoutesRequest, options *routesOptions) error {
+	if err := termbox.Init(); err != nil {
+		return err
+	}
+	defer termbox.Close()
+
+	events := make(chan termbox.Event)
+	results := make(chan topRoutesResult)
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		for {
+			events <- termbox.PollEvent()
+		}
+	}()
+	go pollRouteStats(client, reqs, options.watchInterval, results, done)
+
+	view := &topRoutesView{options: options, filter: options.route}
+	view.draw()
+	for {
+		select {
+		case ev := <-events:
+			if ev.Type == termbox.EventKey && view.handleKey(ev) {
+				return nil
+			}
+		case res := <-results:
+			view.rows, view.err, view.updated = res.rows, res.err, time.Now()
+		case <-cliContext.Done():
+			return nil
+		}
+		view.draw()
+	}
+}
+
+// pollRouteStats sends the route stats to results every interval, until done
+// is closed.
+func pollRouteStats(client pb.ApiClient, reqs []*pb.TopRoutesRequest, interval time.Duration, results chan<- topRoutesResult, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		rows, err := requestRoutesFromAPI(client, reqs)
+		select {
+		case results <- topRoutesResult{rows, err}:
+		case <-done:
+			return
+		}
+
+		select {
+		case <-ticker.C:
+		case <-done:
+			return
+		}
+	}
+}
+
+// handleKey updates the view for a key press, and returns true if the user
+// quits.
+func (v *topRoutesView) handleKey(ev termbox.Event) bool {
+	if ev.Key == termbox.KeyCtrlC {
+		return true
+	}
+
+	if v.editing {
+		switch {
+		case ev.Key == termbox.KeyEnter:
+			v.editing = false
+		case ev.Key == termbox.KeyEsc:
+			v.editing = false
+			v.setFilter(v.previousFilter)
+		case ev.Key == termbox.KeyBackspace || ev.Key == termbox.KeyBackspace2:
+			if runes := []rune(v.filter); len(runes) > 0 {
+				v.setFilter(string(runes[:len(runes)-1]))
+			}
+		case ev.Key == termbox.KeySpace:
+			v.setFilter(v.filter + " ")
+		case ev.Ch != 0:
+			v.setFilter(v.filter + string(ev.Ch))
+		}
+		return false
+	}
+
+	switch {
+	case ev.Ch == 'q':
+		return true
+	case ev.Ch == 's' || ev.Key == termbox.KeyArrowRight:
+		v.moveSortKey(1)
+	case ev.Key == termbox.KeyArrowLeft:
+		v.moveSortKey(-1)
+	case ev.Ch == 'r':
+		v.options.reverse = !v.options.reverse
+	case ev.Ch == '/':
+		v.editing = true
+		v.filter, v.previousFilter = v.options.route, v.options.route
+	case ev.Key == termbox.KeyEsc:
+		v.setFilter("")
+	}
+	return false
+}
+
+// setFilter sets the route filter being typed, and applies it if it's a valid
+// regular expression.
+func (v *topRoutesView) setFilter(filter string) {
+	v.filter = filter
+	if filter == "" {
+		v.options.route, v.options.routeRegex, v.filterErr = "", nil, nil
+		return
+	}
+	regex, err := regexp.Compile(filter)
+	if err != nil {
+		v.filterErr = err
+		return
+	}
+	v.options.route, v.options.routeRegex, v.filterErr = filter, regex, nil
+}
+
+func (v *topRoutesView) moveSortKey(step int) {
+	for i, key := range routeSortKeyNames {
+		if key == v.options.sortBy {
+			n := len(routeSortKeyNames)
+			v.options.sortBy = routeSortKeyNames[((i+step)%n+n)%n]
+			return
+		}
+	}
+}
+
+// lines returns the lines of the view: the help, the sort order and filter,
+// and the route table, rendered as by "linkerd routes".
+func (v *topRoutesView) lines() []topRoutesLine {
+	order := "ascending"
+	if v.options.reverse {
+		order = "descending"
+	}
+	status := fmt.Sprintf("Sorted by %s (%s)", v.options.sortBy, order)
+	if v.options.route != "" {
+		status += fmt.Sprintf(", routes matching %s", v.options.route)
+	}
+	if !v.updated.IsZero() {
+		status += fmt.Sprintf(", last updated %s", v.updated.Format("15:04:05"))
+	}
+	lines := []topRoutesLine{{text: topRoutesHelp}, {text: status}}
+
+	if v.editing {
+		filter := fmt.Sprintf("Filter: %s_", v.filter)
+		if v.filterErr != nil {
+			filter += fmt.Sprintf("  (invalid regular expression: %s)", v.filterErr)
+		}
+		lines = append(lines, topRoutesLine{text: filter})
+	}
+	lines = append(lines, topRoutesLine{})
+
+	switch {
+	case v.err != nil:
+		return append(lines, topRoutesLine{text: v.err.Error()})
+	case v.updated.IsZero():
+		return append(lines, topRoutesLine{text: "Waiting for the route stats..."})
+	}
+
+	output, err := renderRouteStats(v.rows, nil, v.options)
+	if err != nil {
+		return append(lines, topRoutesLine{text: err.Error()})
+	}
+	heading := true
+	for _, text := range strings.Split(strings.TrimSuffix(output, "\n"), "\n") {
+		// the headings of the tables are the first line, and the lines
+		// following the namespace names with --all-namespaces
+		namespace := strings.HasPrefix(text, "namespace/")
+		lines = append(lines, topRoutesLine{text: text, bold: heading || namespace})
+		heading = namespace
+	}
+	return lines
+}
+
+func (v *topRoutesView) draw() {
+	termbox.Clear(termbox.ColorDefault, termbox.ColorDefault)
+	for y, line := range v.lines() {
+		if line.bold {
+			tbprintBold(0, y, line.text)
+		} else {
+			tbprint(0, y, line.text)
+		}
+	}
+	termbox.Flush()
+}
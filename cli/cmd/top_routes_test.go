@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/public"
+	termbox "github.com/nsf/termbox-go"
+)
+
+func TestTopRoutesView(t *testing.T) {
+	key := func(k termbox.Key) termbox.Event { return termbox.Event{Type: termbox.EventKey, Key: k} }
+	char := func(c rune) termbox.Event { return termbox.Event{Type: termbox.EventKey, Ch: c} }
+
+	newView := func() *topRoutesView {
+		response := public.GenTopRoutesResponse([]string{"/a", "/b"}, []uint64{90, 60})
+		return &topRoutesView{
+			options: newRoutesOptions(),
+			rows:    response.GetRoutes().GetRows(),
+			updated: time.Now(),
+		}
+	}
+
+	// routesOf returns the routes of the table, which follows the first blank
+	// line of the view
+	routesOf := func(v *topRoutesView) []string {
+		routes := []string{}
+		table := false
+		for _, line := range v.lines() {
+			if table && !line.bold {
+				routes = append(routes, strings.Fields(line.text)[0])
+			}
+			table = table || line.text == ""
+		}
+		return routes
+	}
+
+	t.Run("Changes the sort column and order", func(t *testing.T) {
+		v := newView()
+		v.handleKey(char('s'))
+		if v.options.sortBy != "rps" {
+			t.Fatalf("Expected to sort by rps, got %s", v.options.sortBy)
+		}
+		v.handleKey(key(termbox.KeyArrowLeft))
+		v.handleKey(key(termbox.KeyArrowLeft))
+		if v.options.sortBy != "tls" {
+			t.Fatalf("Expected to sort by tls, got %s", v.options.sortBy)
+		}
+
+		v.handleKey(char('r'))
+		v.options.sortBy = "rps"
+		if routes := strings.Join(routesOf(v), ","); routes != "/a,/b" {
+			t.Fatalf("Expected the routes to be sorted by decreasing rps, got %s", routes)
+		}
+		if status := v.lines()[1].text; !strings.HasPrefix(status, "Sorted by rps (descending)") {
+			t.Fatalf("Unexpected status %s", status)
+		}
+	})
+
+	t.Run("Filters the routes as the filter is typed", func(t *testing.T) {
+		v := newView()
+		for _, ev := range []termbox.Event{char('/'), char('/'), char('b'), char('q')} {
+			if v.handleKey(ev) {
+				t.Fatal("Expected q to be typed in the filter")
+			}
+		}
+		v.handleKey(key(termbox.KeyBackspace2))
+		if routes := strings.Join(routesOf(v), ","); routes != "/b" {
+			t.Fatalf("Expected only the /b route, got %s", routes)
+		}
+
+		v.handleKey(key(termbox.KeyEnter))
+		if v.editing || v.options.route != "/b" {
+			t.Fatalf("Expected the /b filter to be applied, got %s", v.options.route)
+		}
+
+		// an invalid filter is ignored, and a canceled edit restores the filter
+		v.handleKey(char('/'))
+		v.handleKey(char('('))
+		if v.filterErr == nil || v.options.route != "/b" {
+			t.Fatalf("Expected the invalid filter to be ignored, got %s", v.options.route)
+		}
+		v.handleKey(key(termbox.KeyBackspace2))
+		v.handleKey(key(termbox.KeyBackspace2))
+		v.handleKey(key(termbox.KeyEsc))
+		if v.options.route != "/b" {
+			t.Fatalf("Expected the /b filter to be restored, got %s", v.options.route)
+		}
+
+		v.handleKey(key(termbox.KeyEsc))
+		if routes := strings.Join(routesOf(v), ","); routes != "/a,/b" {
+			t.Fatalf("Expected the filter to be cleared, got %s", routes)
+		}
+	})
+
+	t.Run("Quits on q and ctrl-c", func(t *testing.T) {
+		v := newView()
+		if !v.handleKey(char('q')) || !v.handleKey(key(termbox.KeyCtrlC)) {
+			t.Fatal("Expected to quit")
+		}
+	})
+}
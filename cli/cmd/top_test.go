@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/ptypes/duration"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+)
+
+func TestTableInsert(t *testing.T) {
+	newRequest := func(source, destination, authority, path string) topRequest {
+		return topRequest{
+			event: &pb.TapEvent{
+				SourceMeta:      &pb.TapEvent_EndpointMeta{Labels: map[string]string{"pod": source}},
+				DestinationMeta: &pb.TapEvent_EndpointMeta{Labels: map[string]string{"pod": destination}},
+			},
+			reqInit: &pb.TapEvent_Http_RequestInit{Authority: authority, Path: path},
+			rspInit: &pb.TapEvent_Http_ResponseInit{HttpStatus: 200},
+			rspEnd:  &pb.TapEvent_Http_ResponseEnd{SinceRequestInit: &duration.Duration{Nanos: 1000000}},
+		}
+	}
+	requests := []topRequest{
+		newRequest("vote-bot", "web", "web-svc", "/api/vote"),
+		newRequest("vote-bot", "web", "web-svc", "/api/list"),
+		newRequest("vote-bot", "web", "web-svc", "/api/vote"),
+		newRequest("ingress", "web", "web.example.com", "/api/list"),
+	}
+
+	testCases := []struct {
+		groupBy    string
+		withSource bool
+		expected   map[string]int
+	}{
+		{groupByPath, true, map[string]int{"vote-bot|web|/api/vote": 2, "vote-bot|web|/api/list": 1, "ingress|web|/api/list": 1}},
+		{groupByPath, false, map[string]int{"|web|/api/vote": 2, "|web|/api/list": 2}},
+		{groupBySource, true, map[string]int{"vote-bot||": 3, "ingress||": 1}},
+		{groupByDestination, true, map[string]int{"|web|": 4}},
+		{groupByAuthority, true, map[string]int{"||web-svc": 3, "||web.example.com": 1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.groupBy, func(t *testing.T) {
+			var table []tableRow
+			for _, req := range requests {
+				tableInsert(&table, req, tc.groupBy, tc.withSource)
+			}
+
+			counts := make(map[string]int)
+			for _, row := range table {
+				counts[row.source+"|"+row.destination+"|"+row.by] = row.count
+			}
+			if len(counts) != len(tc.expected) {
+				t.Fatalf("Expected rows %v, got %v", tc.expected, counts)
+			}
+			for key, count := range tc.expected {
+				if counts[key] != count {
+					t.Fatalf("Expected rows %v, got %v", tc.expected, counts)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/cosign"
+	"github.com/spf13/cobra"
+)
+
+// addVerifyImagesFlag adds the flag enabling the verification of the image
+// signatures, for the commands that render the Linkerd images.
+func addVerifyImagesFlag(cmd *cobra.Command, options *proxyConfigOptions) {
+	cmd.PersistentFlags().StringVar(&options.verifyImagesKey, "verify-images-key", options.verifyImagesKey, "Path to a PEM-encoded cosign public key; if set, fail unless every rendered Linkerd image is signed with it, and pin the images to their verified digests")
+}
+
+// proxyImages returns the images added to the injected pods.
+func (options *proxyConfigOptions) proxyImages() []string {
+	images := []string{options.taggedProxyImage()}
+	if !options.noInitContainer {
+		images = append(images, options.taggedProxyInitImage())
+	}
+	return images
+}
+
+// verifyImages checks that the images are signed with the key at keyPath, and
+// returns a check failure listing the images that aren't. Otherwise, it
+// returns the images pinned to the digests they were verified at, keyed by
+// image, so that a tag pushed after the check isn't deployed.
+func verifyImages(keyPath string, images []string) (map[string]string, error) {
+	keyPEM, err := ioutil.ReadFile(keyPath)
+	if err != nil {
+		return nil, newCliError(exitCodeInvalidFlags, fmt.Errorf("failed to read --verify-images-key: %s", err))
+	}
+	verifier, err := cosign.NewVerifier(keyPEM, &http.Client{Timeout: 30 * time.Second})
+	if err != nil {
+		return nil, newCliError(exitCodeInvalidFlags, fmt.Errorf("invalid --verify-images-key %s: %s", keyPath, err))
+	}
+
+	return checkImages(images, verifier.Verify)
+}
+
+// checkImages runs verify on every image, failing closed: any error fails the
+// check. It returns the verified images pinned to their digests.
+func checkImages(images []string, verify func(string) (string, error)) (map[string]string, error) {
+	pinned := map[string]string{}
+	failures := []string{}
+	for _, image := range images {
+		digest, err := verify(image)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("  %s: %s", image, err))
+			continue
+		}
+		pinned[image] = cosign.PinDigest(image, digest)
+	}
+	if len(failures) > 0 {
+		return nil, newCliError(exitCodeCheckFailed, fmt.Errorf("image signature verification failed:\n%s", strings.Join(failures, "\n")))
+	}
+	return pinned, nil
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckImages(t *testing.T) {
+	signed := map[string]bool{"gcr.io/linkerd-io/proxy:stable-2.1.0": true}
+	verify := func(image string) (string, error) {
+		if !signed[image] {
+			return "", errors.New("no signature found")
+		}
+		return "sha256:1234", nil
+	}
+
+	pinned, err := checkImages([]string{"gcr.io/linkerd-io/proxy:stable-2.1.0"}, verify)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if pinned["gcr.io/linkerd-io/proxy:stable-2.1.0"] != "gcr.io/linkerd-io/proxy@sha256:1234" {
+		t.Fatalf("Expected the image to be pinned to its verified digest, got %v", pinned)
+	}
+
+	_, err = checkImages([]string{"gcr.io/linkerd-io/proxy:stable-2.1.0", "gcr.io/linkerd-io/proxy-init:stable-2.1.0"}, verify)
+	expected := "image signature verification failed:\n  gcr.io/linkerd-io/proxy-init:stable-2.1.0: no signature found"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error [%s], got [%v]", expected, err)
+	}
+	if cliErr, ok := err.(*cliError); !ok || cliErr.code != exitCodeCheckFailed {
+		t.Fatalf("Expected a check failure, got %#v", err)
+	}
+}
+
+func TestLinkerdImages(t *testing.T) {
+	options := newInstallOptions()
+	options.linkerdVersion = "stable-2.1.0"
+	options.noInitContainer = true
+	config, err := validateAndBuildConfig(options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	for _, image := range config.linkerdImages() {
+		if image == options.taggedProxyInitImage() {
+			t.Fatalf("Expected the proxy-init image to be skipped with the CNI plugin")
+		}
+	}
+	if len(config.linkerdImages()) != 4 {
+		t.Fatalf("Expected 4 images, got %v", config.linkerdImages())
+	}
+
+	pinned := map[string]string{}
+	for _, image := range config.linkerdImages() {
+		pinned[image] = image + "-pinned"
+	}
+	proxyImage := config.ProxyImage
+	config.pinImages(pinned)
+	if config.ProxyImage != proxyImage+"-pinned" || config.ControllerImage != pinned[options.dockerRegistry+"/controller:stable-2.1.0"] {
+		t.Fatalf("Expected the images to be pinned, got %v", config.linkerdImages())
+	}
+
+	options.verifiedImages = map[string]string{proxyImage: "gcr.io/linkerd-io/proxy@sha256:1234"}
+	if image := options.taggedProxyImage(); image != "gcr.io/linkerd-io/proxy@sha256:1234" {
+		t.Fatalf("Expected the injected proxy image to be pinned, got %s", image)
+	}
+}
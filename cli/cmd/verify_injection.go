@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/spf13/cobra"
+	appsV1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+)
+
+const (
+	injectionIssueNotInjected   = "NotInjected"
+	injectionIssueOutdatedProxy = "OutdatedProxy"
+	injectionIssueNoSidecar     = "MissingSidecar"
+)
+
+type verifyInjectionOptions struct {
+	namespace    string
+	proxyVersion string
+	outputFormat string
+}
+
+func newVerifyInjectionOptions() *verifyInjectionOptions {
+	return &verifyInjectionOptions{
+		namespace:    "",
+		proxyVersion: newProxyConfigOptions().linkerdVersion,
+		outputFormat: tableOutput,
+	}
+}
+
+// injectionIssue is a workload whose pods are not injected as expected.
+type injectionIssue struct {
+	Namespace string `json:"namespace"`
+	Workload  string `json:"workload"`
+	Issue     string `json:"issue"`
+	Detail    string `json:"detail"`
+	Pods      int    `json:"pods"`
+}
+
+type injectionReportSummary struct {
+	Pods         int `json:"pods"`
+	MeshedPods   int `json:"meshedPods"`
+	Workloads    int `json:"workloads"`
+	FailedChecks int `json:"failedChecks"`
+}
+
+type injectionReport struct {
+	Summary injectionReportSummary `json:"summary"`
+	Issues  []injectionIssue       `json:"issues"`
+}
+
+func newCmdVerifyInjection() *cobra.Command {
+	options := newVerifyInjectionOptions()
+
+	cmd := &cobra.Command{
+		Use:   "verify-injection [flags]",
+		Short: "Report workloads that are not injected as expected",
+		Long: `Report workloads that are not injected as expected.
+
+The verify-injection command scans the pods in the cluster and reports the
+workloads that:
+  * are labeled for auto-injection but don't have a proxy (NotInjected)
+  * run a proxy of a different version than --proxy-version (OutdatedProxy)
+  * run without a proxy in a namespace with auto-injection enabled (MissingSidecar)
+
+The command exits with a non-zero exit code if any workload is reported.`,
+		Example: `  # Report injection problems across the cluster
+  linkerd verify-injection
+
+  # Report injection problems in the "emojivoto" namespace as JSON
+  linkerd verify-injection -n emojivoto -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if options.outputFormat != tableOutput && options.outputFormat != jsonOutput {
+				return newCliError(exitCodeInvalidFlags, fmt.Errorf("--output supports %s and %s", tableOutput, jsonOutput))
+			}
+
+			kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup)
+			if err != nil {
+				return err
+			}
+			client, err := kubeAPI.NewClient()
+			if err != nil {
+				return err
+			}
+
+			var pods []v1.Pod
+			if options.namespace == "" {
+				pods, err = kubeAPI.GetAllPods(client)
+			} else {
+				pods, err = kubeAPI.GetPodsByNamespace(client, options.namespace)
+			}
+			if err != nil {
+				return err
+			}
+
+			namespaces, err := kubeAPI.GetNamespaces(client)
+			if err != nil {
+				return err
+			}
+
+			report := verifyInjection(pods, namespaces, options.proxyVersion)
+			if err := renderInjectionReport(report, os.Stdout, options.outputFormat); err != nil {
+				return err
+			}
+
+			if len(report.Issues) > 0 {
+				os.Exit(exitCodeCheckFailed)
+			}
+			return nil
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&options.namespace, "namespace", "n", options.namespace, "Namespace to scan; all namespaces are scanned by default")
+	cmd.PersistentFlags().StringVar(&options.proxyVersion, "proxy-version", options.proxyVersion, "Proxy version that injected workloads are expected to run")
+	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, "Output format; one of: \"table\" or \"json\"")
+
+	return cmd
+}
+
+// verifyInjection checks every pod against the injection settings of its
+// namespace, and groups the pods that fail a check by workload.
+func verifyInjection(pods []v1.Pod, namespaces []v1.Namespace, proxyVersion string) *injectionReport {
+	autoInjectNamespaces := map[string]bool{}
+	for _, ns := range namespaces {
+		if ns.Labels[k8s.ProxyAutoInjectLabel] == k8s.ProxyAutoInjectEnabled {
+			autoInjectNamespaces[ns.Name] = true
+		}
+	}
+
+	report := &injectionReport{Issues: []injectionIssue{}}
+	issues := map[string]*injectionIssue{}
+	workloads := map[string]bool{}
+
+	for i := range pods {
+		pod := &pods[i]
+		workload := podWorkload(pod)
+		workloads[pod.Namespace+"/"+workload] = true
+		report.Summary.Pods++
+
+		issue, detail := checkPodInjection(pod, autoInjectNamespaces[pod.Namespace], proxyVersion)
+		if hasProxyContainer(pod) {
+			report.Summary.MeshedPods++
+		}
+		if issue == "" {
+			continue
+		}
+
+		key := strings.Join([]string{pod.Namespace, workload, issue, detail}, "/")
+		if existing, ok := issues[key]; ok {
+			existing.Pods++
+			continue
+		}
+		issues[key] = &injectionIssue{
+			Namespace: pod.Namespace,
+			Workload:  workload,
+			Issue:     issue,
+			Detail:    detail,
+			Pods:      1,
+		}
+	}
+
+	for _, issue := range issues {
+		report.Issues = append(report.Issues, *issue)
+	}
+	sort.Slice(report.Issues, func(i, j int) bool {
+		a, b := report.Issues[i], report.Issues[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Workload != b.Workload {
+			return a.Workload < b.Workload
+		}
+		return a.Issue < b.Issue
+	})
+
+	report.Summary.Workloads = len(workloads)
+	report.Summary.FailedChecks = len(report.Issues)
+	return report
+}
+
+// checkPodInjection returns the injection issue found for the pod, if any,
+// along with a human-readable detail.
+func checkPodInjection(pod *v1.Pod, autoInjectNamespace bool, proxyVersion string) (string, string) {
+	if hasProxyContainer(pod) {
+		version := pod.Annotations[k8s.ProxyVersionAnnotation]
+		if proxyVersion != "" && version != proxyVersion {
+			if version == "" {
+				version = "unknown"
+			}
+			return injectionIssueOutdatedProxy, fmt.Sprintf("proxy version %s, expected %s", version, proxyVersion)
+		}
+		return "", ""
+	}
+
+	switch pod.Labels[k8s.ProxyAutoInjectLabel] {
+	case k8s.ProxyAutoInjectEnabled:
+		return injectionIssueNotInjected, fmt.Sprintf("labeled %s=%s", k8s.ProxyAutoInjectLabel, k8s.ProxyAutoInjectEnabled)
+	case k8s.ProxyAutoInjectDisabled:
+		return "", ""
+	}
+
+	if autoInjectNamespace && !pod.Spec.HostNetwork {
+		return injectionIssueNoSidecar, fmt.Sprintf("namespace labeled %s=%s", k8s.ProxyAutoInjectLabel, k8s.ProxyAutoInjectEnabled)
+	}
+
+	return "", ""
+}
+
+func hasProxyContainer(pod *v1.Pod) bool {
+	for _, container := range pod.Spec.Containers {
+		if container.Name == k8s.ProxyContainerName {
+			return true
+		}
+	}
+	return false
+}
+
+// podWorkload returns the kind/name of the workload that owns the pod,
+// resolving replicasets to their deployment.
+func podWorkload(pod *v1.Pod) string {
+	for _, owner := range pod.OwnerReferences {
+		if owner.Controller == nil || !*owner.Controller {
+			continue
+		}
+
+		kind := strings.ToLower(owner.Kind)
+		name := owner.Name
+		if owner.Kind == "ReplicaSet" {
+			if hash := pod.Labels[appsV1.DefaultDeploymentUniqueLabelKey]; hash != "" && strings.HasSuffix(name, "-"+hash) {
+				kind = "deployment"
+				name = strings.TrimSuffix(name, "-"+hash)
+			}
+		}
+		return kind + "/" + name
+	}
+
+	return "pod/" + pod.Name
+}
+
+func renderInjectionReport(report *injectionReport, w io.Writer, outputFormat string) error {
+	if outputFormat == jsonOutput {
+		out, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%s\n", out)
+		return nil
+	}
+
+	if len(report.Issues) == 0 {
+		fmt.Fprintf(w, "All %d workloads are injected as expected\n", report.Summary.Workloads)
+		return nil
+	}
+
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 0, padding, ' ', 0)
+	fmt.Fprintln(tw, strings.Join([]string{"NAMESPACE", "WORKLOAD", "PODS", "ISSUE", "DETAIL"}, "\t"))
+	for _, issue := range report.Issues {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n", issue.Namespace, issue.Workload, issue.Pods, issue.Issue, issue.Detail)
+	}
+	tw.Flush()
+
+	fmt.Fprint(w, buf.String())
+	fmt.Fprintf(w, "\n%d injection issues found in %d workloads\n", len(report.Issues), report.Summary.Workloads)
+	return nil
+}
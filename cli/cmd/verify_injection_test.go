@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestVerifyInjection(t *testing.T) {
+	controller := true
+	newPod := func(namespace, name, owner string, labels, annotations map[string]string, proxy bool) v1.Pod {
+		pod := v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:        name,
+				Namespace:   namespace,
+				Labels:      labels,
+				Annotations: annotations,
+			},
+			Spec: v1.PodSpec{
+				Containers: []v1.Container{{Name: "app"}},
+			},
+		}
+		if owner != "" {
+			pod.OwnerReferences = []metaV1.OwnerReference{{Kind: "ReplicaSet", Name: owner, Controller: &controller}}
+		}
+		if proxy {
+			pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{Name: k8s.ProxyContainerName})
+		}
+		return pod
+	}
+
+	namespaces := []v1.Namespace{
+		{ObjectMeta: metaV1.ObjectMeta{Name: "meshed", Labels: map[string]string{k8s.ProxyAutoInjectLabel: k8s.ProxyAutoInjectEnabled}}},
+		{ObjectMeta: metaV1.ObjectMeta{Name: "other"}},
+	}
+
+	hash := map[string]string{"pod-template-hash": "abc"}
+	pods := []v1.Pod{
+		// up to date
+		newPod("meshed", "web-abc-1", "web-abc", hash, map[string]string{k8s.ProxyVersionAnnotation: "v2"}, true),
+		// outdated, two pods of the same deployment
+		newPod("meshed", "api-abc-1", "api-abc", hash, map[string]string{k8s.ProxyVersionAnnotation: "v1"}, true),
+		newPod("meshed", "api-abc-2", "api-abc", hash, map[string]string{k8s.ProxyVersionAnnotation: "v1"}, true),
+		// missing sidecar in an auto-inject namespace
+		newPod("meshed", "db-abc-1", "db-abc", hash, nil, false),
+		// explicitly opted out
+		newPod("meshed", "job", "", map[string]string{k8s.ProxyAutoInjectLabel: k8s.ProxyAutoInjectDisabled}, nil, false),
+		// labeled for injection but not injected
+		newPod("other", "cache", "", map[string]string{k8s.ProxyAutoInjectLabel: k8s.ProxyAutoInjectEnabled}, nil, false),
+		// not meshed, not expected to be
+		newPod("other", "batch", "", nil, nil, false),
+	}
+
+	report := verifyInjection(pods, namespaces, "v2")
+
+	expectedSummary := injectionReportSummary{Pods: 7, MeshedPods: 3, Workloads: 6, FailedChecks: 3}
+	if report.Summary != expectedSummary {
+		t.Fatalf("Expected summary %+v, got %+v", expectedSummary, report.Summary)
+	}
+
+	expectedIssues := []injectionIssue{
+		{Namespace: "meshed", Workload: "deployment/api", Issue: injectionIssueOutdatedProxy, Detail: "proxy version v1, expected v2", Pods: 2},
+		{Namespace: "meshed", Workload: "deployment/db", Issue: injectionIssueNoSidecar, Detail: "namespace labeled linkerd.io/auto-inject=enabled", Pods: 1},
+		{Namespace: "other", Workload: "pod/cache", Issue: injectionIssueNotInjected, Detail: "labeled linkerd.io/auto-inject=enabled", Pods: 1},
+	}
+	if !reflect.DeepEqual(report.Issues, expectedIssues) {
+		t.Fatalf("Expected issues %+v, got %+v", expectedIssues, report.Issues)
+	}
+
+	t.Run("Renders a table of the issues", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := renderInjectionReport(report, &buf, tableOutput); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		output := buf.String()
+		if !strings.Contains(output, "deployment/api") || !strings.Contains(output, "3 injection issues found in 6 workloads") {
+			t.Fatalf("Unexpected output:\n%s", output)
+		}
+	})
+}
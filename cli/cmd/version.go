@@ -2,14 +2,20 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/linkerd/linkerd2/controller/api/public"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/healthcheck"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/version"
 	"github.com/spf13/cobra"
+	"k8s.io/api/core/v1"
 )
 
 const DefaultVersionString = "unavailable"
@@ -17,15 +23,48 @@ const DefaultVersionString = "unavailable"
 type versionOptions struct {
 	shortVersion      bool
 	onlyClientVersion bool
+	outputFormat      string
+	cniNamespace      string
 }
 
 func newVersionOptions() *versionOptions {
 	return &versionOptions{
 		shortVersion:      false,
 		onlyClientVersion: false,
+		outputFormat:      tableOutput,
+		cniNamespace:      healthcheck.DefaultCNIPluginNamespace,
 	}
 }
 
+// versionReport is the version of every Linkerd component running in the
+// cluster, as output by `linkerd version -o json`.
+type versionReport struct {
+	Client       string              `json:"client"`
+	Server       string              `json:"server,omitempty"`
+	ControlPlane []componentVersion  `json:"controlPlane,omitempty"`
+	CNIPlugin    []componentVersion  `json:"cniPlugin,omitempty"`
+	DataPlane    []dataPlaneVersions `json:"dataPlane,omitempty"`
+}
+
+// componentVersion is the version of a pod of a Linkerd component.
+type componentVersion struct {
+	Component  string             `json:"component"`
+	Pod        string             `json:"pod"`
+	Containers []containerVersion `json:"containers"`
+}
+
+type containerVersion struct {
+	Name   string `json:"name"`
+	Image  string `json:"image"`
+	Digest string `json:"digest,omitempty"`
+}
+
+// dataPlaneVersions is the number of meshed pods running a proxy version.
+type dataPlaneVersions struct {
+	ProxyVersion string `json:"proxyVersion"`
+	Pods         int    `json:"pods"`
+}
+
 func newCmdVersion() *cobra.Command {
 	options := newVersionOptions()
 
@@ -33,6 +72,24 @@ func newCmdVersion() *cobra.Command {
 		Use:   "version",
 		Short: "Print the client and server version information",
 		Run: func(cmd *cobra.Command, args []string) {
+			if options.outputFormat != tableOutput && options.outputFormat != jsonOutput {
+				fmt.Fprintf(os.Stderr, "--output must be one of: table, json\n")
+				os.Exit(1)
+			}
+
+			if options.outputFormat == jsonOutput {
+				report, err := buildVersionReport(options)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Error connecting to server: %s\n", err)
+					os.Exit(1)
+				}
+				if err := renderVersionReport(report, os.Stdout); err != nil {
+					fmt.Fprintf(os.Stderr, "Error rendering version report: %s\n", err)
+					os.Exit(1)
+				}
+				return
+			}
+
 			clientVersion := version.Version
 			if options.shortVersion {
 				fmt.Println(clientVersion)
@@ -60,6 +117,8 @@ func newCmdVersion() *cobra.Command {
 	cmd.Args = cobra.NoArgs
 	cmd.PersistentFlags().BoolVar(&options.shortVersion, "short", options.shortVersion, "Print the version number(s) only, with no additional output")
 	cmd.PersistentFlags().BoolVar(&options.onlyClientVersion, "client", options.onlyClientVersion, "Print the client version only")
+	cmd.PersistentFlags().StringVarP(&options.outputFormat, "output", "o", options.outputFormat, "Output format; one of: \"table\" or \"json\". The JSON output includes the versions of the control plane, CNI plugin and data plane pods")
+	cmd.PersistentFlags().StringVar(&options.cniNamespace, "cni-namespace", options.cniNamespace, "Namespace in which the CNI plugin is installed")
 
 	return cmd
 }
@@ -78,9 +137,119 @@ func newVersionClient() (pb.ApiClient, error) {
 	if apiAddr != "" {
 		return public.NewInternalClient(controlPlaneNamespace, apiAddr)
 	}
-	kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext)
+	kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup)
 	if err != nil {
 		return nil, err
 	}
 	return public.NewExternalClient(controlPlaneNamespace, kubeAPI)
 }
+
+// buildVersionReport fetches the server version and the pods of all the Linkerd
+// components. Only the client version is reported with --client.
+func buildVersionReport(options *versionOptions) (*versionReport, error) {
+	if options.onlyClientVersion {
+		return &versionReport{Client: version.Version}, nil
+	}
+
+	client, err := newVersionClient()
+	if err != nil {
+		return nil, err
+	}
+
+	kubeAPI, err := k8s.NewAPI(kubeconfigPath, kubeContext, impersonate, impersonateGroup)
+	if err != nil {
+		return nil, err
+	}
+	httpClient, err := kubeAPI.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	pods, err := kubeAPI.GetAllPods(httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return newVersionReport(version.Version, getServerVersion(client), pods, controlPlaneNamespace, options.cniNamespace), nil
+}
+
+// newVersionReport sorts the pods into the control plane, CNI plugin and data
+// plane ones. Data plane pods are only counted by proxy version, as there can
+// be many of them.
+func newVersionReport(clientVersion, serverVersion string, pods []v1.Pod, controlPlaneNamespace, cniNamespace string) *versionReport {
+	report := &versionReport{
+		Client: clientVersion,
+		Server: serverVersion,
+	}
+
+	proxyVersions := map[string]int{}
+	for i := range pods {
+		pod := &pods[i]
+		component := pod.Labels[k8s.ControllerComponentLabel]
+
+		switch {
+		case pod.Namespace == controlPlaneNamespace && component != "":
+			report.ControlPlane = append(report.ControlPlane, newComponentVersion(component, pod))
+		case pod.Namespace == cniNamespace && component == "cni":
+			report.CNIPlugin = append(report.CNIPlugin, newComponentVersion(component, pod))
+		case hasProxyContainer(pod):
+			proxyVersion := pod.Annotations[k8s.ProxyVersionAnnotation]
+			if proxyVersion == "" {
+				proxyVersion = DefaultVersionString
+			}
+			proxyVersions[proxyVersion]++
+		}
+	}
+
+	for proxyVersion, count := range proxyVersions {
+		report.DataPlane = append(report.DataPlane, dataPlaneVersions{ProxyVersion: proxyVersion, Pods: count})
+	}
+	sort.Slice(report.DataPlane, func(i, j int) bool {
+		return report.DataPlane[i].ProxyVersion < report.DataPlane[j].ProxyVersion
+	})
+
+	return report
+}
+
+func newComponentVersion(component string, pod *v1.Pod) componentVersion {
+	digests := map[string]string{}
+	for _, status := range pod.Status.ContainerStatuses {
+		digests[status.Name] = imageDigest(status.ImageID)
+	}
+
+	containers := []containerVersion{}
+	for _, container := range pod.Spec.Containers {
+		containers = append(containers, containerVersion{
+			Name:   container.Name,
+			Image:  container.Image,
+			Digest: digests[container.Name],
+		})
+	}
+
+	return componentVersion{
+		Component:  component,
+		Pod:        pod.Name,
+		Containers: containers,
+	}
+}
+
+// imageDigest returns the digest of an image ID reported by the container
+// runtime, e.g. "docker-pullable://gcr.io/linkerd-io/proxy@sha256:abc" returns
+// "sha256:abc".
+func imageDigest(imageID string) string {
+	if i := strings.LastIndex(imageID, "@"); i != -1 {
+		return imageID[i+1:]
+	}
+	if strings.HasPrefix(imageID, "sha256:") {
+		return imageID
+	}
+	return ""
+}
+
+func renderVersionReport(report *versionReport, w io.Writer) error {
+	out, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "%s\n", out)
+	return nil
+}
@@ -1,11 +1,15 @@
 package cmd
 
 import (
+	"bytes"
 	"errors"
 	"testing"
 
 	"github.com/linkerd/linkerd2/controller/api/public"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestGetServerVersion(t *testing.T) {
@@ -37,3 +41,60 @@ func TestGetServerVersion(t *testing.T) {
 		}
 	})
 }
+
+func TestNewVersionReport(t *testing.T) {
+	pod := func(namespace, name, component, proxyVersion string) v1.Pod {
+		pod := v1.Pod{
+			ObjectMeta: metaV1.ObjectMeta{
+				Namespace:   namespace,
+				Name:        name,
+				Labels:      map[string]string{},
+				Annotations: map[string]string{},
+			},
+		}
+		if component != "" {
+			pod.Labels[k8s.ControllerComponentLabel] = component
+			pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{Name: component, Image: "gcr.io/linkerd-io/" + component + ":stable-2.1.0"})
+			pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, v1.ContainerStatus{
+				Name:    component,
+				ImageID: "docker-pullable://gcr.io/linkerd-io/" + component + "@sha256:" + component,
+			})
+		}
+		if proxyVersion != "" {
+			pod.Annotations[k8s.ProxyVersionAnnotation] = proxyVersion
+			pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{Name: k8s.ProxyContainerName, Image: "gcr.io/linkerd-io/proxy:" + proxyVersion})
+		}
+		return pod
+	}
+
+	pods := []v1.Pod{
+		pod("linkerd", "linkerd-controller-7d9d47f5c-s2s6k", "controller", "stable-2.1.0"),
+		pod("linkerd-cni", "linkerd-cni-x2vbl", "cni", ""),
+		pod("emojivoto", "web-6b7f9d5c4-jq2bk", "", "stable-2.1.0"),
+		pod("emojivoto", "voting-5d4b6c8d9-8f6xh", "", "stable-2.0.0"),
+		pod("emojivoto", "emoji-7c9d8b6f5-vk4lp", "", "stable-2.1.0"),
+		pod("kube-system", "kube-dns-788979dc8f-7xvhq", "", ""),
+	}
+
+	report := newVersionReport("stable-2.1.0", "stable-2.1.0", pods, "linkerd", "linkerd-cni")
+
+	var buf bytes.Buffer
+	if err := renderVersionReport(report, &buf); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	diffCompareFile(t, buf.String(), "version_output.golden")
+}
+
+func TestImageDigest(t *testing.T) {
+	testCases := map[string]string{
+		"docker-pullable://gcr.io/linkerd-io/proxy@sha256:abc": "sha256:abc",
+		"sha256:abc": "sha256:abc",
+		"":           "",
+	}
+
+	for imageID, expected := range testCases {
+		if digest := imageDigest(imageID); digest != expected {
+			t.Fatalf("Expected digest of [%s] to be [%s], got [%s]", imageID, expected, digest)
+		}
+	}
+}
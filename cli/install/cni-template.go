@@ -0,0 +1,153 @@
+package install
+
+// CNITemplate provides the base template for the `linkerd install-cni` command.
+const CNITemplate = `### Namespace ###
+kind: Namespace
+apiVersion: v1
+metadata:
+  name: {{.Namespace}}
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+
+### Service Account CNI Plugin ###
+---
+kind: ServiceAccount
+apiVersion: v1
+metadata:
+  name: linkerd-cni
+  namespace: {{.Namespace}}
+
+### CNI Plugin RBAC ###
+---
+kind: ClusterRole
+apiVersion: rbac.authorization.k8s.io/v1beta1
+metadata:
+  name: linkerd-cni
+rules:
+- apiGroups: [""]
+  resources: ["pods", "nodes", "namespaces"]
+  verbs: ["list", "get", "watch"]
+
+---
+kind: ClusterRoleBinding
+apiVersion: rbac.authorization.k8s.io/v1beta1
+metadata:
+  name: linkerd-cni
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: linkerd-cni
+subjects:
+- kind: ServiceAccount
+  name: linkerd-cni
+  namespace: {{.Namespace}}
+
+### CNI Plugin Config ###
+---
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-cni-config
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: cni
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+data:
+  dest_cni_net_dir: "{{.DestCNINetDir}}"
+  dest_cni_bin_dir: "{{.DestCNIBinDir}}"
+  # The CNI network configuration to install on each node. The special values
+  # in this config are automatically populated.
+  cni_network_config: |-
+    {
+      "name": "linkerd-cni",
+      "type": "linkerd-cni",
+      "log_level": "{{.LogLevel}}",
+      "policy": {
+          "type": "k8s",
+          "k8s_api_root": "https://__KUBERNETES_SERVICE_HOST__:__KUBERNETES_SERVICE_PORT__",
+          "k8s_auth_token": "__SERVICEACCOUNT_TOKEN__"
+      },
+      "kubernetes": {
+          "kubeconfig": "__KUBECONFIG_FILEPATH__"
+      },
+      "linkerd": {
+        "incoming-proxy-port": {{.InboundPort}},
+        "outgoing-proxy-port": {{.OutboundPort}},
+        "proxy-uid": {{.ProxyUID}},
+        "ports-to-redirect": [],
+        "inbound-ports-to-ignore": [{{.IgnoreInboundPorts}}],
+        "outbound-ports-to-ignore": [{{.IgnoreOutboundPorts}}],
+        "outbound-cidrs-to-ignore": [{{.IgnoreOutboundCIDRs}}],
+        "simulate": false
+      }
+    }
+
+### CNI Plugin DaemonSet ###
+---
+kind: DaemonSet
+apiVersion: extensions/v1beta1
+metadata:
+  name: linkerd-cni
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: cni
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+spec:
+  selector:
+    matchLabels:
+      {{.ControllerComponentLabel}}: cni
+  template:
+    metadata:
+      labels:
+        {{.ControllerComponentLabel}}: cni
+      annotations:
+        {{.CreatedByAnnotation}}: {{.CliVersion}}
+    spec:
+      nodeSelector:
+        beta.kubernetes.io/os: linux
+      hostNetwork: true
+      serviceAccountName: linkerd-cni
+      containers:
+      # This container installs the linkerd CNI binaries and CNI network config
+      # file on each node. The install-cni.sh script chains the linkerd-cni
+      # plugin after the host's CNI plugin.
+      - name: install-cni
+        image: {{.CNIPluginImage}}
+        imagePullPolicy: {{.ImagePullPolicy}}
+        env:
+        - name: DEST_CNI_NET_DIR
+          valueFrom:
+            configMapKeyRef:
+              name: linkerd-cni-config
+              key: dest_cni_net_dir
+        - name: DEST_CNI_BIN_DIR
+          valueFrom:
+            configMapKeyRef:
+              name: linkerd-cni-config
+              key: dest_cni_bin_dir
+        - name: CNI_NETWORK_CONFIG
+          valueFrom:
+            configMapKeyRef:
+              name: linkerd-cni-config
+              key: cni_network_config
+        - name: SLEEP
+          value: "true"
+        lifecycle:
+          preStop:
+            exec:
+              command: ["kill","-15","1"]
+        volumeMounts:
+        - mountPath: /host{{.DestCNIBinDir}}
+          name: cni-bin-dir
+        - mountPath: /host{{.DestCNINetDir}}
+          name: cni-net-dir
+      volumes:
+      - name: cni-bin-dir
+        hostPath:
+          path: {{.DestCNIBinDir}}
+      - name: cni-net-dir
+        hostPath:
+          path: {{.DestCNINetDir}}
+`
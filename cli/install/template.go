@@ -18,6 +18,8 @@ apiVersion: v1
 metadata:
   name: linkerd-controller
   namespace: {{.Namespace}}
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
 
 ### Controller RBAC ###
 ---
@@ -28,6 +30,8 @@ metadata:
   {{- if .SingleNamespace}}
   namespace: {{.Namespace}}
   {{- end}}
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
 rules:
 - apiGroups: ["extensions", "apps"]
   resources: ["deployments", "replicasets"]
@@ -35,6 +39,9 @@ rules:
 - apiGroups: [""]
   resources: ["pods", "endpoints", "services", "namespaces", "replicationcontrollers"]
   verbs: ["list", "get", "watch"]
+- apiGroups: ["batch"]
+  resources: ["jobs", "cronjobs"]
+  verbs: ["list", "get", "watch"]
 - apiGroups: ["linkerd.io"]
   resources: ["serviceprofiles"]
   verbs: ["list", "get", "watch"]
@@ -47,6 +54,8 @@ metadata:
   {{- if .SingleNamespace}}
   namespace: {{.Namespace}}
   {{- end}}
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
 roleRef:
   apiGroup: rbac.authorization.k8s.io
   kind: {{if not .SingleNamespace}}Cluster{{end}}Role
@@ -63,6 +72,8 @@ apiVersion: v1
 metadata:
   name: linkerd-prometheus
   namespace: {{.Namespace}}
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
 
 ### Prometheus RBAC ###
 ---
@@ -73,6 +84,8 @@ metadata:
   {{- if .SingleNamespace}}
   namespace: {{.Namespace}}
   {{- end}}
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
 rules:
 - apiGroups: [""]
   resources: ["pods"]
@@ -86,6 +99,8 @@ metadata:
   {{- if .SingleNamespace}}
   namespace: {{.Namespace}}
   {{- end}}
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
 roleRef:
   apiGroup: rbac.authorization.k8s.io
   kind: {{if not .SingleNamespace}}Cluster{{end}}Role
@@ -267,7 +282,58 @@ spec:
     shortNames:
     - sp
 
+### Mesh Config CRD ###
+---
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: meshconfigs.linkerd.io
+  namespace: {{.Namespace}}
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+spec:
+  group: linkerd.io
+  version: v1alpha1
+  scope: Namespaced
+  names:
+    plural: meshconfigs
+    singular: meshconfig
+    kind: MeshConfig
+
+### Audit Event CRD ###
+---
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: auditevents.linkerd.io
+  namespace: {{.Namespace}}
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+spec:
+  group: linkerd.io
+  version: v1alpha1
+  scope: Namespaced
+  names:
+    plural: auditevents
+    singular: auditevent
+    kind: AuditEvent
+
 ### Web ###
+{{- if .TapAccessPolicy }}
+---
+kind: Secret
+apiVersion: v1
+metadata:
+  name: linkerd-web-tap-access-policy
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: web
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+type: Opaque
+data:
+  policy.yml: {{.TapAccessPolicy}}
+{{- end }}
 ---
 kind: Service
 apiVersion: v1
@@ -323,6 +389,13 @@ spec:
         - "-uuid={{.UUID}}"
         - "-controller-namespace={{.Namespace}}"
         - "-log-level={{.ControllerLogLevel}}"
+        {{- if .TapAccessPolicy }}
+        - "-tap-access-policy=/var/run/linkerd/tap-access-policy/policy.yml"
+        volumeMounts:
+        - name: tap-access-policy
+          mountPath: /var/run/linkerd/tap-access-policy
+          readOnly: true
+        {{- end }}
         livenessProbe:
           httpGet:
             path: /ping
@@ -339,6 +412,12 @@ spec:
             cpu: 20m
             memory: 50Mi
         {{- end }}
+      {{- if .TapAccessPolicy }}
+      volumes:
+      - name: tap-access-policy
+        secret:
+          secretName: linkerd-web-tap-access-policy
+      {{- end }}
 
 ### Prometheus ###
 ---
@@ -434,6 +513,9 @@ data:
       scrape_timeout: 10s
       evaluation_interval: 10s
 
+    rule_files:
+    - /etc/prometheus/alerting-rules.yml
+
     scrape_configs:
     - job_name: 'prometheus'
       static_configs:
@@ -507,6 +589,40 @@ data:
       - action: labelmap
         regex: __meta_kubernetes_pod_label_linkerd_io_(.+)
 
+  # the alerts of these rules are listed by "linkerd alerts status"
+  alerting-rules.yml: |-
+    groups:
+    - name: linkerd
+      rules:
+      - alert: LinkerdRouteSuccessRateLow
+        expr: |
+          sum(rate(route_response_total{direction="inbound", classification="success"}[5m])) by (namespace, dst, rt_route)
+            / sum(rate(route_response_total{direction="inbound"}[5m])) by (namespace, dst, rt_route)
+            < 0.9
+        for: 5m
+        labels:
+          severity: warning
+        annotations:
+          summary: The success rate of a route has been below 90% for 5 minutes
+      - alert: LinkerdProxyRestarted
+        expr: changes(process_start_time_seconds{job="linkerd-proxy"}[15m]) > 0
+        labels:
+          severity: warning
+        annotations:
+          summary: A proxy restarted in the last 15 minutes
+      - alert: LinkerdTrustAnchorExpiringSoon
+        expr: ca_trust_anchor_expiration_timestamp_seconds - time() < 30 * 24 * 3600
+        labels:
+          severity: critical
+        annotations:
+          summary: The certificate of the trust anchor expires within 30 days
+      - alert: LinkerdCertificateExpiringSoon
+        expr: ca_certificate_expiration_timestamp_seconds - time() < 7 * 24 * 3600
+        labels:
+          severity: critical
+        annotations:
+          summary: A certificate issued by the CA expires within 7 days
+
 ### Grafana ###
 ---
 kind: Service
@@ -649,6 +765,8 @@ apiVersion: v1
 metadata:
   name: linkerd-ca
   namespace: {{.Namespace}}
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
 
 ### CA RBAC ###
 ---
@@ -659,6 +777,8 @@ metadata:
   {{- if .SingleNamespace}}
   namespace: {{.Namespace}}
   {{- end}}
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
 rules:
 - apiGroups: [""]
   resources: ["configmaps"]
@@ -690,6 +810,8 @@ metadata:
   {{- if .SingleNamespace}}
   namespace: {{.Namespace}}
   {{- end}}
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
 roleRef:
   apiGroup: rbac.authorization.k8s.io
   kind: {{if not .SingleNamespace}}Cluster{{end}}Role
@@ -786,6 +908,9 @@ spec:
         - "proxy-injector"
         - "-controller-namespace={{.Namespace}}"
         - "-log-level={{.ControllerLogLevel}}"
+        {{- if .NoInitContainer }}
+        - "-no-init-container"
+        {{- end }}
         ports:
         - name: proxy-injector
           containerPort: 443
@@ -830,6 +955,8 @@ apiVersion: v1
 metadata:
   name: linkerd-proxy-injector
   namespace: {{.Namespace}}
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
 
 ---
 ### Proxy Injector RBAC ###
@@ -837,16 +964,26 @@ kind: ClusterRole
 apiVersion: rbac.authorization.k8s.io/v1
 metadata:
   name: linkerd-{{.Namespace}}-proxy-injector
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
 rules:
 - apiGroups: ["admissionregistration.k8s.io"]
   resources: ["mutatingwebhookconfigurations"]
   verbs: ["create", "update", "get", "watch"]
+- apiGroups: [""]
+  resources: ["namespaces"]
+  verbs: ["get"]
+- apiGroups: ["linkerd.io"]
+  resources: ["meshconfigs"]
+  verbs: ["list", "get", "watch"]
 
 ---
 kind: ClusterRoleBinding
 apiVersion: rbac.authorization.k8s.io/v1
 metadata:
   name: linkerd-{{.Namespace}}-proxy-injector
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
 subjects:
 - kind: ServiceAccount
   name: linkerd-proxy-injector
@@ -905,6 +1042,10 @@ data:
     - --outbound-ports-to-ignore
     - {{.IgnoreOutboundPorts}}
     {{- end}}
+    {{- if ne (len .IgnoreOutboundCIDRs) 0}}
+    - --outbound-cidrs-to-ignore
+    - {{.IgnoreOutboundCIDRs}}
+    {{- end}}
     image: {{.ProxyInitImage}}
     imagePullPolicy: IfNotPresent
     name: linkerd-init
@@ -932,6 +1073,10 @@ data:
       value: tcp://0.0.0.0:{{.InboundPort}}
     - name: LINKERD2_PROXY_DESTINATION_PROFILE_SUFFIXES
       value: {{.ProfileSuffixes}}
+    {{- if .OpaquePorts }}
+    - name: LINKERD2_PROXY_INBOUND_PORTS_DISABLE_PROTOCOL_DETECTION
+      value: "{{.OpaquePorts}}"
+    {{- end }}
     - name: LINKERD2_PROXY_POD_NAMESPACE
       valueFrom:
         fieldRef:
@@ -966,8 +1111,9 @@ data:
         path: /metrics
         port: {{.ProxyMetricsPort}}
       initialDelaySeconds: 10
-    {{- if or .ProxyResourceRequestCPU .ProxyResourceRequestMemory }}
+    {{- if or .ProxyResourceRequestCPU .ProxyResourceRequestMemory .ProxyResourceLimitCPU .ProxyResourceLimitMemory }}
     resources:
+      {{- if or .ProxyResourceRequestCPU .ProxyResourceRequestMemory }}
       requests:
         {{- if .ProxyResourceRequestCPU }}
         cpu: {{.ProxyResourceRequestCPU}}
@@ -975,6 +1121,16 @@ data:
         {{- if .ProxyResourceRequestMemory}}
         memory: {{.ProxyResourceRequestMemory}}
         {{- end }}
+      {{- end }}
+      {{- if or .ProxyResourceLimitCPU .ProxyResourceLimitMemory }}
+      limits:
+        {{- if .ProxyResourceLimitCPU }}
+        cpu: {{.ProxyResourceLimitCPU}}
+        {{- end }}
+        {{- if .ProxyResourceLimitMemory}}
+        memory: {{.ProxyResourceLimitMemory}}
+        {{- end }}
+      {{- end }}
     {{- end }}
     securityContext:
       runAsUser: {{.ProxyUID}}
@@ -997,3 +1153,329 @@ data:
       secretName: "" # this value will be computed by the webhook
       optional: true
 `
+
+// AlertsTemplate provides the alerting controller rendered by
+// `linkerd install --alerts`.
+const AlertsTemplate = `
+### Alerts Service Account ###
+---
+kind: ServiceAccount
+apiVersion: v1
+metadata:
+  name: linkerd-alerts
+  namespace: {{.Namespace}}
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+
+### Alerts RBAC ###
+---
+kind: ClusterRole
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: linkerd-{{.Namespace}}-alerts
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+rules:
+- apiGroups: [""]
+  resources: ["events"]
+  verbs: ["create"]
+
+---
+kind: ClusterRoleBinding
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: linkerd-{{.Namespace}}-alerts
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+subjects:
+- kind: ServiceAccount
+  name: linkerd-alerts
+  namespace: {{.Namespace}}
+  apiGroup: ""
+roleRef:
+  kind: ClusterRole
+  name: linkerd-{{.Namespace}}-alerts
+  apiGroup: rbac.authorization.k8s.io
+
+### Alerts Config ###
+# Edit the rules and where their alerts are sent, then restart the
+# linkerd-alerts deployment to apply them.
+---
+kind: ConfigMap
+apiVersion: v1
+metadata:
+  name: linkerd-alerts-config
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: alerts
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+data:
+  config.yml: |-
+    events: true
+    rules: []
+
+### Alerts ###
+---
+kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: linkerd-alerts
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: alerts
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      {{.ControllerComponentLabel}}: alerts
+  template:
+    metadata:
+      labels:
+        {{.ControllerComponentLabel}}: alerts
+      annotations:
+        {{.CreatedByAnnotation}}: {{.CliVersion}}
+    spec:
+      serviceAccount: linkerd-alerts
+      containers:
+      - name: alerts
+        ports:
+        - name: admin-http
+          containerPort: 9993
+        image: {{.ControllerImage}}
+        imagePullPolicy: {{.ImagePullPolicy}}
+        args:
+        - "alerts"
+        - "-api-addr=linkerd-controller-api.{{.Namespace}}.svc.cluster.local:8085"
+        - "-controller-namespace={{.Namespace}}"
+        - "-config=/var/run/linkerd/alerts/config.yml"
+        - "-log-level={{.ControllerLogLevel}}"
+        volumeMounts:
+        - name: alerts-config
+          mountPath: /var/run/linkerd/alerts
+          readOnly: true
+        livenessProbe:
+          httpGet:
+            path: /ping
+            port: 9993
+          initialDelaySeconds: 10
+        readinessProbe:
+          httpGet:
+            path: /ready
+            port: 9993
+          failureThreshold: 7
+        {{- if .EnableHA }}
+        resources:
+          requests:
+            cpu: 20m
+            memory: 50Mi
+        {{- end }}
+      volumes:
+      - name: alerts-config
+        configMap:
+          name: linkerd-alerts-config
+`
+
+// ProfileOperatorTemplate provides the profile-operator rendered by
+// `linkerd install --profile-operator`.
+const ProfileOperatorTemplate = `
+### Profile Operator Service Account ###
+---
+kind: ServiceAccount
+apiVersion: v1
+metadata:
+  name: linkerd-profile-operator
+  namespace: {{.Namespace}}
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+
+### Profile Operator RBAC ###
+---
+kind: ClusterRole
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: linkerd-{{.Namespace}}-profile-operator
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+rules:
+- apiGroups: ["extensions", "apps"]
+  resources: ["replicasets"]
+  verbs: ["list", "get", "watch"]
+- apiGroups: [""]
+  resources: ["pods", "services"]
+  verbs: ["list", "get", "watch"]
+- apiGroups: ["linkerd.io"]
+  resources: ["serviceprofiles"]
+  verbs: ["list", "get", "watch"]
+
+---
+kind: ClusterRoleBinding
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: linkerd-{{.Namespace}}-profile-operator
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+subjects:
+- kind: ServiceAccount
+  name: linkerd-profile-operator
+  namespace: {{.Namespace}}
+  apiGroup: ""
+roleRef:
+  kind: ClusterRole
+  name: linkerd-{{.Namespace}}-profile-operator
+  apiGroup: rbac.authorization.k8s.io
+
+# The generated service profiles are only written in the control plane
+# namespace.
+---
+kind: Role
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: linkerd-profile-operator
+  namespace: {{.Namespace}}
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+rules:
+- apiGroups: ["linkerd.io"]
+  resources: ["serviceprofiles"]
+  verbs: ["create", "update"]
+
+---
+kind: RoleBinding
+apiVersion: rbac.authorization.k8s.io/v1
+metadata:
+  name: linkerd-profile-operator
+  namespace: {{.Namespace}}
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+subjects:
+- kind: ServiceAccount
+  name: linkerd-profile-operator
+  namespace: {{.Namespace}}
+  apiGroup: ""
+roleRef:
+  kind: Role
+  name: linkerd-profile-operator
+  apiGroup: rbac.authorization.k8s.io
+
+### Profile Operator ###
+---
+kind: Deployment
+apiVersion: apps/v1
+metadata:
+  name: linkerd-profile-operator
+  namespace: {{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: profile-operator
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      {{.ControllerComponentLabel}}: profile-operator
+  template:
+    metadata:
+      labels:
+        {{.ControllerComponentLabel}}: profile-operator
+      annotations:
+        {{.CreatedByAnnotation}}: {{.CliVersion}}
+    spec:
+      serviceAccount: linkerd-profile-operator
+      containers:
+      - name: profile-operator
+        ports:
+        - name: admin-http
+          containerPort: 9992
+        image: {{.ControllerImage}}
+        imagePullPolicy: {{.ImagePullPolicy}}
+        args:
+        - "profile-operator"
+        - "-api-addr=linkerd-controller-api.{{.Namespace}}.svc.cluster.local:8085"
+        - "-controller-namespace={{.Namespace}}"
+        - "-log-level={{.ControllerLogLevel}}"
+        livenessProbe:
+          httpGet:
+            path: /ping
+            port: 9992
+          initialDelaySeconds: 10
+        readinessProbe:
+          httpGet:
+            path: /ready
+            port: 9992
+          failureThreshold: 7
+        {{- if .EnableHA }}
+        resources:
+          requests:
+            cpu: 20m
+            memory: 50Mi
+        {{- end }}
+`
+
+// OpenShiftTemplate provides the SecurityContextConstraints rendered by
+// `linkerd install --openshift`.
+const OpenShiftTemplate = `
+### OpenShift SecurityContextConstraints ###
+---
+kind: SecurityContextConstraints
+apiVersion: security.openshift.io/v1
+metadata:
+  name: linkerd-{{.Namespace}}
+  labels:
+    {{.ControllerComponentLabel}}: scc
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+# The proxy runs under a fixed UID, excluded from the iptables redirect rules,
+# which usually lies outside of the UID range assigned to the namespace.
+runAsUser:
+  type: RunAsAny
+seLinuxContext:
+  type: MustRunAs
+fsGroup:
+  type: RunAsAny
+supplementalGroups:
+  type: RunAsAny
+{{- if .NoInitContainer }}
+allowPrivilegedContainer: false
+allowedCapabilities: []
+{{- else }}
+# proxy-init sets up the iptables redirect rules.
+allowPrivilegedContainer: true
+allowedCapabilities:
+- NET_ADMIN
+{{- end }}
+allowHostDirVolumePlugin: false
+allowHostIPC: false
+allowHostNetwork: false
+allowHostPID: false
+allowHostPorts: false
+readOnlyRootFilesystem: false
+volumes:
+- configMap
+- downwardAPI
+- emptyDir
+- persistentVolumeClaim
+- projected
+- secret
+groups:
+- system:serviceaccounts:{{.Namespace}}
+
+### OpenShift SCC RBAC ###
+# Bind this role to the service accounts of the meshed namespaces, e.g.
+#   oc create rolebinding linkerd-scc -n emojivoto \
+#     --clusterrole linkerd-{{.Namespace}}-scc --group system:serviceaccounts:emojivoto
+---
+kind: ClusterRole
+apiVersion: rbac.authorization.k8s.io/v1beta1
+metadata:
+  name: linkerd-{{.Namespace}}-scc
+  annotations:
+    {{.CreatedByAnnotation}}: {{.CliVersion}}
+rules:
+- apiGroups: ["security.openshift.io"]
+  resources: ["securitycontextconstraints"]
+  resourceNames: ["linkerd-{{.Namespace}}"]
+  verbs: ["use"]
+`
@@ -0,0 +1,103 @@
+#!/bin/bash
+
+# Installs the linkerd-cni plugin on the node, and chains it after the host's
+# CNI plugin in its network configuration. The plugin is removed when the
+# container is stopped.
+#
+# Environment:
+#   DEST_CNI_NET_DIR    directory of the CNI network configuration on the host
+#   DEST_CNI_BIN_DIR    directory of the CNI plugin binaries on the host
+#   CNI_NETWORK_CONFIG  network configuration of the linkerd-cni plugin
+#   SLEEP               keep running after the install, to remove the plugin
+#                       when stopped (default: true)
+
+set -eu
+
+host_cni_net=/host${DEST_CNI_NET_DIR}
+host_cni_bin=/host${DEST_CNI_BIN_DIR}
+sa_dir=/var/run/secrets/kubernetes.io/serviceaccount
+kubeconfig_name=ZZZ-linkerd-cni-kubeconfig
+
+# cni_conf returns the network configuration file of the host's CNI plugin,
+# which is the first one in lexicographic order for the kubelet.
+cni_conf() {
+    find "$host_cni_net" -maxdepth 1 -type f \( -name '*.conf' -o -name '*.conflist' \) | sort | head -n 1
+}
+
+# remove_plugin removes the linkerd-cni plugin from the plugin list of $1.
+remove_plugin() {
+    tmp=$(mktemp)
+    jq '.plugins = [.plugins[] | select(.type != "linkerd-cni")]' "$1" > "$tmp"
+    mv "$tmp" "$1"
+}
+
+conf=$(cni_conf)
+if [ -z "$conf" ]; then
+    echo "No CNI network configuration found in $DEST_CNI_NET_DIR" >&2
+    exit 1
+fi
+
+cp /opt/cni/bin/linkerd-cni "$host_cni_bin/linkerd-cni"
+
+# the plugin reads the pod specs with the service account of the DaemonSet
+token=$(cat $sa_dir/token)
+cat > "$host_cni_net/$kubeconfig_name" <<EOK
+apiVersion: v1
+kind: Config
+clusters:
+- name: local
+  cluster:
+    server: https://${KUBERNETES_SERVICE_HOST}:${KUBERNETES_SERVICE_PORT}
+    certificate-authority-data: $(base64 < $sa_dir/ca.crt | tr -d '\n')
+users:
+- name: linkerd-cni
+  user:
+    token: ${token}
+contexts:
+- name: linkerd-cni
+  context:
+    cluster: local
+    user: linkerd-cni
+current-context: linkerd-cni
+EOK
+chmod 600 "$host_cni_net/$kubeconfig_name"
+
+plugin=$(mktemp)
+echo "$CNI_NETWORK_CONFIG" | sed \
+    -e "s|__KUBERNETES_SERVICE_HOST__|${KUBERNETES_SERVICE_HOST}|g" \
+    -e "s|__KUBERNETES_SERVICE_PORT__|${KUBERNETES_SERVICE_PORT}|g" \
+    -e "s|__SERVICEACCOUNT_TOKEN__|${token}|g" \
+    -e "s|__KUBECONFIG_FILEPATH__|${DEST_CNI_NET_DIR}/${kubeconfig_name}|g" > "$plugin"
+
+# a single plugin configuration is turned into a plugin list, to chain
+# linkerd-cni after it
+case "$conf" in
+*.conf)
+    jq '{name: .name, cniVersion: .cniVersion, plugins: [.]}' "$conf" > "${conf}list"
+    rm "$conf"
+    conf=${conf}list
+    ;;
+esac
+
+# the plugin of a previous install is replaced
+remove_plugin "$conf"
+tmp=$(mktemp)
+jq -s '.[1] as $plugin | .[0] | .plugins += [$plugin]' "$conf" "$plugin" > "$tmp"
+mv "$tmp" "$conf"
+rm "$plugin"
+echo "Installed linkerd-cni in $conf"
+
+cleanup() {
+    echo "Removing linkerd-cni from $conf"
+    remove_plugin "$conf"
+    rm -f "$host_cni_net/$kubeconfig_name" "$host_cni_bin/linkerd-cni"
+    exit 0
+}
+
+if [ "${SLEEP:-true}" = "true" ]; then
+    trap cleanup TERM INT
+    while true; do
+        sleep 3600 &
+        wait $!
+    done
+fi
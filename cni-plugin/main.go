@@ -0,0 +1,182 @@
+// linkerd-cni is a chained CNI plugin that sets up the iptables rules redirecting
+// the traffic of meshed pods to their proxy, in place of the proxy-init
+// container. It's installed on every node by the linkerd-cni DaemonSet, which
+// runs install-cni.sh.
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/proxy-init/iptables"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/api/core/v1"
+)
+
+// cniVersion is the version of the CNI specification the plugin implements.
+const cniVersion = "0.3.1"
+
+var supportedVersions = []string{"0.1.0", "0.2.0", "0.3.0", "0.3.1"}
+
+// linkerdConfig holds the redirect settings of the plugin, which mirror the
+// proxy-init flags.
+type linkerdConfig struct {
+	IncomingProxyPort     int      `json:"incoming-proxy-port"`
+	OutgoingProxyPort     int      `json:"outgoing-proxy-port"`
+	ProxyUID              int      `json:"proxy-uid"`
+	PortsToRedirect       []int    `json:"ports-to-redirect"`
+	InboundPortsToIgnore  []int    `json:"inbound-ports-to-ignore"`
+	OutboundPortsToIgnore []int    `json:"outbound-ports-to-ignore"`
+	OutboundCIDRsToIgnore []string `json:"outbound-cidrs-to-ignore"`
+	Simulate              bool     `json:"simulate"`
+}
+
+// pluginConfig is the network configuration the plugin is invoked with. See
+// the cni_network_config entry of the linkerd-cni-config ConfigMap.
+type pluginConfig struct {
+	CNIVersion string          `json:"cniVersion"`
+	Name       string          `json:"name"`
+	Type       string          `json:"type"`
+	LogLevel   string          `json:"log_level"`
+	PrevResult json.RawMessage `json:"prevResult,omitempty"`
+	Kubernetes struct {
+		Kubeconfig string `json:"kubeconfig"`
+	} `json:"kubernetes"`
+	Linkerd linkerdConfig `json:"linkerd"`
+}
+
+// cniError is the error result of the CNI specification.
+type cniError struct {
+	CNIVersion string `json:"cniVersion"`
+	Code       int    `json:"code"`
+	Msg        string `json:"msg"`
+}
+
+func main() {
+	if err := run(os.Getenv("CNI_COMMAND"), os.Stdin, os.Stdout); err != nil {
+		json.NewEncoder(os.Stdout).Encode(cniError{CNIVersion: cniVersion, Code: 100, Msg: err.Error()})
+		os.Exit(1)
+	}
+}
+
+func run(command string, stdin io.Reader, stdout io.Writer) error {
+	switch command {
+	case "VERSION":
+		return json.NewEncoder(stdout).Encode(map[string]interface{}{
+			"cniVersion":        cniVersion,
+			"supportedVersions": supportedVersions,
+		})
+	case "DEL", "CHECK":
+		// the iptables rules are removed along with the network namespace
+		return nil
+	case "ADD":
+	default:
+		return fmt.Errorf("unknown CNI_COMMAND: %s", command)
+	}
+
+	var conf pluginConfig
+	if err := json.NewDecoder(stdin).Decode(&conf); err != nil {
+		return fmt.Errorf("failed to parse the network configuration: %s", err)
+	}
+	if level, err := log.ParseLevel(conf.LogLevel); err == nil {
+		log.SetLevel(level)
+	}
+
+	if err := add(&conf, parseArgs(os.Getenv("CNI_ARGS")), os.Getenv("CNI_NETNS")); err != nil {
+		return err
+	}
+	return writeResult(stdout, &conf)
+}
+
+// add sets up the iptables rules of the pod, if it's meshed and doesn't run
+// proxy-init.
+func add(conf *pluginConfig, args map[string]string, netns string) error {
+	namespace, name := args["K8S_POD_NAMESPACE"], args["K8S_POD_NAME"]
+	if namespace == "" || name == "" {
+		log.Debugf("skipping a container outside of a Kubernetes pod")
+		return nil
+	}
+
+	kubeAPI, err := k8s.NewAPI(conf.Kubernetes.Kubeconfig, "", "", nil)
+	if err != nil {
+		return err
+	}
+	client, err := kubeAPI.NewClient()
+	if err != nil {
+		return err
+	}
+	var pod v1.Pod
+	found, err := kubeAPI.GetObject(client, fmt.Sprintf("/api/v1/namespaces/%s/pods/%s", namespace, name), &pod)
+	if err != nil {
+		return fmt.Errorf("failed to get pod %s/%s: %s", namespace, name, err)
+	}
+	if !found || !redirected(&pod) {
+		log.Debugf("skipping pod %s/%s", namespace, name)
+		return nil
+	}
+
+	log.Infof("setting up the iptables rules of pod %s/%s", namespace, name)
+	return iptables.ConfigureFirewall(firewallConfiguration(&conf.Linkerd, netns))
+}
+
+// redirected returns true if the traffic of the pod must be redirected by the
+// plugin, i.e. if it has a proxy and no proxy-init container.
+func redirected(pod *v1.Pod) bool {
+	for _, container := range pod.Spec.InitContainers {
+		if container.Name == k8s.InitContainerName {
+			return false
+		}
+	}
+	for _, container := range pod.Spec.Containers {
+		if container.Name == k8s.ProxyContainerName {
+			return true
+		}
+	}
+	return false
+}
+
+func firewallConfiguration(conf *linkerdConfig, netns string) iptables.FirewallConfiguration {
+	firewall := iptables.FirewallConfiguration{
+		Mode:                   iptables.RedirectAllMode,
+		PortsToRedirectInbound: conf.PortsToRedirect,
+		InboundPortsToIgnore:   conf.InboundPortsToIgnore,
+		OutboundPortsToIgnore:  conf.OutboundPortsToIgnore,
+		OutboundCIDRsToIgnore:  conf.OutboundCIDRsToIgnore,
+		ProxyInboundPort:       conf.IncomingProxyPort,
+		ProxyOutgoingPort:      conf.OutgoingProxyPort,
+		ProxyUid:               conf.ProxyUID,
+		SimulateOnly:           conf.Simulate,
+		NetNs:                  netns,
+	}
+	if len(conf.PortsToRedirect) > 0 {
+		firewall.Mode = iptables.RedirectListedMode
+	}
+	return firewall
+}
+
+// parseArgs parses the CNI_ARGS environment variable, e.g.
+// K8S_POD_NAMESPACE=emojivoto;K8S_POD_NAME=web-5f86686c4d-58p7k
+func parseArgs(cniArgs string) map[string]string {
+	args := map[string]string{}
+	for _, arg := range strings.Split(cniArgs, ";") {
+		kv := strings.SplitN(arg, "=", 2)
+		if len(kv) == 2 {
+			args[kv[0]] = kv[1]
+		}
+	}
+	return args
+}
+
+// writeResult passes the result of the previous plugin in the chain through,
+// as the plugin doesn't change the interfaces or addresses of the pod.
+func writeResult(w io.Writer, conf *pluginConfig) error {
+	if len(conf.PrevResult) == 0 {
+		return json.NewEncoder(w).Encode(map[string]string{"cniVersion": conf.CNIVersion})
+	}
+	_, err := fmt.Fprintln(w, string(conf.PrevResult))
+	return err
+}
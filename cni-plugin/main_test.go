@@ -0,0 +1,106 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/proxy-init/iptables"
+	"k8s.io/api/core/v1"
+)
+
+func TestRun(t *testing.T) {
+	t.Run("Reports the supported versions", func(t *testing.T) {
+		var out bytes.Buffer
+		if err := run("VERSION", strings.NewReader(""), &out); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		expected := `{"cniVersion":"0.3.1","supportedVersions":["0.1.0","0.2.0","0.3.0","0.3.1"]}` + "\n"
+		if out.String() != expected {
+			t.Fatalf("Expected %s, got %s", expected, out.String())
+		}
+	})
+
+	t.Run("Rejects unknown commands", func(t *testing.T) {
+		if err := run("UPDATE", strings.NewReader(""), &bytes.Buffer{}); err == nil {
+			t.Fatalf("Expected error, got nothing")
+		}
+	})
+}
+
+func TestWriteResult(t *testing.T) {
+	var out bytes.Buffer
+	conf := &pluginConfig{CNIVersion: "0.3.1", PrevResult: []byte(`{"cniVersion":"0.3.1","ips":[{"version":"4","address":"10.1.0.5/16"}]}`)}
+	if err := writeResult(&out, conf); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if out.String() != string(conf.PrevResult)+"\n" {
+		t.Fatalf("Expected the previous result to be passed through, got %s", out.String())
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	args := parseArgs("IgnoreUnknown=1;K8S_POD_NAMESPACE=emojivoto;K8S_POD_NAME=web-5f86686c4d-58p7k")
+	expected := map[string]string{
+		"IgnoreUnknown":     "1",
+		"K8S_POD_NAMESPACE": "emojivoto",
+		"K8S_POD_NAME":      "web-5f86686c4d-58p7k",
+	}
+	if !reflect.DeepEqual(args, expected) {
+		t.Fatalf("Expected %v, got %v", expected, args)
+	}
+}
+
+func TestRedirected(t *testing.T) {
+	pod := func(initContainers, containers []string) *v1.Pod {
+		pod := &v1.Pod{}
+		for _, name := range initContainers {
+			pod.Spec.InitContainers = append(pod.Spec.InitContainers, v1.Container{Name: name})
+		}
+		for _, name := range containers {
+			pod.Spec.Containers = append(pod.Spec.Containers, v1.Container{Name: name})
+		}
+		return pod
+	}
+
+	testCases := []struct {
+		description string
+		pod         *v1.Pod
+		expected    bool
+	}{
+		{"unmeshed pod", pod(nil, []string{"web"}), false},
+		{"meshed pod", pod(nil, []string{"web", k8s.ProxyContainerName}), true},
+		{"meshed pod with proxy-init", pod([]string{k8s.InitContainerName}, []string{"web", k8s.ProxyContainerName}), false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			if actual := redirected(tc.pod); actual != tc.expected {
+				t.Fatalf("Expected %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestFirewallConfiguration(t *testing.T) {
+	conf := &linkerdConfig{
+		IncomingProxyPort:    4143,
+		OutgoingProxyPort:    4140,
+		ProxyUID:             2102,
+		InboundPortsToIgnore: []int{4190, 4191},
+	}
+
+	firewall := firewallConfiguration(conf, "/proc/42/ns/net")
+	if firewall.Mode != iptables.RedirectAllMode {
+		t.Fatalf("Expected mode %s, got %s", iptables.RedirectAllMode, firewall.Mode)
+	}
+	if firewall.NetNs != "/proc/42/ns/net" || firewall.ProxyInboundPort != 4143 || firewall.ProxyOutgoingPort != 4140 || firewall.ProxyUid != 2102 {
+		t.Fatalf("Unexpected firewall configuration: %+v", firewall)
+	}
+
+	conf.PortsToRedirect = []int{8080}
+	if firewall := firewallConfiguration(conf, ""); firewall.Mode != iptables.RedirectListedMode {
+		t.Fatalf("Expected mode %s, got %s", iptables.RedirectListedMode, firewall.Mode)
+	}
+}
@@ -0,0 +1,227 @@
+package alerts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	log "github.com/sirupsen/logrus"
+)
+
+// Alert is a workload, or a route of a workload, crossing the thresholds of a
+// rule, or no longer crossing them once Resolved.
+type Alert struct {
+	Rule      string     `json:"rule"`
+	Namespace string     `json:"namespace"`
+	Type      string     `json:"type"`
+	Name      string     `json:"name"`
+	Route     string     `json:"route,omitempty"`
+	Message   string     `json:"message"`
+	Resolved  bool       `json:"resolved"`
+	StartsAt  time.Time  `json:"startsAt"`
+	EndsAt    *time.Time `json:"endsAt,omitempty"`
+}
+
+func (a *Alert) key() string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s", a.Rule, a.Namespace, a.Type, a.Name, a.Route)
+}
+
+// Sink is a destination of alerts.
+type Sink interface {
+	Send(alerts []*Alert) error
+}
+
+// Evaluator periodically evaluates the rules of a config against the metrics
+// of the public API, and sends the alerts that start firing or get resolved
+// to its sinks.
+type Evaluator struct {
+	apiClient pb.ApiClient
+	rules     []Rule
+	sinks     []Sink
+	now       func() time.Time
+
+	// firing alerts by key
+	firing map[string]*Alert
+}
+
+// NewEvaluator returns an evaluator of the rules, sending the alerts to the
+// sinks.
+func NewEvaluator(apiClient pb.ApiClient, rules []Rule, sinks []Sink) *Evaluator {
+	return &Evaluator{
+		apiClient: apiClient,
+		rules:     rules,
+		sinks:     sinks,
+		now:       time.Now,
+		firing:    make(map[string]*Alert),
+	}
+}
+
+// Run evaluates the rules every interval until stop is closed.
+func (e *Evaluator) Run(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			e.evaluate(context.Background())
+		}
+	}
+}
+
+// evaluate evaluates all the rules once and sends the alerts whose state
+// changed. The alerts of a rule whose metrics cannot be read keep their
+// state.
+func (e *Evaluator) evaluate(ctx context.Context) {
+	now := e.now()
+	changed := make([]*Alert, 0)
+
+	for _, rule := range e.rules {
+		current, err := e.evaluateRule(ctx, rule)
+		if err != nil {
+			log.Errorf("failed to evaluate alerting rule %s: %s", rule.Name, err)
+			continue
+		}
+
+		seen := make(map[string]bool)
+		for _, alert := range current {
+			key := alert.key()
+			seen[key] = true
+			if _, ok := e.firing[key]; ok {
+				continue
+			}
+			alert.StartsAt = now
+			e.firing[key] = alert
+			changed = append(changed, alert)
+		}
+
+		for key, alert := range e.firing {
+			if alert.Rule != rule.Name || seen[key] {
+				continue
+			}
+			delete(e.firing, key)
+			alert.Resolved = true
+			alert.EndsAt = &now
+			changed = append(changed, alert)
+		}
+	}
+
+	if len(changed) == 0 {
+		return
+	}
+	sort.Slice(changed, func(i, j int) bool { return changed[i].key() < changed[j].key() })
+
+	for _, sink := range e.sinks {
+		if err := sink.Send(changed); err != nil {
+			log.Errorf("failed to send %d alerts: %s", len(changed), err)
+		}
+	}
+}
+
+// evaluateRule returns the alerts of the workloads, or routes, crossing the
+// thresholds of the rule.
+func (e *Evaluator) evaluateRule(ctx context.Context, rule Rule) ([]*Alert, error) {
+	resource, err := util.BuildResource(rule.Namespace, rule.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := make([]*Alert, 0)
+
+	if rule.Route == "" {
+		req, err := util.BuildStatSummaryRequest(util.StatsSummaryRequestParams{
+			StatsBaseRequestParams: util.StatsBaseRequestParams{
+				TimeWindow:   rule.TimeWindow,
+				Namespace:    resource.Namespace,
+				ResourceType: resource.Type,
+				ResourceName: resource.Name,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		rsp, err := e.apiClient.StatSummary(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if rsp.GetError() != nil {
+			return nil, errors.New(rsp.GetError().GetError())
+		}
+
+		for _, table := range rsp.GetOk().GetStatTables() {
+			for _, row := range table.GetPodGroup().GetRows() {
+				if msg := rule.check(row.GetStats()); msg != "" {
+					alerts = append(alerts, &Alert{
+						Rule:      rule.Name,
+						Namespace: row.GetResource().GetNamespace(),
+						Type:      row.GetResource().GetType(),
+						Name:      row.GetResource().GetName(),
+						Message:   msg,
+					})
+				}
+			}
+		}
+		return alerts, nil
+	}
+
+	req, err := util.BuildTopRoutesRequest(util.TopRoutesRequestParams{
+		StatsBaseRequestParams: util.StatsBaseRequestParams{
+			TimeWindow:   rule.TimeWindow,
+			Namespace:    resource.Namespace,
+			ResourceType: resource.Type,
+			ResourceName: resource.Name,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := e.apiClient.TopRoutes(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if rsp.GetError() != nil {
+		return nil, errors.New(rsp.GetError().GetError())
+	}
+
+	for _, row := range rsp.GetRoutes().GetRows() {
+		if rule.Route != "*" && row.GetRoute() != rule.Route {
+			continue
+		}
+		if msg := rule.check(row.GetStats()); msg != "" {
+			alerts = append(alerts, &Alert{
+				Rule:      rule.Name,
+				Namespace: resource.Namespace,
+				Type:      resource.Type,
+				Name:      resource.Name,
+				Route:     row.GetRoute(),
+				Message:   msg,
+			})
+		}
+	}
+	return alerts, nil
+}
+
+// check returns a description of the thresholds of the rule the stats cross,
+// or an empty string if they cross none.
+func (r *Rule) check(stats *pb.BasicStats) string {
+	total := stats.GetSuccessCount() + stats.GetFailureCount()
+	if total == 0 || total < r.MinRequests {
+		return ""
+	}
+
+	if r.MinSuccessRate != nil {
+		if sr := util.GetSuccessRate(stats); sr < *r.MinSuccessRate {
+			return fmt.Sprintf("success rate %.2f%% is below %.2f%%", sr*100, *r.MinSuccessRate*100)
+		}
+	}
+	if r.MaxLatencyP99Ms != 0 && stats.GetLatencyMsP99() > r.MaxLatencyP99Ms {
+		return fmt.Sprintf("p99 latency %dms is above %dms", stats.GetLatencyMsP99(), r.MaxLatencyP99Ms)
+	}
+	return ""
+}
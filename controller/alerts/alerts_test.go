@@ -0,0 +1,195 @@
+package alerts
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/public"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type recordingSink struct {
+	sent [][]*Alert
+}
+
+func (s *recordingSink) Send(alerts []*Alert) error {
+	s.sent = append(s.sent, alerts)
+	return nil
+}
+
+func statSummaryResponse(name string, stats *pb.BasicStats) *pb.StatSummaryResponse {
+	return &pb.StatSummaryResponse{
+		Response: &pb.StatSummaryResponse_Ok_{
+			Ok: &pb.StatSummaryResponse_Ok{
+				StatTables: []*pb.StatTable{
+					{
+						Table: &pb.StatTable_PodGroup_{
+							PodGroup: &pb.StatTable_PodGroup{
+								Rows: []*pb.StatTable_PodGroup_Row{
+									{
+										Resource: &pb.Resource{Namespace: "emojivoto", Type: k8s.Deployment, Name: name},
+										Stats:    stats,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestEvaluator(t *testing.T) {
+	minSuccessRate := 0.95
+	rules := []Rule{
+		{Name: "web-success", Namespace: "emojivoto", Resource: "deploy", TimeWindow: "1m", MinSuccessRate: &minSuccessRate, MinRequests: 10},
+	}
+	apiClient := &public.MockApiClient{}
+	sink := &recordingSink{}
+	evaluator := NewEvaluator(apiClient, rules, []Sink{sink})
+	evaluator.now = func() time.Time { return time.Unix(1000, 0) }
+
+	t.Run("Does not fire on scarce traffic", func(t *testing.T) {
+		apiClient.StatSummaryResponseToReturn = statSummaryResponse("web", &pb.BasicStats{SuccessCount: 1, FailureCount: 1})
+		evaluator.evaluate(context.Background())
+		if len(sink.sent) != 0 {
+			t.Fatalf("Expected no alerts, got %v", sink.sent)
+		}
+	})
+
+	t.Run("Fires once while the threshold is crossed", func(t *testing.T) {
+		apiClient.StatSummaryResponseToReturn = statSummaryResponse("web", &pb.BasicStats{SuccessCount: 90, FailureCount: 10})
+		evaluator.evaluate(context.Background())
+		evaluator.evaluate(context.Background())
+		if len(sink.sent) != 1 || len(sink.sent[0]) != 1 {
+			t.Fatalf("Expected a single alert, got %v", sink.sent)
+		}
+		alert := sink.sent[0][0]
+		expected := "web-success: success rate 90.00% is below 95.00% on deployment/web"
+		if alertSummary(alert) != expected || alert.Resolved {
+			t.Fatalf("Expected a firing alert [%s], got [%s]", expected, alertSummary(alert))
+		}
+	})
+
+	t.Run("Resolves once the threshold is no longer crossed", func(t *testing.T) {
+		apiClient.StatSummaryResponseToReturn = statSummaryResponse("web", &pb.BasicStats{SuccessCount: 100})
+		evaluator.evaluate(context.Background())
+		if len(sink.sent) != 2 || !sink.sent[1][0].Resolved {
+			t.Fatalf("Expected a resolved alert, got %v", sink.sent)
+		}
+	})
+}
+
+func TestRouteRules(t *testing.T) {
+	rule := Rule{Name: "books-latency", Namespace: "booksapp", Resource: "svc/books", Route: "*", TimeWindow: "1m", MaxLatencyP99Ms: 100}
+	apiClient := &public.MockApiClient{
+		TopRoutesResponseToReturn: &pb.TopRoutesResponse{
+			Response: &pb.TopRoutesResponse_Routes{
+				Routes: &pb.RouteTable{
+					Rows: []*pb.RouteTable_Row{
+						{Route: "GET /books", Stats: &pb.BasicStats{SuccessCount: 10, LatencyMsP99: 20}},
+						{Route: "POST /books", Stats: &pb.BasicStats{SuccessCount: 10, LatencyMsP99: 250}},
+					},
+				},
+			},
+		},
+	}
+
+	alerts, err := NewEvaluator(apiClient, nil, nil).evaluateRule(context.Background(), rule)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(alerts) != 1 || alerts[0].Route != "POST /books" || alerts[0].Message != "p99 latency 250ms is above 100ms" {
+		t.Fatalf("Expected an alert on POST /books, got %+v", alerts)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	minSuccessRate := 0.9
+	testCases := []struct {
+		config      Config
+		expectedErr string
+	}{
+		{
+			Config{Rules: []Rule{{Name: "a", Namespace: "ns", Resource: "deploy", MinSuccessRate: &minSuccessRate}}, Events: true},
+			"",
+		},
+		{
+			Config{Rules: []Rule{{Name: "a", Namespace: "ns", Resource: "deploy"}}, Events: true},
+			"alerting rule a has no threshold",
+		},
+		{
+			Config{Rules: []Rule{{Name: "a", Namespace: "ns", Resource: "svc", Route: "*", MaxLatencyP99Ms: 100}}, Events: true},
+			"alerting rule a on routes must name a single resource, such as svc/web",
+		},
+		{
+			Config{Rules: []Rule{{Name: "a", Namespace: "ns", Resource: "deploy", MaxLatencyP99Ms: 100}}},
+			"the alerting config sends alerts nowhere; enable events, webhooks or an Alertmanager",
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.config.validate()
+		if tc.expectedErr == "" && err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if tc.expectedErr != "" && (err == nil || err.Error() != tc.expectedErr) {
+			t.Fatalf("Expected error [%s], got [%v]", tc.expectedErr, err)
+		}
+	}
+}
+
+func TestSinks(t *testing.T) {
+	alert := &Alert{
+		Rule:      "web-success",
+		Namespace: "emojivoto",
+		Type:      k8s.Deployment,
+		Name:      "web",
+		Message:   "success rate 90.00% is below 95.00%",
+		StartsAt:  time.Unix(1000, 0),
+	}
+
+	t.Run("Creates Events on the resources", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+		if err := NewEventSink(client).Send([]*Alert{alert}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		events, err := client.CoreV1().Events("emojivoto").List(metav1.ListOptions{})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(events.Items) != 1 || events.Items[0].InvolvedObject.Kind != "Deployment" || events.Items[0].Reason != eventReasonFiring {
+			t.Fatalf("Unexpected events: %+v", events.Items)
+		}
+	})
+
+	t.Run("Posts the alerts to Alertmanager", func(t *testing.T) {
+		var received []alertmanagerAlert
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/api/v1/alerts" {
+				t.Fatalf("Unexpected path %s", r.URL.Path)
+			}
+			b, _ := ioutil.ReadAll(r.Body)
+			if err := json.Unmarshal(b, &received); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}))
+		defer server.Close()
+
+		if err := NewAlertmanagerSink(server.URL + "/").Send([]*Alert{alert}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(received) != 1 || received[0].Labels["alertname"] != "web-success" || received[0].Labels["deployment"] != "web" {
+			t.Fatalf("Unexpected alerts: %+v", received)
+		}
+	})
+}
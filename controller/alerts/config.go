@@ -0,0 +1,107 @@
+package alerts
+
+import (
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/linkerd/linkerd2/controller/api/util"
+)
+
+const defaultTimeWindow = "1m"
+
+// Rule is a threshold on the metrics of the workloads of a type, or of a
+// single workload, optionally restricted to routes. A rule fires for each
+// workload or route that crosses one of its thresholds.
+type Rule struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Resource is a resource type, such as deploy, or a single resource, such
+	// as deploy/web, as accepted by `linkerd stat`.
+	Resource string `json:"resource"`
+	// Route restricts the rule to a route of the ServiceProfile of the
+	// resource, or to all its routes with "*".
+	Route      string `json:"route,omitempty"`
+	TimeWindow string `json:"timeWindow,omitempty"`
+	// MinSuccessRate is the success rate, between 0 and 1, below which the
+	// rule fires.
+	MinSuccessRate *float64 `json:"minSuccessRate,omitempty"`
+	// MaxLatencyP99Ms is the p99 latency above which the rule fires.
+	MaxLatencyP99Ms uint64 `json:"maxLatencyP99Ms,omitempty"`
+	// MinRequests is the number of requests in the time window under which
+	// the rule is not evaluated, to not fire on scarce traffic.
+	MinRequests uint64 `json:"minRequests,omitempty"`
+}
+
+// Config lists the rules and where their alerts are sent.
+type Config struct {
+	Rules []Rule `json:"rules"`
+	// Events enables Kubernetes Events on the resources of the alerts.
+	Events bool `json:"events,omitempty"`
+	// Webhooks are URLs the alerts are posted to as JSON.
+	Webhooks []string `json:"webhooks,omitempty"`
+	// AlertmanagerURL is the base URL of an Alertmanager the alerts are
+	// posted to.
+	AlertmanagerURL string `json:"alertmanagerUrl,omitempty"`
+}
+
+// LoadConfig reads and validates the YAML alerting config at path.
+func LoadConfig(path string) (*Config, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var config Config
+	if err := yaml.Unmarshal(b, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse the alerting config %s: %s", path, err)
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+func (c *Config) validate() error {
+	names := make(map[string]bool)
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if rule.Name == "" {
+			return fmt.Errorf("alerting rule %d has no name", i)
+		}
+		if names[rule.Name] {
+			return fmt.Errorf("alerting rule %s is defined twice", rule.Name)
+		}
+		names[rule.Name] = true
+
+		if rule.Namespace == "" {
+			return fmt.Errorf("alerting rule %s has no namespace", rule.Name)
+		}
+		resource, err := util.BuildResource(rule.Namespace, rule.Resource)
+		if err != nil {
+			return fmt.Errorf("alerting rule %s has an invalid resource: %s", rule.Name, err)
+		}
+		if rule.Route != "" && resource.Name == "" {
+			return fmt.Errorf("alerting rule %s on routes must name a single resource, such as svc/web", rule.Name)
+		}
+		if rule.TimeWindow == "" {
+			rule.TimeWindow = defaultTimeWindow
+		}
+		if _, err := time.ParseDuration(rule.TimeWindow); err != nil {
+			return fmt.Errorf("alerting rule %s has an invalid time window: %s", rule.Name, err)
+		}
+		if rule.MinSuccessRate == nil && rule.MaxLatencyP99Ms == 0 {
+			return fmt.Errorf("alerting rule %s has no threshold", rule.Name)
+		}
+		if rule.MinSuccessRate != nil && (*rule.MinSuccessRate < 0 || *rule.MinSuccessRate > 1) {
+			return fmt.Errorf("alerting rule %s has a success rate threshold outside of [0, 1]", rule.Name)
+		}
+	}
+
+	if !c.Events && len(c.Webhooks) == 0 && c.AlertmanagerURL == "" {
+		return fmt.Errorf("the alerting config sends alerts nowhere; enable events, webhooks or an Alertmanager")
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package alerts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	eventSource = "linkerd-alerts"
+
+	eventReasonFiring   = "MeshAlertFiring"
+	eventReasonResolved = "MeshAlertResolved"
+)
+
+// the API version and kind of the resource types alerts are reported on as
+// Events
+var eventKinds = map[string][2]string{
+	k8s.CronJob:               {"batch/v1beta1", "CronJob"},
+	k8s.DaemonSet:             {"apps/v1", "DaemonSet"},
+	k8s.Deployment:            {"apps/v1", "Deployment"},
+	k8s.Job:                   {"batch/v1", "Job"},
+	k8s.Namespace:             {"v1", "Namespace"},
+	k8s.Pod:                   {"v1", "Pod"},
+	k8s.ReplicaSet:            {"apps/v1", "ReplicaSet"},
+	k8s.ReplicationController: {"v1", "ReplicationController"},
+	k8s.Service:               {"v1", "Service"},
+	k8s.StatefulSet:           {"apps/v1", "StatefulSet"},
+}
+
+// EventSink reports the alerts as Kubernetes Events on their resources.
+type EventSink struct {
+	client kubernetes.Interface
+}
+
+// NewEventSink returns a sink creating Events with the client.
+func NewEventSink(client kubernetes.Interface) *EventSink {
+	return &EventSink{client: client}
+}
+
+// Send creates an Event per alert. Alerts on resource types that cannot be
+// the object of an Event, such as authorities, are skipped.
+func (s *EventSink) Send(alerts []*Alert) error {
+	for _, alert := range alerts {
+		kind, ok := eventKinds[alert.Type]
+		if !ok {
+			continue
+		}
+
+		namespace := alert.Namespace
+		if alert.Type == k8s.Namespace {
+			namespace = alert.Name
+		}
+
+		reason := eventReasonFiring
+		eventType := v1.EventTypeWarning
+		ts := alert.StartsAt
+		if alert.Resolved {
+			reason = eventReasonResolved
+			eventType = v1.EventTypeNormal
+			ts = *alert.EndsAt
+		}
+
+		event := &v1.Event{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: alert.Name + ".",
+				Namespace:    namespace,
+			},
+			InvolvedObject: v1.ObjectReference{
+				APIVersion: kind[0],
+				Kind:       kind[1],
+				Namespace:  alert.Namespace,
+				Name:       alert.Name,
+			},
+			Reason:         reason,
+			Message:        alertSummary(alert),
+			Type:           eventType,
+			Source:         v1.EventSource{Component: eventSource},
+			FirstTimestamp: metav1.NewTime(ts),
+			LastTimestamp:  metav1.NewTime(ts),
+			Count:          1,
+		}
+		if _, err := s.client.CoreV1().Events(namespace).Create(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WebhookSink posts the alerts as a JSON list to a URL.
+type WebhookSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhookSink returns a sink posting to url.
+func NewWebhookSink(url string) *WebhookSink {
+	return &WebhookSink{url: url, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Send posts the alerts.
+func (s *WebhookSink) Send(alerts []*Alert) error {
+	return postJSON(s.client, s.url, alerts)
+}
+
+// AlertmanagerSink posts the alerts to the v1 API of an Alertmanager.
+type AlertmanagerSink struct {
+	url    string
+	client *http.Client
+}
+
+// NewAlertmanagerSink returns a sink posting to the Alertmanager at url.
+func NewAlertmanagerSink(url string) *AlertmanagerSink {
+	return &AlertmanagerSink{
+		url:    strings.TrimSuffix(url, "/") + "/api/v1/alerts",
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type alertmanagerAlert struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	StartsAt    time.Time         `json:"startsAt"`
+	EndsAt      *time.Time        `json:"endsAt,omitempty"`
+}
+
+// Send posts the alerts, with resolved alerts ending at their resolution.
+func (s *AlertmanagerSink) Send(alerts []*Alert) error {
+	amAlerts := make([]alertmanagerAlert, 0, len(alerts))
+	for _, alert := range alerts {
+		labels := map[string]string{
+			"alertname": alert.Rule,
+			"namespace": alert.Namespace,
+			alert.Type:  alert.Name,
+		}
+		if alert.Route != "" {
+			labels["rt_route"] = alert.Route
+		}
+		amAlerts = append(amAlerts, alertmanagerAlert{
+			Labels:      labels,
+			Annotations: map[string]string{"summary": alertSummary(alert)},
+			StartsAt:    alert.StartsAt,
+			EndsAt:      alert.EndsAt,
+		})
+	}
+	return postJSON(s.client, s.url, amAlerts)
+}
+
+func alertSummary(alert *Alert) string {
+	target := fmt.Sprintf("%s/%s", alert.Type, alert.Name)
+	if alert.Route != "" {
+		target = fmt.Sprintf("route %s of %s", alert.Route, target)
+	}
+	if alert.Resolved {
+		return fmt.Sprintf("%s: %s is within its thresholds again", alert.Rule, target)
+	}
+	return fmt.Sprintf("%s: %s on %s", alert.Rule, alert.Message, target)
+}
+
+func postJSON(client *http.Client, url string, body interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	rsp, err := client.Post(url, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response from %s: %s", url, rsp.Status)
+	}
+	return nil
+}
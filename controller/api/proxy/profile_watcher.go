@@ -7,6 +7,7 @@ import (
 	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
 	splisters "github.com/linkerd/linkerd2/controller/gen/client/listers/serviceprofile/v1alpha1"
 	"github.com/linkerd/linkerd2/controller/k8s"
+	"github.com/linkerd/linkerd2/pkg/profiles"
 	log "github.com/sirupsen/logrus"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/tools/cache"
@@ -64,13 +65,14 @@ func (p *profileWatcher) subscribeToProfile(name profileId, listener profileUpda
 
 	profileEntry, ok := p.profiles[name]
 	if !ok {
-		profile, err := p.getProfile(name)
+		profile, bases, err := p.getProfile(name)
 		if err != nil && !apierrors.IsNotFound(err) {
 			log.Errorf("Error getting profile: %s", err)
 			return err
 		}
 
 		profileEntry = newProfileEntry(profile)
+		profileEntry.bases = bases
 		p.profiles[name] = profileEntry
 	}
 	profileEntry.subscribe(listener)
@@ -96,8 +98,21 @@ func (p *profileWatcher) unsubscribeToProfile(profile profileId, listener profil
 	return nil
 }
 
-func (p *profileWatcher) getProfile(profile profileId) (*sp.ServiceProfile, error) {
-	return p.profileLister.ServiceProfiles(profile.namespace).Get(profile.name)
+// getProfile returns the profile merged with its base profiles, along with
+// the names of the base profiles. A profile with an invalid chain of base
+// profiles is returned with the routes of its valid bases.
+func (p *profileWatcher) getProfile(id profileId) (*sp.ServiceProfile, []string, error) {
+	lister := p.profileLister.ServiceProfiles(id.namespace)
+	profile, err := lister.Get(id.name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	merged, bases, err := profiles.MergeBaseProfiles(profile, lister.Get)
+	if err != nil {
+		log.Errorf("Error merging base profiles: %s", err)
+	}
+	return merged, bases, nil
 }
 
 func (p *profileWatcher) addProfile(obj interface{}) {
@@ -111,7 +126,35 @@ func (p *profileWatcher) addProfile(obj interface{}) {
 	defer p.profilesLock.RUnlock()
 	entry, ok := p.profiles[id]
 	if ok {
-		entry.update(profile)
+		merged, bases, err := p.getProfile(id)
+		if err != nil {
+			log.Errorf("Error getting profile: %s", err)
+			merged, bases = profile, nil
+		}
+		entry.setBases(bases)
+		entry.update(merged)
+	}
+	p.updateInheritingProfiles(id)
+}
+
+// updateInheritingProfiles merges again the profiles inheriting from the
+// changed profile, directly or through other base profiles. The caller must
+// hold the profiles lock.
+func (p *profileWatcher) updateInheritingProfiles(changed profileId) {
+	for id, entry := range p.profiles {
+		if id.namespace != changed.namespace || !entry.hasBase(changed.name) {
+			continue
+		}
+
+		merged, bases, err := p.getProfile(id)
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				log.Errorf("Error getting profile: %s", err)
+			}
+			continue
+		}
+		entry.setBases(bases)
+		entry.update(merged)
 	}
 }
 
@@ -130,12 +173,16 @@ func (p *profileWatcher) deleteProfile(obj interface{}) {
 	defer p.profilesLock.RUnlock()
 	entry, ok := p.profiles[id]
 	if ok {
+		entry.setBases(nil)
 		entry.update(&sp.ServiceProfile{})
 	}
+	p.updateInheritingProfiles(id)
 }
 
 type profileEntry struct {
-	profile   *sp.ServiceProfile
+	profile *sp.ServiceProfile
+	// the names of the base profiles the profile inherits from
+	bases     []string
 	listeners []profileUpdateListener
 	mutex     sync.Mutex
 }
@@ -174,6 +221,26 @@ func (e *profileEntry) unsubscribe(listener profileUpdateListener) (bool, int) {
 	return false, len(e.listeners)
 }
 
+func (e *profileEntry) setBases(bases []string) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.bases = bases
+}
+
+// hasBase returns true iff the profile inherits from the named profile.
+func (e *profileEntry) hasBase(name string) bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	for _, base := range e.bases {
+		if base == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (e *profileEntry) update(profile *sp.ServiceProfile) {
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
@@ -56,6 +56,65 @@ spec:
 				},
 			},
 		},
+		{
+			name: "service profile with a base profile",
+			k8sConfigs: []string{`
+apiVersion: linkerd.io/v1alpha1
+kind: ServiceProfile
+metadata:
+  name: foobar.ns.svc.cluster.local
+  namespace: linkerd
+spec:
+  baseProfile: base
+  routes:
+  - name: health
+    condition:
+      pathRegex: "/healthz"
+      method: GET
+  - name: x
+    condition:
+      pathRegex: "/x/y/z"`, `
+apiVersion: linkerd.io/v1alpha1
+kind: ServiceProfile
+metadata:
+  name: base
+  namespace: linkerd
+spec:
+  routes:
+  - name: health
+    condition:
+      pathRegex: "/health"
+  - name: metrics
+    condition:
+      pathRegex: "/metrics"`,
+			},
+			service: profileId{namespace: "linkerd", name: "foobar.ns.svc.cluster.local"},
+			expectedProfiles: []*sp.ServiceProfileSpec{
+				&sp.ServiceProfileSpec{
+					Routes: []*sp.RouteSpec{
+						&sp.RouteSpec{
+							Name: "health",
+							Condition: &sp.RequestMatch{
+								PathRegex: "/healthz",
+								Method:    "GET",
+							},
+						},
+						&sp.RouteSpec{
+							Name: "x",
+							Condition: &sp.RequestMatch{
+								PathRegex: "/x/y/z",
+							},
+						},
+						&sp.RouteSpec{
+							Name: "metrics",
+							Condition: &sp.RequestMatch{
+								PathRegex: "/metrics",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name:       "service without profile",
 			k8sConfigs: []string{},
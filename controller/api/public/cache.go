@@ -0,0 +1,32 @@
+package public
+
+import (
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/util"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var cacheRequests = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "api_cache_requests_total",
+		Help: "A counter for the metrics API requests served by the response cache, by API and hit or miss.",
+	},
+	[]string{"api", "result"},
+)
+
+func init() {
+	prometheus.MustRegister(cacheRequests)
+}
+
+// newResponseCache returns the cache of the responses of the metrics APIs,
+// counting its hits and misses, or nil if ttl isn't positive.
+func newResponseCache(ttl time.Duration) *util.ResponseCache {
+	return util.NewResponseCache(ttl, func(api string, hit bool) {
+		result := "miss"
+		if hit {
+			result = "hit"
+		}
+		cacheRequests.WithLabelValues(api, result).Inc()
+	})
+}
@@ -44,6 +44,24 @@ func (c *grpcOverHttpClient) TopRoutes(ctx context.Context, req *pb.TopRoutesReq
 	return &msg, err
 }
 
+func (c *grpcOverHttpClient) StreamStats(ctx context.Context, req *pb.StatSummaryRequest, _ ...grpc.CallOption) (*pb.StreamStatsResponse, error) {
+	var msg pb.StreamStatsResponse
+	err := c.apiRequest(ctx, "StreamStats", req, &msg)
+	return &msg, err
+}
+
+func (c *grpcOverHttpClient) RouteSLOs(ctx context.Context, req *pb.RouteSLOsRequest, _ ...grpc.CallOption) (*pb.RouteSLOsResponse, error) {
+	var msg pb.RouteSLOsResponse
+	err := c.apiRequest(ctx, "RouteSLOs", req, &msg)
+	return &msg, err
+}
+
+func (c *grpcOverHttpClient) RouteStatsHistory(ctx context.Context, req *pb.RouteStatsHistoryRequest, _ ...grpc.CallOption) (*pb.RouteStatsHistoryResponse, error) {
+	var msg pb.RouteStatsHistoryResponse
+	err := c.apiRequest(ctx, "RouteStatsHistory", req, &msg)
+	return &msg, err
+}
+
 func (c *grpcOverHttpClient) Version(ctx context.Context, req *pb.Empty, _ ...grpc.CallOption) (*pb.VersionInfo, error) {
 	var msg pb.VersionInfo
 	err := c.apiRequest(ctx, "Version", req, &msg)
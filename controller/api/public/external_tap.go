@@ -0,0 +1,205 @@
+package public
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/protobuf/proto"
+	tapPb "github.com/linkerd/linkerd2/controller/gen/controller/tap"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	log "github.com/sirupsen/logrus"
+)
+
+// TapConsumer is a system outside of the cluster, such as a SIEM, allowed to
+// tap the traffic of some namespaces. It authenticates either with a bearer
+// token, or with a client certificate with the given common name.
+type TapConsumer struct {
+	Name       string   `json:"name"`
+	Token      string   `json:"token,omitempty"`
+	CommonName string   `json:"commonName,omitempty"`
+	Namespaces []string `json:"namespaces"`
+	// MaxRps caps the rate of the requests the consumer's taps inspect; 0
+	// leaves the rate of its taps as requested.
+	MaxRps float32 `json:"maxRps,omitempty"`
+}
+
+// TapAccessPolicy lists the consumers of the external Tap API.
+type TapAccessPolicy struct {
+	Consumers []TapConsumer `json:"consumers"`
+}
+
+// LoadTapAccessPolicy reads and validates the YAML tap access policy at path.
+func LoadTapAccessPolicy(path string) (*TapAccessPolicy, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var policy TapAccessPolicy
+	if err := yaml.Unmarshal(b, &policy); err != nil {
+		return nil, fmt.Errorf("failed to parse tap access policy: %s", err)
+	}
+
+	for _, c := range policy.Consumers {
+		if c.Name == "" {
+			return nil, fmt.Errorf("tap consumers must have a name")
+		}
+		if (c.Token == "") == (c.CommonName == "") {
+			return nil, fmt.Errorf("tap consumer %s must have exactly one of a token or a common name", c.Name)
+		}
+		if len(c.Namespaces) == 0 {
+			return nil, fmt.Errorf("tap consumer %s must be allowed at least one namespace", c.Name)
+		}
+	}
+
+	return &policy, nil
+}
+
+// Authenticate returns the consumer sending the request, from its bearer
+// token or verified client certificate.
+func (p *TapAccessPolicy) Authenticate(req *http.Request) (*TapConsumer, error) {
+	if auth := req.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		token := []byte(strings.TrimPrefix(auth, "Bearer "))
+		for i, c := range p.Consumers {
+			if c.Token != "" && subtle.ConstantTimeCompare([]byte(c.Token), token) == 1 {
+				return &p.Consumers[i], nil
+			}
+		}
+		return nil, fmt.Errorf("invalid bearer token")
+	}
+
+	if req.TLS != nil && len(req.TLS.VerifiedChains) > 0 {
+		commonName := req.TLS.VerifiedChains[0][0].Subject.CommonName
+		for i, c := range p.Consumers {
+			if c.CommonName != "" && c.CommonName == commonName {
+				return &p.Consumers[i], nil
+			}
+		}
+		return nil, fmt.Errorf("no tap consumer with common name %s", commonName)
+	}
+
+	return nil, fmt.Errorf("a bearer token or a client certificate is required")
+}
+
+// Authorize checks that the tap request only selects the namespaces of the
+// consumer, and caps its rate.
+func (c *TapConsumer) Authorize(req *pb.TapByResourceRequest) error {
+	if err := c.authorizeResource(req.GetTarget().GetResource()); err != nil {
+		return err
+	}
+	if err := c.authorizeMatch(req.GetMatch()); err != nil {
+		return err
+	}
+
+	if c.MaxRps > 0 && (req.MaxRps <= 0 || req.MaxRps > c.MaxRps) {
+		req.MaxRps = c.MaxRps
+	}
+	return nil
+}
+
+func (c *TapConsumer) authorizeMatch(match *pb.TapByResourceRequest_Match) error {
+	switch m := match.GetMatch().(type) {
+	case *pb.TapByResourceRequest_Match_All:
+		for _, inner := range m.All.GetMatches() {
+			if err := c.authorizeMatch(inner); err != nil {
+				return err
+			}
+		}
+	case *pb.TapByResourceRequest_Match_Any:
+		for _, inner := range m.Any.GetMatches() {
+			if err := c.authorizeMatch(inner); err != nil {
+				return err
+			}
+		}
+	case *pb.TapByResourceRequest_Match_Not:
+		return c.authorizeMatch(m.Not)
+	case *pb.TapByResourceRequest_Match_Destinations:
+		return c.authorizeResource(m.Destinations.GetResource())
+	}
+	return nil
+}
+
+func (c *TapConsumer) authorizeResource(resource *pb.Resource) error {
+	namespace := resource.GetNamespace()
+	if resource.GetType() == k8s.Namespace {
+		namespace = resource.GetName()
+	}
+
+	for _, ns := range c.Namespaces {
+		if ns == "*" || (ns == namespace && namespace != "") {
+			return nil
+		}
+	}
+	return fmt.Errorf("tap consumer %s is not allowed to tap namespace [%s]", c.Name, namespace)
+}
+
+// externalTapHandler serves the Tap API to the consumers of the tap access
+// policy, in front of the public API handler.
+type externalTapHandler struct {
+	handler *handler
+	policy  *TapAccessPolicy
+}
+
+func (h *externalTapHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost || req.URL.Path != tapByResourcePath {
+		http.NotFound(w, req)
+		return
+	}
+
+	consumer, err := h.policy.Authenticate(req)
+	if err != nil {
+		writeErrorToHttpResponse(w, httpError{Code: http.StatusUnauthorized, WrappedError: err})
+		return
+	}
+
+	var protoRequest pb.TapByResourceRequest
+	err = httpRequestToProto(req, &protoRequest)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+
+	err = consumer.Authorize(&protoRequest)
+	if err != nil {
+		writeErrorToHttpResponse(w, httpError{Code: http.StatusForbidden, WrappedError: err})
+		return
+	}
+	log.Infof("tap consumer %s tapping %+v", consumer.Name, protoRequest.GetTarget().GetResource())
+
+	// hand the authorized request over to the public API handler
+	body, err := proto.Marshal(&protoRequest)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	h.handler.handleTapByResource(w, req)
+}
+
+// NewExternalTapServer returns a TLS server exposing the Tap API to the
+// consumers of the policy, outside of the Kubernetes API. When tlsConfig has
+// ClientCAs, consumers may authenticate with a client certificate.
+func NewExternalTapServer(
+	addr string,
+	tlsConfig *tls.Config,
+	policy *TapAccessPolicy,
+	tapClient tapPb.TapClient,
+) *http.Server {
+	return &http.Server{
+		Addr:      addr,
+		TLSConfig: tlsConfig,
+		Handler: &externalTapHandler{
+			handler: &handler{
+				grpcServer: &grpcServer{tapClient: tapClient},
+			},
+			policy: policy,
+		},
+	}
+}
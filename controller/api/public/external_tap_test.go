@@ -0,0 +1,99 @@
+package public
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestExternalTapHandler(t *testing.T) {
+	policy := &TapAccessPolicy{
+		Consumers: []TapConsumer{
+			{Name: "siem", Token: "s3cr3t", Namespaces: []string{"emojivoto"}, MaxRps: 10},
+		},
+	}
+	h := &externalTapHandler{handler: &handler{}, policy: policy}
+
+	newTapRequest := func(token, namespace string) *http.Request {
+		body, err := proto.Marshal(&pb.TapByResourceRequest{
+			Target: &pb.ResourceSelection{
+				Resource: &pb.Resource{Namespace: namespace, Type: pkgK8s.Deployment, Name: "web"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		req := httptest.NewRequest("POST", tapByResourcePath, bytes.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		return req
+	}
+
+	testCases := []struct {
+		req  *http.Request
+		code int
+	}{
+		{newTapRequest("", "emojivoto"), http.StatusUnauthorized},
+		{newTapRequest("wrong", "emojivoto"), http.StatusUnauthorized},
+		{newTapRequest("s3cr3t", "kube-system"), http.StatusForbidden},
+	}
+
+	for _, tc := range testCases {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, tc.req)
+		if w.Header().Get(errorHeader) != http.StatusText(tc.code) {
+			t.Fatalf("Expected error [%s], got [%s]", http.StatusText(tc.code), w.Header().Get(errorHeader))
+		}
+	}
+}
+
+func TestTapConsumerAuthorize(t *testing.T) {
+	consumer := &TapConsumer{Name: "siem", Namespaces: []string{"emojivoto"}, MaxRps: 10}
+
+	t.Run("Caps the rate of the taps", func(t *testing.T) {
+		req := &pb.TapByResourceRequest{
+			Target: &pb.ResourceSelection{
+				Resource: &pb.Resource{Type: pkgK8s.Namespace, Name: "emojivoto"},
+			},
+			MaxRps: 100,
+		}
+		if err := consumer.Authorize(req); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if req.MaxRps != 10 {
+			t.Fatalf("Expected the rate to be capped to 10, got %v", req.MaxRps)
+		}
+	})
+
+	t.Run("Rejects destinations in other namespaces", func(t *testing.T) {
+		req := &pb.TapByResourceRequest{
+			Target: &pb.ResourceSelection{
+				Resource: &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.Deployment, Name: "web"},
+			},
+			Match: &pb.TapByResourceRequest_Match{
+				Match: &pb.TapByResourceRequest_Match_All{
+					All: &pb.TapByResourceRequest_Match_Seq{
+						Matches: []*pb.TapByResourceRequest_Match{
+							{
+								Match: &pb.TapByResourceRequest_Match_Destinations{
+									Destinations: &pb.ResourceSelection{
+										Resource: &pb.Resource{Namespace: "linkerd", Type: pkgK8s.Deployment},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		if err := consumer.Authorize(req); err == nil {
+			t.Fatalf("Expected an error, got none")
+		}
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/golang/protobuf/ptypes/duration"
+	"github.com/linkerd/linkerd2/controller/api/util"
 	healthcheckPb "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
 	tapPb "github.com/linkerd/linkerd2/controller/gen/controller/tap"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
@@ -29,6 +30,7 @@ type (
 		k8sAPI              *k8s.API
 		controllerNamespace string
 		ignoredNamespaces   []string
+		routeStatsStore     *RouteStatsStore
 	}
 )
 
@@ -227,6 +229,11 @@ func (s *grpcServer) Tap(req *pb.TapRequest, stream pb.Api_TapServer) error {
 // Pass through to tap service
 func (s *grpcServer) TapByResource(req *pb.TapByResourceRequest, stream pb.Api_TapByResourceServer) error {
 	tapStream := stream.(tapServer)
+	filter, err := util.NewTapFilter(req.GetFilter())
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid tap filter: %s", err)
+	}
+
 	tapClient, err := s.tapClient.TapByResource(tapStream.Context(), req)
 	if err != nil {
 		log.Errorf("Unexpected error tapping [%v]: %v", req, err)
@@ -241,7 +248,9 @@ func (s *grpcServer) TapByResource(req *pb.TapByResourceRequest, stream pb.Api_T
 			if err != nil {
 				return err
 			}
-			tapStream.Send(event)
+			for _, e := range filter.Apply(event) {
+				tapStream.Send(e)
+			}
 		}
 	}
 }
@@ -0,0 +1,121 @@
+package public
+
+import (
+	"math"
+	"sort"
+	"strconv"
+
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/prometheus/common/model"
+)
+
+// bucket is a cumulative histogram bucket, as returned by a query on the
+// _bucket series of a histogram.
+type bucket struct {
+	upperBound float64
+	count      float64
+}
+
+// latencyHistogram accumulates the buckets of a histogram, so that its
+// quantiles can be computed from a single query instead of a
+// histogram_quantile query per quantile.
+type latencyHistogram []bucket
+
+// add adds the bucket of a sample to the histogram, and ignores samples that
+// aren't buckets. The buckets of samples with the same upper bound, such as
+// the buckets of different pods, are summed.
+func (h *latencyHistogram) add(sample *model.Sample) {
+	le, ok := sample.Metric[model.BucketLabel]
+	if !ok {
+		return
+	}
+	upperBound, err := strconv.ParseFloat(string(le), 64)
+	if err != nil {
+		return
+	}
+	for i := range *h {
+		if (*h)[i].upperBound == upperBound {
+			(*h)[i].count += float64(sample.Value)
+			return
+		}
+	}
+	*h = append(*h, bucket{upperBound, float64(sample.Value)})
+}
+
+// quantile estimates the q-quantile of the histogram the same way as
+// Prometheus' histogram_quantile, and returns NaN when it can't be estimated.
+func (h latencyHistogram) quantile(q float64) float64 {
+	buckets := h.sorted()
+	if len(buckets) < 2 || !math.IsInf(buckets[len(buckets)-1].upperBound, +1) {
+		return math.NaN()
+	}
+
+	total := buckets[len(buckets)-1].count
+	if total == 0 || math.IsNaN(total) {
+		return math.NaN()
+	}
+	rank := q * total
+
+	b := sort.Search(len(buckets)-1, func(i int) bool { return buckets[i].count >= rank })
+	if b == len(buckets)-1 {
+		return buckets[len(buckets)-2].upperBound
+	}
+	if b == 0 && buckets[0].upperBound <= 0 {
+		return buckets[0].upperBound
+	}
+
+	bucketStart := float64(0)
+	bucketEnd := buckets[b].upperBound
+	count := buckets[b].count
+	if b > 0 {
+		bucketStart = buckets[b-1].upperBound
+		count -= buckets[b-1].count
+		rank -= buckets[b-1].count
+	}
+	return bucketStart + (bucketEnd-bucketStart)*(rank/count)
+}
+
+// sorted returns the buckets by increasing upper bound.
+func (h latencyHistogram) sorted() []bucket {
+	buckets := make([]bucket, len(h))
+	copy(buckets, h)
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].upperBound < buckets[j].upperBound
+	})
+
+	// the counts of buckets may be decreasing because of the irate() of
+	// non-atomic scrapes
+	for i := 1; i < len(buckets); i++ {
+		if buckets[i].count < buckets[i-1].count {
+			buckets[i].count = buckets[i-1].count
+		}
+	}
+	return buckets
+}
+
+// latencyBuckets returns the non-cumulative buckets of the histogram, by
+// increasing upper bound, with the counts rounded like the sample values. The
+// upper bound of the +Inf bucket is 0.
+func (h latencyHistogram) latencyBuckets() []*pb.RouteTable_LatencyBucket {
+	buckets := h.sorted()
+	latencyBuckets := make([]*pb.RouteTable_LatencyBucket, len(buckets))
+	previous := float64(0)
+	for i, b := range buckets {
+		maxMs := uint64(0)
+		if !math.IsInf(b.upperBound, +1) {
+			maxMs = uint64(b.upperBound)
+		}
+		latencyBuckets[i] = &pb.RouteTable_LatencyBucket{
+			MaxMs: maxMs,
+			Count: extractSampleValue(&model.Sample{Value: model.SampleValue(b.count - previous)}),
+		}
+		previous = b.count
+	}
+	return latencyBuckets
+}
+
+// quantileValue is the q-quantile of the histogram, rounded like the sample
+// values of the latency queries.
+func (h latencyHistogram) quantileValue(q float64) uint64 {
+	return extractSampleValue(&model.Sample{Value: model.SampleValue(h.quantile(q))})
+}
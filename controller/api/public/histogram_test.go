@@ -0,0 +1,55 @@
+package public
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/common/model"
+)
+
+func TestLatencyHistogramQuantile(t *testing.T) {
+	newHistogram := func(buckets map[string]model.SampleValue) latencyHistogram {
+		h := latencyHistogram{}
+		for le, count := range buckets {
+			h.add(&model.Sample{
+				Metric: model.Metric{"le": model.LabelValue(le)},
+				Value:  count,
+			})
+		}
+		return h
+	}
+
+	h := newHistogram(map[string]model.SampleValue{
+		"10":   50,
+		"100":  90,
+		"1000": 100,
+		"+Inf": 100,
+	})
+	expectations := map[float64]float64{
+		0.5:  10,
+		0.8:  77.5,
+		0.95: 550,
+		0.99: 910,
+	}
+	for q, expected := range expectations {
+		if actual := h.quantile(q); math.Abs(actual-expected) > 1e-9 {
+			t.Fatalf("Expected the %v quantile to be %v, got %v", q, expected, actual)
+		}
+	}
+
+	// quantiles in the +Inf bucket are the highest finite bound
+	h = newHistogram(map[string]model.SampleValue{"10": 0, "+Inf": 10})
+	if actual := h.quantile(0.5); actual != 10 {
+		t.Fatalf("Expected the 0.5 quantile to be 10, got %v", actual)
+	}
+
+	for _, h := range []latencyHistogram{
+		newHistogram(map[string]model.SampleValue{"10": 5, "100": 10}),
+		newHistogram(map[string]model.SampleValue{"10": 0, "+Inf": 0}),
+		newHistogram(map[string]model.SampleValue{}),
+	} {
+		if actual := h.quantile(0.5); !math.IsNaN(actual) {
+			t.Fatalf("Expected no quantile for %v, got %v", h, actual)
+		}
+	}
+}
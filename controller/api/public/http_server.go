@@ -4,7 +4,10 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/linkerd/linkerd2/controller/api/util"
 	healthcheckPb "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
 	tapPb "github.com/linkerd/linkerd2/controller/gen/controller/tap"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
@@ -17,23 +20,32 @@ import (
 )
 
 var (
-	statSummaryPath   = fullUrlPathFor("StatSummary")
-	topRoutesPath     = fullUrlPathFor("TopRoutes")
-	versionPath       = fullUrlPathFor("Version")
-	listPodsPath      = fullUrlPathFor("ListPods")
-	listServicesPath  = fullUrlPathFor("ListServices")
-	tapByResourcePath = fullUrlPathFor("TapByResource")
-	selfCheckPath     = fullUrlPathFor("SelfCheck")
+	statSummaryPath       = fullUrlPathFor("StatSummary")
+	topRoutesPath         = fullUrlPathFor("TopRoutes")
+	streamStatsPath       = fullUrlPathFor("StreamStats")
+	routeSLOsPath         = fullUrlPathFor("RouteSLOs")
+	routeStatsHistoryPath = fullUrlPathFor("RouteStatsHistory")
+	versionPath           = fullUrlPathFor("Version")
+	listPodsPath          = fullUrlPathFor("ListPods")
+	listServicesPath      = fullUrlPathFor("ListServices")
+	tapByResourcePath     = fullUrlPathFor("TapByResource")
+	selfCheckPath         = fullUrlPathFor("SelfCheck")
 )
 
 type handler struct {
 	grpcServer pb.ApiServer
+	cache      *util.ResponseCache
 }
 
 func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	log.WithFields(log.Fields{
 		"req.Method": req.Method, "req.URL": req.URL, "req.Form": req.Form,
 	}).Debugf("Serving %s %s", req.Method, req.URL.Path)
+	if isJSONGatewayPath(req.URL.Path) {
+		h.handleJSONGateway(w, req)
+		return
+	}
+
 	// Validate request method
 	if req.Method != http.MethodPost {
 		writeErrorToHttpResponse(w, fmt.Errorf("POST required"))
@@ -46,6 +58,12 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		h.handleStatSummary(w, req)
 	case topRoutesPath:
 		h.handleTopRoutes(w, req)
+	case streamStatsPath:
+		h.handleStreamStats(w, req)
+	case routeSLOsPath:
+		h.handleRouteSLOs(w, req)
+	case routeStatsHistoryPath:
+		h.handleRouteStatsHistory(w, req)
 	case versionPath:
 		h.handleVersion(w, req)
 	case listPodsPath:
@@ -71,7 +89,9 @@ func (h *handler) handleStatSummary(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	rsp, err := h.grpcServer.StatSummary(req.Context(), &protoRequest)
+	rsp, err := h.cache.Get(req.Context(), "StatSummary", &protoRequest, func(ctx context.Context) (proto.Message, error) {
+		return h.grpcServer.StatSummary(ctx, &protoRequest)
+	})
 	if err != nil {
 		writeErrorToHttpResponse(w, err)
 		return
@@ -92,7 +112,76 @@ func (h *handler) handleTopRoutes(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	rsp, err := h.grpcServer.TopRoutes(req.Context(), &protoRequest)
+	rsp, err := h.cache.Get(req.Context(), "TopRoutes", &protoRequest, func(ctx context.Context) (proto.Message, error) {
+		return h.grpcServer.TopRoutes(ctx, &protoRequest)
+	})
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+	err = writeProtoToHttpResponse(w, rsp)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+}
+
+func (h *handler) handleStreamStats(w http.ResponseWriter, req *http.Request) {
+	var protoRequest pb.StatSummaryRequest
+
+	err := httpRequestToProto(req, &protoRequest)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+
+	rsp, err := h.cache.Get(req.Context(), "StreamStats", &protoRequest, func(ctx context.Context) (proto.Message, error) {
+		return h.grpcServer.StreamStats(ctx, &protoRequest)
+	})
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+	err = writeProtoToHttpResponse(w, rsp)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+}
+
+func (h *handler) handleRouteSLOs(w http.ResponseWriter, req *http.Request) {
+	var protoRequest pb.RouteSLOsRequest
+
+	err := httpRequestToProto(req, &protoRequest)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+
+	rsp, err := h.cache.Get(req.Context(), "RouteSLOs", &protoRequest, func(ctx context.Context) (proto.Message, error) {
+		return h.grpcServer.RouteSLOs(ctx, &protoRequest)
+	})
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+	err = writeProtoToHttpResponse(w, rsp)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+}
+
+func (h *handler) handleRouteStatsHistory(w http.ResponseWriter, req *http.Request) {
+	var protoRequest pb.RouteStatsHistoryRequest
+
+	err := httpRequestToProto(req, &protoRequest)
+	if err != nil {
+		writeErrorToHttpResponse(w, err)
+		return
+	}
+
+	rsp, err := h.grpcServer.RouteStatsHistory(req.Context(), &protoRequest)
 	if err != nil {
 		writeErrorToHttpResponse(w, err)
 		return
@@ -246,15 +335,21 @@ func NewServer(
 	k8sAPI *k8s.API,
 	controllerNamespace string,
 	ignoredNamespaces []string,
+	cacheTTL time.Duration,
+	routeStatsStore *RouteStatsStore,
 ) *http.Server {
+	grpcServer := newGrpcServer(
+		promv1.NewAPI(prometheusClient),
+		tapClient,
+		k8sAPI,
+		controllerNamespace,
+		ignoredNamespaces,
+	)
+	grpcServer.routeStatsStore = routeStatsStore
+
 	baseHandler := &handler{
-		grpcServer: newGrpcServer(
-			promv1.NewAPI(prometheusClient),
-			tapClient,
-			k8sAPI,
-			controllerNamespace,
-			ignoredNamespaces,
-		),
+		grpcServer: grpcServer,
+		cache:      newResponseCache(cacheTTL),
 	}
 
 	instrumentedHandler := prometheus.WithTelemetry(baseHandler)
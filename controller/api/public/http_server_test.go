@@ -29,6 +29,21 @@ func (m *mockGrpcServer) TopRoutes(ctx context.Context, req *pb.TopRoutesRequest
 	return m.ResponseToReturn.(*pb.TopRoutesResponse), m.ErrorToReturn
 }
 
+func (m *mockGrpcServer) StreamStats(ctx context.Context, req *pb.StatSummaryRequest) (*pb.StreamStatsResponse, error) {
+	m.LastRequestReceived = req
+	return m.ResponseToReturn.(*pb.StreamStatsResponse), m.ErrorToReturn
+}
+
+func (m *mockGrpcServer) RouteSLOs(ctx context.Context, req *pb.RouteSLOsRequest) (*pb.RouteSLOsResponse, error) {
+	m.LastRequestReceived = req
+	return m.ResponseToReturn.(*pb.RouteSLOsResponse), m.ErrorToReturn
+}
+
+func (m *mockGrpcServer) RouteStatsHistory(ctx context.Context, req *pb.RouteStatsHistoryRequest) (*pb.RouteStatsHistoryResponse, error) {
+	m.LastRequestReceived = req
+	return m.ResponseToReturn.(*pb.RouteStatsHistoryResponse), m.ErrorToReturn
+}
+
 func (m *mockGrpcServer) Version(ctx context.Context, req *pb.Empty) (*pb.VersionInfo, error) {
 	m.LastRequestReceived = req
 	return m.ResponseToReturn.(*pb.VersionInfo), m.ErrorToReturn
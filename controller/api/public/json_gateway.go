@@ -0,0 +1,349 @@
+package public
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/protoc-gen-go/descriptor"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// jsonGatewayPrefix is the path prefix of the JSON gateway, which serves a
+	// subset of the API as JSON, for the clients that don't link against the
+	// protobuf bindings. The messages are encoded as in the proto3 JSON
+	// mapping, keeping the field names of public.proto.
+	jsonGatewayPrefix = "json/"
+	jsonContentType   = "application/json"
+	protoFile         = "public.proto"
+	protoPackage      = "linkerd2.public"
+)
+
+var openAPIPath = fullUrlPathFor(jsonGatewayPrefix + "openapi.json")
+
+// jsonGatewayMethod is an API method exposed by the JSON gateway.
+type jsonGatewayMethod struct {
+	name        string
+	description string
+	request     func() proto.Message
+	response    proto.Message
+	call        func(ctx context.Context, server pb.ApiServer, req proto.Message) (proto.Message, error)
+}
+
+var jsonGatewayMethods = []jsonGatewayMethod{
+	{
+		name:        "StatSummary",
+		description: "Returns the request stats of the selected resources",
+		request:     func() proto.Message { return &pb.StatSummaryRequest{} },
+		response:    &pb.StatSummaryResponse{},
+		call: func(ctx context.Context, server pb.ApiServer, req proto.Message) (proto.Message, error) {
+			return server.StatSummary(ctx, req.(*pb.StatSummaryRequest))
+		},
+	},
+	{
+		name:        "TopRoutes",
+		description: "Returns the request stats of the routes of the selected resource, per the service profiles",
+		request:     func() proto.Message { return &pb.TopRoutesRequest{} },
+		response:    &pb.TopRoutesResponse{},
+		call: func(ctx context.Context, server pb.ApiServer, req proto.Message) (proto.Message, error) {
+			return server.TopRoutes(ctx, req.(*pb.TopRoutesRequest))
+		},
+	},
+}
+
+var (
+	jsonMarshaler   = jsonpb.Marshaler{OrigName: true, EmitDefaults: true}
+	jsonUnmarshaler = jsonpb.Unmarshaler{}
+
+	openAPIOnce sync.Once
+	openAPIDoc  []byte
+	openAPIErr  error
+)
+
+func jsonGatewayPath(method string) string {
+	return fullUrlPathFor(jsonGatewayPrefix + method)
+}
+
+func isJSONGatewayPath(path string) bool {
+	return strings.HasPrefix(path, fullUrlPathFor(jsonGatewayPrefix))
+}
+
+func (h *handler) handleJSONGateway(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == openAPIPath {
+		if req.Method != http.MethodGet {
+			writeJSONError(w, http.StatusMethodNotAllowed, "GET required")
+			return
+		}
+		h.handleOpenAPI(w)
+		return
+	}
+
+	for _, method := range jsonGatewayMethods {
+		if req.URL.Path != jsonGatewayPath(method.name) {
+			continue
+		}
+		if req.Method != http.MethodPost {
+			writeJSONError(w, http.StatusMethodNotAllowed, "POST required")
+			return
+		}
+		h.handleJSONMethod(w, req, method)
+		return
+	}
+
+	writeJSONError(w, http.StatusNotFound, fmt.Sprintf("unknown method %s", strings.TrimPrefix(req.URL.Path, fullUrlPathFor(jsonGatewayPrefix))))
+}
+
+func (h *handler) handleJSONMethod(w http.ResponseWriter, req *http.Request, method jsonGatewayMethod) {
+	protoRequest := method.request()
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	// an empty body is the default request, as with the protobuf endpoints
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := jsonUnmarshaler.Unmarshal(bytes.NewReader(body), protoRequest); err != nil {
+			writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("invalid %s: %s", proto.MessageName(protoRequest), err))
+			return
+		}
+	}
+
+	rsp, err := h.cache.Get(req.Context(), method.name, protoRequest, func(ctx context.Context) (proto.Message, error) {
+		return method.call(ctx, h.grpcServer, protoRequest)
+	})
+	if err != nil {
+		message := err.Error()
+		if grpcError, ok := status.FromError(err); ok {
+			message = grpcError.Message()
+		}
+		writeJSONError(w, http.StatusInternalServerError, message)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := jsonMarshaler.Marshal(&buf, rsp); err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	w.Header().Set(contentTypeHeader, jsonContentType)
+	w.Write(buf.Bytes())
+}
+
+func (h *handler) handleOpenAPI(w http.ResponseWriter) {
+	openAPIOnce.Do(func() {
+		openAPIDoc, openAPIErr = openAPISpec()
+	})
+	if openAPIErr != nil {
+		writeJSONError(w, http.StatusInternalServerError, openAPIErr.Error())
+		return
+	}
+	w.Header().Set(contentTypeHeader, jsonContentType)
+	w.Write(openAPIDoc)
+}
+
+// writeJSONError writes the error as an ApiError, the error message of the
+// protobuf endpoints.
+func writeJSONError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set(contentTypeHeader, jsonContentType)
+	w.Header().Set(errorHeader, http.StatusText(statusCode))
+	w.WriteHeader(statusCode)
+	jsonMarshaler.Marshal(w, &pb.ApiError{Error: message})
+}
+
+// openAPISpec returns the OpenAPI 3 document describing the JSON gateway. The
+// schemas are derived from the descriptor of public.proto registered by the
+// generated code, so that they can't drift from the messages.
+func openAPISpec() ([]byte, error) {
+	gz := proto.FileDescriptor(protoFile)
+	if gz == nil {
+		return nil, fmt.Errorf("no descriptor registered for %s", protoFile)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(gz))
+	if err != nil {
+		return nil, err
+	}
+	b, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var fd descriptor.FileDescriptorProto
+	if err := proto.Unmarshal(b, &fd); err != nil {
+		return nil, err
+	}
+
+	s := newSchemaBuilder(&fd)
+	paths := map[string]interface{}{}
+	for _, method := range jsonGatewayMethods {
+		request := s.ref(proto.MessageName(method.request()))
+		response := s.ref(proto.MessageName(method.response))
+		paths[jsonGatewayPath(method.name)] = map[string]interface{}{
+			"post": map[string]interface{}{
+				"operationId": method.name,
+				"summary":     method.description,
+				"requestBody": map[string]interface{}{
+					"required": true,
+					"content":  jsonContent(request),
+				},
+				"responses": map[string]interface{}{
+					"200": map[string]interface{}{
+						"description": "The " + method.name + " response",
+						"content":     jsonContent(response),
+					},
+					"default": map[string]interface{}{
+						"description": "The error that failed the request",
+						"content":     jsonContent(s.ref(proto.MessageName(&pb.ApiError{}))),
+					},
+				},
+			},
+		}
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"openapi": "3.0.0",
+		"info": map[string]interface{}{
+			"title":   "Linkerd public API",
+			"version": apiVersion,
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": s.schemas,
+		},
+	}, "", "  ")
+}
+
+func jsonContent(schema interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		jsonContentType: map[string]interface{}{"schema": schema},
+	}
+}
+
+// schemaBuilder maps the messages of a proto file to OpenAPI schemas, per the
+// proto3 JSON mapping.
+type schemaBuilder struct {
+	messages map[string]*descriptor.DescriptorProto
+	enums    map[string]*descriptor.EnumDescriptorProto
+	schemas  map[string]interface{}
+}
+
+func newSchemaBuilder(fd *descriptor.FileDescriptorProto) *schemaBuilder {
+	s := &schemaBuilder{
+		messages: map[string]*descriptor.DescriptorProto{},
+		enums:    map[string]*descriptor.EnumDescriptorProto{},
+		schemas:  map[string]interface{}{},
+	}
+	prefix := "." + fd.GetPackage()
+	for _, enum := range fd.GetEnumType() {
+		s.enums[prefix+"."+enum.GetName()] = enum
+	}
+	for _, msg := range fd.GetMessageType() {
+		s.index(prefix, msg)
+	}
+	return s
+}
+
+func (s *schemaBuilder) index(prefix string, msg *descriptor.DescriptorProto) {
+	name := prefix + "." + msg.GetName()
+	s.messages[name] = msg
+	for _, enum := range msg.GetEnumType() {
+		s.enums[name+"."+enum.GetName()] = enum
+	}
+	for _, nested := range msg.GetNestedType() {
+		s.index(name, nested)
+	}
+}
+
+// ref returns a reference to the schema of the message with the given full
+// name, e.g. linkerd2.public.TopRoutesRequest, adding the schemas of the
+// message and of the messages it contains to the components.
+func (s *schemaBuilder) ref(name string) map[string]interface{} {
+	name = strings.TrimPrefix(name, ".")
+	schemaName := strings.TrimPrefix(name, protoPackage+".")
+	if _, ok := s.schemas[schemaName]; !ok {
+		msg, ok := s.messages["."+name]
+		if !ok {
+			return map[string]interface{}{"type": "object"}
+		}
+		// reserve the name first, as messages can be recursive
+		s.schemas[schemaName] = nil
+		s.schemas[schemaName] = s.message(msg)
+	}
+	return map[string]interface{}{"$ref": "#/components/schemas/" + schemaName}
+}
+
+func (s *schemaBuilder) message(msg *descriptor.DescriptorProto) map[string]interface{} {
+	properties := map[string]interface{}{}
+	for _, field := range msg.GetField() {
+		properties[field.GetName()] = s.field(field)
+	}
+	return map[string]interface{}{"type": "object", "properties": properties}
+}
+
+func (s *schemaBuilder) field(field *descriptor.FieldDescriptorProto) map[string]interface{} {
+	if field.GetType() == descriptor.FieldDescriptorProto_TYPE_MESSAGE {
+		if entry, ok := s.messages[field.GetTypeName()]; ok && entry.GetOptions().GetMapEntry() {
+			// map entries have the key and the value fields, in that order
+			return map[string]interface{}{
+				"type":                 "object",
+				"additionalProperties": s.field(entry.GetField()[1]),
+			}
+		}
+	}
+
+	schema := s.scalar(field)
+	if field.GetLabel() == descriptor.FieldDescriptorProto_LABEL_REPEATED {
+		return map[string]interface{}{"type": "array", "items": schema}
+	}
+	return schema
+}
+
+func (s *schemaBuilder) scalar(field *descriptor.FieldDescriptorProto) map[string]interface{} {
+	switch field.GetType() {
+	case descriptor.FieldDescriptorProto_TYPE_BOOL:
+		return map[string]interface{}{"type": "boolean"}
+	case descriptor.FieldDescriptorProto_TYPE_STRING:
+		return map[string]interface{}{"type": "string"}
+	case descriptor.FieldDescriptorProto_TYPE_BYTES:
+		return map[string]interface{}{"type": "string", "format": "byte"}
+	case descriptor.FieldDescriptorProto_TYPE_FLOAT:
+		return map[string]interface{}{"type": "number", "format": "float"}
+	case descriptor.FieldDescriptorProto_TYPE_DOUBLE:
+		return map[string]interface{}{"type": "number", "format": "double"}
+	case descriptor.FieldDescriptorProto_TYPE_INT32,
+		descriptor.FieldDescriptorProto_TYPE_SINT32,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED32:
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case descriptor.FieldDescriptorProto_TYPE_UINT32,
+		descriptor.FieldDescriptorProto_TYPE_FIXED32:
+		return map[string]interface{}{"type": "integer", "format": "int64", "minimum": 0}
+	case descriptor.FieldDescriptorProto_TYPE_INT64,
+		descriptor.FieldDescriptorProto_TYPE_SINT64,
+		descriptor.FieldDescriptorProto_TYPE_SFIXED64:
+		// 64-bit integers are encoded as strings in JSON
+		return map[string]interface{}{"type": "string", "format": "int64"}
+	case descriptor.FieldDescriptorProto_TYPE_UINT64,
+		descriptor.FieldDescriptorProto_TYPE_FIXED64:
+		return map[string]interface{}{"type": "string", "format": "uint64"}
+	case descriptor.FieldDescriptorProto_TYPE_ENUM:
+		values := []string{}
+		for _, value := range s.enums[field.GetTypeName()].GetValue() {
+			values = append(values, value.GetName())
+		}
+		return map[string]interface{}{"type": "string", "enum": values}
+	case descriptor.FieldDescriptorProto_TYPE_MESSAGE:
+		if field.GetTypeName() == ".google.protobuf.Duration" {
+			return map[string]interface{}{"type": "string", "description": "A duration in seconds, e.g. 1.5s"}
+		}
+		return s.ref(field.GetTypeName())
+	}
+	return map[string]interface{}{}
+}
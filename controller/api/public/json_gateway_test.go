@@ -0,0 +1,152 @@
+package public
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+)
+
+func TestJSONGateway(t *testing.T) {
+	serve := func(mock *mockGrpcServer, method, path, body string) *httptest.ResponseRecorder {
+		h := &handler{grpcServer: mock}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+		return w
+	}
+
+	t.Run("Decodes the JSON request and encodes the response with the proto field names", func(t *testing.T) {
+		mock := &mockGrpcServer{
+			ResponseToReturn: &pb.TopRoutesResponse{
+				Response: &pb.TopRoutesResponse_Routes{
+					Routes: &pb.RouteTable{
+						Rows: []*pb.RouteTable_Row{
+							{
+								Route:     "GET /books",
+								Authority: "webapp.default.svc.cluster.local",
+								Stats:     &pb.BasicStats{SuccessCount: 3},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		w := serve(mock, http.MethodPost, "/api/v1/json/TopRoutes",
+			`{"selector": {"resource": {"namespace": "default", "type": "deployment", "name": "webapp"}}, "time_window": "1m"}`)
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		if w.Header().Get(contentTypeHeader) != jsonContentType {
+			t.Fatalf("Unexpected content type %s", w.Header().Get(contentTypeHeader))
+		}
+
+		expectedRequest := &pb.TopRoutesRequest{
+			Selector: &pb.ResourceSelection{
+				Resource: &pb.Resource{Namespace: "default", Type: "deployment", Name: "webapp"},
+			},
+			TimeWindow: "1m",
+		}
+		if !proto.Equal(mock.LastRequestReceived, expectedRequest) {
+			t.Fatalf("Expected request %v, got %v", expectedRequest, mock.LastRequestReceived)
+		}
+
+		var rsp struct {
+			Routes struct {
+				Rows []struct {
+					Route string `json:"route"`
+					Stats struct {
+						SuccessCount string `json:"success_count"`
+					} `json:"stats"`
+				} `json:"rows"`
+			} `json:"routes"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &rsp); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(rsp.Routes.Rows) != 1 || rsp.Routes.Rows[0].Route != "GET /books" {
+			t.Fatalf("Unexpected response %s", w.Body.String())
+		}
+		if rsp.Routes.Rows[0].Stats.SuccessCount != "3" {
+			t.Fatalf("Expected success_count to be encoded as \"3\", got %s", w.Body.String())
+		}
+	})
+
+	t.Run("Returns bad request for invalid JSON", func(t *testing.T) {
+		w := serve(&mockGrpcServer{}, http.MethodPost, "/api/v1/json/StatSummary", `{"time_window": 60}`)
+		if w.Code != http.StatusBadRequest {
+			t.Fatalf("Expected status 400, got %d", w.Code)
+		}
+		if !strings.Contains(w.Body.String(), "invalid linkerd2.public.StatSummaryRequest") {
+			t.Fatalf("Unexpected error %s", w.Body.String())
+		}
+	})
+
+	t.Run("Returns the errors of the API as JSON", func(t *testing.T) {
+		mock := &mockGrpcServer{
+			ResponseToReturn: &pb.StatSummaryResponse{},
+			ErrorToReturn:    errors.New("prometheus is unreachable"),
+		}
+		w := serve(mock, http.MethodPost, "/api/v1/json/StatSummary", "")
+		if w.Code != http.StatusInternalServerError {
+			t.Fatalf("Expected status 500, got %d", w.Code)
+		}
+		if strings.TrimSpace(w.Body.String()) != `{"error":"prometheus is unreachable"}` {
+			t.Fatalf("Unexpected error %s", w.Body.String())
+		}
+	})
+
+	t.Run("Rejects unknown methods and methods that aren't exposed", func(t *testing.T) {
+		w := serve(&mockGrpcServer{}, http.MethodPost, "/api/v1/json/ListPods", "")
+		if w.Code != http.StatusNotFound {
+			t.Fatalf("Expected status 404, got %d", w.Code)
+		}
+		w = serve(&mockGrpcServer{}, http.MethodGet, "/api/v1/json/TopRoutes", "")
+		if w.Code != http.StatusMethodNotAllowed {
+			t.Fatalf("Expected status 405, got %d", w.Code)
+		}
+	})
+
+	t.Run("Serves the OpenAPI spec", func(t *testing.T) {
+		w := serve(&mockGrpcServer{}, http.MethodGet, "/api/v1/json/openapi.json", "")
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+
+		var spec struct {
+			OpenAPI    string                     `json:"openapi"`
+			Paths      map[string]json.RawMessage `json:"paths"`
+			Components struct {
+				Schemas map[string]struct {
+					Properties map[string]map[string]interface{} `json:"properties"`
+				} `json:"schemas"`
+			} `json:"components"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &spec); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		for _, path := range []string{"/api/v1/json/StatSummary", "/api/v1/json/TopRoutes"} {
+			if _, ok := spec.Paths[path]; !ok {
+				t.Fatalf("Expected the spec to describe %s", path)
+			}
+		}
+
+		schemas := spec.Components.Schemas
+		for _, name := range []string{"TopRoutesRequest", "TopRoutesResponse", "RouteTable.Row", "StatSummaryResponse", "ApiError"} {
+			if _, ok := schemas[name]; !ok {
+				t.Fatalf("Expected a schema for %s", name)
+			}
+		}
+		if typ := schemas["BasicStats"].Properties["success_count"]["type"]; typ != "string" {
+			t.Fatalf("Expected success_count to be a string, got %v", typ)
+		}
+		if typ := schemas["StatTable.PodGroup.Row"].Properties["errors_by_pod"]["type"]; typ != "object" {
+			t.Fatalf("Expected errors_by_pod to be an object, got %v", typ)
+		}
+	})
+}
@@ -25,6 +25,15 @@ const (
 	promLatencyP95 = promType("0.95")
 	promLatencyP99 = promType("0.99")
 
+	promLatencyBuckets   = promType("QUERY_LATENCY_BUCKETS")
+	promLatencyHistogram = promType("QUERY_LATENCY_HISTOGRAM")
+
+	promTcpConnections = promType("QUERY_TCP_CONNECTIONS")
+	promTcpReadBytes   = promType("QUERY_TCP_READ_BYTES")
+	promTcpWriteBytes  = promType("QUERY_TCP_WRITE_BYTES")
+
+	promGrpcResponses = promType("QUERY_GRPC_RESPONSES")
+
 	namespaceLabel    = model.LabelName("namespace")
 	dstNamespaceLabel = model.LabelName("dst_namespace")
 )
@@ -39,11 +48,21 @@ func extractSampleValue(sample *model.Sample) uint64 {
 	return value
 }
 
+type queryTimeKey struct{}
+
+// withQueryTime returns a context evaluating the Prometheus queries run with
+// it at ts, rather than now.
+func withQueryTime(ctx context.Context, ts time.Time) context.Context {
+	return context.WithValue(ctx, queryTimeKey{}, ts)
+}
+
 func (s *grpcServer) queryProm(ctx context.Context, query string) (model.Vector, error) {
 	log.Debugf("Query request:\n\t%+v", query)
 
+	ts, _ := ctx.Value(queryTimeKey{}).(time.Time)
+
 	// single data point (aka summary) query
-	res, err := s.prometheusAPI.Query(ctx, query, time.Time{})
+	res, err := s.prometheusAPI.Query(ctx, query, ts)
 	if err != nil {
 		log.Errorf("Query(%+v) failed with: %+v", query, err)
 		return nil, err
@@ -95,20 +114,23 @@ func promQueryLabels(resource *pb.Resource) model.LabelSet {
 	return set
 }
 
-// query a named resource
+// query a destination resource; the namespace of the resource restricts the
+// query even if the resource has no name
 func promDstQueryLabels(resource *pb.Resource) model.LabelSet {
 	set := model.LabelSet{}
-	if resource.Name != "" {
-		if isNonK8sResourceQuery(resource.GetType()) {
+	if isNonK8sResourceQuery(resource.GetType()) {
+		if resource.Name != "" {
 			set[promResourceType(resource)] = model.LabelValue(resource.Name)
-		} else {
-			set["dst_"+promResourceType(resource)] = model.LabelValue(resource.Name)
-			if shouldAddNamespaceLabel(resource) {
-				set[dstNamespaceLabel] = model.LabelValue(resource.Namespace)
-			}
 		}
+		return set
 	}
 
+	if resource.Name != "" {
+		set["dst_"+promResourceType(resource)] = model.LabelValue(resource.Name)
+	}
+	if shouldAddNamespaceLabel(resource) {
+		set[dstNamespaceLabel] = model.LabelValue(resource.Namespace)
+	}
 	return set
 }
 
@@ -176,3 +198,59 @@ func (s *grpcServer) getPrometheusMetrics(ctx context.Context, volumeQueryTempla
 
 	return results, nil
 }
+
+// getTcpMetrics queries the open TCP connections and the bytes read and
+// written over the TCP connections of the resources.
+func (s *grpcServer) getTcpMetrics(ctx context.Context, labels, timeWindow, groupBy string) ([]promResult, error) {
+	queries := map[promType]string{
+		promTcpConnections: fmt.Sprintf(tcpConnectionsQuery, labels, groupBy),
+		promTcpReadBytes:   fmt.Sprintf(tcpReadBytesQuery, labels, timeWindow, groupBy),
+		promTcpWriteBytes:  fmt.Sprintf(tcpWriteBytesQuery, labels, timeWindow, groupBy),
+	}
+
+	return s.runPromQueries(ctx, queries)
+}
+
+// getStreamMetrics queries the gRPC responses by status code of the
+// resources.
+func (s *grpcServer) getStreamMetrics(ctx context.Context, labels, timeWindow, groupBy string) ([]promResult, error) {
+	queries := map[promType]string{
+		promGrpcResponses: fmt.Sprintf(grpcResponsesQuery, labels, timeWindow, groupBy),
+	}
+
+	return s.runPromQueries(ctx, queries)
+}
+
+// runPromQueries runs the queries concurrently, and returns their results
+// tagged with their prom type.
+func (s *grpcServer) runPromQueries(ctx context.Context, queries map[promType]string) ([]promResult, error) {
+	resultChan := make(chan promResult)
+	for prom, query := range queries {
+		go func(prom promType, query string) {
+			resultVector, err := s.queryProm(ctx, query)
+
+			resultChan <- promResult{
+				prom: prom,
+				vec:  resultVector,
+				err:  err,
+			}
+		}(prom, query)
+	}
+
+	var err error
+	results := []promResult{}
+	for i := 0; i < len(queries); i++ {
+		result := <-resultChan
+		if result.err != nil {
+			log.Errorf("queryProm failed with: %s", result.err)
+			err = result.err
+		} else {
+			results = append(results, result)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
@@ -0,0 +1,31 @@
+package public
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+func TestQueryPromTime(t *testing.T) {
+	mockProm, fakeGrpcServer, err := newMockGrpcServer(expectedStatRpc{mockPromResponse: model.Vector{}})
+	if err != nil {
+		t.Fatalf("Error creating mock grpc server: %s", err)
+	}
+
+	ts := time.Unix(1546819200, 0)
+	if _, err := fakeGrpcServer.queryProm(context.Background(), "up"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if _, err := fakeGrpcServer.queryProm(withQueryTime(context.Background(), ts), "up"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	if !mockProm.QueryTimes[0].IsZero() {
+		t.Fatalf("Expected the first query to be evaluated now, got %s", mockProm.QueryTimes[0])
+	}
+	if !mockProm.QueryTimes[1].Equal(ts) {
+		t.Fatalf("Expected the second query to be evaluated at %s, got %s", ts, mockProm.QueryTimes[1])
+	}
+}
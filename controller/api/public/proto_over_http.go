@@ -28,6 +28,13 @@ type httpError struct {
 	WrappedError error
 }
 
+// UnexpectedResponseError is returned by the client when the API responds
+// with a non-OK status that doesn't carry a Linkerd error.
+type UnexpectedResponseError struct {
+	StatusCode int
+	Status     string
+}
+
 type flushableResponseWriter interface {
 	http.ResponseWriter
 	http.Flusher
@@ -37,6 +44,10 @@ func (e httpError) Error() string {
 	return fmt.Sprintf("HTTP error, status Code [%d], wrapped error is: %v", e.Code, e.WrappedError)
 }
 
+func (e UnexpectedResponseError) Error() string {
+	return fmt.Sprintf("Unexpected API response: %s", e.Status)
+}
+
 func httpRequestToProto(req *http.Request, protoRequestOut proto.Message) error {
 	bytes, err := ioutil.ReadAll(req.Body)
 	if err != nil {
@@ -150,7 +161,7 @@ func checkIfResponseHasError(rsp *http.Response) error {
 	}
 
 	if rsp.StatusCode != http.StatusOK {
-		return fmt.Errorf("Unexpected API response: %s", rsp.Status)
+		return UnexpectedResponseError{StatusCode: rsp.StatusCode, Status: rsp.Status}
 	}
 
 	return nil
@@ -0,0 +1,152 @@
+package public
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/linkerd/linkerd2/controller/api/util"
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/profiles"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+func (s *grpcServer) RouteSLOs(ctx context.Context, req *pb.RouteSLOsRequest) (*pb.RouteSLOsResponse, error) {
+	resource := req.GetSelector().GetResource()
+
+	// check for well-formed request
+	if resource == nil {
+		return routeSLOsError(req, "RouteSLOs request missing Selector Resource"), nil
+	}
+	if resource.GetType() != k8s.Service {
+		return routeSLOsError(req, "route SLOs are only declared for services"), nil
+	}
+
+	name := fmt.Sprintf("%s.%s.svc.cluster.local", resource.GetName(), resource.GetNamespace())
+	profile, err := s.k8sAPI.SP().Lister().ServiceProfiles(s.controllerNamespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return routeSLOsError(req, fmt.Sprintf("no ServiceProfile found for service %s", name)), nil
+		}
+		return nil, util.GRPCError(err)
+	}
+
+	// the routes sharing an SLO window are queried together
+	statsByWindow := make(map[string]map[string]*pb.BasicStats)
+	rows := make([]*pb.RouteSLOTable_Row, 0)
+	for _, route := range profile.Spec.Routes {
+		if route.SLO == nil {
+			continue
+		}
+		if err := profiles.ValidateSLO(route.SLO); err != nil {
+			return routeSLOsError(req, fmt.Sprintf("ServiceProfile %s has a route with an invalid SLO: %s", name, err)), nil
+		}
+
+		stats, ok := statsByWindow[route.SLO.Window]
+		if !ok {
+			stats, err = s.getRouteStatsByName(ctx, req.GetSelector(), route.SLO.Window)
+			if err != nil {
+				return nil, util.GRPCError(err)
+			}
+			statsByWindow[route.SLO.Window] = stats
+		}
+
+		rows = append(rows, newRouteSLORow(route.Name, route.SLO, stats[route.Name]))
+	}
+
+	return &pb.RouteSLOsResponse{
+		Response: &pb.RouteSLOsResponse_Ok{
+			Ok: &pb.RouteSLOTable{
+				Rows: rows,
+			},
+		},
+	}, nil
+}
+
+func routeSLOsError(req *pb.RouteSLOsRequest, message string) *pb.RouteSLOsResponse {
+	return &pb.RouteSLOsResponse{
+		Response: &pb.RouteSLOsResponse_Error{
+			Error: &pb.ResourceError{
+				Resource: req.GetSelector().GetResource(),
+				Error:    message,
+			},
+		},
+	}
+}
+
+// getRouteStatsByName returns the inbound stats of the routes of a service
+// over the window, keyed by route name. The stats of a route are summed
+// across its authorities, keeping the worst latencies.
+func (s *grpcServer) getRouteStatsByName(ctx context.Context, selector *pb.ResourceSelection, timeWindow string) (map[string]*pb.BasicStats, error) {
+	table, err := s.getRouteMetrics(ctx, &pb.TopRoutesRequest{
+		Selector:   selector,
+		TimeWindow: timeWindow,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]*pb.BasicStats)
+	for _, row := range table.GetRows() {
+		routeStats, ok := stats[row.Route]
+		if !ok {
+			routeStats = &pb.BasicStats{}
+			stats[row.Route] = routeStats
+		}
+		routeStats.SuccessCount += row.Stats.GetSuccessCount()
+		routeStats.FailureCount += row.Stats.GetFailureCount()
+		routeStats.TlsRequestCount += row.Stats.GetTlsRequestCount()
+		routeStats.LatencyMsP50 = maxUint64(routeStats.LatencyMsP50, row.Stats.GetLatencyMsP50())
+		routeStats.LatencyMsP95 = maxUint64(routeStats.LatencyMsP95, row.Stats.GetLatencyMsP95())
+		routeStats.LatencyMsP99 = maxUint64(routeStats.LatencyMsP99, row.Stats.GetLatencyMsP99())
+	}
+
+	return stats, nil
+}
+
+func maxUint64(a, b uint64) uint64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// newRouteSLORow computes the compliance of a route with its SLO. The error
+// budget is the number of failures the success rate objective allows over
+// the window. A zero budget, i.e. a success rate objective of 1, which
+// ValidateSLO rejects, is exhausted by the first failure.
+func newRouteSLORow(route string, slo *sp.RouteSLO, stats *pb.BasicStats) *pb.RouteSLOTable_Row {
+	if stats == nil {
+		stats = &pb.BasicStats{}
+	}
+
+	row := &pb.RouteSLOTable_Row{
+		Route:                route,
+		TimeWindow:           slo.Window,
+		SuccessRateObjective: slo.SuccessRate,
+		LatencyMsObjective:   slo.LatencyMs,
+		Stats:                stats,
+		Compliant:            true,
+	}
+
+	if slo.SuccessRate > 0 {
+		row.ErrorBudgetRemaining = 1
+		total := stats.SuccessCount + stats.FailureCount
+		if total > 0 {
+			budget := (1 - slo.SuccessRate) * float64(total)
+			if budget > 0 {
+				row.ErrorBudgetRemaining = 1 - float64(stats.FailureCount)/budget
+			} else if stats.FailureCount > 0 {
+				row.ErrorBudgetRemaining = 0
+			}
+			row.Compliant = float64(stats.SuccessCount)/float64(total) >= slo.SuccessRate
+		}
+	}
+
+	if slo.LatencyMs > 0 && stats.LatencyMsP99 > slo.LatencyMs {
+		row.Compliant = false
+	}
+
+	return row
+}
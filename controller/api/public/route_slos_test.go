@@ -0,0 +1,154 @@
+package public
+
+import (
+	"context"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestRouteSLOs(t *testing.T) {
+	profile := `
+apiVersion: linkerd.io/v1alpha1
+kind: ServiceProfile
+metadata:
+  name: webapp.books.svc.cluster.local
+  namespace: linkerd
+spec:
+  routes:
+  - name: /a
+    condition:
+      pathRegex: /a
+    slo:
+      successRate: 0.99
+      latencyMs: 100
+      window: 30d
+  - name: /b
+    condition:
+      pathRegex: /b`
+
+	req := &pb.RouteSLOsRequest{
+		Selector: &pb.ResourceSelection{
+			Resource: &pb.Resource{
+				Namespace: "books",
+				Type:      pkgK8s.Service,
+				Name:      "webapp",
+			},
+		},
+	}
+
+	t.Run("Reports the compliance of the routes with an SLO", func(t *testing.T) {
+		exp := expectedStatRpc{
+			k8sConfigs:       []string{profile},
+			mockPromResponse: routesMetric([]string{"/a", "/b"}),
+			expectedPrometheusQueries: []string{
+				`sum(irate(route_response_latency_ms_bucket{direction="inbound", dst=~"webapp.books.svc.cluster.local(:\\d+)?"}[30d])) by (le, dst, rt_route)`,
+				`sum(increase(route_response_total{direction="inbound", dst=~"webapp.books.svc.cluster.local(:\\d+)?"}[30d])) by (rt_route, dst, classification, tls, grpc_status)`,
+			},
+		}
+
+		mockProm, fakeGrpcServer, err := newMockGrpcServer(exp)
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		rsp, err := fakeGrpcServer.RouteSLOs(context.TODO(), req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		err = exp.verifyPromQueries(mockProm)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// the p99 latency of the mocked route is above its objective
+		expected := []*pb.RouteSLOTable_Row{
+			{
+				Route:                "/a",
+				TimeWindow:           "30d",
+				SuccessRateObjective: 0.99,
+				LatencyMsObjective:   100,
+				Stats: &pb.BasicStats{
+					SuccessCount:    123,
+					TlsRequestCount: 123,
+					LatencyMsP50:    123,
+					LatencyMsP95:    123,
+					LatencyMsP99:    123,
+				},
+				ErrorBudgetRemaining: 1,
+				Compliant:            false,
+			},
+		}
+
+		rows := rsp.GetOk().GetRows()
+		if len(rows) != len(expected) {
+			t.Fatalf("Expected %d rows, got %d: %v", len(expected), len(rows), rows)
+		}
+		for i, row := range rows {
+			if !proto.Equal(row, expected[i]) {
+				t.Fatalf("Expected: %+v\n Got: %+v", expected[i], row)
+			}
+		}
+	})
+
+	t.Run("Returns an error for a route with an invalid SLO", func(t *testing.T) {
+		invalid := strings.Replace(profile, "successRate: 0.99", "successRate: 1.5", 1)
+		_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRpc{k8sConfigs: []string{invalid}})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		rsp, err := fakeGrpcServer.RouteSLOs(context.TODO(), req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expected := "ServiceProfile webapp.books.svc.cluster.local has a route with an invalid SLO: An SLO success rate must be between 0 and 1, e.g. 0.999"
+		if rsp.GetError().GetError() != expected {
+			t.Fatalf("Expected error [%s], got [%s]", expected, rsp.GetError().GetError())
+		}
+	})
+
+	t.Run("Returns an error for a service without a ServiceProfile", func(t *testing.T) {
+		_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRpc{})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		rsp, err := fakeGrpcServer.RouteSLOs(context.TODO(), req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expected := "no ServiceProfile found for service webapp.books.svc.cluster.local"
+		if rsp.GetError().GetError() != expected {
+			t.Fatalf("Expected error [%s], got [%s]", expected, rsp.GetError().GetError())
+		}
+	})
+}
+
+func TestNewRouteSLORow(t *testing.T) {
+	slo := &sp.RouteSLO{SuccessRate: 0.99, Window: "7d"}
+
+	row := newRouteSLORow("/a", slo, &pb.BasicStats{SuccessCount: 995, FailureCount: 5})
+	if math.Abs(row.ErrorBudgetRemaining-0.5) > 1e-9 || !row.Compliant {
+		t.Fatalf("Expected half of the error budget to remain, got %+v", row)
+	}
+
+	row = newRouteSLORow("/a", slo, &pb.BasicStats{SuccessCount: 980, FailureCount: 20})
+	if math.Abs(row.ErrorBudgetRemaining+1) > 1e-9 || row.Compliant {
+		t.Fatalf("Expected the error budget to be exhausted, got %+v", row)
+	}
+
+	slo = &sp.RouteSLO{SuccessRate: 1, Window: "7d"}
+	row = newRouteSLORow("/a", slo, &pb.BasicStats{SuccessCount: 1, FailureCount: 1})
+	if row.ErrorBudgetRemaining != 0 || row.Compliant {
+		t.Fatalf("Expected the zero error budget to be exhausted, got %+v", row)
+	}
+}
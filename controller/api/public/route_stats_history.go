@@ -0,0 +1,305 @@
+package public
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	promApi "github.com/prometheus/client_golang/api"
+	promv1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	"github.com/prometheus/common/model"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	snapshotSuffix = ".json.gz"
+
+	// the default range of RouteStatsHistory requests without a start time
+	defaultHistoryRange = 24 * time.Hour
+)
+
+// RouteStatsStore keeps snapshots of the inbound route stats of the services
+// with a ServiceProfile in a directory, typically on a persistent volume, so
+// that they outlive the retention of Prometheus. Each snapshot is a gzipped
+// JSON file named after its Unix timestamp.
+type RouteStatsStore struct {
+	dir       string
+	retention time.Duration
+}
+
+// NewRouteStatsStore returns a store keeping the snapshots in dir for the
+// retention duration.
+func NewRouteStatsStore(dir string, retention time.Duration) (*RouteStatsStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &RouteStatsStore{dir: dir, retention: retention}, nil
+}
+
+// write stores the route tables of a snapshot, keyed by service FQDN, and
+// prunes the snapshots older than the retention.
+func (s *RouteStatsStore) write(ts time.Time, tables map[string]*pb.RouteTable) error {
+	tmp, err := ioutil.TempFile(s.dir, ".snapshot")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	gz := gzip.NewWriter(tmp)
+	if err := json.NewEncoder(gz).Encode(tables); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	// the rename makes the snapshot visible atomically to readers
+	path := filepath.Join(s.dir, strconv.FormatInt(ts.Unix(), 10)+snapshotSuffix)
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+
+	return s.prune(ts.Add(-s.retention))
+}
+
+// prune deletes the snapshots taken before the cutoff.
+func (s *RouteStatsStore) prune(cutoff time.Time) error {
+	timestamps, err := s.timestamps()
+	if err != nil {
+		return err
+	}
+	for _, ts := range timestamps {
+		if ts < cutoff.Unix() {
+			if err := os.Remove(s.path(ts)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// read returns the snapshots of the route stats of a service taken between
+// start and end, oldest first.
+func (s *RouteStatsStore) read(service string, start, end time.Time) ([]*pb.RouteStatsHistory_Snapshot, error) {
+	timestamps, err := s.timestamps()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]*pb.RouteStatsHistory_Snapshot, 0)
+	for _, ts := range timestamps {
+		if ts < start.Unix() || ts > end.Unix() {
+			continue
+		}
+
+		tables, err := s.readSnapshot(ts)
+		if err != nil {
+			if os.IsNotExist(err) {
+				// pruned since it was listed
+				continue
+			}
+			return nil, err
+		}
+		if table, ok := tables[service]; ok {
+			snapshots = append(snapshots, &pb.RouteStatsHistory_Snapshot{
+				Timestamp: ts,
+				Routes:    table,
+			})
+		}
+	}
+
+	return snapshots, nil
+}
+
+func (s *RouteStatsStore) readSnapshot(ts int64) (map[string]*pb.RouteTable, error) {
+	f, err := os.Open(s.path(ts))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer gz.Close()
+
+	var tables map[string]*pb.RouteTable
+	if err := json.NewDecoder(gz).Decode(&tables); err != nil {
+		return nil, err
+	}
+	return tables, nil
+}
+
+// timestamps lists the timestamps of the snapshots in the store, in
+// ascending order.
+func (s *RouteStatsStore) timestamps() ([]int64, error) {
+	files, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamps := make([]int64, 0)
+	for _, f := range files {
+		if !strings.HasSuffix(f.Name(), snapshotSuffix) {
+			continue
+		}
+		ts, err := strconv.ParseInt(strings.TrimSuffix(f.Name(), snapshotSuffix), 10, 64)
+		if err != nil {
+			continue
+		}
+		timestamps = append(timestamps, ts)
+	}
+	sort.Slice(timestamps, func(i, j int) bool { return timestamps[i] < timestamps[j] })
+
+	return timestamps, nil
+}
+
+func (s *RouteStatsStore) path(ts int64) string {
+	return filepath.Join(s.dir, strconv.FormatInt(ts, 10)+snapshotSuffix)
+}
+
+// RouteStatsRecorder periodically snapshots the inbound route stats of the
+// services with a ServiceProfile into a RouteStatsStore. Each snapshot covers
+// the interval since the previous one.
+type RouteStatsRecorder struct {
+	server   *grpcServer
+	store    *RouteStatsStore
+	interval time.Duration
+}
+
+// NewRouteStatsRecorder returns a recorder taking a snapshot every interval.
+func NewRouteStatsRecorder(
+	prometheusClient promApi.Client,
+	k8sAPI *k8s.API,
+	controllerNamespace string,
+	store *RouteStatsStore,
+	interval time.Duration,
+) *RouteStatsRecorder {
+	return &RouteStatsRecorder{
+		server:   newGrpcServer(promv1.NewAPI(prometheusClient), nil, k8sAPI, controllerNamespace, nil),
+		store:    store,
+		interval: interval,
+	}
+}
+
+// Run takes snapshots until stop is closed.
+func (r *RouteStatsRecorder) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			if err := r.snapshot(context.Background(), now); err != nil {
+				log.Errorf("failed to snapshot route stats: %s", err)
+			}
+		}
+	}
+}
+
+func (r *RouteStatsRecorder) snapshot(ctx context.Context, now time.Time) error {
+	profiles, err := r.server.k8sAPI.SP().Lister().ServiceProfiles(r.server.controllerNamespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	timeWindow := model.Duration(r.interval).String()
+	tables := make(map[string]*pb.RouteTable)
+	for _, profile := range profiles {
+		// ServiceProfiles are named after the FQDN of their service
+		parts := strings.Split(profile.Name, ".")
+		if len(parts) < 2 {
+			continue
+		}
+
+		table, err := r.server.getRouteMetrics(ctx, &pb.TopRoutesRequest{
+			Selector: &pb.ResourceSelection{
+				Resource: &pb.Resource{
+					Namespace: parts[1],
+					Type:      pkgK8s.Service,
+					Name:      parts[0],
+				},
+			},
+			TimeWindow: timeWindow,
+		})
+		if err != nil {
+			return err
+		}
+		tables[profile.Name] = table
+	}
+
+	return r.store.write(now, tables)
+}
+
+func (s *grpcServer) RouteStatsHistory(ctx context.Context, req *pb.RouteStatsHistoryRequest) (*pb.RouteStatsHistoryResponse, error) {
+	resource := req.GetSelector().GetResource()
+
+	// check for well-formed request
+	if resource == nil {
+		return routeStatsHistoryError(req, "RouteStatsHistory request missing Selector Resource"), nil
+	}
+	if resource.GetType() != pkgK8s.Service {
+		return routeStatsHistoryError(req, "route stats history is only recorded for services"), nil
+	}
+	if s.routeStatsStore == nil {
+		return routeStatsHistoryError(req, "route stats retention is not enabled"), nil
+	}
+
+	end := time.Now()
+	if req.GetEndTime() != 0 {
+		end = time.Unix(req.GetEndTime(), 0)
+	}
+	start := end.Add(-defaultHistoryRange)
+	if req.GetStartTime() != 0 {
+		start = time.Unix(req.GetStartTime(), 0)
+	}
+	if start.After(end) {
+		return routeStatsHistoryError(req, "the start time must be before the end time"), nil
+	}
+
+	service := fmt.Sprintf("%s.%s.svc.cluster.local", resource.GetName(), resource.GetNamespace())
+	snapshots, err := s.routeStatsStore.read(service, start, end)
+	if err != nil {
+		return nil, util.GRPCError(err)
+	}
+
+	return &pb.RouteStatsHistoryResponse{
+		Response: &pb.RouteStatsHistoryResponse_Ok{
+			Ok: &pb.RouteStatsHistory{
+				Snapshots: snapshots,
+			},
+		},
+	}, nil
+}
+
+func routeStatsHistoryError(req *pb.RouteStatsHistoryRequest, message string) *pb.RouteStatsHistoryResponse {
+	return &pb.RouteStatsHistoryResponse{
+		Response: &pb.RouteStatsHistoryResponse_Error{
+			Error: &pb.ResourceError{
+				Resource: req.GetSelector().GetResource(),
+				Error:    message,
+			},
+		},
+	}
+}
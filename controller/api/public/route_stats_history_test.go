@@ -0,0 +1,87 @@
+package public
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+)
+
+func TestRouteStatsStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "route-stats")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	store, err := NewRouteStatsStore(dir, 45*time.Minute)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	table := func(route string) *pb.RouteTable {
+		return &pb.RouteTable{
+			Rows: []*pb.RouteTable_Row{
+				{Route: route, TimeWindow: "10m", Stats: &pb.BasicStats{SuccessCount: 10}},
+			},
+		}
+	}
+
+	start := time.Unix(1000000, 0)
+	for i, route := range []string{"/a", "/b", "/c"} {
+		err := store.write(start.Add(time.Duration(i)*30*time.Minute), map[string]*pb.RouteTable{
+			"webapp.books.svc.cluster.local":  table(route),
+			"authors.books.svc.cluster.local": table("/authors"),
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	// the first snapshot is past the retention of the last one
+	snapshots, err := store.read("webapp.books.svc.cluster.local", start, start.Add(2*time.Hour))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []*pb.RouteStatsHistory_Snapshot{
+		{Timestamp: start.Add(30 * time.Minute).Unix(), Routes: table("/b")},
+		{Timestamp: start.Add(60 * time.Minute).Unix(), Routes: table("/c")},
+	}
+	if len(snapshots) != len(expected) {
+		t.Fatalf("Expected %d snapshots, got %d: %v", len(expected), len(snapshots), snapshots)
+	}
+	for i, snapshot := range snapshots {
+		if !proto.Equal(snapshot, expected[i]) {
+			t.Fatalf("Expected: %+v\n Got: %+v", expected[i], snapshot)
+		}
+	}
+}
+
+func TestRouteStatsHistory(t *testing.T) {
+	req := &pb.RouteStatsHistoryRequest{
+		Selector: &pb.ResourceSelection{
+			Resource: &pb.Resource{Namespace: "books", Type: pkgK8s.Service, Name: "webapp"},
+		},
+	}
+
+	_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRpc{})
+	if err != nil {
+		t.Fatalf("Error creating mock grpc server: %s", err)
+	}
+
+	rsp, err := fakeGrpcServer.RouteStatsHistory(context.TODO(), req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expectedErr := "route stats retention is not enabled"
+	if rsp.GetError().GetError() != expectedErr {
+		t.Fatalf("Expected error [%s], got [%s]", expectedErr, rsp.GetError().GetError())
+	}
+}
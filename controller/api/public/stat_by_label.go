@@ -0,0 +1,183 @@
+package public
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/prometheus/common/model"
+	apiv1 "k8s.io/api/core/v1"
+)
+
+const (
+	podReqQuery           = "sum(increase(response_total%s[%s])) by (namespace, pod, classification, tls)"
+	podLatencyBucketQuery = "sum(irate(response_latency_ms_bucket%s[%s])) by (le, namespace, pod)"
+)
+
+// labelStatSummary returns the stats of the pods of the requested resources,
+// grouped by namespace and by the value of the group_by_label pod label. As
+// the label isn't on the metrics, the stats are queried by pod, and the pods
+// are grouped by their label in Kubernetes; the latency quantiles of a group
+// are computed from the latency buckets of its pods.
+func (s *grpcServer) labelStatSummary(ctx context.Context, req *pb.StatSummaryRequest) (*pb.StatSummaryResponse, error) {
+	resource := req.GetSelector().GetResource()
+	switch {
+	case req.GetFromResource() != nil:
+		return statSummaryError(req, "grouping by label is not supported on 'from' queries"), nil
+	case resource.GetType() == k8s.All || isNonK8sResourceQuery(resource.GetType()):
+		return statSummaryError(req, fmt.Sprintf("resource type '%s' can't be grouped by label", resource.GetType())), nil
+	}
+
+	groups, err := s.getLabelGroups(req)
+	if err != nil {
+		return nil, util.GRPCError(err)
+	}
+
+	reqLabels, _ := buildRequestLabels(req)
+	results, err := s.runPromQueries(ctx, map[promType]string{
+		promRequests:       fmt.Sprintf(podReqQuery, reqLabels, req.TimeWindow),
+		promLatencyBuckets: fmt.Sprintf(podLatencyBucketQuery, reqLabels, req.TimeWindow),
+	})
+	if err != nil {
+		return nil, util.GRPCError(err)
+	}
+
+	basicStats := make(map[rKey]*pb.BasicStats)
+	histograms := make(map[rKey]*latencyHistogram)
+	for _, result := range results {
+		for _, sample := range result.vec {
+			pod := rKey{
+				Namespace: string(sample.Metric[namespaceLabel]),
+				Name:      string(sample.Metric[model.LabelName("pod")]),
+			}
+			// the metrics of the pods that are gone are ignored
+			group, ok := groups.podGroups[pod]
+			if !ok {
+				continue
+			}
+
+			if basicStats[group] == nil {
+				basicStats[group] = &pb.BasicStats{}
+			}
+			value := extractSampleValue(sample)
+
+			switch result.prom {
+			case promRequests:
+				switch string(sample.Metric[model.LabelName("classification")]) {
+				case "success":
+					basicStats[group].SuccessCount += value
+				case "failure":
+					basicStats[group].FailureCount += value
+				}
+				switch string(sample.Metric[model.LabelName("tls")]) {
+				case "true":
+					basicStats[group].TlsRequestCount += value
+				case "no_identity":
+					basicStats[group].UnmeshedRequestCount += value
+				}
+			case promLatencyBuckets:
+				if histograms[group] == nil {
+					histograms[group] = &latencyHistogram{}
+				}
+				histograms[group].add(sample)
+			}
+		}
+	}
+
+	rows := make([]*pb.StatTable_PodGroup_Row, 0)
+	for group, pods := range groups.pods {
+		stats := basicStats[group]
+		// with outbound filtering, only the groups with stats are returned,
+		// as for the resources
+		if stats == nil && req.GetOutbound() != nil && req.GetNone() == nil {
+			continue
+		}
+		if h := histograms[group]; h != nil {
+			stats.LatencyMsP50 = h.quantileValue(0.5)
+			stats.LatencyMsP95 = h.quantileValue(0.95)
+			stats.LatencyMsP99 = h.quantileValue(0.99)
+		}
+
+		podStat := s.podStatsOf(pods)
+		rows = append(rows, &pb.StatTable_PodGroup_Row{
+			Resource: &pb.Resource{
+				Namespace: group.Namespace,
+				Type:      resource.GetType(),
+			},
+			LabelValue:      group.Name,
+			TimeWindow:      req.TimeWindow,
+			Stats:           stats,
+			MeshedPodCount:  podStat.inMesh,
+			RunningPodCount: podStat.total,
+			FailedPodCount:  podStat.failed,
+			ErrorsByPod:     podStat.errors,
+		})
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Resource.Namespace != rows[j].Resource.Namespace {
+			return rows[i].Resource.Namespace < rows[j].Resource.Namespace
+		}
+		return rows[i].LabelValue < rows[j].LabelValue
+	})
+
+	return &pb.StatSummaryResponse{
+		Response: &pb.StatSummaryResponse_Ok_{
+			Ok: &pb.StatSummaryResponse_Ok{
+				StatTables: []*pb.StatTable{
+					{
+						Table: &pb.StatTable_PodGroup_{
+							PodGroup: &pb.StatTable_PodGroup{Rows: rows},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// labelGroups are the pods of the requested resources, grouped by namespace
+// and label value; the groups are keyed by rKeys whose name is the label
+// value.
+type labelGroups struct {
+	pods      map[rKey][]*apiv1.Pod
+	podGroups map[rKey]rKey
+}
+
+func (s *grpcServer) getLabelGroups(req *pb.StatSummaryRequest) (*labelGroups, error) {
+	resource := req.GetSelector().GetResource()
+	selector, err := labelSelector(req)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := s.k8sAPI.GetObjectsWithSelector(resource.GetNamespace(), resource.GetType(), resource.GetName(), selector)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := &labelGroups{
+		pods:      make(map[rKey][]*apiv1.Pod),
+		podGroups: make(map[rKey]rKey),
+	}
+	for _, object := range objects {
+		pods, err := s.k8sAPI.GetPodsFor(object, true)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pod := range pods {
+			key := rKey{Namespace: pod.Namespace, Name: pod.Name}
+			// a pod can belong to several of the resources
+			if _, ok := groups.podGroups[key]; ok {
+				continue
+			}
+			group := rKey{Namespace: pod.Namespace, Name: pod.Labels[req.GetGroupByLabel()]}
+			groups.podGroups[key] = group
+			groups.pods[group] = append(groups.pods[group], pod)
+		}
+	}
+	return groups, nil
+}
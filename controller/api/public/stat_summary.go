@@ -2,6 +2,11 @@ package public
 
 import (
 	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
 	proto "github.com/golang/protobuf/proto"
 	"github.com/linkerd/linkerd2/controller/api/util"
@@ -11,17 +16,22 @@ import (
 	apiv1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
 type resourceResult struct {
 	res *pb.StatTable
 	err error
+	// the page token of the rows following the table, if any
+	nextPageToken string
 }
 
-type k8sStat struct {
-	object   metav1.Object
-	podStats *podStats
+// k8sObject is a Kubernetes object with its metadata. The pod stats of the
+// objects are only computed for the rows of the response.
+type k8sObject struct {
+	object   runtime.Object
+	metadata metav1.Object
 }
 
 type rKey struct {
@@ -33,6 +43,10 @@ type rKey struct {
 const (
 	reqQuery             = "sum(increase(response_total%s[%s])) by (%s, classification, tls)"
 	latencyQuantileQuery = "histogram_quantile(%s, sum(irate(response_latency_ms_bucket%s[%s])) by (le, %s))"
+
+	tcpConnectionsQuery = "sum(tcp_open_connections%s) by (%s)"
+	tcpReadBytesQuery   = "sum(increase(tcp_read_bytes_total%s[%s])) by (%s)"
+	tcpWriteBytesQuery  = "sum(increase(tcp_write_bytes_total%s[%s])) by (%s)"
 )
 
 type podStats struct {
@@ -65,11 +79,53 @@ func (s *grpcServer) StatSummary(ctx context.Context, req *pb.StatSummaryRequest
 		}
 	}
 
+	if req.Selector.GetLabelSelector() != "" {
+		if isNonK8sResourceQuery(req.Selector.Resource.Type) {
+			return statSummaryError(req, "label selectors are not supported for authorities"), nil
+		}
+		if _, err := labelSelector(req); err != nil {
+			return statSummaryError(req, fmt.Sprintf("invalid label selector: %s", err)), nil
+		}
+	}
+
+	if req.GetLimit() > 0 || req.GetPageToken() != "" {
+		if req.Selector.Resource.Type == k8s.All {
+			return statSummaryError(req, "pagination is not supported for resource type 'all'"), nil
+		}
+		if req.GetGroupByLabel() != "" {
+			return statSummaryError(req, "pagination is not supported when grouping by label"), nil
+		}
+	}
+
+	if req.GetPodStats() {
+		switch req.Selector.Resource.Type {
+		case k8s.All, k8s.Pod, k8s.Authority:
+			return statSummaryError(req, fmt.Sprintf("pod stats are not supported for resource type '%s'", req.Selector.Resource.Type)), nil
+		}
+		if req.GetGroupByLabel() != "" {
+			return statSummaryError(req, "pod stats are not supported when grouping by label"), nil
+		}
+	}
+
+	if req.GetEndTime() != 0 {
+		ctx = withQueryTime(ctx, time.Unix(req.GetEndTime(), 0))
+	}
+
+	if req.GetGroupByLabel() != "" {
+		return s.labelStatSummary(ctx, req)
+	}
+
 	statTables := make([]*pb.StatTable, 0)
 
 	var resourcesToQuery []string
 	if req.Selector.Resource.Type == k8s.All {
-		resourcesToQuery = k8s.StatAllResourceTypes
+		for _, resource := range k8s.StatAllResourceTypes {
+			// authorities have no labels to select them by
+			if req.Selector.GetLabelSelector() != "" && isNonK8sResourceQuery(resource) {
+				continue
+			}
+			resourcesToQuery = append(resourcesToQuery, resource)
+		}
 	} else {
 		resourcesToQuery = []string{req.Selector.Resource.Type}
 	}
@@ -90,18 +146,22 @@ func (s *grpcServer) StatSummary(ctx context.Context, req *pb.StatSummaryRequest
 		}()
 	}
 
+	// only requests for a single resource type are paginated
+	nextPageToken := ""
 	for i := 0; i < len(resourcesToQuery); i++ {
 		result := <-resultChan
 		if result.err != nil {
 			return nil, util.GRPCError(result.err)
 		}
 		statTables = append(statTables, result.res)
+		nextPageToken = result.nextPageToken
 	}
 
 	rsp := pb.StatSummaryResponse{
 		Response: &pb.StatSummaryResponse_Ok_{ // https://github.com/golang/protobuf/issues/205
 			Ok: &pb.StatSummaryResponse_Ok{
-				StatTables: statTables,
+				StatTables:    statTables,
+				NextPageToken: nextPageToken,
 			},
 		},
 	}
@@ -128,14 +188,24 @@ func statSummaryError(req *pb.StatSummaryRequest, message string) *pb.StatSummar
 	}
 }
 
-func (s *grpcServer) getKubernetesObjectStats(req *pb.StatSummaryRequest) (map[rKey]k8sStat, error) {
+// labelSelector returns the selector of the labels of the requested resources,
+// which matches every resource if the request doesn't have one.
+func labelSelector(req *pb.StatSummaryRequest) (labels.Selector, error) {
+	return labels.Parse(req.GetSelector().GetLabelSelector())
+}
+
+func (s *grpcServer) getKubernetesObjects(req *pb.StatSummaryRequest) (map[rKey]k8sObject, error) {
 	requestedResource := req.GetSelector().GetResource()
-	objects, err := s.k8sAPI.GetObjects(requestedResource.Namespace, requestedResource.Type, requestedResource.Name)
+	selector, err := labelSelector(req)
+	if err != nil {
+		return nil, err
+	}
+	objects, err := s.k8sAPI.GetObjectsWithSelector(requestedResource.Namespace, requestedResource.Type, requestedResource.Name, selector)
 	if err != nil {
 		return nil, err
 	}
 
-	objectMap := map[rKey]k8sStat{}
+	objectMap := map[rKey]k8sObject{}
 
 	for _, object := range objects {
 		metaObj, err := meta.Accessor(object)
@@ -149,39 +219,83 @@ func (s *grpcServer) getKubernetesObjectStats(req *pb.StatSummaryRequest) (map[r
 			Type:      requestedResource.GetType(),
 		}
 
-		podStats, err := s.getPodStats(object)
-		if err != nil {
-			return nil, err
-		}
-
-		objectMap[key] = k8sStat{
-			object:   metaObj,
-			podStats: podStats,
+		objectMap[key] = k8sObject{
+			object:   object,
+			metadata: metaObj,
 		}
 	}
 	return objectMap, nil
 }
 
 func (s *grpcServer) k8sResourceQuery(ctx context.Context, req *pb.StatSummaryRequest) resourceResult {
-	k8sObjects, err := s.getKubernetesObjectStats(req)
+	k8sObjects, err := s.getKubernetesObjects(req)
 	if err != nil {
 		return resourceResult{res: nil, err: err}
 	}
 
-	requestMetrics, err := s.getStatMetrics(ctx, req, req.TimeWindow)
+	// the page of the objects is selected before querying their stats, so that
+	// the queries are restricted to the objects of the page
+	var page []rKey
+	nextPageToken := ""
+	if isPaginated(req) {
+		objectKeys := make([]rKey, 0, len(k8sObjects))
+		for key := range k8sObjects {
+			objectKeys = append(objectKeys, key)
+		}
+		page, nextPageToken = paginate(req, objectKeys)
+	}
+
+	requestMetrics, err := s.getStatMetrics(ctx, req, req.TimeWindow, page)
 	if err != nil {
 		return resourceResult{res: nil, err: err}
 	}
 
-	rows := make([]*pb.StatTable_PodGroup_Row, 0)
-	keys := getResultKeys(req, k8sObjects, requestMetrics)
+	tcpMetrics, err := s.getTcpStatMetrics(ctx, req, req.TimeWindow, page)
+	if err != nil {
+		return resourceResult{res: nil, err: err}
+	}
 
+	keys := []rKey{}
+	for _, key := range getResultKeys(req, k8sObjects, requestMetrics) {
+		if _, ok := k8sObjects[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	if page != nil {
+		keys = keysInPage(keys, page)
+	}
+
+	// the pod stats are restricted to the pods of the page as well
+	podsByKey := make(map[rKey][]*apiv1.Pod, len(keys))
+	var podPage []rKey
+	if page != nil {
+		podPage = []rKey{}
+	}
 	for _, key := range keys {
-		objInfo, ok := k8sObjects[key]
-		if !ok {
-			continue
+		pods, err := s.k8sAPI.GetPodsFor(k8sObjects[key].object, true)
+		if err != nil {
+			return resourceResult{res: nil, err: err}
 		}
-		k8sResource := objInfo.object
+		podsByKey[key] = pods
+		if page != nil {
+			for _, pod := range pods {
+				podPage = append(podPage, rKey{Namespace: pod.Namespace, Type: k8s.Pod, Name: pod.Name})
+			}
+		}
+	}
+
+	var podMetrics map[rKey]*pb.BasicStats
+	if req.GetPodStats() {
+		podMetrics, err = s.getStatMetrics(ctx, podStatsRequest(req), req.TimeWindow, podPage)
+		if err != nil {
+			return resourceResult{res: nil, err: err}
+		}
+	}
+
+	rows := make([]*pb.StatTable_PodGroup_Row, 0)
+	for _, key := range keys {
+		objInfo := k8sObjects[key]
+		k8sResource := objInfo.metadata
 		row := pb.StatTable_PodGroup_Row{
 			Resource: &pb.Resource{
 				Name:      k8sResource.GetName(),
@@ -190,14 +304,20 @@ func (s *grpcServer) k8sResourceQuery(ctx context.Context, req *pb.StatSummaryRe
 			},
 			TimeWindow: req.TimeWindow,
 			Stats:      requestMetrics[key],
+			TcpStats:   tcpMetrics[key],
 		}
 
-		podStat := objInfo.podStats
+		pods := podsByKey[key]
+		podStat := s.podStatsOf(pods)
 		row.MeshedPodCount = podStat.inMesh
 		row.RunningPodCount = podStat.total
 		row.FailedPodCount = podStat.failed
 		row.ErrorsByPod = podStat.errors
 
+		if req.GetPodStats() {
+			row.PodRows = s.podRows(pods, podMetrics, req.TimeWindow)
+		}
+
 		rows = append(rows, &row)
 	}
 
@@ -209,27 +329,32 @@ func (s *grpcServer) k8sResourceQuery(ctx context.Context, req *pb.StatSummaryRe
 		},
 	}
 
-	return resourceResult{res: &rsp, err: nil}
+	return resourceResult{res: &rsp, err: nil, nextPageToken: nextPageToken}
 }
 
 func (s *grpcServer) nonK8sResourceQuery(ctx context.Context, req *pb.StatSummaryRequest) resourceResult {
-	requestMetrics, err := s.getStatMetrics(ctx, req, req.TimeWindow)
+	requestMetrics, err := s.getStatMetrics(ctx, req, req.TimeWindow, nil)
 	if err != nil {
 		return resourceResult{res: nil, err: err}
 	}
-	rows := make([]*pb.StatTable_PodGroup_Row, 0)
-
-	for rkey, metrics := range requestMetrics {
-		rkey.Type = req.GetSelector().GetResource().GetType()
+	// authorities are only known from their stats, so their page is selected
+	// once they are queried
+	keys := []rKey{}
+	for rkey := range requestMetrics {
+		keys = append(keys, rkey)
+	}
+	keys, nextPageToken := paginate(req, keys)
 
+	rows := make([]*pb.StatTable_PodGroup_Row, 0)
+	for _, rkey := range keys {
 		row := pb.StatTable_PodGroup_Row{
 			Resource: &pb.Resource{
-				Type:      rkey.Type,
+				Type:      req.GetSelector().GetResource().GetType(),
 				Namespace: rkey.Namespace,
 				Name:      rkey.Name,
 			},
 			TimeWindow: req.TimeWindow,
-			Stats:      metrics,
+			Stats:      requestMetrics[rkey],
 		}
 		rows = append(rows, &row)
 	}
@@ -241,7 +366,7 @@ func (s *grpcServer) nonK8sResourceQuery(ctx context.Context, req *pb.StatSummar
 			},
 		},
 	}
-	return resourceResult{res: &rsp, err: nil}
+	return resourceResult{res: &rsp, err: nil, nextPageToken: nextPageToken}
 }
 
 func isNonK8sResourceQuery(resourceType string) bool {
@@ -251,7 +376,7 @@ func isNonK8sResourceQuery(resourceType string) bool {
 // get the list of objects for which we want to return results
 func getResultKeys(
 	req *pb.StatSummaryRequest,
-	k8sObjects map[rKey]k8sStat,
+	k8sObjects map[rKey]k8sObject,
 	metricResults map[rKey]*pb.BasicStats,
 ) []rKey {
 	var keys []rKey
@@ -271,6 +396,99 @@ func getResultKeys(
 	return keys
 }
 
+// paginate returns the page of keys requested: the keys following the page
+// token of the request, up to its limit, in the order of their page tokens. It
+// also returns the page token of the following keys, if any remain.
+func paginate(req *pb.StatSummaryRequest, keys []rKey) ([]rKey, string) {
+	if !isPaginated(req) {
+		return keys, ""
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return pageToken(keys[i]) < pageToken(keys[j])
+	})
+	start := sort.Search(len(keys), func(i int) bool {
+		return pageToken(keys[i]) > req.GetPageToken()
+	})
+	keys = keys[start:]
+
+	limit := int(req.GetLimit())
+	if limit == 0 || len(keys) <= limit {
+		return keys, ""
+	}
+	return keys[:limit], pageToken(keys[limit-1])
+}
+
+func isPaginated(req *pb.StatSummaryRequest) bool {
+	return req.GetLimit() != 0 || req.GetPageToken() != ""
+}
+
+// keysInPage returns the keys of the page that are also in keys, in the order
+// of the page.
+func keysInPage(keys []rKey, page []rKey) []rKey {
+	found := make(map[rKey]bool, len(keys))
+	for _, key := range keys {
+		found[key] = true
+	}
+	inPage := make([]rKey, 0, len(page))
+	for _, key := range page {
+		if found[key] {
+			inPage = append(inPage, key)
+		}
+	}
+	return inPage
+}
+
+// pageSelector returns the Prometheus label selector of labels, further
+// restricted to the names and namespaces of the resources of the page, unless
+// the page is nil. As groupBy is ordered (..., namespace, name) like in
+// metricToKey, its last label is the name of the resources.
+func pageSelector(labels model.LabelSet, groupBy model.LabelNames, page []rKey) string {
+	if page == nil {
+		return labels.String()
+	}
+
+	matchers := make([]string, 0, len(labels)+len(groupBy))
+	for name, value := range labels {
+		matchers = append(matchers, fmt.Sprintf("%s=%q", name, value))
+	}
+
+	names := make([]string, 0, len(page))
+	namespaces := make([]string, 0, len(page))
+	for _, key := range page {
+		names = append(names, key.Name)
+		namespaces = append(namespaces, key.Namespace)
+	}
+	matchers = append(matchers, regexMatcher(groupBy[len(groupBy)-1], names))
+	if len(groupBy) == 2 {
+		matchers = append(matchers, regexMatcher(groupBy[0], namespaces))
+	}
+
+	sort.Strings(matchers)
+	return "{" + strings.Join(matchers, ", ") + "}"
+}
+
+// regexMatcher returns the Prometheus label matcher of the label taking any
+// of the values.
+func regexMatcher(label model.LabelName, values []string) string {
+	seen := make(map[string]bool, len(values))
+	alternatives := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			alternatives = append(alternatives, regexp.QuoteMeta(value))
+		}
+	}
+	sort.Strings(alternatives)
+	return fmt.Sprintf("%s=~%q", label, strings.Join(alternatives, "|"))
+}
+
+// pageToken is the page token of the keys following key. As a key is only
+// compared with the keys of the same resource type, its type is left out.
+func pageToken(key rKey) string {
+	return key.Namespace + "/" + key.Name
+}
+
 func buildRequestLabels(req *pb.StatSummaryRequest) (labels model.LabelSet, labelNames model.LabelNames) {
 	// labelNames: the group by in the prometheus query
 	// labels: the labels for the resource we want to query for
@@ -300,9 +518,15 @@ func buildRequestLabels(req *pb.StatSummaryRequest) (labels model.LabelSet, labe
 	return
 }
 
-func (s *grpcServer) getStatMetrics(ctx context.Context, req *pb.StatSummaryRequest, timeWindow string) (map[rKey]*pb.BasicStats, error) {
+// getStatMetrics returns the request stats of the resources, restricted to
+// the resources of the page unless it is nil.
+func (s *grpcServer) getStatMetrics(ctx context.Context, req *pb.StatSummaryRequest, timeWindow string, page []rKey) (map[rKey]*pb.BasicStats, error) {
+	if page != nil && len(page) == 0 {
+		return map[rKey]*pb.BasicStats{}, nil
+	}
+
 	reqLabels, groupBy := buildRequestLabels(req)
-	results, err := s.getPrometheusMetrics(ctx, reqQuery, latencyQuantileQuery, reqLabels.String(), timeWindow, groupBy.String())
+	results, err := s.getPrometheusMetrics(ctx, reqQuery, latencyQuantileQuery, pageSelector(reqLabels, groupBy, page), timeWindow, groupBy.String())
 
 	if err != nil {
 		return nil, err
@@ -335,6 +559,8 @@ func processPrometheusMetrics(req *pb.StatSummaryRequest, results []promResult,
 				switch string(sample.Metric[model.LabelName("tls")]) {
 				case "true":
 					basicStats[resource].TlsRequestCount += value
+				case "no_identity":
+					basicStats[resource].UnmeshedRequestCount += value
 				}
 			case promLatencyP50:
 				basicStats[resource].LatencyMsP50 = value
@@ -349,6 +575,58 @@ func processPrometheusMetrics(req *pb.StatSummaryRequest, results []promResult,
 	return basicStats
 }
 
+// getTcpStatMetrics returns the TCP connection stats of the resources, or nil
+// if the request didn't ask for them. The inbound stats are those of the
+// connections accepted by the proxies, and the outbound stats those of the
+// connections they opened. The stats are restricted to the resources of the
+// page unless it is nil.
+func (s *grpcServer) getTcpStatMetrics(ctx context.Context, req *pb.StatSummaryRequest, timeWindow string, page []rKey) (map[rKey]*pb.TcpStats, error) {
+	if !req.GetTcpStats() || page != nil && len(page) == 0 {
+		return nil, nil
+	}
+
+	reqLabels, groupBy := buildRequestLabels(req)
+	peer := "src"
+	if req.GetToResource() != nil || req.GetFromResource() != nil {
+		peer = "dst"
+	}
+	reqLabels = reqLabels.Merge(model.LabelSet{"peer": model.LabelValue(peer)})
+
+	results, err := s.getTcpMetrics(ctx, pageSelector(reqLabels, groupBy, page), timeWindow, groupBy.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return processTcpMetrics(req, results, groupBy), nil
+}
+
+func processTcpMetrics(req *pb.StatSummaryRequest, results []promResult, groupBy model.LabelNames) map[rKey]*pb.TcpStats {
+	tcpStats := make(map[rKey]*pb.TcpStats)
+
+	for _, result := range results {
+		for _, sample := range result.vec {
+			resource := metricToKey(req, sample.Metric, groupBy)
+
+			if tcpStats[resource] == nil {
+				tcpStats[resource] = &pb.TcpStats{}
+			}
+
+			value := extractSampleValue(sample)
+
+			switch result.prom {
+			case promTcpConnections:
+				tcpStats[resource].OpenConnections = value
+			case promTcpReadBytes:
+				tcpStats[resource].ReadBytes = value
+			case promTcpWriteBytes:
+				tcpStats[resource].WriteBytes = value
+			}
+		}
+	}
+
+	return tcpStats
+}
+
 func metricToKey(req *pb.StatSummaryRequest, metric model.Metric, groupBy model.LabelNames) rKey {
 	// this key is used to match the metric stats we queried from prometheus
 	// with the k8s object stats we queried from k8s
@@ -365,11 +643,59 @@ func metricToKey(req *pb.StatSummaryRequest, metric model.Metric, groupBy model.
 	return key
 }
 
-func (s *grpcServer) getPodStats(obj runtime.Object) (*podStats, error) {
-	pods, err := s.k8sAPI.GetPodsFor(obj, true)
-	if err != nil {
-		return nil, err
+// podStatsRequest is the request of the stats of the pods of the namespace of
+// the resources of req, with the same outbound filter.
+func podStatsRequest(req *pb.StatSummaryRequest) *pb.StatSummaryRequest {
+	resource := req.GetSelector().GetResource()
+	namespace := resource.GetNamespace()
+	if resource.GetType() == k8s.Namespace {
+		namespace = resource.GetName()
 	}
+
+	podReq := proto.Clone(req).(*pb.StatSummaryRequest)
+	podReq.Selector = &pb.ResourceSelection{
+		Resource: &pb.Resource{
+			Namespace: namespace,
+			Type:      k8s.Pod,
+		},
+	}
+	return podReq
+}
+
+// podRows returns a row for each of the pending or running pods, with the pod
+// stats of podMetrics.
+func (s *grpcServer) podRows(pods []*apiv1.Pod, podMetrics map[rKey]*pb.BasicStats, timeWindow string) []*pb.StatTable_PodGroup_Row {
+	rows := make([]*pb.StatTable_PodGroup_Row, 0)
+	for _, pod := range pods {
+		if pod.Status.Phase == apiv1.PodFailed {
+			continue
+		}
+
+		key := rKey{Namespace: pod.Namespace, Type: k8s.Pod, Name: pod.Name}
+		podStat := s.podStatsOf([]*apiv1.Pod{pod})
+		rows = append(rows, &pb.StatTable_PodGroup_Row{
+			Resource: &pb.Resource{
+				Namespace: pod.Namespace,
+				Type:      k8s.Pod,
+				Name:      pod.Name,
+			},
+			TimeWindow:      timeWindow,
+			MeshedPodCount:  podStat.inMesh,
+			RunningPodCount: podStat.total,
+			ErrorsByPod:     podStat.errors,
+			Stats:           podMetrics[key],
+		})
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].Resource.Name < rows[j].Resource.Name
+	})
+	return rows
+}
+
+// podStatsOf counts the meshed, running and failed pods, and collects their
+// container errors.
+func (s *grpcServer) podStatsOf(pods []*apiv1.Pod) *podStats {
 	podErrors := make(map[string]*pb.PodErrors)
 	meshCount := &podStats{}
 
@@ -391,7 +717,7 @@ func (s *grpcServer) getPodStats(obj runtime.Object) (*podStats, error) {
 		}
 	}
 	meshCount.errors = podErrors
-	return meshCount, nil
+	return meshCount
 }
 
 func toPodError(container, image, reason, message string) *pb.PodErrors_PodError {
@@ -3,6 +3,7 @@ package public
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sort"
 	"testing"
 
@@ -75,7 +76,9 @@ func testStatSummary(t *testing.T, expectations []statSumExpected) {
 			t.Fatalf("Expected error: %s, Got: %s", exp.err, err)
 		}
 
-		exp.verifyPromQueries(mockProm)
+		if err := exp.verifyPromQueries(mockProm); err != nil {
+			t.Fatal(err)
+		}
 
 		rspStatTables := rsp.GetOk().StatTables
 
@@ -91,7 +94,8 @@ func testStatSummary(t *testing.T, expectations []statSumExpected) {
 
 		sort.Sort(byStatResult(rspStatTables))
 		statOkRsp := &pb.StatSummaryResponse_Ok{
-			StatTables: rspStatTables,
+			StatTables:    rspStatTables,
+			NextPageToken: rsp.GetOk().GetNextPageToken(),
 		}
 
 		for i, st := range rspStatTables {
@@ -206,6 +210,351 @@ status:
 		testStatSummary(t, expectations)
 	})
 
+	t.Run("Counts the requests of unmeshed clients", func(t *testing.T) {
+		expectedResponse := GenStatSummaryResponse("emoji", pkgK8s.Deployment, []string{"emojivoto"}, &PodCounts{
+			MeshedPods:  1,
+			RunningPods: 1,
+			FailedPods:  0,
+		})
+		expectedResponse.GetOk().StatTables[0].GetPodGroup().Rows[0].Stats.SuccessCount = 246
+		expectedResponse.GetOk().StatTables[0].GetPodGroup().Rows[0].Stats.UnmeshedRequestCount = 123
+
+		unmeshed := genPromSample("emoji", "deployment", "emojivoto", "success", false)
+		unmeshed.Metric["tls"] = "no_identity"
+
+		expectations := []statSumExpected{
+			statSumExpected{
+				expectedStatRpc: expectedStatRpc{
+					err: nil,
+					k8sConfigs: []string{`
+apiVersion: apps/v1beta2
+kind: Deployment
+metadata:
+  name: emoji
+  namespace: emojivoto
+spec:
+  selector:
+    matchLabels:
+      app: emoji-svc
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-meshed
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`,
+					},
+					mockPromResponse: model.Vector{
+						genPromSample("emoji", "deployment", "emojivoto", "success", false),
+						unmeshed,
+					},
+				},
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Deployment,
+						},
+					},
+					TimeWindow: "1m",
+				},
+				expectedResponse: expectedResponse,
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
+	t.Run("Successfully performs a query for jobs and cronjobs", func(t *testing.T) {
+		expectations := []statSumExpected{
+			statSumExpected{
+				expectedStatRpc: expectedStatRpc{
+					err: nil,
+					k8sConfigs: []string{`
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: backup
+  namespace: batch
+  uid: backup-uid
+spec:
+  selector:
+    matchLabels:
+      controller-uid: backup-uid
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: backup-x8vzj
+  namespace: batch
+  labels:
+    controller-uid: backup-uid
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`,
+					},
+					mockPromResponse: prometheusMetric("backup", "k8s_job", "batch", "success", false),
+				},
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "batch",
+							Type:      pkgK8s.Job,
+						},
+					},
+					TimeWindow: "1m",
+				},
+				expectedResponse: GenStatSummaryResponse("backup", pkgK8s.Job, []string{"batch"}, &PodCounts{
+					MeshedPods:  1,
+					RunningPods: 1,
+					FailedPods:  0,
+				}),
+			},
+			statSumExpected{
+				expectedStatRpc: expectedStatRpc{
+					err: nil,
+					k8sConfigs: []string{`
+apiVersion: batch/v1beta1
+kind: CronJob
+metadata:
+  name: report
+  namespace: batch
+  uid: report-uid
+`, `
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: report-1545000000
+  namespace: batch
+  uid: report-1545000000-uid
+  ownerReferences:
+  - apiVersion: batch/v1beta1
+    kind: CronJob
+    name: report
+    uid: report-uid
+spec:
+  selector:
+    matchLabels:
+      controller-uid: report-1545000000-uid
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: report-1545000000-jl2vx
+  namespace: batch
+  labels:
+    controller-uid: report-1545000000-uid
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: report-1545000000-5kq8d
+  namespace: batch
+  labels:
+    controller-uid: report-1545000000-uid
+status:
+  phase: Failed
+`,
+					},
+					mockPromResponse: prometheusMetric("report", "cronjob", "batch", "success", false),
+				},
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "batch",
+							Type:      pkgK8s.CronJob,
+						},
+					},
+					TimeWindow: "1m",
+				},
+				expectedResponse: GenStatSummaryResponse("report", pkgK8s.CronJob, []string{"batch"}, &PodCounts{
+					MeshedPods:  1,
+					RunningPods: 1,
+					FailedPods:  1,
+				}),
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
+	t.Run("Only queries the resources matching the label selector", func(t *testing.T) {
+		expectations := []statSumExpected{
+			statSumExpected{
+				expectedStatRpc: expectedStatRpc{
+					err: nil,
+					k8sConfigs: []string{`
+apiVersion: apps/v1beta2
+kind: Deployment
+metadata:
+  name: emoji
+  namespace: emojivoto
+  labels:
+    app: web
+    tier: frontend
+spec:
+  selector:
+    matchLabels:
+      app: emoji-svc
+`, `
+apiVersion: apps/v1beta2
+kind: Deployment
+metadata:
+  name: voting
+  namespace: emojivoto
+  labels:
+    app: web
+    tier: backend
+spec:
+  selector:
+    matchLabels:
+      app: voting-svc
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-meshed
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: voting-meshed
+  namespace: emojivoto
+  labels:
+    app: voting-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`,
+					},
+					mockPromResponse: prometheusMetric("emoji", "deployment", "emojivoto", "success", false),
+				},
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Deployment,
+						},
+						LabelSelector: "app=web,tier=frontend",
+					},
+					TimeWindow: "1m",
+				},
+				expectedResponse: GenStatSummaryResponse("emoji", pkgK8s.Deployment, []string{"emojivoto"}, &PodCounts{
+					MeshedPods:  1,
+					RunningPods: 1,
+					FailedPods:  0,
+				}),
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
+	t.Run("Returns the rows of the pods of the resources if requested", func(t *testing.T) {
+		expectedResponse := GenStatSummaryResponse("emoji", pkgK8s.Deployment, []string{"emojivoto"}, &PodCounts{
+			MeshedPods:  1,
+			RunningPods: 2,
+			FailedPods:  1,
+		})
+		meshedRsp := GenStatSummaryResponse("emojivoto-meshed", pkgK8s.Pod, []string{"emojivoto"}, &PodCounts{
+			MeshedPods:  1,
+			RunningPods: 1,
+		})
+		notMeshedRsp := GenStatSummaryResponse("emojivoto-not-meshed", pkgK8s.Pod, []string{"emojivoto"}, &PodCounts{
+			RunningPods: 1,
+		})
+		notMeshedRow := notMeshedRsp.GetOk().StatTables[0].GetPodGroup().Rows[0]
+		notMeshedRow.Stats = nil
+		expectedResponse.GetOk().StatTables[0].GetPodGroup().Rows[0].PodRows = []*pb.StatTable_PodGroup_Row{
+			meshedRsp.GetOk().StatTables[0].GetPodGroup().Rows[0],
+			notMeshedRow,
+		}
+
+		expectations := []statSumExpected{
+			statSumExpected{
+				expectedStatRpc: expectedStatRpc{
+					err: nil,
+					k8sConfigs: []string{`
+apiVersion: apps/v1beta2
+kind: Deployment
+metadata:
+  name: emoji
+  namespace: emojivoto
+spec:
+  selector:
+    matchLabels:
+      app: emoji-svc
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-meshed
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-not-meshed
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+status:
+  phase: Running
+`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-failed
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Failed
+`,
+					},
+					mockPromResponse: model.Vector{
+						genPromSample("emoji", "deployment", "emojivoto", "success", false),
+						genPromSample("emojivoto-meshed", "pod", "emojivoto", "success", false),
+					},
+				},
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Deployment,
+						},
+					},
+					TimeWindow: "1m",
+					PodStats:   true,
+				},
+				expectedResponse: expectedResponse,
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
 	t.Run("Queries prometheus for a specific resource if name is specified", func(t *testing.T) {
 		expectations := []statSumExpected{
 			statSumExpected{
@@ -253,6 +602,64 @@ status:
 		testStatSummary(t, expectations)
 	})
 
+	t.Run("Queries prometheus for TCP stats if requested", func(t *testing.T) {
+		expectedResponse := GenStatSummaryResponse("emojivoto-1", pkgK8s.Pod, []string{"emojivoto"}, &PodCounts{
+			MeshedPods:  1,
+			RunningPods: 1,
+			FailedPods:  0,
+		})
+		expectedResponse.GetOk().StatTables[0].GetPodGroup().Rows[0].TcpStats = &pb.TcpStats{
+			OpenConnections: 123,
+			ReadBytes:       123,
+			WriteBytes:      123,
+		}
+
+		expectations := []statSumExpected{
+			statSumExpected{
+				expectedStatRpc: expectedStatRpc{
+					err: nil,
+					k8sConfigs: []string{`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-1
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`,
+					},
+					mockPromResponse: prometheusMetric("emojivoto-1", "pod", "emojivoto", "success", false),
+					expectedPrometheusQueries: []string{
+						`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", pod="emojivoto-1"}[1m])) by (le, namespace, pod))`,
+						`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", pod="emojivoto-1"}[1m])) by (le, namespace, pod))`,
+						`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{direction="inbound", namespace="emojivoto", pod="emojivoto-1"}[1m])) by (le, namespace, pod))`,
+						`sum(increase(response_total{direction="inbound", namespace="emojivoto", pod="emojivoto-1"}[1m])) by (namespace, pod, classification, tls)`,
+						`sum(tcp_open_connections{direction="inbound", namespace="emojivoto", peer="src", pod="emojivoto-1"}) by (namespace, pod)`,
+						`sum(increase(tcp_read_bytes_total{direction="inbound", namespace="emojivoto", peer="src", pod="emojivoto-1"}[1m])) by (namespace, pod)`,
+						`sum(increase(tcp_write_bytes_total{direction="inbound", namespace="emojivoto", peer="src", pod="emojivoto-1"}[1m])) by (namespace, pod)`,
+					},
+				},
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Name:      "emojivoto-1",
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Pod,
+						},
+					},
+					TimeWindow: "1m",
+					TcpStats:   true,
+				},
+				expectedResponse: expectedResponse,
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
 	t.Run("Queries prometheus for outbound metrics if from resource is specified, ignores resource name", func(t *testing.T) {
 		expectations := []statSumExpected{
 			statSumExpected{
@@ -448,10 +855,10 @@ status:
 						genPromSample("emojivoto-1", "pod", "emojivoto", "success", true),
 					},
 					expectedPrometheusQueries: []string{
-						`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="outbound", pod="emojivoto-2"}[1m])) by (le, dst_namespace, dst_pod))`,
-						`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="outbound", pod="emojivoto-2"}[1m])) by (le, dst_namespace, dst_pod))`,
-						`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{direction="outbound", pod="emojivoto-2"}[1m])) by (le, dst_namespace, dst_pod))`,
-						`sum(increase(response_total{direction="outbound", pod="emojivoto-2"}[1m])) by (dst_namespace, dst_pod, classification, tls)`,
+						`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="emojivoto", pod="emojivoto-2"}[1m])) by (le, dst_namespace, dst_pod))`,
+						`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="emojivoto", pod="emojivoto-2"}[1m])) by (le, dst_namespace, dst_pod))`,
+						`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="emojivoto", pod="emojivoto-2"}[1m])) by (le, dst_namespace, dst_pod))`,
+						`sum(increase(response_total{direction="outbound", dst_namespace="emojivoto", pod="emojivoto-2"}[1m])) by (dst_namespace, dst_pod, classification, tls)`,
 					},
 				},
 				req: pb.StatSummaryRequest{
@@ -549,6 +956,114 @@ status:
 		testStatSummary(t, expectations)
 	})
 
+	t.Run("Queries prometheus for outbound metrics if only a --from-namespace is specified", func(t *testing.T) {
+		expectations := []statSumExpected{
+			statSumExpected{
+				expectedStatRpc: expectedStatRpc{
+					err: nil,
+					k8sConfigs: []string{`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-1
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`,
+					},
+					mockPromResponse: model.Vector{
+						genPromSample("emojivoto-1", "pod", "emojivoto", "success", true),
+					},
+					expectedPrometheusQueries: []string{
+						`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="emojivoto", namespace="vote"}[1m])) by (le, dst_namespace, dst_pod))`,
+						`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="emojivoto", namespace="vote"}[1m])) by (le, dst_namespace, dst_pod))`,
+						`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="emojivoto", namespace="vote"}[1m])) by (le, dst_namespace, dst_pod))`,
+						`sum(increase(response_total{direction="outbound", dst_namespace="emojivoto", namespace="vote"}[1m])) by (dst_namespace, dst_pod, classification, tls)`,
+					},
+				},
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Pod,
+						},
+					},
+					TimeWindow: "1m",
+					Outbound: &pb.StatSummaryRequest_FromResource{
+						FromResource: &pb.Resource{
+							Name:      "vote",
+							Namespace: "vote",
+							Type:      pkgK8s.Namespace,
+						},
+					},
+				},
+				expectedResponse: GenStatSummaryResponse("emojivoto-1", pkgK8s.Pod, []string{"emojivoto"}, &PodCounts{
+					MeshedPods:  1,
+					RunningPods: 1,
+					FailedPods:  0,
+				}),
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
+	t.Run("Queries prometheus for outbound metrics if only a --to-namespace is specified", func(t *testing.T) {
+		expectations := []statSumExpected{
+			statSumExpected{
+				expectedStatRpc: expectedStatRpc{
+					err: nil,
+					k8sConfigs: []string{`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-1
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`,
+					},
+					mockPromResponse: prometheusMetric("emojivoto-1", "pod", "emojivoto", "success", false),
+					expectedPrometheusQueries: []string{
+						`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="vote", namespace="emojivoto"}[1m])) by (le, namespace, pod))`,
+						`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="vote", namespace="emojivoto"}[1m])) by (le, namespace, pod))`,
+						`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{direction="outbound", dst_namespace="vote", namespace="emojivoto"}[1m])) by (le, namespace, pod))`,
+						`sum(increase(response_total{direction="outbound", dst_namespace="vote", namespace="emojivoto"}[1m])) by (namespace, pod, classification, tls)`,
+					},
+				},
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Pod,
+						},
+					},
+					TimeWindow: "1m",
+					Outbound: &pb.StatSummaryRequest_ToResource{
+						ToResource: &pb.Resource{
+							Name:      "vote",
+							Namespace: "vote",
+							Type:      pkgK8s.Namespace,
+						},
+					},
+				},
+				expectedResponse: GenStatSummaryResponse("emojivoto-1", pkgK8s.Pod, []string{"emojivoto"}, &PodCounts{
+					MeshedPods:  1,
+					RunningPods: 1,
+					FailedPods:  0,
+				}),
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
 	t.Run("Successfully queries for resource type 'all'", func(t *testing.T) {
 		expectations := []statSumExpected{
 			statSumExpected{
@@ -721,6 +1236,204 @@ status:
 		testStatSummary(t, expectations)
 	})
 
+	t.Run("Groups the stats of the pods by label", func(t *testing.T) {
+		podSample := func(pod string, labels model.Metric, value model.SampleValue) *model.Sample {
+			metric := model.Metric{"namespace": "emojivoto", "pod": model.LabelValue(pod)}
+			for name, value := range labels {
+				metric[name] = value
+			}
+			return &model.Sample{Metric: metric, Value: value, Timestamp: 456}
+		}
+		pod := func(name, version string) string {
+			return fmt.Sprintf(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: %s
+  namespace: emojivoto
+  labels:
+    app: emoji-svc
+    version: %s
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`, name, version)
+		}
+
+		expectations := []statSumExpected{
+			statSumExpected{
+				expectedStatRpc: expectedStatRpc{
+					err: nil,
+					k8sConfigs: []string{`
+apiVersion: apps/v1beta2
+kind: Deployment
+metadata:
+  name: emoji
+  namespace: emojivoto
+spec:
+  selector:
+    matchLabels:
+      app: emoji-svc
+  strategy: {}
+  template:
+    spec:
+      containers:
+      - image: buoyantio/emojivoto-emoji-svc:v3
+`, pod("emoji-stable-1", "stable"), pod("emoji-stable-2", "stable"), pod("emoji-canary", "canary"),
+					},
+					mockPromResponse: model.Vector{
+						podSample("emoji-stable-1", model.Metric{"classification": "success", "tls": "true"}, 60),
+						podSample("emoji-stable-2", model.Metric{"classification": "success", "tls": "true"}, 40),
+						podSample("emoji-canary", model.Metric{"classification": "failure", "tls": "false"}, 10),
+						podSample("emoji-stable-1", model.Metric{"le": "10"}, 1),
+						podSample("emoji-stable-2", model.Metric{"le": "10"}, 1),
+						podSample("emoji-stable-1", model.Metric{"le": "+Inf"}, 1),
+						podSample("emoji-stable-2", model.Metric{"le": "+Inf"}, 1),
+						podSample("emoji-canary", model.Metric{"le": "10"}, 0),
+						podSample("emoji-canary", model.Metric{"le": "+Inf"}, 1),
+						podSample("emoji-gone", model.Metric{"classification": "success", "tls": "true"}, 5),
+					},
+					expectedPrometheusQueries: []string{
+						`sum(increase(response_total{deployment="emoji", direction="inbound", namespace="emojivoto"}[1m])) by (namespace, pod, classification, tls)`,
+						`sum(irate(response_latency_ms_bucket{deployment="emoji", direction="inbound", namespace="emojivoto"}[1m])) by (le, namespace, pod)`,
+					},
+				},
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "emojivoto",
+							Type:      pkgK8s.Deployment,
+							Name:      "emoji",
+						},
+					},
+					TimeWindow:   "1m",
+					GroupByLabel: "version",
+				},
+				expectedResponse: pb.StatSummaryResponse{
+					Response: &pb.StatSummaryResponse_Ok_{
+						Ok: &pb.StatSummaryResponse_Ok{
+							StatTables: []*pb.StatTable{
+								&pb.StatTable{
+									Table: &pb.StatTable_PodGroup_{
+										PodGroup: &pb.StatTable_PodGroup{
+											Rows: []*pb.StatTable_PodGroup_Row{
+												&pb.StatTable_PodGroup_Row{
+													Resource:        &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.Deployment},
+													LabelValue:      "canary",
+													TimeWindow:      "1m",
+													MeshedPodCount:  1,
+													RunningPodCount: 1,
+													ErrorsByPod:     map[string]*pb.PodErrors{},
+													Stats: &pb.BasicStats{
+														FailureCount: 10,
+														LatencyMsP50: 10,
+														LatencyMsP95: 10,
+														LatencyMsP99: 10,
+													},
+												},
+												&pb.StatTable_PodGroup_Row{
+													Resource:        &pb.Resource{Namespace: "emojivoto", Type: pkgK8s.Deployment},
+													LabelValue:      "stable",
+													TimeWindow:      "1m",
+													MeshedPodCount:  2,
+													RunningPodCount: 2,
+													ErrorsByPod:     map[string]*pb.PodErrors{},
+													Stats: &pb.BasicStats{
+														SuccessCount:    100,
+														TlsRequestCount: 100,
+														LatencyMsP50:    5,
+														LatencyMsP95:    10,
+														LatencyMsP99:    10,
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
+	t.Run("Paginates the rows if requested", func(t *testing.T) {
+		pods := []string{}
+		samples := model.Vector{}
+		for _, ns := range []string{"emojivoto1", "emojivoto2", "emojivoto3"} {
+			pods = append(pods, fmt.Sprintf(`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: emojivoto-1
+  namespace: %s
+  labels:
+    linkerd.io/control-plane-ns: linkerd
+status:
+  phase: Running
+`, ns))
+			samples = append(samples, genPromSample("emojivoto-1", "pod", ns, "success", false))
+		}
+		counts := &PodCounts{
+			MeshedPods:  1,
+			RunningPods: 1,
+			FailedPods:  0,
+		}
+
+		firstPage := GenStatSummaryResponse("emojivoto-1", pkgK8s.Pod, []string{"emojivoto1", "emojivoto2"}, counts)
+		firstPage.GetOk().NextPageToken = "emojivoto2/emojivoto-1"
+		lastPage := GenStatSummaryResponse("emojivoto-1", pkgK8s.Pod, []string{"emojivoto3"}, counts)
+
+		expectations := []statSumExpected{
+			statSumExpected{
+				expectedStatRpc: expectedStatRpc{
+					err:              nil,
+					k8sConfigs:       pods,
+					mockPromResponse: samples,
+					expectedPrometheusQueries: []string{
+						`histogram_quantile(0.5, sum(irate(response_latency_ms_bucket{direction="inbound", namespace=~"emojivoto1|emojivoto2", pod=~"emojivoto-1"}[1m])) by (le, namespace, pod))`,
+						`histogram_quantile(0.95, sum(irate(response_latency_ms_bucket{direction="inbound", namespace=~"emojivoto1|emojivoto2", pod=~"emojivoto-1"}[1m])) by (le, namespace, pod))`,
+						`histogram_quantile(0.99, sum(irate(response_latency_ms_bucket{direction="inbound", namespace=~"emojivoto1|emojivoto2", pod=~"emojivoto-1"}[1m])) by (le, namespace, pod))`,
+						`sum(increase(response_total{direction="inbound", namespace=~"emojivoto1|emojivoto2", pod=~"emojivoto-1"}[1m])) by (namespace, pod, classification, tls)`,
+					},
+				},
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Type: pkgK8s.Pod,
+						},
+					},
+					TimeWindow: "1m",
+					Limit:      2,
+				},
+				expectedResponse: firstPage,
+			},
+			statSumExpected{
+				expectedStatRpc: expectedStatRpc{
+					err:              nil,
+					k8sConfigs:       pods,
+					mockPromResponse: samples,
+				},
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Type: pkgK8s.Pod,
+						},
+					},
+					TimeWindow: "1m",
+					Limit:      2,
+					PageToken:  "emojivoto2/emojivoto-1",
+				},
+				expectedResponse: lastPage,
+			},
+		}
+
+		testStatSummary(t, expectations)
+	})
+
 	t.Run("Given an invalid resource type, returns error", func(t *testing.T) {
 		k8sAPI, err := k8s.NewFakeAPI("")
 		if err != nil {
@@ -840,6 +1553,57 @@ status:
 					},
 				},
 			},
+			statSumExpected{
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Type: pkgK8s.All,
+						},
+					},
+					Limit: 10,
+				},
+			},
+			statSumExpected{
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Type: pkgK8s.Deployment,
+						},
+					},
+					GroupByLabel: "version",
+					Limit:        10,
+				},
+			},
+			statSumExpected{
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Type: pkgK8s.Authority,
+						},
+						LabelSelector: "app=web",
+					},
+				},
+			},
+			statSumExpected{
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Type: pkgK8s.Deployment,
+						},
+						LabelSelector: "app in (web",
+					},
+				},
+			},
+			statSumExpected{
+				req: pb.StatSummaryRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Type: pkgK8s.Pod,
+						},
+					},
+					PodStats: true,
+				},
+			},
 		}
 
 		for _, invalid := range invalidRequests {
@@ -0,0 +1,101 @@
+package public
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/util"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/prometheus/common/model"
+)
+
+const grpcResponsesQuery = "sum(increase(response_total%s[%s])) by (%s, grpc_status)"
+
+func (s *grpcServer) StreamStats(ctx context.Context, req *pb.StatSummaryRequest) (*pb.StreamStatsResponse, error) {
+
+	// check for well-formed request
+	if req.GetSelector().GetResource() == nil {
+		return streamStatsError(req, "StreamStats request missing Selector Resource"), nil
+	}
+
+	if req.GetSelector().GetResource().GetType() == k8s.All {
+		return streamStatsError(req, "resource type 'all' is not supported by StreamStats"), nil
+	}
+
+	if isInvalidServiceRequest(req.Selector, req.GetFromResource()) {
+		return streamStatsError(req, "service only supported as a target on 'from' queries, or as a destination on 'to' queries"), nil
+	}
+
+	if req.GetEndTime() != 0 {
+		ctx = withQueryTime(ctx, time.Unix(req.GetEndTime(), 0))
+	}
+
+	reqLabels, groupBy := buildRequestLabels(req)
+	results, err := s.getStreamMetrics(ctx, reqLabels.String(), req.TimeWindow, groupBy.String())
+	if err != nil {
+		return nil, util.GRPCError(err)
+	}
+
+	rows := make([]*pb.StreamStatsTable_Row, 0)
+	for key, stats := range processStreamMetrics(req, results, groupBy) {
+		rows = append(rows, &pb.StreamStatsTable_Row{
+			Resource: &pb.Resource{
+				Type:      key.Type,
+				Namespace: key.Namespace,
+				Name:      key.Name,
+			},
+			TimeWindow: req.TimeWindow,
+			Stats:      stats,
+		})
+	}
+
+	return &pb.StreamStatsResponse{
+		Response: &pb.StreamStatsResponse_Ok{
+			Ok: &pb.StreamStatsTable{
+				Rows: rows,
+			},
+		},
+	}, nil
+}
+
+func streamStatsError(req *pb.StatSummaryRequest, message string) *pb.StreamStatsResponse {
+	return &pb.StreamStatsResponse{
+		Response: &pb.StreamStatsResponse_Error{
+			Error: &pb.ResourceError{
+				Resource: req.GetSelector().GetResource(),
+				Error:    message,
+			},
+		},
+	}
+}
+
+func processStreamMetrics(req *pb.StatSummaryRequest, results []promResult, groupBy model.LabelNames) map[rKey]*pb.StreamStats {
+	streamStats := make(map[rKey]*pb.StreamStats)
+
+	for _, result := range results {
+		for _, sample := range result.vec {
+			resource := metricToKey(req, sample.Metric, groupBy)
+
+			if streamStats[resource] == nil {
+				streamStats[resource] = &pb.StreamStats{
+					ResponsesByGrpcStatus: make(map[uint32]uint64),
+				}
+			}
+
+			value := extractSampleValue(sample)
+
+			switch result.prom {
+			case promGrpcResponses:
+				// responses to plain HTTP requests have no grpc_status label
+				code, err := strconv.ParseUint(string(sample.Metric[model.LabelName("grpc_status")]), 10, 32)
+				if err == nil && value > 0 {
+					streamStats[resource].ResponsesByGrpcStatus[uint32(code)] += value
+				}
+			}
+		}
+	}
+
+	return streamStats
+}
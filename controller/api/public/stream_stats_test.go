@@ -0,0 +1,129 @@
+package public
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/prometheus/common/model"
+)
+
+func TestStreamStats(t *testing.T) {
+	t.Run("Successfully performs a stream stats query", func(t *testing.T) {
+		exp := expectedStatRpc{
+			mockPromResponse: model.Vector{
+				&model.Sample{
+					Metric: model.Metric{
+						"deployment":  "emoji",
+						"namespace":   "emojivoto",
+						"grpc_status": "14",
+					},
+					Value:     123,
+					Timestamp: 456,
+				},
+			},
+			expectedPrometheusQueries: []string{
+				`sum(increase(response_total{deployment="emoji", direction="inbound", namespace="emojivoto"}[1m])) by (namespace, deployment, grpc_status)`,
+			},
+		}
+
+		mockProm, fakeGrpcServer, err := newMockGrpcServer(exp)
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		rsp, err := fakeGrpcServer.StreamStats(context.TODO(), &pb.StatSummaryRequest{
+			Selector: &pb.ResourceSelection{
+				Resource: &pb.Resource{
+					Namespace: "emojivoto",
+					Type:      pkgK8s.Deployment,
+					Name:      "emoji",
+				},
+			},
+			TimeWindow: "1m",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		err = exp.verifyPromQueries(mockProm)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := &pb.StreamStatsTable_Row{
+			Resource: &pb.Resource{
+				Namespace: "emojivoto",
+				Type:      pkgK8s.Deployment,
+				Name:      "emoji",
+			},
+			TimeWindow: "1m",
+			Stats: &pb.StreamStats{
+				ResponsesByGrpcStatus: map[uint32]uint64{14: 123},
+			},
+		}
+
+		rows := rsp.GetOk().GetRows()
+		if len(rows) != 1 {
+			t.Fatalf("Expected 1 row, got %d: %v", len(rows), rows)
+		}
+		if !proto.Equal(rows[0], expected) {
+			t.Fatalf("Expected: %+v\n Got: %+v", expected, rows[0])
+		}
+	})
+
+	t.Run("Returns an error for the 'all' resource type", func(t *testing.T) {
+		_, fakeGrpcServer, err := newMockGrpcServer(expectedStatRpc{})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		rsp, err := fakeGrpcServer.StreamStats(context.TODO(), &pb.StatSummaryRequest{
+			Selector: &pb.ResourceSelection{
+				Resource: &pb.Resource{
+					Namespace: "emojivoto",
+					Type:      pkgK8s.All,
+				},
+			},
+			TimeWindow: "1m",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expected := "resource type 'all' is not supported by StreamStats"
+		if rsp.GetError().GetError() != expected {
+			t.Fatalf("Expected error [%s], got [%s]", expected, rsp.GetError().GetError())
+		}
+	})
+	t.Run("Evaluates the queries at the end time of the request", func(t *testing.T) {
+		mockProm, fakeGrpcServer, err := newMockGrpcServer(expectedStatRpc{mockPromResponse: model.Vector{}})
+		if err != nil {
+			t.Fatalf("Error creating mock grpc server: %s", err)
+		}
+
+		endTime := time.Unix(1546300800, 0)
+		_, err = fakeGrpcServer.StreamStats(context.TODO(), &pb.StatSummaryRequest{
+			Selector: &pb.ResourceSelection{
+				Resource: &pb.Resource{
+					Namespace: "emojivoto",
+					Type:      pkgK8s.Deployment,
+				},
+			},
+			TimeWindow: "1m",
+			EndTime:    endTime.Unix(),
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		for _, ts := range mockProm.QueryTimes {
+			if !ts.Equal(endTime) {
+				t.Fatalf("Expected the queries to be evaluated at %s, got %s", endTime, ts)
+			}
+		}
+	})
+}
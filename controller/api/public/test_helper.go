@@ -19,15 +19,18 @@ import (
 )
 
 type MockApiClient struct {
-	ErrorToReturn                   error
-	VersionInfoToReturn             *pb.VersionInfo
-	ListPodsResponseToReturn        *pb.ListPodsResponse
-	ListServicesResponseToReturn    *pb.ListServicesResponse
-	StatSummaryResponseToReturn     *pb.StatSummaryResponse
-	TopRoutesResponseToReturn       *pb.TopRoutesResponse
-	SelfCheckResponseToReturn       *healthcheckPb.SelfCheckResponse
-	Api_TapClientToReturn           pb.Api_TapClient
-	Api_TapByResourceClientToReturn pb.Api_TapByResourceClient
+	ErrorToReturn                     error
+	VersionInfoToReturn               *pb.VersionInfo
+	ListPodsResponseToReturn          *pb.ListPodsResponse
+	ListServicesResponseToReturn      *pb.ListServicesResponse
+	StatSummaryResponseToReturn       *pb.StatSummaryResponse
+	TopRoutesResponseToReturn         *pb.TopRoutesResponse
+	StreamStatsResponseToReturn       *pb.StreamStatsResponse
+	RouteSLOsResponseToReturn         *pb.RouteSLOsResponse
+	RouteStatsHistoryResponseToReturn *pb.RouteStatsHistoryResponse
+	SelfCheckResponseToReturn         *healthcheckPb.SelfCheckResponse
+	Api_TapClientToReturn             pb.Api_TapClient
+	Api_TapByResourceClientToReturn   pb.Api_TapByResourceClient
 }
 
 func (c *MockApiClient) StatSummary(ctx context.Context, in *pb.StatSummaryRequest, opts ...grpc.CallOption) (*pb.StatSummaryResponse, error) {
@@ -38,6 +41,18 @@ func (c *MockApiClient) TopRoutes(ctx context.Context, in *pb.TopRoutesRequest,
 	return c.TopRoutesResponseToReturn, c.ErrorToReturn
 }
 
+func (c *MockApiClient) StreamStats(ctx context.Context, in *pb.StatSummaryRequest, opts ...grpc.CallOption) (*pb.StreamStatsResponse, error) {
+	return c.StreamStatsResponseToReturn, c.ErrorToReturn
+}
+
+func (c *MockApiClient) RouteSLOs(ctx context.Context, in *pb.RouteSLOsRequest, opts ...grpc.CallOption) (*pb.RouteSLOsResponse, error) {
+	return c.RouteSLOsResponseToReturn, c.ErrorToReturn
+}
+
+func (c *MockApiClient) RouteStatsHistory(ctx context.Context, in *pb.RouteStatsHistoryRequest, opts ...grpc.CallOption) (*pb.RouteStatsHistoryResponse, error) {
+	return c.RouteStatsHistoryResponseToReturn, c.ErrorToReturn
+}
+
 func (c *MockApiClient) Version(ctx context.Context, in *pb.Empty, opts ...grpc.CallOption) (*pb.VersionInfo, error) {
 	return c.VersionInfoToReturn, c.ErrorToReturn
 }
@@ -113,6 +128,7 @@ func (a *MockApi_TapByResourceClient) Recv() (*pb.TapEvent, error) {
 type MockProm struct {
 	Res             model.Value
 	QueriesExecuted []string // expose the queries our Mock Prometheus receives, to test query generation
+	QueryTimes      []time.Time
 	rwLock          sync.Mutex
 }
 
@@ -127,6 +143,7 @@ func (m *MockProm) Query(ctx context.Context, query string, ts time.Time) (model
 	m.rwLock.Lock()
 	defer m.rwLock.Unlock()
 	m.QueriesExecuted = append(m.QueriesExecuted, query)
+	m.QueryTimes = append(m.QueryTimes, ts)
 	return m.Res, nil
 }
 func (m *MockProm) QueryRange(ctx context.Context, query string, r v1.Range) (model.Value, error) {
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/k8s"
@@ -12,9 +14,10 @@ import (
 )
 
 const (
-	routeReqQuery             = "sum(increase(route_response_total%s[%s])) by (%s, dst, classification, tls)"
-	routeLatencyQuantileQuery = "histogram_quantile(%s, sum(irate(route_response_latency_ms_bucket%s[%s])) by (le, dst, %s))"
-	dstLabel                  = `dst=~"%s(:\\d+)?"`
+	routeReqQuery           = "sum(increase(route_response_total%s[%s])) by (%s, dst, classification, tls, grpc_status)"
+	routeLatencyBucketQuery = "sum(irate(route_response_latency_ms_bucket%s[%s])) by (le, dst, %s)"
+	routeHistogramQuery     = "sum(increase(route_response_latency_ms_bucket%s[%s])) by (le, dst, %s)"
+	dstLabel                = `dst=~"%s(:\\d+)?"`
 )
 
 func (s *grpcServer) TopRoutes(ctx context.Context, req *pb.TopRoutesRequest) (*pb.TopRoutesResponse, error) {
@@ -24,6 +27,10 @@ func (s *grpcServer) TopRoutes(ctx context.Context, req *pb.TopRoutesRequest) (*
 		return topRoutesError(req, "TopRoutes request missing Selector Resource"), nil
 	}
 
+	if req.GetEndTime() != 0 {
+		ctx = withQueryTime(ctx, time.Unix(req.GetEndTime(), 0))
+	}
+
 	table, err := s.getRouteMetrics(ctx, req)
 	if err != nil {
 		return nil, err
@@ -51,13 +58,74 @@ func (s *grpcServer) getRouteMetrics(ctx context.Context, req *pb.TopRoutesReque
 	timeWindow := req.TimeWindow
 	reqLabels := buildRouteLabels(req)
 	groupBy := "rt_route"
+	resourceOf := routeResourceOf(req.Selector.Resource)
+	if resourceOf != nil && req.Selector.Resource.GetType() != k8s.Service {
+		groupBy = fmt.Sprintf("%s, %s", groupBy, promGroupByLabelNames(req.Selector.Resource))
+	}
 
-	results, err := s.getPrometheusMetrics(ctx, routeReqQuery, routeLatencyQuantileQuery, reqLabels, timeWindow, groupBy)
+	// the latency quantiles of all the routes are computed from a single query
+	// on the latency buckets, rather than with a query per quantile
+	queries := map[promType]string{
+		promRequests:       fmt.Sprintf(routeReqQuery, reqLabels, timeWindow, groupBy),
+		promLatencyBuckets: fmt.Sprintf(routeLatencyBucketQuery, reqLabels, timeWindow, groupBy),
+	}
+	// unlike the quantiles, the histogram counts the responses of the whole
+	// time window
+	if req.GetIncludeLatencyHistogram() {
+		queries[promLatencyHistogram] = fmt.Sprintf(routeHistogramQuery, reqLabels, timeWindow, groupBy)
+	}
+	results, err := s.runPromQueries(ctx, queries)
 	if err != nil {
 		return nil, err
 	}
 
-	return processRouteMetrics(results, timeWindow), nil
+	return processRouteMetrics(results, timeWindow, resourceOf), nil
+}
+
+// routeResourceOf returns the function telling the resource of the samples of
+// the route queries when the request selects all the resources of a type, so
+// that the routes of every resource are reported separately. It returns nil
+// when the request selects a named resource.
+func routeResourceOf(resource *pb.Resource) func(model.Metric) *pb.Resource {
+	if resource.GetName() != "" || resource.GetType() == k8s.Authority {
+		return nil
+	}
+
+	if resource.GetType() == k8s.Service {
+		// the services are told apart by the authority of their requests
+		return func(metric model.Metric) *pb.Resource {
+			host := strings.Split(string(metric[model.LabelName("dst")]), ":")[0]
+			parts := strings.Split(host, ".")
+			if len(parts) < 2 {
+				return nil
+			}
+			return &pb.Resource{Type: k8s.Service, Namespace: parts[1], Name: parts[0]}
+		}
+	}
+
+	label := promResourceType(resource)
+	return func(metric model.Metric) *pb.Resource {
+		namespace := string(metric[namespaceLabel])
+		name := namespace
+		if resource.GetType() != k8s.Namespace {
+			name = string(metric[label])
+		}
+		return &pb.Resource{Type: resource.GetType(), Namespace: namespace, Name: name}
+	}
+}
+
+// serviceAuthority returns the authority of the service, or a pattern
+// matching the authorities of all the services of its namespace, or of all the
+// namespaces, when the service isn't named.
+func serviceAuthority(service *pb.Resource) string {
+	name, namespace := service.GetName(), service.GetNamespace()
+	if name == "" {
+		name = "[^.]+"
+	}
+	if namespace == "" {
+		namespace = "[^.]+"
+	}
+	return fmt.Sprintf("%s.%s.svc.cluster.local", name, namespace)
 }
 
 func buildRouteLabels(req *pb.TopRoutesRequest) string {
@@ -76,12 +144,22 @@ func buildRouteLabels(req *pb.TopRoutesRequest) string {
 		labels = labels.Merge(promDirectionLabels("outbound"))
 		return renderLabels(labels, "")
 
+	case *pb.TopRoutesRequest_ToResource:
+		labels = labels.Merge(promQueryLabels(req.Selector.Resource))
+		labels = labels.Merge(promDirectionLabels("outbound"))
+		// services are selected by their authority, as the other types by
+		// their dst_ labels
+		if out.ToResource.GetType() == k8s.Service {
+			return renderLabels(labels, serviceAuthority(out.ToResource))
+		}
+		labels = labels.Merge(promDstQueryLabels(out.ToResource))
+		return renderLabels(labels, "")
+
 	default:
 		labels = labels.Merge(promDirectionLabels("inbound"))
 
 		if req.Selector.Resource.GetType() == k8s.Service {
-			service := fmt.Sprintf("%s.%s.svc.cluster.local", req.Selector.Resource.GetName(), req.Selector.Resource.GetNamespace())
-			return renderLabels(labels, service)
+			return renderLabels(labels, serviceAuthority(req.Selector.Resource))
 		}
 
 		labels = labels.Merge(promQueryLabels(req.Selector.Resource))
@@ -104,10 +182,15 @@ func renderLabels(labels model.LabelSet, service string) string {
 type dstAndRoute struct {
 	dst   string
 	route string
+	// resource is the namespace and name of the resource of the route, if the
+	// routes are grouped by resource
+	resource string
 }
 
-func processRouteMetrics(results []promResult, timeWindow string) *pb.RouteTable {
+func processRouteMetrics(results []promResult, timeWindow string, resourceOf func(model.Metric) *pb.Resource) *pb.RouteTable {
 	routeStats := make(map[dstAndRoute]*pb.RouteTable_Row)
+	histograms := make(map[dstAndRoute]*latencyHistogram)
+	responseHistograms := make(map[dstAndRoute]*latencyHistogram)
 
 	for _, result := range results {
 		for _, sample := range result.vec {
@@ -115,7 +198,11 @@ func processRouteMetrics(results []promResult, timeWindow string) *pb.RouteTable
 			route := string(sample.Metric[model.LabelName("rt_route")])
 			dst := string(sample.Metric[model.LabelName("dst")])
 
-			key := dstAndRoute{dst, route}
+			var resource *pb.Resource
+			if resourceOf != nil {
+				resource = resourceOf(sample.Metric)
+			}
+			key := dstAndRoute{dst, route, resource.GetNamespace() + "/" + resource.GetName()}
 
 			if routeStats[key] == nil {
 				routeStats[key] = &pb.RouteTable_Row{
@@ -123,6 +210,7 @@ func processRouteMetrics(results []promResult, timeWindow string) *pb.RouteTable
 					Route:      route,
 					TimeWindow: timeWindow,
 					Stats:      &pb.BasicStats{},
+					Resource:   resource,
 				}
 			}
 
@@ -140,18 +228,38 @@ func processRouteMetrics(results []promResult, timeWindow string) *pb.RouteTable
 				case "true":
 					routeStats[key].Stats.TlsRequestCount += value
 				}
-			case promLatencyP50:
-				routeStats[key].Stats.LatencyMsP50 = value
-			case promLatencyP95:
-				routeStats[key].Stats.LatencyMsP95 = value
-			case promLatencyP99:
-				routeStats[key].Stats.LatencyMsP99 = value
+				// responses to plain HTTP requests have no grpc_status label
+				code, err := strconv.ParseUint(string(sample.Metric[model.LabelName("grpc_status")]), 10, 32)
+				if err == nil && value > 0 {
+					if routeStats[key].ResponsesByGrpcStatus == nil {
+						routeStats[key].ResponsesByGrpcStatus = make(map[uint32]uint64)
+					}
+					routeStats[key].ResponsesByGrpcStatus[uint32(code)] += value
+				}
+			case promLatencyBuckets:
+				if histograms[key] == nil {
+					histograms[key] = &latencyHistogram{}
+				}
+				histograms[key].add(sample)
+			case promLatencyHistogram:
+				if responseHistograms[key] == nil {
+					responseHistograms[key] = &latencyHistogram{}
+				}
+				responseHistograms[key].add(sample)
 			}
 		}
 	}
 
 	rows := make([]*pb.RouteTable_Row, 0)
-	for _, row := range routeStats {
+	for key, row := range routeStats {
+		if h := histograms[key]; h != nil {
+			row.Stats.LatencyMsP50 = h.quantileValue(0.5)
+			row.Stats.LatencyMsP95 = h.quantileValue(0.95)
+			row.Stats.LatencyMsP99 = h.quantileValue(0.99)
+		}
+		if h := responseHistograms[key]; h != nil {
+			row.LatencyHistogram = h.latencyBuckets()
+		}
 		rows = append(rows, row)
 	}
 
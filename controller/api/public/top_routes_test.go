@@ -30,6 +30,7 @@ func routesMetric(routes []string) model.Vector {
 	samples := make(model.Vector, 0)
 	for _, route := range routes {
 		samples = append(samples, genRouteSample(route))
+		samples = append(samples, genRouteBucketSamples(route)...)
 	}
 	return samples
 }
@@ -47,6 +48,34 @@ func genRouteSample(route string) *model.Sample {
 	}
 }
 
+// genRouteBucketSamples mocks the latency buckets of a route, where all the
+// quantiles are 123ms
+func genRouteBucketSamples(route string) []*model.Sample {
+	samples := make([]*model.Sample, 0)
+	for le, count := range map[string]model.SampleValue{"123": 0, "+Inf": 123} {
+		samples = append(samples, &model.Sample{
+			Metric: model.Metric{
+				"rt_route": model.LabelValue(route),
+				"dst":      "foo.default.svc.cluster.local",
+				"le":       model.LabelValue(le),
+			},
+			Value:     count,
+			Timestamp: 456,
+		})
+	}
+	return samples
+}
+
+// withLabels adds the labels to the metrics of the samples
+func withLabels(samples model.Vector, labels model.LabelSet) model.Vector {
+	for _, sample := range samples {
+		for name, value := range labels {
+			sample.Metric[name] = value
+		}
+	}
+	return samples
+}
+
 func testTopRoutes(t *testing.T, expectations []topRoutesExpected) {
 	for _, exp := range expectations {
 
@@ -96,10 +125,8 @@ func TestTopRoutes(t *testing.T) {
 					err:              nil,
 					mockPromResponse: routesMetric([]string{"/a"}),
 					expectedPrometheusQueries: []string{
-						`histogram_quantile(0.5, sum(irate(route_response_latency_ms_bucket{deployment="webapp", direction="inbound", namespace="books"}[1m])) by (le, dst, rt_route))`,
-						`histogram_quantile(0.95, sum(irate(route_response_latency_ms_bucket{deployment="webapp", direction="inbound", namespace="books"}[1m])) by (le, dst, rt_route))`,
-						`histogram_quantile(0.99, sum(irate(route_response_latency_ms_bucket{deployment="webapp", direction="inbound", namespace="books"}[1m])) by (le, dst, rt_route))`,
-						`sum(increase(route_response_total{deployment="webapp", direction="inbound", namespace="books"}[1m])) by (rt_route, dst, classification, tls)`,
+						`sum(irate(route_response_latency_ms_bucket{deployment="webapp", direction="inbound", namespace="books"}[1m])) by (le, dst, rt_route)`,
+						`sum(increase(route_response_total{deployment="webapp", direction="inbound", namespace="books"}[1m])) by (rt_route, dst, classification, tls, grpc_status)`,
 					},
 				},
 				req: pb.TopRoutesRequest{
@@ -128,10 +155,8 @@ func TestTopRoutes(t *testing.T) {
 					err:              nil,
 					mockPromResponse: routesMetric([]string{"/a"}),
 					expectedPrometheusQueries: []string{
-						`histogram_quantile(0.5, sum(irate(route_response_latency_ms_bucket{direction="inbound", dst=~"webapp.books.svc.cluster.local(:\\d+)?"}[1m])) by (le, dst, rt_route))`,
-						`histogram_quantile(0.95, sum(irate(route_response_latency_ms_bucket{direction="inbound", dst=~"webapp.books.svc.cluster.local(:\\d+)?"}[1m])) by (le, dst, rt_route))`,
-						`histogram_quantile(0.99, sum(irate(route_response_latency_ms_bucket{direction="inbound", dst=~"webapp.books.svc.cluster.local(:\\d+)?"}[1m])) by (le, dst, rt_route))`,
-						`sum(increase(route_response_total{direction="inbound", dst=~"webapp.books.svc.cluster.local(:\\d+)?"}[1m])) by (rt_route, dst, classification, tls)`,
+						`sum(irate(route_response_latency_ms_bucket{direction="inbound", dst=~"webapp.books.svc.cluster.local(:\\d+)?"}[1m])) by (le, dst, rt_route)`,
+						`sum(increase(route_response_total{direction="inbound", dst=~"webapp.books.svc.cluster.local(:\\d+)?"}[1m])) by (rt_route, dst, classification, tls, grpc_status)`,
 					},
 				},
 				req: pb.TopRoutesRequest{
@@ -160,10 +185,8 @@ func TestTopRoutes(t *testing.T) {
 					err:              nil,
 					mockPromResponse: routesMetric([]string{"/a"}),
 					expectedPrometheusQueries: []string{
-						`histogram_quantile(0.5, sum(irate(route_response_latency_ms_bucket{deployment="traffic", direction="outbound", namespace="books"}[1m])) by (le, dst, rt_route))`,
-						`histogram_quantile(0.95, sum(irate(route_response_latency_ms_bucket{deployment="traffic", direction="outbound", namespace="books"}[1m])) by (le, dst, rt_route))`,
-						`histogram_quantile(0.99, sum(irate(route_response_latency_ms_bucket{deployment="traffic", direction="outbound", namespace="books"}[1m])) by (le, dst, rt_route))`,
-						`sum(increase(route_response_total{deployment="traffic", direction="outbound", namespace="books"}[1m])) by (rt_route, dst, classification, tls)`,
+						`sum(irate(route_response_latency_ms_bucket{deployment="traffic", direction="outbound", namespace="books"}[1m])) by (le, dst, rt_route)`,
+						`sum(increase(route_response_total{deployment="traffic", direction="outbound", namespace="books"}[1m])) by (rt_route, dst, classification, tls, grpc_status)`,
 					},
 				},
 				req: pb.TopRoutesRequest{
@@ -195,10 +218,8 @@ func TestTopRoutes(t *testing.T) {
 					err:              nil,
 					mockPromResponse: routesMetric([]string{"/a"}),
 					expectedPrometheusQueries: []string{
-						`histogram_quantile(0.5, sum(irate(route_response_latency_ms_bucket{deployment="traffic", direction="outbound", dst=~"books.default.svc.cluster.local(:\\d+)?", namespace="books"}[1m])) by (le, dst, rt_route))`,
-						`histogram_quantile(0.95, sum(irate(route_response_latency_ms_bucket{deployment="traffic", direction="outbound", dst=~"books.default.svc.cluster.local(:\\d+)?", namespace="books"}[1m])) by (le, dst, rt_route))`,
-						`histogram_quantile(0.99, sum(irate(route_response_latency_ms_bucket{deployment="traffic", direction="outbound", dst=~"books.default.svc.cluster.local(:\\d+)?", namespace="books"}[1m])) by (le, dst, rt_route))`,
-						`sum(increase(route_response_total{deployment="traffic", direction="outbound", dst=~"books.default.svc.cluster.local(:\\d+)?", namespace="books"}[1m])) by (rt_route, dst, classification, tls)`,
+						`sum(irate(route_response_latency_ms_bucket{deployment="traffic", direction="outbound", dst=~"books.default.svc.cluster.local(:\\d+)?", namespace="books"}[1m])) by (le, dst, rt_route)`,
+						`sum(increase(route_response_total{deployment="traffic", direction="outbound", dst=~"books.default.svc.cluster.local(:\\d+)?", namespace="books"}[1m])) by (rt_route, dst, classification, tls, grpc_status)`,
 					},
 				},
 				req: pb.TopRoutesRequest{
@@ -220,4 +241,170 @@ func TestTopRoutes(t *testing.T) {
 
 		testTopRoutes(t, expectations)
 	})
+
+	t.Run("Successfully performs an outbound routes query to a statefulset", func(t *testing.T) {
+		expectations := []topRoutesExpected{
+			topRoutesExpected{
+				expectedStatRpc: expectedStatRpc{
+					err:              nil,
+					mockPromResponse: routesMetric([]string{"/a"}),
+					expectedPrometheusQueries: []string{
+						`sum(irate(route_response_latency_ms_bucket{deployment="traffic", direction="outbound", dst_namespace="books", dst_statefulset="books", namespace="books"}[1m])) by (le, dst, rt_route)`,
+						`sum(increase(route_response_total{deployment="traffic", direction="outbound", dst_namespace="books", dst_statefulset="books", namespace="books"}[1m])) by (rt_route, dst, classification, tls, grpc_status)`,
+					},
+				},
+				req: pb.TopRoutesRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "books",
+							Type:      pkgK8s.Deployment,
+							Name:      "traffic",
+						},
+					},
+					Outbound: &pb.TopRoutesRequest_ToResource{
+						ToResource: &pb.Resource{
+							Namespace: "books",
+							Type:      pkgK8s.StatefulSet,
+							Name:      "books",
+						},
+					},
+					TimeWindow: "1m",
+				},
+				expectedResponse: GenTopRoutesResponse([]string{"/a"}, []uint64{123}),
+			},
+		}
+
+		testTopRoutes(t, expectations)
+	})
+
+	t.Run("Successfully performs a routes query for all the deployments of all the namespaces", func(t *testing.T) {
+		expectedResponse := GenTopRoutesResponse([]string{"/a"}, []uint64{123})
+		expectedResponse.GetRoutes().Rows[0].Resource = &pb.Resource{
+			Namespace: "books",
+			Type:      pkgK8s.Deployment,
+			Name:      "webapp",
+		}
+		expectations := []topRoutesExpected{
+			topRoutesExpected{
+				expectedStatRpc: expectedStatRpc{
+					err:              nil,
+					mockPromResponse: withLabels(routesMetric([]string{"/a"}), model.LabelSet{"namespace": "books", "deployment": "webapp"}),
+					expectedPrometheusQueries: []string{
+						`sum(irate(route_response_latency_ms_bucket{direction="inbound"}[1m])) by (le, dst, rt_route, namespace, deployment)`,
+						`sum(increase(route_response_total{direction="inbound"}[1m])) by (rt_route, namespace, deployment, dst, classification, tls, grpc_status)`,
+					},
+				},
+				req: pb.TopRoutesRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Type: pkgK8s.Deployment,
+						},
+					},
+					TimeWindow: "1m",
+				},
+				expectedResponse: expectedResponse,
+			},
+		}
+
+		testTopRoutes(t, expectations)
+	})
+
+	t.Run("Successfully performs a routes query for all the services of a namespace", func(t *testing.T) {
+		expectedResponse := GenTopRoutesResponse([]string{"/a"}, []uint64{123})
+		expectedResponse.GetRoutes().Rows[0].Resource = &pb.Resource{
+			Namespace: "default",
+			Type:      pkgK8s.Service,
+			Name:      "foo",
+		}
+		expectations := []topRoutesExpected{
+			topRoutesExpected{
+				expectedStatRpc: expectedStatRpc{
+					err:              nil,
+					mockPromResponse: routesMetric([]string{"/a"}),
+					expectedPrometheusQueries: []string{
+						`sum(irate(route_response_latency_ms_bucket{direction="inbound", dst=~"[^.]+.default.svc.cluster.local(:\\d+)?"}[1m])) by (le, dst, rt_route)`,
+						`sum(increase(route_response_total{direction="inbound", dst=~"[^.]+.default.svc.cluster.local(:\\d+)?"}[1m])) by (rt_route, dst, classification, tls, grpc_status)`,
+					},
+				},
+				req: pb.TopRoutesRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "default",
+							Type:      pkgK8s.Service,
+						},
+					},
+					TimeWindow: "1m",
+				},
+				expectedResponse: expectedResponse,
+			},
+		}
+
+		testTopRoutes(t, expectations)
+	})
+
+	t.Run("Successfully breaks down the responses of a gRPC route by status code", func(t *testing.T) {
+		expectedResponse := GenTopRoutesResponse([]string{"/a"}, []uint64{123})
+		expectedResponse.GetRoutes().Rows[0].ResponsesByGrpcStatus = map[uint32]uint64{14: 123}
+		sample := genRouteSample("/a")
+		sample.Metric["grpc_status"] = "14"
+		expectations := []topRoutesExpected{
+			topRoutesExpected{
+				expectedStatRpc: expectedStatRpc{
+					err:              nil,
+					mockPromResponse: append(model.Vector{sample}, genRouteBucketSamples("/a")...),
+					expectedPrometheusQueries: []string{
+						`sum(irate(route_response_latency_ms_bucket{deployment="webapp", direction="inbound", namespace="books"}[1m])) by (le, dst, rt_route)`,
+						`sum(increase(route_response_total{deployment="webapp", direction="inbound", namespace="books"}[1m])) by (rt_route, dst, classification, tls, grpc_status)`,
+					},
+				},
+				req: pb.TopRoutesRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "books",
+							Type:      pkgK8s.Deployment,
+							Name:      "webapp",
+						},
+					},
+					TimeWindow: "1m",
+				},
+				expectedResponse: expectedResponse,
+			},
+		}
+
+		testTopRoutes(t, expectations)
+	})
+	t.Run("Successfully reports the latency histogram of the routes", func(t *testing.T) {
+		expectedResponse := GenTopRoutesResponse([]string{"/a"}, []uint64{123})
+		expectedResponse.GetRoutes().Rows[0].LatencyHistogram = []*pb.RouteTable_LatencyBucket{
+			{MaxMs: 123, Count: 0},
+			{MaxMs: 0, Count: 123},
+		}
+		expectations := []topRoutesExpected{
+			topRoutesExpected{
+				expectedStatRpc: expectedStatRpc{
+					err:              nil,
+					mockPromResponse: routesMetric([]string{"/a"}),
+					expectedPrometheusQueries: []string{
+						`sum(irate(route_response_latency_ms_bucket{deployment="webapp", direction="inbound", namespace="books"}[1m])) by (le, dst, rt_route)`,
+						`sum(increase(route_response_latency_ms_bucket{deployment="webapp", direction="inbound", namespace="books"}[1m])) by (le, dst, rt_route)`,
+						`sum(increase(route_response_total{deployment="webapp", direction="inbound", namespace="books"}[1m])) by (rt_route, dst, classification, tls, grpc_status)`,
+					},
+				},
+				req: pb.TopRoutesRequest{
+					Selector: &pb.ResourceSelection{
+						Resource: &pb.Resource{
+							Namespace: "books",
+							Type:      pkgK8s.Deployment,
+							Name:      "webapp",
+						},
+					},
+					TimeWindow:              "1m",
+					IncludeLatencyHistogram: true,
+				},
+				expectedResponse: expectedResponse,
+			},
+		}
+
+		testTopRoutes(t, expectations)
+	})
 }
@@ -1,11 +1,14 @@
 package util
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/addr"
 	"github.com/linkerd/linkerd2/pkg/k8s"
@@ -30,7 +33,9 @@ var (
 	// destination resource on an outbound 'from' query
 	ValidTargets = []string{
 		k8s.Authority,
+		k8s.CronJob,
 		k8s.Deployment,
+		k8s.Job,
 		k8s.Namespace,
 		k8s.Pod,
 		k8s.ReplicationController,
@@ -39,6 +44,7 @@ var (
 	// ValidTapDestinations specifies resource types allowed as a tap destination:
 	// destination resource on an outbound 'to' query
 	ValidTapDestinations = []string{
+		k8s.CronJob,
 		k8s.Deployment,
 		k8s.Job,
 		k8s.Namespace,
@@ -56,6 +62,8 @@ type StatsBaseRequestParams struct {
 	ResourceType  string
 	ResourceName  string
 	AllNamespaces bool
+	// EndTime is the end of the time window; the zero time means now
+	EndTime time.Time
 }
 
 type StatsSummaryRequestParams struct {
@@ -66,12 +74,27 @@ type StatsSummaryRequestParams struct {
 	FromNamespace string
 	FromType      string
 	FromName      string
+
+	TCPStats bool
+	// LabelSelector restricts the stats to the resources whose labels match it
+	LabelSelector string
+	// PodStats also requests the stats of the pods of each resource
+	PodStats bool
+	// GroupByLabel groups the stats of the pods by the value of this label
+	GroupByLabel string
 }
 
 type TopRoutesRequestParams struct {
 	StatsBaseRequestParams
 	To    string
 	ToAll bool
+	// ToNamespace, ToType and ToName select a destination resource other than
+	// a service, which has no authority of its own
+	ToNamespace string
+	ToType      string
+	ToName      string
+	// IncludeLatencyHistogram requests the latency histogram of every route
+	IncludeLatencyHistogram bool
 }
 
 type TapRequestParams struct {
@@ -84,6 +107,15 @@ type TapRequestParams struct {
 	Method      string
 	Authority   string
 	Path        string
+	// StatusClasses only reports the requests whose response status is in
+	// these classes, e.g. "5xx"
+	StatusClasses []string
+	// MinLatency only reports the requests whose response took at least this
+	// long
+	MinLatency time.Duration
+	// RPCMethod only reports the requests to this gRPC method, e.g.
+	// "/pkg.Service/Method"
+	RPCMethod string
 }
 
 // GRPCError generates a gRPC error code, as defined in
@@ -151,11 +183,24 @@ func BuildStatSummaryRequest(p StatsSummaryRequestParams) (*pb.StatSummaryReques
 				Name:      p.ResourceName,
 				Type:      resourceType,
 			},
+			LabelSelector: p.LabelSelector,
 		},
-		TimeWindow: window,
+		TimeWindow:   window,
+		TcpStats:     p.TCPStats,
+		GroupByLabel: p.GroupByLabel,
+		PodStats:     p.PodStats,
+	}
+	if !p.EndTime.IsZero() {
+		statRequest.EndTime = p.EndTime.Unix()
 	}
 
-	if p.ToName != "" || p.ToType != "" || p.ToNamespace != "" {
+	if p.ToName == "" && p.ToType == "" && p.ToNamespace != "" {
+		// a namespace on its own restricts the stats to the traffic to any
+		// resource of the namespace
+		statRequest.Outbound = &pb.StatSummaryRequest_ToResource{
+			ToResource: namespaceResource(p.ToNamespace),
+		}
+	} else if p.ToName != "" || p.ToType != "" {
 		if p.ToNamespace == "" {
 			p.ToNamespace = targetNamespace
 		}
@@ -178,7 +223,13 @@ func BuildStatSummaryRequest(p StatsSummaryRequestParams) (*pb.StatSummaryReques
 		statRequest.Outbound = &toResource
 	}
 
-	if p.FromName != "" || p.FromType != "" || p.FromNamespace != "" {
+	if p.FromName == "" && p.FromType == "" && p.FromNamespace != "" {
+		// a namespace on its own restricts the stats to the traffic from any
+		// resource of the namespace
+		statRequest.Outbound = &pb.StatSummaryRequest_FromResource{
+			FromResource: namespaceResource(p.FromNamespace),
+		}
+	} else if p.FromName != "" || p.FromType != "" {
 		if p.FromNamespace == "" {
 			p.FromNamespace = targetNamespace
 		}
@@ -204,6 +255,53 @@ func BuildStatSummaryRequest(p StatsSummaryRequestParams) (*pb.StatSummaryReques
 	return statRequest, nil
 }
 
+// StatSummaryPageSize is the number of rows requested at a time by
+// GetStatSummary.
+const StatSummaryPageSize = 500
+
+// GetStatSummary sends the StatSummary request page by page, unless it can't
+// be paginated, so that the responses for clusters with many resources stay
+// small. It returns the rows of all the pages in a single response. Responses
+// with an error are returned as is.
+func GetStatSummary(ctx context.Context, client pb.ApiClient, req *pb.StatSummaryRequest) (*pb.StatSummaryResponse, error) {
+	req = proto.Clone(req).(*pb.StatSummaryRequest)
+	if req.GetLimit() == 0 && req.GetSelector().GetResource().GetType() != k8s.All && req.GetGroupByLabel() == "" {
+		req.Limit = StatSummaryPageSize
+	}
+
+	var rsp *pb.StatSummaryResponse
+	for {
+		page, err := client.StatSummary(ctx, req)
+		if err != nil || page.GetError() != nil {
+			return page, err
+		}
+
+		if rsp == nil {
+			rsp = page
+		} else {
+			tables := rsp.GetOk().GetStatTables()
+			for i, table := range page.GetOk().GetStatTables() {
+				if i < len(tables) && tables[i].GetPodGroup() != nil {
+					podGroup := tables[i].GetPodGroup()
+					podGroup.Rows = append(podGroup.Rows, table.GetPodGroup().GetRows()...)
+				}
+			}
+		}
+
+		// servers that don't paginate return all the rows without a token
+		token := page.GetOk().GetNextPageToken()
+		if token == "" || token == req.PageToken {
+			break
+		}
+		req.PageToken = token
+	}
+
+	if ok := rsp.GetOk(); ok != nil {
+		ok.NextPageToken = ""
+	}
+	return rsp, nil
+}
+
 func BuildTopRoutesRequest(p TopRoutesRequestParams) (*pb.TopRoutesRequest, error) {
 	window := defaultMetricTimeWindow
 	if p.TimeWindow != "" {
@@ -238,12 +336,19 @@ func BuildTopRoutesRequest(p TopRoutesRequestParams) (*pb.TopRoutesRequest, erro
 				Type:      resourceType,
 			},
 		},
-		TimeWindow: window,
+		TimeWindow:              window,
+		IncludeLatencyHistogram: p.IncludeLatencyHistogram,
+	}
+	if !p.EndTime.IsZero() {
+		topRoutesRequest.EndTime = p.EndTime.Unix()
 	}
 
 	if p.To != "" && p.ToAll {
 		return nil, errors.New("ToService and ToAll are mutually exclusive")
 	}
+	if p.ToType != "" && (p.To != "" || p.ToAll) {
+		return nil, errors.New("ToType is mutually exclusive with ToService and ToAll")
+	}
 
 	if p.To != "" {
 		topRoutesRequest.Outbound = &pb.TopRoutesRequest_ToAuthority{
@@ -257,9 +362,32 @@ func BuildTopRoutesRequest(p TopRoutesRequestParams) (*pb.TopRoutesRequest, erro
 		}
 	}
 
+	if p.ToType != "" {
+		toType, err := k8s.CanonicalResourceNameFromFriendlyName(p.ToType)
+		if err != nil {
+			return nil, err
+		}
+		topRoutesRequest.Outbound = &pb.TopRoutesRequest_ToResource{
+			ToResource: &pb.Resource{
+				Namespace: p.ToNamespace,
+				Type:      toType,
+				Name:      p.ToName,
+			},
+		}
+	}
+
 	return topRoutesRequest, nil
 }
 
+// namespaceResource returns the namespace resource named namespace.
+func namespaceResource(namespace string) *pb.Resource {
+	return &pb.Resource{
+		Namespace: namespace,
+		Type:      k8s.Namespace,
+		Name:      namespace,
+	}
+}
+
 // An authority can only receive traffic, not send it, so it can't be a --from
 func validateFromResourceType(resourceType string) (string, error) {
 	name, err := k8s.CanonicalResourceNameFromFriendlyName(resourceType)
@@ -424,6 +552,19 @@ func BuildTapByResourceRequest(params TapRequestParams) (*pb.TapByResourceReques
 		})
 		matches = append(matches, &match)
 	}
+	// the proxies only match path prefixes, the filter then drops the other
+	// methods sharing the prefix
+	if params.RPCMethod != "" {
+		match := buildMatchHTTP(&pb.TapByResourceRequest_Match_Http{
+			Match: &pb.TapByResourceRequest_Match_Http_Path{Path: params.RPCMethod},
+		})
+		matches = append(matches, &match)
+	}
+
+	filter, err := BuildTapFilter(params)
+	if err != nil {
+		return nil, err
+	}
 
 	return &pb.TapByResourceRequest{
 		Target: &pb.ResourceSelection{
@@ -437,9 +578,44 @@ func BuildTapByResourceRequest(params TapRequestParams) (*pb.TapByResourceReques
 				},
 			},
 		},
+		Filter: filter,
 	}, nil
 }
 
+// BuildTapFilter returns the filter of the responses and gRPC methods of the
+// tap request, or nil if the requests aren't filtered.
+func BuildTapFilter(params TapRequestParams) (*pb.TapByResourceRequest_Filter, error) {
+	if len(params.StatusClasses) == 0 && params.MinLatency == 0 && params.RPCMethod == "" {
+		return nil, nil
+	}
+	if params.MinLatency < 0 {
+		return nil, errors.New("minimum latency must not be negative")
+	}
+	if params.RPCMethod != "" && !isRPCMethod(params.RPCMethod) {
+		return nil, fmt.Errorf("invalid gRPC method [%s], expected /package.Service/Method", params.RPCMethod)
+	}
+
+	filter := &pb.TapByResourceRequest_Filter{RpcMethod: params.RPCMethod}
+	for _, class := range params.StatusClasses {
+		class = strings.ToLower(class)
+		if len(class) != 3 || class[0] < '1' || class[0] > '5' || class[1:] != "xx" {
+			return nil, fmt.Errorf("invalid status class [%s], expected one of 1xx, 2xx, 3xx, 4xx or 5xx", class)
+		}
+		filter.StatusClasses = append(filter.StatusClasses, uint32(class[0]-'0'))
+	}
+	if params.MinLatency > 0 {
+		filter.MinLatency = ptypes.DurationProto(params.MinLatency)
+	}
+	return filter, nil
+}
+
+// isRPCMethod returns true if method is the path of a gRPC method, i.e.
+// /package.Service/Method.
+func isRPCMethod(method string) bool {
+	parts := strings.Split(method, "/")
+	return len(parts) == 3 && parts[0] == "" && parts[1] != "" && parts[2] != ""
+}
+
 func buildMatchHTTP(match *pb.TapByResourceRequest_Match_Http) pb.TapByResourceRequest_Match {
 	return pb.TapByResourceRequest_Match{
 		Match: &pb.TapByResourceRequest_Match_Http_{
@@ -524,6 +700,34 @@ func (p *peer) tlsStatus() string {
 	return p.labels["tls"]
 }
 
+// The labels of the identities of the peers of a tap event, set by the proxy
+// when the connection is secured with mTLS: the client identity is the one
+// authenticated by the inbound proxy, and the server identity is the one
+// verified by the outbound proxy. The proxy pinned by this release (see
+// bin/docker-build-proxy) only reports the TLS status of the connections, so
+// the identities are only rendered when the proxies report them.
+const (
+	ClientIdentityLabel = "client_id"
+	ServerIdentityLabel = "server_id"
+)
+
+// MissingIdentity returns true if the connection of the event is secured with
+// mTLS, but the proxy reporting it doesn't set the identity of its peer.
+func MissingIdentity(event *pb.TapEvent) bool {
+	var p peer
+	var label string
+	switch event.GetProxyDirection() {
+	case pb.TapEvent_INBOUND:
+		p, label = src(event), ClientIdentityLabel
+	case pb.TapEvent_OUTBOUND:
+		p, label = dst(event), ServerIdentityLabel
+	default:
+		return false
+	}
+	_, ok := p.labels[label]
+	return p.tlsStatus() == "true" && !ok
+}
+
 func routeLabels(event *pb.TapEvent) string {
 	out := ""
 	for key, val := range event.GetRouteMeta().GetLabels() {
@@ -534,6 +738,17 @@ func routeLabels(event *pb.TapEvent) string {
 }
 
 func RenderTapEvent(event *pb.TapEvent, resource string) string {
+	return renderTapEvent(event, resource, false)
+}
+
+// RenderTapEventWithIdentity renders the event like RenderTapEvent, with the
+// client and server identities of the request after its TLS status, if the
+// proxy reported them.
+func RenderTapEventWithIdentity(event *pb.TapEvent, resource string) string {
+	return renderTapEvent(event, resource, true)
+}
+
+func renderTapEvent(event *pb.TapEvent, resource string, showIdentity bool) string {
 	dst := dst(event)
 	src := src(event)
 
@@ -556,6 +771,14 @@ func RenderTapEvent(event *pb.TapEvent, resource string) string {
 		dst.formatAddr(),
 		tls,
 	)
+	if showIdentity {
+		if id, ok := src.labels[ClientIdentityLabel]; ok {
+			flow += fmt.Sprintf(" client_id=%s", id)
+		}
+		if id, ok := dst.labels[ServerIdentityLabel]; ok {
+			flow += fmt.Sprintf(" server_id=%s", id)
+		}
+	}
 
 	// If `resource` is non-empty, then
 	resources := ""
@@ -594,13 +817,14 @@ func RenderTapEvent(event *pb.TapEvent, resource string) string {
 		switch eos := ev.ResponseEnd.GetEos().GetEnd().(type) {
 		case *pb.Eos_GrpcStatusCode:
 			return fmt.Sprintf(
-				"end id=%d:%d %s grpc-status=%s duration=%dµs response-length=%dB%s",
+				"end id=%d:%d %s grpc-status=%s duration=%dµs response-length=%dB%s%s",
 				ev.ResponseEnd.GetId().GetBase(),
 				ev.ResponseEnd.GetId().GetStream(),
 				flow,
 				codes.Code(eos.GrpcStatusCode),
 				ev.ResponseEnd.GetSinceResponseInit().GetNanos()/1000,
 				ev.ResponseEnd.GetResponseBytes(),
+				formatGrpcMessages(ev.ResponseEnd),
 				resources,
 			)
 
@@ -632,6 +856,15 @@ func RenderTapEvent(event *pb.TapEvent, resource string) string {
 	}
 }
 
+// formatGrpcMessages formats the number of messages of a gRPC stream, if the
+// proxy counted them.
+func formatGrpcMessages(end *pb.TapEvent_Http_ResponseEnd) string {
+	if end.GetRequestMessages() == 0 && end.GetResponseMessages() == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" request-messages=%d response-messages=%d", end.GetRequestMessages(), end.GetResponseMessages())
+}
+
 func GetRequestRate(stats *pb.BasicStats, timeWindow string) float64 {
 	success := stats.SuccessCount
 	failure := stats.FailureCount
@@ -653,6 +886,26 @@ func GetSuccessRate(stats *pb.BasicStats) float64 {
 	return float64(success) / float64(success+failure)
 }
 
+// GetUnmeshedRequestRate returns the rate of the requests whose peer had no
+// identity, i.e. of the requests received from unmeshed clients for inbound
+// stats.
+func GetUnmeshedRequestRate(stats *pb.BasicStats, timeWindow string) float64 {
+	return GetRequestRate(&pb.BasicStats{
+		SuccessCount: stats.UnmeshedRequestCount,
+	}, timeWindow)
+}
+
+// GetPercentMeshed returns the share of the requests whose peer had an
+// identity, i.e. of the requests received from meshed clients for inbound
+// stats.
+func GetPercentMeshed(stats *pb.BasicStats) float64 {
+	reqTotal := stats.SuccessCount + stats.FailureCount
+	if reqTotal == 0 {
+		return 0.0
+	}
+	return 1 - float64(stats.UnmeshedRequestCount)/float64(reqTotal)
+}
+
 func GetPercentTls(stats *pb.BasicStats) float64 {
 	reqTotal := stats.SuccessCount + stats.FailureCount
 	if reqTotal == 0 {
@@ -1,12 +1,17 @@
 package util
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/k8s"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	k8sError "k8s.io/apimachinery/pkg/api/errors"
@@ -137,6 +142,71 @@ func TestBuildStatSummaryRequest(t *testing.T) {
 	})
 }
 
+// pagingAPIClient returns the StatSummary page of the page token of each
+// request, and records the requests.
+type pagingAPIClient struct {
+	pb.ApiClient
+	pages map[string]*pb.StatSummaryResponse
+	reqs  []*pb.StatSummaryRequest
+}
+
+func (c *pagingAPIClient) StatSummary(ctx context.Context, in *pb.StatSummaryRequest, _ ...grpc.CallOption) (*pb.StatSummaryResponse, error) {
+	c.reqs = append(c.reqs, proto.Clone(in).(*pb.StatSummaryRequest))
+	return proto.Clone(c.pages[in.GetPageToken()]).(*pb.StatSummaryResponse), nil
+}
+
+func statPage(names []string, token string) *pb.StatSummaryResponse {
+	rows := []*pb.StatTable_PodGroup_Row{}
+	for _, name := range names {
+		rows = append(rows, &pb.StatTable_PodGroup_Row{Resource: &pb.Resource{Type: k8s.Deployment, Name: name}})
+	}
+	return &pb.StatSummaryResponse{
+		Response: &pb.StatSummaryResponse_Ok_{
+			Ok: &pb.StatSummaryResponse_Ok{
+				StatTables:    []*pb.StatTable{{Table: &pb.StatTable_PodGroup_{PodGroup: &pb.StatTable_PodGroup{Rows: rows}}}},
+				NextPageToken: token,
+			},
+		},
+	}
+}
+
+func TestGetStatSummary(t *testing.T) {
+	t.Run("Requests all the pages", func(t *testing.T) {
+		client := &pagingAPIClient{pages: map[string]*pb.StatSummaryResponse{
+			"":   statPage([]string{"a", "b"}, "/b"),
+			"/b": statPage([]string{"c"}, ""),
+		}}
+		req := &pb.StatSummaryRequest{Selector: &pb.ResourceSelection{Resource: &pb.Resource{Type: k8s.Deployment}}}
+
+		rsp, err := GetStatSummary(context.Background(), client, req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		expected := statPage([]string{"a", "b", "c"}, "")
+		if !proto.Equal(rsp, expected) {
+			t.Fatalf("Expected %v, got %v", expected, rsp)
+		}
+		if len(client.reqs) != 2 || client.reqs[1].GetPageToken() != "/b" || client.reqs[1].GetLimit() != StatSummaryPageSize {
+			t.Fatalf("Unexpected requests %v", client.reqs)
+		}
+		if req.GetLimit() != 0 || req.GetPageToken() != "" {
+			t.Fatalf("Expected the request to be unchanged, got %v", req)
+		}
+	})
+
+	t.Run("Doesn't paginate the stats of all the resource types", func(t *testing.T) {
+		client := &pagingAPIClient{pages: map[string]*pb.StatSummaryResponse{"": statPage([]string{"a"}, "")}}
+		req := &pb.StatSummaryRequest{Selector: &pb.ResourceSelection{Resource: &pb.Resource{Type: k8s.All}}}
+
+		if _, err := GetStatSummary(context.Background(), client, req); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(client.reqs) != 1 || client.reqs[0].GetLimit() != 0 {
+			t.Fatalf("Expected a single request without a limit, got %v", client.reqs)
+		}
+	})
+}
+
 func TestBuildTopRoutesRequest(t *testing.T) {
 	t.Run("Parses valid time windows", func(t *testing.T) {
 		expectations := []string{
@@ -188,6 +258,80 @@ func TestBuildTopRoutesRequest(t *testing.T) {
 	})
 }
 
+func TestBuildTapByResourceRequest(t *testing.T) {
+	t.Run("Filters the responses by status class and latency", func(t *testing.T) {
+		req, err := BuildTapByResourceRequest(TapRequestParams{
+			Resource:      "deploy/web",
+			StatusClasses: []string{"4xx", "5XX"},
+			MinLatency:    200 * time.Millisecond,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expected := &pb.TapByResourceRequest_Filter{
+			StatusClasses: []uint32{4, 5},
+			MinLatency:    ptypes.DurationProto(200 * time.Millisecond),
+		}
+		if !proto.Equal(req.GetFilter(), expected) {
+			t.Fatalf("Expected filter %v, got %v", expected, req.GetFilter())
+		}
+	})
+
+	t.Run("Doesn't filter the responses by default", func(t *testing.T) {
+		req, err := BuildTapByResourceRequest(TapRequestParams{Resource: "deploy/web"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if req.GetFilter() != nil {
+			t.Fatalf("Expected no filter, got %v", req.GetFilter())
+		}
+	})
+
+	t.Run("Rejects invalid status classes", func(t *testing.T) {
+		for _, class := range []string{"500", "6xx", "5x"} {
+			_, err := BuildTapByResourceRequest(TapRequestParams{
+				Resource:      "deploy/web",
+				StatusClasses: []string{class},
+			})
+			if err == nil {
+				t.Fatalf("Expected an error for status class %s", class)
+			}
+		}
+	})
+
+	t.Run("Matches the path prefix of the gRPC method, then filters the method", func(t *testing.T) {
+		method := "/emojivoto.v1.VotingService/VoteDoughnut"
+		req, err := BuildTapByResourceRequest(TapRequestParams{
+			Resource:  "deploy/web",
+			RPCMethod: method,
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		matches := req.GetMatch().GetAll().GetMatches()
+		if len(matches) != 1 || matches[0].GetHttp().GetPath() != method {
+			t.Fatalf("Expected a match of the path %s, got %v", method, matches)
+		}
+		if req.GetFilter().GetRpcMethod() != method {
+			t.Fatalf("Expected the filter of the method %s, got %v", method, req.GetFilter())
+		}
+	})
+
+	t.Run("Rejects invalid gRPC methods", func(t *testing.T) {
+		for _, method := range []string{"VoteDoughnut", "/emojivoto.v1.VotingService", "/emojivoto.v1.VotingService/", "/a/b/c"} {
+			_, err := BuildTapByResourceRequest(TapRequestParams{
+				Resource:  "deploy/web",
+				RPCMethod: method,
+			})
+			if err == nil {
+				t.Fatalf("Expected an error for gRPC method %s", method)
+			}
+		}
+	})
+}
+
 func TestBuildResource(t *testing.T) {
 	type resourceExp struct {
 		namespace string
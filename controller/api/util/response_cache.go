@@ -0,0 +1,121 @@
+package util
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// ResponseCache shares the responses of the public API between identical
+// requests for a short TTL, so that many clients, or watch modes refreshing
+// faster than the TTL, don't multiply the queries of the same resources.
+// Identical requests arriving while the response is being fetched wait for it,
+// instead of querying again. Errors are not cached. A nil ResponseCache caches
+// nothing.
+//
+// The response is fetched with the context of the first request. When that
+// request is canceled, e.g. because its client went away, the requests
+// waiting for its response fetch it again with their own context rather than
+// failing with its context error.
+type ResponseCache struct {
+	ttl     time.Duration
+	now     func() time.Time
+	observe func(api string, hit bool)
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+}
+
+type cacheEntry struct {
+	// ctx is the context of the request fetching the response
+	ctx    context.Context
+	done   chan struct{}
+	rsp    proto.Message
+	err    error
+	expiry time.Time
+}
+
+// NewResponseCache returns a cache keeping the responses for ttl, or nil if
+// ttl isn't positive. If observe isn't nil, it is called for every request
+// served by the cache, e.g. to count its hits and misses.
+func NewResponseCache(ttl time.Duration, observe func(api string, hit bool)) *ResponseCache {
+	if ttl <= 0 {
+		return nil
+	}
+
+	return &ResponseCache{
+		ttl:     ttl,
+		now:     time.Now,
+		observe: observe,
+		entries: make(map[string]*cacheEntry),
+	}
+}
+
+// Get returns the cached response of the API to the request, or the response
+// of fetch otherwise. Requests are keyed by their normalized text encoding.
+// The cached responses are shared: the callers must not modify them.
+func (c *ResponseCache) Get(ctx context.Context, api string, req proto.Message, fetch func(context.Context) (proto.Message, error)) (proto.Message, error) {
+	if c == nil {
+		return fetch(ctx)
+	}
+
+	key := api + " " + proto.CompactTextString(req)
+
+	for {
+		c.mu.Lock()
+		c.evictExpired(c.now())
+		entry, ok := c.entries[key]
+		if !ok {
+			break
+		}
+		c.mu.Unlock()
+		c.observed(api, true)
+
+		select {
+		case <-entry.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		if entry.err != nil && entry.ctx.Err() != nil {
+			// the fetching request was canceled; fetch again
+			continue
+		}
+		return entry.rsp, entry.err
+	}
+
+	// c.mu is held
+	entry := &cacheEntry{ctx: ctx, done: make(chan struct{})}
+	c.entries[key] = entry
+	c.mu.Unlock()
+
+	c.observed(api, false)
+	entry.rsp, entry.err = fetch(ctx)
+
+	c.mu.Lock()
+	if entry.err != nil {
+		delete(c.entries, key)
+	} else {
+		entry.expiry = c.now().Add(c.ttl)
+	}
+	c.mu.Unlock()
+	close(entry.done)
+
+	return entry.rsp, entry.err
+}
+
+func (c *ResponseCache) observed(api string, hit bool) {
+	if c.observe != nil {
+		c.observe(api, hit)
+	}
+}
+
+// evictExpired removes the expired responses; the caller holds c.mu. Entries
+// still being fetched have a zero expiry and are kept.
+func (c *ResponseCache) evictExpired(now time.Time) {
+	for key, entry := range c.entries {
+		if !entry.expiry.IsZero() && !now.Before(entry.expiry) {
+			delete(c.entries, key)
+		}
+	}
+}
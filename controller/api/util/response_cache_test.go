@@ -0,0 +1,132 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+)
+
+func TestResponseCache(t *testing.T) {
+	newReq := func(window string) *pb.StatSummaryRequest {
+		return &pb.StatSummaryRequest{
+			Selector: &pb.ResourceSelection{
+				Resource: &pb.Resource{Namespace: "emojivoto", Type: "deployment"},
+			},
+			TimeWindow: window,
+		}
+	}
+
+	fetches := 0
+	fetch := func(context.Context) (proto.Message, error) {
+		fetches++
+		return &pb.StatSummaryResponse{}, nil
+	}
+
+	t.Run("Shares the response of identical requests until it expires", func(t *testing.T) {
+		fetches = 0
+		now := time.Unix(0, 0)
+		cache := NewResponseCache(5*time.Second, nil)
+		cache.now = func() time.Time { return now }
+
+		cache.Get(context.Background(), "StatSummary", newReq("1m"), fetch)
+		cache.Get(context.Background(), "StatSummary", newReq("1m"), fetch)
+		if fetches != 1 {
+			t.Fatalf("Expected 1 fetch, got %d", fetches)
+		}
+
+		cache.Get(context.Background(), "StatSummary", newReq("10m"), fetch)
+		cache.Get(context.Background(), "StreamStats", newReq("1m"), fetch)
+		if fetches != 3 {
+			t.Fatalf("Expected 3 fetches, got %d", fetches)
+		}
+
+		now = now.Add(5 * time.Second)
+		cache.Get(context.Background(), "StatSummary", newReq("1m"), fetch)
+		if fetches != 4 {
+			t.Fatalf("Expected 4 fetches, got %d", fetches)
+		}
+	})
+
+	t.Run("Doesn't cache errors", func(t *testing.T) {
+		fetches = 0
+		cache := NewResponseCache(5*time.Second, nil)
+		failing := func(context.Context) (proto.Message, error) {
+			fetches++
+			return nil, errors.New("prometheus unavailable")
+		}
+
+		for i := 0; i < 2; i++ {
+			_, err := cache.Get(context.Background(), "StatSummary", newReq("1m"), failing)
+			if err == nil {
+				t.Fatalf("Expected an error, got none")
+			}
+		}
+		if fetches != 2 {
+			t.Fatalf("Expected 2 fetches, got %d", fetches)
+		}
+	})
+
+	t.Run("Fetches again for the waiting requests when the fetching request is canceled", func(t *testing.T) {
+		cache := NewResponseCache(5*time.Second, nil)
+		leaderCtx, cancel := context.WithCancel(context.Background())
+		fetching := make(chan struct{})
+		blocking := func(ctx context.Context) (proto.Message, error) {
+			close(fetching)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+
+		leaderErr := make(chan error)
+		go func() {
+			_, err := cache.Get(leaderCtx, "StatSummary", newReq("1m"), blocking)
+			leaderErr <- err
+		}()
+		<-fetching
+
+		waiterRsp := make(chan proto.Message)
+		go func() {
+			rsp, _ := cache.Get(context.Background(), "StatSummary", newReq("1m"), fetch)
+			waiterRsp <- rsp
+		}()
+
+		cancel()
+		if err := <-leaderErr; err != context.Canceled {
+			t.Fatalf("Expected %s, got %v", context.Canceled, err)
+		}
+		if rsp := <-waiterRsp; rsp == nil {
+			t.Fatalf("Expected the waiting request to get a response, got none")
+		}
+	})
+
+	t.Run("Reports the hits and misses", func(t *testing.T) {
+		hits, misses := 0, 0
+		cache := NewResponseCache(5*time.Second, func(api string, hit bool) {
+			if hit {
+				hits++
+			} else {
+				misses++
+			}
+		})
+
+		cache.Get(context.Background(), "StatSummary", newReq("1m"), fetch)
+		cache.Get(context.Background(), "StatSummary", newReq("1m"), fetch)
+		if hits != 1 || misses != 1 {
+			t.Fatalf("Expected 1 hit and 1 miss, got %d hits and %d misses", hits, misses)
+		}
+	})
+
+	t.Run("Caches nothing with a zero TTL", func(t *testing.T) {
+		fetches = 0
+		cache := NewResponseCache(0, nil)
+
+		cache.Get(context.Background(), "StatSummary", newReq("1m"), fetch)
+		cache.Get(context.Background(), "StatSummary", newReq("1m"), fetch)
+		if fetches != 2 {
+			t.Fatalf("Expected 2 fetches, got %d", fetches)
+		}
+	})
+}
@@ -0,0 +1,146 @@
+package util
+
+import (
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+)
+
+// maxPendingTapRequests caps the requests a tap filter holds back while
+// waiting for their response, so that requests never answered don't grow the
+// filter without bound.
+const maxPendingTapRequests = 10000
+
+// TapFilter holds back the request events of a tap until their response is
+// known, and only reports the requests whose responses match the filter of the
+// tap request. The requests filtered only by their gRPC method are reported
+// right away.
+type TapFilter struct {
+	statusClasses map[uint32]bool
+	minLatency    time.Duration
+	rpcMethod     string
+
+	// the request events waiting for their response
+	pending map[tapStreamKey]*pb.TapEvent
+	// the streams whose response matched, until their response ends
+	matched map[tapStreamKey]bool
+}
+
+// tapStreamKey identifies the HTTP stream of an event. Stream IDs are only
+// unique within a proxy, so the key includes the addresses of the stream.
+type tapStreamKey struct {
+	base        uint32
+	stream      uint64
+	direction   pb.TapEvent_ProxyDirection
+	source      string
+	destination string
+}
+
+// NewTapFilter returns nil when the filter doesn't filter any event.
+func NewTapFilter(filter *pb.TapByResourceRequest_Filter) (*TapFilter, error) {
+	if len(filter.GetStatusClasses()) == 0 && filter.GetMinLatency() == nil && filter.GetRpcMethod() == "" {
+		return nil, nil
+	}
+
+	f := &TapFilter{
+		rpcMethod:     filter.GetRpcMethod(),
+		statusClasses: make(map[uint32]bool),
+		pending:       make(map[tapStreamKey]*pb.TapEvent),
+		matched:       make(map[tapStreamKey]bool),
+	}
+	for _, class := range filter.GetStatusClasses() {
+		f.statusClasses[class] = true
+	}
+	if filter.GetMinLatency() != nil {
+		minLatency, err := ptypes.Duration(filter.GetMinLatency())
+		if err != nil {
+			return nil, err
+		}
+		f.minLatency = minLatency
+	}
+	return f, nil
+}
+
+// Apply returns the events to report after event: none while the response of
+// its request is unknown, or the held back request along with the response
+// once it matches.
+func (f *TapFilter) Apply(event *pb.TapEvent) []*pb.TapEvent {
+	if f == nil {
+		return []*pb.TapEvent{event}
+	}
+
+	switch ev := event.GetHttp().GetEvent().(type) {
+	case *pb.TapEvent_Http_RequestInit_:
+		if f.rpcMethod != "" && ev.RequestInit.GetPath() != f.rpcMethod {
+			return nil
+		}
+		key := streamKeyOf(event, ev.RequestInit.GetId())
+		if !f.filtersResponses() {
+			if len(f.matched) < maxPendingTapRequests {
+				f.matched[key] = true
+			}
+			return []*pb.TapEvent{event}
+		}
+		if len(f.pending) < maxPendingTapRequests {
+			f.pending[key] = event
+		}
+		return nil
+
+	case *pb.TapEvent_Http_ResponseInit_:
+		key := streamKeyOf(event, ev.ResponseInit.GetId())
+		if !f.filtersResponses() {
+			if !f.matched[key] {
+				return nil
+			}
+			return []*pb.TapEvent{event}
+		}
+		request, ok := f.pending[key]
+		delete(f.pending, key)
+		if !ok || !f.matches(ev.ResponseInit) {
+			return nil
+		}
+		f.matched[key] = true
+		return []*pb.TapEvent{request, event}
+
+	case *pb.TapEvent_Http_ResponseEnd_:
+		// the streams reset before their response never match
+		key := streamKeyOf(event, ev.ResponseEnd.GetId())
+		delete(f.pending, key)
+		if !f.matched[key] {
+			return nil
+		}
+		delete(f.matched, key)
+		return []*pb.TapEvent{event}
+	}
+
+	return nil
+}
+
+func (f *TapFilter) filtersResponses() bool {
+	return len(f.statusClasses) > 0 || f.minLatency > 0
+}
+
+func (f *TapFilter) matches(rsp *pb.TapEvent_Http_ResponseInit) bool {
+	if len(f.statusClasses) > 0 && !f.statusClasses[rsp.GetHttpStatus()/100] {
+		return false
+	}
+	if f.minLatency > 0 {
+		latency, err := ptypes.Duration(rsp.GetSinceRequestInit())
+		if err != nil || latency < f.minLatency {
+			return false
+		}
+	}
+	return true
+}
+
+func streamKeyOf(event *pb.TapEvent, id *pb.TapEvent_Http_StreamId) tapStreamKey {
+	return tapStreamKey{
+		base:        id.GetBase(),
+		stream:      id.GetStream(),
+		direction:   event.GetProxyDirection(),
+		source:      proto.CompactTextString(event.GetSource()),
+		destination: proto.CompactTextString(event.GetDestination()),
+	}
+}
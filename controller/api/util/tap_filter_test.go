@@ -0,0 +1,135 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+)
+
+func tapHTTPEvent(http *pb.TapEvent_Http) *pb.TapEvent {
+	return &pb.TapEvent{
+		Source:         &pb.TcpAddress{Port: 1983},
+		Destination:    &pb.TcpAddress{Port: 8080},
+		ProxyDirection: pb.TapEvent_INBOUND,
+		Event:          &pb.TapEvent_Http_{Http: http},
+	}
+}
+
+func tapRequestInit(stream uint64, path string) *pb.TapEvent {
+	return tapHTTPEvent(&pb.TapEvent_Http{
+		Event: &pb.TapEvent_Http_RequestInit_{
+			RequestInit: &pb.TapEvent_Http_RequestInit{
+				Id:   &pb.TapEvent_Http_StreamId{Base: 1, Stream: stream},
+				Path: path,
+			},
+		},
+	})
+}
+
+func tapResponseInit(stream uint64, status uint32, latency time.Duration) *pb.TapEvent {
+	return tapHTTPEvent(&pb.TapEvent_Http{
+		Event: &pb.TapEvent_Http_ResponseInit_{
+			ResponseInit: &pb.TapEvent_Http_ResponseInit{
+				Id:               &pb.TapEvent_Http_StreamId{Base: 1, Stream: stream},
+				HttpStatus:       status,
+				SinceRequestInit: ptypes.DurationProto(latency),
+			},
+		},
+	})
+}
+
+func tapResponseEnd(stream uint64) *pb.TapEvent {
+	return tapHTTPEvent(&pb.TapEvent_Http{
+		Event: &pb.TapEvent_Http_ResponseEnd_{
+			ResponseEnd: &pb.TapEvent_Http_ResponseEnd{
+				Id: &pb.TapEvent_Http_StreamId{Base: 1, Stream: stream},
+			},
+		},
+	})
+}
+
+func TestTapFilter(t *testing.T) {
+	events := []*pb.TapEvent{
+		tapRequestInit(1, "/emojivoto.v1.VotingService/VoteDoughnut"),
+		tapRequestInit(2, "/emojivoto.v1.EmojiService/ListAll"),
+		tapRequestInit(3, "/emojivoto.v1.VotingService/VoteDoughnutAndCoffee"),
+		tapResponseInit(1, 200, 10*time.Millisecond),
+		tapResponseInit(2, 503, 10*time.Millisecond),
+		tapResponseInit(3, 200, 300*time.Millisecond),
+		tapResponseEnd(1),
+		tapResponseEnd(2),
+		tapResponseEnd(3),
+		// a stream reset before its response
+		tapRequestInit(4, "/emojivoto.v1.VotingService/VoteDoughnut"),
+		tapResponseEnd(4),
+	}
+
+	testCases := []struct {
+		name     string
+		filter   *pb.TapByResourceRequest_Filter
+		expected []*pb.TapEvent
+	}{
+		{
+			name:     "Reports all the events without filter",
+			filter:   nil,
+			expected: events,
+		},
+		{
+			name:     "Reports the requests with a response in the status classes",
+			filter:   &pb.TapByResourceRequest_Filter{StatusClasses: []uint32{5}},
+			expected: []*pb.TapEvent{events[1], events[4], events[7]},
+		},
+		{
+			name:     "Reports the requests with a response slower than the minimum latency",
+			filter:   &pb.TapByResourceRequest_Filter{MinLatency: ptypes.DurationProto(200 * time.Millisecond)},
+			expected: []*pb.TapEvent{events[2], events[5], events[8]},
+		},
+		{
+			name: "Reports the requests matching both the status classes and the minimum latency",
+			filter: &pb.TapByResourceRequest_Filter{
+				StatusClasses: []uint32{2},
+				MinLatency:    ptypes.DurationProto(5 * time.Millisecond),
+			},
+			expected: []*pb.TapEvent{events[0], events[3], events[2], events[5], events[6], events[8]},
+		},
+		{
+			name:     "Reports the requests to the gRPC method right away",
+			filter:   &pb.TapByResourceRequest_Filter{RpcMethod: "/emojivoto.v1.VotingService/VoteDoughnut"},
+			expected: []*pb.TapEvent{events[0], events[3], events[6], events[9], events[10]},
+		},
+		{
+			name: "Reports the requests to the gRPC method matching the status classes",
+			filter: &pb.TapByResourceRequest_Filter{
+				StatusClasses: []uint32{2},
+				RpcMethod:     "/emojivoto.v1.VotingService/VoteDoughnut",
+			},
+			expected: []*pb.TapEvent{events[0], events[3], events[6]},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := NewTapFilter(tc.filter)
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+
+			reported := make([]*pb.TapEvent, 0)
+			for _, event := range events {
+				reported = append(reported, filter.Apply(event)...)
+			}
+
+			if len(reported) != len(tc.expected) {
+				t.Fatalf("Expected %d events, got %d: %v", len(tc.expected), len(reported), reported)
+			}
+			for i := range reported {
+				if reported[i] != tc.expected[i] {
+					t.Fatalf("Expected event %d to be %v, got %v", i, tc.expected[i], reported[i])
+				}
+			}
+		})
+	}
+}
@@ -53,6 +53,9 @@ type CertificateAndPrivateKey struct {
 
 	// The PKCS#8 DER-encoded (binary, not PEM) private key.
 	PrivateKey []byte
+
+	// The time after which the certificate is no longer valid.
+	NotAfter time.Time
 }
 
 // NewCA is the only way to create a CA.
@@ -110,6 +113,12 @@ func (ca *CA) TrustAnchorPEM() string {
 	return ca.rootPEM
 }
 
+// TrustAnchorNotAfter returns the time after which the certificate of the
+// trust anchor is no longer valid.
+func (ca *CA) TrustAnchorNotAfter() time.Time {
+	return ca.root.NotAfter
+}
+
 // IssueEndEntityCertificate creates a new certificate that is valid for the
 // given DNS name, generating a new keypair for it.
 func (ca *CA) IssueEndEntityCertificate(dnsName string) (*CertificateAndPrivateKey, error) {
@@ -131,6 +140,7 @@ func (ca *CA) IssueEndEntityCertificate(dnsName string) (*CertificateAndPrivateK
 	return &CertificateAndPrivateKey{
 		Certificate: crt,
 		PrivateKey:  p8,
+		NotAfter:    template.NotAfter,
 	}, nil
 }
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/linkerd/linkerd2/controller/k8s"
 	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 	"k8s.io/api/admissionregistration/v1beta1"
 	"k8s.io/api/core/v1"
@@ -18,6 +19,26 @@ import (
 	"k8s.io/client-go/util/workqueue"
 )
 
+var (
+	trustAnchorExpiration = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "ca_trust_anchor_expiration_timestamp_seconds",
+			Help: "The time after which the certificate of the trust anchor is no longer valid, in seconds since the epoch.",
+		},
+	)
+	certificateExpiration = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ca_certificate_expiration_timestamp_seconds",
+			Help: "The time after which the certificates issued to the secrets are no longer valid, in seconds since the epoch.",
+		},
+		[]string{"secret_namespace", "secret"},
+	)
+)
+
+func init() {
+	prometheus.MustRegister(trustAnchorExpiration, certificateExpiration)
+}
+
 type CertificateController struct {
 	namespace   string
 	k8sAPI      *k8s.API
@@ -37,6 +58,7 @@ func NewCertificateController(controllerNamespace string, k8sAPI *k8s.API, proxy
 	if err != nil {
 		return nil, err
 	}
+	trustAnchorExpiration.Set(float64(ca.TrustAnchorNotAfter().Unix()))
 
 	c := &CertificateController{
 		namespace: controllerNamespace,
@@ -163,6 +185,9 @@ func (c *CertificateController) syncSecret(key string) error {
 	if apierrors.IsAlreadyExists(err) {
 		_, err = c.k8sAPI.Client.CoreV1().Secrets(identity.Namespace).Update(secret)
 	}
+	if err == nil {
+		certificateExpiration.WithLabelValues(identity.Namespace, secretName).Set(float64(certAndPrivateKey.NotAfter.Unix()))
+	}
 
 	return err
 }
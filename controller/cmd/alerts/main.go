@@ -0,0 +1,70 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/alerts"
+	"github.com/linkerd/linkerd2/controller/api/public"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	"github.com/linkerd/linkerd2/pkg/admin"
+	"github.com/linkerd/linkerd2/pkg/flags"
+	log "github.com/sirupsen/logrus"
+)
+
+func main() {
+	apiAddr := flag.String("api-addr", "127.0.0.1:8085", "address of the public API")
+	metricsAddr := flag.String("metrics-addr", ":9993", "address to serve scrapable metrics on")
+	kubeConfigPath := flag.String("kubeconfig", "", "path to kube config")
+	controllerNamespace := flag.String("controller-namespace", "linkerd", "namespace in which Linkerd is installed")
+	configPath := flag.String("config", "/var/run/linkerd/alerts/config.yml", "path to the YAML alerting config with the rules and where to send their alerts")
+	interval := flag.Duration("interval", time.Minute, "interval between two evaluations of the rules")
+	flags.ConfigureAndParse()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+
+	config, err := alerts.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	apiClient, err := public.NewInternalClient(*controllerNamespace, *apiAddr)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	sinks := []alerts.Sink{}
+	if config.Events {
+		k8sClient, err := k8s.NewClientSet(*kubeConfigPath)
+		if err != nil {
+			log.Fatalf("failed to create Kubernetes client: %s", err)
+		}
+		sinks = append(sinks, alerts.NewEventSink(k8sClient))
+	}
+	for _, url := range config.Webhooks {
+		sinks = append(sinks, alerts.NewWebhookSink(url))
+	}
+	if config.AlertmanagerURL != "" {
+		sinks = append(sinks, alerts.NewAlertmanagerSink(config.AlertmanagerURL))
+	}
+
+	evaluator := alerts.NewEvaluator(apiClient, config.Rules, sinks)
+	evaluatorStop := make(chan struct{})
+	go func() {
+		log.Infof("evaluating %d alerting rules every %s", len(config.Rules), *interval)
+		evaluator.Run(*interval, evaluatorStop)
+	}()
+
+	ready := make(chan struct{})
+	close(ready)
+	go admin.StartServer(*metricsAddr, ready)
+
+	<-stop
+
+	log.Info("shutting down the alerting controller")
+	close(evaluatorStop)
+}
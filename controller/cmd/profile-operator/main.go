@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/public"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	"github.com/linkerd/linkerd2/controller/profile-operator"
+	"github.com/linkerd/linkerd2/pkg/admin"
+	"github.com/linkerd/linkerd2/pkg/flags"
+	log "github.com/sirupsen/logrus"
+)
+
+func main() {
+	apiAddr := flag.String("api-addr", "127.0.0.1:8085", "address of the public API")
+	metricsAddr := flag.String("metrics-addr", ":9992", "address to serve scrapable metrics on")
+	kubeConfigPath := flag.String("kubeconfig", "", "path to kube config")
+	controllerNamespace := flag.String("controller-namespace", "linkerd", "namespace in which Linkerd is installed")
+	interval := flag.Duration("interval", 10*time.Minute, "interval between two generations of the service profiles")
+	timeWindow := flag.String("time-window", "10m", "window of the route metrics telling the services with requests falling into their default route")
+	minRequests := flag.Uint64("min-requests", 100, "requests falling into the default route of a service from which it is tapped")
+	tapDuration := flag.Duration("tap-duration", 30*time.Second, "how long the pods of a service are tapped")
+	tapMaxRps := flag.Float64("tap-max-rps", 100, "maximum rate of requests tapped from each workload")
+	paramThreshold := flag.Uint("param-threshold", 10, "distinct values of a path segment from which it is collapsed into a {param}, 0 to only collapse identifiers")
+	routeLimit := flag.Uint("route-limit", 50, "maximum number of routes of a generated service profile, 0 for no limit")
+	flags.ConfigureAndParse()
+
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+
+	apiClient, err := public.NewInternalClient(*controllerNamespace, *apiAddr)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
+	k8sClient, err := k8s.NewClientSet(*kubeConfigPath)
+	if err != nil {
+		log.Fatalf("failed to create Kubernetes client: %s", err)
+	}
+	spClient, err := k8s.NewSpClientSet(*kubeConfigPath)
+	if err != nil {
+		log.Fatalf("failed to create ServiceProfile client: %s", err)
+	}
+	k8sAPI := k8s.NewAPI(k8sClient, spClient, "", k8s.Pod, k8s.RS, k8s.SP, k8s.Svc)
+
+	profileOperator := operator.NewOperator(apiClient, k8sAPI, *controllerNamespace, operator.Config{
+		TimeWindow:     *timeWindow,
+		MinRequests:    *minRequests,
+		TapDuration:    *tapDuration,
+		TapMaxRps:      float32(*tapMaxRps),
+		ParamThreshold: *paramThreshold,
+		RouteLimit:     *routeLimit,
+	})
+
+	ready := make(chan struct{})
+	go k8sAPI.Sync(ready)
+
+	operatorStop := make(chan struct{})
+	go func() {
+		<-ready
+		log.Infof("generating the service profiles every %s", *interval)
+		profileOperator.Run(*interval, operatorStop)
+	}()
+
+	go admin.StartServer(*metricsAddr, ready)
+
+	<-stop
+
+	log.Info("shutting down the profile operator")
+	close(operatorStop)
+}
@@ -24,6 +24,7 @@ func main() {
 	controllerNamespace := flag.String("controller-namespace", "linkerd", "namespace in which Linkerd is installed")
 	volumeMountsWaitTime := flag.Duration("volume-mounts-wait", 3*time.Minute, "maximum wait time for the secret volumes to mount before the timeout expires")
 	webhookServiceName := flag.String("webhook-service", "linkerd-proxy-injector.linkerd.io", "name of the admission webhook")
+	noInitContainer := flag.Bool("no-init-container", false, "omit the proxy-init container, as the linkerd-cni plugin sets up the iptables rules of the pods")
 	flags.ConfigureAndParse()
 
 	stop := make(chan os.Signal, 1)
@@ -34,6 +35,15 @@ func main() {
 	if err != nil {
 		log.Fatalf("failed to initialize Kubernetes client: %s", err)
 	}
+	spClient, err := k8s.NewSpClientSet(*kubeconfig)
+	if err != nil {
+		log.Fatalf("failed to initialize Kubernetes client: %s", err)
+	}
+
+	// the mesh config is watched, so that changes apply to the next injected
+	// pods without a restart
+	k8sAPI := k8s.NewAPI(k8sClient, spClient, *controllerNamespace, k8s.MC)
+	go k8sAPI.Sync(nil)
 
 	log.Infof("waiting for the trust anchors volume to mount at %s", k8sPkg.MountPathTLSTrustAnchor)
 	if err := waitForMounts(*volumeMountsWaitTime, k8sPkg.MountPathTLSTrustAnchor); err != context.Canceled {
@@ -66,7 +76,11 @@ func main() {
 		FileTLSTrustAnchorVolumeSpec: k8sPkg.MountPathTLSTrustAnchorVolumeSpec,
 		FileTLSIdentityVolumeSpec:    k8sPkg.MountPathTLSIdentityVolumeSpec,
 	}
-	s, err := injector.NewWebhookServer(k8sClient, resources, *addr, *controllerNamespace, certFile, keyFile)
+	if *noInitContainer {
+		resources.FileProxyInitSpec = ""
+	}
+	meshConfigs := k8sAPI.MC().Lister().MeshConfigs(*controllerNamespace)
+	s, err := injector.NewWebhookServer(k8sClient, resources, *addr, *controllerNamespace, certFile, keyFile, meshConfigs)
 	if err != nil {
 		log.Fatalf("failed to initialize the webhook server: %s", err)
 	}
@@ -2,18 +2,25 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
 	"os"
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/linkerd/linkerd2/controller/api/public"
+	tapPb "github.com/linkerd/linkerd2/controller/gen/controller/tap"
 	"github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/linkerd/linkerd2/controller/tap"
 	"github.com/linkerd/linkerd2/pkg/admin"
 	"github.com/linkerd/linkerd2/pkg/flags"
-	promApi "github.com/prometheus/client_golang/api"
+	"github.com/linkerd/linkerd2/pkg/prometheus"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -21,11 +28,29 @@ func main() {
 	addr := flag.String("addr", ":8085", "address to serve on")
 	kubeConfigPath := flag.String("kubeconfig", "", "path to kube config")
 	prometheusUrl := flag.String("prometheus-url", "http://127.0.0.1:9090", "prometheus url")
+	prometheusBearerTokenFile := flag.String("prometheus-bearer-token-file", "", "path to a file with the bearer token to authenticate to prometheus with")
+	prometheusBasicAuthUsername := flag.String("prometheus-basic-auth-username", "", "username to authenticate to prometheus with")
+	prometheusBasicAuthPasswordFile := flag.String("prometheus-basic-auth-password-file", "", "path to a file with the password to authenticate to prometheus with")
+	prometheusCAFile := flag.String("prometheus-ca-file", "", "path to the CA bundle that verifies the prometheus server certificate")
+	prometheusCertFile := flag.String("prometheus-cert-file", "", "path to the client certificate presented to prometheus")
+	prometheusKeyFile := flag.String("prometheus-key-file", "", "path to the key of the client certificate presented to prometheus")
+	prometheusQueryStep := flag.String("prometheus-query-step", "", "query resolution step sent to Thanos/Cortex/Mimir backends, e.g. 5m")
+	prometheusMaxSourceResolution := flag.String("prometheus-max-source-resolution", "", "coarsest downsampling level Thanos reads, e.g. 5m, 1h or auto")
+	prometheusPartialResponse := flag.String("prometheus-partial-response", "", "whether Thanos returns partial responses when store nodes are unavailable (true or false)")
 	metricsAddr := flag.String("metrics-addr", ":9995", "address to serve scrapable metrics on")
 	tapAddr := flag.String("tap-addr", "127.0.0.1:8088", "address of tap service")
 	controllerNamespace := flag.String("controller-namespace", "linkerd", "namespace in which Linkerd is installed")
 	singleNamespace := flag.Bool("single-namespace", false, "only operate in the controller namespace")
 	ignoredNamespaces := flag.String("ignore-namespaces", "kube-system", "comma separated list of namespaces to not list pods from")
+	externalTapAddr := flag.String("external-tap-addr", "", "address to serve the Tap API to external consumers on, with TLS; disabled when empty")
+	externalTapCert := flag.String("external-tap-tls-cert", "", "path to the TLS certificate of the external Tap API")
+	externalTapKey := flag.String("external-tap-tls-key", "", "path to the TLS key of the external Tap API")
+	externalTapClientCA := flag.String("external-tap-client-ca", "", "path to the CA bundle verifying the client certificates of external tap consumers")
+	externalTapPolicy := flag.String("external-tap-policy", "", "path to the YAML tap access policy listing the external tap consumers")
+	routeStatsDir := flag.String("route-stats-dir", "", "directory, typically on a persistent volume, to keep route stats snapshots in; route stats retention is disabled when empty")
+	routeStatsInterval := flag.Duration("route-stats-interval", 10*time.Minute, "interval between route stats snapshots")
+	routeStatsRetention := flag.Duration("route-stats-retention", 90*24*time.Hour, "how long to keep route stats snapshots")
+	cacheTTL := flag.Duration("metrics-cache-ttl", 5*time.Second, "how long identical metrics requests share a response, 0 to disable")
 	flags.ConfigureAndParse()
 
 	stop := make(chan os.Signal, 1)
@@ -41,26 +66,52 @@ func main() {
 	if err != nil {
 		log.Fatal(err.Error())
 	}
+	spClient, err := k8s.NewSpClientSet(*kubeConfigPath)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
 	restrictToNamespace := ""
 	if *singleNamespace {
 		restrictToNamespace = *controllerNamespace
 	}
 	k8sAPI := k8s.NewAPI(
 		k8sClient,
-		nil,
+		spClient,
 		restrictToNamespace,
+		k8s.CronJob,
 		k8s.Deploy,
+		k8s.Job,
 		k8s.Pod,
 		k8s.RC,
 		k8s.RS,
 		k8s.Svc,
+		k8s.SP,
 	)
 
-	prometheusClient, err := promApi.NewClient(promApi.Config{Address: *prometheusUrl})
+	prometheusClient, err := prometheus.NewClient(prometheus.ClientConfig{
+		Address:               *prometheusUrl,
+		BearerTokenFile:       *prometheusBearerTokenFile,
+		BasicAuthUsername:     *prometheusBasicAuthUsername,
+		BasicAuthPasswordFile: *prometheusBasicAuthPasswordFile,
+		CAFile:                *prometheusCAFile,
+		CertFile:              *prometheusCertFile,
+		KeyFile:               *prometheusKeyFile,
+		Step:                  *prometheusQueryStep,
+		MaxSourceResolution:   *prometheusMaxSourceResolution,
+		PartialResponse:       *prometheusPartialResponse,
+	})
 	if err != nil {
 		log.Fatal(err.Error())
 	}
 
+	var routeStatsStore *public.RouteStatsStore
+	if *routeStatsDir != "" {
+		routeStatsStore, err = public.NewRouteStatsStore(*routeStatsDir, *routeStatsRetention)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+	}
+
 	server := public.NewServer(
 		*addr,
 		prometheusClient,
@@ -68,6 +119,8 @@ func main() {
 		k8sAPI,
 		*controllerNamespace,
 		strings.Split(*ignoredNamespaces, ","),
+		*cacheTTL,
+		routeStatsStore,
 	)
 
 	ready := make(chan struct{})
@@ -79,6 +132,30 @@ func main() {
 		server.ListenAndServe()
 	}()
 
+	if *externalTapAddr != "" {
+		externalTapServer, err := newExternalTapServer(*externalTapAddr, *externalTapCert, *externalTapKey, *externalTapClientCA, *externalTapPolicy, tapClient)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		go func() {
+			log.Infof("starting external tap server on %+v", *externalTapAddr)
+			if err := externalTapServer.ListenAndServeTLS("", ""); err != http.ErrServerClosed {
+				log.Fatal(err.Error())
+			}
+		}()
+		defer externalTapServer.Shutdown(context.Background())
+	}
+
+	if routeStatsStore != nil {
+		recorder := public.NewRouteStatsRecorder(prometheusClient, k8sAPI, *controllerNamespace, routeStatsStore, *routeStatsInterval)
+		recorderStop := make(chan struct{})
+		defer close(recorderStop)
+		go func() {
+			<-ready
+			recorder.Run(recorderStop)
+		}()
+	}
+
 	go admin.StartServer(*metricsAddr, ready)
 
 	<-stop
@@ -86,3 +163,35 @@ func main() {
 	log.Infof("shutting down HTTP server on %+v", *addr)
 	server.Shutdown(context.Background())
 }
+
+func newExternalTapServer(addr, certFile, keyFile, clientCAFile, policyFile string, tapClient tapPb.TapClient) (*http.Server, error) {
+	if certFile == "" || keyFile == "" || policyFile == "" {
+		return nil, fmt.Errorf("the external Tap API requires a TLS certificate, a TLS key and a tap access policy")
+	}
+
+	policy, err := public.LoadTapAccessPolicy(policyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{Certificates: []tls.Certificate{cert}}
+
+	if clientCAFile != "" {
+		pem, err := ioutil.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.ClientCAs = x509.NewCertPool()
+		if !tlsConfig.ClientCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", clientCAFile)
+		}
+		// consumers authenticating with a token don't present a certificate
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	return public.NewExternalTapServer(addr, tlsConfig, policy, tapClient), nil
+}
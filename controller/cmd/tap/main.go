@@ -4,10 +4,15 @@ import (
 	"flag"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
+	apiUtil "github.com/linkerd/linkerd2/controller/api/util"
+	public "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/linkerd/linkerd2/controller/tap"
+	"github.com/linkerd/linkerd2/controller/tap/otlp"
 	"github.com/linkerd/linkerd2/pkg/admin"
 	"github.com/linkerd/linkerd2/pkg/flags"
 	log "github.com/sirupsen/logrus"
@@ -20,8 +25,29 @@ func main() {
 	controllerNamespace := flag.String("controller-namespace", "linkerd", "namespace in which Linkerd is installed")
 	singleNamespace := flag.Bool("single-namespace", false, "only operate in the controller namespace")
 	tapPort := flag.Uint("tap-port", 4190, "proxy tap port to connect to")
+	otlpEndpoint := flag.String("otlp-endpoint", "", "OTLP/HTTP endpoint of an OpenTelemetry collector to export the tapped requests to as spans, e.g. http://otel-collector.tracing:4318; disabled if empty")
+	otlpInterval := flag.Duration("otlp-export-interval", 5*time.Second, "interval between the exports of the spans to the OpenTelemetry collector")
+	otlpTargets := flag.String("otlp-targets", "", "comma-separated resources to tap for the export to the OpenTelemetry collector, e.g. deploy/web,deploy/voting")
+	otlpNamespace := flag.String("otlp-namespace", "default", "namespace of the resources of -otlp-targets")
 	flags.ConfigureAndParse()
 
+	var exportTargets []*public.TapByResourceRequest
+	if *otlpEndpoint != "" {
+		if *otlpTargets == "" {
+			log.Fatal("-otlp-targets is required to export to -otlp-endpoint")
+		}
+		for _, target := range strings.Split(*otlpTargets, ",") {
+			req, err := apiUtil.BuildTapByResourceRequest(apiUtil.TapRequestParams{
+				Resource:  target,
+				Namespace: *otlpNamespace,
+			})
+			if err != nil {
+				log.Fatalf("invalid -otlp-targets: %s", err)
+			}
+			exportTargets = append(exportTargets, req)
+		}
+	}
+
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 
@@ -37,7 +63,9 @@ func main() {
 		k8sClient,
 		nil,
 		restrictToNamespace,
+		k8s.CronJob,
 		k8s.Deploy,
+		k8s.Job,
 		k8s.Pod,
 		k8s.RC,
 		k8s.Svc,
@@ -53,6 +81,17 @@ func main() {
 
 	go k8sAPI.Sync(ready)
 
+	if len(exportTargets) > 0 {
+		exporter := otlp.NewExporter(*otlpEndpoint, *otlpInterval)
+		exportStop := make(chan struct{})
+		defer close(exportStop)
+		go exporter.Run(exportStop)
+		go func() {
+			<-ready
+			tap.ExportTaps(*tapPort, *controllerNamespace, k8sAPI, exportTargets, exporter, exportStop)
+		}()
+	}
+
 	go func() {
 		log.Println("starting gRPC server on", *addr)
 		server.Serve(lis)
@@ -35,6 +35,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
 		&ServiceProfile{},
 		&ServiceProfileList{},
+		&MeshConfig{},
+		&MeshConfigList{},
+		&AuditEvent{},
+		&AuditEventList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
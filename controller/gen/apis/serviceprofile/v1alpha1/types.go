@@ -27,12 +27,28 @@ type ServiceProfile struct {
 
 type ServiceProfileSpec struct {
 	Routes []*RouteSpec `json:"routes"`
+	// BaseProfile is the name of a ServiceProfile of the same namespace whose
+	// routes are inherited, after the routes of this profile; a route of this
+	// profile overrides the route of the base profile with the same name
+	BaseProfile string `json:"baseProfile,omitempty"`
 }
 
 type RouteSpec struct {
 	Name            string           `json:"name"`
 	Condition       *RequestMatch    `json:"condition"`
 	ResponseClasses []*ResponseClass `json:"responseClasses,omitempty"`
+	SLO             *RouteSLO        `json:"slo,omitempty"`
+}
+
+// RouteSLO is the service level objective of a route over a rolling window.
+// The success rate and latency objectives are both optional.
+type RouteSLO struct {
+	// SuccessRate is the objective ratio of successful responses, e.g. 0.999
+	SuccessRate float64 `json:"successRate,omitempty"`
+	// LatencyMs is the objective p99 latency of the responses
+	LatencyMs uint64 `json:"latencyMs,omitempty"`
+	// Window is the Prometheus duration of the rolling window, e.g. "30d"
+	Window string `json:"window"`
 }
 
 type RequestMatch struct {
@@ -69,3 +85,88 @@ type ServiceProfileList struct {
 
 	Items []ServiceProfile `json:"items"`
 }
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MeshConfig holds the mesh-wide configuration that the control plane reads at
+// runtime. The control plane reads the MeshConfig named linkerd in its
+// namespace.
+type MeshConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MeshConfigSpec `json:"spec"`
+}
+
+// MeshConfigSpec holds the mesh-wide defaults of the proxy configuration. The
+// proxy injector applies them to the pods it injects, unless overridden by the
+// annotations of the namespace or pod. Empty fields keep the install-time
+// defaults.
+type MeshConfigSpec struct {
+	// ProxyLogLevel is the log level of the proxies, e.g. "warn,linkerd2_proxy=info"
+	ProxyLogLevel string `json:"proxyLogLevel,omitempty"`
+	// OpaquePorts are the inbound ports whose traffic is proxied as TCP,
+	// e.g. "3306,5432"
+	OpaquePorts string `json:"opaquePorts,omitempty"`
+	// SkipOutboundCIDRs are the networks whose outbound traffic bypasses the
+	// proxies
+	SkipOutboundCIDRs []string `json:"skipOutboundCIDRs,omitempty"`
+	// DefaultInjectPolicy is the inject policy of the workloads whose pods
+	// and namespace don't have the linkerd.io/inject annotation, "enabled" or
+	// "disabled"
+	DefaultInjectPolicy string `json:"defaultInjectPolicy,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// MeshConfigList is a list of MeshConfig resources
+type MeshConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []MeshConfig `json:"items"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AuditEvent records a CLI operation that changed the configuration of the
+// mesh. The CLI creates one in the control plane namespace for every such
+// operation.
+type AuditEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec AuditEventSpec `json:"spec"`
+}
+
+// AuditEventSpec describes who ran an operation, when, and how.
+type AuditEventSpec struct {
+	// Operation is the CLI command that was run, e.g. "install"
+	Operation string `json:"operation"`
+	// User is the Kubernetes user the operation was run as
+	User string `json:"user,omitempty"`
+	// Time is when the operation was run
+	Time metav1.Time `json:"time"`
+	// Version is the version of the CLI that ran the operation
+	Version string `json:"version"`
+	// Args are the arguments of the command, e.g. the field and value of
+	// "config-set"
+	Args []string `json:"args,omitempty"`
+	// Flags are the flags set on the command line, e.g. "--ha=true"
+	Flags []string `json:"flags,omitempty"`
+	// ConfigHash is the hash of the configuration applied by the operation,
+	// if any
+	ConfigHash string `json:"configHash,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AuditEventList is a list of AuditEvent resources
+type AuditEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []AuditEvent `json:"items"`
+}
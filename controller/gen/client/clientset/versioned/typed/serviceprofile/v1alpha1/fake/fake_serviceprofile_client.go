@@ -28,6 +28,10 @@ type FakeLinkerdV1alpha1 struct {
 	*testing.Fake
 }
 
+func (c *FakeLinkerdV1alpha1) MeshConfigs(namespace string) v1alpha1.MeshConfigInterface {
+	return &FakeMeshConfigs{c, namespace}
+}
+
 func (c *FakeLinkerdV1alpha1) ServiceProfiles(namespace string) v1alpha1.ServiceProfileInterface {
 	return &FakeServiceProfiles{c, namespace}
 }
@@ -27,6 +27,7 @@ import (
 
 type LinkerdV1alpha1Interface interface {
 	RESTClient() rest.Interface
+	MeshConfigsGetter
 	ServiceProfilesGetter
 }
 
@@ -35,6 +36,10 @@ type LinkerdV1alpha1Client struct {
 	restClient rest.Interface
 }
 
+func (c *LinkerdV1alpha1Client) MeshConfigs(namespace string) MeshConfigInterface {
+	return newMeshConfigs(c, namespace)
+}
+
 func (c *LinkerdV1alpha1Client) ServiceProfiles(namespace string) ServiceProfileInterface {
 	return newServiceProfiles(c, namespace)
 }
@@ -53,6 +53,8 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=linkerd.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithResource("meshconfigs"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Linkerd().V1alpha1().MeshConfigs().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("serviceprofiles"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Linkerd().V1alpha1().ServiceProfiles().Informer()}, nil
 
@@ -18,6 +18,14 @@ limitations under the License.
 
 package v1alpha1
 
+// MeshConfigListerExpansion allows custom methods to be added to
+// MeshConfigLister.
+type MeshConfigListerExpansion interface{}
+
+// MeshConfigNamespaceListerExpansion allows custom methods to be added to
+// MeshConfigNamespaceLister.
+type MeshConfigNamespaceListerExpansion interface{}
+
 // ServiceProfileListerExpansion allows custom methods to be added to
 // ServiceProfileLister.
 type ServiceProfileListerExpansion interface{}
@@ -951,10 +951,14 @@ type TapByResourceRequest struct {
 	// Selects over events to be reported.
 	Match *TapByResourceRequest_Match `protobuf:"bytes,2,opt,name=match,proto3" json:"match,omitempty"`
 	// Limits the number of events to be inspected.
-	MaxRps               float32  `protobuf:"fixed32,3,opt,name=maxRps,proto3" json:"maxRps,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	MaxRps float32 `protobuf:"fixed32,3,opt,name=maxRps,proto3" json:"maxRps,omitempty"`
+	// Selects over the responses of the requests to be reported. Unlike the
+	// match, evaluated by the proxies, the filter is applied to the events by
+	// the public API, as the responses are only known after the requests.
+	Filter               *TapByResourceRequest_Filter `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
 }
 
 func (m *TapByResourceRequest) Reset()         { *m = TapByResourceRequest{} }
@@ -1002,6 +1006,13 @@ func (m *TapByResourceRequest) GetMaxRps() float32 {
 	return 0
 }
 
+func (m *TapByResourceRequest) GetFilter() *TapByResourceRequest_Filter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
 type TapByResourceRequest_Match struct {
 	// Types that are valid to be assigned to Match:
 	//	*TapByResourceRequest_Match_All
@@ -1479,6 +1490,65 @@ func _TapByResourceRequest_Match_Http_OneofSizer(msg proto.Message) (n int) {
 	return n
 }
 
+type TapByResourceRequest_Filter struct {
+	// Matches the responses whose HTTP status is in one of these classes,
+	// e.g. 5 for 5xx; any status when empty.
+	StatusClasses []uint32 `protobuf:"varint,1,rep,packed,name=status_classes,json=statusClasses,proto3" json:"status_classes,omitempty"`
+	// Matches the responses received at least this long after their request.
+	MinLatency *duration.Duration `protobuf:"bytes,2,opt,name=min_latency,json=minLatency,proto3" json:"min_latency,omitempty"`
+	// Matches the requests to this gRPC method, e.g. /pkg.Service/Method, as
+	// opposed to the prefix matched by the `path` of an HTTP match.
+	RpcMethod            string   `protobuf:"bytes,3,opt,name=rpc_method,json=rpcMethod,proto3" json:"rpc_method,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TapByResourceRequest_Filter) Reset()         { *m = TapByResourceRequest_Filter{} }
+func (m *TapByResourceRequest_Filter) String() string { return proto.CompactTextString(m) }
+func (*TapByResourceRequest_Filter) ProtoMessage()    {}
+func (*TapByResourceRequest_Filter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_135b2b880504db8b, []int{9, 1}
+}
+func (m *TapByResourceRequest_Filter) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TapByResourceRequest_Filter.Unmarshal(m, b)
+}
+func (m *TapByResourceRequest_Filter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TapByResourceRequest_Filter.Marshal(b, m, deterministic)
+}
+func (dst *TapByResourceRequest_Filter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TapByResourceRequest_Filter.Merge(dst, src)
+}
+func (m *TapByResourceRequest_Filter) XXX_Size() int {
+	return xxx_messageInfo_TapByResourceRequest_Filter.Size(m)
+}
+func (m *TapByResourceRequest_Filter) XXX_DiscardUnknown() {
+	xxx_messageInfo_TapByResourceRequest_Filter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TapByResourceRequest_Filter proto.InternalMessageInfo
+
+func (m *TapByResourceRequest_Filter) GetStatusClasses() []uint32 {
+	if m != nil {
+		return m.StatusClasses
+	}
+	return nil
+}
+
+func (m *TapByResourceRequest_Filter) GetMinLatency() *duration.Duration {
+	if m != nil {
+		return m.MinLatency
+	}
+	return nil
+}
+
+func (m *TapByResourceRequest_Filter) GetRpcMethod() string {
+	if m != nil {
+		return m.RpcMethod
+	}
+	return ""
+}
+
 type HttpMethod struct {
 	// Types that are valid to be assigned to Type:
 	//	*HttpMethod_Registered_
@@ -2705,14 +2775,18 @@ func (m *TapEvent_Http_ResponseInit) GetHttpStatus() uint32 {
 }
 
 type TapEvent_Http_ResponseEnd struct {
-	Id                   *TapEvent_Http_StreamId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	SinceRequestInit     *duration.Duration      `protobuf:"bytes,2,opt,name=since_request_init,json=sinceRequestInit,proto3" json:"since_request_init,omitempty"`
-	SinceResponseInit    *duration.Duration      `protobuf:"bytes,3,opt,name=since_response_init,json=sinceResponseInit,proto3" json:"since_response_init,omitempty"`
-	ResponseBytes        uint64                  `protobuf:"varint,4,opt,name=response_bytes,json=responseBytes,proto3" json:"response_bytes,omitempty"`
-	Eos                  *Eos                    `protobuf:"bytes,5,opt,name=eos,proto3" json:"eos,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+	Id                *TapEvent_Http_StreamId `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SinceRequestInit  *duration.Duration      `protobuf:"bytes,2,opt,name=since_request_init,json=sinceRequestInit,proto3" json:"since_request_init,omitempty"`
+	SinceResponseInit *duration.Duration      `protobuf:"bytes,3,opt,name=since_response_init,json=sinceResponseInit,proto3" json:"since_response_init,omitempty"`
+	ResponseBytes     uint64                  `protobuf:"varint,4,opt,name=response_bytes,json=responseBytes,proto3" json:"response_bytes,omitempty"`
+	Eos               *Eos                    `protobuf:"bytes,5,opt,name=eos,proto3" json:"eos,omitempty"`
+	// The number of messages of the request and of the response, for gRPC
+	// streams.
+	RequestMessages      uint64   `protobuf:"varint,8,opt,name=request_messages,json=requestMessages,proto3" json:"request_messages,omitempty"`
+	ResponseMessages     uint64   `protobuf:"varint,9,opt,name=response_messages,json=responseMessages,proto3" json:"response_messages,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *TapEvent_Http_ResponseEnd) Reset()         { *m = TapEvent_Http_ResponseEnd{} }
@@ -2774,6 +2848,20 @@ func (m *TapEvent_Http_ResponseEnd) GetEos() *Eos {
 	return nil
 }
 
+func (m *TapEvent_Http_ResponseEnd) GetRequestMessages() uint64 {
+	if m != nil {
+		return m.RequestMessages
+	}
+	return 0
+}
+
+func (m *TapEvent_Http_ResponseEnd) GetResponseMessages() uint64 {
+	if m != nil {
+		return m.ResponseMessages
+	}
+	return 0
+}
+
 type ApiError struct {
 	Error                string   `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -3194,10 +3282,25 @@ type StatSummaryRequest struct {
 	//	*StatSummaryRequest_None
 	//	*StatSummaryRequest_ToResource
 	//	*StatSummaryRequest_FromResource
-	Outbound             isStatSummaryRequest_Outbound `protobuf_oneof:"outbound"`
-	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
-	XXX_unrecognized     []byte                        `json:"-"`
-	XXX_sizecache        int32                         `json:"-"`
+	Outbound isStatSummaryRequest_Outbound `protobuf_oneof:"outbound"`
+	// the end of the time window, in seconds since the epoch; now when unset
+	EndTime int64 `protobuf:"varint,7,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// if set, the stats of the pods of the selected resources are grouped by
+	// namespace and by the value of this pod label, rather than by resource
+	GroupByLabel string `protobuf:"bytes,8,opt,name=group_by_label,json=groupByLabel,proto3" json:"group_by_label,omitempty"`
+	// if true, the TCP connection stats of each resource are also returned
+	TcpStats bool `protobuf:"varint,9,opt,name=tcp_stats,json=tcpStats,proto3" json:"tcp_stats,omitempty"`
+	// if set, at most this number of rows are returned, and the response has the
+	// page token of the following rows; not supported for the 'all' resource
+	// type, nor with group_by_label
+	Limit uint32 `protobuf:"varint,10,opt,name=limit,proto3" json:"limit,omitempty"`
+	// the next_page_token of the previous response, to request the following
+	// rows
+	PageToken            string   `protobuf:"bytes,11,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	PodStats             bool     `protobuf:"varint,12,opt,name=pod_stats,json=podStats,proto3" json:"pod_stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *StatSummaryRequest) Reset()         { *m = StatSummaryRequest{} }
@@ -3288,6 +3391,48 @@ func (m *StatSummaryRequest) GetFromResource() *Resource {
 	return nil
 }
 
+func (m *StatSummaryRequest) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *StatSummaryRequest) GetGroupByLabel() string {
+	if m != nil {
+		return m.GroupByLabel
+	}
+	return ""
+}
+
+func (m *StatSummaryRequest) GetTcpStats() bool {
+	if m != nil {
+		return m.TcpStats
+	}
+	return false
+}
+
+func (m *StatSummaryRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *StatSummaryRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+func (m *StatSummaryRequest) GetPodStats() bool {
+	if m != nil {
+		return m.PodStats
+	}
+	return false
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*StatSummaryRequest) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _StatSummaryRequest_OneofMarshaler, _StatSummaryRequest_OneofUnmarshaler, _StatSummaryRequest_OneofSizer, []interface{}{
@@ -3527,10 +3672,12 @@ func _StatSummaryResponse_OneofSizer(msg proto.Message) (n int) {
 }
 
 type StatSummaryResponse_Ok struct {
-	StatTables           []*StatTable `protobuf:"bytes,1,rep,name=stat_tables,json=statTables,proto3" json:"stat_tables,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
-	XXX_unrecognized     []byte       `json:"-"`
-	XXX_sizecache        int32        `json:"-"`
+	StatTables []*StatTable `protobuf:"bytes,1,rep,name=stat_tables,json=statTables,proto3" json:"stat_tables,omitempty"`
+	// set if the request had a limit and rows remain
+	NextPageToken        string   `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *StatSummaryResponse_Ok) Reset()         { *m = StatSummaryResponse_Ok{} }
@@ -3564,13 +3711,23 @@ func (m *StatSummaryResponse_Ok) GetStatTables() []*StatTable {
 	return nil
 }
 
+func (m *StatSummaryResponse_Ok) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
 type BasicStats struct {
-	SuccessCount         uint64   `protobuf:"varint,1,opt,name=success_count,json=successCount,proto3" json:"success_count,omitempty"`
-	FailureCount         uint64   `protobuf:"varint,2,opt,name=failure_count,json=failureCount,proto3" json:"failure_count,omitempty"`
-	LatencyMsP50         uint64   `protobuf:"varint,3,opt,name=latency_ms_p50,json=latencyMsP50,proto3" json:"latency_ms_p50,omitempty"`
-	LatencyMsP95         uint64   `protobuf:"varint,4,opt,name=latency_ms_p95,json=latencyMsP95,proto3" json:"latency_ms_p95,omitempty"`
-	LatencyMsP99         uint64   `protobuf:"varint,5,opt,name=latency_ms_p99,json=latencyMsP99,proto3" json:"latency_ms_p99,omitempty"`
-	TlsRequestCount      uint64   `protobuf:"varint,6,opt,name=tls_request_count,json=tlsRequestCount,proto3" json:"tls_request_count,omitempty"`
+	SuccessCount    uint64 `protobuf:"varint,1,opt,name=success_count,json=successCount,proto3" json:"success_count,omitempty"`
+	FailureCount    uint64 `protobuf:"varint,2,opt,name=failure_count,json=failureCount,proto3" json:"failure_count,omitempty"`
+	LatencyMsP50    uint64 `protobuf:"varint,3,opt,name=latency_ms_p50,json=latencyMsP50,proto3" json:"latency_ms_p50,omitempty"`
+	LatencyMsP95    uint64 `protobuf:"varint,4,opt,name=latency_ms_p95,json=latencyMsP95,proto3" json:"latency_ms_p95,omitempty"`
+	LatencyMsP99    uint64 `protobuf:"varint,5,opt,name=latency_ms_p99,json=latencyMsP99,proto3" json:"latency_ms_p99,omitempty"`
+	TlsRequestCount uint64 `protobuf:"varint,6,opt,name=tls_request_count,json=tlsRequestCount,proto3" json:"tls_request_count,omitempty"`
+	// number of requests whose peer had no identity (tls="no_identity"), which
+	// for inbound stats are the requests received from unmeshed clients
+	UnmeshedRequestCount uint64   `protobuf:"varint,9,opt,name=unmeshed_request_count,json=unmeshedRequestCount,proto3" json:"unmeshed_request_count,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -3642,6 +3799,69 @@ func (m *BasicStats) GetTlsRequestCount() uint64 {
 	return 0
 }
 
+func (m *BasicStats) GetUnmeshedRequestCount() uint64 {
+	if m != nil {
+		return m.UnmeshedRequestCount
+	}
+	return 0
+}
+
+type TcpStats struct {
+	// number of connections open at the end of the time window
+	OpenConnections uint64 `protobuf:"varint,1,opt,name=open_connections,json=openConnections,proto3" json:"open_connections,omitempty"`
+	// number of bytes read and written during the time window
+	ReadBytes            uint64   `protobuf:"varint,2,opt,name=read_bytes,json=readBytes,proto3" json:"read_bytes,omitempty"`
+	WriteBytes           uint64   `protobuf:"varint,3,opt,name=write_bytes,json=writeBytes,proto3" json:"write_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TcpStats) Reset()         { *m = TcpStats{} }
+func (m *TcpStats) String() string { return proto.CompactTextString(m) }
+func (*TcpStats) ProtoMessage()    {}
+func (*TcpStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_135b2b880504db8b, []int{25}
+}
+func (m *TcpStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TcpStats.Unmarshal(m, b)
+}
+func (m *TcpStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TcpStats.Marshal(b, m, deterministic)
+}
+func (dst *TcpStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TcpStats.Merge(dst, src)
+}
+func (m *TcpStats) XXX_Size() int {
+	return xxx_messageInfo_TcpStats.Size(m)
+}
+func (m *TcpStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_TcpStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TcpStats proto.InternalMessageInfo
+
+func (m *TcpStats) GetOpenConnections() uint64 {
+	if m != nil {
+		return m.OpenConnections
+	}
+	return 0
+}
+
+func (m *TcpStats) GetReadBytes() uint64 {
+	if m != nil {
+		return m.ReadBytes
+	}
+	return 0
+}
+
+func (m *TcpStats) GetWriteBytes() uint64 {
+	if m != nil {
+		return m.WriteBytes
+	}
+	return 0
+}
+
 type StatTable struct {
 	// Types that are valid to be assigned to Table:
 	//	*StatTable_PodGroup_
@@ -3655,7 +3875,7 @@ func (m *StatTable) Reset()         { *m = StatTable{} }
 func (m *StatTable) String() string { return proto.CompactTextString(m) }
 func (*StatTable) ProtoMessage()    {}
 func (*StatTable) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_135b2b880504db8b, []int{25}
+	return fileDescriptor_public_135b2b880504db8b, []int{26}
 }
 func (m *StatTable) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StatTable.Unmarshal(m, b)
@@ -3765,7 +3985,7 @@ func (m *StatTable_PodGroup) Reset()         { *m = StatTable_PodGroup{} }
 func (m *StatTable_PodGroup) String() string { return proto.CompactTextString(m) }
 func (*StatTable_PodGroup) ProtoMessage()    {}
 func (*StatTable_PodGroup) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_135b2b880504db8b, []int{25, 0}
+	return fileDescriptor_public_135b2b880504db8b, []int{26, 0}
 }
 func (m *StatTable_PodGroup) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StatTable_PodGroup.Unmarshal(m, b)
@@ -3803,17 +4023,25 @@ type StatTable_PodGroup_Row struct {
 	FailedPodCount uint64      `protobuf:"varint,6,opt,name=failed_pod_count,json=failedPodCount,proto3" json:"failed_pod_count,omitempty"`
 	Stats          *BasicStats `protobuf:"bytes,5,opt,name=stats,proto3" json:"stats,omitempty"`
 	// Stores a set of errors for each pod name. If a pod has no errors, it may be omitted.
-	ErrorsByPod          map[string]*PodErrors `protobuf:"bytes,7,rep,name=errors_by_pod,json=errorsByPod,proto3" json:"errors_by_pod,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
-	XXX_unrecognized     []byte                `json:"-"`
-	XXX_sizecache        int32                 `json:"-"`
+	ErrorsByPod map[string]*PodErrors `protobuf:"bytes,7,rep,name=errors_by_pod,json=errorsByPod,proto3" json:"errors_by_pod,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// only set if the request had group_by_label set, in which case the
+	// row has the pods of the resource namespace with this value of the
+	// label, empty for the pods without the label, and the resource has no
+	// name
+	LabelValue string `protobuf:"bytes,9,opt,name=label_value,json=labelValue,proto3" json:"label_value,omitempty"`
+	// only set if the request had tcp_stats set
+	TcpStats             *TcpStats                 `protobuf:"bytes,10,opt,name=tcp_stats,json=tcpStats,proto3" json:"tcp_stats,omitempty"`
+	PodRows              []*StatTable_PodGroup_Row `protobuf:"bytes,11,rep,name=pod_rows,json=podRows,proto3" json:"pod_rows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                  `json:"-"`
+	XXX_unrecognized     []byte                    `json:"-"`
+	XXX_sizecache        int32                     `json:"-"`
 }
 
 func (m *StatTable_PodGroup_Row) Reset()         { *m = StatTable_PodGroup_Row{} }
 func (m *StatTable_PodGroup_Row) String() string { return proto.CompactTextString(m) }
 func (*StatTable_PodGroup_Row) ProtoMessage()    {}
 func (*StatTable_PodGroup_Row) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_135b2b880504db8b, []int{25, 0, 0}
+	return fileDescriptor_public_135b2b880504db8b, []int{26, 0, 0}
 }
 func (m *StatTable_PodGroup_Row) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_StatTable_PodGroup_Row.Unmarshal(m, b)
@@ -3882,6 +4110,27 @@ func (m *StatTable_PodGroup_Row) GetErrorsByPod() map[string]*PodErrors {
 	return nil
 }
 
+func (m *StatTable_PodGroup_Row) GetLabelValue() string {
+	if m != nil {
+		return m.LabelValue
+	}
+	return ""
+}
+
+func (m *StatTable_PodGroup_Row) GetTcpStats() *TcpStats {
+	if m != nil {
+		return m.TcpStats
+	}
+	return nil
+}
+
+func (m *StatTable_PodGroup_Row) GetPodRows() []*StatTable_PodGroup_Row {
+	if m != nil {
+		return m.PodRows
+	}
+	return nil
+}
+
 type TopRoutesRequest struct {
 	Selector   *ResourceSelection `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
 	TimeWindow string             `protobuf:"bytes,2,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
@@ -3889,17 +4138,23 @@ type TopRoutesRequest struct {
 	//	*TopRoutesRequest_None
 	//	*TopRoutesRequest_ToAuthority
 	//	*TopRoutesRequest_ToAll
-	Outbound             isTopRoutesRequest_Outbound `protobuf_oneof:"outbound"`
-	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
-	XXX_unrecognized     []byte                      `json:"-"`
-	XXX_sizecache        int32                       `json:"-"`
+	//	*TopRoutesRequest_ToResource
+	Outbound isTopRoutesRequest_Outbound `protobuf_oneof:"outbound"`
+	// the end of the time window, in seconds since the epoch; now when unset
+	EndTime int64 `protobuf:"varint,7,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// whether to report the latency histogram of every route, on top of its
+	// latency quantiles
+	IncludeLatencyHistogram bool     `protobuf:"varint,9,opt,name=include_latency_histogram,json=includeLatencyHistogram,proto3" json:"include_latency_histogram,omitempty"`
+	XXX_NoUnkeyedLiteral    struct{} `json:"-"`
+	XXX_unrecognized        []byte   `json:"-"`
+	XXX_sizecache           int32    `json:"-"`
 }
 
 func (m *TopRoutesRequest) Reset()         { *m = TopRoutesRequest{} }
 func (m *TopRoutesRequest) String() string { return proto.CompactTextString(m) }
 func (*TopRoutesRequest) ProtoMessage()    {}
 func (*TopRoutesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_135b2b880504db8b, []int{26}
+	return fileDescriptor_public_135b2b880504db8b, []int{27}
 }
 func (m *TopRoutesRequest) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TopRoutesRequest.Unmarshal(m, b)
@@ -3949,12 +4204,18 @@ type TopRoutesRequest_ToAll struct {
 	ToAll *Empty `protobuf:"bytes,6,opt,name=to_all,json=toAll,proto3,oneof"`
 }
 
+type TopRoutesRequest_ToResource struct {
+	ToResource *Resource `protobuf:"bytes,8,opt,name=to_resource,json=toResource,proto3,oneof"`
+}
+
 func (*TopRoutesRequest_None) isTopRoutesRequest_Outbound() {}
 
 func (*TopRoutesRequest_ToAuthority) isTopRoutesRequest_Outbound() {}
 
 func (*TopRoutesRequest_ToAll) isTopRoutesRequest_Outbound() {}
 
+func (*TopRoutesRequest_ToResource) isTopRoutesRequest_Outbound() {}
+
 func (m *TopRoutesRequest) GetOutbound() isTopRoutesRequest_Outbound {
 	if m != nil {
 		return m.Outbound
@@ -3983,12 +4244,34 @@ func (m *TopRoutesRequest) GetToAll() *Empty {
 	return nil
 }
 
+func (m *TopRoutesRequest) GetToResource() *Resource {
+	if x, ok := m.GetOutbound().(*TopRoutesRequest_ToResource); ok {
+		return x.ToResource
+	}
+	return nil
+}
+
+func (m *TopRoutesRequest) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *TopRoutesRequest) GetIncludeLatencyHistogram() bool {
+	if m != nil {
+		return m.IncludeLatencyHistogram
+	}
+	return false
+}
+
 // XXX_OneofFuncs is for the internal use of the proto package.
 func (*TopRoutesRequest) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
 	return _TopRoutesRequest_OneofMarshaler, _TopRoutesRequest_OneofUnmarshaler, _TopRoutesRequest_OneofSizer, []interface{}{
 		(*TopRoutesRequest_None)(nil),
 		(*TopRoutesRequest_ToAuthority)(nil),
 		(*TopRoutesRequest_ToAll)(nil),
+		(*TopRoutesRequest_ToResource)(nil),
 	}
 }
 
@@ -4009,6 +4292,11 @@ func _TopRoutesRequest_OneofMarshaler(msg proto.Message, b *proto.Buffer) error
 		if err := b.EncodeMessage(x.ToAll); err != nil {
 			return err
 		}
+	case *TopRoutesRequest_ToResource:
+		b.EncodeVarint(8<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.ToResource); err != nil {
+			return err
+		}
 	case nil:
 	default:
 		return fmt.Errorf("TopRoutesRequest.Outbound has unexpected type %T", x)
@@ -4042,6 +4330,14 @@ func _TopRoutesRequest_OneofUnmarshaler(msg proto.Message, tag, wire int, b *pro
 		err := b.DecodeMessage(msg)
 		m.Outbound = &TopRoutesRequest_ToAll{msg}
 		return true, err
+	case 8: // outbound.to_resource
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(Resource)
+		err := b.DecodeMessage(msg)
+		m.Outbound = &TopRoutesRequest_ToResource{msg}
+		return true, err
 	default:
 		return false, nil
 	}
@@ -4065,6 +4361,11 @@ func _TopRoutesRequest_OneofSizer(msg proto.Message) (n int) {
 		n += 1 // tag and wire
 		n += proto.SizeVarint(uint64(s))
 		n += s
+	case *TopRoutesRequest_ToResource:
+		s := proto.Size(x.ToResource)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
 	case nil:
 	default:
 		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
@@ -4086,7 +4387,7 @@ func (m *TopRoutesResponse) Reset()         { *m = TopRoutesResponse{} }
 func (m *TopRoutesResponse) String() string { return proto.CompactTextString(m) }
 func (*TopRoutesResponse) ProtoMessage()    {}
 func (*TopRoutesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_135b2b880504db8b, []int{27}
+	return fileDescriptor_public_135b2b880504db8b, []int{28}
 }
 func (m *TopRoutesResponse) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_TopRoutesResponse.Unmarshal(m, b)
@@ -4228,7 +4529,7 @@ func (m *RouteTable) Reset()         { *m = RouteTable{} }
 func (m *RouteTable) String() string { return proto.CompactTextString(m) }
 func (*RouteTable) ProtoMessage()    {}
 func (*RouteTable) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_135b2b880504db8b, []int{28}
+	return fileDescriptor_public_135b2b880504db8b, []int{29}
 }
 func (m *RouteTable) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RouteTable.Unmarshal(m, b)
@@ -4256,20 +4557,30 @@ func (m *RouteTable) GetRows() []*RouteTable_Row {
 }
 
 type RouteTable_Row struct {
-	Route                string      `protobuf:"bytes,1,opt,name=route,proto3" json:"route,omitempty"`
-	TimeWindow           string      `protobuf:"bytes,2,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
-	Authority            string      `protobuf:"bytes,6,opt,name=authority,proto3" json:"authority,omitempty"`
-	Stats                *BasicStats `protobuf:"bytes,5,opt,name=stats,proto3" json:"stats,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+	Route      string      `protobuf:"bytes,1,opt,name=route,proto3" json:"route,omitempty"`
+	TimeWindow string      `protobuf:"bytes,2,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
+	Authority  string      `protobuf:"bytes,6,opt,name=authority,proto3" json:"authority,omitempty"`
+	Stats      *BasicStats `protobuf:"bytes,5,opt,name=stats,proto3" json:"stats,omitempty"`
+	// The resource whose routes these are, set when the request selects all
+	// the resources of a type rather than a named resource.
+	Resource *Resource `protobuf:"bytes,7,opt,name=resource,proto3" json:"resource,omitempty"`
+	// number of gRPC responses during the time window, by grpc-status code;
+	// empty for the routes of plain HTTP destinations
+	ResponsesByGrpcStatus map[uint32]uint64 `protobuf:"bytes,8,rep,name=responses_by_grpc_status,json=responsesByGrpcStatus,proto3" json:"responses_by_grpc_status,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// the latency buckets of the responses during the time window, by
+	// increasing upper bound; only set when include_latency_histogram is
+	// requested
+	LatencyHistogram     []*RouteTable_LatencyBucket `protobuf:"bytes,9,rep,name=latency_histogram,json=latencyHistogram,proto3" json:"latency_histogram,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
 }
 
 func (m *RouteTable_Row) Reset()         { *m = RouteTable_Row{} }
 func (m *RouteTable_Row) String() string { return proto.CompactTextString(m) }
 func (*RouteTable_Row) ProtoMessage()    {}
 func (*RouteTable_Row) Descriptor() ([]byte, []int) {
-	return fileDescriptor_public_135b2b880504db8b, []int{28, 0}
+	return fileDescriptor_public_135b2b880504db8b, []int{29, 0}
 }
 func (m *RouteTable_Row) XXX_Unmarshal(b []byte) error {
 	return xxx_messageInfo_RouteTable_Row.Unmarshal(m, b)
@@ -4317,55 +4628,1021 @@ func (m *RouteTable_Row) GetStats() *BasicStats {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*Empty)(nil), "linkerd2.public.Empty")
-	proto.RegisterType((*VersionInfo)(nil), "linkerd2.public.VersionInfo")
-	proto.RegisterType((*ListServicesRequest)(nil), "linkerd2.public.ListServicesRequest")
-	proto.RegisterType((*ListServicesResponse)(nil), "linkerd2.public.ListServicesResponse")
-	proto.RegisterType((*Service)(nil), "linkerd2.public.Service")
-	proto.RegisterType((*ListPodsRequest)(nil), "linkerd2.public.ListPodsRequest")
-	proto.RegisterType((*ListPodsResponse)(nil), "linkerd2.public.ListPodsResponse")
-	proto.RegisterType((*Pod)(nil), "linkerd2.public.Pod")
-	proto.RegisterType((*TapRequest)(nil), "linkerd2.public.TapRequest")
-	proto.RegisterType((*TapByResourceRequest)(nil), "linkerd2.public.TapByResourceRequest")
-	proto.RegisterType((*TapByResourceRequest_Match)(nil), "linkerd2.public.TapByResourceRequest.Match")
-	proto.RegisterType((*TapByResourceRequest_Match_Seq)(nil), "linkerd2.public.TapByResourceRequest.Match.Seq")
-	proto.RegisterType((*TapByResourceRequest_Match_Http)(nil), "linkerd2.public.TapByResourceRequest.Match.Http")
-	proto.RegisterType((*HttpMethod)(nil), "linkerd2.public.HttpMethod")
-	proto.RegisterType((*Scheme)(nil), "linkerd2.public.Scheme")
-	proto.RegisterType((*IPAddress)(nil), "linkerd2.public.IPAddress")
-	proto.RegisterType((*IPv6)(nil), "linkerd2.public.IPv6")
-	proto.RegisterType((*TcpAddress)(nil), "linkerd2.public.TcpAddress")
-	proto.RegisterType((*Eos)(nil), "linkerd2.public.Eos")
-	proto.RegisterType((*TapEvent)(nil), "linkerd2.public.TapEvent")
-	proto.RegisterType((*TapEvent_EndpointMeta)(nil), "linkerd2.public.TapEvent.EndpointMeta")
-	proto.RegisterMapType((map[string]string)(nil), "linkerd2.public.TapEvent.EndpointMeta.LabelsEntry")
-	proto.RegisterType((*TapEvent_RouteMeta)(nil), "linkerd2.public.TapEvent.RouteMeta")
-	proto.RegisterMapType((map[string]string)(nil), "linkerd2.public.TapEvent.RouteMeta.LabelsEntry")
-	proto.RegisterType((*TapEvent_Http)(nil), "linkerd2.public.TapEvent.Http")
-	proto.RegisterType((*TapEvent_Http_StreamId)(nil), "linkerd2.public.TapEvent.Http.StreamId")
-	proto.RegisterType((*TapEvent_Http_RequestInit)(nil), "linkerd2.public.TapEvent.Http.RequestInit")
-	proto.RegisterType((*TapEvent_Http_ResponseInit)(nil), "linkerd2.public.TapEvent.Http.ResponseInit")
-	proto.RegisterType((*TapEvent_Http_ResponseEnd)(nil), "linkerd2.public.TapEvent.Http.ResponseEnd")
-	proto.RegisterType((*ApiError)(nil), "linkerd2.public.ApiError")
-	proto.RegisterType((*PodErrors)(nil), "linkerd2.public.PodErrors")
-	proto.RegisterType((*PodErrors_PodError)(nil), "linkerd2.public.PodErrors.PodError")
-	proto.RegisterType((*PodErrors_PodError_ContainerError)(nil), "linkerd2.public.PodErrors.PodError.ContainerError")
-	proto.RegisterType((*Resource)(nil), "linkerd2.public.Resource")
-	proto.RegisterType((*ResourceSelection)(nil), "linkerd2.public.ResourceSelection")
-	proto.RegisterType((*ResourceError)(nil), "linkerd2.public.ResourceError")
-	proto.RegisterType((*StatSummaryRequest)(nil), "linkerd2.public.StatSummaryRequest")
-	proto.RegisterType((*StatSummaryResponse)(nil), "linkerd2.public.StatSummaryResponse")
-	proto.RegisterType((*StatSummaryResponse_Ok)(nil), "linkerd2.public.StatSummaryResponse.Ok")
-	proto.RegisterType((*BasicStats)(nil), "linkerd2.public.BasicStats")
-	proto.RegisterType((*StatTable)(nil), "linkerd2.public.StatTable")
-	proto.RegisterType((*StatTable_PodGroup)(nil), "linkerd2.public.StatTable.PodGroup")
-	proto.RegisterType((*StatTable_PodGroup_Row)(nil), "linkerd2.public.StatTable.PodGroup.Row")
-	proto.RegisterMapType((map[string]*PodErrors)(nil), "linkerd2.public.StatTable.PodGroup.Row.ErrorsByPodEntry")
-	proto.RegisterType((*TopRoutesRequest)(nil), "linkerd2.public.TopRoutesRequest")
-	proto.RegisterType((*TopRoutesResponse)(nil), "linkerd2.public.TopRoutesResponse")
-	proto.RegisterType((*RouteTable)(nil), "linkerd2.public.RouteTable")
-	proto.RegisterType((*RouteTable_Row)(nil), "linkerd2.public.RouteTable.Row")
+func (m *RouteTable_Row) GetResource() *Resource {
+	if m != nil {
+		return m.Resource
+	}
+	return nil
+}
+
+func (m *RouteTable_Row) GetResponsesByGrpcStatus() map[uint32]uint64 {
+	if m != nil {
+		return m.ResponsesByGrpcStatus
+	}
+	return nil
+}
+
+func (m *RouteTable_Row) GetLatencyHistogram() []*RouteTable_LatencyBucket {
+	if m != nil {
+		return m.LatencyHistogram
+	}
+	return nil
+}
+
+type RouteTable_LatencyBucket struct {
+	// the upper bound of the bucket, in milliseconds; 0 for the last bucket,
+	// which has no upper bound
+	MaxMs uint64 `protobuf:"varint,1,opt,name=max_ms,json=maxMs,proto3" json:"max_ms,omitempty"`
+	// number of responses slower than the previous bucket, and no slower
+	// than max_ms
+	Count                uint64   `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RouteTable_LatencyBucket) Reset()         { *m = RouteTable_LatencyBucket{} }
+func (m *RouteTable_LatencyBucket) String() string { return proto.CompactTextString(m) }
+func (*RouteTable_LatencyBucket) ProtoMessage()    {}
+func (*RouteTable_LatencyBucket) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_135b2b880504db8b, []int{29, 1}
+}
+func (m *RouteTable_LatencyBucket) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RouteTable_LatencyBucket.Unmarshal(m, b)
+}
+func (m *RouteTable_LatencyBucket) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RouteTable_LatencyBucket.Marshal(b, m, deterministic)
+}
+func (dst *RouteTable_LatencyBucket) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RouteTable_LatencyBucket.Merge(dst, src)
+}
+func (m *RouteTable_LatencyBucket) XXX_Size() int {
+	return xxx_messageInfo_RouteTable_LatencyBucket.Size(m)
+}
+func (m *RouteTable_LatencyBucket) XXX_DiscardUnknown() {
+	xxx_messageInfo_RouteTable_LatencyBucket.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RouteTable_LatencyBucket proto.InternalMessageInfo
+
+func (m *RouteTable_LatencyBucket) GetMaxMs() uint64 {
+	if m != nil {
+		return m.MaxMs
+	}
+	return 0
+}
+
+func (m *RouteTable_LatencyBucket) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+type StreamStats struct {
+	// number of gRPC responses during the time window, by grpc-status code
+	ResponsesByGrpcStatus map[uint32]uint64 `protobuf:"bytes,1,rep,name=responses_by_grpc_status,json=responsesByGrpcStatus,proto3" json:"responses_by_grpc_status,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral  struct{}          `json:"-"`
+	XXX_unrecognized      []byte            `json:"-"`
+	XXX_sizecache         int32             `json:"-"`
+}
+
+func (m *StreamStats) Reset()         { *m = StreamStats{} }
+func (m *StreamStats) String() string { return proto.CompactTextString(m) }
+func (*StreamStats) ProtoMessage()    {}
+func (*StreamStats) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_135b2b880504db8b, []int{30}
+}
+func (m *StreamStats) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamStats.Unmarshal(m, b)
+}
+func (m *StreamStats) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamStats.Marshal(b, m, deterministic)
+}
+func (dst *StreamStats) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamStats.Merge(dst, src)
+}
+func (m *StreamStats) XXX_Size() int {
+	return xxx_messageInfo_StreamStats.Size(m)
+}
+func (m *StreamStats) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamStats.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamStats proto.InternalMessageInfo
+
+func (m *StreamStats) GetResponsesByGrpcStatus() map[uint32]uint64 {
+	if m != nil {
+		return m.ResponsesByGrpcStatus
+	}
+	return nil
+}
+
+type StreamStatsTable struct {
+	Rows                 []*StreamStatsTable_Row `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *StreamStatsTable) Reset()         { *m = StreamStatsTable{} }
+func (m *StreamStatsTable) String() string { return proto.CompactTextString(m) }
+func (*StreamStatsTable) ProtoMessage()    {}
+func (*StreamStatsTable) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_135b2b880504db8b, []int{31}
+}
+func (m *StreamStatsTable) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamStatsTable.Unmarshal(m, b)
+}
+func (m *StreamStatsTable) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamStatsTable.Marshal(b, m, deterministic)
+}
+func (dst *StreamStatsTable) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamStatsTable.Merge(dst, src)
+}
+func (m *StreamStatsTable) XXX_Size() int {
+	return xxx_messageInfo_StreamStatsTable.Size(m)
+}
+func (m *StreamStatsTable) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamStatsTable.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamStatsTable proto.InternalMessageInfo
+
+func (m *StreamStatsTable) GetRows() []*StreamStatsTable_Row {
+	if m != nil {
+		return m.Rows
+	}
+	return nil
+}
+
+type StreamStatsTable_Row struct {
+	Resource             *Resource    `protobuf:"bytes,1,opt,name=resource,proto3" json:"resource,omitempty"`
+	TimeWindow           string       `protobuf:"bytes,2,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
+	Stats                *StreamStats `protobuf:"bytes,3,opt,name=stats,proto3" json:"stats,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *StreamStatsTable_Row) Reset()         { *m = StreamStatsTable_Row{} }
+func (m *StreamStatsTable_Row) String() string { return proto.CompactTextString(m) }
+func (*StreamStatsTable_Row) ProtoMessage()    {}
+func (*StreamStatsTable_Row) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_135b2b880504db8b, []int{31, 0}
+}
+func (m *StreamStatsTable_Row) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamStatsTable_Row.Unmarshal(m, b)
+}
+func (m *StreamStatsTable_Row) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamStatsTable_Row.Marshal(b, m, deterministic)
+}
+func (dst *StreamStatsTable_Row) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamStatsTable_Row.Merge(dst, src)
+}
+func (m *StreamStatsTable_Row) XXX_Size() int {
+	return xxx_messageInfo_StreamStatsTable_Row.Size(m)
+}
+func (m *StreamStatsTable_Row) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamStatsTable_Row.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamStatsTable_Row proto.InternalMessageInfo
+
+func (m *StreamStatsTable_Row) GetResource() *Resource {
+	if m != nil {
+		return m.Resource
+	}
+	return nil
+}
+
+func (m *StreamStatsTable_Row) GetTimeWindow() string {
+	if m != nil {
+		return m.TimeWindow
+	}
+	return ""
+}
+
+func (m *StreamStatsTable_Row) GetStats() *StreamStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+type StreamStatsResponse struct {
+	// Types that are valid to be assigned to Response:
+	//	*StreamStatsResponse_Ok
+	//	*StreamStatsResponse_Error
+	Response             isStreamStatsResponse_Response `protobuf_oneof:"response"`
+	XXX_NoUnkeyedLiteral struct{}                       `json:"-"`
+	XXX_unrecognized     []byte                         `json:"-"`
+	XXX_sizecache        int32                          `json:"-"`
+}
+
+func (m *StreamStatsResponse) Reset()         { *m = StreamStatsResponse{} }
+func (m *StreamStatsResponse) String() string { return proto.CompactTextString(m) }
+func (*StreamStatsResponse) ProtoMessage()    {}
+func (*StreamStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_135b2b880504db8b, []int{32}
+}
+func (m *StreamStatsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StreamStatsResponse.Unmarshal(m, b)
+}
+func (m *StreamStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StreamStatsResponse.Marshal(b, m, deterministic)
+}
+func (dst *StreamStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamStatsResponse.Merge(dst, src)
+}
+func (m *StreamStatsResponse) XXX_Size() int {
+	return xxx_messageInfo_StreamStatsResponse.Size(m)
+}
+func (m *StreamStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StreamStatsResponse proto.InternalMessageInfo
+
+type isStreamStatsResponse_Response interface {
+	isStreamStatsResponse_Response()
+}
+
+type StreamStatsResponse_Ok struct {
+	Ok *StreamStatsTable `protobuf:"bytes,1,opt,name=ok,proto3,oneof"`
+}
+
+type StreamStatsResponse_Error struct {
+	Error *ResourceError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*StreamStatsResponse_Ok) isStreamStatsResponse_Response() {}
+
+func (*StreamStatsResponse_Error) isStreamStatsResponse_Response() {}
+
+func (m *StreamStatsResponse) GetResponse() isStreamStatsResponse_Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *StreamStatsResponse) GetOk() *StreamStatsTable {
+	if x, ok := m.GetResponse().(*StreamStatsResponse_Ok); ok {
+		return x.Ok
+	}
+	return nil
+}
+
+func (m *StreamStatsResponse) GetError() *ResourceError {
+	if x, ok := m.GetResponse().(*StreamStatsResponse_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*StreamStatsResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _StreamStatsResponse_OneofMarshaler, _StreamStatsResponse_OneofUnmarshaler, _StreamStatsResponse_OneofSizer, []interface{}{
+		(*StreamStatsResponse_Ok)(nil),
+		(*StreamStatsResponse_Error)(nil),
+	}
+}
+
+func _StreamStatsResponse_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*StreamStatsResponse)
+	// response
+	switch x := m.Response.(type) {
+	case *StreamStatsResponse_Ok:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Ok); err != nil {
+			return err
+		}
+	case *StreamStatsResponse_Error:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Error); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("StreamStatsResponse.Response has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _StreamStatsResponse_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*StreamStatsResponse)
+	switch tag {
+	case 1: // response.ok
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(StreamStatsTable)
+		err := b.DecodeMessage(msg)
+		m.Response = &StreamStatsResponse_Ok{msg}
+		return true, err
+	case 2: // response.error
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResourceError)
+		err := b.DecodeMessage(msg)
+		m.Response = &StreamStatsResponse_Error{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _StreamStatsResponse_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*StreamStatsResponse)
+	// response
+	switch x := m.Response.(type) {
+	case *StreamStatsResponse_Ok:
+		s := proto.Size(x.Ok)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *StreamStatsResponse_Error:
+		s := proto.Size(x.Error)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type RouteSLOsRequest struct {
+	// the service whose ServiceProfile declares the route SLOs
+	Selector             *ResourceSelection `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *RouteSLOsRequest) Reset()         { *m = RouteSLOsRequest{} }
+func (m *RouteSLOsRequest) String() string { return proto.CompactTextString(m) }
+func (*RouteSLOsRequest) ProtoMessage()    {}
+func (*RouteSLOsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_135b2b880504db8b, []int{33}
+}
+func (m *RouteSLOsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RouteSLOsRequest.Unmarshal(m, b)
+}
+func (m *RouteSLOsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RouteSLOsRequest.Marshal(b, m, deterministic)
+}
+func (dst *RouteSLOsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RouteSLOsRequest.Merge(dst, src)
+}
+func (m *RouteSLOsRequest) XXX_Size() int {
+	return xxx_messageInfo_RouteSLOsRequest.Size(m)
+}
+func (m *RouteSLOsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RouteSLOsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RouteSLOsRequest proto.InternalMessageInfo
+
+func (m *RouteSLOsRequest) GetSelector() *ResourceSelection {
+	if m != nil {
+		return m.Selector
+	}
+	return nil
+}
+
+type RouteSLOsResponse struct {
+	// Types that are valid to be assigned to Response:
+	//	*RouteSLOsResponse_Ok
+	//	*RouteSLOsResponse_Error
+	Response             isRouteSLOsResponse_Response `protobuf_oneof:"response"`
+	XXX_NoUnkeyedLiteral struct{}                     `json:"-"`
+	XXX_unrecognized     []byte                       `json:"-"`
+	XXX_sizecache        int32                        `json:"-"`
+}
+
+func (m *RouteSLOsResponse) Reset()         { *m = RouteSLOsResponse{} }
+func (m *RouteSLOsResponse) String() string { return proto.CompactTextString(m) }
+func (*RouteSLOsResponse) ProtoMessage()    {}
+func (*RouteSLOsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_135b2b880504db8b, []int{34}
+}
+func (m *RouteSLOsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RouteSLOsResponse.Unmarshal(m, b)
+}
+func (m *RouteSLOsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RouteSLOsResponse.Marshal(b, m, deterministic)
+}
+func (dst *RouteSLOsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RouteSLOsResponse.Merge(dst, src)
+}
+func (m *RouteSLOsResponse) XXX_Size() int {
+	return xxx_messageInfo_RouteSLOsResponse.Size(m)
+}
+func (m *RouteSLOsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RouteSLOsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RouteSLOsResponse proto.InternalMessageInfo
+
+type isRouteSLOsResponse_Response interface {
+	isRouteSLOsResponse_Response()
+}
+
+type RouteSLOsResponse_Ok struct {
+	Ok *RouteSLOTable `protobuf:"bytes,1,opt,name=ok,proto3,oneof"`
+}
+
+type RouteSLOsResponse_Error struct {
+	Error *ResourceError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*RouteSLOsResponse_Ok) isRouteSLOsResponse_Response() {}
+
+func (*RouteSLOsResponse_Error) isRouteSLOsResponse_Response() {}
+
+func (m *RouteSLOsResponse) GetResponse() isRouteSLOsResponse_Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *RouteSLOsResponse) GetOk() *RouteSLOTable {
+	if x, ok := m.GetResponse().(*RouteSLOsResponse_Ok); ok {
+		return x.Ok
+	}
+	return nil
+}
+
+func (m *RouteSLOsResponse) GetError() *ResourceError {
+	if x, ok := m.GetResponse().(*RouteSLOsResponse_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*RouteSLOsResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _RouteSLOsResponse_OneofMarshaler, _RouteSLOsResponse_OneofUnmarshaler, _RouteSLOsResponse_OneofSizer, []interface{}{
+		(*RouteSLOsResponse_Ok)(nil),
+		(*RouteSLOsResponse_Error)(nil),
+	}
+}
+
+func _RouteSLOsResponse_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*RouteSLOsResponse)
+	// response
+	switch x := m.Response.(type) {
+	case *RouteSLOsResponse_Ok:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Ok); err != nil {
+			return err
+		}
+	case *RouteSLOsResponse_Error:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Error); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("RouteSLOsResponse.Response has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _RouteSLOsResponse_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*RouteSLOsResponse)
+	switch tag {
+	case 1: // response.ok
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RouteSLOTable)
+		err := b.DecodeMessage(msg)
+		m.Response = &RouteSLOsResponse_Ok{msg}
+		return true, err
+	case 2: // response.error
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResourceError)
+		err := b.DecodeMessage(msg)
+		m.Response = &RouteSLOsResponse_Error{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _RouteSLOsResponse_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*RouteSLOsResponse)
+	// response
+	switch x := m.Response.(type) {
+	case *RouteSLOsResponse_Ok:
+		s := proto.Size(x.Ok)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *RouteSLOsResponse_Error:
+		s := proto.Size(x.Error)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type RouteSLOTable struct {
+	Rows                 []*RouteSLOTable_Row `protobuf:"bytes,1,rep,name=rows,proto3" json:"rows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *RouteSLOTable) Reset()         { *m = RouteSLOTable{} }
+func (m *RouteSLOTable) String() string { return proto.CompactTextString(m) }
+func (*RouteSLOTable) ProtoMessage()    {}
+func (*RouteSLOTable) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_135b2b880504db8b, []int{35}
+}
+func (m *RouteSLOTable) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RouteSLOTable.Unmarshal(m, b)
+}
+func (m *RouteSLOTable) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RouteSLOTable.Marshal(b, m, deterministic)
+}
+func (dst *RouteSLOTable) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RouteSLOTable.Merge(dst, src)
+}
+func (m *RouteSLOTable) XXX_Size() int {
+	return xxx_messageInfo_RouteSLOTable.Size(m)
+}
+func (m *RouteSLOTable) XXX_DiscardUnknown() {
+	xxx_messageInfo_RouteSLOTable.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RouteSLOTable proto.InternalMessageInfo
+
+func (m *RouteSLOTable) GetRows() []*RouteSLOTable_Row {
+	if m != nil {
+		return m.Rows
+	}
+	return nil
+}
+
+type RouteSLOTable_Row struct {
+	Route string `protobuf:"bytes,1,opt,name=route,proto3" json:"route,omitempty"`
+	// the rolling window of the SLO
+	TimeWindow string `protobuf:"bytes,2,opt,name=time_window,json=timeWindow,proto3" json:"time_window,omitempty"`
+	// the objective success rate, or 0 if the SLO has no success rate objective
+	SuccessRateObjective float64 `protobuf:"fixed64,3,opt,name=success_rate_objective,json=successRateObjective,proto3" json:"success_rate_objective,omitempty"`
+	// the objective p99 latency, or 0 if the SLO has no latency objective
+	LatencyMsObjective uint64 `protobuf:"varint,4,opt,name=latency_ms_objective,json=latencyMsObjective,proto3" json:"latency_ms_objective,omitempty"`
+	// the stats of the route over the window
+	Stats *BasicStats `protobuf:"bytes,5,opt,name=stats,proto3" json:"stats,omitempty"`
+	// the ratio of the error budget left over the window, negative once the
+	// budget is exhausted
+	ErrorBudgetRemaining float64 `protobuf:"fixed64,6,opt,name=error_budget_remaining,json=errorBudgetRemaining,proto3" json:"error_budget_remaining,omitempty"`
+	// true if the route currently meets all of its objectives
+	Compliant            bool     `protobuf:"varint,7,opt,name=compliant,proto3" json:"compliant,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RouteSLOTable_Row) Reset()         { *m = RouteSLOTable_Row{} }
+func (m *RouteSLOTable_Row) String() string { return proto.CompactTextString(m) }
+func (*RouteSLOTable_Row) ProtoMessage()    {}
+func (*RouteSLOTable_Row) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_135b2b880504db8b, []int{35, 0}
+}
+func (m *RouteSLOTable_Row) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RouteSLOTable_Row.Unmarshal(m, b)
+}
+func (m *RouteSLOTable_Row) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RouteSLOTable_Row.Marshal(b, m, deterministic)
+}
+func (dst *RouteSLOTable_Row) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RouteSLOTable_Row.Merge(dst, src)
+}
+func (m *RouteSLOTable_Row) XXX_Size() int {
+	return xxx_messageInfo_RouteSLOTable_Row.Size(m)
+}
+func (m *RouteSLOTable_Row) XXX_DiscardUnknown() {
+	xxx_messageInfo_RouteSLOTable_Row.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RouteSLOTable_Row proto.InternalMessageInfo
+
+func (m *RouteSLOTable_Row) GetRoute() string {
+	if m != nil {
+		return m.Route
+	}
+	return ""
+}
+
+func (m *RouteSLOTable_Row) GetTimeWindow() string {
+	if m != nil {
+		return m.TimeWindow
+	}
+	return ""
+}
+
+func (m *RouteSLOTable_Row) GetSuccessRateObjective() float64 {
+	if m != nil {
+		return m.SuccessRateObjective
+	}
+	return 0
+}
+
+func (m *RouteSLOTable_Row) GetLatencyMsObjective() uint64 {
+	if m != nil {
+		return m.LatencyMsObjective
+	}
+	return 0
+}
+
+func (m *RouteSLOTable_Row) GetStats() *BasicStats {
+	if m != nil {
+		return m.Stats
+	}
+	return nil
+}
+
+func (m *RouteSLOTable_Row) GetErrorBudgetRemaining() float64 {
+	if m != nil {
+		return m.ErrorBudgetRemaining
+	}
+	return 0
+}
+
+func (m *RouteSLOTable_Row) GetCompliant() bool {
+	if m != nil {
+		return m.Compliant
+	}
+	return false
+}
+
+type RouteStatsHistoryRequest struct {
+	// the service whose route stats snapshots are returned
+	Selector *ResourceSelection `protobuf:"bytes,1,opt,name=selector,proto3" json:"selector,omitempty"`
+	// the range of the snapshots, in seconds since the epoch
+	StartTime            int64    `protobuf:"varint,2,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime              int64    `protobuf:"varint,3,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RouteStatsHistoryRequest) Reset()         { *m = RouteStatsHistoryRequest{} }
+func (m *RouteStatsHistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*RouteStatsHistoryRequest) ProtoMessage()    {}
+func (*RouteStatsHistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_135b2b880504db8b, []int{36}
+}
+func (m *RouteStatsHistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RouteStatsHistoryRequest.Unmarshal(m, b)
+}
+func (m *RouteStatsHistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RouteStatsHistoryRequest.Marshal(b, m, deterministic)
+}
+func (dst *RouteStatsHistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RouteStatsHistoryRequest.Merge(dst, src)
+}
+func (m *RouteStatsHistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_RouteStatsHistoryRequest.Size(m)
+}
+func (m *RouteStatsHistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RouteStatsHistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RouteStatsHistoryRequest proto.InternalMessageInfo
+
+func (m *RouteStatsHistoryRequest) GetSelector() *ResourceSelection {
+	if m != nil {
+		return m.Selector
+	}
+	return nil
+}
+
+func (m *RouteStatsHistoryRequest) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *RouteStatsHistoryRequest) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+type RouteStatsHistoryResponse struct {
+	// Types that are valid to be assigned to Response:
+	//	*RouteStatsHistoryResponse_Ok
+	//	*RouteStatsHistoryResponse_Error
+	Response             isRouteStatsHistoryResponse_Response `protobuf_oneof:"response"`
+	XXX_NoUnkeyedLiteral struct{}                             `json:"-"`
+	XXX_unrecognized     []byte                               `json:"-"`
+	XXX_sizecache        int32                                `json:"-"`
+}
+
+func (m *RouteStatsHistoryResponse) Reset()         { *m = RouteStatsHistoryResponse{} }
+func (m *RouteStatsHistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*RouteStatsHistoryResponse) ProtoMessage()    {}
+func (*RouteStatsHistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_135b2b880504db8b, []int{37}
+}
+func (m *RouteStatsHistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RouteStatsHistoryResponse.Unmarshal(m, b)
+}
+func (m *RouteStatsHistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RouteStatsHistoryResponse.Marshal(b, m, deterministic)
+}
+func (dst *RouteStatsHistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RouteStatsHistoryResponse.Merge(dst, src)
+}
+func (m *RouteStatsHistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_RouteStatsHistoryResponse.Size(m)
+}
+func (m *RouteStatsHistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RouteStatsHistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RouteStatsHistoryResponse proto.InternalMessageInfo
+
+type isRouteStatsHistoryResponse_Response interface {
+	isRouteStatsHistoryResponse_Response()
+}
+
+type RouteStatsHistoryResponse_Ok struct {
+	Ok *RouteStatsHistory `protobuf:"bytes,1,opt,name=ok,proto3,oneof"`
+}
+
+type RouteStatsHistoryResponse_Error struct {
+	Error *ResourceError `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*RouteStatsHistoryResponse_Ok) isRouteStatsHistoryResponse_Response() {}
+
+func (*RouteStatsHistoryResponse_Error) isRouteStatsHistoryResponse_Response() {}
+
+func (m *RouteStatsHistoryResponse) GetResponse() isRouteStatsHistoryResponse_Response {
+	if m != nil {
+		return m.Response
+	}
+	return nil
+}
+
+func (m *RouteStatsHistoryResponse) GetOk() *RouteStatsHistory {
+	if x, ok := m.GetResponse().(*RouteStatsHistoryResponse_Ok); ok {
+		return x.Ok
+	}
+	return nil
+}
+
+func (m *RouteStatsHistoryResponse) GetError() *ResourceError {
+	if x, ok := m.GetResponse().(*RouteStatsHistoryResponse_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+// XXX_OneofFuncs is for the internal use of the proto package.
+func (*RouteStatsHistoryResponse) XXX_OneofFuncs() (func(msg proto.Message, b *proto.Buffer) error, func(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error), func(msg proto.Message) (n int), []interface{}) {
+	return _RouteStatsHistoryResponse_OneofMarshaler, _RouteStatsHistoryResponse_OneofUnmarshaler, _RouteStatsHistoryResponse_OneofSizer, []interface{}{
+		(*RouteStatsHistoryResponse_Ok)(nil),
+		(*RouteStatsHistoryResponse_Error)(nil),
+	}
+}
+
+func _RouteStatsHistoryResponse_OneofMarshaler(msg proto.Message, b *proto.Buffer) error {
+	m := msg.(*RouteStatsHistoryResponse)
+	// response
+	switch x := m.Response.(type) {
+	case *RouteStatsHistoryResponse_Ok:
+		b.EncodeVarint(1<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Ok); err != nil {
+			return err
+		}
+	case *RouteStatsHistoryResponse_Error:
+		b.EncodeVarint(2<<3 | proto.WireBytes)
+		if err := b.EncodeMessage(x.Error); err != nil {
+			return err
+		}
+	case nil:
+	default:
+		return fmt.Errorf("RouteStatsHistoryResponse.Response has unexpected type %T", x)
+	}
+	return nil
+}
+
+func _RouteStatsHistoryResponse_OneofUnmarshaler(msg proto.Message, tag, wire int, b *proto.Buffer) (bool, error) {
+	m := msg.(*RouteStatsHistoryResponse)
+	switch tag {
+	case 1: // response.ok
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(RouteStatsHistory)
+		err := b.DecodeMessage(msg)
+		m.Response = &RouteStatsHistoryResponse_Ok{msg}
+		return true, err
+	case 2: // response.error
+		if wire != proto.WireBytes {
+			return true, proto.ErrInternalBadWireType
+		}
+		msg := new(ResourceError)
+		err := b.DecodeMessage(msg)
+		m.Response = &RouteStatsHistoryResponse_Error{msg}
+		return true, err
+	default:
+		return false, nil
+	}
+}
+
+func _RouteStatsHistoryResponse_OneofSizer(msg proto.Message) (n int) {
+	m := msg.(*RouteStatsHistoryResponse)
+	// response
+	switch x := m.Response.(type) {
+	case *RouteStatsHistoryResponse_Ok:
+		s := proto.Size(x.Ok)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case *RouteStatsHistoryResponse_Error:
+		s := proto.Size(x.Error)
+		n += 1 // tag and wire
+		n += proto.SizeVarint(uint64(s))
+		n += s
+	case nil:
+	default:
+		panic(fmt.Sprintf("proto: unexpected type %T in oneof", x))
+	}
+	return n
+}
+
+type RouteStatsHistory struct {
+	Snapshots            []*RouteStatsHistory_Snapshot `protobuf:"bytes,1,rep,name=snapshots,proto3" json:"snapshots,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                      `json:"-"`
+	XXX_unrecognized     []byte                        `json:"-"`
+	XXX_sizecache        int32                         `json:"-"`
+}
+
+func (m *RouteStatsHistory) Reset()         { *m = RouteStatsHistory{} }
+func (m *RouteStatsHistory) String() string { return proto.CompactTextString(m) }
+func (*RouteStatsHistory) ProtoMessage()    {}
+func (*RouteStatsHistory) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_135b2b880504db8b, []int{38}
+}
+func (m *RouteStatsHistory) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RouteStatsHistory.Unmarshal(m, b)
+}
+func (m *RouteStatsHistory) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RouteStatsHistory.Marshal(b, m, deterministic)
+}
+func (dst *RouteStatsHistory) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RouteStatsHistory.Merge(dst, src)
+}
+func (m *RouteStatsHistory) XXX_Size() int {
+	return xxx_messageInfo_RouteStatsHistory.Size(m)
+}
+func (m *RouteStatsHistory) XXX_DiscardUnknown() {
+	xxx_messageInfo_RouteStatsHistory.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RouteStatsHistory proto.InternalMessageInfo
+
+func (m *RouteStatsHistory) GetSnapshots() []*RouteStatsHistory_Snapshot {
+	if m != nil {
+		return m.Snapshots
+	}
+	return nil
+}
+
+type RouteStatsHistory_Snapshot struct {
+	// the time of the snapshot, in seconds since the epoch
+	Timestamp int64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// the inbound route stats of the service over the snapshot interval
+	Routes               *RouteTable `protobuf:"bytes,2,opt,name=routes,proto3" json:"routes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *RouteStatsHistory_Snapshot) Reset()         { *m = RouteStatsHistory_Snapshot{} }
+func (m *RouteStatsHistory_Snapshot) String() string { return proto.CompactTextString(m) }
+func (*RouteStatsHistory_Snapshot) ProtoMessage()    {}
+func (*RouteStatsHistory_Snapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_public_135b2b880504db8b, []int{38, 0}
+}
+func (m *RouteStatsHistory_Snapshot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RouteStatsHistory_Snapshot.Unmarshal(m, b)
+}
+func (m *RouteStatsHistory_Snapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RouteStatsHistory_Snapshot.Marshal(b, m, deterministic)
+}
+func (dst *RouteStatsHistory_Snapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RouteStatsHistory_Snapshot.Merge(dst, src)
+}
+func (m *RouteStatsHistory_Snapshot) XXX_Size() int {
+	return xxx_messageInfo_RouteStatsHistory_Snapshot.Size(m)
+}
+func (m *RouteStatsHistory_Snapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_RouteStatsHistory_Snapshot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RouteStatsHistory_Snapshot proto.InternalMessageInfo
+
+func (m *RouteStatsHistory_Snapshot) GetTimestamp() int64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *RouteStatsHistory_Snapshot) GetRoutes() *RouteTable {
+	if m != nil {
+		return m.Routes
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*Empty)(nil), "linkerd2.public.Empty")
+	proto.RegisterType((*VersionInfo)(nil), "linkerd2.public.VersionInfo")
+	proto.RegisterType((*ListServicesRequest)(nil), "linkerd2.public.ListServicesRequest")
+	proto.RegisterType((*ListServicesResponse)(nil), "linkerd2.public.ListServicesResponse")
+	proto.RegisterType((*Service)(nil), "linkerd2.public.Service")
+	proto.RegisterType((*ListPodsRequest)(nil), "linkerd2.public.ListPodsRequest")
+	proto.RegisterType((*ListPodsResponse)(nil), "linkerd2.public.ListPodsResponse")
+	proto.RegisterType((*Pod)(nil), "linkerd2.public.Pod")
+	proto.RegisterType((*TapRequest)(nil), "linkerd2.public.TapRequest")
+	proto.RegisterType((*TapByResourceRequest)(nil), "linkerd2.public.TapByResourceRequest")
+	proto.RegisterType((*TapByResourceRequest_Match)(nil), "linkerd2.public.TapByResourceRequest.Match")
+	proto.RegisterType((*TapByResourceRequest_Match_Seq)(nil), "linkerd2.public.TapByResourceRequest.Match.Seq")
+	proto.RegisterType((*TapByResourceRequest_Match_Http)(nil), "linkerd2.public.TapByResourceRequest.Match.Http")
+	proto.RegisterType((*TapByResourceRequest_Filter)(nil), "linkerd2.public.TapByResourceRequest.Filter")
+	proto.RegisterType((*HttpMethod)(nil), "linkerd2.public.HttpMethod")
+	proto.RegisterType((*Scheme)(nil), "linkerd2.public.Scheme")
+	proto.RegisterType((*IPAddress)(nil), "linkerd2.public.IPAddress")
+	proto.RegisterType((*IPv6)(nil), "linkerd2.public.IPv6")
+	proto.RegisterType((*TcpAddress)(nil), "linkerd2.public.TcpAddress")
+	proto.RegisterType((*Eos)(nil), "linkerd2.public.Eos")
+	proto.RegisterType((*TapEvent)(nil), "linkerd2.public.TapEvent")
+	proto.RegisterType((*TapEvent_EndpointMeta)(nil), "linkerd2.public.TapEvent.EndpointMeta")
+	proto.RegisterMapType((map[string]string)(nil), "linkerd2.public.TapEvent.EndpointMeta.LabelsEntry")
+	proto.RegisterType((*TapEvent_RouteMeta)(nil), "linkerd2.public.TapEvent.RouteMeta")
+	proto.RegisterMapType((map[string]string)(nil), "linkerd2.public.TapEvent.RouteMeta.LabelsEntry")
+	proto.RegisterType((*TapEvent_Http)(nil), "linkerd2.public.TapEvent.Http")
+	proto.RegisterType((*TapEvent_Http_StreamId)(nil), "linkerd2.public.TapEvent.Http.StreamId")
+	proto.RegisterType((*TapEvent_Http_RequestInit)(nil), "linkerd2.public.TapEvent.Http.RequestInit")
+	proto.RegisterType((*TapEvent_Http_ResponseInit)(nil), "linkerd2.public.TapEvent.Http.ResponseInit")
+	proto.RegisterType((*TapEvent_Http_ResponseEnd)(nil), "linkerd2.public.TapEvent.Http.ResponseEnd")
+	proto.RegisterType((*ApiError)(nil), "linkerd2.public.ApiError")
+	proto.RegisterType((*PodErrors)(nil), "linkerd2.public.PodErrors")
+	proto.RegisterType((*PodErrors_PodError)(nil), "linkerd2.public.PodErrors.PodError")
+	proto.RegisterType((*PodErrors_PodError_ContainerError)(nil), "linkerd2.public.PodErrors.PodError.ContainerError")
+	proto.RegisterType((*Resource)(nil), "linkerd2.public.Resource")
+	proto.RegisterType((*ResourceSelection)(nil), "linkerd2.public.ResourceSelection")
+	proto.RegisterType((*ResourceError)(nil), "linkerd2.public.ResourceError")
+	proto.RegisterType((*StatSummaryRequest)(nil), "linkerd2.public.StatSummaryRequest")
+	proto.RegisterType((*StatSummaryResponse)(nil), "linkerd2.public.StatSummaryResponse")
+	proto.RegisterType((*StatSummaryResponse_Ok)(nil), "linkerd2.public.StatSummaryResponse.Ok")
+	proto.RegisterType((*BasicStats)(nil), "linkerd2.public.BasicStats")
+	proto.RegisterType((*TcpStats)(nil), "linkerd2.public.TcpStats")
+	proto.RegisterType((*StatTable)(nil), "linkerd2.public.StatTable")
+	proto.RegisterType((*StatTable_PodGroup)(nil), "linkerd2.public.StatTable.PodGroup")
+	proto.RegisterType((*StatTable_PodGroup_Row)(nil), "linkerd2.public.StatTable.PodGroup.Row")
+	proto.RegisterMapType((map[string]*PodErrors)(nil), "linkerd2.public.StatTable.PodGroup.Row.ErrorsByPodEntry")
+	proto.RegisterType((*TopRoutesRequest)(nil), "linkerd2.public.TopRoutesRequest")
+	proto.RegisterType((*TopRoutesResponse)(nil), "linkerd2.public.TopRoutesResponse")
+	proto.RegisterType((*RouteTable)(nil), "linkerd2.public.RouteTable")
+	proto.RegisterType((*RouteTable_Row)(nil), "linkerd2.public.RouteTable.Row")
+	proto.RegisterMapType((map[uint32]uint64)(nil), "linkerd2.public.RouteTable.Row.ResponsesByGrpcStatusEntry")
+	proto.RegisterType((*RouteTable_LatencyBucket)(nil), "linkerd2.public.RouteTable.LatencyBucket")
+	proto.RegisterType((*StreamStats)(nil), "linkerd2.public.StreamStats")
+	proto.RegisterMapType((map[uint32]uint64)(nil), "linkerd2.public.StreamStats.ResponsesByGrpcStatusEntry")
+	proto.RegisterType((*StreamStatsTable)(nil), "linkerd2.public.StreamStatsTable")
+	proto.RegisterType((*StreamStatsTable_Row)(nil), "linkerd2.public.StreamStatsTable.Row")
+	proto.RegisterType((*StreamStatsResponse)(nil), "linkerd2.public.StreamStatsResponse")
+	proto.RegisterType((*RouteSLOsRequest)(nil), "linkerd2.public.RouteSLOsRequest")
+	proto.RegisterType((*RouteSLOsResponse)(nil), "linkerd2.public.RouteSLOsResponse")
+	proto.RegisterType((*RouteSLOTable)(nil), "linkerd2.public.RouteSLOTable")
+	proto.RegisterType((*RouteSLOTable_Row)(nil), "linkerd2.public.RouteSLOTable.Row")
+	proto.RegisterType((*RouteStatsHistoryRequest)(nil), "linkerd2.public.RouteStatsHistoryRequest")
+	proto.RegisterType((*RouteStatsHistoryResponse)(nil), "linkerd2.public.RouteStatsHistoryResponse")
+	proto.RegisterType((*RouteStatsHistory)(nil), "linkerd2.public.RouteStatsHistory")
+	proto.RegisterType((*RouteStatsHistory_Snapshot)(nil), "linkerd2.public.RouteStatsHistory.Snapshot")
 	proto.RegisterEnum("linkerd2.public.HttpMethod_Registered", HttpMethod_Registered_name, HttpMethod_Registered_value)
 	proto.RegisterEnum("linkerd2.public.Scheme_Registered", Scheme_Registered_name, Scheme_Registered_value)
 	proto.RegisterEnum("linkerd2.public.TapEvent_ProxyDirection", TapEvent_ProxyDirection_name, TapEvent_ProxyDirection_value)
@@ -4385,6 +5662,15 @@ const _ = grpc.SupportPackageIsVersion4
 type ApiClient interface {
 	StatSummary(ctx context.Context, in *StatSummaryRequest, opts ...grpc.CallOption) (*StatSummaryResponse, error)
 	TopRoutes(ctx context.Context, in *TopRoutesRequest, opts ...grpc.CallOption) (*TopRoutesResponse, error)
+	// Returns the HTTP/2 stream and gRPC status stats of the resources selected
+	// by a StatSummaryRequest.
+	StreamStats(ctx context.Context, in *StatSummaryRequest, opts ...grpc.CallOption) (*StreamStatsResponse, error)
+	// Returns the compliance and remaining error budget of the route SLOs
+	// declared in a service's ServiceProfile.
+	RouteSLOs(ctx context.Context, in *RouteSLOsRequest, opts ...grpc.CallOption) (*RouteSLOsResponse, error)
+	// Returns the route stats snapshots of a service recorded by the route stats
+	// retention subsystem.
+	RouteStatsHistory(ctx context.Context, in *RouteStatsHistoryRequest, opts ...grpc.CallOption) (*RouteStatsHistoryResponse, error)
 	ListPods(ctx context.Context, in *ListPodsRequest, opts ...grpc.CallOption) (*ListPodsResponse, error)
 	ListServices(ctx context.Context, in *ListServicesRequest, opts ...grpc.CallOption) (*ListServicesResponse, error)
 	// Superceded by `TapByResource`.
@@ -4421,6 +5707,33 @@ func (c *apiClient) TopRoutes(ctx context.Context, in *TopRoutesRequest, opts ..
 	return out, nil
 }
 
+func (c *apiClient) StreamStats(ctx context.Context, in *StatSummaryRequest, opts ...grpc.CallOption) (*StreamStatsResponse, error) {
+	out := new(StreamStatsResponse)
+	err := c.cc.Invoke(ctx, "/linkerd2.public.Api/StreamStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiClient) RouteSLOs(ctx context.Context, in *RouteSLOsRequest, opts ...grpc.CallOption) (*RouteSLOsResponse, error) {
+	out := new(RouteSLOsResponse)
+	err := c.cc.Invoke(ctx, "/linkerd2.public.Api/RouteSLOs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *apiClient) RouteStatsHistory(ctx context.Context, in *RouteStatsHistoryRequest, opts ...grpc.CallOption) (*RouteStatsHistoryResponse, error) {
+	out := new(RouteStatsHistoryResponse)
+	err := c.cc.Invoke(ctx, "/linkerd2.public.Api/RouteStatsHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *apiClient) ListPods(ctx context.Context, in *ListPodsRequest, opts ...grpc.CallOption) (*ListPodsResponse, error) {
 	out := new(ListPodsResponse)
 	err := c.cc.Invoke(ctx, "/linkerd2.public.Api/ListPods", in, out, opts...)
@@ -4526,6 +5839,15 @@ func (c *apiClient) SelfCheck(ctx context.Context, in *healthcheck.SelfCheckRequ
 type ApiServer interface {
 	StatSummary(context.Context, *StatSummaryRequest) (*StatSummaryResponse, error)
 	TopRoutes(context.Context, *TopRoutesRequest) (*TopRoutesResponse, error)
+	// Returns the HTTP/2 stream and gRPC status stats of the resources selected
+	// by a StatSummaryRequest.
+	StreamStats(context.Context, *StatSummaryRequest) (*StreamStatsResponse, error)
+	// Returns the compliance and remaining error budget of the route SLOs
+	// declared in a service's ServiceProfile.
+	RouteSLOs(context.Context, *RouteSLOsRequest) (*RouteSLOsResponse, error)
+	// Returns the route stats snapshots of a service recorded by the route stats
+	// retention subsystem.
+	RouteStatsHistory(context.Context, *RouteStatsHistoryRequest) (*RouteStatsHistoryResponse, error)
 	ListPods(context.Context, *ListPodsRequest) (*ListPodsResponse, error)
 	ListServices(context.Context, *ListServicesRequest) (*ListServicesResponse, error)
 	// Superceded by `TapByResource`.
@@ -4576,6 +5898,60 @@ func _Api_TopRoutes_Handler(srv interface{}, ctx context.Context, dec func(inter
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Api_StreamStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatSummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServer).StreamStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/linkerd2.public.Api/StreamStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServer).StreamStats(ctx, req.(*StatSummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Api_RouteSLOs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RouteSLOsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServer).RouteSLOs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/linkerd2.public.Api/RouteSLOs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServer).RouteSLOs(ctx, req.(*RouteSLOsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Api_RouteStatsHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RouteStatsHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApiServer).RouteStatsHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/linkerd2.public.Api/RouteStatsHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApiServer).RouteStatsHistory(ctx, req.(*RouteStatsHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Api_ListPods_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListPodsRequest)
 	if err := dec(in); err != nil {
@@ -4702,6 +6078,18 @@ var _Api_serviceDesc = grpc.ServiceDesc{
 			MethodName: "TopRoutes",
 			Handler:    _Api_TopRoutes_Handler,
 		},
+		{
+			MethodName: "StreamStats",
+			Handler:    _Api_StreamStats_Handler,
+		},
+		{
+			MethodName: "RouteSLOs",
+			Handler:    _Api_RouteSLOs_Handler,
+		},
+		{
+			MethodName: "RouteStatsHistory",
+			Handler:    _Api_RouteStatsHistory_Handler,
+		},
 		{
 			MethodName: "ListPods",
 			Handler:    _Api_ListPods_Handler,
@@ -4737,177 +6125,230 @@ var _Api_serviceDesc = grpc.ServiceDesc{
 func init() { proto.RegisterFile("public.proto", fileDescriptor_public_135b2b880504db8b) }
 
 var fileDescriptor_public_135b2b880504db8b = []byte{
-	// 2746 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x39, 0xbd, 0x73, 0x1b, 0xc7,
-	0xf5, 0xf8, 0x38, 0x7c, 0x3d, 0x80, 0x24, 0xb4, 0x92, 0xf5, 0x83, 0xcf, 0x1e, 0x99, 0x3a, 0xca,
-	0x32, 0x47, 0xfe, 0x05, 0xa4, 0x29, 0x4b, 0xb6, 0x2c, 0xe7, 0x83, 0x20, 0x11, 0x81, 0x09, 0x45,
-	0xc2, 0x0b, 0x28, 0x9e, 0xf1, 0x38, 0x83, 0x39, 0xe2, 0x96, 0xe4, 0x85, 0x87, 0xdb, 0xd3, 0xdd,
-	0x42, 0x32, 0xea, 0x34, 0x69, 0x92, 0x34, 0x49, 0x9d, 0x3a, 0x69, 0x32, 0x69, 0x92, 0xff, 0x21,
-	0x55, 0x52, 0x64, 0xd2, 0x25, 0x5d, 0xda, 0x34, 0xa9, 0x33, 0x99, 0xfd, 0x3a, 0xdc, 0x11, 0x00,
-	0x49, 0x29, 0x29, 0x5c, 0x61, 0xdf, 0xdb, 0xf7, 0xde, 0xbd, 0x7d, 0x9f, 0xfb, 0x16, 0x50, 0x0b,
-	0xc6, 0x47, 0x9e, 0x3b, 0x6c, 0x06, 0x21, 0x65, 0x14, 0xad, 0x78, 0xae, 0x7f, 0x46, 0x42, 0x67,
-	0xab, 0x29, 0xd1, 0xe6, 0xad, 0x13, 0x4a, 0x4f, 0x3c, 0xb2, 0x21, 0xb6, 0x8f, 0xc6, 0xc7, 0x1b,
-	0xce, 0x38, 0xb4, 0x99, 0x4b, 0x7d, 0xc9, 0x60, 0x36, 0x86, 0x74, 0x34, 0xa2, 0xfe, 0xc6, 0x29,
-	0xb1, 0x3d, 0x76, 0x3a, 0x3c, 0x25, 0xc3, 0x33, 0xb9, 0x63, 0x95, 0xa0, 0xd0, 0x1e, 0x05, 0x6c,
-	0x62, 0x3d, 0x87, 0xea, 0x0f, 0x48, 0x18, 0xb9, 0xd4, 0xdf, 0xf3, 0x8f, 0x29, 0x7a, 0x1b, 0x2a,
-	0x27, 0x54, 0x21, 0x1a, 0xd9, 0xd5, 0xec, 0x7a, 0x05, 0x4f, 0x11, 0x7c, 0xf7, 0x68, 0xec, 0x7a,
-	0xce, 0xae, 0xcd, 0x48, 0x23, 0x27, 0x77, 0x63, 0x04, 0xba, 0x0b, 0xcb, 0x21, 0xf1, 0x88, 0x1d,
-	0x11, 0x2d, 0x20, 0x2f, 0x48, 0xce, 0x61, 0xad, 0xfb, 0x70, 0x7d, 0xdf, 0x8d, 0x58, 0x8f, 0x84,
-	0x2f, 0xdc, 0x21, 0x89, 0x30, 0x79, 0x3e, 0x26, 0x11, 0xe3, 0xc2, 0x7d, 0x7b, 0x44, 0xa2, 0xc0,
-	0x1e, 0x12, 0xfd, 0xe9, 0x18, 0x61, 0xed, 0xc3, 0x8d, 0x34, 0x53, 0x14, 0x50, 0x3f, 0x22, 0xe8,
-	0x43, 0x28, 0x47, 0x0a, 0xd7, 0xc8, 0xae, 0xe6, 0xd7, 0xab, 0x5b, 0x8d, 0xe6, 0x39, 0x33, 0x35,
-	0x15, 0x13, 0x8e, 0x29, 0xad, 0xc7, 0x50, 0x52, 0x48, 0x84, 0xc0, 0xe0, 0x5f, 0x51, 0x5f, 0x14,
-	0xeb, 0xb4, 0x2a, 0xb9, 0xf3, 0xaa, 0x6c, 0xc0, 0x0a, 0x57, 0xa5, 0x4b, 0x9d, 0x2b, 0xea, 0xfe,
-	0x29, 0xd4, 0xa7, 0x0c, 0x4a, 0xef, 0x75, 0x30, 0x02, 0xea, 0x68, 0x9d, 0x6f, 0xcc, 0xe8, 0xdc,
-	0xa5, 0x0e, 0x16, 0x14, 0xd6, 0x9f, 0x0c, 0xc8, 0x77, 0xa9, 0x33, 0x57, 0xd1, 0x1b, 0x50, 0x08,
-	0xa8, 0xb3, 0xd7, 0x55, 0x4a, 0x4a, 0x00, 0xad, 0x02, 0x38, 0x24, 0xf0, 0xe8, 0x64, 0x44, 0x7c,
-	0x26, 0x9d, 0xd0, 0xc9, 0xe0, 0x04, 0x0e, 0xdd, 0x86, 0x6a, 0x48, 0x02, 0xcf, 0x1d, 0xda, 0x83,
-	0x88, 0xb0, 0x06, 0x68, 0x12, 0x85, 0xec, 0x11, 0x86, 0x3e, 0x82, 0x9b, 0x0a, 0xe2, 0x01, 0x35,
-	0x18, 0x52, 0x9f, 0x85, 0xd4, 0xf3, 0x48, 0xd8, 0xa8, 0x2a, 0xea, 0x37, 0x12, 0xfb, 0x3b, 0xf1,
-	0x36, 0x5a, 0x83, 0x5a, 0xc4, 0x6c, 0x46, 0x8e, 0xc7, 0x9e, 0x10, 0x5e, 0x53, 0xe4, 0x55, 0x8d,
-	0xe5, 0xd2, 0xdf, 0x01, 0x70, 0x6c, 0x32, 0xa2, 0xbe, 0x20, 0x59, 0x52, 0x24, 0x15, 0x89, 0xe3,
-	0x04, 0x08, 0xf2, 0x3f, 0xa2, 0x47, 0x8d, 0x65, 0xb5, 0xc3, 0x01, 0x74, 0x13, 0x8a, 0x5c, 0xc6,
-	0x38, 0x6a, 0x18, 0xe2, 0xb8, 0x0a, 0xe2, 0x56, 0xb0, 0x1d, 0x87, 0x38, 0x8d, 0xc2, 0x6a, 0x76,
-	0xbd, 0x8c, 0x25, 0x80, 0x76, 0x60, 0x25, 0x72, 0xfd, 0x21, 0xd9, 0xb7, 0x23, 0x86, 0x49, 0x40,
-	0x43, 0xd6, 0x28, 0xae, 0x66, 0xd7, 0xab, 0x5b, 0x6f, 0x36, 0x65, 0xda, 0x34, 0x75, 0xda, 0x34,
-	0x77, 0x55, 0xda, 0xe0, 0xf3, 0x1c, 0x68, 0x13, 0xae, 0x4f, 0x4f, 0x7e, 0x10, 0xbb, 0xb8, 0x24,
-	0xbe, 0x3f, 0x6f, 0x0b, 0x59, 0x50, 0x53, 0xe8, 0xae, 0x67, 0xfb, 0xa4, 0x51, 0x16, 0x3a, 0xa5,
-	0x70, 0xe8, 0x03, 0x28, 0x8e, 0x03, 0xe6, 0x8e, 0x48, 0xa3, 0x72, 0x99, 0x46, 0x8a, 0x10, 0xdd,
-	0x02, 0x08, 0x42, 0xfa, 0xd5, 0x04, 0x13, 0xdb, 0x99, 0x34, 0x56, 0x84, 0xd0, 0x04, 0x86, 0x7f,
-	0x56, 0x40, 0x3a, 0xf5, 0xea, 0x42, 0xc3, 0x14, 0xae, 0x55, 0x82, 0x02, 0x7d, 0xe9, 0x93, 0xd0,
-	0xfa, 0x4d, 0x0e, 0xa0, 0x6f, 0x07, 0x3a, 0x7a, 0x11, 0xe4, 0x03, 0xea, 0xc8, 0xc0, 0xe2, 0xb6,
-	0x0e, 0xa8, 0x73, 0x2e, 0x86, 0x72, 0x73, 0x62, 0xe8, 0x26, 0x14, 0x47, 0xf6, 0x57, 0x38, 0x88,
-	0x44, 0x84, 0xe5, 0xb0, 0x82, 0x38, 0x9e, 0xd1, 0x2e, 0x37, 0x37, 0xf7, 0xd2, 0x12, 0x56, 0x10,
-	0x8f, 0x5f, 0x46, 0xf7, 0xba, 0xc2, 0x49, 0x15, 0x2c, 0xd6, 0xc8, 0x84, 0xf2, 0x71, 0x48, 0x47,
-	0x5d, 0xed, 0x9c, 0x25, 0x1c, 0xc3, 0x5c, 0x0e, 0x5f, 0xef, 0x75, 0x95, 0xb5, 0x15, 0x24, 0xa2,
-	0x60, 0x78, 0x4a, 0x46, 0xd2, 0xb4, 0x3c, 0x0a, 0x04, 0x24, 0xf4, 0x21, 0xec, 0x94, 0x3a, 0xc2,
-	0xa8, 0x15, 0xac, 0x20, 0x9e, 0x9b, 0xf6, 0x98, 0x9d, 0xd2, 0xd0, 0x65, 0x13, 0x19, 0xe9, 0x78,
-	0x8a, 0xe0, 0x5a, 0x05, 0x36, 0x3b, 0x95, 0x41, 0x8d, 0xc5, 0xfa, 0x93, 0x5c, 0x23, 0xdb, 0x2a,
-	0x43, 0x91, 0xd9, 0xe1, 0x09, 0x61, 0xd6, 0x3f, 0x0a, 0x70, 0xa3, 0x6f, 0x07, 0xad, 0x09, 0x26,
-	0x11, 0x1d, 0x87, 0x43, 0xa2, 0xcd, 0xf6, 0x89, 0x26, 0x11, 0x96, 0xab, 0x6e, 0x59, 0x33, 0x49,
-	0xac, 0x39, 0x7a, 0xc4, 0x23, 0x43, 0xe9, 0x4e, 0xc9, 0x81, 0xb6, 0xa1, 0x30, 0xb2, 0xd9, 0xf0,
-	0x54, 0x58, 0xb6, 0xba, 0xf5, 0xfe, 0x0c, 0xeb, 0xbc, 0x2f, 0x36, 0x9f, 0x72, 0x16, 0x2c, 0x39,
-	0x17, 0xd9, 0xdf, 0xfc, 0xbd, 0x01, 0x05, 0x41, 0x88, 0x76, 0x20, 0x6f, 0x7b, 0x9e, 0xd2, 0x6e,
-	0xe3, 0x15, 0x3e, 0xd1, 0xec, 0x91, 0xe7, 0x3c, 0x10, 0x6c, 0xcf, 0x13, 0x42, 0xfc, 0x89, 0xd2,
-	0xf3, 0xb5, 0x84, 0xf8, 0x13, 0xf4, 0x6d, 0xc8, 0xfb, 0x54, 0x96, 0xa2, 0x57, 0x3b, 0x2c, 0x17,
-	0xe0, 0x53, 0x86, 0x3a, 0x50, 0x73, 0x48, 0xc4, 0x5c, 0x5f, 0x64, 0x85, 0x2c, 0x00, 0x57, 0xb2,
-	0x78, 0x27, 0x83, 0x53, 0x9c, 0xe8, 0xbb, 0x60, 0x9c, 0x32, 0x16, 0x88, 0x30, 0xac, 0x6e, 0x6d,
-	0xbe, 0xca, 0x81, 0x3a, 0x8c, 0x05, 0x9d, 0x0c, 0x16, 0xfc, 0xe6, 0x3e, 0xe4, 0x7b, 0xe4, 0x39,
-	0x6a, 0x43, 0x49, 0xb8, 0x23, 0x6e, 0x3f, 0xaf, 0xe4, 0x4a, 0xcd, 0x6b, 0x4e, 0xc0, 0xe0, 0xd2,
-	0x51, 0x23, 0x0e, 0x6e, 0x9d, 0x8d, 0x3a, 0xbc, 0x1b, 0x71, 0x78, 0xeb, 0x64, 0xd4, 0x01, 0x7e,
-	0x2b, 0x19, 0xe0, 0xba, 0xda, 0x27, 0x42, 0xfc, 0x86, 0x0a, 0x71, 0x43, 0x6d, 0x09, 0x88, 0x17,
-	0x03, 0xf1, 0xf1, 0x78, 0x61, 0xfd, 0x2b, 0x0b, 0xc0, 0x95, 0x78, 0x2a, 0xc5, 0x76, 0x00, 0x42,
-	0x72, 0xe2, 0x46, 0x8c, 0x84, 0x44, 0x16, 0x87, 0xe5, 0xad, 0xbb, 0x33, 0x87, 0x9b, 0x32, 0x34,
-	0x71, 0x4c, 0x2d, 0x5b, 0x89, 0x86, 0xd0, 0x1d, 0xa8, 0x8d, 0xfd, 0x84, 0x2c, 0x7d, 0x80, 0x14,
-	0xd6, 0xf2, 0x01, 0xa6, 0x12, 0x50, 0x09, 0xf2, 0x4f, 0xda, 0xfd, 0x7a, 0x06, 0x95, 0xc1, 0xe8,
-	0x1e, 0xf6, 0xfa, 0xf5, 0x2c, 0x47, 0x75, 0x9f, 0xf5, 0xeb, 0x39, 0x04, 0x50, 0xdc, 0x6d, 0xef,
-	0xb7, 0xfb, 0xed, 0x7a, 0x1e, 0x55, 0xa0, 0xd0, 0xdd, 0xee, 0xef, 0x74, 0xea, 0x06, 0xaa, 0x42,
-	0xe9, 0xb0, 0xdb, 0xdf, 0x3b, 0x3c, 0xe8, 0xd5, 0x0b, 0x1c, 0xd8, 0x39, 0x3c, 0x38, 0x68, 0xef,
-	0xf4, 0xeb, 0x45, 0x2e, 0xa3, 0xd3, 0xde, 0xde, 0xad, 0x97, 0x38, 0x79, 0x1f, 0x6f, 0xef, 0xb4,
-	0xeb, 0xe5, 0x56, 0x11, 0x0c, 0x36, 0x09, 0x88, 0xf5, 0xab, 0x2c, 0x14, 0x7b, 0xd2, 0xc6, 0xbb,
-	0x73, 0x8e, 0x3c, 0x1b, 0x63, 0x92, 0xf8, 0xbf, 0x3d, 0xee, 0xed, 0xd4, 0x71, 0xb9, 0x86, 0xfd,
-	0x7e, 0xb7, 0x9e, 0xe1, 0x1a, 0xf2, 0x55, 0xaf, 0x9e, 0x8d, 0x35, 0xec, 0x43, 0x65, 0xaf, 0xbb,
-	0xed, 0x38, 0x21, 0x89, 0x78, 0xb3, 0x33, 0xdc, 0xe0, 0xc5, 0x87, 0x42, 0xbb, 0x12, 0xf7, 0x26,
-	0x87, 0xd0, 0xfb, 0x02, 0xfb, 0x50, 0xa5, 0xe9, 0x1b, 0x33, 0x3a, 0xef, 0x75, 0x5f, 0x3c, 0x54,
-	0xc4, 0x0f, 0x5b, 0x06, 0xe4, 0xdc, 0xc0, 0xda, 0x04, 0x83, 0x63, 0x79, 0xf7, 0x3c, 0x76, 0xc3,
-	0x48, 0x56, 0xb1, 0x22, 0x96, 0x00, 0xaf, 0x8b, 0x9e, 0x1d, 0xc9, 0xca, 0x5f, 0xc4, 0x62, 0x6d,
-	0xed, 0x03, 0xf4, 0x87, 0x81, 0x56, 0xe4, 0x1e, 0x97, 0xa2, 0x8a, 0x8b, 0x39, 0xe7, 0x83, 0x8a,
-	0x0e, 0xe7, 0xdc, 0x40, 0x54, 0x59, 0x5e, 0xe3, 0x73, 0xa2, 0xc6, 0x8b, 0xb5, 0xe5, 0x40, 0xbe,
-	0x4d, 0xb9, 0x98, 0xfa, 0x49, 0x18, 0x0c, 0x07, 0xb2, 0x97, 0x0f, 0x86, 0xd4, 0x91, 0xb1, 0xbf,
-	0xd4, 0xc9, 0xe0, 0x65, 0xbe, 0xd3, 0x13, 0x1b, 0x3b, 0xd4, 0x21, 0x9c, 0x36, 0x24, 0x11, 0x61,
-	0x03, 0x12, 0x86, 0x34, 0x94, 0xb4, 0x39, 0x4d, 0x2b, 0x76, 0xda, 0x7c, 0x83, 0xd3, 0xb6, 0x0a,
-	0x90, 0x27, 0xbe, 0x63, 0xfd, 0x65, 0x19, 0xca, 0x7d, 0x3b, 0x68, 0xbf, 0xe0, 0x2d, 0xeb, 0x3e,
-	0x14, 0x65, 0x16, 0x2a, 0xb5, 0xdf, 0x9a, 0xcd, 0xd5, 0xf8, 0x7c, 0x58, 0x91, 0xa2, 0x27, 0x50,
-	0x95, 0xab, 0xc1, 0x88, 0x30, 0x5b, 0xd5, 0x8d, 0xbb, 0xf3, 0xb2, 0x5c, 0x7c, 0xa4, 0xd9, 0xf6,
-	0x9d, 0x80, 0xba, 0x3e, 0x7b, 0x4a, 0x98, 0x8d, 0x41, 0xb2, 0xf2, 0x35, 0xfa, 0x26, 0x54, 0x13,
-	0x95, 0x48, 0xb9, 0xea, 0x42, 0x15, 0x92, 0xf4, 0xe8, 0x33, 0xa8, 0x27, 0x40, 0xa9, 0x8c, 0xf1,
-	0x4a, 0xca, 0xac, 0x24, 0xf8, 0x85, 0x46, 0x2d, 0x80, 0x90, 0x8e, 0x99, 0x3a, 0x59, 0x49, 0x08,
-	0x5b, 0x5b, 0x2c, 0x0c, 0x73, 0x5a, 0x21, 0xa9, 0x12, 0xea, 0x25, 0xfa, 0x0c, 0x56, 0xc4, 0x25,
-	0x63, 0xe0, 0xb8, 0xa1, 0x2c, 0xb9, 0xa2, 0x93, 0x2f, 0x6f, 0xad, 0x2f, 0x16, 0xd4, 0xe5, 0x0c,
-	0xbb, 0x9a, 0x1e, 0x2f, 0x07, 0x29, 0x18, 0x7d, 0xa8, 0x4a, 0xb4, 0x6c, 0x17, 0xb7, 0x16, 0xcb,
-	0x49, 0x15, 0xe4, 0x5f, 0x66, 0xa1, 0x96, 0x3c, 0x2e, 0xfa, 0x1e, 0x14, 0x3d, 0xfb, 0x88, 0x78,
-	0xba, 0x32, 0x6f, 0x5d, 0xcd, 0x4c, 0xcd, 0x7d, 0xc1, 0xd4, 0xf6, 0x59, 0x38, 0xc1, 0x4a, 0x82,
-	0xf9, 0x08, 0xaa, 0x09, 0x34, 0xaa, 0x43, 0xfe, 0x8c, 0x4c, 0xd4, 0x55, 0x9c, 0x2f, 0x79, 0x16,
-	0xbd, 0xb0, 0xbd, 0xb1, 0x1e, 0x17, 0x24, 0xf0, 0x49, 0xee, 0xe3, 0xac, 0xf9, 0xf3, 0x2c, 0x54,
-	0x62, 0xcb, 0xa1, 0x27, 0xe7, 0x94, 0xda, 0xb8, 0x82, 0xb9, 0xff, 0xd7, 0x1a, 0xfd, 0xbb, 0xa4,
-	0xba, 0xcd, 0x21, 0xd4, 0x42, 0xd9, 0x8f, 0x06, 0xae, 0xef, 0xea, 0x7b, 0xcc, 0xbd, 0x8b, 0x0d,
-	0xde, 0x54, 0x2d, 0x6c, 0xcf, 0x77, 0x19, 0xbf, 0xd6, 0x87, 0x53, 0x10, 0x61, 0x58, 0x0a, 0xd5,
-	0x84, 0x23, 0x25, 0x5e, 0x70, 0xbd, 0x49, 0x49, 0x94, 0x3c, 0x4a, 0x64, 0x2d, 0x4c, 0xc0, 0x52,
-	0x49, 0x25, 0x93, 0xf8, 0x8e, 0x8a, 0x8a, 0x7b, 0x57, 0x14, 0xd9, 0xf6, 0x1d, 0xa9, 0x64, 0x0c,
-	0x9a, 0x0f, 0xa1, 0xdc, 0x63, 0x21, 0xb1, 0x47, 0x7b, 0x62, 0xa8, 0x3a, 0xb2, 0x23, 0x55, 0x71,
-	0xb0, 0x58, 0xcb, 0x31, 0x83, 0xef, 0x0b, 0xed, 0x0d, 0xac, 0x20, 0xf3, 0x6f, 0x59, 0xa8, 0x26,
-	0xce, 0x8e, 0x3e, 0x82, 0x9c, 0xeb, 0x28, 0x9b, 0xbd, 0x77, 0x89, 0x3a, 0xfa, 0x83, 0x38, 0xe7,
-	0x3a, 0xbc, 0x0c, 0x25, 0x5a, 0xf9, 0xbc, 0x1a, 0x30, 0xed, 0xaa, 0x71, 0x97, 0xdf, 0x88, 0x6f,
-	0x06, 0xd2, 0x00, 0xff, 0xb7, 0xa0, 0x2f, 0xc5, 0x17, 0x86, 0xd4, 0xbd, 0xd7, 0x58, 0x74, 0xef,
-	0x2d, 0x4c, 0xef, 0xbd, 0xe6, 0xef, 0xb2, 0x50, 0x4b, 0xba, 0xe2, 0xf5, 0x4f, 0xf8, 0x04, 0x90,
-	0x98, 0xa4, 0x06, 0xa9, 0xf0, 0xca, 0x5d, 0x36, 0xec, 0xd4, 0x05, 0x53, 0xd2, 0xc6, 0xef, 0x40,
-	0x95, 0x27, 0xb7, 0xea, 0x0e, 0xe2, 0xe8, 0x4b, 0x18, 0x38, 0x4a, 0xb6, 0x05, 0xf3, 0xd7, 0x39,
-	0xee, 0x94, 0xd8, 0xb9, 0x5f, 0x03, 0x95, 0xf7, 0xe0, 0xba, 0x16, 0x94, 0xcc, 0x84, 0xfc, 0x65,
-	0x92, 0xae, 0x29, 0x49, 0x09, 0xfb, 0xbf, 0x0b, 0xcb, 0xb1, 0x90, 0xa3, 0x09, 0x23, 0xf2, 0xde,
-	0x6b, 0xe0, 0x38, 0xc9, 0x5a, 0x1c, 0x89, 0xee, 0x42, 0x9e, 0xd0, 0x48, 0x75, 0xa6, 0xd9, 0xa7,
-	0x84, 0x36, 0x8d, 0x30, 0x27, 0xe0, 0x37, 0x3d, 0xc2, 0x4f, 0x6f, 0x7d, 0x0c, 0xcb, 0xe9, 0x12,
-	0xcc, 0xaf, 0x4b, 0xcf, 0x0e, 0xbe, 0x7f, 0x70, 0xf8, 0xf9, 0x41, 0x3d, 0xc3, 0x81, 0xbd, 0x83,
-	0xd6, 0xe1, 0xb3, 0x83, 0xdd, 0x7a, 0x16, 0xd5, 0xa0, 0x7c, 0xf8, 0xac, 0x2f, 0xa1, 0xdc, 0x54,
-	0xc4, 0x2a, 0x94, 0xb7, 0x03, 0x57, 0xb4, 0x5b, 0x5e, 0x69, 0x44, 0x43, 0x56, 0xd5, 0x47, 0x02,
-	0x7c, 0xc8, 0xac, 0x74, 0xa9, 0x23, 0x48, 0x22, 0xf4, 0x18, 0x8a, 0x02, 0xad, 0xeb, 0xde, 0xda,
-	0xbc, 0x17, 0x0f, 0x49, 0x1b, 0xaf, 0xb0, 0x62, 0x31, 0xff, 0x9e, 0x85, 0xb2, 0x46, 0x22, 0x0c,
-	0x15, 0x3e, 0x4c, 0xdb, 0xae, 0x4f, 0x42, 0xe5, 0xe8, 0xad, 0x2b, 0x08, 0x6b, 0xee, 0x68, 0x26,
-	0x01, 0xf2, 0x2b, 0x72, 0x2c, 0xc6, 0x7c, 0x01, 0xcb, 0xe9, 0x6d, 0xd4, 0x80, 0xd2, 0x88, 0x44,
-	0x91, 0x7d, 0xa2, 0x1f, 0x5c, 0x34, 0xc8, 0xf3, 0x6a, 0xfa, 0x7d, 0xf5, 0x38, 0x14, 0x23, 0xb8,
-	0x2d, 0xdc, 0x11, 0xe7, 0x92, 0x6f, 0x5f, 0x12, 0xe0, 0x25, 0x25, 0x24, 0x76, 0x44, 0x7d, 0xfd,
-	0x72, 0x21, 0x21, 0x61, 0x4e, 0x61, 0xac, 0x2e, 0x94, 0xf5, 0x84, 0x70, 0xf1, 0x63, 0x92, 0x18,
-	0xa3, 0x27, 0x81, 0xae, 0xea, 0x62, 0x1d, 0x3f, 0x0d, 0xe5, 0xa7, 0x4f, 0x43, 0xd6, 0x73, 0xb8,
-	0x36, 0x33, 0x0c, 0xa1, 0x07, 0x50, 0x0e, 0x49, 0xea, 0x0a, 0xf4, 0xe6, 0xc2, 0x11, 0x0a, 0xc7,
-	0xa4, 0x3c, 0x0e, 0x45, 0xd7, 0x19, 0x44, 0x42, 0x12, 0xd5, 0xe7, 0x5e, 0x12, 0xd8, 0x9e, 0x42,
-	0x5a, 0x5f, 0xc2, 0x92, 0x66, 0x96, 0x46, 0x7c, 0xcd, 0xcf, 0xc5, 0xf1, 0x94, 0x4b, 0xc6, 0xd3,
-	0x6f, 0x73, 0x80, 0x78, 0xd2, 0xf7, 0xc6, 0xa3, 0x91, 0x1d, 0x4e, 0xf4, 0x14, 0xfe, 0x2d, 0x28,
-	0xc7, 0x5a, 0x5d, 0x7d, 0x0e, 0x8f, 0x79, 0x78, 0x85, 0x61, 0xee, 0x88, 0x0c, 0x5e, 0xba, 0xbe,
-	0x43, 0x5f, 0xaa, 0x4f, 0x02, 0x47, 0x7d, 0x2e, 0x30, 0xe8, 0xff, 0xc1, 0xf0, 0xa9, 0xaf, 0xcb,
-	0xee, 0xcd, 0xd9, 0xf4, 0x1a, 0x05, 0x6c, 0xc2, 0x6f, 0x21, 0x9c, 0x0a, 0x7d, 0x0a, 0x55, 0x46,
-	0x07, 0xf1, 0xa9, 0x8d, 0x4b, 0x4e, 0xcd, 0x47, 0x07, 0x46, 0x63, 0xd7, 0x7f, 0x07, 0x96, 0x8e,
-	0x43, 0x3a, 0x9a, 0xf2, 0x17, 0x2e, 0xe7, 0xaf, 0x71, 0x0e, 0x0d, 0xb7, 0x00, 0xca, 0x74, 0xcc,
-	0x8e, 0xe8, 0xd8, 0x77, 0xac, 0xbf, 0x66, 0xe1, 0x7a, 0xca, 0x62, 0xea, 0xed, 0xf1, 0x11, 0xe4,
-	0xe8, 0xd9, 0xc2, 0x1a, 0x39, 0x87, 0xa3, 0x79, 0x78, 0xd6, 0xc9, 0xe0, 0x1c, 0x3d, 0x43, 0x0f,
-	0x93, 0xae, 0x99, 0x77, 0x37, 0x4b, 0x05, 0x40, 0x27, 0xa3, 0x9c, 0x67, 0x6e, 0x43, 0xee, 0xf0,
-	0x0c, 0x3d, 0x06, 0xf1, 0x08, 0x38, 0x60, 0xf6, 0x91, 0x17, 0x0f, 0xcc, 0xe6, 0x5c, 0x0d, 0xfa,
-	0x9c, 0x04, 0x43, 0xa4, 0x97, 0x11, 0x3f, 0x99, 0x2e, 0x7b, 0x62, 0x54, 0x6d, 0xd9, 0x91, 0x2b,
-	0x86, 0x83, 0x08, 0xad, 0xc1, 0x52, 0x34, 0x1e, 0x0e, 0x49, 0xc4, 0xe7, 0x87, 0xb1, 0x2f, 0x2f,
-	0x32, 0x06, 0xae, 0x29, 0xe4, 0x0e, 0xc7, 0x71, 0xa2, 0x63, 0xdb, 0xf5, 0xc6, 0x21, 0x51, 0x44,
-	0xb2, 0xbb, 0xd7, 0x14, 0x52, 0x12, 0xdd, 0xe1, 0x91, 0xce, 0x88, 0x3f, 0x9c, 0x0c, 0x46, 0xd1,
-	0x20, 0x78, 0xb0, 0x29, 0xdc, 0x6e, 0xe0, 0x9a, 0xc2, 0x3e, 0x8d, 0xba, 0x0f, 0x36, 0xcf, 0x53,
-	0x3d, 0x7a, 0xa0, 0xea, 0x72, 0x82, 0xea, 0xd1, 0x83, 0x19, 0xaa, 0x47, 0xc2, 0x9b, 0x69, 0xaa,
-	0x47, 0xe8, 0x1e, 0x5c, 0x63, 0x5e, 0x14, 0x77, 0x1d, 0xa9, 0x5a, 0x51, 0x10, 0xae, 0x30, 0x4f,
-	0xbf, 0x30, 0x0b, 0xed, 0xac, 0x7f, 0x1a, 0x50, 0x89, 0x8d, 0x83, 0x5a, 0x50, 0x09, 0xa8, 0x33,
-	0x38, 0x09, 0xe9, 0x58, 0xcf, 0x61, 0x6b, 0x8b, 0x6d, 0xc9, 0x0b, 0xe1, 0x13, 0x4e, 0xda, 0xc9,
-	0xe0, 0x72, 0xa0, 0xd6, 0xe6, 0x2f, 0x0c, 0x51, 0x59, 0x05, 0x80, 0x1e, 0x83, 0x11, 0xd2, 0x97,
-	0xda, 0x2f, 0xef, 0x5d, 0x41, 0x56, 0x13, 0xd3, 0x97, 0x58, 0x30, 0x99, 0x7f, 0xcc, 0x43, 0x1e,
-	0xd3, 0x97, 0xaf, 0x9b, 0xf3, 0x97, 0xa6, 0xe1, 0x3a, 0xd4, 0x47, 0x24, 0x3a, 0x25, 0xce, 0x80,
-	0x1f, 0x5a, 0x9a, 0x49, 0xfa, 0x66, 0x59, 0xe2, 0xbb, 0xd4, 0x91, 0x3e, 0xbc, 0x07, 0xd7, 0xc2,
-	0xb1, 0xef, 0xbb, 0xfe, 0x49, 0x82, 0x54, 0x3a, 0x68, 0x45, 0x6d, 0xc4, 0xb4, 0xeb, 0x50, 0xe7,
-	0xfe, 0x4f, 0x49, 0x95, 0xc6, 0x5f, 0x96, 0xf8, 0x98, 0xf2, 0x03, 0x28, 0xf0, 0x60, 0xd4, 0x6d,
-	0x76, 0xf6, 0xce, 0x36, 0x8d, 0x47, 0x2c, 0x29, 0xd1, 0x97, 0xb0, 0x24, 0x1b, 0xd8, 0xe0, 0x68,
-	0xc2, 0xe5, 0x37, 0x4a, 0xc2, 0xb0, 0x1f, 0x5f, 0xd1, 0xb0, 0x4d, 0xd9, 0xc1, 0x5a, 0x13, 0xde,
-	0xc2, 0xc4, 0xdd, 0xbf, 0x4a, 0xa6, 0x18, 0xf3, 0x0b, 0xa8, 0x9f, 0x27, 0x98, 0x33, 0x05, 0x6c,
-	0x26, 0xa7, 0x80, 0x79, 0xc9, 0x16, 0x77, 0xca, 0xc4, 0x84, 0xc0, 0xfb, 0x92, 0xc8, 0x51, 0xeb,
-	0xc7, 0x39, 0xa8, 0xf7, 0x69, 0x20, 0x46, 0x91, 0xe8, 0x6b, 0x5a, 0x72, 0xd7, 0xa0, 0xc6, 0xe8,
-	0x60, 0x7a, 0xd7, 0x2d, 0xe8, 0x3f, 0x1c, 0x18, 0xdd, 0x8e, 0xef, 0xbb, 0x1b, 0x50, 0xe4, 0x44,
-	0x9e, 0xa7, 0xfe, 0x04, 0x58, 0x2c, 0xb4, 0xc0, 0xe8, 0xb6, 0xe7, 0xa5, 0x0a, 0xe9, 0xcf, 0xb2,
-	0x70, 0x2d, 0x61, 0x05, 0x55, 0x46, 0x1f, 0x40, 0x51, 0x8c, 0xc1, 0xd1, 0xc2, 0xd7, 0x04, 0xc1,
-	0x20, 0x1c, 0xdb, 0xc9, 0x60, 0x45, 0xfc, 0xba, 0x25, 0x34, 0x55, 0xff, 0xfe, 0x9c, 0x05, 0x98,
-	0x0a, 0x47, 0xf7, 0x53, 0x89, 0xfb, 0xce, 0x05, 0x7a, 0x24, 0x12, 0xf6, 0xa7, 0x59, 0x99, 0xb0,
-	0x37, 0xa0, 0x20, 0x34, 0xd3, 0xb7, 0x37, 0x01, 0x5c, 0xee, 0xa3, 0xd4, 0x78, 0x51, 0x3c, 0x3f,
-	0x5e, 0xbc, 0x7a, 0xb6, 0x6c, 0xfd, 0xa1, 0x00, 0xf9, 0xed, 0xc0, 0x45, 0x5f, 0x40, 0x35, 0xd1,
-	0x82, 0xd0, 0xda, 0xc5, 0x0d, 0x4a, 0x44, 0xa4, 0x79, 0xe7, 0x2a, 0x5d, 0xcc, 0xca, 0xa0, 0x3e,
-	0x54, 0x62, 0x3f, 0xa2, 0xdb, 0xb3, 0xe3, 0xc1, 0xb9, 0x48, 0x37, 0xad, 0x8b, 0x48, 0x62, 0xa9,
-	0x9f, 0x41, 0x59, 0xff, 0xbf, 0x87, 0x56, 0x67, 0x38, 0xce, 0xfd, 0x57, 0x68, 0xde, 0xbe, 0x80,
-	0x22, 0x16, 0xf9, 0x43, 0xa8, 0x25, 0xff, 0xee, 0x44, 0x77, 0xe6, 0x32, 0x9d, 0xfb, 0x0b, 0xd5,
-	0x7c, 0xf7, 0x12, 0xaa, 0x58, 0xfc, 0x2e, 0xe4, 0xfb, 0x76, 0x80, 0xde, 0x9a, 0x37, 0x20, 0x69,
-	0x61, 0x6f, 0x2e, 0x9c, 0x9e, 0xac, 0xfc, 0x4f, 0x72, 0xd9, 0xcd, 0x2c, 0x7a, 0x06, 0x4b, 0xa9,
-	0xb7, 0x6d, 0xf4, 0xee, 0x95, 0xde, 0xbe, 0x2f, 0x92, 0x9c, 0xd9, 0xcc, 0xa2, 0x6d, 0x28, 0xe9,
-	0x3f, 0x9c, 0x17, 0x64, 0xa9, 0xf9, 0xf6, 0x0c, 0x3e, 0xf1, 0x27, 0xb6, 0x95, 0x41, 0x1e, 0x54,
-	0x7a, 0xc4, 0x3b, 0xde, 0x39, 0x25, 0xc3, 0x33, 0xf4, 0x8d, 0x29, 0xb1, 0xfc, 0x3f, 0xbc, 0x99,
-	0xfc, 0x3f, 0x3c, 0xa6, 0xd3, 0xda, 0x35, 0xaf, 0x4a, 0xae, 0xad, 0xd9, 0xba, 0xff, 0xc5, 0x07,
-	0x27, 0x2e, 0x3b, 0x1d, 0x1f, 0x71, 0x86, 0x0d, 0xc5, 0xad, 0x7f, 0xb7, 0x36, 0xa6, 0xff, 0x12,
-	0x6e, 0x9c, 0x10, 0x7f, 0x43, 0x2a, 0x7c, 0x54, 0x14, 0x13, 0xe0, 0xfd, 0xff, 0x04, 0x00, 0x00,
-	0xff, 0xff, 0x12, 0xea, 0x65, 0x15, 0xe3, 0x1f, 0x00, 0x00,
+	// 3593 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xcc, 0x3a, 0x4d, 0x6f, 0x1b, 0x49,
+	0x76, 0xe2, 0x37, 0xf9, 0x48, 0x4a, 0x74, 0x59, 0xe3, 0xa5, 0x39, 0xbb, 0x1e, 0x4f, 0xdb, 0x9e,
+	0xf5, 0x78, 0x36, 0x94, 0x46, 0xfe, 0x98, 0xb1, 0x67, 0xf2, 0x21, 0x4a, 0x5c, 0x4b, 0x89, 0x2c,
+	0x71, 0x8a, 0xf4, 0x2c, 0x30, 0xd8, 0x05, 0xd1, 0x62, 0x97, 0xa5, 0x5e, 0x35, 0xbb, 0xda, 0xdd,
+	0x45, 0x7b, 0x78, 0xcc, 0x2d, 0x09, 0x82, 0x04, 0x01, 0xb2, 0x40, 0x6e, 0xb9, 0x27, 0xc8, 0x61,
+	0x2f, 0x01, 0x72, 0xc8, 0x0f, 0xc8, 0x2d, 0xa7, 0xe4, 0x10, 0x24, 0x8b, 0x9c, 0xf2, 0x07, 0x92,
+	0x73, 0x82, 0x57, 0x1f, 0xcd, 0xe6, 0x97, 0x24, 0x7b, 0x1c, 0x60, 0x4e, 0xac, 0x7a, 0xf5, 0xde,
+	0xab, 0x57, 0xaf, 0xde, 0x57, 0x3d, 0x36, 0x54, 0x82, 0xd1, 0xb1, 0xe7, 0x0e, 0x9a, 0x41, 0xc8,
+	0x05, 0x27, 0x6b, 0x9e, 0xeb, 0x9f, 0xb1, 0xd0, 0xd9, 0x6a, 0x2a, 0x70, 0xe3, 0xc6, 0x09, 0xe7,
+	0x27, 0x1e, 0xdb, 0x90, 0xcb, 0xc7, 0xa3, 0x17, 0x1b, 0xce, 0x28, 0xb4, 0x85, 0xcb, 0x7d, 0x45,
+	0xd0, 0xa8, 0x0f, 0xf8, 0x70, 0xc8, 0xfd, 0x8d, 0x53, 0x66, 0x7b, 0xe2, 0x74, 0x70, 0xca, 0x06,
+	0x67, 0x6a, 0xc5, 0x2a, 0x40, 0xae, 0x3d, 0x0c, 0xc4, 0xd8, 0x7a, 0x09, 0xe5, 0xaf, 0x59, 0x18,
+	0xb9, 0xdc, 0xdf, 0xf7, 0x5f, 0x70, 0xf2, 0x43, 0x28, 0x9d, 0x70, 0x0d, 0xa8, 0xa7, 0x6e, 0xa6,
+	0xee, 0x96, 0xe8, 0x04, 0x80, 0xab, 0xc7, 0x23, 0xd7, 0x73, 0x76, 0x6d, 0xc1, 0xea, 0x69, 0xb5,
+	0x1a, 0x03, 0xc8, 0x47, 0xb0, 0x1a, 0x32, 0x8f, 0xd9, 0x11, 0x33, 0x0c, 0x32, 0x12, 0x65, 0x06,
+	0x6a, 0xdd, 0x87, 0xab, 0x07, 0x6e, 0x24, 0xba, 0x2c, 0x7c, 0xe5, 0x0e, 0x58, 0x44, 0xd9, 0xcb,
+	0x11, 0x8b, 0x04, 0x32, 0xf7, 0xed, 0x21, 0x8b, 0x02, 0x7b, 0xc0, 0xcc, 0xd6, 0x31, 0xc0, 0x3a,
+	0x80, 0xf5, 0x69, 0xa2, 0x28, 0xe0, 0x7e, 0xc4, 0xc8, 0x03, 0x28, 0x46, 0x1a, 0x56, 0x4f, 0xdd,
+	0xcc, 0xdc, 0x2d, 0x6f, 0xd5, 0x9b, 0x33, 0x6a, 0x6a, 0x6a, 0x22, 0x1a, 0x63, 0x5a, 0x5f, 0x40,
+	0x41, 0x03, 0x09, 0x81, 0x2c, 0xee, 0xa2, 0x77, 0x94, 0xe3, 0x69, 0x51, 0xd2, 0xb3, 0xa2, 0x6c,
+	0xc0, 0x1a, 0x8a, 0xd2, 0xe1, 0xce, 0x25, 0x65, 0xff, 0x12, 0x6a, 0x13, 0x02, 0x2d, 0xf7, 0x5d,
+	0xc8, 0x06, 0xdc, 0x31, 0x32, 0xaf, 0xcf, 0xc9, 0xdc, 0xe1, 0x0e, 0x95, 0x18, 0xd6, 0x3f, 0x67,
+	0x21, 0xd3, 0xe1, 0xce, 0x42, 0x41, 0xd7, 0x21, 0x17, 0x70, 0x67, 0xbf, 0xa3, 0x85, 0x54, 0x13,
+	0x72, 0x13, 0xc0, 0x61, 0x81, 0xc7, 0xc7, 0x43, 0xe6, 0x0b, 0x75, 0x09, 0x7b, 0x2b, 0x34, 0x01,
+	0x23, 0x1f, 0x42, 0x39, 0x64, 0x81, 0xe7, 0x0e, 0xec, 0x7e, 0xc4, 0x44, 0x1d, 0x0c, 0x8a, 0x06,
+	0x76, 0x99, 0x20, 0x9f, 0xc1, 0x35, 0x3d, 0x43, 0x83, 0xea, 0x0f, 0xb8, 0x2f, 0x42, 0xee, 0x79,
+	0x2c, 0xac, 0x97, 0x35, 0xf6, 0x7b, 0x89, 0xf5, 0x9d, 0x78, 0x99, 0xdc, 0x82, 0x4a, 0x24, 0x6c,
+	0xc1, 0x5e, 0x8c, 0x3c, 0xc9, 0xbc, 0xa2, 0xd1, 0xcb, 0x06, 0x8a, 0xdc, 0x3f, 0x00, 0x70, 0x6c,
+	0x36, 0xe4, 0xbe, 0x44, 0xa9, 0x6a, 0x94, 0x92, 0x82, 0x21, 0x02, 0x81, 0xcc, 0x2f, 0xf9, 0x71,
+	0x7d, 0x55, 0xaf, 0xe0, 0x84, 0x5c, 0x83, 0x3c, 0xf2, 0x18, 0x45, 0xf5, 0xac, 0x3c, 0xae, 0x9e,
+	0xa1, 0x16, 0x6c, 0xc7, 0x61, 0x4e, 0x3d, 0x77, 0x33, 0x75, 0xb7, 0x48, 0xd5, 0x84, 0xec, 0xc0,
+	0x5a, 0xe4, 0xfa, 0x03, 0x76, 0x60, 0x47, 0x82, 0xb2, 0x80, 0x87, 0xa2, 0x9e, 0xbf, 0x99, 0xba,
+	0x5b, 0xde, 0xba, 0xde, 0x54, 0x6e, 0xd3, 0x34, 0x6e, 0xd3, 0xdc, 0xd5, 0x6e, 0x43, 0x67, 0x29,
+	0xc8, 0x26, 0x5c, 0x9d, 0x9c, 0xfc, 0x30, 0xbe, 0xe2, 0x82, 0xdc, 0x7f, 0xd1, 0x12, 0xb1, 0xa0,
+	0xa2, 0xc1, 0x1d, 0xcf, 0xf6, 0x59, 0xbd, 0x28, 0x65, 0x9a, 0x82, 0x91, 0x4f, 0x21, 0x3f, 0x0a,
+	0x84, 0x3b, 0x64, 0xf5, 0xd2, 0x45, 0x12, 0x69, 0x44, 0x72, 0x03, 0x20, 0x08, 0xf9, 0xb7, 0x63,
+	0xca, 0x6c, 0x67, 0x5c, 0x5f, 0x93, 0x4c, 0x13, 0x10, 0xdc, 0x56, 0xce, 0x8c, 0xeb, 0xd5, 0xa4,
+	0x84, 0x53, 0xb0, 0x56, 0x01, 0x72, 0xfc, 0xb5, 0xcf, 0x42, 0xeb, 0x6f, 0xd2, 0x00, 0x3d, 0x3b,
+	0x30, 0xd6, 0x4b, 0x20, 0x13, 0x70, 0xa7, 0x9e, 0x32, 0xba, 0x0e, 0xb8, 0x33, 0x63, 0x43, 0xe9,
+	0x05, 0x36, 0x74, 0x0d, 0xf2, 0x43, 0xfb, 0x5b, 0x1a, 0x44, 0xd2, 0xc2, 0xd2, 0x54, 0xcf, 0x10,
+	0x2e, 0x78, 0x07, 0xd5, 0x8d, 0xb7, 0x54, 0xa5, 0x7a, 0x86, 0xf6, 0x2b, 0xf8, 0x7e, 0x47, 0x5e,
+	0x52, 0x89, 0xca, 0x31, 0x69, 0x40, 0xf1, 0x45, 0xc8, 0x87, 0x1d, 0x73, 0x39, 0x55, 0x1a, 0xcf,
+	0x91, 0x0f, 0x8e, 0xf7, 0x3b, 0x5a, 0xdb, 0x7a, 0x86, 0xf0, 0x68, 0x70, 0xca, 0x86, 0x4a, 0xb5,
+	0x25, 0xaa, 0x67, 0x52, 0x1e, 0x26, 0x4e, 0xb9, 0x23, 0x95, 0x5a, 0xa2, 0x7a, 0x86, 0xbe, 0x69,
+	0x8f, 0xc4, 0x29, 0x0f, 0x5d, 0x31, 0x56, 0x96, 0x4e, 0x27, 0x00, 0x94, 0x2a, 0xb0, 0xc5, 0xa9,
+	0x32, 0x6a, 0x2a, 0xc7, 0x4f, 0xd2, 0xf5, 0x54, 0xab, 0x08, 0x79, 0x61, 0x87, 0x27, 0x4c, 0x58,
+	0xff, 0x50, 0x80, 0xf5, 0x9e, 0x1d, 0xb4, 0xc6, 0x94, 0x45, 0x7c, 0x14, 0x0e, 0x98, 0x51, 0xdb,
+	0x13, 0x83, 0x22, 0x35, 0x57, 0xde, 0xb2, 0xe6, 0x9c, 0xd8, 0x50, 0x74, 0x99, 0xc7, 0x06, 0xea,
+	0x3a, 0x15, 0x05, 0xd9, 0x86, 0xdc, 0xd0, 0x16, 0x83, 0x53, 0xa9, 0xd9, 0xf2, 0xd6, 0x27, 0x73,
+	0xa4, 0x8b, 0x76, 0x6c, 0x3e, 0x43, 0x12, 0xaa, 0x28, 0x97, 0xea, 0x7f, 0x17, 0xf2, 0x2f, 0x5c,
+	0x4f, 0xb0, 0x50, 0xea, 0xbf, 0xbc, 0xf5, 0x93, 0xcb, 0xf1, 0xfe, 0xa9, 0xa4, 0xa1, 0x9a, 0xb6,
+	0xf1, 0xf7, 0x59, 0xc8, 0xc9, 0xed, 0xc8, 0x0e, 0x64, 0x6c, 0xcf, 0xd3, 0x67, 0xdc, 0x78, 0x03,
+	0x41, 0x9b, 0x5d, 0xf6, 0x12, 0xcd, 0xc9, 0xf6, 0x3c, 0xc9, 0xc4, 0x1f, 0xd7, 0xd3, 0x6f, 0xcf,
+	0xc4, 0x1f, 0x93, 0xdf, 0x85, 0x8c, 0xcf, 0x55, 0x40, 0x7b, 0x33, 0x95, 0x21, 0x03, 0x9f, 0x0b,
+	0xb2, 0x07, 0x15, 0x87, 0x45, 0xc2, 0xf5, 0xa5, 0x6f, 0x45, 0xf5, 0xec, 0x65, 0xef, 0x6d, 0x6f,
+	0x85, 0x4e, 0x51, 0x92, 0x9f, 0x42, 0xf6, 0x54, 0x88, 0x40, 0x1a, 0x73, 0x79, 0x6b, 0xf3, 0x4d,
+	0x0e, 0xb4, 0x27, 0x44, 0xb0, 0xb7, 0x42, 0x25, 0x7d, 0xe3, 0x00, 0x32, 0x5d, 0xf6, 0x92, 0xb4,
+	0xa1, 0x20, 0x2f, 0x35, 0x4e, 0x62, 0x6f, 0x64, 0x10, 0x86, 0xb6, 0x31, 0x86, 0x2c, 0x72, 0x27,
+	0xf5, 0xd8, 0x45, 0x8c, 0x4f, 0x1b, 0x27, 0xa9, 0xc7, 0x4e, 0x62, 0x5c, 0xda, 0xb8, 0xc9, 0x8d,
+	0xa4, 0x9b, 0x98, 0x9c, 0x31, 0x01, 0x91, 0x75, 0xed, 0x28, 0x59, 0xbd, 0x24, 0x67, 0x18, 0x52,
+	0xe4, 0xe6, 0xf1, 0xa0, 0xf1, 0x27, 0x29, 0xc8, 0x2b, 0x5b, 0x22, 0x77, 0x60, 0x55, 0x45, 0xe8,
+	0xfe, 0xc0, 0xb3, 0xa3, 0x48, 0x1f, 0xae, 0x4a, 0xab, 0x0a, 0xba, 0xa3, 0x80, 0xe4, 0x09, 0x94,
+	0x87, 0xae, 0xdf, 0xf7, 0x6c, 0xc1, 0xfc, 0x81, 0xb1, 0x91, 0x73, 0x42, 0x22, 0x0c, 0x5d, 0xff,
+	0x40, 0x21, 0x93, 0x1f, 0x01, 0x84, 0xc1, 0xa0, 0xaf, 0xcf, 0xa4, 0xea, 0x8d, 0x52, 0x18, 0x0c,
+	0x9e, 0x49, 0x80, 0xf5, 0xdf, 0x29, 0x00, 0xd4, 0x88, 0x9a, 0x92, 0x3d, 0x80, 0x90, 0x9d, 0xb8,
+	0x91, 0x60, 0x21, 0x53, 0xf1, 0x6e, 0x75, 0xeb, 0xa3, 0x39, 0x4d, 0x4f, 0x08, 0x9a, 0x34, 0xc6,
+	0x56, 0xd9, 0xd1, 0xcc, 0xc8, 0x6d, 0xa8, 0x8c, 0xfc, 0x04, 0x2f, 0xa3, 0xcd, 0x29, 0xa8, 0xe5,
+	0x03, 0x4c, 0x38, 0x90, 0x02, 0x64, 0x9e, 0xb6, 0x7b, 0xb5, 0x15, 0x52, 0x84, 0x6c, 0xe7, 0xa8,
+	0xdb, 0xab, 0xa5, 0x10, 0xd4, 0x79, 0xde, 0xab, 0xa5, 0x09, 0x40, 0x7e, 0xb7, 0x7d, 0xd0, 0xee,
+	0xb5, 0x6b, 0x19, 0x52, 0x82, 0x5c, 0x67, 0xbb, 0xb7, 0xb3, 0x57, 0xcb, 0x92, 0x32, 0x14, 0x8e,
+	0x3a, 0xbd, 0xfd, 0xa3, 0xc3, 0x6e, 0x2d, 0x87, 0x93, 0x9d, 0xa3, 0xc3, 0xc3, 0xf6, 0x4e, 0xaf,
+	0x96, 0x47, 0x1e, 0x7b, 0xed, 0xed, 0xdd, 0x5a, 0x01, 0xd1, 0x7b, 0x74, 0x7b, 0xa7, 0x5d, 0x2b,
+	0xb6, 0xf2, 0x90, 0x15, 0xe3, 0x80, 0x59, 0x7f, 0x9d, 0x82, 0x7c, 0x57, 0x5d, 0xf8, 0xee, 0x82,
+	0x23, 0xcf, 0x1b, 0xbc, 0x42, 0xfe, 0xae, 0xc7, 0xfd, 0x70, 0xea, 0xb8, 0x28, 0x61, 0xaf, 0xd7,
+	0xa9, 0xad, 0xa0, 0x84, 0x38, 0xea, 0xd6, 0x52, 0xb1, 0x84, 0x3d, 0x28, 0xed, 0x77, 0xb6, 0x1d,
+	0x27, 0x64, 0x11, 0xe6, 0xef, 0xac, 0x1b, 0xbc, 0x7a, 0x20, 0xa5, 0x2b, 0xa0, 0x69, 0xe1, 0x8c,
+	0x7c, 0x22, 0xa1, 0x8f, 0xb4, 0x3d, 0xbc, 0x37, 0x27, 0xf3, 0x7e, 0xe7, 0xd5, 0x23, 0x8d, 0xfc,
+	0xa8, 0x95, 0x85, 0xb4, 0x1b, 0x58, 0x9b, 0x90, 0x45, 0x28, 0x16, 0x04, 0x2f, 0xdc, 0x30, 0x52,
+	0x81, 0x39, 0x4f, 0xd5, 0x04, 0x43, 0xbd, 0x67, 0x47, 0x2a, 0x99, 0xe5, 0xa9, 0x1c, 0x5b, 0x07,
+	0x00, 0xbd, 0x41, 0x60, 0x04, 0xb9, 0x87, 0x5c, 0x74, 0xa4, 0x6b, 0x2c, 0xd8, 0x50, 0xe3, 0xd1,
+	0xb4, 0x1b, 0xc8, 0xc4, 0xc1, 0x43, 0xc5, 0xad, 0x4a, 0xe5, 0xd8, 0x72, 0x20, 0xd3, 0xe6, 0xc8,
+	0xa6, 0x76, 0x82, 0x56, 0x69, 0x8c, 0x9f, 0x3b, 0xca, 0x11, 0xab, 0x7b, 0x2b, 0x74, 0x15, 0x57,
+	0xba, 0xca, 0xfe, 0xb9, 0xc3, 0x10, 0x37, 0x64, 0x11, 0x13, 0x7d, 0x16, 0x86, 0x3c, 0x54, 0xb8,
+	0x69, 0x83, 0x2b, 0x57, 0xda, 0xb8, 0x80, 0xb8, 0xad, 0x1c, 0x64, 0x98, 0xef, 0x58, 0x7f, 0xb7,
+	0x06, 0xc5, 0x9e, 0x1d, 0xb4, 0x5f, 0x61, 0x16, 0xbe, 0x0f, 0x79, 0x15, 0x12, 0xb4, 0xd8, 0xef,
+	0xcf, 0x07, 0x8e, 0xf8, 0x7c, 0x54, 0xa3, 0x92, 0xa7, 0x50, 0x56, 0x23, 0x74, 0x1c, 0x5b, 0x07,
+	0xb1, 0x8f, 0x16, 0x85, 0x1c, 0xb9, 0x49, 0xb3, 0xed, 0x3b, 0x01, 0x77, 0x7d, 0xf1, 0x8c, 0x09,
+	0x9b, 0x82, 0x22, 0xc5, 0x31, 0xf9, 0x6d, 0x28, 0x27, 0xc2, 0x62, 0x3d, 0x7d, 0xb1, 0x08, 0x49,
+	0x7c, 0xf2, 0x15, 0xd4, 0x12, 0x53, 0x25, 0x4c, 0xf6, 0x8d, 0x84, 0x59, 0x4b, 0xd0, 0x4b, 0x89,
+	0x5a, 0x00, 0x21, 0x1f, 0x09, 0x7d, 0xb2, 0x82, 0x64, 0x76, 0x6b, 0x39, 0x33, 0x8a, 0xb8, 0x92,
+	0x53, 0x29, 0x34, 0x43, 0xf2, 0x15, 0xac, 0xc9, 0xba, 0xa9, 0xef, 0xb8, 0xa1, 0x8a, 0xff, 0xb2,
+	0x38, 0x59, 0xdd, 0xba, 0xbb, 0x9c, 0x51, 0x07, 0x09, 0x76, 0x0d, 0x3e, 0x5d, 0x0d, 0xa6, 0xe6,
+	0xe4, 0x81, 0xce, 0x17, 0x2a, 0x77, 0xdd, 0x58, 0xce, 0x67, 0x2a, 0x3b, 0xfc, 0x2a, 0x05, 0x95,
+	0xe4, 0x71, 0xc9, 0xef, 0x43, 0xde, 0xb3, 0x8f, 0x99, 0x67, 0xd2, 0xc4, 0xd6, 0xe5, 0xd4, 0xd4,
+	0x3c, 0x90, 0x44, 0x6d, 0x5f, 0x84, 0x63, 0xaa, 0x39, 0x34, 0x1e, 0x43, 0x39, 0x01, 0x26, 0x35,
+	0xc8, 0x9c, 0xb1, 0xb1, 0x7e, 0x5d, 0xe0, 0x10, 0xbd, 0xe8, 0x95, 0xed, 0x8d, 0xcc, 0x0b, 0x48,
+	0x4d, 0x9e, 0xa4, 0x3f, 0x4f, 0x35, 0xfe, 0x3c, 0x05, 0xa5, 0x58, 0x73, 0xe4, 0xe9, 0x8c, 0x50,
+	0x1b, 0x97, 0x50, 0xf7, 0xbb, 0x96, 0xe8, 0x5f, 0x8a, 0x3a, 0xf5, 0x1d, 0x41, 0x25, 0x54, 0xc9,
+	0xb1, 0xef, 0xfa, 0xae, 0x29, 0xcd, 0xee, 0x9d, 0xaf, 0xf0, 0xa6, 0xce, 0xa7, 0xfb, 0xbe, 0x2b,
+	0xf0, 0xa5, 0x12, 0x4e, 0xa6, 0x84, 0x42, 0x35, 0xd4, 0x8f, 0x36, 0xc5, 0xf1, 0x9c, 0x8a, 0x6d,
+	0x8a, 0xa3, 0xa2, 0xd1, 0x2c, 0x2b, 0x61, 0x62, 0xae, 0x84, 0xd4, 0x3c, 0x99, 0xef, 0xd4, 0x33,
+	0x97, 0x14, 0x52, 0x91, 0xb4, 0x7d, 0x47, 0x09, 0x19, 0x4f, 0x1b, 0x8f, 0xa0, 0xd8, 0x15, 0x21,
+	0xb3, 0x87, 0xfb, 0xf2, 0x9d, 0x78, 0x6c, 0x47, 0x3a, 0xe2, 0x50, 0x39, 0x56, 0x2f, 0x27, 0x5c,
+	0x97, 0xd2, 0x67, 0xa9, 0x9e, 0x35, 0xfe, 0x23, 0x05, 0xe5, 0xc4, 0xd9, 0xc9, 0x67, 0x90, 0x76,
+	0x1d, 0xad, 0xb3, 0x1f, 0x5f, 0x20, 0x8e, 0xd9, 0x90, 0xa6, 0x5d, 0x07, 0xc3, 0x50, 0xa2, 0xae,
+	0x58, 0x14, 0x03, 0x26, 0x59, 0x35, 0x2e, 0x39, 0x36, 0xe2, 0x32, 0x45, 0x29, 0xe0, 0x07, 0x4b,
+	0xf2, 0x52, 0x5c, 0xbd, 0x4c, 0x95, 0xf2, 0xd9, 0x65, 0xa5, 0x7c, 0x6e, 0x52, 0xca, 0x37, 0x7e,
+	0x9d, 0x82, 0x4a, 0xf2, 0x2a, 0xde, 0xfe, 0x84, 0x4f, 0x81, 0xc8, 0xc7, 0x61, 0x7f, 0xca, 0xbc,
+	0x2e, 0x2c, 0x56, 0x6a, 0x92, 0x28, 0xa9, 0xe3, 0x0f, 0xa0, 0x8c, 0xce, 0xad, 0xb3, 0x83, 0x3c,
+	0x7a, 0x95, 0x02, 0x82, 0x54, 0x5a, 0x68, 0xfc, 0x61, 0x06, 0xca, 0x46, 0xe6, 0xb6, 0xef, 0x7c,
+	0x0f, 0x44, 0xde, 0x87, 0xab, 0x86, 0x51, 0xd2, 0x13, 0x32, 0x17, 0x71, 0xba, 0xa2, 0x39, 0x25,
+	0xf4, 0x7f, 0x07, 0x9b, 0x44, 0x9a, 0xc9, 0xf1, 0x58, 0x30, 0x55, 0x84, 0x67, 0x69, 0xec, 0x64,
+	0x2d, 0x04, 0x92, 0x8f, 0x20, 0xc3, 0x78, 0xa4, 0x33, 0xd3, 0x7c, 0x77, 0xa4, 0xcd, 0x23, 0x8a,
+	0x08, 0xe4, 0x63, 0x4c, 0x9f, 0xea, 0x70, 0x43, 0x16, 0x45, 0xf6, 0x09, 0x8b, 0xe4, 0xb3, 0x30,
+	0x4b, 0xd7, 0x34, 0xfc, 0x99, 0x06, 0x93, 0x4f, 0xe0, 0x4a, 0xbc, 0x73, 0x8c, 0x5b, 0x92, 0xb8,
+	0x35, 0xb3, 0x60, 0x90, 0xb1, 0x9c, 0x65, 0xa8, 0x55, 0xeb, 0x73, 0x58, 0x9d, 0x0e, 0xed, 0x58,
+	0x86, 0x3d, 0x3f, 0xfc, 0x83, 0xc3, 0xa3, 0x9f, 0x1d, 0xd6, 0x56, 0x70, 0xb2, 0x7f, 0xd8, 0x3a,
+	0x7a, 0x7e, 0xb8, 0x5b, 0x4b, 0x91, 0x0a, 0x14, 0x8f, 0x9e, 0xf7, 0xd4, 0x2c, 0x3d, 0x61, 0x71,
+	0x13, 0x8a, 0xdb, 0x81, 0x2b, 0xd3, 0x38, 0x46, 0x30, 0x99, 0xe8, 0x75, 0x54, 0x53, 0x13, 0x7c,
+	0x8f, 0x97, 0x3a, 0xdc, 0x91, 0x28, 0x11, 0xf9, 0x02, 0xf2, 0x12, 0x6c, 0xe2, 0xe9, 0xad, 0x45,
+	0xcd, 0x21, 0x85, 0x1b, 0x8f, 0xa8, 0x26, 0x69, 0xfc, 0x26, 0x05, 0x45, 0x03, 0x24, 0x14, 0x4a,
+	0xd8, 0x77, 0xb0, 0x5d, 0x9f, 0x85, 0xda, 0x80, 0xb6, 0x2e, 0xc1, 0xac, 0xb9, 0x63, 0x88, 0xe4,
+	0x14, 0xdf, 0x01, 0x31, 0x9b, 0xc6, 0x2b, 0x58, 0x9d, 0x5e, 0x26, 0x75, 0x28, 0x68, 0x7d, 0xea,
+	0x53, 0x99, 0x29, 0xfa, 0xeb, 0x64, 0x7f, 0xdd, 0x47, 0x8b, 0x01, 0xa8, 0x0b, 0x77, 0x88, 0x54,
+	0xaa, 0x6c, 0x57, 0x13, 0x0c, 0x55, 0x21, 0xb3, 0x23, 0xee, 0x9b, 0x26, 0x8f, 0x9a, 0x49, 0x75,
+	0x4a, 0x65, 0x75, 0xa0, 0x68, 0x9e, 0x41, 0xe7, 0xf7, 0xdd, 0x64, 0xc7, 0x61, 0x1c, 0x98, 0x6c,
+	0x21, 0xc7, 0x71, 0x17, 0x2d, 0x33, 0xe9, 0xa2, 0x59, 0x2f, 0xe1, 0xca, 0xdc, 0x8b, 0x8f, 0x3c,
+	0x84, 0x62, 0xc8, 0xa6, 0x4a, 0xab, 0xeb, 0x4b, 0xdf, 0x89, 0x34, 0x46, 0x45, 0xfb, 0x96, 0xd9,
+	0xac, 0x1f, 0x49, 0x4e, 0xdc, 0x9c, 0xbb, 0x2a, 0xa1, 0x5d, 0x0d, 0xb4, 0x7e, 0x0e, 0x55, 0x43,
+	0xac, 0x94, 0xf8, 0x96, 0xdb, 0xc5, 0xf6, 0x94, 0x4e, 0xda, 0xd3, 0x6f, 0x32, 0x40, 0x30, 0x98,
+	0x74, 0x47, 0xc3, 0xa1, 0x1d, 0x8e, 0x4d, 0xc3, 0xe2, 0x77, 0xb0, 0x57, 0xaa, 0xa5, 0xba, 0x7c,
+	0xcb, 0x22, 0xa6, 0xc1, 0xc8, 0x85, 0xbd, 0xa8, 0xfe, 0x6b, 0xd7, 0x77, 0xf8, 0x6b, 0xbd, 0x25,
+	0x20, 0xe8, 0x67, 0x12, 0x42, 0x7e, 0x02, 0x59, 0x9f, 0xfb, 0x26, 0x9c, 0x5f, 0x9b, 0x77, 0x5b,
+	0x6c, 0x39, 0x63, 0x75, 0x83, 0x58, 0xe4, 0x4b, 0x28, 0x0b, 0xde, 0x8f, 0x4f, 0x9d, 0xbd, 0xe0,
+	0xd4, 0xf8, 0x24, 0x11, 0x3c, 0xbe, 0xfa, 0xdf, 0x83, 0x2a, 0x36, 0x84, 0x26, 0xf4, 0xb9, 0x8b,
+	0xe9, 0x2b, 0x48, 0x11, 0x73, 0xb8, 0x0e, 0x45, 0xe6, 0x3b, 0x7d, 0xd9, 0x87, 0xc3, 0x42, 0x31,
+	0x43, 0x0b, 0xcc, 0x77, 0x7a, 0xd8, 0x6d, 0xbb, 0x0d, 0xab, 0x27, 0x21, 0x1f, 0x05, 0xfd, 0xe3,
+	0x71, 0x5f, 0x5e, 0x9c, 0xee, 0x35, 0x55, 0x24, 0xb4, 0x35, 0x96, 0x65, 0x0a, 0x79, 0x1f, 0x4a,
+	0x62, 0xa0, 0x02, 0xb9, 0x8a, 0x24, 0x45, 0x5a, 0x14, 0x03, 0x19, 0xc6, 0x65, 0x53, 0xd2, 0x73,
+	0x87, 0xae, 0x6a, 0xae, 0x56, 0xa9, 0x9a, 0xe0, 0x7b, 0x35, 0xb0, 0x4f, 0x58, 0x5f, 0xf0, 0x33,
+	0xe6, 0xeb, 0xa6, 0x53, 0x09, 0x21, 0x3d, 0x04, 0x20, 0xc7, 0x80, 0x3b, 0x9a, 0x63, 0x45, 0x71,
+	0x0c, 0xb8, 0x23, 0x39, 0xb6, 0x00, 0x8a, 0x7c, 0x24, 0x8e, 0xf9, 0xc8, 0x77, 0xac, 0xff, 0x4d,
+	0xc1, 0xd5, 0xa9, 0x1b, 0xd6, 0x6d, 0xe5, 0xc7, 0x90, 0xe6, 0x67, 0x4b, 0x73, 0xc5, 0x02, 0x8a,
+	0xe6, 0xd1, 0xd9, 0xde, 0x0a, 0x4d, 0xf3, 0x33, 0xf2, 0x28, 0x69, 0x4a, 0x8b, 0x6a, 0xd4, 0x29,
+	0x83, 0xdd, 0x5b, 0xd1, 0xc6, 0xd6, 0x70, 0x21, 0x7d, 0x74, 0x46, 0xbe, 0x00, 0xd9, 0xdf, 0xed,
+	0x0b, 0xfb, 0xd8, 0x8b, 0xbb, 0x18, 0x8d, 0x85, 0x12, 0xf4, 0x10, 0x85, 0x42, 0x64, 0x86, 0x18,
+	0xed, 0xd7, 0x7c, 0xf6, 0xad, 0xe8, 0x27, 0x54, 0xa3, 0xbd, 0x06, 0xc1, 0x1d, 0xa3, 0x1e, 0xd4,
+	0x80, 0x89, 0xd4, 0xb2, 0x87, 0xd9, 0xb2, 0x23, 0x77, 0xa0, 0xd4, 0x7d, 0x0b, 0xaa, 0xd1, 0x68,
+	0x30, 0x60, 0x11, 0xbe, 0xb7, 0x46, 0xbe, 0x2a, 0xfc, 0xb2, 0xb4, 0xa2, 0x81, 0x3b, 0x08, 0x43,
+	0xa4, 0x17, 0xb6, 0xeb, 0x8d, 0x42, 0xa6, 0x91, 0x54, 0x35, 0x54, 0xd1, 0x40, 0x85, 0x74, 0x1b,
+	0x3d, 0x58, 0x76, 0x17, 0xfa, 0xc3, 0xa8, 0x1f, 0x3c, 0xdc, 0x94, 0xe6, 0x9c, 0xa5, 0x15, 0x0d,
+	0x7d, 0x16, 0x75, 0x1e, 0x6e, 0xce, 0x62, 0x3d, 0x7e, 0x58, 0xcf, 0xce, 0x62, 0x3d, 0x7e, 0x38,
+	0x87, 0xf5, 0xb8, 0x9e, 0x9b, 0xc3, 0x7a, 0x4c, 0xee, 0xc1, 0x15, 0xe1, 0x45, 0x71, 0x96, 0x56,
+	0xa2, 0xe5, 0x55, 0x16, 0x13, 0x9e, 0xf9, 0x93, 0x41, 0x49, 0xf7, 0x00, 0xae, 0x8d, 0xfc, 0x21,
+	0x8b, 0x4e, 0x99, 0x33, 0x43, 0xa0, 0x52, 0xd9, 0xba, 0x59, 0x4d, 0x52, 0x59, 0x23, 0x28, 0xf6,
+	0x8c, 0x61, 0x7e, 0x0c, 0x35, 0x1e, 0x30, 0xd9, 0xd1, 0xf7, 0x95, 0x8b, 0x47, 0x5a, 0x59, 0x6b,
+	0x08, 0xdf, 0x99, 0x80, 0x65, 0x77, 0x85, 0xd9, 0x8e, 0x4e, 0xd4, 0x4a, 0x59, 0x25, 0x84, 0xa8,
+	0x24, 0xfd, 0x01, 0x94, 0x5f, 0x87, 0xae, 0x30, 0x89, 0x5c, 0xa9, 0x09, 0x24, 0x48, 0x22, 0x58,
+	0x7f, 0x9a, 0x87, 0x52, 0x7c, 0xe3, 0xa4, 0xa5, 0x8c, 0x5b, 0xba, 0x90, 0x36, 0xd1, 0x5b, 0xcb,
+	0x0d, 0x04, 0xb3, 0xd1, 0x53, 0x44, 0xdd, 0x5b, 0x91, 0x3e, 0x20, 0xc7, 0x8d, 0x5f, 0xe7, 0x64,
+	0x7a, 0x93, 0x13, 0xf2, 0x05, 0x64, 0x43, 0xfe, 0xda, 0x18, 0xdb, 0x8f, 0x2f, 0xc1, 0xab, 0x49,
+	0xf9, 0x6b, 0x2a, 0x89, 0x1a, 0xff, 0x95, 0x85, 0x0c, 0xe5, 0xaf, 0xdf, 0x36, 0xf0, 0x5e, 0x18,
+	0x0b, 0xef, 0x42, 0x4d, 0x5f, 0x13, 0x1e, 0x5a, 0x5d, 0x91, 0xd2, 0xd0, 0xaa, 0x82, 0x77, 0xb8,
+	0xa3, 0xae, 0xf4, 0x1e, 0x5c, 0x09, 0x47, 0xbe, 0xef, 0xfa, 0x27, 0x09, 0xd4, 0xac, 0x2e, 0x62,
+	0xd4, 0x42, 0x8c, 0x7b, 0x17, 0x6a, 0x68, 0xac, 0x53, 0x5c, 0x95, 0xa5, 0xac, 0x2a, 0x78, 0x8c,
+	0xf9, 0x29, 0xe4, 0x54, 0x18, 0xc9, 0x2d, 0x29, 0xc8, 0x27, 0xce, 0x43, 0x15, 0x26, 0xf9, 0x39,
+	0x54, 0x55, 0x15, 0x81, 0x61, 0x0f, 0xff, 0x11, 0x28, 0x48, 0xc5, 0x7e, 0x7e, 0x49, 0xc5, 0x36,
+	0x55, 0x19, 0xd1, 0x1a, 0x63, 0x1d, 0x21, 0x1f, 0x76, 0x65, 0x36, 0x81, 0xa0, 0xc6, 0x54, 0x66,
+	0x54, 0x4f, 0x38, 0xd5, 0xa4, 0x07, 0x09, 0xfa, 0x1a, 0x21, 0xe4, 0x51, 0x32, 0x9c, 0xc2, 0x92,
+	0xab, 0x30, 0x66, 0x9c, 0x88, 0xb4, 0x2d, 0x40, 0xfb, 0xe8, 0x4b, 0x53, 0x28, 0xbf, 0x99, 0x29,
+	0x14, 0x02, 0xee, 0x50, 0xb4, 0x86, 0x6f, 0xa0, 0x36, 0x2b, 0xfd, 0x82, 0xf7, 0xe7, 0x66, 0xf2,
+	0xfd, 0xb9, 0x28, 0xbc, 0xc5, 0xb5, 0x54, 0xe2, 0x6d, 0x8a, 0x95, 0x8b, 0x8c, 0x8a, 0xd6, 0x5f,
+	0x65, 0xa0, 0xd6, 0xe3, 0x81, 0x7c, 0x04, 0x47, 0xdf, 0xd3, 0xa4, 0x7c, 0x0b, 0x2a, 0x82, 0xf7,
+	0x27, 0xaf, 0xac, 0x9c, 0xf9, 0xf7, 0x4e, 0xf0, 0x6d, 0x03, 0xc4, 0x87, 0x1b, 0x22, 0x79, 0x5e,
+	0x3d, 0x7f, 0x01, 0xd3, 0x9c, 0xe0, 0xdb, 0x9e, 0x37, 0x9b, 0xea, 0x8b, 0x6f, 0x96, 0xea, 0xcf,
+	0x49, 0xd4, 0x4f, 0xe0, 0xba, 0xeb, 0x0f, 0xbc, 0x91, 0xc3, 0x4c, 0xff, 0xb8, 0x7f, 0xea, 0x46,
+	0x82, 0x9f, 0x84, 0xf6, 0x50, 0xa7, 0xe4, 0x1f, 0x68, 0x04, 0xdd, 0x32, 0xde, 0x33, 0xcb, 0x53,
+	0xf9, 0xf4, 0xcf, 0x52, 0x70, 0x25, 0x71, 0x35, 0x3a, 0x9b, 0x3e, 0x84, 0xbc, 0xec, 0x0a, 0x45,
+	0x4b, 0x9b, 0x6b, 0x92, 0x40, 0x1a, 0x16, 0xb6, 0xd2, 0x15, 0xf2, 0xdb, 0x66, 0xd2, 0xa9, 0xf4,
+	0xf6, 0xef, 0x59, 0x80, 0x09, 0x73, 0x72, 0x7f, 0x2a, 0xd4, 0x7d, 0x70, 0x8e, 0x1c, 0x89, 0x10,
+	0xf7, 0x6f, 0x19, 0x15, 0xe2, 0xd6, 0x21, 0x27, 0x25, 0x33, 0x8f, 0x0e, 0x39, 0xb9, 0xd8, 0x70,
+	0xa6, 0x5e, 0xdb, 0xf9, 0xd9, 0xd7, 0xf6, 0x5b, 0xc4, 0x97, 0x64, 0xa8, 0x2d, 0x5c, 0x3e, 0xd4,
+	0x46, 0x50, 0x37, 0x6a, 0x91, 0x91, 0x29, 0xd1, 0x5b, 0xad, 0x17, 0xa5, 0x3e, 0x9e, 0x5c, 0xa0,
+	0x8f, 0xb8, 0x75, 0x12, 0xb5, 0xc6, 0x4f, 0xe3, 0xfe, 0xab, 0x8a, 0x51, 0xef, 0x85, 0x8b, 0xd6,
+	0xc8, 0xd7, 0x70, 0x65, 0x91, 0x41, 0xe1, 0x6e, 0x1f, 0x9f, 0xb7, 0x9b, 0xb6, 0xb2, 0xd6, 0x68,
+	0x70, 0xc6, 0x04, 0xad, 0x79, 0x33, 0x46, 0xd7, 0xd8, 0x83, 0xc6, 0x72, 0x61, 0x92, 0x21, 0xa7,
+	0xba, 0xa0, 0xe5, 0x95, 0x4d, 0xb6, 0xbc, 0xbe, 0x84, 0xea, 0xd4, 0x66, 0xe4, 0x3d, 0xf9, 0x87,
+	0x60, 0x7f, 0x68, 0xd2, 0x79, 0x6e, 0x68, 0x7f, 0xfb, 0x4c, 0x16, 0xa2, 0xc9, 0x62, 0x47, 0x4d,
+	0xac, 0x7f, 0x4a, 0x41, 0x59, 0x35, 0x0b, 0x54, 0x10, 0x0d, 0xce, 0x51, 0xb2, 0x32, 0xba, 0xcf,
+	0x16, 0x04, 0xd5, 0x98, 0xfe, 0xcd, 0x35, 0xfc, 0xee, 0x34, 0x61, 0xfd, 0x67, 0x0a, 0x6a, 0x09,
+	0x59, 0x94, 0xc7, 0x3c, 0x9e, 0xf2, 0x98, 0x3b, 0xe7, 0x09, 0x3f, 0xeb, 0x37, 0x7f, 0x91, 0xfa,
+	0xff, 0x2d, 0x0d, 0xb6, 0x8c, 0xeb, 0xa8, 0x90, 0xfc, 0xc3, 0xf3, 0x64, 0xd3, 0xbe, 0x83, 0x01,
+	0xea, 0x6a, 0x12, 0x6c, 0x42, 0xd4, 0xfd, 0x44, 0xc1, 0xff, 0xe1, 0x85, 0x87, 0xfc, 0x6e, 0xa5,
+	0xfe, 0x54, 0x80, 0xa2, 0x50, 0x93, 0x66, 0xdf, 0x3d, 0x38, 0x7a, 0x57, 0xb9, 0xcc, 0xfa, 0xe3,
+	0x14, 0x5c, 0x49, 0x30, 0xd5, 0x47, 0xdc, 0x4c, 0x1c, 0xf1, 0xc6, 0x62, 0xdf, 0xeb, 0x1e, 0x1c,
+	0xbd, 0xeb, 0xf3, 0xfd, 0x4f, 0x1a, 0xaa, 0x53, 0xbc, 0xc9, 0xa3, 0x29, 0x8b, 0xb2, 0xce, 0x97,
+	0x24, 0x61, 0x4e, 0x7f, 0x9b, 0xfe, 0x4e, 0x61, 0xf8, 0x01, 0x5c, 0x33, 0x2f, 0x9b, 0xd0, 0x16,
+	0xac, 0xcf, 0x8f, 0x7f, 0x89, 0x8a, 0x7b, 0xa5, 0x32, 0x7a, 0x8a, 0xae, 0xeb, 0x55, 0x6a, 0x0b,
+	0x76, 0x64, 0xd6, 0xc8, 0x26, 0xac, 0x27, 0x5e, 0x1e, 0x13, 0x1a, 0x55, 0x57, 0x92, 0xf8, 0xfd,
+	0x31, 0xa1, 0x78, 0x8b, 0x80, 0xfe, 0x00, 0xae, 0xa9, 0xbf, 0xad, 0x8e, 0x47, 0xce, 0x09, 0x13,
+	0xfd, 0x90, 0x0d, 0x6d, 0x17, 0xeb, 0x55, 0x99, 0x2e, 0x52, 0x74, 0x5d, 0xa9, 0x55, 0x2e, 0x52,
+	0xb3, 0xa6, 0xba, 0x42, 0xc3, 0xc0, 0x73, 0x6d, 0x5f, 0xc8, 0x3c, 0x50, 0xa4, 0x13, 0x80, 0xf5,
+	0x97, 0x29, 0xa8, 0x2b, 0x4d, 0xe2, 0x16, 0x32, 0x70, 0xbe, 0xbb, 0x0e, 0xc6, 0x8f, 0x00, 0x9f,
+	0x9d, 0xa1, 0x50, 0xb5, 0x44, 0x5a, 0xd6, 0x12, 0x25, 0x09, 0x91, 0xd5, 0x44, 0xb2, 0xd0, 0xc8,
+	0x4c, 0x15, 0x1a, 0xd6, 0xaf, 0x52, 0x70, 0x7d, 0x81, 0x58, 0xf1, 0x57, 0x68, 0x13, 0x13, 0x5d,
+	0x66, 0x18, 0x09, 0xba, 0x77, 0x68, 0xa6, 0xff, 0x18, 0xbb, 0x4c, 0x82, 0x3f, 0xd9, 0x87, 0x52,
+	0xe4, 0xdb, 0x41, 0x74, 0xca, 0xc5, 0xf2, 0x2f, 0x0a, 0xe6, 0xc8, 0x9a, 0x5d, 0x4d, 0x43, 0x27,
+	0xd4, 0x8d, 0x5f, 0x40, 0xd1, 0x80, 0xf1, 0xe6, 0x50, 0x37, 0x91, 0xb0, 0x87, 0xea, 0x05, 0x97,
+	0xa1, 0x13, 0x00, 0xfe, 0x07, 0xa0, 0xab, 0xa5, 0xf4, 0x85, 0xd5, 0x92, 0xa9, 0x95, 0xb6, 0xfe,
+	0xb5, 0x00, 0x99, 0xed, 0xc0, 0x25, 0xdf, 0x40, 0x39, 0xd1, 0x9d, 0x20, 0xb7, 0xce, 0xef, 0x5d,
+	0x48, 0x6b, 0x68, 0xdc, 0xbe, 0x4c, 0x83, 0xc3, 0x5a, 0x21, 0x3d, 0x28, 0xc5, 0xb5, 0x1d, 0x99,
+	0x0f, 0x92, 0xb3, 0x25, 0x79, 0xc3, 0x3a, 0x0f, 0x25, 0xe6, 0xfa, 0xcd, 0x74, 0x02, 0x7d, 0x6b,
+	0x89, 0xe7, 0x62, 0xba, 0x92, 0x38, 0x8e, 0x83, 0x0b, 0x24, 0x9e, 0x0d, 0xbc, 0x0d, 0xeb, 0x3c,
+	0x94, 0x98, 0xab, 0xb7, 0xc8, 0x54, 0x3e, 0xbe, 0xd8, 0x2e, 0xcc, 0x2e, 0xf7, 0x2e, 0x83, 0x1a,
+	0xef, 0xf6, 0x15, 0x14, 0xcd, 0x57, 0x8f, 0xe4, 0xe6, 0x1c, 0xe5, 0xcc, 0x17, 0x94, 0x8d, 0x0f,
+	0xcf, 0xc1, 0x88, 0x59, 0xfe, 0x02, 0x2a, 0xc9, 0x8f, 0x40, 0xc9, 0xed, 0x85, 0x44, 0x33, 0x1f,
+	0x96, 0x36, 0xee, 0x5c, 0x80, 0x15, 0xb3, 0xdf, 0x85, 0x4c, 0xcf, 0x0e, 0xc8, 0xfb, 0x8b, 0xfe,
+	0x63, 0x31, 0xcc, 0xae, 0x2f, 0xfd, 0x03, 0xc6, 0xca, 0xfc, 0x51, 0x3a, 0xb5, 0x99, 0x22, 0xcf,
+	0xa1, 0x3a, 0xf5, 0xad, 0x0e, 0xb9, 0x73, 0xa9, 0x6f, 0x79, 0xce, 0xe3, 0xbc, 0xb2, 0x99, 0x22,
+	0xdb, 0x50, 0x30, 0x9f, 0xe1, 0x2e, 0x79, 0x6e, 0x35, 0xe6, 0x0b, 0x89, 0xc4, 0xa7, 0xbd, 0xf2,
+	0xfe, 0x4b, 0x5d, 0xe6, 0xbd, 0xd8, 0xc1, 0xef, 0x80, 0xc9, 0x6f, 0x4d, 0x90, 0xd5, 0x57, 0xc2,
+	0xcd, 0xe4, 0x57, 0xc2, 0x31, 0x9e, 0x91, 0xae, 0x79, 0x59, 0x74, 0xa3, 0xcd, 0xd6, 0xfd, 0x6f,
+	0x3e, 0x3d, 0x71, 0xc5, 0xe9, 0xe8, 0x18, 0x09, 0x36, 0x34, 0xb5, 0xf9, 0xdd, 0xda, 0x98, 0x7c,
+	0x3b, 0xb9, 0x71, 0xc2, 0xfc, 0x0d, 0x25, 0xf0, 0x71, 0x5e, 0xfe, 0x89, 0x74, 0xff, 0xff, 0x06,
+	0x00, 0x94, 0x2e, 0xfb, 0xf5, 0xf9, 0x2c, 0x00, 0x00,
 }
@@ -14,13 +14,18 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	appsv1beta2 "k8s.io/api/apps/v1beta2"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	apiv1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/informers"
 	arinformers "k8s.io/client-go/informers/admissionregistration/v1beta1"
 	appinformers "k8s.io/client-go/informers/apps/v1beta2"
+	batchinformers "k8s.io/client-go/informers/batch/v1"
+	batchv1beta1informers "k8s.io/client-go/informers/batch/v1beta1"
 	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
@@ -30,8 +35,11 @@ type ApiResource int
 
 const (
 	CM ApiResource = iota
+	CronJob
 	Deploy
 	Endpoint
+	Job
+	MC  // mesh config
 	MWC // mutating webhook configuration
 	Pod
 	RC
@@ -42,11 +50,15 @@ const (
 
 // API provides shared informers for all Kubernetes objects
 type API struct {
-	Client kubernetes.Interface
+	Client   kubernetes.Interface
+	SpClient spclient.Interface
 
 	cm       coreinformers.ConfigMapInformer
+	cronJob  batchv1beta1informers.CronJobInformer
 	deploy   appinformers.DeploymentInformer
 	endpoint coreinformers.EndpointsInformer
+	job      batchinformers.JobInformer
+	mc       spinformers.MeshConfigInformer
 	mwc      arinformers.MutatingWebhookConfigurationInformer
 	pod      coreinformers.PodInformer
 	rc       coreinformers.ReplicationControllerInformer
@@ -84,6 +96,7 @@ func NewAPI(k8sClient kubernetes.Interface, spClient spclient.Interface, namespa
 
 	api := &API{
 		Client:            k8sClient,
+		SpClient:          spClient,
 		syncChecks:        make([]cache.InformerSynced, 0),
 		sharedInformers:   sharedInformers,
 		spSharedInformers: spSharedInformers,
@@ -95,12 +108,21 @@ func NewAPI(k8sClient kubernetes.Interface, spClient spclient.Interface, namespa
 		case CM:
 			api.cm = sharedInformers.Core().V1().ConfigMaps()
 			api.syncChecks = append(api.syncChecks, api.cm.Informer().HasSynced)
+		case CronJob:
+			api.cronJob = sharedInformers.Batch().V1beta1().CronJobs()
+			api.syncChecks = append(api.syncChecks, api.cronJob.Informer().HasSynced)
 		case Deploy:
 			api.deploy = sharedInformers.Apps().V1beta2().Deployments()
 			api.syncChecks = append(api.syncChecks, api.deploy.Informer().HasSynced)
 		case Endpoint:
 			api.endpoint = sharedInformers.Core().V1().Endpoints()
 			api.syncChecks = append(api.syncChecks, api.endpoint.Informer().HasSynced)
+		case Job:
+			api.job = sharedInformers.Batch().V1().Jobs()
+			api.syncChecks = append(api.syncChecks, api.job.Informer().HasSynced)
+		case MC:
+			api.mc = spSharedInformers.Linkerd().V1alpha1().MeshConfigs()
+			api.syncChecks = append(api.syncChecks, api.mc.Informer().HasSynced)
 		case MWC:
 			api.mwc = sharedInformers.Admissionregistration().V1beta1().MutatingWebhookConfigurations()
 			api.syncChecks = append(api.syncChecks, api.mwc.Informer().HasSynced)
@@ -202,6 +224,27 @@ func (api *API) SP() spinformers.ServiceProfileInformer {
 	return api.sp
 }
 
+func (api *API) MC() spinformers.MeshConfigInformer {
+	if api.mc == nil {
+		panic("MC informer not configured")
+	}
+	return api.mc
+}
+
+func (api *API) Job() batchinformers.JobInformer {
+	if api.job == nil {
+		panic("Job informer not configured")
+	}
+	return api.job
+}
+
+func (api *API) CronJob() batchv1beta1informers.CronJobInformer {
+	if api.cronJob == nil {
+		panic("CronJob informer not configured")
+	}
+	return api.cronJob
+}
+
 func (api *API) MWC() arinformers.MutatingWebhookConfigurationInformer {
 	if api.mwc == nil {
 		panic("MWC informer not configured")
@@ -213,17 +256,27 @@ func (api *API) MWC() arinformers.MutatingWebhookConfigurationInformer {
 // If namespace is an empty string, match objects in all namespaces.
 // If name is an empty string, match all objects of the given type.
 func (api *API) GetObjects(namespace, restype, name string) ([]runtime.Object, error) {
+	return api.GetObjectsWithSelector(namespace, restype, name, labels.Everything())
+}
+
+// GetObjectsWithSelector returns the objects of GetObjects whose labels match
+// the given selector.
+func (api *API) GetObjectsWithSelector(namespace, restype, name string, selector labels.Selector) ([]runtime.Object, error) {
 	switch restype {
 	case k8s.Namespace:
-		return api.getNamespaces(name)
+		return api.getNamespaces(name, selector)
+	case k8s.CronJob:
+		return api.getCronJobs(namespace, name, selector)
 	case k8s.Deployment:
-		return api.getDeployments(namespace, name)
+		return api.getDeployments(namespace, name, selector)
+	case k8s.Job:
+		return api.getJobs(namespace, name, selector)
 	case k8s.Pod:
-		return api.getPods(namespace, name)
+		return api.getPods(namespace, name, selector)
 	case k8s.ReplicationController:
-		return api.getRCs(namespace, name)
+		return api.getRCs(namespace, name, selector)
 	case k8s.Service:
-		return api.getServices(namespace, name)
+		return api.getServices(namespace, name, selector)
 	default:
 		// TODO: ReplicaSet
 		return nil, status.Errorf(codes.Unimplemented, "unimplemented resource type: %s", restype)
@@ -276,6 +329,19 @@ func (api *API) GetPodsFor(obj runtime.Object, includeFailed bool) ([]*apiv1.Pod
 		namespace = typed.Namespace
 		selector = labels.Set(typed.Spec.Selector).AsSelector()
 
+	case *batchv1.Job:
+		namespace = typed.Namespace
+		selector = labels.Set(typed.Spec.Selector.MatchLabels).AsSelector()
+
+	case *batchv1beta1.CronJob:
+		// CronJobs don't have a selector of their own: their pods are the pods
+		// of the Jobs they own
+		namespace = typed.Namespace
+		pods, err = api.getPodsForCronJob(typed)
+		if err != nil {
+			return nil, err
+		}
+
 	case *apiv1.Service:
 		namespace = typed.Namespace
 		selector = labels.Set(typed.Spec.Selector).AsSelector()
@@ -294,9 +360,10 @@ func (api *API) GetPodsFor(obj runtime.Object, includeFailed bool) ([]*apiv1.Pod
 		return nil, fmt.Errorf("Cannot get object selector: %v", obj)
 	}
 
-	// if obj.(type) is Pod, we've already retrieved it and put it in pods
-	// for the other types, pods will still be empty
-	if len(pods) == 0 {
+	// if obj.(type) is Pod or CronJob, we've already retrieved its pods (or
+	// there are none, in which case selector is nil); for the other types, pods
+	// will still be empty
+	if len(pods) == 0 && selector != nil {
 		pods, err = api.Pod().Lister().Pods(namespace).List(selector)
 		if err != nil {
 			return nil, err
@@ -317,7 +384,7 @@ func (api *API) GetPodsFor(obj runtime.Object, includeFailed bool) ([]*apiv1.Pod
 // is given, it returns all namespaces, unless the API was configured to only
 // work with a single namespace, in which case it returns that namespace. Note
 // that namespace reads are not cached.
-func (api *API) getNamespaces(name string) ([]runtime.Object, error) {
+func (api *API) getNamespaces(name string, selector labels.Selector) ([]runtime.Object, error) {
 	namespaces := make([]*apiv1.Namespace, 0)
 
 	if name == "" && api.namespace != "" {
@@ -325,7 +392,7 @@ func (api *API) getNamespaces(name string) ([]runtime.Object, error) {
 	}
 
 	if name == "" {
-		namespaceList, err := api.Client.CoreV1().Namespaces().List(metav1.ListOptions{})
+		namespaceList, err := api.Client.CoreV1().Namespaces().List(metav1.ListOptions{LabelSelector: selector.String()})
 		if err != nil {
 			return nil, err
 		}
@@ -343,20 +410,23 @@ func (api *API) getNamespaces(name string) ([]runtime.Object, error) {
 
 	objects := []runtime.Object{}
 	for _, ns := range namespaces {
+		if !selector.Matches(labels.Set(ns.Labels)) {
+			continue
+		}
 		objects = append(objects, ns)
 	}
 
 	return objects, nil
 }
 
-func (api *API) getDeployments(namespace, name string) ([]runtime.Object, error) {
+func (api *API) getDeployments(namespace, name string, selector labels.Selector) ([]runtime.Object, error) {
 	var err error
 	var deploys []*appsv1beta2.Deployment
 
 	if namespace == "" {
-		deploys, err = api.Deploy().Lister().List(labels.Everything())
+		deploys, err = api.Deploy().Lister().List(selector)
 	} else if name == "" {
-		deploys, err = api.Deploy().Lister().Deployments(namespace).List(labels.Everything())
+		deploys, err = api.Deploy().Lister().Deployments(namespace).List(selector)
 	} else {
 		var deploy *appsv1beta2.Deployment
 		deploy, err = api.Deploy().Lister().Deployments(namespace).Get(name)
@@ -369,20 +439,113 @@ func (api *API) getDeployments(namespace, name string) ([]runtime.Object, error)
 
 	objects := []runtime.Object{}
 	for _, deploy := range deploys {
+		if !selector.Matches(labels.Set(deploy.Labels)) {
+			continue
+		}
 		objects = append(objects, deploy)
 	}
 
 	return objects, nil
 }
 
-func (api *API) getPods(namespace, name string) ([]runtime.Object, error) {
+func (api *API) getJobs(namespace, name string, selector labels.Selector) ([]runtime.Object, error) {
+	var err error
+	var jobs []*batchv1.Job
+
+	if namespace == "" {
+		jobs, err = api.Job().Lister().List(selector)
+	} else if name == "" {
+		jobs, err = api.Job().Lister().Jobs(namespace).List(selector)
+	} else {
+		var job *batchv1.Job
+		job, err = api.Job().Lister().Jobs(namespace).Get(name)
+		jobs = []*batchv1.Job{job}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []runtime.Object{}
+	for _, job := range jobs {
+		if !selector.Matches(labels.Set(job.Labels)) {
+			continue
+		}
+		objects = append(objects, job)
+	}
+
+	return objects, nil
+}
+
+func (api *API) getCronJobs(namespace, name string, selector labels.Selector) ([]runtime.Object, error) {
+	var err error
+	var cronJobs []*batchv1beta1.CronJob
+
+	if namespace == "" {
+		cronJobs, err = api.CronJob().Lister().List(selector)
+	} else if name == "" {
+		cronJobs, err = api.CronJob().Lister().CronJobs(namespace).List(selector)
+	} else {
+		var cronJob *batchv1beta1.CronJob
+		cronJob, err = api.CronJob().Lister().CronJobs(namespace).Get(name)
+		cronJobs = []*batchv1beta1.CronJob{cronJob}
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	objects := []runtime.Object{}
+	for _, cronJob := range cronJobs {
+		if !selector.Matches(labels.Set(cronJob.Labels)) {
+			continue
+		}
+		objects = append(objects, cronJob)
+	}
+
+	return objects, nil
+}
+
+// getPodsForCronJob returns the pods of all the Jobs owned by the given
+// CronJob.
+func (api *API) getPodsForCronJob(cronJob *batchv1beta1.CronJob) ([]*apiv1.Pod, error) {
+	jobs, err := api.Job().Lister().Jobs(cronJob.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	pods := []*apiv1.Pod{}
+	for _, job := range jobs {
+		if !isOwnedBy(job.GetOwnerReferences(), cronJob.UID) {
+			continue
+		}
+		jobPods, err := api.Pod().Lister().Pods(job.Namespace).List(labels.Set(job.Spec.Selector.MatchLabels).AsSelector())
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, jobPods...)
+	}
+
+	return pods, nil
+}
+
+func isOwnedBy(refs []metav1.OwnerReference, uid types.UID) bool {
+	for _, ref := range refs {
+		if ref.UID == uid {
+			return true
+		}
+	}
+	return false
+}
+
+func (api *API) getPods(namespace, name string, selector labels.Selector) ([]runtime.Object, error) {
 	var err error
 	var pods []*apiv1.Pod
 
 	if namespace == "" {
-		pods, err = api.Pod().Lister().List(labels.Everything())
+		pods, err = api.Pod().Lister().List(selector)
 	} else if name == "" {
-		pods, err = api.Pod().Lister().Pods(namespace).List(labels.Everything())
+		pods, err = api.Pod().Lister().Pods(namespace).List(selector)
 	} else {
 		var pod *apiv1.Pod
 		pod, err = api.Pod().Lister().Pods(namespace).Get(name)
@@ -395,7 +558,7 @@ func (api *API) getPods(namespace, name string) ([]runtime.Object, error) {
 
 	objects := []runtime.Object{}
 	for _, pod := range pods {
-		if !isPendingOrRunning(pod) {
+		if !isPendingOrRunning(pod) || !selector.Matches(labels.Set(pod.Labels)) {
 			continue
 		}
 		objects = append(objects, pod)
@@ -404,14 +567,14 @@ func (api *API) getPods(namespace, name string) ([]runtime.Object, error) {
 	return objects, nil
 }
 
-func (api *API) getRCs(namespace, name string) ([]runtime.Object, error) {
+func (api *API) getRCs(namespace, name string, selector labels.Selector) ([]runtime.Object, error) {
 	var err error
 	var rcs []*apiv1.ReplicationController
 
 	if namespace == "" {
-		rcs, err = api.RC().Lister().List(labels.Everything())
+		rcs, err = api.RC().Lister().List(selector)
 	} else if name == "" {
-		rcs, err = api.RC().Lister().ReplicationControllers(namespace).List(labels.Everything())
+		rcs, err = api.RC().Lister().ReplicationControllers(namespace).List(selector)
 	} else {
 		var rc *apiv1.ReplicationController
 		rc, err = api.RC().Lister().ReplicationControllers(namespace).Get(name)
@@ -424,13 +587,16 @@ func (api *API) getRCs(namespace, name string) ([]runtime.Object, error) {
 
 	objects := []runtime.Object{}
 	for _, rc := range rcs {
+		if !selector.Matches(labels.Set(rc.Labels)) {
+			continue
+		}
 		objects = append(objects, rc)
 	}
 
 	return objects, nil
 }
 
-func (api *API) getServices(namespace, name string) ([]runtime.Object, error) {
+func (api *API) getServices(namespace, name string, selector labels.Selector) ([]runtime.Object, error) {
 	services, err := api.GetServices(namespace, name)
 
 	if err != nil {
@@ -439,6 +605,9 @@ func (api *API) getServices(namespace, name string) ([]runtime.Object, error) {
 
 	objects := []runtime.Object{}
 	for _, svc := range services {
+		if !selector.Matches(labels.Set(svc.Labels)) {
+			continue
+		}
 		objects = append(objects, svc)
 	}
 
@@ -425,6 +425,63 @@ metadata:
   namespace: emojivoto
   labels:
     app: emoji-svc
+status:
+  phase: Running`,
+				},
+			},
+			getPodsForExpected{
+				err: nil,
+				k8sResInput: `
+apiVersion: batch/v1beta1
+kind: CronJob
+metadata:
+  name: backup
+  namespace: emojivoto
+  uid: backup-uid`,
+				k8sResResults: []string{`
+apiVersion: v1
+kind: Pod
+metadata:
+  name: backup-1545000000-x8vzj
+  namespace: emojivoto
+  labels:
+    controller-uid: backup-1545000000-uid
+status:
+  phase: Running`,
+				},
+				k8sResMisc: []string{`
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: backup-1545000000
+  namespace: emojivoto
+  uid: backup-1545000000-uid
+  ownerReferences:
+  - apiVersion: batch/v1beta1
+    kind: CronJob
+    name: backup
+    uid: backup-uid
+spec:
+  selector:
+    matchLabels:
+      controller-uid: backup-1545000000-uid`, `
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: restore
+  namespace: emojivoto
+  uid: restore-uid
+spec:
+  selector:
+    matchLabels:
+      controller-uid: restore-uid`, `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: restore-jl2vx
+  namespace: emojivoto
+  labels:
+    controller-uid: restore-uid
 status:
   phase: Running`,
 				},
@@ -26,7 +26,8 @@ func NewFakeAPI(namespace string, configs ...string) (*API, error) {
 		if err != nil {
 			return nil, err
 		}
-		if strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind) == k8s.ServiceProfile {
+		kind := obj.GetObjectKind().GroupVersionKind().Kind
+		if strings.ToLower(kind) == k8s.ServiceProfile || kind == "MeshConfig" {
 			spObjs = append(spObjs, obj)
 		} else {
 			objs = append(objs, obj)
@@ -40,8 +41,11 @@ func NewFakeAPI(namespace string, configs ...string) (*API, error) {
 		spClientSet,
 		namespace,
 		CM,
+		CronJob,
 		Deploy,
 		Endpoint,
+		Job,
+		MC,
 		Pod,
 		RC,
 		RS,
@@ -0,0 +1,351 @@
+package operator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/util"
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	"github.com/linkerd/linkerd2/pkg/profiles"
+	"github.com/linkerd/linkerd2/pkg/version"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// createdBy prefixes the created-by annotation of the generated profiles. The
+// profiles without it are never changed by the operator, so removing the
+// annotation of a generated profile hands it over to its owners.
+const createdBy = "linkerd/profile-operator"
+
+// Config configures when and how the profiles are generated.
+type Config struct {
+	// TimeWindow is the window of the route metrics telling which services
+	// have requests falling into their default route.
+	TimeWindow string
+	// MinRequests is the count of requests falling into the default route of
+	// a service during TimeWindow from which its pods are tapped.
+	MinRequests uint64
+	// TapDuration is how long the pods of a service are tapped.
+	TapDuration time.Duration
+	// TapMaxRps is the maximum rate of requests tapped from each workload.
+	TapMaxRps float32
+	// ParamThreshold and RouteLimit collapse the tapped paths into routes,
+	// as with the --tap-param-threshold and --tap-route-limit flags of
+	// linkerd profile.
+	ParamThreshold uint
+	RouteLimit     uint
+}
+
+// Operator generates the service profiles of the services lacking one, from
+// their traffic: the route metrics tell the services with requests falling
+// into their default route, whose pods are then tapped to infer their routes.
+// The routes observed since are added to the generated profiles.
+type Operator struct {
+	apiClient           pb.ApiClient
+	k8sAPI              *k8s.API
+	controllerNamespace string
+	config              Config
+}
+
+// NewOperator returns an operator writing the generated profiles to the
+// controller namespace. The k8sAPI must have the Pod, RS, Svc and SP
+// informers.
+func NewOperator(apiClient pb.ApiClient, k8sAPI *k8s.API, controllerNamespace string, config Config) *Operator {
+	return &Operator{
+		apiClient:           apiClient,
+		k8sAPI:              k8sAPI,
+		controllerNamespace: controllerNamespace,
+		config:              config,
+	}
+}
+
+// Run generates the profiles every interval until stop is closed.
+func (o *Operator) Run(interval time.Duration, stop <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			o.reconcile(context.Background())
+		}
+	}
+}
+
+// reconcile generates or updates the profiles of the services with requests
+// falling into their default route.
+func (o *Operator) reconcile(ctx context.Context) {
+	services, err := o.defaultRouteServices(ctx)
+	if err != nil {
+		log.Errorf("failed to get the route metrics of the services: %s", err)
+		return
+	}
+
+	for _, svc := range services {
+		if err := o.updateProfile(ctx, svc); err != nil {
+			log.Errorf("failed to generate the ServiceProfile of service %s/%s: %s", svc.Namespace, svc.Name, err)
+		}
+	}
+}
+
+// defaultRouteServices returns the services of the other namespaces than the
+// controller's with at least MinRequests requests falling into their default
+// route during the time window, sorted by namespace and name.
+func (o *Operator) defaultRouteServices(ctx context.Context) ([]*corev1.Service, error) {
+	req, err := util.BuildTopRoutesRequest(util.TopRoutesRequestParams{
+		StatsBaseRequestParams: util.StatsBaseRequestParams{
+			TimeWindow:    o.config.TimeWindow,
+			AllNamespaces: true,
+			ResourceType:  pkgK8s.Service,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := o.apiClient.TopRoutes(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if e := rsp.GetError(); e != nil {
+		return nil, errors.New(e.Error)
+	}
+
+	// the requests falling into the default route, by service host
+	requests := make(map[string]uint64)
+	for _, row := range rsp.GetRoutes().GetRows() {
+		if row.GetRoute() != "" {
+			continue
+		}
+		host := strings.Split(row.GetAuthority(), ":")[0]
+		requests[host] += row.GetStats().GetSuccessCount() + row.GetStats().GetFailureCount()
+	}
+
+	hosts := make([]string, 0)
+	for host, count := range requests {
+		if count > 0 && count >= o.config.MinRequests {
+			hosts = append(hosts, host)
+		}
+	}
+	sort.Strings(hosts)
+
+	services := make([]*corev1.Service, 0)
+	for _, host := range hosts {
+		// the hosts are the fully qualified names of the services, e.g.
+		// web-svc.emojivoto.svc.cluster.local
+		parts := strings.Split(host, ".")
+		if len(parts) < 3 || parts[2] != "svc" || parts[1] == o.controllerNamespace {
+			continue
+		}
+		svc, err := o.k8sAPI.Svc().Lister().Services(parts[1]).Get(parts[0])
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return nil, err
+			}
+			continue
+		}
+		services = append(services, svc)
+	}
+	return services, nil
+}
+
+// updateProfile generates the profile of the service, or adds the routes
+// observed since to its generated profile. The profiles not generated by the
+// operator are left unchanged.
+func (o *Operator) updateProfile(ctx context.Context, svc *corev1.Service) error {
+	name := fmt.Sprintf("%s.%s.svc.cluster.local", svc.Name, svc.Namespace)
+	existing, err := o.k8sAPI.SP().Lister().ServiceProfiles(o.controllerNamespace).Get(name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil && !isGenerated(existing) {
+		return nil
+	}
+
+	tapped, err := o.tapService(ctx, svc)
+	if err != nil {
+		return err
+	}
+	routes := profiles.TapRouteSpecs(tapped, o.config.ParamThreshold, o.config.RouteLimit)
+
+	client := o.k8sAPI.SpClient.LinkerdV1alpha1().ServiceProfiles(o.controllerNamespace)
+	if existing == nil {
+		if len(routes) == 0 {
+			return nil
+		}
+		profile := &sp.ServiceProfile{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   o.controllerNamespace,
+				Annotations: map[string]string{pkgK8s.CreatedByAnnotation: createdByValue()},
+			},
+			Spec: sp.ServiceProfileSpec{Routes: routes},
+		}
+		log.Infof("creating ServiceProfile %s with %d routes", name, len(routes))
+		_, err = client.Create(profile)
+		return err
+	}
+
+	merged := mergeRoutes(existing.Spec.Routes, routes, o.config.RouteLimit)
+	if len(merged) == len(existing.Spec.Routes) {
+		return nil
+	}
+	profile := existing.DeepCopy()
+	profile.Annotations[pkgK8s.CreatedByAnnotation] = createdByValue()
+	profile.Spec.Routes = merged
+	log.Infof("adding %d routes to ServiceProfile %s", len(merged)-len(existing.Spec.Routes), name)
+	_, err = client.Update(profile)
+	return err
+}
+
+func isGenerated(profile *sp.ServiceProfile) bool {
+	return strings.HasPrefix(profile.Annotations[pkgK8s.CreatedByAnnotation], createdBy)
+}
+
+func createdByValue() string {
+	return fmt.Sprintf("%s %s", createdBy, version.Version)
+}
+
+// mergeRoutes returns the routes along with the added routes of other names,
+// up to routeLimit routes, sorted by name. The routes are never removed, so
+// that the routes of the rarely requested paths are kept.
+func mergeRoutes(routes, added []*sp.RouteSpec, routeLimit uint) []*sp.RouteSpec {
+	merged := append([]*sp.RouteSpec{}, routes...)
+	names := make(map[string]bool)
+	for _, route := range routes {
+		names[route.Name] = true
+	}
+	for _, route := range added {
+		if routeLimit > 0 && uint(len(merged)) >= routeLimit {
+			break
+		}
+		if !names[route.Name] {
+			merged = append(merged, route)
+		}
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Name < merged[j].Name })
+	return merged
+}
+
+// tapService taps the inbound requests to the service of its pods' workloads
+// for the tap duration, and returns their routes.
+func (o *Operator) tapService(ctx context.Context, svc *corev1.Service) ([]*profiles.TapRoute, error) {
+	// the endpoints of the services without a selector aren't pods
+	if len(svc.Spec.Selector) == 0 {
+		return nil, nil
+	}
+	pods, err := o.k8sAPI.GetPodsFor(svc, false)
+	if err != nil {
+		return nil, err
+	}
+	targets := make(map[string]bool)
+	for _, pod := range pods {
+		kind, name := o.k8sAPI.GetOwnerKindAndName(pod)
+		if !isTapTarget(kind) {
+			kind, name = pkgK8s.Pod, pod.Name
+		}
+		targets[fmt.Sprintf("%s/%s", kind, name)] = true
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, o.config.TapDuration)
+	defer cancel()
+
+	hosts := serviceHosts(svc)
+	routes := make([]*profiles.TapRoute, 0)
+	var tapErr error
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	for target := range targets {
+		wg.Add(1)
+		go func(target string) {
+			defer wg.Done()
+			tapped, err := o.tapTarget(ctx, svc.Namespace, target, hosts)
+
+			lock.Lock()
+			defer lock.Unlock()
+			routes = append(routes, tapped...)
+			if err != nil && ctx.Err() == nil && tapErr == nil {
+				tapErr = fmt.Errorf("failed to tap %s: %s", target, err)
+			}
+		}(target)
+	}
+	wg.Wait()
+
+	return routes, tapErr
+}
+
+func (o *Operator) tapTarget(ctx context.Context, namespace, target string, hosts map[string]bool) ([]*profiles.TapRoute, error) {
+	req, err := util.BuildTapByResourceRequest(util.TapRequestParams{
+		Resource:  target,
+		Namespace: namespace,
+		MaxRps:    o.config.TapMaxRps,
+	})
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := o.apiClient.TapByResource(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return profiles.CollectTapRoutes(&serviceTapClient{Api_TapByResourceClient: rsp, hosts: hosts})
+}
+
+func isTapTarget(kind string) bool {
+	for _, target := range util.ValidTargets {
+		if kind == target {
+			return true
+		}
+	}
+	return false
+}
+
+// serviceHosts returns the hosts the clients of the service may use in the
+// authority of their requests.
+func serviceHosts(svc *corev1.Service) map[string]bool {
+	hosts := map[string]bool{
+		svc.Name: true,
+		fmt.Sprintf("%s.%s", svc.Name, svc.Namespace):                   true,
+		fmt.Sprintf("%s.%s.svc", svc.Name, svc.Namespace):               true,
+		fmt.Sprintf("%s.%s.svc.cluster.local", svc.Name, svc.Namespace): true,
+	}
+	if svc.Spec.ClusterIP != "" && svc.Spec.ClusterIP != corev1.ClusterIPNone {
+		hosts[svc.Spec.ClusterIP] = true
+	}
+	return hosts
+}
+
+// serviceTapClient wraps a tap stream, dropping the events of the outbound
+// requests, and of the inbound requests to other authorities than the
+// service's, e.g. the probes of the kubelet or the requests to the other
+// services of the pods.
+type serviceTapClient struct {
+	pb.Api_TapByResourceClient
+	hosts map[string]bool
+}
+
+func (c *serviceTapClient) Recv() (*pb.TapEvent, error) {
+	for {
+		event, err := c.Api_TapByResourceClient.Recv()
+		if err != nil {
+			return event, err
+		}
+		if event.GetProxyDirection() != pb.TapEvent_INBOUND {
+			continue
+		}
+		if init := event.GetHttp().GetRequestInit(); init != nil && !c.hosts[strings.Split(init.GetAuthority(), ":")[0]] {
+			continue
+		}
+		return event, nil
+	}
+}
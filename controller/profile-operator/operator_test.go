@@ -0,0 +1,236 @@
+package operator
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linkerd/linkerd2/controller/api/public"
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/controller/k8s"
+	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const webResources = `
+apiVersion: v1
+kind: Service
+metadata:
+  name: web-svc
+  namespace: emojivoto
+spec:
+  clusterIP: 10.0.0.10
+  selector:
+    app: web
+---
+apiVersion: apps/v1beta2
+kind: ReplicaSet
+metadata:
+  name: web-5f9d7
+  namespace: emojivoto
+  ownerReferences:
+  - apiVersion: apps/v1beta2
+    kind: Deployment
+    name: web
+spec:
+  selector:
+    matchLabels:
+      app: web
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web-5f9d7-x8vzj
+  namespace: emojivoto
+  labels:
+    app: web
+  ownerReferences:
+  - apiVersion: apps/v1beta2
+    kind: ReplicaSet
+    name: web-5f9d7
+status:
+  phase: Running`
+
+func topRoutesResponse(authority string, requests uint64) *pb.TopRoutesResponse {
+	return &pb.TopRoutesResponse{
+		Response: &pb.TopRoutesResponse_Routes{
+			Routes: &pb.RouteTable{
+				Rows: []*pb.RouteTable_Row{
+					{
+						Authority: authority,
+						Stats:     &pb.BasicStats{SuccessCount: requests},
+					},
+				},
+			},
+		},
+	}
+}
+
+func tapEvent(direction pb.TapEvent_ProxyDirection, stream uint64, http *pb.TapEvent_Http) pb.TapEvent {
+	return pb.TapEvent{
+		ProxyDirection: direction,
+		Source:         &pb.TcpAddress{Ip: &pb.IPAddress{Ip: &pb.IPAddress_Ipv4{Ipv4: uint32(stream)}}},
+		Destination:    &pb.TcpAddress{Ip: &pb.IPAddress{Ip: &pb.IPAddress_Ipv4{Ipv4: 9}}},
+		Event:          &pb.TapEvent_Http_{Http: http},
+	}
+}
+
+// request returns the events of a request and its response.
+func request(direction pb.TapEvent_ProxyDirection, stream uint64, authority, path string, status uint32) []pb.TapEvent {
+	id := &pb.TapEvent_Http_StreamId{Base: 1, Stream: stream}
+	return []pb.TapEvent{
+		tapEvent(direction, stream, &pb.TapEvent_Http{Event: &pb.TapEvent_Http_RequestInit_{RequestInit: &pb.TapEvent_Http_RequestInit{
+			Id:        id,
+			Method:    &pb.HttpMethod{Type: &pb.HttpMethod_Registered_{Registered: pb.HttpMethod_GET}},
+			Authority: authority,
+			Path:      path,
+		}}}),
+		tapEvent(direction, stream, &pb.TapEvent_Http{Event: &pb.TapEvent_Http_ResponseInit_{ResponseInit: &pb.TapEvent_Http_ResponseInit{
+			Id:         id,
+			HttpStatus: status,
+		}}}),
+	}
+}
+
+func webTapEvents() []pb.TapEvent {
+	events := make([]pb.TapEvent, 0)
+	events = append(events, request(pb.TapEvent_INBOUND, 1, "web-svc.emojivoto.svc.cluster.local:80", "/books/1", 200)...)
+	events = append(events, request(pb.TapEvent_INBOUND, 2, "web-svc:80", "/books/2?page=3", 404)...)
+	// the probes of the kubelet and the outbound requests are left out
+	events = append(events, request(pb.TapEvent_INBOUND, 3, "10.1.0.4:8080", "/ready", 200)...)
+	events = append(events, request(pb.TapEvent_OUTBOUND, 4, "emoji-svc.emojivoto.svc.cluster.local:8080", "/emojis", 200)...)
+	return events
+}
+
+func newTestOperator(t *testing.T, apiClient pb.ApiClient, configs ...string) *Operator {
+	k8sConfigs := strings.Split(webResources, "---")
+	k8sConfigs = append(k8sConfigs, configs...)
+	k8sAPI, err := k8s.NewFakeAPI("", k8sConfigs...)
+	if err != nil {
+		t.Fatalf("NewFakeAPI returned an error: %s", err)
+	}
+	k8sAPI.Sync(nil)
+
+	return NewOperator(apiClient, k8sAPI, "linkerd", Config{
+		TimeWindow:     "10m",
+		MinRequests:    10,
+		TapDuration:    time.Second,
+		TapMaxRps:      100,
+		ParamThreshold: 10,
+		RouteLimit:     20,
+	})
+}
+
+func getProfile(t *testing.T, operator *Operator, name string) *sp.ServiceProfile {
+	profile, err := operator.k8sAPI.SpClient.LinkerdV1alpha1().ServiceProfiles("linkerd").Get(name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("Unexpected error getting ServiceProfile %s: %s", name, err)
+	}
+	return profile
+}
+
+func routeNames(profile *sp.ServiceProfile) []string {
+	names := make([]string, 0)
+	for _, route := range profile.Spec.Routes {
+		names = append(names, route.Name)
+	}
+	return names
+}
+
+func TestOperator(t *testing.T) {
+	authority := "web-svc.emojivoto.svc.cluster.local:80"
+	profileName := "web-svc.emojivoto.svc.cluster.local"
+
+	t.Run("Generates the profile of a service from its traffic", func(t *testing.T) {
+		apiClient := &public.MockApiClient{
+			TopRoutesResponseToReturn:       topRoutesResponse(authority, 100),
+			Api_TapByResourceClientToReturn: &public.MockApi_TapByResourceClient{TapEventsToReturn: webTapEvents()},
+		}
+		operator := newTestOperator(t, apiClient)
+		operator.reconcile(context.Background())
+
+		profile := getProfile(t, operator, profileName)
+		if !reflect.DeepEqual(routeNames(profile), []string{"GET /books/{id}"}) {
+			t.Fatalf("Expected the route [GET /books/{id}], got %v", routeNames(profile))
+		}
+		if !isGenerated(profile) {
+			t.Fatalf("Expected the profile to be annotated as generated, got %v", profile.Annotations)
+		}
+		if len(profile.Spec.Routes[0].ResponseClasses) != 2 {
+			t.Fatalf("Expected a response class for each status, got %v", profile.Spec.Routes[0].ResponseClasses)
+		}
+	})
+
+	t.Run("Adds the routes observed since to a generated profile", func(t *testing.T) {
+		apiClient := &public.MockApiClient{
+			TopRoutesResponseToReturn:       topRoutesResponse(authority, 100),
+			Api_TapByResourceClientToReturn: &public.MockApi_TapByResourceClient{TapEventsToReturn: webTapEvents()},
+		}
+		operator := newTestOperator(t, apiClient, `
+apiVersion: linkerd.io/v1alpha1
+kind: ServiceProfile
+metadata:
+  name: web-svc.emojivoto.svc.cluster.local
+  namespace: linkerd
+  annotations:
+    linkerd.io/created-by: linkerd/profile-operator dev-undefined
+spec:
+  routes:
+  - name: GET /authors
+    condition:
+      method: GET
+      pathRegex: /authors`)
+		operator.reconcile(context.Background())
+
+		profile := getProfile(t, operator, profileName)
+		expected := []string{"GET /authors", "GET /books/{id}"}
+		if !reflect.DeepEqual(routeNames(profile), expected) {
+			t.Fatalf("Expected the routes %v, got %v", expected, routeNames(profile))
+		}
+	})
+
+	t.Run("Leaves the profiles not generated unchanged", func(t *testing.T) {
+		apiClient := &public.MockApiClient{
+			TopRoutesResponseToReturn:       topRoutesResponse(authority, 100),
+			Api_TapByResourceClientToReturn: &public.MockApi_TapByResourceClient{TapEventsToReturn: webTapEvents()},
+		}
+		operator := newTestOperator(t, apiClient, `
+apiVersion: linkerd.io/v1alpha1
+kind: ServiceProfile
+metadata:
+  name: web-svc.emojivoto.svc.cluster.local
+  namespace: linkerd
+spec:
+  routes:
+  - name: GET /authors
+    condition:
+      method: GET
+      pathRegex: /authors`)
+		operator.reconcile(context.Background())
+
+		profile := getProfile(t, operator, profileName)
+		if !reflect.DeepEqual(routeNames(profile), []string{"GET /authors"}) {
+			t.Fatalf("Expected the profile to be unchanged, got routes %v", routeNames(profile))
+		}
+		if profile.Annotations[pkgK8s.CreatedByAnnotation] != "" {
+			t.Fatalf("Expected the profile not to be annotated, got %v", profile.Annotations)
+		}
+	})
+
+	t.Run("Does not generate profiles on scarce traffic", func(t *testing.T) {
+		apiClient := &public.MockApiClient{
+			TopRoutesResponseToReturn:       topRoutesResponse(authority, 5),
+			Api_TapByResourceClientToReturn: &public.MockApi_TapByResourceClient{TapEventsToReturn: webTapEvents()},
+		}
+		operator := newTestOperator(t, apiClient)
+		operator.reconcile(context.Background())
+
+		_, err := operator.k8sAPI.SpClient.LinkerdV1alpha1().ServiceProfiles("linkerd").Get(profileName, metav1.GetOptions{})
+		if err == nil {
+			t.Fatal("Expected no ServiceProfile to be generated")
+		}
+	})
+}
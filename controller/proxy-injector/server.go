@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"net/http"
 
+	splisters "github.com/linkerd/linkerd2/controller/gen/client/listers/serviceprofile/v1alpha1"
 	pem "github.com/linkerd/linkerd2/pkg/tls"
 	log "github.com/sirupsen/logrus"
 	"k8s.io/client-go/kubernetes"
@@ -20,7 +21,7 @@ type WebhookServer struct {
 }
 
 // NewWebhookServer returns a new instance of the WebhookServer.
-func NewWebhookServer(client kubernetes.Interface, resources *WebhookResources, addr, controllerNamespace, certFile, keyFile string) (*WebhookServer, error) {
+func NewWebhookServer(client kubernetes.Interface, resources *WebhookResources, addr, controllerNamespace, certFile, keyFile string, meshConfigs splisters.MeshConfigNamespaceLister) (*WebhookServer, error) {
 	c, err := tlsConfig(certFile, keyFile)
 	if err != nil {
 		return nil, err
@@ -31,7 +32,7 @@ func NewWebhookServer(client kubernetes.Interface, resources *WebhookResources,
 		TLSConfig: c,
 	}
 
-	webhook, err := NewWebhook(client, resources, controllerNamespace)
+	webhook, err := NewWebhook(client, resources, controllerNamespace, meshConfigs)
 	if err != nil {
 		return nil, err
 	}
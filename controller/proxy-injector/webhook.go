@@ -7,12 +7,15 @@ import (
 	"strings"
 
 	yaml "github.com/ghodss/yaml"
+	spv1alpha1 "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	splisters "github.com/linkerd/linkerd2/controller/gen/client/listers/serviceprofile/v1alpha1"
 	"github.com/linkerd/linkerd2/pkg/healthcheck"
 	k8sPkg "github.com/linkerd/linkerd2/pkg/k8s"
 	log "github.com/sirupsen/logrus"
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
@@ -21,6 +24,7 @@ import (
 
 const (
 	defaultNamespace                    = "default"
+	envVarKeyProxyLog                   = "LINKERD2_PROXY_LOG"
 	envVarKeyProxyTLSPodIdentity        = "LINKERD2_PROXY_TLS_POD_IDENTITY"
 	envVarKeyProxyTLSControllerIdentity = "LINKERD2_PROXY_TLS_CONTROLLER_IDENTITY"
 )
@@ -29,22 +33,27 @@ const (
 // requests by injecting sidecar container spec into the pod spec during pod
 // creation.
 type Webhook struct {
+	client              kubernetes.Interface
 	deserializer        runtime.Decoder
 	controllerNamespace string
 	resources           *WebhookResources
+	meshConfigs         splisters.MeshConfigNamespaceLister
 }
 
-// NewWebhook returns a new instance of Webhook.
-func NewWebhook(client kubernetes.Interface, resources *WebhookResources, controllerNamespace string) (*Webhook, error) {
+// NewWebhook returns a new instance of Webhook. The mesh-wide configuration
+// is read from meshConfigs, if not nil.
+func NewWebhook(client kubernetes.Interface, resources *WebhookResources, controllerNamespace string, meshConfigs splisters.MeshConfigNamespaceLister) (*Webhook, error) {
 	var (
 		scheme = runtime.NewScheme()
 		codecs = serializer.NewCodecFactory(scheme)
 	)
 
 	return &Webhook{
+		client:              client,
 		deserializer:        codecs.UniversalDeserializer(),
 		controllerNamespace: controllerNamespace,
 		resources:           resources,
+		meshConfigs:         meshConfigs,
 	}, nil
 }
 
@@ -116,6 +125,32 @@ func (w *Webhook) inject(request *admissionv1beta1.AdmissionRequest) (*admission
 		}, nil
 	}
 
+	meshConfig, err := w.meshConfig()
+	if err != nil {
+		return nil, err
+	}
+	nsAnnotations, err := w.namespaceAnnotations(ns)
+	if err != nil {
+		return nil, err
+	}
+	injected, reason, err := k8sPkg.InjectPolicy(deployment.Spec.Template.Annotations, nsAnnotations, defaultInjectPolicy(meshConfig))
+	if err != nil {
+		return nil, err
+	}
+	if !injected {
+		log.Infof("skipping deployment %s, disabled by the %s", deployment.ObjectMeta.Name, reason)
+		return &admissionv1beta1.AdmissionResponse{
+			UID:     request.UID,
+			Allowed: true,
+		}, nil
+	}
+	log.Infof("injecting deployment %s, enabled by the %s", deployment.ObjectMeta.Name, reason)
+
+	if k8sPkg.TargetsWindows(&deployment.Spec.Template.Spec) {
+		log.Infof("skipping deployment %s, which runs on Windows nodes", deployment.ObjectMeta.Name)
+		return skipWindows(request, &deployment)
+	}
+
 	identity := &k8sPkg.TLSIdentity{
 		Name:                deployment.ObjectMeta.Name,
 		Kind:                strings.ToLower(request.Kind.Kind),
@@ -126,10 +161,26 @@ func (w *Webhook) inject(request *admissionv1beta1.AdmissionRequest) (*admission
 	if err != nil {
 		return nil, err
 	}
+	if meshConfig != nil {
+		if err := applyMeshConfig(proxy, proxyInit, &meshConfig.Spec); err != nil {
+			return nil, err
+		}
+	}
+	if err := overrideProxyInitArgs(proxyInit, deployment.Spec.Template.Annotations); err != nil {
+		return nil, err
+	}
+	if err := overrideProxyResources(proxy, deployment.Spec.Template.Annotations); err != nil {
+		return nil, err
+	}
+	if err := overrideOpaquePorts(proxy, nsAnnotations, deployment.Spec.Template.Annotations); err != nil {
+		return nil, err
+	}
 	log.Infof("proxy image: %s", proxy.Image)
-	log.Infof("proxy-init image: %s", proxyInit.Image)
 	log.Debugf("proxy container: %+v", proxy)
-	log.Debugf("init container: %+v", proxyInit)
+	if proxyInit != nil {
+		log.Infof("proxy-init image: %s", proxyInit.Image)
+		log.Debugf("init container: %+v", proxyInit)
+	}
 
 	caBundle, tlsSecrets, err := w.volumesSpec(identity)
 	if err != nil {
@@ -141,10 +192,12 @@ func (w *Webhook) inject(request *admissionv1beta1.AdmissionRequest) (*admission
 	patch := NewPatch()
 	patch.addContainer(proxy)
 
-	if len(deployment.Spec.Template.Spec.InitContainers) == 0 {
-		patch.addInitContainerRoot()
+	if proxyInit != nil {
+		if len(deployment.Spec.Template.Spec.InitContainers) == 0 {
+			patch.addInitContainerRoot()
+		}
+		patch.addInitContainer(proxyInit)
 	}
-	patch.addInitContainer(proxyInit)
 
 	if len(deployment.Spec.Template.Spec.Volumes) == 0 {
 		patch.addVolumeRoot()
@@ -202,6 +255,30 @@ func (w *Webhook) inject(request *admissionv1beta1.AdmissionRequest) (*admission
 	return admissionResponse, nil
 }
 
+// skipWindows admits the deployment without injecting it, annotating its pods
+// with the reason why: the proxy and proxy-init images only run on Linux.
+func skipWindows(request *admissionv1beta1.AdmissionRequest, deployment *appsv1.Deployment) (*admissionv1beta1.AdmissionResponse, error) {
+	if deployment.Spec.Template.Annotations == nil {
+		deployment.Spec.Template.Annotations = map[string]string{}
+	}
+	deployment.Spec.Template.Annotations[k8sPkg.ProxyInjectStatusAnnotation] = k8sPkg.ProxyInjectSkippedWindows
+
+	patch := NewPatch()
+	patch.addPodAnnotations(deployment.Spec.Template.Annotations)
+	patchJSON, err := json.Marshal(patch.patchOps)
+	if err != nil {
+		return nil, err
+	}
+
+	patchType := admissionv1beta1.PatchTypeJSONPatch
+	return &admissionv1beta1.AdmissionResponse{
+		UID:       request.UID,
+		Allowed:   true,
+		Patch:     patchJSON,
+		PatchType: &patchType,
+	}, nil
+}
+
 func (w *Webhook) ignore(deployment *appsv1.Deployment) bool {
 	labels := deployment.Spec.Template.ObjectMeta.GetLabels()
 	status, defined := labels[k8sPkg.ProxyAutoInjectLabel]
@@ -234,6 +311,9 @@ func (w *Webhook) containersSpec(identity *k8sPkg.TLSIdentity) (*corev1.Containe
 		}
 	}
 
+	if w.resources.FileProxyInitSpec == "" {
+		return &proxy, nil, nil
+	}
 	proxyInitSpec, err := ioutil.ReadFile(w.resources.FileProxyInitSpec)
 	if err != nil {
 		return nil, nil, err
@@ -247,6 +327,158 @@ func (w *Webhook) containersSpec(identity *k8sPkg.TLSIdentity) (*corev1.Containe
 	return &proxy, &proxyInit, nil
 }
 
+// overrideProxyResources applies the resource requests and limits of the pod
+// annotations to the proxy container spec, on top of those of the install.
+func overrideProxyResources(proxy *corev1.Container, annotations map[string]string) error {
+	quantity := func(list corev1.ResourceList, name corev1.ResourceName) string {
+		if q, ok := list[name]; ok {
+			return q.String()
+		}
+		return ""
+	}
+
+	resources, err := k8sPkg.ProxyResources(annotations,
+		quantity(proxy.Resources.Requests, corev1.ResourceCPU),
+		quantity(proxy.Resources.Requests, corev1.ResourceMemory),
+		quantity(proxy.Resources.Limits, corev1.ResourceCPU),
+		quantity(proxy.Resources.Limits, corev1.ResourceMemory))
+	if err != nil {
+		return err
+	}
+	proxy.Resources = resources
+	return nil
+}
+
+// setProxyEnv sets the value of an environment variable of the proxy
+// container spec, adding it if it's not set.
+func setProxyEnv(proxy *corev1.Container, env corev1.EnvVar) {
+	for index := range proxy.Env {
+		if proxy.Env[index].Name == env.Name {
+			proxy.Env[index].Value = env.Value
+			return
+		}
+	}
+	proxy.Env = append(proxy.Env, env)
+}
+
+// meshConfig returns the mesh-wide configuration, or nil if there's none.
+func (w *Webhook) meshConfig() (*spv1alpha1.MeshConfig, error) {
+	if w.meshConfigs == nil {
+		return nil, nil
+	}
+	meshConfig, err := w.meshConfigs.Get(k8sPkg.MeshConfigName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return meshConfig, nil
+}
+
+// defaultInjectPolicy returns the inject policy of the workloads whose pods
+// and namespace don't set one, which is to inject them unless the mesh config
+// says otherwise.
+func defaultInjectPolicy(meshConfig *spv1alpha1.MeshConfig) string {
+	if meshConfig != nil && meshConfig.Spec.DefaultInjectPolicy != "" {
+		return meshConfig.Spec.DefaultInjectPolicy
+	}
+	return k8sPkg.ProxyInjectEnabled
+}
+
+// applyMeshConfig applies the mesh-wide configuration to the proxy and
+// proxy-init container specs, replacing the install-time defaults. It's
+// applied before the namespace and pod annotations, which take precedence.
+func applyMeshConfig(proxy, proxyInit *corev1.Container, spec *spv1alpha1.MeshConfigSpec) error {
+	if spec.ProxyLogLevel != "" {
+		setProxyEnv(proxy, corev1.EnvVar{Name: envVarKeyProxyLog, Value: spec.ProxyLogLevel})
+	}
+
+	if spec.OpaquePorts != "" {
+		ports, err := k8sPkg.OpaquePorts(spec.OpaquePorts, nil, nil)
+		if err != nil {
+			return err
+		}
+		setProxyEnv(proxy, corev1.EnvVar{Name: k8sPkg.ProxyOpaquePortsEnvVar, Value: ports})
+	}
+
+	// the outbound CIDRs to skip are applied like the equivalent annotation
+	annotations := map[string]string{}
+	if len(spec.SkipOutboundCIDRs) > 0 {
+		annotations[k8sPkg.ProxySkipOutboundCIDRsAnnotation] = strings.Join(spec.SkipOutboundCIDRs, ",")
+	}
+	return overrideProxyInitArgs(proxyInit, annotations)
+}
+
+// overrideProxyInitArgs applies the outbound CIDRs to skip of the pod
+// annotations to the proxy-init container spec, if it's injected.
+func overrideProxyInitArgs(proxyInit *corev1.Container, annotations map[string]string) error {
+	if proxyInit == nil {
+		return nil
+	}
+	if _, ok := annotations[k8sPkg.ProxySkipOutboundCIDRsAnnotation]; !ok {
+		return nil
+	}
+	cidrs, err := k8sPkg.SkipOutboundCIDRs(annotations, nil)
+	if err != nil {
+		return err
+	}
+
+	args := []string{}
+	for index := 0; index < len(proxyInit.Args); index++ {
+		if proxyInit.Args[index] == "--outbound-cidrs-to-ignore" {
+			index++
+			continue
+		}
+		args = append(args, proxyInit.Args[index])
+	}
+	if len(cidrs) > 0 {
+		args = append(args, "--outbound-cidrs-to-ignore", strings.Join(cidrs, ","))
+	}
+	proxyInit.Args = args
+
+	return nil
+}
+
+// overrideOpaquePorts merges the opaque ports of the namespace and pod
+// annotations with the mesh-wide defaults of the proxy container spec.
+func overrideOpaquePorts(proxy *corev1.Container, nsAnnotations, annotations map[string]string) error {
+	index := -1
+	defaults := ""
+	for i, env := range proxy.Env {
+		if env.Name == k8sPkg.ProxyOpaquePortsEnvVar {
+			index = i
+			defaults = env.Value
+		}
+	}
+
+	ports, err := k8sPkg.OpaquePorts(defaults, nsAnnotations, annotations)
+	if err != nil {
+		return err
+	}
+
+	if index >= 0 {
+		proxy.Env[index].Value = ports
+	} else if ports != "" {
+		proxy.Env = append(proxy.Env, corev1.EnvVar{Name: k8sPkg.ProxyOpaquePortsEnvVar, Value: ports})
+	}
+
+	return nil
+}
+
+// namespaceAnnotations returns the annotations of the namespace ns, which
+// hold the namespace-wide proxy configuration defaults.
+func (w *Webhook) namespaceAnnotations(ns string) (map[string]string, error) {
+	namespace, err := w.client.CoreV1().Namespaces().Get(ns, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return namespace.Annotations, nil
+}
+
 func (w *Webhook) volumesSpec(identity *k8sPkg.TLSIdentity) (*corev1.Volume, *corev1.Volume, error) {
 	trustAnchorVolumeSpec, err := ioutil.ReadFile(w.resources.FileTLSTrustAnchorVolumeSpec)
 	if err != nil {
@@ -277,7 +509,9 @@ type WebhookResources struct {
 	// FileProxySpec is the path to the proxy spec.
 	FileProxySpec string
 
-	// FileProxyInitSpec is the path to the proxy-init spec.
+	// FileProxyInitSpec is the path to the proxy-init spec. When empty, the
+	// proxy-init container isn't injected, as the linkerd-cni plugin sets up
+	// the iptables rules of the pods.
 	FileProxyInitSpec string
 
 	// FileTLSTrustAnchorVolumeSpec is the path to the trust anchor volume spec.
@@ -1,15 +1,23 @@
 package injector
 
 import (
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"reflect"
 	"testing"
 
+	spv1alpha1 "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	controllerK8s "github.com/linkerd/linkerd2/controller/k8s"
 	"github.com/linkerd/linkerd2/controller/proxy-injector/fake"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	log "github.com/sirupsen/logrus"
 	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
 )
 
 var (
@@ -24,7 +32,7 @@ func init() {
 		panic(err)
 	}
 
-	webhook, err = NewWebhook(fakeClient, testWebhookResources, fake.DefaultControllerNamespace)
+	webhook, err = NewWebhook(fakeClient, testWebhookResources, fake.DefaultControllerNamespace, nil)
 	if err != nil {
 		panic(err)
 	}
@@ -99,6 +107,103 @@ func TestIgnore(t *testing.T) {
 	})
 }
 
+func TestInjectPolicy(t *testing.T) {
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "opted-out",
+			Annotations: map[string]string{k8s.ProxyInjectAnnotation: k8s.ProxyInjectDisabled},
+		},
+	}
+	policyWebhook, err := NewWebhook(k8sfake.NewSimpleClientset(namespace), testWebhookResources, fake.DefaultControllerNamespace, nil)
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	testCases := []struct {
+		description    string
+		podAnnotations map[string]string
+		expected       bool
+	}{
+		{"namespace opt-out", nil, false},
+		{"pod opt-in", map[string]string{k8s.ProxyInjectAnnotation: k8s.ProxyInjectEnabled}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			deployment, err := factory.Deployment("deployment-inject-status-empty.yaml")
+			if err != nil {
+				t.Fatal("Unexpected error: ", err)
+			}
+			for k, v := range tc.podAnnotations {
+				deployment.Spec.Template.Annotations[k] = v
+			}
+			raw, err := json.Marshal(deployment)
+			if err != nil {
+				t.Fatal("Unexpected error: ", err)
+			}
+
+			request := &admissionv1beta1.AdmissionRequest{
+				UID:       "1234",
+				Kind:      metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+				Namespace: namespace.Name,
+				Object:    runtime.RawExtension{Raw: raw},
+			}
+			response, err := policyWebhook.inject(request)
+			if err != nil {
+				t.Fatal("Unexpected error: ", err)
+			}
+			if !response.Allowed {
+				t.Fatal("Expected the deployment to be admitted")
+			}
+			if injected := len(response.Patch) > 0; injected != tc.expected {
+				t.Fatalf("Expected injected to be %t, got %t", tc.expected, injected)
+			}
+		})
+	}
+
+	t.Run("by default", func(t *testing.T) {
+		if policy := defaultInjectPolicy(nil); policy != k8s.ProxyInjectEnabled {
+			t.Fatalf("Expected the default inject policy to be %s, got %s", k8s.ProxyInjectEnabled, policy)
+		}
+		meshConfig := &spv1alpha1.MeshConfig{Spec: spv1alpha1.MeshConfigSpec{DefaultInjectPolicy: k8s.ProxyInjectDisabled}}
+		if policy := defaultInjectPolicy(meshConfig); policy != k8s.ProxyInjectDisabled {
+			t.Fatalf("Expected the default inject policy to be %s, got %s", k8s.ProxyInjectDisabled, policy)
+		}
+	})
+}
+
+func TestSkipWindows(t *testing.T) {
+	deployment, err := factory.Deployment("deployment-inject-status-empty.yaml")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	deployment.Spec.Template.Spec.NodeSelector = map[string]string{k8s.NodeOSLabel: "windows"}
+	if !k8s.TargetsWindows(&deployment.Spec.Template.Spec) {
+		t.Fatal("Expected the deployment to target Windows nodes")
+	}
+
+	request := &admissionv1beta1.AdmissionRequest{UID: "1234"}
+	response, err := skipWindows(request, deployment)
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if !response.Allowed {
+		t.Fatal("Expected the deployment to be admitted")
+	}
+
+	var patch []patchOp
+	if err := json.Unmarshal(response.Patch, &patch); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if len(patch) != 1 || patch[0].Path != patchPathPodAnnotations {
+		t.Fatalf("Expected a single patch of the pod annotations, got %+v", patch)
+	}
+	annotations := patch[0].Value.(map[string]interface{})
+	if annotations[k8s.ProxyInjectStatusAnnotation] != k8s.ProxyInjectSkippedWindows {
+		t.Fatalf("Expected the %s annotation to be %s, got %v", k8s.ProxyInjectStatusAnnotation, k8s.ProxyInjectSkippedWindows, annotations)
+	}
+}
+
 func TestContainersSpec(t *testing.T) {
 	expectedSidecar, err := factory.Container("inject-sidecar-container-spec.yaml")
 	if err != nil {
@@ -131,6 +236,36 @@ func TestContainersSpec(t *testing.T) {
 	}
 }
 
+func TestContainersSpecWithoutInitContainer(t *testing.T) {
+	resources := *testWebhookResources
+	resources.FileProxyInitSpec = ""
+	cniWebhook, err := NewWebhook(k8sfake.NewSimpleClientset(), &resources, fake.DefaultControllerNamespace, nil)
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	identity := &k8s.TLSIdentity{
+		Name:                "nginx",
+		Kind:                "deployment",
+		Namespace:           fake.DefaultNamespace,
+		ControllerNamespace: fake.DefaultControllerNamespace,
+	}
+
+	actualSidecar, actualInit, err := cniWebhook.containersSpec(identity)
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if actualSidecar == nil {
+		t.Errorf("Expected the proxy container spec")
+	}
+	if actualInit != nil {
+		t.Errorf("Expected no init container spec, got: %+v", actualInit)
+	}
+	if err := overrideProxyInitArgs(actualInit, map[string]string{k8s.ProxySkipOutboundCIDRsAnnotation: "10.0.0.0/8"}); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+}
+
 func TestVolumesSpec(t *testing.T) {
 	expectedTrustAnchors, err := factory.Volume("inject-trust-anchors-volume-spec.yaml")
 	if err != nil {
@@ -179,3 +314,156 @@ func assertEqualAdmissionReview(t *testing.T, expected, actual *admissionv1beta1
 		t.Errorf("Response patch mismatch\nExpected: %s\nActual: %s", expected.Response.Patch, actual.Response.Patch)
 	}
 }
+
+func TestOverrideProxyResources(t *testing.T) {
+	proxy, err := factory.Container("inject-sidecar-container-spec.yaml")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	proxy.Resources.Requests = corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("10m")}
+
+	annotations := map[string]string{
+		k8s.ProxyCPULimitAnnotation:      "1",
+		k8s.ProxyMemoryRequestAnnotation: "64Mi",
+	}
+	if err := overrideProxyResources(proxy, annotations); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	expected := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceCPU:    resource.MustParse("10m"),
+			corev1.ResourceMemory: resource.MustParse("64Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceCPU: resource.MustParse("1"),
+		},
+	}
+	if !reflect.DeepEqual(proxy.Resources, expected) {
+		t.Fatalf("Expected resources %v, got %v", expected, proxy.Resources)
+	}
+
+	annotations[k8s.ProxyCPULimitAnnotation] = "5m"
+	if err := overrideProxyResources(proxy, annotations); err == nil {
+		t.Fatal("Expected error, got nothing")
+	}
+}
+
+func TestOverrideProxyInitArgs(t *testing.T) {
+	proxyInit, err := factory.Container("inject-init-container-spec.yaml")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	argCount := len(proxyInit.Args)
+
+	annotations := map[string]string{k8s.ProxySkipOutboundCIDRsAnnotation: "169.254.169.254/32,10.0.0.0/8"}
+	for i := 0; i < 2; i++ {
+		if err := overrideProxyInitArgs(proxyInit, annotations); err != nil {
+			t.Fatal("Unexpected error: ", err)
+		}
+	}
+
+	expected := []string{"--outbound-cidrs-to-ignore", "169.254.169.254/32,10.0.0.0/8"}
+	if !reflect.DeepEqual(proxyInit.Args[argCount:], expected) {
+		t.Fatalf("Expected args %v, got %v", expected, proxyInit.Args[argCount:])
+	}
+
+	annotations[k8s.ProxySkipOutboundCIDRsAnnotation] = "169.254.169.254"
+	if err := overrideProxyInitArgs(proxyInit, annotations); err == nil {
+		t.Fatal("Expected error, got nothing")
+	}
+}
+
+func TestOverrideOpaquePorts(t *testing.T) {
+	proxy, err := factory.Container("inject-sidecar-container-spec.yaml")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	proxy.Env = append(proxy.Env, corev1.EnvVar{Name: k8s.ProxyOpaquePortsEnvVar, Value: "25"})
+	envCount := len(proxy.Env)
+
+	nsAnnotations := map[string]string{k8s.ProxyOpaquePortsAnnotation: "3306,5432"}
+	annotations := map[string]string{k8s.ProxyOpaquePortsAnnotation: "11211"}
+	if err := overrideOpaquePorts(proxy, nsAnnotations, annotations); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	if len(proxy.Env) != envCount {
+		t.Fatalf("Expected %d env vars, got %d", envCount, len(proxy.Env))
+	}
+	if env := proxy.Env[envCount-1]; env.Value != "25,3306,5432,11211" {
+		t.Fatalf("Expected %s to be [25,3306,5432,11211], got [%s]", env.Name, env.Value)
+	}
+
+	nsAnnotations[k8s.ProxyOpaquePortsAnnotation] = "mysql"
+	if err := overrideOpaquePorts(proxy, nsAnnotations, annotations); err == nil {
+		t.Fatal("Expected error, got nothing")
+	}
+}
+
+func TestApplyMeshConfig(t *testing.T) {
+	proxy, err := factory.Container("inject-sidecar-container-spec.yaml")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	proxyInit, err := factory.Container("inject-init-container-spec.yaml")
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	argCount := len(proxyInit.Args)
+
+	k8sAPI, err := controllerK8s.NewFakeAPI("", `
+apiVersion: linkerd.io/v1alpha1
+kind: MeshConfig
+metadata:
+  name: linkerd
+  namespace: linkerd
+spec:
+  proxyLogLevel: debug
+  opaquePorts: "3306"
+  skipOutboundCIDRs:
+  - 169.254.169.254/32`)
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	k8sAPI.Sync(nil)
+
+	meshConfigWebhook, err := NewWebhook(nil, testWebhookResources, fake.DefaultControllerNamespace, k8sAPI.MC().Lister().MeshConfigs("linkerd"))
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	meshConfig, err := meshConfigWebhook.meshConfig()
+	if err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if err := applyMeshConfig(proxy, proxyInit, &meshConfig.Spec); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	// the pod annotations still take precedence
+	annotations := map[string]string{k8s.ProxyOpaquePortsAnnotation: "11211"}
+	if err := overrideOpaquePorts(proxy, nil, annotations); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	expectedEnv := map[string]string{
+		"LINKERD2_PROXY_LOG":       "debug",
+		k8s.ProxyOpaquePortsEnvVar: "3306,11211",
+	}
+	for _, env := range proxy.Env {
+		if expected, ok := expectedEnv[env.Name]; ok {
+			if env.Value != expected {
+				t.Fatalf("Expected %s to be [%s], got [%s]", env.Name, expected, env.Value)
+			}
+			delete(expectedEnv, env.Name)
+		}
+	}
+	if len(expectedEnv) != 0 {
+		t.Fatalf("Expected env vars %v to be set", expectedEnv)
+	}
+
+	expectedArgs := []string{"--outbound-cidrs-to-ignore", "169.254.169.254/32"}
+	if !reflect.DeepEqual(proxyInit.Args[argCount:], expectedArgs) {
+		t.Fatalf("Expected args %v, got %v", expectedArgs, proxyInit.Args[argCount:])
+	}
+}
@@ -0,0 +1,370 @@
+package otlp
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/golang/protobuf/ptypes"
+	public "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/addr"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+)
+
+const (
+	scopeName = "linkerd-tap"
+
+	// the span kinds and status codes of OTLP
+	spanKindServer  = 2
+	spanKindClient  = 3
+	statusCodeError = 2
+
+	// maxPendingSpans caps the spans waiting to be sent, and the requests
+	// waiting for their response, so that an unreachable collector or streams
+	// never ending don't grow the exporter without bound.
+	maxPendingSpans = 10000
+)
+
+// Exporter converts the tap events of each request and its response into an
+// OpenTelemetry span, and sends the spans in batches to a collector with the
+// OTLP/HTTP protocol, in its JSON encoding. The spans aren't part of the
+// traces of the applications: each request starts a new trace.
+type Exporter struct {
+	url      string
+	client   *http.Client
+	interval time.Duration
+	now      func() time.Time
+	random   io.Reader
+
+	sync.Mutex
+	requests map[streamKey]*request
+	spans    []span
+	dropped  int
+}
+
+// streamKey identifies the HTTP stream of an event. Stream IDs are only unique
+// within a proxy, so the key includes the addresses of the stream.
+type streamKey struct {
+	base        uint32
+	stream      uint64
+	direction   public.TapEvent_ProxyDirection
+	source      string
+	destination string
+}
+
+// request is a request waiting for the end of its response.
+type request struct {
+	start  time.Time
+	event  *public.TapEvent
+	init   *public.TapEvent_Http_RequestInit
+	status uint32
+}
+
+// NewExporter returns an exporter sending the spans to the OTLP/HTTP receiver
+// of a collector at endpoint, e.g. http://otel-collector.tracing:4318, every
+// interval.
+func NewExporter(endpoint string, interval time.Duration) *Exporter {
+	return &Exporter{
+		url:      strings.TrimSuffix(endpoint, "/") + "/v1/traces",
+		client:   &http.Client{Timeout: 10 * time.Second},
+		interval: interval,
+		now:      time.Now,
+		random:   rand.Reader,
+		requests: make(map[streamKey]*request),
+	}
+}
+
+// Export records the tap event, adding a span once the response of its
+// request ends.
+func (e *Exporter) Export(event *public.TapEvent) {
+	e.Lock()
+	defer e.Unlock()
+
+	switch ev := event.GetHttp().GetEvent().(type) {
+	case *public.TapEvent_Http_RequestInit_:
+		if len(e.requests) < maxPendingSpans {
+			e.requests[streamKeyOf(event, ev.RequestInit.GetId())] = &request{
+				start: e.now(),
+				event: event,
+				init:  ev.RequestInit,
+			}
+		}
+
+	case *public.TapEvent_Http_ResponseInit_:
+		if req, ok := e.requests[streamKeyOf(event, ev.ResponseInit.GetId())]; ok {
+			req.status = ev.ResponseInit.GetHttpStatus()
+		}
+
+	case *public.TapEvent_Http_ResponseEnd_:
+		key := streamKeyOf(event, ev.ResponseEnd.GetId())
+		req, ok := e.requests[key]
+		if !ok {
+			return
+		}
+		delete(e.requests, key)
+
+		if len(e.spans) >= maxPendingSpans {
+			e.dropped++
+			return
+		}
+		s, err := e.newSpan(req, ev.ResponseEnd)
+		if err != nil {
+			log.Errorf("failed to create the tap span of %s: %s", req.init.GetPath(), err)
+			return
+		}
+		e.spans = append(e.spans, s)
+	}
+}
+
+// Run sends the spans every interval, until stop is closed.
+func (e *Exporter) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(e.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := e.Flush(); err != nil {
+				log.Errorf("failed to export the tap spans: %s", err)
+			}
+		}
+	}
+}
+
+// Flush sends the pending spans. The spans are dropped if the collector
+// can't be reached, rather than retried.
+func (e *Exporter) Flush() error {
+	e.Lock()
+	spans, dropped := e.spans, e.dropped
+	e.spans, e.dropped = nil, 0
+	e.Unlock()
+
+	if dropped > 0 {
+		log.Warnf("dropped %d tap spans exceeding the limit of %d pending spans", dropped, maxPendingSpans)
+	}
+	if len(spans) == 0 {
+		return nil
+	}
+
+	b, err := json.Marshal(newTracesData(spans))
+	if err != nil {
+		return err
+	}
+	rsp, err := e.client.Post(e.url, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode < 200 || rsp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected response from %s: %s", e.url, rsp.Status)
+	}
+	return nil
+}
+
+// span is a span of the OTLP JSON encoding, along with the name of the service
+// of the proxy that reported it.
+type span struct {
+	service string
+
+	TraceID           string      `json:"traceId"`
+	SpanID            string      `json:"spanId"`
+	Name              string      `json:"name"`
+	Kind              int         `json:"kind"`
+	StartTimeUnixNano string      `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string      `json:"endTimeUnixNano"`
+	Attributes        []attribute `json:"attributes"`
+	Status            spanStatus  `json:"status"`
+}
+
+type spanStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type attribute struct {
+	Key   string         `json:"key"`
+	Value attributeValue `json:"value"`
+}
+
+type attributeValue struct {
+	StringValue *string `json:"stringValue,omitempty"`
+	IntValue    *string `json:"intValue,omitempty"`
+}
+
+func stringAttribute(key, value string) attribute {
+	return attribute{Key: key, Value: attributeValue{StringValue: &value}}
+}
+
+func intAttribute(key string, value int64) attribute {
+	v := strconv.FormatInt(value, 10)
+	return attribute{Key: key, Value: attributeValue{IntValue: &v}}
+}
+
+type tracesData struct {
+	ResourceSpans []resourceSpans `json:"resourceSpans"`
+}
+
+type resourceSpans struct {
+	Resource   resource     `json:"resource"`
+	ScopeSpans []scopeSpans `json:"scopeSpans"`
+}
+
+type resource struct {
+	Attributes []attribute `json:"attributes"`
+}
+
+type scopeSpans struct {
+	Scope scope  `json:"scope"`
+	Spans []span `json:"spans"`
+}
+
+type scope struct {
+	Name string `json:"name"`
+}
+
+// newTracesData groups the spans by the service of their proxy, in the order
+// of their first span.
+func newTracesData(spans []span) tracesData {
+	data := tracesData{ResourceSpans: []resourceSpans{}}
+	services := make(map[string]int)
+	for _, s := range spans {
+		i, ok := services[s.service]
+		if !ok {
+			i = len(data.ResourceSpans)
+			services[s.service] = i
+			data.ResourceSpans = append(data.ResourceSpans, resourceSpans{
+				Resource:   resource{Attributes: []attribute{stringAttribute("service.name", s.service)}},
+				ScopeSpans: []scopeSpans{{Scope: scope{Name: scopeName}}},
+			})
+		}
+		data.ResourceSpans[i].ScopeSpans[0].Spans = append(data.ResourceSpans[i].ScopeSpans[0].Spans, s)
+	}
+	return data
+}
+
+func (e *Exporter) newSpan(req *request, end *public.TapEvent_Http_ResponseEnd) (span, error) {
+	traceID, err := e.randomID(16)
+	if err != nil {
+		return span{}, err
+	}
+	spanID, err := e.randomID(8)
+	if err != nil {
+		return span{}, err
+	}
+
+	duration, err := ptypes.Duration(end.GetSinceRequestInit())
+	if err != nil {
+		duration = 0
+	}
+
+	method := formatMethod(req.init.GetMethod())
+	s := span{
+		TraceID:           traceID,
+		SpanID:            spanID,
+		Name:              fmt.Sprintf("%s %s", method, req.init.GetPath()),
+		StartTimeUnixNano: strconv.FormatInt(req.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(req.start.Add(duration).UnixNano(), 10),
+		Attributes: []attribute{
+			stringAttribute("http.method", method),
+			stringAttribute("http.host", req.init.GetAuthority()),
+			stringAttribute("http.target", req.init.GetPath()),
+			stringAttribute("net.peer.name", addr.PublicAddressToString(req.event.GetSource())),
+			stringAttribute("net.host.name", addr.PublicAddressToString(req.event.GetDestination())),
+		},
+	}
+	if scheme := formatScheme(req.init.GetScheme()); scheme != "" {
+		s.Attributes = append(s.Attributes, stringAttribute("http.scheme", scheme))
+	}
+	if req.status != 0 {
+		s.Attributes = append(s.Attributes, intAttribute("http.status_code", int64(req.status)))
+	}
+
+	// the tapped proxy is the destination of inbound requests, and the source of
+	// outbound requests
+	labels := req.event.GetSourceMeta().GetLabels()
+	s.Kind = spanKindClient
+	if req.event.GetProxyDirection() == public.TapEvent_INBOUND {
+		labels = req.event.GetDestinationMeta().GetLabels()
+		s.Kind = spanKindServer
+	}
+	s.service = serviceName(labels)
+	for _, label := range []string{"namespace", "pod"} {
+		if value := labels[label]; value != "" {
+			s.Attributes = append(s.Attributes, stringAttribute("k8s."+label+".name", value))
+		}
+	}
+
+	if req.status >= 500 {
+		s.Status = spanStatus{Code: statusCodeError}
+	}
+	switch eos := end.GetEos().GetEnd().(type) {
+	case *public.Eos_GrpcStatusCode:
+		s.Attributes = append(s.Attributes, intAttribute("rpc.grpc.status_code", int64(eos.GrpcStatusCode)))
+		if eos.GrpcStatusCode != 0 {
+			s.Status = spanStatus{Code: statusCodeError, Message: codes.Code(eos.GrpcStatusCode).String()}
+		}
+	case *public.Eos_ResetErrorCode:
+		s.Status = spanStatus{Code: statusCodeError, Message: fmt.Sprintf("stream reset with error code %d", eos.ResetErrorCode)}
+	}
+
+	return s, nil
+}
+
+// serviceName returns the name of the workload of the tapped proxy.
+func serviceName(labels map[string]string) string {
+	for _, label := range []string{"deployment", "statefulset", "daemonset", "job", "replicationcontroller", "pod"} {
+		if value := labels[label]; value != "" {
+			return value
+		}
+	}
+	return "unknown"
+}
+
+func formatMethod(method *public.HttpMethod) string {
+	if unregistered := method.GetUnregistered(); unregistered != "" {
+		return unregistered
+	}
+	return method.GetRegistered().String()
+}
+
+func formatScheme(scheme *public.Scheme) string {
+	if scheme == nil {
+		return ""
+	}
+	if unregistered := scheme.GetUnregistered(); unregistered != "" {
+		return unregistered
+	}
+	return strings.ToLower(scheme.GetRegistered().String())
+}
+
+func streamKeyOf(event *public.TapEvent, id *public.TapEvent_Http_StreamId) streamKey {
+	return streamKey{
+		base:        id.GetBase(),
+		stream:      id.GetStream(),
+		direction:   event.GetProxyDirection(),
+		source:      proto.CompactTextString(event.GetSource()),
+		destination: proto.CompactTextString(event.GetDestination()),
+	}
+}
+
+// randomID returns a random trace or span ID of size bytes, hex-encoded.
+func (e *Exporter) randomID(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := io.ReadFull(e.random, b); err != nil {
+		return "", fmt.Errorf("failed to generate a random ID: %s", err)
+	}
+	return hex.EncodeToString(b), nil
+}
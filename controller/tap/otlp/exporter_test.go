@@ -0,0 +1,150 @@
+package otlp
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/protobuf/ptypes/duration"
+	public "github.com/linkerd/linkerd2/controller/gen/public"
+)
+
+func tapEvent(http *public.TapEvent_Http) *public.TapEvent {
+	return &public.TapEvent{
+		Source:          &public.TcpAddress{Ip: &public.IPAddress{Ip: &public.IPAddress_Ipv4{Ipv4: 1}}, Port: 1983},
+		Destination:     &public.TcpAddress{Ip: &public.IPAddress{Ip: &public.IPAddress_Ipv4{Ipv4: 9}}, Port: 8080},
+		DestinationMeta: &public.TapEvent_EndpointMeta{Labels: map[string]string{"namespace": "emojivoto", "deployment": "web", "pod": "web-6b7f9d5c4-jq2bk"}},
+		ProxyDirection:  public.TapEvent_INBOUND,
+		Event:           &public.TapEvent_Http_{Http: http},
+	}
+}
+
+func TestExporter(t *testing.T) {
+	id := &public.TapEvent_Http_StreamId{Base: 1, Stream: 2}
+	events := []*public.TapEvent{
+		tapEvent(&public.TapEvent_Http{Event: &public.TapEvent_Http_RequestInit_{RequestInit: &public.TapEvent_Http_RequestInit{
+			Id:        id,
+			Method:    &public.HttpMethod{Type: &public.HttpMethod_Registered_{Registered: public.HttpMethod_POST}},
+			Scheme:    &public.Scheme{Type: &public.Scheme_Registered_{Registered: public.Scheme_HTTP}},
+			Authority: "web-svc.emojivoto:80",
+			Path:      "/emojivoto.v1.VotingService/VoteDoughnut",
+		}}}),
+		tapEvent(&public.TapEvent_Http{Event: &public.TapEvent_Http_ResponseInit_{ResponseInit: &public.TapEvent_Http_ResponseInit{
+			Id:         id,
+			HttpStatus: 200,
+		}}}),
+		tapEvent(&public.TapEvent_Http{Event: &public.TapEvent_Http_ResponseEnd_{ResponseEnd: &public.TapEvent_Http_ResponseEnd{
+			Id:               id,
+			SinceRequestInit: &duration.Duration{Nanos: 2000000},
+			Eos:              &public.Eos{End: &public.Eos_GrpcStatusCode{GrpcStatusCode: 14}},
+		}}}),
+	}
+
+	t.Run("Sends a span per request", func(t *testing.T) {
+		var body []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/v1/traces" || r.Header.Get("Content-Type") != "application/json" {
+				t.Errorf("Unexpected request to %s with content type %s", r.URL.Path, r.Header.Get("Content-Type"))
+			}
+			body, _ = ioutil.ReadAll(r.Body)
+		}))
+		defer server.Close()
+
+		exporter := NewExporter(server.URL+"/", time.Second)
+		exporter.now = func() time.Time { return time.Unix(1546300800, 0) }
+		for _, event := range events {
+			exporter.Export(event)
+		}
+		if err := exporter.Flush(); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		var data tracesData
+		if err := json.Unmarshal(body, &data); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(data.ResourceSpans) != 1 || len(data.ResourceSpans[0].ScopeSpans) != 1 || len(data.ResourceSpans[0].ScopeSpans[0].Spans) != 1 {
+			t.Fatalf("Expected a single span, got %s", body)
+		}
+		if service := data.ResourceSpans[0].Resource.Attributes[0]; service.Key != "service.name" || *service.Value.StringValue != "web" {
+			t.Fatalf("Expected the service of the deployment, got %s", body)
+		}
+
+		s := data.ResourceSpans[0].ScopeSpans[0].Spans[0]
+		if len(s.TraceID) != 32 || len(s.SpanID) != 16 {
+			t.Fatalf("Unexpected trace ID %s and span ID %s", s.TraceID, s.SpanID)
+		}
+		s.TraceID, s.SpanID = "", ""
+		expected := span{
+			Name:              "POST /emojivoto.v1.VotingService/VoteDoughnut",
+			Kind:              spanKindServer,
+			StartTimeUnixNano: "1546300800000000000",
+			EndTimeUnixNano:   "1546300800002000000",
+			Attributes: []attribute{
+				stringAttribute("http.method", "POST"),
+				stringAttribute("http.host", "web-svc.emojivoto:80"),
+				stringAttribute("http.target", "/emojivoto.v1.VotingService/VoteDoughnut"),
+				stringAttribute("net.peer.name", "0.0.0.1:1983"),
+				stringAttribute("net.host.name", "0.0.0.9:8080"),
+				stringAttribute("http.scheme", "http"),
+				intAttribute("http.status_code", 200),
+				stringAttribute("k8s.namespace.name", "emojivoto"),
+				stringAttribute("k8s.pod.name", "web-6b7f9d5c4-jq2bk"),
+				intAttribute("rpc.grpc.status_code", 14),
+			},
+			Status: spanStatus{Code: statusCodeError, Message: "Unavailable"},
+		}
+		if !reflect.DeepEqual(s, expected) {
+			t.Fatalf("Expected span %+v, got %+v", expected, s)
+		}
+	})
+
+	t.Run("Doesn't send anything without spans", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}))
+		defer server.Close()
+
+		exporter := NewExporter(server.URL, time.Second)
+		exporter.Export(events[0])
+		if err := exporter.Flush(); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Reports the errors of the collector", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		exporter := NewExporter(server.URL, time.Second)
+		for _, event := range events {
+			exporter.Export(event)
+		}
+		if err := exporter.Flush(); err == nil {
+			t.Fatal("Expected an error")
+		}
+	})
+
+	t.Run("Drops the spans whose IDs can't be generated", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("Unexpected request to %s", r.URL.Path)
+		}))
+		defer server.Close()
+
+		exporter := NewExporter(server.URL, time.Second)
+		exporter.random = strings.NewReader("")
+		for _, event := range events {
+			exporter.Export(event)
+		}
+		if err := exporter.Flush(); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
+}
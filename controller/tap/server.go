@@ -14,6 +14,7 @@ import (
 	pb "github.com/linkerd/linkerd2/controller/gen/controller/tap"
 	public "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/controller/k8s"
+	"github.com/linkerd/linkerd2/controller/tap/otlp"
 	"github.com/linkerd/linkerd2/pkg/addr"
 	pkgK8s "github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/prometheus"
@@ -39,6 +40,10 @@ type (
 
 var (
 	tapInterval = 1 * time.Second
+
+	// exportRefreshInterval is how often the taps of ExportTaps look up the
+	// pods of their targets again, to follow their rollouts.
+	exportRefreshInterval = 1 * time.Minute
 )
 
 func (s *server) Tap(req *public.TapRequest, stream pb.Tap_TapServer) error {
@@ -52,6 +57,29 @@ func (s *server) TapByResource(req *public.TapByResourceRequest, stream pb.Tap_T
 	if req.Target == nil {
 		return status.Error(codes.InvalidArgument, "TapByResource received nil target ResourceSelection")
 	}
+
+	events := make(chan *public.TapEvent)
+	if err := s.startTaps(stream.Context(), req, events); err != nil {
+		return err
+	}
+
+	// read events from the taps and send them back
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case event := <-events:
+			err := stream.Send(event)
+			if err != nil {
+				return apiUtil.GRPCError(err)
+			}
+		}
+	}
+}
+
+// startTaps taps the meshed pods of the target of req, sending their events to
+// events until ctx is done.
+func (s *server) startTaps(ctx context.Context, req *public.TapByResourceRequest, events chan *public.TapEvent) error {
 	if req.MaxRps == 0.0 {
 		req.MaxRps = defaultMaxRps
 	}
@@ -82,8 +110,6 @@ func (s *server) TapByResource(req *public.TapByResourceRequest, stream pb.Tap_T
 
 	log.Infof("Tapping %d pods for target: %+v", len(pods), *req.Target.Resource)
 
-	events := make(chan *public.TapEvent)
-
 	// divide the rps evenly between all pods to tap
 	rpsPerPod := req.MaxRps / float32(len(pods))
 	if rpsPerPod < 1 {
@@ -97,20 +123,54 @@ func (s *server) TapByResource(req *public.TapByResourceRequest, stream pb.Tap_T
 
 	for _, pod := range pods {
 		// initiate a tap on the pod
-		go s.tapProxy(stream.Context(), rpsPerPod, match, pod.Status.PodIP, events)
+		go s.tapProxy(ctx, rpsPerPod, match, pod.Status.PodIP, events)
 	}
 
-	// read events from the taps and send them back
+	return nil
+}
+
+// ExportTaps taps the targets on behalf of the exporter, independently of the
+// taps of the users, and records their events until stop is closed. It relies
+// on the pod index added by NewServer, and on the caches of k8sAPI being
+// synced.
+func ExportTaps(
+	tapPort uint,
+	controllerNamespace string,
+	k8sAPI *k8s.API,
+	targets []*public.TapByResourceRequest,
+	exporter *otlp.Exporter,
+	stop <-chan struct{},
+) {
+	s := server{
+		tapPort:             tapPort,
+		k8sAPI:              k8sAPI,
+		controllerNamespace: controllerNamespace,
+	}
+	events := make(chan *public.TapEvent)
+
 	for {
-		select {
-		case <-stream.Context().Done():
-			return nil
-		case event := <-events:
-			err := stream.Send(event)
-			if err != nil {
-				return apiUtil.GRPCError(err)
+		ctx, cancel := context.WithTimeout(context.Background(), exportRefreshInterval)
+		for _, target := range targets {
+			if err := s.startTaps(ctx, target, events); err != nil {
+				log.Errorf("failed to tap %s/%s for the export: %s",
+					target.GetTarget().GetResource().GetType(), target.GetTarget().GetResource().GetName(), err)
+			}
+		}
+
+		// the events of the previous taps are still read until they stop, as
+		// the taps share the channel
+		for refresh := false; !refresh; {
+			select {
+			case <-stop:
+				cancel()
+				return
+			case <-ctx.Done():
+				refresh = true
+			case event := <-events:
+				exporter.Export(event)
 			}
 		}
+		cancel()
 	}
 }
 
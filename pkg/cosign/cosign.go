@@ -0,0 +1,340 @@
+package cosign
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	// SignatureAnnotation is the annotation of the signature manifest layers
+	// holding the base64-encoded signature of the layer.
+	SignatureAnnotation = "dev.cosignproject.cosign/signature"
+
+	// signatureType is the type of the signed payloads of image signatures.
+	signatureType = "cosign container image signature"
+
+	defaultRegistry = "registry-1.docker.io"
+)
+
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+}
+
+// ErrNoSignature is returned when an image isn't signed.
+var ErrNoSignature = errors.New("no signature found")
+
+// Verifier verifies the cosign signatures of container images against a
+// public key, reading the images and their signatures from their registries.
+type Verifier struct {
+	key    *ecdsa.PublicKey
+	client *http.Client
+	// tokens caches the registry tokens, keyed by registry and repository
+	tokens map[string]string
+}
+
+// NewVerifier returns a Verifier for the PEM-encoded ECDSA public key, as
+// generated by `cosign generate-key-pair`.
+func NewVerifier(keyPEM []byte, client *http.Client) (*Verifier, error) {
+	key, err := ParsePublicKey(keyPEM)
+	if err != nil {
+		return nil, err
+	}
+	return &Verifier{key: key, client: client, tokens: map[string]string{}}, nil
+}
+
+// ParsePublicKey decodes a PEM-encoded ECDSA public key.
+func ParsePublicKey(keyPEM []byte) (*ecdsa.PublicKey, error) {
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, errors.New("no PEM-encoded public key found")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key: %s", err)
+	}
+	ecdsaKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported public key type %T; expected an ECDSA key", key)
+	}
+	return ecdsaKey, nil
+}
+
+// Reference is a parsed image reference, such as
+// gcr.io/linkerd-io/proxy:stable-2.1.0.
+type Reference struct {
+	Registry   string
+	Repository string
+	// Tag is the tag of the image, or its digest if the reference is pinned
+	// to one
+	Tag string
+}
+
+// ParseReference parses an image reference, defaulting to the Docker Hub
+// registry and the latest tag, as the container runtimes do.
+func ParseReference(image string) (*Reference, error) {
+	if image == "" {
+		return nil, errors.New("empty image reference")
+	}
+
+	ref := &Reference{Registry: defaultRegistry}
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		ref.Tag = name[i+1:]
+		name = name[:i]
+	} else if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		ref.Tag = name[i+1:]
+		name = name[:i]
+	} else {
+		ref.Tag = "latest"
+	}
+
+	parts := strings.SplitN(name, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		ref.Registry = parts[0]
+		name = parts[1]
+	}
+	if ref.Registry == defaultRegistry && !strings.Contains(name, "/") {
+		name = "library/" + name
+	}
+	ref.Repository = name
+
+	if ref.Repository == "" || ref.Tag == "" {
+		return nil, fmt.Errorf("invalid image reference %s", image)
+	}
+	return ref, nil
+}
+
+// PinDigest returns the image reference pinned to digest, replacing its tag or
+// digest, e.g. gcr.io/linkerd-io/proxy@sha256:... for
+// gcr.io/linkerd-io/proxy:stable-2.1.0.
+func PinDigest(image, digest string) string {
+	name := image
+	if i := strings.Index(name, "@"); i >= 0 {
+		name = name[:i]
+	} else if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+	return name + "@" + digest
+}
+
+// Verify checks that the image has a signature made with the verifier's key,
+// and returns the digest of the verified image.
+func (v *Verifier) Verify(image string) (string, error) {
+	ref, err := ParseReference(image)
+	if err != nil {
+		return "", err
+	}
+
+	digest, err := v.manifestDigest(ref)
+	if err != nil {
+		return "", err
+	}
+
+	var manifest struct {
+		Layers []struct {
+			Digest      string            `json:"digest"`
+			Annotations map[string]string `json:"annotations"`
+		} `json:"layers"`
+	}
+	signatureTag := strings.Replace(digest, ":", "-", 1) + ".sig"
+	body, status, err := v.get(ref, "/manifests/"+signatureTag, manifestMediaTypes)
+	if err != nil {
+		return "", err
+	}
+	if status == http.StatusNotFound {
+		return "", ErrNoSignature
+	}
+	if status != http.StatusOK {
+		return "", fmt.Errorf("failed to read the signature manifest: %s", http.StatusText(status))
+	}
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return "", fmt.Errorf("invalid signature manifest: %s", err)
+	}
+
+	lastErr := ErrNoSignature
+	for _, layer := range manifest.Layers {
+		signature, ok := layer.Annotations[SignatureAnnotation]
+		if !ok {
+			continue
+		}
+		payload, status, err := v.get(ref, "/blobs/"+layer.Digest, nil)
+		if err != nil {
+			return "", err
+		}
+		if status != http.StatusOK {
+			lastErr = fmt.Errorf("failed to read the signed payload: %s", http.StatusText(status))
+			continue
+		}
+		if sha256Digest(payload) != layer.Digest {
+			lastErr = fmt.Errorf("signed payload doesn't match its digest %s", layer.Digest)
+			continue
+		}
+		if lastErr = VerifyPayload(v.key, payload, signature, digest); lastErr == nil {
+			return digest, nil
+		}
+	}
+	return "", lastErr
+}
+
+// VerifyPayload checks that signature is a valid base64-encoded signature of
+// payload, and that payload is the signed description of the image with the
+// given digest.
+func VerifyPayload(key *ecdsa.PublicKey, payload []byte, signature, digest string) error {
+	der, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature encoding: %s", err)
+	}
+	var sig struct {
+		R, S *big.Int
+	}
+	if _, err := asn1.Unmarshal(der, &sig); err != nil {
+		return fmt.Errorf("invalid signature: %s", err)
+	}
+	hash := sha256.Sum256(payload)
+	if !ecdsa.Verify(key, hash[:], sig.R, sig.S) {
+		return errors.New("signature doesn't match the public key")
+	}
+
+	var simpleSigning struct {
+		Critical struct {
+			Image struct {
+				DockerManifestDigest string `json:"docker-manifest-digest"`
+			} `json:"image"`
+			Type string `json:"type"`
+		} `json:"critical"`
+	}
+	if err := json.Unmarshal(payload, &simpleSigning); err != nil {
+		return fmt.Errorf("invalid signed payload: %s", err)
+	}
+	if simpleSigning.Critical.Type != signatureType {
+		return fmt.Errorf("unexpected signed payload type %s", simpleSigning.Critical.Type)
+	}
+	if simpleSigning.Critical.Image.DockerManifestDigest != digest {
+		return fmt.Errorf("signature is for image %s, not %s", simpleSigning.Critical.Image.DockerManifestDigest, digest)
+	}
+	return nil
+}
+
+// manifestDigest returns the digest of the manifest the reference points to.
+func (v *Verifier) manifestDigest(ref *Reference) (string, error) {
+	body, status, err := v.get(ref, "/manifests/"+ref.Tag, manifestMediaTypes)
+	if err != nil {
+		return "", err
+	}
+	if status == http.StatusNotFound {
+		return "", errors.New("image not found")
+	}
+	if status != http.StatusOK {
+		return "", fmt.Errorf("failed to read the image manifest: %s", http.StatusText(status))
+	}
+
+	digest := sha256Digest(body)
+	if strings.HasPrefix(ref.Tag, "sha256:") && ref.Tag != digest {
+		return "", fmt.Errorf("image manifest doesn't match its digest %s", ref.Tag)
+	}
+	return digest, nil
+}
+
+// get reads the given path of the repository of ref from its registry,
+// authenticating with an anonymous token if the registry asks for one.
+func (v *Verifier) get(ref *Reference, path string, accept []string) ([]byte, int, error) {
+	u := fmt.Sprintf("https://%s/v2/%s%s", ref.Registry, ref.Repository, path)
+	tokenKey := ref.Registry + "/" + ref.Repository
+
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequest("GET", u, nil)
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(accept) > 0 {
+			req.Header.Set("Accept", strings.Join(accept, ","))
+		}
+		if token, ok := v.tokens[tokenKey]; ok {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		rsp, err := v.client.Do(req)
+		if err != nil {
+			return nil, 0, err
+		}
+		body, err := ioutil.ReadAll(rsp.Body)
+		rsp.Body.Close()
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if rsp.StatusCode != http.StatusUnauthorized || attempt > 0 {
+			return body, rsp.StatusCode, nil
+		}
+		token, err := v.token(rsp.Header.Get("WWW-Authenticate"), ref.Repository)
+		if err != nil {
+			return nil, 0, err
+		}
+		v.tokens[tokenKey] = token
+	}
+}
+
+// token requests an anonymous pull token for the repository from the token
+// service described by the WWW-Authenticate challenge.
+func (v *Verifier) token(challenge, repository string) (string, error) {
+	if !strings.HasPrefix(challenge, "Bearer ") {
+		return "", fmt.Errorf("unsupported registry authentication: %s", challenge)
+	}
+	params := map[string]string{}
+	for _, param := range strings.Split(strings.TrimPrefix(challenge, "Bearer "), ",") {
+		kv := strings.SplitN(strings.TrimSpace(param), "=", 2)
+		if len(kv) == 2 {
+			params[kv[0]] = strings.Trim(kv[1], `"`)
+		}
+	}
+	if params["realm"] == "" {
+		return "", fmt.Errorf("registry authentication challenge without a realm: %s", challenge)
+	}
+
+	query := url.Values{}
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	query.Set("scope", fmt.Sprintf("repository:%s:pull", repository))
+	rsp, err := v.client.Get(params["realm"] + "?" + query.Encode())
+	if err != nil {
+		return "", err
+	}
+	defer rsp.Body.Close()
+	if rsp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get a registry token: %s", rsp.Status)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(rsp.Body).Decode(&token); err != nil {
+		return "", err
+	}
+	if token.Token != "" {
+		return token.Token, nil
+	}
+	return token.AccessToken, nil
+}
+
+func sha256Digest(b []byte) string {
+	sum := sha256.Sum256(b)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
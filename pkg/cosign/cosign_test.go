@@ -0,0 +1,194 @@
+package cosign
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestParseReference(t *testing.T) {
+	expectations := map[string]Reference{
+		"gcr.io/linkerd-io/proxy:stable-2.1.0": {"gcr.io", "linkerd-io/proxy", "stable-2.1.0"},
+		"localhost:5000/proxy":                 {"localhost:5000", "proxy", "latest"},
+		"prom/prometheus:v2.4.0":               {"registry-1.docker.io", "prom/prometheus", "v2.4.0"},
+		"nginx":                                {"registry-1.docker.io", "library/nginx", "latest"},
+		"gcr.io/linkerd-io/proxy@sha256:abcd":  {"gcr.io", "linkerd-io/proxy", "sha256:abcd"},
+	}
+
+	for image, expected := range expectations {
+		ref, err := ParseReference(image)
+		if err != nil {
+			t.Fatalf("Unexpected error parsing %s: %s", image, err)
+		}
+		if *ref != expected {
+			t.Fatalf("Expected %s to parse as %+v, got %+v", image, expected, *ref)
+		}
+	}
+}
+
+func TestPinDigest(t *testing.T) {
+	expectations := map[string]string{
+		"gcr.io/linkerd-io/proxy:stable-2.1.0": "gcr.io/linkerd-io/proxy@sha256:1234",
+		"localhost:5000/proxy":                 "localhost:5000/proxy@sha256:1234",
+		"gcr.io/linkerd-io/proxy@sha256:abcd":  "gcr.io/linkerd-io/proxy@sha256:1234",
+	}
+
+	for image, expected := range expectations {
+		if pinned := PinDigest(image, "sha256:1234"); pinned != expected {
+			t.Fatalf("Expected %s to be pinned as %s, got %s", image, expected, pinned)
+		}
+	}
+}
+
+// fakeRegistry serves a single repository holding one image and, optionally,
+// its signature.
+type fakeRegistry struct {
+	manifests map[string][]byte
+	blobs     map[string][]byte
+}
+
+func (r *fakeRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	prefix := "/v2/linkerd-io/proxy/"
+	if !strings.HasPrefix(req.URL.Path, prefix) {
+		http.NotFound(w, req)
+		return
+	}
+	path := strings.TrimPrefix(req.URL.Path, prefix)
+
+	var content []byte
+	switch {
+	case strings.HasPrefix(path, "manifests/"):
+		content = r.manifests[strings.TrimPrefix(path, "manifests/")]
+	case strings.HasPrefix(path, "blobs/"):
+		content = r.blobs[strings.TrimPrefix(path, "blobs/")]
+	}
+	if content == nil {
+		http.NotFound(w, req)
+		return
+	}
+	w.Write(content)
+}
+
+func sign(t *testing.T, key *ecdsa.PrivateKey, payload []byte) string {
+	hash := sha256.Sum256(payload)
+	sig, err := key.Sign(rand.Reader, hash[:], nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	return base64.StdEncoding.EncodeToString(sig)
+}
+
+func newSignedRegistry(t *testing.T, key *ecdsa.PrivateKey) *fakeRegistry {
+	image := []byte(`{"schemaVersion":2,"layers":[]}`)
+	digest := sha256Digest(image)
+	payload := []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"gcr.io/linkerd-io/proxy"},"image":{"docker-manifest-digest":"%s"},"type":"cosign container image signature"},"optional":null}`, digest))
+	payloadDigest := sha256Digest(payload)
+
+	signature, err := json.Marshal(map[string]interface{}{
+		"schemaVersion": 2,
+		"layers": []map[string]interface{}{{
+			"digest":      payloadDigest,
+			"annotations": map[string]string{SignatureAnnotation: sign(t, key, payload)},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	return &fakeRegistry{
+		manifests: map[string][]byte{
+			"stable-2.1.0": image,
+			strings.Replace(digest, ":", "-", 1) + ".sig": signature,
+		},
+		blobs: map[string][]byte{payloadDigest: payload},
+	}
+}
+
+func publicKeyPEM(t *testing.T, key *ecdsa.PrivateKey) []byte {
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func TestVerify(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	registry := newSignedRegistry(t, key)
+	server := httptest.NewTLSServer(registry)
+	defer server.Close()
+	image := strings.TrimPrefix(server.URL, "https://") + "/linkerd-io/proxy:stable-2.1.0"
+
+	t.Run("Verifies an image signed with the key", func(t *testing.T) {
+		verifier, err := NewVerifier(publicKeyPEM(t, key), server.Client())
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		digest, err := verifier.Verify(image)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if digest != sha256Digest(registry.manifests["stable-2.1.0"]) {
+			t.Fatalf("Unexpected digest %s", digest)
+		}
+	})
+
+	t.Run("Rejects an image signed with another key", func(t *testing.T) {
+		verifier, err := NewVerifier(publicKeyPEM(t, otherKey), server.Client())
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		_, err = verifier.Verify(image)
+		if err == nil || err.Error() != "signature doesn't match the public key" {
+			t.Fatalf("Expected a signature mismatch, got %v", err)
+		}
+	})
+
+	t.Run("Rejects an unsigned image", func(t *testing.T) {
+		unsigned := &fakeRegistry{manifests: map[string][]byte{"stable-2.1.0": []byte(`{}`)}}
+		server := httptest.NewTLSServer(unsigned)
+		defer server.Close()
+
+		verifier, err := NewVerifier(publicKeyPEM(t, key), server.Client())
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		_, err = verifier.Verify(strings.TrimPrefix(server.URL, "https://") + "/linkerd-io/proxy:stable-2.1.0")
+		if err != ErrNoSignature {
+			t.Fatalf("Expected %s, got %v", ErrNoSignature, err)
+		}
+	})
+}
+
+func TestVerifyPayloadDigest(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	// a valid signature of another image must not verify this one
+	payload := []byte(`{"critical":{"image":{"docker-manifest-digest":"sha256:1111"},"type":"cosign container image signature"}}`)
+	err = VerifyPayload(&key.PublicKey, payload, sign(t, key, payload), "sha256:2222")
+	expected := "signature is for image sha256:1111, not sha256:2222"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("Expected error [%s], got [%v]", expected, err)
+	}
+}
@@ -2,18 +2,23 @@ package healthcheck
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/linkerd/linkerd2/controller/api/public"
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
 	spclient "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned"
 	healthcheckPb "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/k8s"
 	"github.com/linkerd/linkerd2/pkg/profiles"
 	"github.com/linkerd/linkerd2/pkg/version"
+	log "github.com/sirupsen/logrus"
+	appsv1 "k8s.io/api/apps/v1"
 	authorizationapi "k8s.io/api/authorization/v1beta1"
 	"k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -56,11 +61,58 @@ const (
 	// and ShouldCheckDataPlaneVersion options are false.
 	LinkerdVersionChecks
 
+	// LinkerdCNIPluginChecks adds a check to validate that the linkerd-cni
+	// plugin is ready on all nodes.
+	// This check is dependent on the output of KubernetesAPIChecks, so those
+	// checks must be added first.
+	LinkerdCNIPluginChecks
+
+	// LinkerdOpenShiftChecks adds a series of checks to validate that the
+	// SecurityContextConstraints rendered by `linkerd install --openshift` exist
+	// and allow the proxy to run, along with proxy-init unless the CNIEnabled
+	// option is true.
+	// These checks are dependent on the output of KubernetesAPIChecks, so those
+	// checks must be added first.
+	LinkerdOpenShiftChecks
+
 	KubernetesAPICategory     = "kubernetes-api"
 	LinkerdPreInstallCategory = "kubernetes-setup"
 	LinkerdDataPlaneCategory  = "linkerd-data-plane"
 	LinkerdAPICategory        = "linkerd-api"
 	LinkerdVersionCategory    = "linkerd-version"
+	LinkerdCNIPluginCategory  = "linkerd-cni-plugin"
+	LinkerdOpenShiftCategory  = "linkerd-openshift"
+)
+
+const (
+	// CNIPluginDaemonSetName is the name of the DaemonSet rendered by
+	// `linkerd install-cni`.
+	CNIPluginDaemonSetName = "linkerd-cni"
+
+	// DefaultCNIPluginNamespace is the namespace in which `linkerd install-cni`
+	// installs the CNI plugin, unless told otherwise.
+	DefaultCNIPluginNamespace = "linkerd-cni"
+
+	// cniValidationTimeout bounds the time the CNI validation pod takes to
+	// be scheduled and complete.
+	cniValidationTimeout  = 1 * time.Minute
+	cniValidationInterval = 2 * time.Second
+)
+
+const (
+	// ViaAuto reaches the public API through the Kubernetes API's service
+	// proxy, and falls back to a port-forward to the controller pod when the
+	// API server can't reach the service.
+	ViaAuto = "auto"
+
+	// ViaDirect only reaches the public API through the service proxy.
+	ViaDirect = "direct"
+
+	// ViaPortForward only reaches the public API through a port-forward to the
+	// controller pod.
+	ViaPortForward = "portforward"
+
+	publicAPIPort = 8085
 )
 
 var (
@@ -113,15 +165,22 @@ type checkObserver func(*CheckResult)
 type HealthCheckOptions struct {
 	ControlPlaneNamespace          string
 	DataPlaneNamespace             string
+	CNIPluginNamespace             string
 	KubeConfig                     string
 	KubeContext                    string
+	Impersonate                    string
+	ImpersonateGroup               []string
 	APIAddr                        string
+	APIVia                         string
 	VersionOverride                string
 	RetryDeadline                  time.Time
 	ShouldCheckKubeVersion         bool
 	ShouldCheckControlPlaneVersion bool
 	ShouldCheckDataPlaneVersion    bool
 	SingleNamespace                bool
+	OpenShift                      bool
+	CNIEnabled                     bool
+	ProxyUID                       int64
 }
 
 type HealthChecker struct {
@@ -136,6 +195,7 @@ type HealthChecker struct {
 	kubeVersion      *k8sVersion.Info
 	controlPlanePods []v1.Pod
 	apiClient        pb.ApiClient
+	portForward      *k8s.PortForward
 	latestVersion    string
 }
 
@@ -157,6 +217,10 @@ func NewHealthChecker(checks []Checks, options *HealthCheckOptions) *HealthCheck
 			hc.addLinkerdAPIChecks()
 		case LinkerdVersionChecks:
 			hc.addLinkerdVersionChecks()
+		case LinkerdCNIPluginChecks:
+			hc.addLinkerdCNIPluginChecks()
+		case LinkerdOpenShiftChecks:
+			hc.addLinkerdOpenShiftChecks()
 		}
 	}
 
@@ -169,7 +233,7 @@ func (hc *HealthChecker) addKubernetesAPIChecks() {
 		description: "can initialize the client",
 		fatal:       true,
 		check: func() (err error) {
-			hc.kubeAPI, err = k8s.NewAPI(hc.KubeConfig, hc.KubeContext)
+			hc.kubeAPI, err = k8s.NewAPI(hc.KubeConfig, hc.KubeContext, hc.Impersonate, hc.ImpersonateGroup)
 			return
 		},
 	})
@@ -285,6 +349,16 @@ func (hc *HealthChecker) addLinkerdPreInstallChecks() {
 			return hc.checkCanCreate(hc.ControlPlaneNamespace, "apiextensions.k8s.io", "v1beta1", "CustomResourceDefinition")
 		},
 	})
+
+	if hc.OpenShift {
+		hc.checkers = append(hc.checkers, &checker{
+			category:    LinkerdPreInstallCategory,
+			description: "can create SecurityContextConstraints",
+			check: func() error {
+				return hc.checkCanCreate("", "security.openshift.io", "v1", "SecurityContextConstraints")
+			},
+		})
+	}
 }
 
 func (hc *HealthChecker) addLinkerdAPIChecks() {
@@ -317,9 +391,12 @@ func (hc *HealthChecker) addLinkerdAPIChecks() {
 		description: "can initialize the client",
 		fatal:       true,
 		check: func() (err error) {
-			if hc.APIAddr != "" {
+			switch {
+			case hc.APIAddr != "":
 				hc.apiClient, err = public.NewInternalClient(hc.ControlPlaneNamespace, hc.APIAddr)
-			} else {
+			case hc.APIVia == ViaPortForward:
+				hc.apiClient, err = hc.portForwardedAPIClient()
+			default:
 				hc.apiClient, err = public.NewExternalClient(hc.ControlPlaneNamespace, hc.kubeAPI)
 			}
 			return
@@ -332,9 +409,19 @@ func (hc *HealthChecker) addLinkerdAPIChecks() {
 		fatal:         true,
 		retryDeadline: hc.RetryDeadline,
 		checkRPC: func() (*healthcheckPb.SelfCheckResponse, error) {
-			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-			defer cancel()
-			return hc.apiClient.SelfCheck(ctx, &healthcheckPb.SelfCheckRequest{})
+			rsp, err := hc.selfCheck()
+			if err == nil || !hc.canFallBackToPortForward() {
+				return rsp, err
+			}
+
+			log.Debugf("Linkerd API unreachable through the Kubernetes API, falling back to a port-forward: %s", err)
+			client, pfErr := hc.portForwardedAPIClient()
+			if pfErr != nil {
+				log.Debugf("Port-forward to the Linkerd API failed: %s", pfErr)
+				return rsp, err
+			}
+			hc.apiClient = client
+			return hc.selfCheck()
 		},
 	})
 
@@ -348,6 +435,236 @@ func (hc *HealthChecker) addLinkerdAPIChecks() {
 	})
 }
 
+func (hc *HealthChecker) selfCheck() (*healthcheckPb.SelfCheckResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return hc.apiClient.SelfCheck(ctx, &healthcheckPb.SelfCheckRequest{})
+}
+
+// canFallBackToPortForward returns true if the public API client can be
+// replaced by one connected through a port-forward.
+func (hc *HealthChecker) canFallBackToPortForward() bool {
+	return hc.APIAddr == "" && (hc.APIVia == "" || hc.APIVia == ViaAuto) && hc.portForward == nil
+}
+
+// portForwardedAPIClient starts a port-forward to a running controller pod, and
+// returns a public API client connected through it.
+func (hc *HealthChecker) portForwardedAPIClient() (pb.ApiClient, error) {
+	podName, err := runningControllerPod(hc.controlPlanePods)
+	if err != nil {
+		return nil, err
+	}
+
+	pf, err := hc.kubeAPI.NewPortForward(hc.ControlPlaneNamespace, podName, publicAPIPort)
+	if err != nil {
+		return nil, err
+	}
+	go pf.Run()
+	hc.portForward = pf
+
+	return public.NewInternalClient(hc.ControlPlaneNamespace, pf.Addr())
+}
+
+func runningControllerPod(pods []v1.Pod) (string, error) {
+	for _, pod := range pods {
+		if pod.Labels[k8s.ControllerComponentLabel] == "controller" && pod.Status.Phase == v1.PodRunning {
+			return pod.Name, nil
+		}
+	}
+	return "", fmt.Errorf("No running pods for \"linkerd-controller\"")
+}
+
+func (hc *HealthChecker) addLinkerdCNIPluginChecks() {
+	var ds appsv1.DaemonSet
+
+	hc.checkers = append(hc.checkers, &checker{
+		category:      LinkerdCNIPluginCategory,
+		description:   "cni plugin is ready on all nodes",
+		retryDeadline: hc.RetryDeadline,
+		fatal:         true,
+		check: func() error {
+			path := fmt.Sprintf("/apis/apps/v1/namespaces/%s/daemonsets/%s", hc.CNIPluginNamespace, CNIPluginDaemonSetName)
+			found, err := hc.kubeAPI.GetObject(hc.httpClient, path, &ds)
+			if err != nil {
+				return err
+			}
+			if !found {
+				return fmt.Errorf("The \"%s\" DaemonSet does not exist; install it with: linkerd install-cni", CNIPluginDaemonSetName)
+			}
+			return validateCNIPluginDaemonSet(&ds)
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		category:    LinkerdCNIPluginCategory,
+		description: "cni plugin redirects the traffic of meshed pods",
+		check: func() error {
+			if len(ds.Spec.Template.Spec.Containers) == 0 {
+				return fmt.Errorf("The \"%s\" DaemonSet has no containers", CNIPluginDaemonSetName)
+			}
+			rules, err := hc.runCNIValidationPod(ds.Spec.Template.Spec.Containers[0].Image)
+			if err != nil {
+				return err
+			}
+			return validateCNIRedirectRules(rules)
+		},
+	})
+}
+
+// runCNIValidationPod runs a pod with a proxy container and no proxy-init
+// container, which the CNI plugin sets up the iptables rules of, and returns
+// the rules of its nat table. The pod is deleted once it completes.
+func (hc *HealthChecker) runCNIValidationPod(image string) (string, error) {
+	pod := cniValidationPod(hc.CNIPluginNamespace, fmt.Sprintf("linkerd-cni-validation-%d", time.Now().Unix()), image)
+	body, err := json.Marshal(pod)
+	if err != nil {
+		return "", err
+	}
+	podsPath := fmt.Sprintf("/api/v1/namespaces/%s/pods", pod.Namespace)
+	if err := hc.kubeAPI.CreateObject(hc.httpClient, podsPath, body); err != nil {
+		return "", err
+	}
+	podPath := podsPath + "/" + pod.Name
+	defer hc.kubeAPI.DeleteObject(hc.httpClient, podPath)
+
+	deadline := time.Now().Add(cniValidationTimeout)
+	for {
+		found, err := hc.kubeAPI.GetObject(hc.httpClient, podPath, pod)
+		if err != nil {
+			return "", err
+		}
+		if !found {
+			return "", fmt.Errorf("The CNI validation pod %s/%s was deleted before it completed", pod.Namespace, pod.Name)
+		}
+		if pod.Status.Phase == v1.PodSucceeded || pod.Status.Phase == v1.PodFailed {
+			break
+		}
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("The CNI validation pod %s/%s didn't complete within %s", pod.Namespace, pod.Name, cniValidationTimeout)
+		}
+		time.Sleep(cniValidationInterval)
+	}
+
+	if pod.Status.Phase == v1.PodFailed || len(pod.Status.ContainerStatuses) == 0 || pod.Status.ContainerStatuses[0].State.Terminated == nil {
+		return "", fmt.Errorf("The CNI validation pod %s/%s failed to list its iptables rules", pod.Namespace, pod.Name)
+	}
+	return pod.Status.ContainerStatuses[0].State.Terminated.Message, nil
+}
+
+// cniValidationPod returns a pod that lists the iptables rules of its nat table
+// to its termination log. It runs the image of the CNI plugin, which contains
+// iptables, in a container named like the proxy so that the plugin redirects
+// its traffic.
+func cniValidationPod(namespace, name, image string) *v1.Pod {
+	return &v1.Pod{
+		TypeMeta: meta_v1.TypeMeta{Kind: "Pod", APIVersion: "v1"},
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{k8s.ControllerComponentLabel: "cni-validation"},
+		},
+		Spec: v1.PodSpec{
+			RestartPolicy: v1.RestartPolicyNever,
+			Containers: []v1.Container{{
+				Name:    k8s.ProxyContainerName,
+				Image:   image,
+				Command: []string{"sh", "-c", "iptables-save -t nat > /dev/termination-log"},
+				SecurityContext: &v1.SecurityContext{
+					Capabilities: &v1.Capabilities{Add: []v1.Capability{"NET_ADMIN"}},
+				},
+			}},
+		},
+	}
+}
+
+// validateCNIRedirectRules returns an error if the iptables rules listed by
+// the CNI validation pod don't redirect its traffic to the proxy.
+func validateCNIRedirectRules(rules string) error {
+	for _, chain := range []string{"PROXY_INIT_REDIRECT", "PROXY_INIT_OUTPUT"} {
+		if !strings.Contains(rules, "-j "+chain) {
+			return fmt.Errorf("The CNI plugin didn't redirect the traffic of the validation pod: the %s iptables chain isn't installed; check the kubelet logs for the linkerd-cni plugin errors", chain)
+		}
+	}
+	return nil
+}
+
+func validateCNIPluginDaemonSet(ds *appsv1.DaemonSet) error {
+	if ds.Status.NumberReady < ds.Status.DesiredNumberScheduled {
+		return fmt.Errorf("The \"%s\" DaemonSet is ready on %d of %d nodes",
+			ds.Name, ds.Status.NumberReady, ds.Status.DesiredNumberScheduled)
+	}
+	return nil
+}
+
+// securityContextConstraints is the subset of an OpenShift
+// SecurityContextConstraints that determines whether Linkerd's containers can
+// run under it.
+type securityContextConstraints struct {
+	Name                     string   `json:"-"`
+	AllowPrivilegedContainer bool     `json:"allowPrivilegedContainer"`
+	AllowedCapabilities      []string `json:"allowedCapabilities"`
+	RunAsUser                struct {
+		Type        string `json:"type"`
+		UIDRangeMin *int64 `json:"uidRangeMin"`
+		UIDRangeMax *int64 `json:"uidRangeMax"`
+	} `json:"runAsUser"`
+}
+
+func (hc *HealthChecker) addLinkerdOpenShiftChecks() {
+	var scc securityContextConstraints
+	scc.Name = "linkerd-" + hc.ControlPlaneNamespace
+
+	hc.checkers = append(hc.checkers, &checker{
+		category:    LinkerdOpenShiftCategory,
+		description: "control plane SecurityContextConstraints exist",
+		fatal:       true,
+		check: func() error {
+			found, err := hc.kubeAPI.GetObject(hc.httpClient, "/apis/security.openshift.io/v1/securitycontextconstraints/"+scc.Name, &scc)
+			if err != nil {
+				return err
+			}
+			if !found {
+				return fmt.Errorf("The \"%s\" SecurityContextConstraints do not exist; render them with: linkerd install --openshift", scc.Name)
+			}
+			return nil
+		},
+	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		category:    LinkerdOpenShiftCategory,
+		description: "SecurityContextConstraints allow the proxy to run",
+		check: func() error {
+			return validateSecurityContextConstraints(&scc, hc.ProxyUID, !hc.CNIEnabled)
+		},
+	})
+}
+
+// validateSecurityContextConstraints returns an error if the proxy can't run
+// under its UID with the given SCC, or, if proxyInit is true, if proxy-init
+// can't set up the iptables rules.
+func validateSecurityContextConstraints(scc *securityContextConstraints, proxyUID int64, proxyInit bool) error {
+	if scc.RunAsUser.Type == "MustRunAsRange" || scc.RunAsUser.Type == "MustRunAs" {
+		min, max := scc.RunAsUser.UIDRangeMin, scc.RunAsUser.UIDRangeMax
+		if min == nil || max == nil || proxyUID < *min || proxyUID > *max {
+			return fmt.Errorf("The \"%s\" SecurityContextConstraints don't allow the proxy UID %d; use --proxy-uid with a UID in the allowed range", scc.Name, proxyUID)
+		}
+	}
+
+	if !proxyInit {
+		return nil
+	}
+
+	if !scc.AllowPrivilegedContainer {
+		return fmt.Errorf("The \"%s\" SecurityContextConstraints don't allow privileged containers, required by proxy-init; allow them, or install the linkerd-cni plugin", scc.Name)
+	}
+	for _, capability := range scc.AllowedCapabilities {
+		if capability == "NET_ADMIN" || capability == "*" {
+			return nil
+		}
+	}
+	return fmt.Errorf("The \"%s\" SecurityContextConstraints don't allow the NET_ADMIN capability, required by proxy-init; allow it, or install the linkerd-cni plugin", scc.Name)
+}
+
 func (hc *HealthChecker) addLinkerdDataPlaneChecks() {
 	if hc.DataPlaneNamespace != "" {
 		hc.checkers = append(hc.checkers, &checker{
@@ -388,6 +705,45 @@ func (hc *HealthChecker) addLinkerdDataPlaneChecks() {
 			return validateDataPlanePodReporting(pods)
 		},
 	})
+
+	hc.checkers = append(hc.checkers, &checker{
+		category:    LinkerdDataPlaneCategory,
+		description: "no Windows workloads are excluded from the mesh",
+		warning:     true,
+		check: func() error {
+			var pods []v1.Pod
+			var err error
+			if hc.DataPlaneNamespace != "" {
+				pods, err = hc.kubeAPI.GetPodsByNamespace(hc.httpClient, hc.DataPlaneNamespace)
+			} else {
+				pods, err = hc.kubeAPI.GetAllPods(hc.httpClient)
+			}
+			if err != nil {
+				return err
+			}
+
+			return validateWindowsPods(pods)
+		},
+	})
+}
+
+// validateWindowsPods returns an error listing the pods that the proxy
+// injector skipped because they run on Windows nodes, where the proxy can't
+// run.
+func validateWindowsPods(pods []v1.Pod) error {
+	skipped := []string{}
+	for _, pod := range pods {
+		if pod.Annotations[k8s.ProxyInjectStatusAnnotation] == k8s.ProxyInjectSkippedWindows {
+			skipped = append(skipped, fmt.Sprintf("%s/%s", pod.Namespace, pod.Name))
+		}
+	}
+	if len(skipped) == 0 {
+		return nil
+	}
+
+	sort.Strings(skipped)
+	return fmt.Errorf("The proxy injector skipped %d pod(s) running on Windows nodes, which aren't meshed: %s",
+		len(skipped), strings.Join(skipped, ", "))
 }
 
 func (hc *HealthChecker) addLinkerdVersionChecks() {
@@ -639,6 +995,41 @@ func (hc *HealthChecker) checkCanCreate(namespace, group, version, resource stri
 	return nil
 }
 
+// validateServiceProfileName checks that a profile is named after an existing
+// service, or after an authority outside of the cluster, e.g. api.stripe.com.
+// The names with a "svc" label are the names of services.
+func (hc *HealthChecker) validateServiceProfileName(name string) error {
+	nameParts := strings.Split(name, ".")
+	isService := false
+	for _, part := range nameParts {
+		if part == clusterZoneSuffix[0] {
+			isService = true
+		}
+	}
+	if !isService {
+		if err := profiles.ValidateExternalAuthority(name); err != nil {
+			return fmt.Errorf("ServiceProfile \"%s\" has invalid name (must be \"<service>.<namespace>.svc.cluster.local\" or an external DNS name): %s", name, err)
+		}
+		return nil
+	}
+
+	if len(nameParts) != 2+len(clusterZoneSuffix) {
+		return fmt.Errorf("ServiceProfile \"%s\" has invalid name (must be \"<service>.<namespace>.svc.cluster.local\")", name)
+	}
+	for i, part := range nameParts[2:] {
+		if part != clusterZoneSuffix[i] {
+			return fmt.Errorf("ServiceProfile \"%s\" has invalid name (must be \"<service>.<namespace>.svc.cluster.local\")", name)
+		}
+	}
+	service := nameParts[0]
+	namespace := nameParts[1]
+	_, err := hc.clientset.Core().Services(namespace).Get(service, meta_v1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("ServiceProfile \"%s\" has unknown service: %s", name, err)
+	}
+	return nil
+}
+
 func (hc *HealthChecker) validateServiceProfiles() error {
 	if hc.clientset == nil {
 		var err error
@@ -661,21 +1052,34 @@ func (hc *HealthChecker) validateServiceProfiles() error {
 		return err
 	}
 
-	for _, p := range svcProfiles.Items {
-		nameParts := strings.Split(p.Name, ".")
-		if len(nameParts) != 2+len(clusterZoneSuffix) {
-			return fmt.Errorf("ServiceProfile \"%s\" has invalid name (must be \"<service>.<namespace>.svc.cluster.local\")", p.Name)
+	// the profiles by name, to look up the base profiles, which don't have to
+	// be named after a service
+	profilesByName := make(map[string]*sp.ServiceProfile)
+	baseProfiles := make(map[string]bool)
+	for i := range svcProfiles.Items {
+		profilesByName[svcProfiles.Items[i].Name] = &svcProfiles.Items[i]
+		if base := svcProfiles.Items[i].Spec.BaseProfile; base != "" {
+			baseProfiles[base] = true
+		}
+	}
+	getProfile := func(name string) (*sp.ServiceProfile, error) {
+		profile, ok := profilesByName[name]
+		if !ok {
+			return nil, fmt.Errorf("ServiceProfile \"%s\" not found", name)
 		}
-		for i, part := range nameParts[2:] {
-			if part != clusterZoneSuffix[i] {
-				return fmt.Errorf("ServiceProfile \"%s\" has invalid name (must be \"<service>.<namespace>.svc.cluster.local\")", p.Name)
+		return profile, nil
+	}
+
+	for _, p := range svcProfiles.Items {
+		if !baseProfiles[p.Name] {
+			if err := hc.validateServiceProfileName(p.Name); err != nil {
+				return err
 			}
 		}
-		service := nameParts[0]
-		namespace := nameParts[1]
-		_, err := hc.clientset.Core().Services(namespace).Get(service, meta_v1.GetOptions{})
-		if err != nil {
-			return fmt.Errorf("ServiceProfile \"%s\" has unknown service: %s", p.Name, err)
+		if p.Spec.BaseProfile != "" {
+			if _, _, err := profiles.MergeBaseProfiles(&p, getProfile); err != nil {
+				return err
+			}
 		}
 		for _, route := range p.Spec.Routes {
 			if route.Name == "" {
@@ -697,6 +1101,12 @@ func (hc *HealthChecker) validateServiceProfiles() error {
 					return fmt.Errorf("ServiceProfile \"%s\" has a response class with an invalid condition: %s", p.Name, err)
 				}
 			}
+			if route.SLO != nil {
+				err = profiles.ValidateSLO(route.SLO)
+				if err != nil {
+					return fmt.Errorf("ServiceProfile \"%s\" has a route with an invalid SLO: %s", p.Name, err)
+				}
+			}
 		}
 	}
 	return nil
@@ -11,6 +11,8 @@ import (
 	"github.com/linkerd/linkerd2/controller/api/public"
 	healthcheckPb "github.com/linkerd/linkerd2/controller/gen/common/healthcheck"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/k8s"
+	appsv1 "k8s.io/api/apps/v1"
 	"k8s.io/api/core/v1"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -423,3 +425,128 @@ func TestValidateDataPlanePodReporting(t *testing.T) {
 		}
 	})
 }
+
+func TestValidateCNIPluginDaemonSet(t *testing.T) {
+	t.Run("Returns success if the DaemonSet is ready on all nodes", func(t *testing.T) {
+		ds := &appsv1.DaemonSet{
+			ObjectMeta: meta.ObjectMeta{Name: "linkerd-cni"},
+			Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, NumberReady: 3},
+		}
+
+		err := validateCNIPluginDaemonSet(ds)
+		if err != nil {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+
+	t.Run("Returns an error if the DaemonSet isn't ready on some nodes", func(t *testing.T) {
+		ds := &appsv1.DaemonSet{
+			ObjectMeta: meta.ObjectMeta{Name: "linkerd-cni"},
+			Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, NumberReady: 2},
+		}
+
+		err := validateCNIPluginDaemonSet(ds)
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		if err.Error() != "The \"linkerd-cni\" DaemonSet is ready on 2 of 3 nodes" {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+}
+
+func TestValidateCNIRedirectRules(t *testing.T) {
+	t.Run("Returns success if the traffic is redirected", func(t *testing.T) {
+		rules := `*nat
+:PREROUTING ACCEPT [0:0]
+:OUTPUT ACCEPT [0:0]
+:PROXY_INIT_OUTPUT - [0:0]
+:PROXY_INIT_REDIRECT - [0:0]
+-A PREROUTING -m comment --comment "proxy-init/install-proxy-init-prerouting/1550000000" -j PROXY_INIT_REDIRECT
+-A OUTPUT -m comment --comment "proxy-init/install-proxy-init-output/1550000000" -j PROXY_INIT_OUTPUT
+COMMIT
+`
+		if err := validateCNIRedirectRules(rules); err != nil {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+
+	t.Run("Returns an error if the traffic isn't redirected", func(t *testing.T) {
+		err := validateCNIRedirectRules("*nat\n:PREROUTING ACCEPT [0:0]\nCOMMIT\n")
+		if err == nil {
+			t.Fatal("Expected error, got nothing")
+		}
+		expected := "The CNI plugin didn't redirect the traffic of the validation pod: the PROXY_INIT_REDIRECT iptables chain isn't installed; check the kubelet logs for the linkerd-cni plugin errors"
+		if err.Error() != expected {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+}
+
+func TestValidateSecurityContextConstraints(t *testing.T) {
+	uid := func(u int64) *int64 { return &u }
+
+	runAsAny := &securityContextConstraints{Name: "linkerd-linkerd", AllowPrivilegedContainer: true, AllowedCapabilities: []string{"NET_ADMIN"}}
+	runAsAny.RunAsUser.Type = "RunAsAny"
+
+	restricted := &securityContextConstraints{Name: "linkerd-linkerd"}
+	restricted.RunAsUser.Type = "MustRunAsRange"
+	restricted.RunAsUser.UIDRangeMin = uid(1000620000)
+	restricted.RunAsUser.UIDRangeMax = uid(1000629999)
+
+	testCases := []struct {
+		scc       *securityContextConstraints
+		proxyUID  int64
+		proxyInit bool
+		err       string
+	}{
+		{runAsAny, 2102, true, ""},
+		{restricted, 1000620000, false, ""},
+		{restricted, 2102, false, "The \"linkerd-linkerd\" SecurityContextConstraints don't allow the proxy UID 2102; use --proxy-uid with a UID in the allowed range"},
+		{restricted, 1000620000, true, "The \"linkerd-linkerd\" SecurityContextConstraints don't allow privileged containers, required by proxy-init; allow them, or install the linkerd-cni plugin"},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("%d", i), func(t *testing.T) {
+			err := validateSecurityContextConstraints(tc.scc, tc.proxyUID, tc.proxyInit)
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("Unexpected error message: %s", err.Error())
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Expected error, got nothing")
+			}
+			if err.Error() != tc.err {
+				t.Fatalf("Unexpected error message: %s", err.Error())
+			}
+		})
+	}
+}
+
+func TestValidateWindowsPods(t *testing.T) {
+	pod := func(namespace, name string, annotations map[string]string) v1.Pod {
+		return v1.Pod{ObjectMeta: meta.ObjectMeta{Namespace: namespace, Name: name, Annotations: annotations}}
+	}
+	skipped := map[string]string{k8s.ProxyInjectStatusAnnotation: k8s.ProxyInjectSkippedWindows}
+
+	t.Run("Returns success if no pods were skipped", func(t *testing.T) {
+		if err := validateWindowsPods([]v1.Pod{pod("emojivoto", "web", nil)}); err != nil {
+			t.Fatalf("Unexpected error message: %s", err.Error())
+		}
+	})
+
+	t.Run("Returns an error listing the skipped pods", func(t *testing.T) {
+		pods := []v1.Pod{
+			pod("emojivoto", "web", nil),
+			pod("legacy", "iis-2", skipped),
+			pod("legacy", "iis-1", skipped),
+		}
+		err := validateWindowsPods(pods)
+		expected := "The proxy injector skipped 2 pod(s) running on Windows nodes, which aren't meshed: legacy/iis-1, legacy/iis-2"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error [%s], got [%v]", expected, err)
+		}
+	})
+}
@@ -1,15 +1,18 @@
 package k8s
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"time"
 
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/version"
 	"k8s.io/client-go/rest"
 
@@ -95,6 +98,40 @@ func (kubeAPI *KubernetesAPI) GetPodsByNamespace(client *http.Client, namespace
 	return kubeAPI.getPods(client, "/api/v1/namespaces/"+namespace+"/pods")
 }
 
+// GetAllPods returns all pods in all namespaces
+func (kubeAPI *KubernetesAPI) GetAllPods(client *http.Client) ([]v1.Pod, error) {
+	return kubeAPI.getPods(client, "/api/v1/pods")
+}
+
+// GetNamespaces returns all namespaces
+func (kubeAPI *KubernetesAPI) GetNamespaces(client *http.Client) ([]v1.Namespace, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rsp, err := kubeAPI.getRequest(ctx, client, "/api/v1/namespaces")
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Unexpected Kubernetes API response: %s", rsp.Status)
+	}
+
+	bytes, err := ioutil.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var namespaceList v1.NamespaceList
+	err = json.Unmarshal(bytes, &namespaceList)
+	if err != nil {
+		return nil, err
+	}
+
+	return namespaceList.Items, nil
+}
+
 func (kubeAPI *KubernetesAPI) getPods(client *http.Client, path string) ([]v1.Pod, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -123,32 +160,159 @@ func (kubeAPI *KubernetesAPI) getPods(client *http.Client, path string) ([]v1.Po
 	return podList.Items, nil
 }
 
+// ListObjectMeta returns the metadata of the objects in the list at path, for
+// example /api/v1/namespaces/linkerd/services.
+func (kubeAPI *KubernetesAPI) ListObjectMeta(client *http.Client, path string) ([]metav1.ObjectMeta, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rsp, err := kubeAPI.getRequest(ctx, client, path)
+	if err != nil {
+		return nil, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Unexpected Kubernetes API response: %s", rsp.Status)
+	}
+
+	bytes, err := ioutil.ReadAll(rsp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var list struct {
+		Items []struct {
+			metav1.ObjectMeta `json:"metadata,omitempty"`
+		} `json:"items"`
+	}
+	err = json.Unmarshal(bytes, &list)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]metav1.ObjectMeta, len(list.Items))
+	for i, item := range list.Items {
+		objects[i] = item.ObjectMeta
+	}
+	return objects, nil
+}
+
+// DeleteObject deletes the object at path, for example
+// /api/v1/namespaces/linkerd/services/linkerd-web. Deleting an object that
+// doesn't exist is not an error.
+func (kubeAPI *KubernetesAPI) DeleteObject(client *http.Client, path string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rsp, err := kubeAPI.request(ctx, client, "DELETE", path)
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK && rsp.StatusCode != http.StatusAccepted && rsp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("Unexpected Kubernetes API response: %s", rsp.Status)
+	}
+
+	return nil
+}
+
+// GetObject reads the object at path into obj, for example
+// /api/v1/namespaces/linkerd/configmaps/linkerd-ca-bundle. It returns false if
+// the object doesn't exist.
+func (kubeAPI *KubernetesAPI) GetObject(client *http.Client, path string, obj interface{}) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rsp, err := kubeAPI.getRequest(ctx, client, path)
+	if err != nil {
+		return false, err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if rsp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("Unexpected Kubernetes API response: %s", rsp.Status)
+	}
+
+	bytes, err := ioutil.ReadAll(rsp.Body)
+	if err != nil {
+		return false, err
+	}
+
+	return true, json.Unmarshal(bytes, obj)
+}
+
+// CreateObject creates the given JSON object in the list at path, for example
+// /apis/apiextensions.k8s.io/v1beta1/customresourcedefinitions.
+func (kubeAPI *KubernetesAPI) CreateObject(client *http.Client, path string, obj []byte) error {
+	return kubeAPI.sendObject(client, "POST", path, "application/json", obj)
+}
+
+// PatchObject applies the given JSON patch to the object at path.
+func (kubeAPI *KubernetesAPI) PatchObject(client *http.Client, path string, patch []byte) error {
+	return kubeAPI.sendObject(client, "PATCH", path, "application/json-patch+json", patch)
+}
+
+func (kubeAPI *KubernetesAPI) sendObject(client *http.Client, method, path, contentType string, body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	rsp, err := kubeAPI.requestWithBody(ctx, client, method, path, contentType, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK && rsp.StatusCode != http.StatusCreated {
+		return fmt.Errorf("Unexpected Kubernetes API response: %s", rsp.Status)
+	}
+
+	return nil
+}
+
 // UrlFor generates a URL based on the Kubernetes config.
 func (kubeAPI *KubernetesAPI) UrlFor(namespace string, extraPathStartingWithSlash string) (*url.URL, error) {
 	return generateKubernetesApiBaseUrlFor(kubeAPI.Host, namespace, extraPathStartingWithSlash)
 }
 
 func (kubeAPI *KubernetesAPI) getRequest(ctx context.Context, client *http.Client, path string) (*http.Response, error) {
+	return kubeAPI.request(ctx, client, "GET", path)
+}
+
+func (kubeAPI *KubernetesAPI) request(ctx context.Context, client *http.Client, method, path string) (*http.Response, error) {
+	return kubeAPI.requestWithBody(ctx, client, method, path, "", nil)
+}
+
+func (kubeAPI *KubernetesAPI) requestWithBody(ctx context.Context, client *http.Client, method, path, contentType string, body io.Reader) (*http.Response, error) {
 	endpoint, err := url.Parse(kubeAPI.Host + path)
 	if err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequest("GET", endpoint.String(), nil)
+	req, err := http.NewRequest(method, endpoint.String(), body)
 	if err != nil {
 		return nil, err
 	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
 
 	return client.Do(req.WithContext(ctx))
 }
 
 // NewAPI validates a Kubernetes config and returns a client for accessing the
-// configured cluster
-func NewAPI(configPath, kubeContext string) (*KubernetesAPI, error) {
+// configured cluster. If impersonate is set, requests are made as that user and
+// the given groups.
+func NewAPI(configPath, kubeContext, impersonate string, impersonateGroup []string) (*KubernetesAPI, error) {
 	config, err := GetConfig(configPath, kubeContext)
 	if err != nil {
 		return nil, fmt.Errorf("error configuring Kubernetes API client: %v", err)
 	}
+	setImpersonation(config, impersonate, impersonateGroup)
 
 	return &KubernetesAPI{Config: config}, nil
 }
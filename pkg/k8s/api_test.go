@@ -11,7 +11,7 @@ func TestKubernetesApiUrlFor(t *testing.T) {
 
 	t.Run("Returns base config containing k8s endpoint listed in config.test", func(t *testing.T) {
 		expected := fmt.Sprintf("https://55.197.171.239/api/v1/namespaces/%s%s", namespace, extraPath)
-		api, err := NewAPI("testdata/config.test", "")
+		api, err := NewAPI("testdata/config.test", "", "", []string{})
 		if err != nil {
 			t.Fatalf("Unexpected error creating Kubernetes API: %+v", err)
 		}
@@ -24,3 +24,28 @@ func TestKubernetesApiUrlFor(t *testing.T) {
 		}
 	})
 }
+
+func TestNewAPIImpersonation(t *testing.T) {
+	t.Run("Sets impersonation config when a user is given", func(t *testing.T) {
+		api, err := NewAPI("testdata/config.test", "", "jane", []string{"dev", "ops"})
+		if err != nil {
+			t.Fatalf("Unexpected error creating Kubernetes API: %+v", err)
+		}
+		if api.Impersonate.UserName != "jane" {
+			t.Fatalf("Expected impersonated user to be [jane], but got [%s]", api.Impersonate.UserName)
+		}
+		if len(api.Impersonate.Groups) != 2 || api.Impersonate.Groups[0] != "dev" || api.Impersonate.Groups[1] != "ops" {
+			t.Fatalf("Expected impersonated groups to be [dev ops], but got %v", api.Impersonate.Groups)
+		}
+	})
+
+	t.Run("Does not impersonate when no user is given", func(t *testing.T) {
+		api, err := NewAPI("testdata/config.test", "", "", []string{"dev"})
+		if err != nil {
+			t.Fatalf("Unexpected error creating Kubernetes API: %+v", err)
+		}
+		if api.Impersonate.UserName != "" || len(api.Impersonate.Groups) != 0 {
+			t.Fatalf("Expected no impersonation, but got %+v", api.Impersonate)
+		}
+	})
+}
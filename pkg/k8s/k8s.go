@@ -3,6 +3,7 @@ package k8s
 import (
 	"fmt"
 	"net/url"
+	"sort"
 
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
@@ -11,6 +12,7 @@ import (
 const (
 	All                   = "all"
 	Authority             = "authority"
+	CronJob               = "cronjob"
 	DaemonSet             = "daemonset"
 	Deployment            = "deployment"
 	Job                   = "job"
@@ -29,6 +31,7 @@ const (
 // AllResources is a sorted list of all resources defined as constants above.
 var AllResources = []string{
 	Authority,
+	CronJob,
 	DaemonSet,
 	Deployment,
 	Job,
@@ -94,6 +97,69 @@ func GetConfig(fpath, kubeContext string) (*rest.Config, error) {
 		ClientConfig()
 }
 
+// GetContexts returns the sorted names of the contexts in the Kubernetes
+// configuration, loaded with the same strategy as GetConfig.
+func GetContexts(fpath string) ([]string, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if fpath != "" {
+		rules.ExplicitPath = fpath
+	}
+	config, err := clientcmd.
+		NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{}).
+		RawConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	contexts := make([]string, 0, len(config.Contexts))
+	for name := range config.Contexts {
+		contexts = append(contexts, name)
+	}
+	sort.Strings(contexts)
+	return contexts, nil
+}
+
+// GetUser returns the name of the user that requests are made as: the
+// impersonated user if one is set, or else the user of the context of the
+// Kubernetes configuration, loaded with the same strategy as GetConfig.
+func GetUser(fpath, kubeContext, impersonate string) (string, error) {
+	if impersonate != "" {
+		return impersonate, nil
+	}
+
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	if fpath != "" {
+		rules.ExplicitPath = fpath
+	}
+	config, err := clientcmd.
+		NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{}).
+		RawConfig()
+	if err != nil {
+		return "", err
+	}
+
+	if kubeContext == "" {
+		kubeContext = config.CurrentContext
+	}
+	context, ok := config.Contexts[kubeContext]
+	if !ok {
+		return "", fmt.Errorf("context %s not found in the Kubernetes configuration", kubeContext)
+	}
+	return context.AuthInfo, nil
+}
+
+// setImpersonation configures config to make requests as the impersonate user
+// and impersonateGroup groups, if a user is set.
+func setImpersonation(config *rest.Config, impersonate string, impersonateGroup []string) {
+	if impersonate == "" {
+		return
+	}
+	config.Impersonate = rest.ImpersonationConfig{
+		UserName: impersonate,
+		Groups:   impersonateGroup,
+	}
+}
+
 // CanonicalResourceNameFromFriendlyName returns a canonical name from common shorthands used in command line tools.
 // This works based on https://github.com/kubernetes/kubernetes/blob/63ffb1995b292be0a1e9ebde6216b83fc79dd988/pkg/kubectl/kubectl.go#L39
 // This also works for non-k8s resources, e.g. authorities
@@ -101,6 +167,8 @@ func CanonicalResourceNameFromFriendlyName(friendlyName string) (string, error)
 	switch friendlyName {
 	case "au", "authority", "authorities":
 		return Authority, nil
+	case "cj", "cronjob", "cronjobs":
+		return CronJob, nil
 	case "ds", "daemonset", "daemonsets":
 		return DaemonSet, nil
 	case "deploy", "deployment", "deployments":
@@ -134,6 +202,8 @@ func ShortNameFromCanonicalResourceName(canonicalName string) string {
 	switch canonicalName {
 	case Authority:
 		return "au"
+	case CronJob:
+		return "cj"
 	case DaemonSet:
 		return "ds"
 	case Deployment:
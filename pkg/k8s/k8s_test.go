@@ -1,6 +1,7 @@
 package k8s
 
 import (
+	"reflect"
 	"testing"
 )
 
@@ -88,6 +89,8 @@ func TestCanonicalResourceNameFromFriendlyName(t *testing.T) {
 			"deployments": Deployment,
 			"au":          Authority,
 			"authorities": Authority,
+			"cj":          CronJob,
+			"cronjobs":    CronJob,
 		}
 
 		for input, expectedName := range expectations {
@@ -115,3 +118,41 @@ func TestCanonicalResourceNameFromFriendlyName(t *testing.T) {
 		}
 	})
 }
+
+func TestGetContexts(t *testing.T) {
+	contexts, err := GetContexts("testdata/config.test")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"cluster1", "cluster2", "cluster3", "cluster4", "dev"}
+	if !reflect.DeepEqual(contexts, expected) {
+		t.Fatalf("Expected contexts %v, got %v", expected, contexts)
+	}
+}
+
+func TestGetUser(t *testing.T) {
+	testCases := []struct {
+		kubeContext  string
+		impersonate  string
+		expectedUser string
+	}{
+		{"", "", "cluster1"},
+		{"dev", "", "cluster3"},
+		{"dev", "alice", "alice"},
+	}
+
+	for _, tc := range testCases {
+		user, err := GetUser("testdata/config.test", tc.kubeContext, tc.impersonate)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if user != tc.expectedUser {
+			t.Fatalf("Expected user %s for context [%s], got %s", tc.expectedUser, tc.kubeContext, user)
+		}
+	}
+
+	if _, err := GetUser("testdata/config.test", "missing", ""); err == nil {
+		t.Fatalf("Expected an error for a missing context")
+	}
+}
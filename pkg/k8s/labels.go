@@ -7,10 +7,15 @@ package k8s
 
 import (
 	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/linkerd/linkerd2/pkg/version"
 	appsV1 "k8s.io/api/apps/v1"
 	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 const (
@@ -42,6 +47,10 @@ const (
 	// this proxy belongs to.
 	ProxyJobLabel = "linkerd.io/proxy-job"
 
+	// ProxyCronJobLabel is injected into mesh-enabled apps, identifying the
+	// CronJob that this proxy belongs to.
+	ProxyCronJobLabel = "linkerd.io/proxy-cronjob"
+
 	// ProxyDaemonSetLabel is injected into mesh-enabled apps, identifying the
 	// DaemonSet that this proxy belongs to.
 	ProxyDaemonSetLabel = "linkerd.io/proxy-daemonset"
@@ -62,6 +71,52 @@ const (
 	// (e.g. v0.1.3).
 	ProxyVersionAnnotation = "linkerd.io/proxy-version"
 
+	// ConfigHashAnnotation records the hash of the configuration an object of
+	// the control plane was rendered from, when installed with
+	// `linkerd install --config-hash`.
+	ConfigHashAnnotation = "linkerd.io/config-hash"
+
+	// ProxySkipOutboundCIDRsAnnotation lists the destination networks whose
+	// outbound traffic bypasses the proxy (e.g. 169.254.169.254/32,10.0.0.0/8),
+	// in addition to the outbound ports that skip it.
+	ProxySkipOutboundCIDRsAnnotation = "config.linkerd.io/skip-outbound-cidrs"
+
+	// ProxyOpaquePortsAnnotation lists the inbound ports whose traffic the
+	// proxy forwards as opaque TCP without detecting its protocol (e.g.
+	// 3306,5432). On a namespace, it sets the default of its workloads; the
+	// opaque ports of the mesh, namespace and workload are merged.
+	ProxyOpaquePortsAnnotation = "config.linkerd.io/opaque-ports"
+
+	// ProxyCPURequestAnnotation sets the CPU request of the proxy container of
+	// a workload (e.g. 50m), as recommended by `linkerd recommend`.
+	ProxyCPURequestAnnotation = "config.linkerd.io/proxy-cpu-request"
+
+	// ProxyMemoryRequestAnnotation sets the memory request of the proxy
+	// container of a workload (e.g. 32Mi).
+	ProxyMemoryRequestAnnotation = "config.linkerd.io/proxy-memory-request"
+
+	// ProxyCPULimitAnnotation sets the CPU limit of the proxy container of a
+	// workload (e.g. 1).
+	ProxyCPULimitAnnotation = "config.linkerd.io/proxy-cpu-limit"
+
+	// ProxyMemoryLimitAnnotation sets the memory limit of the proxy container
+	// of a workload (e.g. 250Mi).
+	ProxyMemoryLimitAnnotation = "config.linkerd.io/proxy-memory-limit"
+
+	// ProxyInjectAnnotation sets the inject policy of a workload, on its pod
+	// template, or of the workloads of a namespace, on the namespace. It's
+	// honored by both `linkerd inject` and the proxy injector. Supported values
+	// are "enabled" or "disabled".
+	ProxyInjectAnnotation = "linkerd.io/inject"
+
+	// ProxyInjectEnabled is assigned to the ProxyInjectAnnotation annotation to
+	// inject the proxy, and is the default inject policy.
+	ProxyInjectEnabled = "enabled"
+
+	// ProxyInjectDisabled is assigned to the ProxyInjectAnnotation annotation
+	// to leave the workloads without a proxy.
+	ProxyInjectDisabled = "disabled"
+
 	// ProxyAutoInjectLabel indicates if sidecar auto-inject should be performed
 	// on the pod. Supported values are "enabled", "disabled" or "completed".
 	ProxyAutoInjectLabel = "linkerd.io/auto-inject"
@@ -78,6 +133,22 @@ const (
 	// indicate that the sidecar auto-inject is completed for a particular resource.
 	ProxyAutoInjectCompleted = "completed"
 
+	// NodeOSLabel is the label holding the operating system of a node.
+	NodeOSLabel = "kubernetes.io/os"
+
+	// NodeOSLabelBeta is the label holding the operating system of a node,
+	// before Kubernetes 1.14.
+	NodeOSLabelBeta = "beta.kubernetes.io/os"
+
+	// ProxyInjectStatusAnnotation is set by the proxy injector on the pods it
+	// skipped, with the reason why they were skipped.
+	ProxyInjectStatusAnnotation = "linkerd.io/inject-status"
+
+	// ProxyInjectSkippedWindows is assigned to the ProxyInjectStatusAnnotation
+	// annotation of the pods that run on Windows nodes, where the proxy and
+	// its init container can't run.
+	ProxyInjectSkippedWindows = "skipped-windows"
+
 	/*
 	 * Component Names
 	 */
@@ -116,6 +187,10 @@ const (
 	// trust anchors (trusted root certificates).
 	TLSTrustAnchorConfigMapName = "linkerd-ca-bundle"
 
+	// MeshConfigName is the name of the MeshConfig in the control plane
+	// namespace that holds the mesh-wide configuration.
+	MeshConfigName = "linkerd"
+
 	// TLSTrustAnchorFileName is the name (key) within the trust anchor ConfigMap
 	// that contains the actual trust anchor bundle.
 	TLSTrustAnchorFileName = "trust-anchors.pem"
@@ -123,6 +198,14 @@ const (
 	TLSCertFileName       = "certificate.crt"
 	TLSPrivateKeyFileName = "private-key.p8"
 
+	/*
+	 * Proxy environment variables
+	 */
+
+	// ProxyOpaquePortsEnvVar configures the inbound ports on which the proxy
+	// skips protocol detection.
+	ProxyOpaquePortsEnvVar = "LINKERD2_PROXY_INBOUND_PORTS_DISABLE_PROTOCOL_DETECTION"
+
 	/*
 	 * Mount paths
 	 */
@@ -185,6 +268,53 @@ func GetPodLabels(ownerKind, ownerName string, pod *coreV1.Pod) map[string]strin
 	return labels
 }
 
+// TargetsWindows returns true if the pod can only be scheduled on Windows
+// nodes, through its node selector or its required node affinity.
+func TargetsWindows(spec *coreV1.PodSpec) bool {
+	for _, label := range []string{NodeOSLabel, NodeOSLabelBeta} {
+		if spec.NodeSelector[label] == "windows" {
+			return true
+		}
+	}
+
+	if spec.Affinity == nil || spec.Affinity.NodeAffinity == nil ||
+		spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return false
+	}
+	terms := spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms
+	if len(terms) == 0 {
+		return false
+	}
+	// the terms are ORed, so the pod targets Windows only if all of them do
+	for _, term := range terms {
+		if !termTargetsWindows(term) {
+			return false
+		}
+	}
+	return true
+}
+
+func termTargetsWindows(term coreV1.NodeSelectorTerm) bool {
+	for _, req := range term.MatchExpressions {
+		if req.Key != NodeOSLabel && req.Key != NodeOSLabelBeta {
+			continue
+		}
+		switch req.Operator {
+		case coreV1.NodeSelectorOpIn:
+			if len(req.Values) == 1 && req.Values[0] == "windows" {
+				return true
+			}
+		case coreV1.NodeSelectorOpNotIn:
+			for _, value := range req.Values {
+				if value == "linux" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
 func IsMeshed(pod *coreV1.Pod, controllerNS string) bool {
 	return pod.Labels[ControllerNSLabel] == controllerNS
 }
@@ -227,3 +357,172 @@ func (i TLSIdentity) ToControllerIdentity() TLSIdentity {
 		ControllerNamespace: i.ControllerNamespace,
 	}
 }
+
+// SkipOutboundCIDRs returns the destination networks whose outbound traffic
+// bypasses the proxy. The pod annotations override the given defaults.
+func SkipOutboundCIDRs(annotations map[string]string, cidrs []string) ([]string, error) {
+	if v, ok := annotations[ProxySkipOutboundCIDRsAnnotation]; ok {
+		cidrs = []string{}
+		for _, cidr := range strings.Split(v, ",") {
+			if cidr = strings.TrimSpace(cidr); cidr != "" {
+				cidrs = append(cidrs, cidr)
+			}
+		}
+	}
+
+	if err := ValidateCIDRs(cidrs); err != nil {
+		return nil, err
+	}
+	return cidrs, nil
+}
+
+// ValidateCIDRs returns an error if any of cidrs isn't a valid CIDR.
+func ValidateCIDRs(cidrs []string) error {
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("Invalid CIDR '%s'", cidr)
+		}
+	}
+	return nil
+}
+
+// OpaquePorts merges the mesh-wide default opaque ports with those of the
+// namespace and workload annotations, returning them as a sorted,
+// comma-separated list.
+func OpaquePorts(defaults string, nsAnnotations, annotations map[string]string) (string, error) {
+	lists := []string{defaults, nsAnnotations[ProxyOpaquePortsAnnotation], annotations[ProxyOpaquePortsAnnotation]}
+
+	merged := map[uint64]struct{}{}
+	for _, list := range lists {
+		ports, err := parsePorts(list)
+		if err != nil {
+			return "", err
+		}
+		for _, port := range ports {
+			merged[port] = struct{}{}
+		}
+	}
+
+	ports := make([]uint64, 0, len(merged))
+	for port := range merged {
+		ports = append(ports, port)
+	}
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+
+	portsStr := make([]string, len(ports))
+	for i, port := range ports {
+		portsStr[i] = strconv.FormatUint(port, 10)
+	}
+	return strings.Join(portsStr, ","), nil
+}
+
+// ValidateOpaquePorts returns an error if ports isn't a valid comma-separated
+// list of ports.
+func ValidateOpaquePorts(ports string) error {
+	_, err := parsePorts(ports)
+	return err
+}
+
+func parsePorts(ports string) ([]uint64, error) {
+	parsed := []uint64{}
+	for _, port := range strings.Split(ports, ",") {
+		if port = strings.TrimSpace(port); port == "" {
+			continue
+		}
+		p, err := strconv.ParseUint(port, 10, 16)
+		if err != nil || p == 0 {
+			return nil, fmt.Errorf("Invalid opaque port '%s'", port)
+		}
+		parsed = append(parsed, p)
+	}
+	return parsed, nil
+}
+
+// ProxyResources returns the resource requirements of the proxy container.
+// The pod annotations override the given defaults, which are left empty to
+// leave the request or limit unset.
+func ProxyResources(annotations map[string]string, cpuRequest, memoryRequest, cpuLimit, memoryLimit string) (coreV1.ResourceRequirements, error) {
+	settings := []struct {
+		annotation  string
+		value       string
+		name        coreV1.ResourceName
+		limit       bool
+		description string
+	}{
+		{ProxyCPURequestAnnotation, cpuRequest, coreV1.ResourceCPU, false, "cpu request"},
+		{ProxyMemoryRequestAnnotation, memoryRequest, coreV1.ResourceMemory, false, "memory request"},
+		{ProxyCPULimitAnnotation, cpuLimit, coreV1.ResourceCPU, true, "cpu limit"},
+		{ProxyMemoryLimitAnnotation, memoryLimit, coreV1.ResourceMemory, true, "memory limit"},
+	}
+
+	resources := coreV1.ResourceRequirements{}
+	for _, setting := range settings {
+		value := setting.value
+		if v, ok := annotations[setting.annotation]; ok {
+			value = v
+		}
+		if value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return resources, fmt.Errorf("Invalid proxy %s '%s'", setting.description, value)
+		}
+
+		list := &resources.Requests
+		if setting.limit {
+			list = &resources.Limits
+		}
+		if *list == nil {
+			*list = coreV1.ResourceList{}
+		}
+		(*list)[setting.name] = quantity
+	}
+
+	for _, name := range []coreV1.ResourceName{coreV1.ResourceCPU, coreV1.ResourceMemory} {
+		request, hasRequest := resources.Requests[name]
+		limit, hasLimit := resources.Limits[name]
+		if hasRequest && hasLimit && request.Cmp(limit) > 0 {
+			return resources, fmt.Errorf("The proxy %s request %s is greater than its limit %s", name, request.String(), limit.String())
+		}
+	}
+
+	return resources, nil
+}
+
+// InjectPolicy returns whether a workload is injected with the proxy, and the
+// reason why. The ProxyInjectAnnotation annotation of its pod template takes
+// precedence over the one of its namespace, which takes precedence over the
+// default policy.
+func InjectPolicy(annotations, nsAnnotations map[string]string, defaultPolicy string) (bool, string, error) {
+	sources := []struct {
+		scope       string
+		annotations map[string]string
+	}{
+		{"pod", annotations},
+		{"namespace", nsAnnotations},
+	}
+	for _, source := range sources {
+		policy, ok := source.annotations[ProxyInjectAnnotation]
+		if !ok {
+			continue
+		}
+		if err := ValidateInjectPolicy(policy); err != nil {
+			return false, "", fmt.Errorf("%s of the %s annotation %s", err, source.scope, ProxyInjectAnnotation)
+		}
+		return policy == ProxyInjectEnabled, fmt.Sprintf("%s annotation %s=%s", source.scope, ProxyInjectAnnotation, policy), nil
+	}
+
+	if err := ValidateInjectPolicy(defaultPolicy); err != nil {
+		return false, "", err
+	}
+	return defaultPolicy == ProxyInjectEnabled, fmt.Sprintf("default policy %s", defaultPolicy), nil
+}
+
+// ValidateInjectPolicy returns an error if policy isn't a valid inject policy.
+func ValidateInjectPolicy(policy string) error {
+	if policy != ProxyInjectEnabled && policy != ProxyInjectDisabled {
+		return fmt.Errorf("Invalid inject policy '%s'", policy)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 
 	appsV1 "k8s.io/api/apps/v1"
 	coreV1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -38,3 +39,198 @@ func TestGetPodLabels(t *testing.T) {
 		}
 	})
 }
+
+func TestSkipOutboundCIDRs(t *testing.T) {
+	t.Run("Annotations override the defaults", func(t *testing.T) {
+		annotations := map[string]string{ProxySkipOutboundCIDRsAnnotation: "169.254.169.254/32, 10.0.0.0/8"}
+
+		cidrs, err := SkipOutboundCIDRs(annotations, []string{"192.168.0.0/16"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expectedCIDRs := []string{"169.254.169.254/32", "10.0.0.0/8"}
+		if !reflect.DeepEqual(cidrs, expectedCIDRs) {
+			t.Fatalf("Expected CIDRs [%v] but got [%v]", expectedCIDRs, cidrs)
+		}
+	})
+
+	t.Run("Keeps the defaults", func(t *testing.T) {
+		cidrs, err := SkipOutboundCIDRs(nil, []string{"192.168.0.0/16"})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !reflect.DeepEqual(cidrs, []string{"192.168.0.0/16"}) {
+			t.Fatalf("Expected the default CIDRs, got [%v]", cidrs)
+		}
+	})
+
+	t.Run("Rejects invalid CIDRs", func(t *testing.T) {
+		for _, cidr := range []string{"169.254.169.254", "10.0.0.0/33", "metadata"} {
+			annotations := map[string]string{ProxySkipOutboundCIDRsAnnotation: cidr}
+			if _, err := SkipOutboundCIDRs(annotations, nil); err == nil {
+				t.Fatalf("Expected error for [%s], got nothing", cidr)
+			}
+		}
+	})
+}
+
+func TestOpaquePorts(t *testing.T) {
+	t.Run("Merges the mesh, namespace and workload opaque ports", func(t *testing.T) {
+		nsAnnotations := map[string]string{ProxyOpaquePortsAnnotation: "5432, 3306"}
+		annotations := map[string]string{ProxyOpaquePortsAnnotation: "11211,3306"}
+
+		ports, err := OpaquePorts("25", nsAnnotations, annotations)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if ports != "25,3306,5432,11211" {
+			t.Fatalf("Expected opaque ports [25,3306,5432,11211], got [%s]", ports)
+		}
+	})
+
+	t.Run("Returns no opaque ports by default", func(t *testing.T) {
+		ports, err := OpaquePorts("", nil, nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if ports != "" {
+			t.Fatalf("Expected no opaque ports, got [%s]", ports)
+		}
+	})
+
+	t.Run("Rejects invalid ports", func(t *testing.T) {
+		for _, ports := range []string{"mysql", "0", "70000", "3306-3310"} {
+			nsAnnotations := map[string]string{ProxyOpaquePortsAnnotation: ports}
+			if _, err := OpaquePorts("", nsAnnotations, nil); err == nil {
+				t.Fatalf("Expected error for [%s], got nothing", ports)
+			}
+		}
+	})
+}
+
+func TestProxyResources(t *testing.T) {
+	t.Run("Overrides the defaults with the annotations", func(t *testing.T) {
+		annotations := map[string]string{
+			ProxyCPULimitAnnotation:      "2",
+			ProxyMemoryRequestAnnotation: "64Mi",
+		}
+
+		resources, err := ProxyResources(annotations, "100m", "32Mi", "1", "")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		expected := coreV1.ResourceRequirements{
+			Requests: coreV1.ResourceList{
+				coreV1.ResourceCPU:    resource.MustParse("100m"),
+				coreV1.ResourceMemory: resource.MustParse("64Mi"),
+			},
+			Limits: coreV1.ResourceList{
+				coreV1.ResourceCPU: resource.MustParse("2"),
+			},
+		}
+		if !reflect.DeepEqual(resources, expected) {
+			t.Fatalf("Expected resources [%v], got [%v]", expected, resources)
+		}
+	})
+
+	t.Run("Leaves the resources unset by default", func(t *testing.T) {
+		resources, err := ProxyResources(nil, "", "", "", "")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if resources.Requests != nil || resources.Limits != nil {
+			t.Fatalf("Expected no resources, got [%v]", resources)
+		}
+	})
+
+	t.Run("Rejects invalid resources", func(t *testing.T) {
+		testCases := []struct {
+			annotations map[string]string
+			expectedErr string
+		}{
+			{map[string]string{ProxyMemoryLimitAnnotation: "lots"}, "Invalid proxy memory limit 'lots'"},
+			{map[string]string{ProxyCPULimitAnnotation: "50m"}, "The proxy cpu request 100m is greater than its limit 50m"},
+		}
+		for _, tc := range testCases {
+			_, err := ProxyResources(tc.annotations, "100m", "", "", "")
+			if err == nil || err.Error() != tc.expectedErr {
+				t.Fatalf("Expected error [%s], got [%v]", tc.expectedErr, err)
+			}
+		}
+	})
+}
+
+func TestTargetsWindows(t *testing.T) {
+	affinity := func(terms ...coreV1.NodeSelectorTerm) *coreV1.Affinity {
+		return &coreV1.Affinity{
+			NodeAffinity: &coreV1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &coreV1.NodeSelector{NodeSelectorTerms: terms},
+			},
+		}
+	}
+	osTerm := func(operator coreV1.NodeSelectorOperator, values ...string) coreV1.NodeSelectorTerm {
+		return coreV1.NodeSelectorTerm{
+			MatchExpressions: []coreV1.NodeSelectorRequirement{{Key: NodeOSLabel, Operator: operator, Values: values}},
+		}
+	}
+
+	testCases := []struct {
+		description string
+		spec        coreV1.PodSpec
+		expected    bool
+	}{
+		{"no constraints", coreV1.PodSpec{}, false},
+		{"node selector", coreV1.PodSpec{NodeSelector: map[string]string{NodeOSLabel: "windows"}}, true},
+		{"beta node selector", coreV1.PodSpec{NodeSelector: map[string]string{NodeOSLabelBeta: "windows"}}, true},
+		{"linux node selector", coreV1.PodSpec{NodeSelector: map[string]string{NodeOSLabel: "linux"}}, false},
+		{"required affinity", coreV1.PodSpec{Affinity: affinity(osTerm(coreV1.NodeSelectorOpIn, "windows"))}, true},
+		{"affinity excluding linux", coreV1.PodSpec{Affinity: affinity(osTerm(coreV1.NodeSelectorOpNotIn, "linux"))}, true},
+		{"affinity allowing linux", coreV1.PodSpec{Affinity: affinity(osTerm(coreV1.NodeSelectorOpIn, "windows", "linux"))}, false},
+		{"one of the terms allows linux", coreV1.PodSpec{Affinity: affinity(osTerm(coreV1.NodeSelectorOpIn, "windows"), osTerm(coreV1.NodeSelectorOpIn, "linux"))}, false},
+	}
+
+	for _, tc := range testCases {
+		if actual := TargetsWindows(&tc.spec); actual != tc.expected {
+			t.Fatalf("Expected %t for %s, got %t", tc.expected, tc.description, actual)
+		}
+	}
+}
+
+func TestInjectPolicy(t *testing.T) {
+	testCases := []struct {
+		description    string
+		annotations    map[string]string
+		nsAnnotations  map[string]string
+		defaultPolicy  string
+		expected       bool
+		expectedReason string
+	}{
+		{"default policy", nil, nil, ProxyInjectEnabled, true, "default policy enabled"},
+		{"disabled default policy", nil, nil, ProxyInjectDisabled, false, "default policy disabled"},
+		{"namespace opt-out", nil, map[string]string{ProxyInjectAnnotation: ProxyInjectDisabled}, ProxyInjectEnabled, false, "namespace annotation linkerd.io/inject=disabled"},
+		{"namespace opt-in", nil, map[string]string{ProxyInjectAnnotation: ProxyInjectEnabled}, ProxyInjectDisabled, true, "namespace annotation linkerd.io/inject=enabled"},
+		{"pod overrides namespace", map[string]string{ProxyInjectAnnotation: ProxyInjectEnabled}, map[string]string{ProxyInjectAnnotation: ProxyInjectDisabled}, ProxyInjectDisabled, true, "pod annotation linkerd.io/inject=enabled"},
+	}
+
+	for _, tc := range testCases {
+		injected, reason, err := InjectPolicy(tc.annotations, tc.nsAnnotations, tc.defaultPolicy)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %s", tc.description, err)
+		}
+		if injected != tc.expected || reason != tc.expectedReason {
+			t.Fatalf("Expected %t (%s) for %s, got %t (%s)", tc.expected, tc.expectedReason, tc.description, injected, reason)
+		}
+	}
+
+	t.Run("Rejects invalid policies", func(t *testing.T) {
+		nsAnnotations := map[string]string{ProxyInjectAnnotation: "true"}
+		_, _, err := InjectPolicy(nil, nsAnnotations, ProxyInjectEnabled)
+		if err == nil || err.Error() != "Invalid inject policy 'true' of the namespace annotation linkerd.io/inject" {
+			t.Fatalf("Expected an invalid namespace annotation error, got [%v]", err)
+		}
+		if _, _, err := InjectPolicy(nil, nil, "always"); err == nil {
+			t.Fatal("Expected error for an invalid default policy, got nothing")
+		}
+	})
+}
@@ -0,0 +1,231 @@
+package k8s
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	log "github.com/sirupsen/logrus"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// portForwardProtocol is the Kubernetes WebSocket port-forward protocol.
+	// Each binary message is prefixed with the index of its channel, and the
+	// first message of each channel holds the forwarded port number.
+	portForwardProtocol     = "v4.channel.k8s.io"
+	portForwardDataChannel  = 0
+	portForwardErrorChannel = 1
+	portForwardPortLength   = 2
+)
+
+// PortForward forwards connections accepted on a local port to a port of a
+// pod, through the Kubernetes API's port-forward subresource. Unlike the
+// service proxy, it doesn't require the Kubernetes API server to be able to
+// reach the pod network.
+type PortForward struct {
+	kubeAPI   *KubernetesAPI
+	namespace string
+	podName   string
+	port      int
+	listener  net.Listener
+}
+
+// NewPortForward returns a new PortForward listening on a random local port.
+// Call Run to start forwarding connections to the given pod port.
+func (kubeAPI *KubernetesAPI) NewPortForward(namespace, podName string, port int) (*PortForward, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	return &PortForward{
+		kubeAPI:   kubeAPI,
+		namespace: namespace,
+		podName:   podName,
+		port:      port,
+		listener:  listener,
+	}, nil
+}
+
+// Addr returns the local host:port forwarded to the pod.
+func (pf *PortForward) Addr() string {
+	return pf.listener.Addr().String()
+}
+
+// Run forwards local connections to the pod until Stop is called.
+func (pf *PortForward) Run() error {
+	for {
+		conn, err := pf.listener.Accept()
+		if err != nil {
+			return err
+		}
+
+		go func() {
+			if err := pf.forward(conn); err != nil {
+				log.Debugf("Port-forward to %s/%s:%d failed: %s", pf.namespace, pf.podName, pf.port, err)
+			}
+		}()
+	}
+}
+
+// Stop stops accepting local connections.
+func (pf *PortForward) Stop() {
+	pf.listener.Close()
+}
+
+func (pf *PortForward) forward(local net.Conn) error {
+	defer local.Close()
+
+	ws, err := pf.dial()
+	if err != nil {
+		return err
+	}
+	defer ws.Close()
+
+	errCh := make(chan error, 2)
+	go func() { errCh <- pf.copyToPod(ws, local) }()
+	go func() { errCh <- pf.copyFromPod(local, ws) }()
+	return <-errCh
+}
+
+func (pf *PortForward) dial() (*websocket.Conn, error) {
+	endpoint, err := pf.kubeAPI.UrlFor(pf.namespace, fmt.Sprintf("/pods/%s/portforward", pf.podName))
+	if err != nil {
+		return nil, err
+	}
+	endpoint.RawQuery = "ports=" + strconv.Itoa(pf.port)
+	if endpoint.Scheme == "https" {
+		endpoint.Scheme = "wss"
+	} else {
+		endpoint.Scheme = "ws"
+	}
+
+	tlsConfig, err := rest.TLSConfigFor(pf.kubeAPI.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	header, err := pf.kubeAPI.requestHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		TLSClientConfig:  tlsConfig,
+		HandshakeTimeout: 10 * time.Second,
+		Subprotocols:     []string{portForwardProtocol},
+	}
+
+	ws, rsp, err := dialer.Dial(endpoint.String(), header)
+	if err != nil {
+		if rsp != nil {
+			return nil, fmt.Errorf("Unexpected Kubernetes API response: %s", rsp.Status)
+		}
+		return nil, err
+	}
+
+	return ws, nil
+}
+
+func (pf *PortForward) copyToPod(ws *websocket.Conn, local net.Conn) error {
+	buf := make([]byte, 32*1024)
+	for {
+		n, err := local.Read(buf)
+		if n > 0 {
+			msg := append([]byte{portForwardDataChannel}, buf[:n]...)
+			if err := ws.WriteMessage(websocket.BinaryMessage, msg); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (pf *PortForward) copyFromPod(local net.Conn, ws *websocket.Conn) error {
+	// the port number prefixing each channel isn't part of the forwarded data
+	skip := map[byte]int{
+		portForwardDataChannel:  portForwardPortLength,
+		portForwardErrorChannel: portForwardPortLength,
+	}
+
+	for {
+		_, msg, err := ws.ReadMessage()
+		if err != nil {
+			return err
+		}
+		if len(msg) == 0 {
+			continue
+		}
+
+		channel, payload := msg[0], msg[1:]
+		n := skip[channel]
+		if n > len(payload) {
+			n = len(payload)
+		}
+		skip[channel] -= n
+		payload = payload[n:]
+
+		if len(payload) == 0 {
+			continue
+		}
+
+		switch channel {
+		case portForwardDataChannel:
+			if _, err := local.Write(payload); err != nil {
+				return err
+			}
+		case portForwardErrorChannel:
+			return fmt.Errorf("%s", payload)
+		}
+	}
+}
+
+// requestHeader returns the headers, such as credentials and impersonation,
+// that a Kubernetes API client adds to its requests. WebSocket connections
+// aren't dialed by the client's transport, so they must be added explicitly.
+func (kubeAPI *KubernetesAPI) requestHeader() (http.Header, error) {
+	recorder := &headerRecorder{}
+	rt, err := rest.HTTPWrappersForConfig(kubeAPI.Config, recorder)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", kubeAPI.Host, nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := rt.RoundTrip(req); err != nil {
+		return nil, err
+	}
+
+	return recorder.header, nil
+}
+
+// headerRecorder is a RoundTripper recording the headers of the last request,
+// without sending it.
+type headerRecorder struct {
+	header http.Header
+}
+
+func (r *headerRecorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	r.header = req.Header
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+		Request:    req,
+	}, nil
+}
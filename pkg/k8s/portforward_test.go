@@ -0,0 +1,77 @@
+package k8s
+
+import (
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"k8s.io/client-go/rest"
+)
+
+// fakePortForwardServer echoes the data forwarded to the pod, prefixing each
+// channel with the port number as the Kubernetes API does.
+func fakePortForwardServer(t *testing.T, expectedPath string) *httptest.Server {
+	upgrader := websocket.Upgrader{Subprotocols: []string{portForwardProtocol}}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != expectedPath || r.URL.Query().Get("ports") != "8085" {
+			t.Errorf("Unexpected port-forward request: %s", r.URL)
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("Expected bearer token, got [%s]", r.Header.Get("Authorization"))
+		}
+
+		ws, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("Unexpected error: %s", err)
+			return
+		}
+		defer ws.Close()
+
+		ws.WriteMessage(websocket.BinaryMessage, []byte{portForwardDataChannel, 0x95, 0x1f})
+		ws.WriteMessage(websocket.BinaryMessage, []byte{portForwardErrorChannel, 0x95, 0x1f})
+		for {
+			_, msg, err := ws.ReadMessage()
+			if err != nil {
+				return
+			}
+			ws.WriteMessage(websocket.BinaryMessage, msg)
+		}
+	}))
+}
+
+func TestPortForward(t *testing.T) {
+	server := fakePortForwardServer(t, "/api/v1/namespaces/linkerd/pods/linkerd-controller-7d9d47f5c-s2s6k/portforward")
+	defer server.Close()
+
+	kubeAPI := &KubernetesAPI{Config: &rest.Config{Host: server.URL, BearerToken: "token"}}
+	pf, err := kubeAPI.NewPortForward("linkerd", "linkerd-controller-7d9d47f5c-s2s6k", 8085)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer pf.Stop()
+	go pf.Run()
+
+	conn, err := net.Dial("tcp", pf.Addr())
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("ping")); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	buf := make([]byte, 4)
+	if _, err := io.ReadFull(conn, buf); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	if string(buf) != "ping" {
+		t.Fatalf("Expected [ping], got [%s]", buf)
+	}
+}
@@ -0,0 +1,149 @@
+package k8s
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	// prometheusQueryPath is the path of the Prometheus instant query API of a
+	// control plane, through the Kubernetes API's service proxy.
+	prometheusQueryPath = "/api/v1/namespaces/%s/services/linkerd-prometheus:9090/proxy/api/v1/query"
+	// prometheusRulesPath and prometheusAlertsPath are the paths of the
+	// Prometheus APIs listing the rules, and the active alerts.
+	prometheusRulesPath  = "/api/v1/namespaces/%s/services/linkerd-prometheus:9090/proxy/api/v1/rules"
+	prometheusAlertsPath = "/api/v1/namespaces/%s/services/linkerd-prometheus:9090/proxy/api/v1/alerts"
+)
+
+// PromQueryResponse is the response of the Prometheus instant query API to a
+// vector query.
+type PromQueryResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		Result []PromSample `json:"result"`
+	} `json:"data"`
+}
+
+// PromSample is a sample of a Prometheus instant vector.
+type PromSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  []interface{}     `json:"value"`
+}
+
+// Float returns the value of the sample, and false if it has none.
+func (s *PromSample) Float() (float64, bool) {
+	if len(s.Value) != 2 {
+		return 0, false
+	}
+	v, ok := s.Value[1].(string)
+	if !ok {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return 0, false
+	}
+	return f, true
+}
+
+// PromRulesResponse is the response of the Prometheus rules API.
+type PromRulesResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		Groups []PromRuleGroup `json:"groups"`
+	} `json:"data"`
+}
+
+// PromRuleGroup is a group of Prometheus rules, evaluated together.
+type PromRuleGroup struct {
+	Name  string     `json:"name"`
+	File  string     `json:"file"`
+	Rules []PromRule `json:"rules"`
+}
+
+// PromRule is a Prometheus rule. Alerting rules have the "alerting" type, and
+// list their active alerts.
+type PromRule struct {
+	Type        string            `json:"type"`
+	Name        string            `json:"name"`
+	Query       string            `json:"query"`
+	Duration    float64           `json:"duration"`
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	Alerts      []PromAlert       `json:"alerts"`
+}
+
+// PromAlertsResponse is the response of the Prometheus alerts API.
+type PromAlertsResponse struct {
+	Status string `json:"status"`
+	Data   struct {
+		Alerts []PromAlert `json:"alerts"`
+	} `json:"data"`
+}
+
+// PromAlert is an active alert of a Prometheus alerting rule, either pending
+// until the rule has held for its duration, or firing.
+type PromAlert struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	State       string            `json:"state"`
+	ActiveAt    *time.Time        `json:"activeAt,omitempty"`
+}
+
+// QueryPrometheus runs an instant query against the Prometheus of the control
+// plane in namespace.
+func (kubeAPI *KubernetesAPI) QueryPrometheus(client *http.Client, namespace, query string) (*PromQueryResponse, error) {
+	var rsp PromQueryResponse
+	path := fmt.Sprintf(prometheusQueryPath, namespace) + "?query=" + url.QueryEscape(query)
+	if err := kubeAPI.getPrometheus(client, namespace, path, &rsp); err != nil {
+		return nil, err
+	}
+	if rsp.Status != "success" {
+		return nil, fmt.Errorf("Prometheus query failed: %s", rsp.Status)
+	}
+
+	return &rsp, nil
+}
+
+// GetPrometheusRules returns the rule groups of the Prometheus of the control
+// plane in namespace.
+func (kubeAPI *KubernetesAPI) GetPrometheusRules(client *http.Client, namespace string) ([]PromRuleGroup, error) {
+	var rsp PromRulesResponse
+	if err := kubeAPI.getPrometheus(client, namespace, fmt.Sprintf(prometheusRulesPath, namespace), &rsp); err != nil {
+		return nil, err
+	}
+	if rsp.Status != "success" {
+		return nil, fmt.Errorf("Prometheus rules request failed: %s", rsp.Status)
+	}
+
+	return rsp.Data.Groups, nil
+}
+
+// GetPrometheusAlerts returns the active alerts of the Prometheus of the
+// control plane in namespace.
+func (kubeAPI *KubernetesAPI) GetPrometheusAlerts(client *http.Client, namespace string) ([]PromAlert, error) {
+	var rsp PromAlertsResponse
+	if err := kubeAPI.getPrometheus(client, namespace, fmt.Sprintf(prometheusAlertsPath, namespace), &rsp); err != nil {
+		return nil, err
+	}
+	if rsp.Status != "success" {
+		return nil, fmt.Errorf("Prometheus alerts request failed: %s", rsp.Status)
+	}
+
+	return rsp.Data.Alerts, nil
+}
+
+// getPrometheus reads the response of the Prometheus API at path into rsp.
+func (kubeAPI *KubernetesAPI) getPrometheus(client *http.Client, namespace, path string, rsp interface{}) error {
+	found, err := kubeAPI.GetObject(client, path, rsp)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("Could not find Prometheus in the %s namespace", namespace)
+	}
+	return nil
+}
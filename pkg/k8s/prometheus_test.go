@@ -0,0 +1,101 @@
+package k8s
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"k8s.io/client-go/rest"
+)
+
+func TestQueryPrometheus(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/namespaces/linkerd/services/linkerd-prometheus:9090/proxy/api/v1/query" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"status": "success", "data": {"resultType": "vector", "result": [
+			{"metric": {"pod": "web-6b7f9d5c4-jq2bk"}, "value": [1540000000, "0.25"]},
+			{"metric": {"pod": "web-6b7f9d5c4-x8r2m"}, "value": [1540000000, "NaN-ish"]}
+		]}, "query": %q}`, r.URL.Query().Get("query"))
+	}))
+	defer ts.Close()
+
+	kubeAPI := &KubernetesAPI{Config: &rest.Config{Host: ts.URL}}
+
+	t.Run("Returns the samples of the query", func(t *testing.T) {
+		rsp, err := kubeAPI.QueryPrometheus(http.DefaultClient, "linkerd", `sum(rate(request_total{namespace="emojivoto"}[1m])) by (pod)`)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(rsp.Data.Result) != 2 {
+			t.Fatalf("Expected 2 samples, got %d", len(rsp.Data.Result))
+		}
+		if v, ok := rsp.Data.Result[0].Float(); !ok || v != 0.25 {
+			t.Fatalf("Expected the first sample to be [0.25], got [%v]", rsp.Data.Result[0].Value)
+		}
+		if _, ok := rsp.Data.Result[1].Float(); ok {
+			t.Fatalf("Expected the second sample to have no value, got [%v]", rsp.Data.Result[1].Value)
+		}
+	})
+
+	t.Run("Returns an error if Prometheus isn't found", func(t *testing.T) {
+		_, err := kubeAPI.QueryPrometheus(http.DefaultClient, "linkerd-test", "up")
+		expected := "Could not find Prometheus in the linkerd-test namespace"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error [%s], got [%v]", expected, err)
+		}
+	})
+}
+
+func TestGetPrometheusAlerts(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/namespaces/linkerd/services/linkerd-prometheus:9090/proxy/api/v1/alerts":
+			fmt.Fprint(w, `{"status": "success", "data": {"alerts": [
+				{"labels": {"alertname": "LinkerdProxyRestarted", "pod": "web-6b7f9d5c4-jq2bk"}, "annotations": {}, "state": "firing", "activeAt": "2018-10-20T10:00:00Z"}
+			]}}`)
+		case "/api/v1/namespaces/linkerd/services/linkerd-prometheus:9090/proxy/api/v1/rules":
+			fmt.Fprint(w, `{"status": "success", "data": {"groups": [
+				{"name": "linkerd", "file": "/etc/prometheus/alerting-rules.yml", "rules": [
+					{"type": "alerting", "name": "LinkerdProxyRestarted", "query": "up", "duration": 0, "labels": {"severity": "warning"}, "annotations": {}, "alerts": []}
+				]}
+			]}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	kubeAPI := &KubernetesAPI{Config: &rest.Config{Host: ts.URL}}
+
+	t.Run("Returns the active alerts", func(t *testing.T) {
+		alerts, err := kubeAPI.GetPrometheusAlerts(http.DefaultClient, "linkerd")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(alerts) != 1 || alerts[0].State != "firing" || alerts[0].Labels["pod"] != "web-6b7f9d5c4-jq2bk" {
+			t.Fatalf("Unexpected alerts: %+v", alerts)
+		}
+		if alerts[0].ActiveAt == nil || alerts[0].ActiveAt.Unix() != 1540029600 {
+			t.Fatalf("Expected the alert to be active since 1540029600, got %v", alerts[0].ActiveAt)
+		}
+	})
+
+	t.Run("Returns the rules", func(t *testing.T) {
+		groups, err := kubeAPI.GetPrometheusRules(http.DefaultClient, "linkerd")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if len(groups) != 1 || len(groups[0].Rules) != 1 || groups[0].Rules[0].Labels["severity"] != "warning" {
+			t.Fatalf("Unexpected rules: %+v", groups)
+		}
+	})
+
+	t.Run("Returns an error if Prometheus isn't found", func(t *testing.T) {
+		if _, err := kubeAPI.GetPrometheusAlerts(http.DefaultClient, "linkerd-test"); err == nil {
+			t.Fatal("Expected an error")
+		}
+	})
+}
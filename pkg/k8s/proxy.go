@@ -19,11 +19,12 @@ type KubernetesProxy struct {
 
 // NewProxy returns a new KubernetesProxy object and starts listening on a
 // network address.
-func NewProxy(configPath, kubeContext string, proxyPort int) (*KubernetesProxy, error) {
+func NewProxy(configPath, kubeContext, impersonate string, impersonateGroup []string, proxyPort int) (*KubernetesProxy, error) {
 	config, err := GetConfig(configPath, kubeContext)
 	if err != nil {
 		return nil, fmt.Errorf("error configuring Kubernetes API client: %v", err)
 	}
+	setImpersonation(config, impersonate, impersonateGroup)
 
 	server, err := proxyCreate(config)
 	if err != nil {
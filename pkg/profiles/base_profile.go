@@ -0,0 +1,57 @@
+package profiles
+
+import (
+	"fmt"
+
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+)
+
+// maxBaseProfileDepth is the longest chain of base profiles a profile can
+// inherit from.
+const maxBaseProfileDepth = 8
+
+// MergeBaseProfiles returns a copy of the profile merged with the chain of its
+// base profiles, looked up by name with getProfile. The routes of a profile
+// come before the routes of its base, so that they're matched first, and a
+// route overrides the routes of its bases with the same name. The names of the
+// base profiles are returned along with the merged profile, including the
+// base profile failing to be looked up, if any, so that the profile can be
+// merged again when one of them changes. When the chain is invalid, the
+// merged profile only has the routes of the bases looked up so far.
+func MergeBaseProfiles(profile *sp.ServiceProfile, getProfile func(name string) (*sp.ServiceProfile, error)) (*sp.ServiceProfile, []string, error) {
+	merged := profile.DeepCopy()
+	merged.Spec.BaseProfile = ""
+
+	bases := make([]string, 0)
+	names := map[string]bool{profile.Name: true}
+	routeNames := make(map[string]bool)
+	for _, route := range merged.Spec.Routes {
+		routeNames[route.Name] = true
+	}
+
+	for base := profile.Spec.BaseProfile; base != ""; {
+		bases = append(bases, base)
+		if names[base] {
+			return merged, bases, fmt.Errorf("ServiceProfile \"%s\" inherits from itself through base profile \"%s\"", profile.Name, base)
+		}
+		if len(bases) > maxBaseProfileDepth {
+			return merged, bases, fmt.Errorf("ServiceProfile \"%s\" has more than %d nested base profiles", profile.Name, maxBaseProfileDepth)
+		}
+		names[base] = true
+
+		baseProfile, err := getProfile(base)
+		if err != nil {
+			return merged, bases, fmt.Errorf("ServiceProfile \"%s\" has an invalid base profile \"%s\": %s", profile.Name, base, err)
+		}
+		for _, route := range baseProfile.Spec.Routes {
+			if routeNames[route.Name] {
+				continue
+			}
+			routeNames[route.Name] = true
+			merged.Spec.Routes = append(merged.Spec.Routes, route.DeepCopy())
+		}
+		base = baseProfile.Spec.BaseProfile
+	}
+
+	return merged, bases, nil
+}
@@ -0,0 +1,93 @@
+package profiles
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func genProfile(name, base string, routes ...string) *sp.ServiceProfile {
+	profile := &sp.ServiceProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       sp.ServiceProfileSpec{BaseProfile: base},
+	}
+	for _, route := range routes {
+		profile.Spec.Routes = append(profile.Spec.Routes, &sp.RouteSpec{
+			Name:      route,
+			Condition: &sp.RequestMatch{PathRegex: fmt.Sprintf("/%s/%s", name, route)},
+		})
+	}
+	return profile
+}
+
+func TestMergeBaseProfiles(t *testing.T) {
+	getProfile := func(profiles ...*sp.ServiceProfile) func(string) (*sp.ServiceProfile, error) {
+		return func(name string) (*sp.ServiceProfile, error) {
+			for _, profile := range profiles {
+				if profile.Name == name {
+					return profile, nil
+				}
+			}
+			return nil, fmt.Errorf("not found")
+		}
+	}
+
+	t.Run("Merges the routes of the chain of base profiles", func(t *testing.T) {
+		profile := genProfile("webapp", "team", "/books", "/health")
+		team := genProfile("team", "defaults", "/health", "/metrics")
+		defaults := genProfile("defaults", "", "/metrics", "/ready")
+
+		merged, bases, err := MergeBaseProfiles(profile, getProfile(team, defaults))
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		expectedRoutes := []*sp.RouteSpec{
+			profile.Spec.Routes[0],
+			profile.Spec.Routes[1],
+			team.Spec.Routes[1],
+			defaults.Spec.Routes[1],
+		}
+		if !reflect.DeepEqual(merged.Spec.Routes, expectedRoutes) {
+			t.Fatalf("Expected routes %v, got %v", expectedRoutes, merged.Spec.Routes)
+		}
+		if merged.Spec.BaseProfile != "" {
+			t.Fatalf("Expected the merged profile to have no base profile, got %s", merged.Spec.BaseProfile)
+		}
+		if profile.Spec.BaseProfile != "team" || len(profile.Spec.Routes) != 2 {
+			t.Fatalf("Expected the profile to be left unchanged, got %v", profile.Spec)
+		}
+		if !reflect.DeepEqual(bases, []string{"team", "defaults"}) {
+			t.Fatalf("Expected bases [team defaults], got %v", bases)
+		}
+	})
+
+	t.Run("Returns an error for a missing base profile", func(t *testing.T) {
+		profile := genProfile("webapp", "team", "/books")
+
+		merged, bases, err := MergeBaseProfiles(profile, getProfile())
+		if err == nil {
+			t.Fatal("Expected an error for a missing base profile")
+		}
+		if len(merged.Spec.Routes) != 1 {
+			t.Fatalf("Expected the routes of the profile, got %v", merged.Spec.Routes)
+		}
+		if !reflect.DeepEqual(bases, []string{"team"}) {
+			t.Fatalf("Expected bases [team], got %v", bases)
+		}
+	})
+
+	t.Run("Returns an error for a cycle of base profiles", func(t *testing.T) {
+		profile := genProfile("webapp", "team", "/books")
+		team := genProfile("team", "webapp", "/health")
+
+		_, _, err := MergeBaseProfiles(profile, getProfile(profile, team))
+		expected := "ServiceProfile \"webapp\" inherits from itself through base profile \"webapp\""
+		if err == nil || err.Error() != expected {
+			t.Fatalf("Expected error [%s], got [%v]", expected, err)
+		}
+	})
+}
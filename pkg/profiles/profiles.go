@@ -3,19 +3,24 @@ package profiles
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"io"
+	"strings"
 	"text/template"
 
 	pb "github.com/linkerd/linkerd2-proxy-api/go/destination"
 	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
 	"github.com/linkerd/linkerd2/pkg/util"
+	"github.com/prometheus/common/model"
+	"k8s.io/apimachinery/pkg/util/validation"
 )
 
 type profileTemplateConfig struct {
 	ControlPlaneNamespace string
-	ServiceNamespace      string
-	ServiceName           string
-	ClusterZone           string
+	ProfileName           string
+	// Target describes the destination of the profile in the header of the
+	// template
+	Target string
 }
 
 func ToRoute(route *sp.RouteSpec) (*pb.Route, error) {
@@ -309,17 +314,65 @@ func ValidateResponseMatch(rspMatch *sp.ResponseMatch) error {
 	return nil
 }
 
-func buildConfig(namespace, service, controlPlaneNamespace string) *profileTemplateConfig {
-	return &profileTemplateConfig{
-		ControlPlaneNamespace: controlPlaneNamespace,
-		ServiceNamespace:      namespace,
-		ServiceName:           service,
-		ClusterZone:           "svc.cluster.local",
+// ValidateSLO checks that the objectives of a route SLO are within range, and
+// that its window is a valid Prometheus duration.
+func ValidateSLO(slo *sp.RouteSLO) error {
+	if slo.SuccessRate == 0 && slo.LatencyMs == 0 {
+		return errors.New("An SLO must have a success rate or a latency objective")
+	}
+	if slo.SuccessRate < 0 || slo.SuccessRate >= 1 {
+		return errors.New("An SLO success rate must be between 0 and 1, e.g. 0.999")
+	}
+	if slo.Window == "" {
+		return errors.New("An SLO must have a window")
+	}
+	if _, err := model.ParseDuration(slo.Window); err != nil {
+		return fmt.Errorf("Invalid SLO window: %s", err)
+	}
+
+	return nil
+}
+
+// ValidateExternalAuthority checks that an authority is a DNS name outside
+// of the cluster, e.g. api.stripe.com, for which the proxies look up a service
+// profile like for the Kubernetes services. The authority must be a DNS-1123
+// subdomain, as it names the profile.
+func ValidateExternalAuthority(authority string) error {
+	if errs := validation.IsDNS1123Subdomain(authority); len(errs) != 0 {
+		return fmt.Errorf("invalid external authority %q: %v", authority, errs)
+	}
+	if !strings.Contains(authority, ".") {
+		return fmt.Errorf("invalid external authority %q: must be a fully qualified DNS name, e.g. api.example.com", authority)
+	}
+	labels := strings.Split(authority, ".")
+	for _, label := range labels {
+		if label == "svc" {
+			return fmt.Errorf("invalid external authority %q: the services of the cluster are named \"<service>.<namespace>.svc.cluster.local\"", authority)
+		}
 	}
+
+	return nil
 }
 
 func RenderProfileTemplate(namespace, service, controlPlaneNamespace string, w io.Writer) error {
-	config := buildConfig(namespace, service, controlPlaneNamespace)
+	return renderTemplate(&profileTemplateConfig{
+		ControlPlaneNamespace: controlPlaneNamespace,
+		ProfileName:           fmt.Sprintf("%s.%s.svc.cluster.local", service, namespace),
+		Target:                fmt.Sprintf("%s.%s", service, namespace),
+	}, w)
+}
+
+// RenderExternalProfileTemplate writes the service profile template of an
+// external authority, e.g. api.stripe.com.
+func RenderExternalProfileTemplate(authority, controlPlaneNamespace string, w io.Writer) error {
+	return renderTemplate(&profileTemplateConfig{
+		ControlPlaneNamespace: controlPlaneNamespace,
+		ProfileName:           authority,
+		Target:                fmt.Sprintf("the external authority %s", authority),
+	}, w)
+}
+
+func renderTemplate(config *profileTemplateConfig, w io.Writer) error {
 	template, err := template.New("profile").Parse(Template)
 	if err != nil {
 		return err
@@ -0,0 +1,255 @@
+package profiles
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	sp "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha1"
+	pb "github.com/linkerd/linkerd2/controller/gen/public"
+	"github.com/linkerd/linkerd2/pkg/addr"
+)
+
+var pathParamRegex = regexp.MustCompile(`\\{[^\}]*\\}`)
+
+// the path segments collapsed into a parameter regardless of their siblings:
+// numbers, UUIDs and long hexadecimal strings, e.g. hashes
+var tapIDSegmentRegex = regexp.MustCompile(`^([0-9]+|[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|[0-9a-fA-F]{16,})$`)
+
+const (
+	tapIDParam      = "{id}"
+	tapGenericParam = "{param}"
+
+	// maxTapRoutePending caps the requests waiting for their response, so that
+	// requests never answered don't grow without bound.
+	maxTapRoutePending = 10000
+)
+
+// TapRoute is a method and path observed by a tap, with the statuses of its
+// responses.
+type TapRoute struct {
+	Method   string
+	Segments []string
+	Requests int
+	Statuses map[uint32]bool
+}
+
+// Path returns the path of the route, with its collapsed segments.
+func (r *TapRoute) Path() string {
+	return strings.Join(r.Segments, "/")
+}
+
+// PathToRegex returns the regex matching a path template, its {parameters}
+// matching any path segment.
+func PathToRegex(path string) string {
+	escaped := regexp.QuoteMeta(path)
+	return pathParamRegex.ReplaceAllLiteralString(escaped, "[^/]*")
+}
+
+// CollectTapRoutes returns the routes of the requests of a tap, until its
+// stream ends.
+func CollectTapRoutes(tapClient pb.Api_TapByResourceClient) ([]*TapRoute, error) {
+	routes := make(map[string]*TapRoute)
+	// the routes of the requests waiting for their response
+	pending := make(map[string]*TapRoute)
+	// stream ids are only unique within a proxy
+	streamKey := func(event *pb.TapEvent, id *pb.TapEvent_Http_StreamId) string {
+		return fmt.Sprintf("%d:%d %s %s %s", id.GetBase(), id.GetStream(), event.GetProxyDirection(),
+			addr.PublicAddressToString(event.GetSource()), addr.PublicAddressToString(event.GetDestination()))
+	}
+
+	for {
+		event, err := tapClient.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return routeList(routes), err
+		}
+
+		switch ev := event.GetHttp().GetEvent().(type) {
+		case *pb.TapEvent_Http_RequestInit_:
+			method := tapMethod(ev.RequestInit.GetMethod())
+			path := strings.SplitN(ev.RequestInit.GetPath(), "?", 2)[0]
+			key := method + " " + path
+			route, ok := routes[key]
+			if !ok {
+				route = &TapRoute{
+					Method:   method,
+					Segments: strings.Split(path, "/"),
+					Statuses: make(map[uint32]bool),
+				}
+				routes[key] = route
+			}
+			route.Requests++
+			if len(pending) < maxTapRoutePending {
+				pending[streamKey(event, ev.RequestInit.GetId())] = route
+			}
+
+		case *pb.TapEvent_Http_ResponseInit_:
+			key := streamKey(event, ev.ResponseInit.GetId())
+			if route, ok := pending[key]; ok {
+				route.Statuses[ev.ResponseInit.GetHttpStatus()] = true
+				delete(pending, key)
+			}
+
+		case *pb.TapEvent_Http_ResponseEnd_:
+			delete(pending, streamKey(event, ev.ResponseEnd.GetId()))
+		}
+	}
+
+	return routeList(routes), nil
+}
+
+func tapMethod(method *pb.HttpMethod) string {
+	if unregistered := method.GetUnregistered(); unregistered != "" {
+		return unregistered
+	}
+	return method.GetRegistered().String()
+}
+
+func routeList(routes map[string]*TapRoute) []*TapRoute {
+	list := make([]*TapRoute, 0, len(routes))
+	for _, route := range routes {
+		list = append(list, route)
+	}
+	return list
+}
+
+// TapRouteSpecs collapses the observed paths into parameterized routes, and
+// returns the specs of the routeLimit most requested ones, sorted by name.
+func TapRouteSpecs(routes []*TapRoute, paramThreshold, routeLimit uint) []*sp.RouteSpec {
+	names, merged := MergeTapRoutes(routes, paramThreshold, routeLimit)
+	sort.Strings(names)
+
+	specs := make([]*sp.RouteSpec, 0, len(names))
+	for _, name := range names {
+		route := merged[name]
+		specs = append(specs, &sp.RouteSpec{
+			Name: name,
+			Condition: &sp.RequestMatch{
+				PathRegex: PathToRegex(route.Path()),
+				Method:    route.Method,
+			},
+			ResponseClasses: toTapRspClasses(route.Statuses),
+		})
+	}
+	return specs
+}
+
+// MergeTapRoutes collapses the observed paths into parameterized routes, and
+// returns the names of the routeLimit most requested ones, most requested
+// first, along with the routes by name.
+func MergeTapRoutes(routes []*TapRoute, paramThreshold, routeLimit uint) ([]string, map[string]*TapRoute) {
+	collapseTapRoutes(routes, paramThreshold)
+
+	// merge the routes collapsed into the same template
+	merged := make(map[string]*TapRoute)
+	for _, route := range routes {
+		name := route.Method + " " + route.Path()
+		if m, ok := merged[name]; ok {
+			m.Requests += route.Requests
+			for status := range route.Statuses {
+				m.Statuses[status] = true
+			}
+			continue
+		}
+		merged[name] = route
+	}
+
+	names := make([]string, 0, len(merged))
+	for name := range merged {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if merged[names[i]].Requests != merged[names[j]].Requests {
+			return merged[names[i]].Requests > merged[names[j]].Requests
+		}
+		return names[i] < names[j]
+	})
+	if routeLimit > 0 && uint(len(names)) > routeLimit {
+		names = names[:routeLimit]
+	}
+	return names, merged
+}
+
+// collapseTapRoutes replaces the segments of the paths of the routes which
+// look like identifiers with {id}, then, from the second segment to the last,
+// the segments taking at least paramThreshold values among the paths of the
+// same method, length and parent segments with {param}. The first segments
+// are kept, as they usually name the resources of the API rather than
+// parameters.
+func collapseTapRoutes(routes []*TapRoute, paramThreshold uint) {
+	maxSegments := 0
+	for _, route := range routes {
+		for i, segment := range route.Segments {
+			if tapIDSegmentRegex.MatchString(segment) {
+				route.Segments[i] = tapIDParam
+			}
+		}
+		if len(route.Segments) > maxSegments {
+			maxSegments = len(route.Segments)
+		}
+	}
+	if paramThreshold == 0 {
+		return
+	}
+
+	// the paths start with a /, so their first segment is empty
+	for i := 2; i < maxSegments; i++ {
+		// the values of the segment under each parent
+		values := make(map[string]map[string]bool)
+		parent := func(route *TapRoute) string {
+			return fmt.Sprintf("%s %d %s", route.Method, len(route.Segments), strings.Join(route.Segments[:i], "/"))
+		}
+		for _, route := range routes {
+			if i >= len(route.Segments) || isTapParam(route.Segments[i]) {
+				continue
+			}
+			p := parent(route)
+			if values[p] == nil {
+				values[p] = make(map[string]bool)
+			}
+			values[p][route.Segments[i]] = true
+		}
+		for _, route := range routes {
+			if i < len(route.Segments) && !isTapParam(route.Segments[i]) && uint(len(values[parent(route)])) >= paramThreshold {
+				route.Segments[i] = tapGenericParam
+			}
+		}
+	}
+}
+
+func isTapParam(segment string) bool {
+	return segment == tapIDParam || segment == tapGenericParam
+}
+
+// toTapRspClasses returns a response class for each observed status, the
+// server errors being failures.
+func toTapRspClasses(statuses map[uint32]bool) []*sp.ResponseClass {
+	if len(statuses) == 0 {
+		return nil
+	}
+
+	sorted := make([]int, 0, len(statuses))
+	for status := range statuses {
+		sorted = append(sorted, int(status))
+	}
+	sort.Ints(sorted)
+
+	classes := make([]*sp.ResponseClass, 0, len(sorted))
+	for _, status := range sorted {
+		classes = append(classes, &sp.ResponseClass{
+			Condition: &sp.ResponseMatch{
+				Status: &sp.Range{
+					Min: uint32(status),
+					Max: uint32(status),
+				},
+			},
+			IsFailure: status >= 500,
+		})
+	}
+	return classes
+}
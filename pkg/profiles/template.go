@@ -1,13 +1,19 @@
 package profiles
 
 // Template provides the base template for the `linkerd profile --template` command.
-const Template = `### ServiceProfile for {{.ServiceName}}.{{.ServiceNamespace}} ###
+const Template = `### ServiceProfile for {{.Target}} ###
 apiVersion: linkerd.io/v1alpha1
 kind: ServiceProfile
 metadata:
-  name: {{.ServiceName}}.{{.ServiceNamespace}}.{{.ClusterZone}}
+  name: {{.ProfileName}}
   namespace: {{.ControlPlaneNamespace}}
 spec:
+  # A service profile may inherit the routes of a base profile of the same
+  # namespace, e.g. the routes shared by the services of a team.  The routes
+  # of this profile are matched first, and override the routes of the base
+  # profile with the same name.
+  # baseProfile: defaults
+
   # A service profile defines a list of routes.  Linkerd can aggregate metrics
   # like request volume, latency, and success rate by route.
   routes:
@@ -67,4 +73,14 @@ spec:
       # The response class defines whether responses should be counted as
       # successes or failures.
       isFailure: true
+
+    # A route may optionally define a service level objective, whose current
+    # compliance and remaining error budget are reported by 'linkerd slo'.
+    # slo:
+    #   # The objective ratio of successful responses.
+    #   successRate: 0.999
+    #   # The objective p99 latency of the responses, in milliseconds.
+    #   latencyMs: 300
+    #   # The rolling window of the objective.
+    #   window: 30d
 `
@@ -0,0 +1,211 @@
+package prometheus
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	promApi "github.com/prometheus/client_golang/api"
+	"github.com/prometheus/common/model"
+)
+
+// ClientConfig configures the Prometheus API client of the control plane,
+// including the credentials it authenticates with when Prometheus sits behind
+// an auth proxy. At most one of BearerTokenFile and BasicAuthUsername may be
+// set. Credential files are read on every request, so that rotated
+// credentials are picked up without a restart.
+type ClientConfig struct {
+	Address string
+
+	BearerTokenFile       string
+	BasicAuthUsername     string
+	BasicAuthPasswordFile string
+
+	// CAFile verifies the Prometheus server certificate, instead of the system
+	// roots.
+	CAFile string
+	// CertFile and KeyFile are the client certificate presented to Prometheus.
+	CertFile string
+	KeyFile  string
+
+	// Step, MaxSourceResolution and PartialResponse are sent with every query,
+	// for Thanos, Cortex and Mimir backends serving downsampled data. Step is
+	// the resolution the query frontends align and pick the downsampling level
+	// with, MaxSourceResolution is "auto" or the coarsest downsampling level to
+	// read, and PartialResponse is "true" or "false" to tolerate, or not,
+	// unavailable store nodes. Empty values leave the backend defaults.
+	Step                string
+	MaxSourceResolution string
+	PartialResponse     string
+}
+
+// NewClient returns a Prometheus API client for the config.
+func NewClient(config ClientConfig) (promApi.Client, error) {
+	rt, err := newRoundTripper(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return promApi.NewClient(promApi.Config{
+		Address:      config.Address,
+		RoundTripper: rt,
+	})
+}
+
+func newRoundTripper(config ClientConfig) (http.RoundTripper, error) {
+	if config.BearerTokenFile != "" && config.BasicAuthUsername != "" {
+		return nil, fmt.Errorf("a bearer token and basic auth credentials cannot both be configured")
+	}
+	if config.BasicAuthUsername == "" && config.BasicAuthPasswordFile != "" {
+		return nil, fmt.Errorf("a basic auth password requires a basic auth username")
+	}
+	if (config.CertFile == "") != (config.KeyFile == "") {
+		return nil, fmt.Errorf("a client certificate requires both a certificate and a key file")
+	}
+	params, err := queryParams(config)
+	if err != nil {
+		return nil, err
+	}
+
+	tlsConfig := &tls.Config{}
+	if config.CAFile != "" {
+		pem, err := ioutil.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", config.CAFile)
+		}
+	}
+	if config.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	// same settings as promApi.DefaultRoundTripper
+	var rt http.RoundTripper = &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSHandshakeTimeout: 10 * time.Second,
+		TLSClientConfig:     tlsConfig,
+	}
+
+	if config.BearerTokenFile != "" || config.BasicAuthUsername != "" {
+		rt = &authRoundTripper{config: config, next: rt}
+	}
+	if len(params) > 0 {
+		rt = &queryParamsRoundTripper{params: params, next: rt}
+	}
+
+	return rt, nil
+}
+
+// authRoundTripper sets the Authorization header of the requests to
+// Prometheus.
+type authRoundTripper struct {
+	config ClientConfig
+	next   http.RoundTripper
+}
+
+func (a *authRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	// a RoundTripper must not modify the caller's request
+	req2 := new(http.Request)
+	*req2 = *req
+	req2.Header = make(http.Header, len(req.Header))
+	for k, v := range req.Header {
+		req2.Header[k] = v
+	}
+
+	if a.config.BearerTokenFile != "" {
+		token, err := readSecretFile(a.config.BearerTokenFile)
+		if err != nil {
+			return nil, err
+		}
+		req2.Header.Set("Authorization", "Bearer "+token)
+	} else {
+		password := ""
+		if a.config.BasicAuthPasswordFile != "" {
+			var err error
+			password, err = readSecretFile(a.config.BasicAuthPasswordFile)
+			if err != nil {
+				return nil, err
+			}
+		}
+		req2.SetBasicAuth(a.config.BasicAuthUsername, password)
+	}
+
+	return a.next.RoundTrip(req2)
+}
+
+// queryParams validates the query settings of the config, and returns them as
+// the URL query parameters of the backends.
+func queryParams(config ClientConfig) (url.Values, error) {
+	params := url.Values{}
+	if config.Step != "" {
+		if _, err := model.ParseDuration(config.Step); err != nil {
+			return nil, fmt.Errorf("invalid query step: %s", err)
+		}
+		params.Set("step", config.Step)
+	}
+	if config.MaxSourceResolution != "" {
+		if config.MaxSourceResolution != "auto" {
+			if _, err := model.ParseDuration(config.MaxSourceResolution); err != nil {
+				return nil, fmt.Errorf("invalid max source resolution: %s", err)
+			}
+		}
+		params.Set("max_source_resolution", config.MaxSourceResolution)
+	}
+	if config.PartialResponse != "" {
+		if _, err := strconv.ParseBool(config.PartialResponse); err != nil {
+			return nil, fmt.Errorf("invalid partial response setting: %s", config.PartialResponse)
+		}
+		params.Set("partial_response", config.PartialResponse)
+	}
+	return params, nil
+}
+
+// queryParamsRoundTripper adds the query settings to the requests to
+// Prometheus. Prometheus itself ignores the parameters it doesn't know.
+type queryParamsRoundTripper struct {
+	params url.Values
+	next   http.RoundTripper
+}
+
+func (q *queryParamsRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	req2 := new(http.Request)
+	*req2 = *req
+	u := *req.URL
+	req2.URL = &u
+
+	values := u.Query()
+	for k, v := range q.params {
+		if _, ok := values[k]; !ok {
+			values[k] = v
+		}
+	}
+	req2.URL.RawQuery = values.Encode()
+
+	return q.next.RoundTrip(req2)
+}
+
+func readSecretFile(path string) (string, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
@@ -0,0 +1,136 @@
+package prometheus
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestNewRoundTripper(t *testing.T) {
+	dir, err := ioutil.TempDir("", "prometheus-client")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	secretFile := filepath.Join(dir, "secret")
+	err = ioutil.WriteFile(secretFile, []byte("s3cr3t\n"), 0600)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		config        ClientConfig
+		authorization string
+	}{
+		{
+			ClientConfig{},
+			"",
+		},
+		{
+			ClientConfig{BearerTokenFile: secretFile},
+			"Bearer s3cr3t",
+		},
+		{
+			ClientConfig{BasicAuthUsername: "linkerd", BasicAuthPasswordFile: secretFile},
+			"Basic bGlua2VyZDpzM2NyM3Q=",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc // pin
+		t.Run(tc.authorization, func(t *testing.T) {
+			var authorization string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				authorization = r.Header.Get("Authorization")
+			}))
+			defer server.Close()
+
+			rt, err := newRoundTripper(tc.config)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			req, err := http.NewRequest("GET", server.URL, nil)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			rsp, err := rt.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			rsp.Body.Close()
+
+			if authorization != tc.authorization {
+				t.Fatalf("Expected Authorization header [%s], got [%s]", tc.authorization, authorization)
+			}
+			if req.Header.Get("Authorization") != "" {
+				t.Fatalf("Expected the original request to be left unmodified")
+			}
+		})
+	}
+
+	t.Run("Rejects conflicting credentials", func(t *testing.T) {
+		_, err := newRoundTripper(ClientConfig{BearerTokenFile: secretFile, BasicAuthUsername: "linkerd"})
+		if err == nil {
+			t.Fatalf("Expected an error, got none")
+		}
+	})
+}
+
+func TestQueryParams(t *testing.T) {
+	t.Run("Adds the query settings to the requests", func(t *testing.T) {
+		var query url.Values
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.Query()
+		}))
+		defer server.Close()
+
+		rt, err := newRoundTripper(ClientConfig{
+			Step:                "5m",
+			MaxSourceResolution: "auto",
+			PartialResponse:     "true",
+		})
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		req, err := http.NewRequest("GET", server.URL+"/api/v1/query?query=up", nil)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		rsp, err := rt.RoundTrip(req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		rsp.Body.Close()
+
+		expected := url.Values{
+			"query":                 []string{"up"},
+			"step":                  []string{"5m"},
+			"max_source_resolution": []string{"auto"},
+			"partial_response":      []string{"true"},
+		}
+		if !reflect.DeepEqual(query, expected) {
+			t.Fatalf("Expected query %v, got %v", expected, query)
+		}
+	})
+
+	t.Run("Rejects invalid query settings", func(t *testing.T) {
+		configs := []ClientConfig{
+			{Step: "5 minutes"},
+			{MaxSourceResolution: "raw"},
+			{PartialResponse: "sometimes"},
+		}
+		for _, config := range configs {
+			if _, err := newRoundTripper(config); err == nil {
+				t.Fatalf("Expected an error for %+v, got none", config)
+			}
+		}
+	})
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"net"
 
 	"github.com/linkerd/linkerd2/proxy-init/iptables"
 	"github.com/spf13/cobra"
@@ -14,6 +15,7 @@ type rootOptions struct {
 	portsToRedirect       []int
 	inboundPortsToIgnore  []int
 	outboundPortsToIgnore []int
+	outboundCIDRsToIgnore []string
 	simulateOnly          bool
 }
 
@@ -25,6 +27,7 @@ func newRootOptions() *rootOptions {
 		portsToRedirect:       make([]int, 0),
 		inboundPortsToIgnore:  make([]int, 0),
 		outboundPortsToIgnore: make([]int, 0),
+		outboundCIDRsToIgnore: make([]string, 0),
 		simulateOnly:          false,
 	}
 }
@@ -51,6 +54,7 @@ func NewRootCmd() *cobra.Command {
 	cmd.PersistentFlags().IntSliceVarP(&options.portsToRedirect, "ports-to-redirect", "r", options.portsToRedirect, "Port to redirect to proxy, if no port is specified then ALL ports are redirected")
 	cmd.PersistentFlags().IntSliceVar(&options.inboundPortsToIgnore, "inbound-ports-to-ignore", options.inboundPortsToIgnore, "Inbound ports to ignore and not redirect to proxy. This has higher precedence than any other parameters.")
 	cmd.PersistentFlags().IntSliceVar(&options.outboundPortsToIgnore, "outbound-ports-to-ignore", options.outboundPortsToIgnore, "Outbound ports to ignore and not redirect to proxy. This has higher precedence than any other parameters.")
+	cmd.PersistentFlags().StringSliceVar(&options.outboundCIDRsToIgnore, "outbound-cidrs-to-ignore", options.outboundCIDRsToIgnore, "Outbound destination CIDRs to ignore and not redirect to proxy, e.g. 169.254.169.254/32. This has higher precedence than any other parameters.")
 	cmd.PersistentFlags().BoolVar(&options.simulateOnly, "simulate", options.simulateOnly, "Don't execute any command, just print what would be executed")
 
 	return cmd
@@ -65,6 +69,12 @@ func buildFirewallConfiguration(options *rootOptions) (*iptables.FirewallConfigu
 		return nil, fmt.Errorf("--outgoing-proxy-port must be a valid TCP port number")
 	}
 
+	for _, cidr := range options.outboundCIDRsToIgnore {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return nil, fmt.Errorf("--outbound-cidrs-to-ignore must be a list of valid CIDRs, got %s", cidr)
+		}
+	}
+
 	firewallConfiguration := &iptables.FirewallConfiguration{
 		ProxyInboundPort:       options.incomingProxyPort,
 		ProxyOutgoingPort:      options.outgoingProxyPort,
@@ -72,6 +82,7 @@ func buildFirewallConfiguration(options *rootOptions) (*iptables.FirewallConfigu
 		PortsToRedirectInbound: options.portsToRedirect,
 		InboundPortsToIgnore:   options.inboundPortsToIgnore,
 		OutboundPortsToIgnore:  options.outboundPortsToIgnore,
+		OutboundCIDRsToIgnore:  options.outboundCIDRsToIgnore,
 		SimulateOnly:           options.simulateOnly,
 	}
 
@@ -17,6 +17,7 @@ func TestBuildFirewallConfiguration(t *testing.T) {
 			PortsToRedirectInbound: make([]int, 0),
 			InboundPortsToIgnore:   make([]int, 0),
 			OutboundPortsToIgnore:  make([]int, 0),
+			OutboundCIDRsToIgnore:  make([]string, 0),
 			ProxyInboundPort:       expectedIncomingProxyPort,
 			ProxyOutgoingPort:      expectedOutgoingProxyPort,
 			ProxyUid:               expectedProxyUserId,
@@ -71,6 +72,14 @@ func TestBuildFirewallConfiguration(t *testing.T) {
 				},
 				errorMessage: "--outgoing-proxy-port must be a valid TCP port number",
 			},
+			{
+				options: &rootOptions{
+					incomingProxyPort:     1234,
+					outgoingProxyPort:     2345,
+					outboundCIDRsToIgnore: []string{"169.254.169.254"},
+				},
+				errorMessage: "--outbound-cidrs-to-ignore must be a list of valid CIDRs, got 169.254.169.254",
+			},
 		} {
 			_, err := buildFirewallConfiguration(tt.options)
 			if err == nil {
@@ -25,10 +25,14 @@ type FirewallConfiguration struct {
 	PortsToRedirectInbound []int
 	InboundPortsToIgnore   []int
 	OutboundPortsToIgnore  []int
+	OutboundCIDRsToIgnore  []string
 	ProxyInboundPort       int
 	ProxyOutgoingPort      int
 	ProxyUid               int
 	SimulateOnly           bool
+	// NetNs is the path of the network namespace to configure, when it isn't
+	// the one of the process, e.g. for the linkerd-cni plugin.
+	NetNs string
 }
 
 //ConfigureFirewall configures a pod's internal iptables to redirect all desired traffic through the proxy, allowing for
@@ -93,6 +97,8 @@ func addOutgoingTrafficRules(commands []*exec.Cmd, firewallConfiguration Firewal
 	commands = append(commands, makeIgnoreLoopback(outputChainName, "ignore-loopback"))
 	// Ignore ports
 	commands = addRulesForIgnoredPorts(firewallConfiguration.OutboundPortsToIgnore, outputChainName, commands)
+	// Ignore destination networks
+	commands = addRulesForIgnoredCIDRs(firewallConfiguration.OutboundCIDRsToIgnore, outputChainName, commands)
 
 	log.Printf("Redirecting all OUTPUT to %d", firewallConfiguration.ProxyOutgoingPort)
 	commands = append(commands, makeRedirectChainToPort(outputChainName, firewallConfiguration.ProxyOutgoingPort, "redirect-all-outgoing-to-proxy-port"))
@@ -146,8 +152,21 @@ func addRulesForIgnoredPorts(portsToIgnore []int, chainName string, commands []*
 	return commands
 }
 
+func addRulesForIgnoredCIDRs(cidrsToIgnore []string, chainName string, commands []*exec.Cmd) []*exec.Cmd {
+	for _, ignoredCIDR := range cidrsToIgnore {
+		log.Printf("Will ignore destination %s on chain %s", ignoredCIDR, chainName)
+
+		commands = append(commands, makeIgnoreDestination(chainName, ignoredCIDR, fmt.Sprintf("ignore-destination-%s", ignoredCIDR)))
+	}
+	return commands
+}
+
 func executeCommand(firewallConfiguration FirewallConfiguration, cmd *exec.Cmd) error {
 
+	if firewallConfiguration.NetNs != "" {
+		cmd = exec.Command("nsenter", append([]string{"--net=" + firewallConfiguration.NetNs, "--"}, cmd.Args...)...)
+	}
+
 	log.Printf("> %s", strings.Trim(fmt.Sprintf("%v", cmd.Args), "[]"))
 
 	if !firewallConfiguration.SimulateOnly {
@@ -213,6 +232,16 @@ func makeIgnorePort(chainName string, portToIgnore int, comment string) *exec.Cm
 		"--comment", formatComment(comment))
 }
 
+func makeIgnoreDestination(chainName string, cidrToIgnore string, comment string) *exec.Cmd {
+	return exec.Command("iptables",
+		"-t", "nat",
+		"-A", chainName,
+		"-d", cidrToIgnore,
+		"-j", "RETURN",
+		"-m", "comment",
+		"--comment", formatComment(comment))
+}
+
 func makeIgnoreLoopback(chainName string, comment string) *exec.Cmd {
 	return exec.Command("iptables",
 		"-t", "nat",
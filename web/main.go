@@ -26,6 +26,7 @@ func main() {
 	reload := flag.Bool("reload", true, "reloading set to true or false")
 	webpackDevServer := flag.String("webpack-dev-server", "", "use webpack to serve static assets; frontend will use this instead of static-dir")
 	controllerNamespace := flag.String("controller-namespace", "linkerd", "namespace in which Linkerd is installed")
+	tapAccessPolicyPath := flag.String("tap-access-policy", "", "path to the YAML policy of the consumers of the /api/tap-stream WebSocket endpoint; disabled if empty")
 	flags.ConfigureAndParse()
 
 	_, _, err := net.SplitHostPort(*kubernetesApiHost) // Verify kubernetesApiHost is of the form host:port.
@@ -40,7 +41,15 @@ func main() {
 	stop := make(chan os.Signal, 1)
 	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
 
-	server := srv.NewServer(*addr, *templateDir, *staticDir, *uuid, *controllerNamespace, *webpackDevServer, *reload, client)
+	var tapPolicy *public.TapAccessPolicy
+	if *tapAccessPolicyPath != "" {
+		tapPolicy, err = public.LoadTapAccessPolicy(*tapAccessPolicyPath)
+		if err != nil {
+			log.Fatalf("failed to load the tap access policy: %s", err)
+		}
+	}
+
+	server := srv.NewServer(*addr, *templateDir, *staticDir, *uuid, *controllerNamespace, *webpackDevServer, *reload, client, tapPolicy)
 
 	go func() {
 		log.Infof("starting HTTP server on %+v", *addr)
@@ -2,9 +2,14 @@ package srv
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/golang/protobuf/jsonpb"
@@ -31,6 +36,13 @@ var (
 		ReadBufferSize:  maxMessageSize,
 		WriteBufferSize: maxMessageSize,
 	}
+	// the tap stream endpoint is embedded by the dashboards of other origins,
+	// and authenticates its consumers with tokens rather than cookies
+	tapStreamUpgrader = websocket.Upgrader{
+		ReadBufferSize:  maxMessageSize,
+		WriteBufferSize: maxMessageSize,
+		CheckOrigin:     func(*http.Request) bool { return true },
+	}
 )
 
 func renderJsonError(w http.ResponseWriter, err error, status int) {
@@ -127,7 +139,9 @@ func (h *handler) handleApiStat(w http.ResponseWriter, req *http.Request, p http
 		return
 	}
 
-	result, err := h.apiClient.StatSummary(req.Context(), statRequest)
+	// the stats are requested page by page, so that each response stays small
+	// on clusters with many resources
+	result, err := util.GetStatSummary(req.Context(), h.apiClient, statRequest)
 	if err != nil {
 		renderJsonError(w, err, http.StatusInternalServerError)
 		return
@@ -198,8 +212,112 @@ func (h *handler) handleApiTap(w http.ResponseWriter, req *http.Request, p httpr
 		return
 	}
 
+	streamTapEvents(req.Context(), ws, h.apiClient, tapReq)
+}
+
+// handleApiTapStream streams the events of a tap, selected by the query
+// parameters, to the consumers of the tap access policy. The parameters are
+// named after the flags of "linkerd tap", e.g.
+// /api/tap-stream?resource=deploy/web&namespace=emojivoto&status=5xx
+//
+// Consumers authenticate with a bearer token, in the Authorization header or,
+// for browsers which can't set the headers of WebSockets, in the access_token
+// parameter.
+func (h *handler) handleApiTapStream(w http.ResponseWriter, req *http.Request, p httprouter.Params) {
+	if h.tapPolicy == nil {
+		http.NotFound(w, req)
+		return
+	}
+
+	query := req.URL.Query()
+	if token := query.Get("access_token"); token != "" && req.Header.Get("Authorization") == "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	consumer, err := h.tapPolicy.Authenticate(req)
+	if err != nil {
+		renderJsonError(w, err, http.StatusUnauthorized)
+		return
+	}
+
+	requestParams, err := tapRequestParamsFromQuery(query)
+	if err != nil {
+		renderJsonError(w, err, http.StatusBadRequest)
+		return
+	}
+	tapReq, err := util.BuildTapByResourceRequest(requestParams)
+	if err != nil {
+		renderJsonError(w, err, http.StatusBadRequest)
+		return
+	}
+	if err := consumer.Authorize(tapReq); err != nil {
+		renderJsonError(w, err, http.StatusForbidden)
+		return
+	}
+	log.Infof("tap consumer %s streaming the tap of %+v", consumer.Name, tapReq.GetTarget().GetResource())
+
+	ws, err := tapStreamUpgrader.Upgrade(w, req, nil)
+	if err != nil {
+		// the upgrader already replied with an HTTP error
+		log.Error(err)
+		return
+	}
+	defer ws.Close()
+
+	streamTapEvents(req.Context(), ws, h.apiClient, tapReq)
+}
+
+// tapRequestParamsFromQuery returns the parameters of a tap request from the
+// query parameters of a request.
+func tapRequestParamsFromQuery(query url.Values) (util.TapRequestParams, error) {
+	params := util.TapRequestParams{
+		Resource:    query.Get("resource"),
+		Namespace:   query.Get("namespace"),
+		ToResource:  query.Get("to"),
+		ToNamespace: query.Get("to-namespace"),
+		Scheme:      query.Get("scheme"),
+		Method:      query.Get("method"),
+		Authority:   query.Get("authority"),
+		Path:        query.Get("path"),
+		RPCMethod:   query.Get("rpc-method"),
+	}
+	if params.Resource == "" {
+		return params, fmt.Errorf("the resource parameter is required, e.g. resource=deploy/web")
+	}
+	if params.Namespace == "" {
+		params.Namespace = "default"
+	}
+
+	// the status classes may be repeated or separated with commas, like the
+	// --status flag
+	for _, status := range query["status"] {
+		for _, class := range strings.Split(status, ",") {
+			if class != "" {
+				params.StatusClasses = append(params.StatusClasses, class)
+			}
+		}
+	}
+	if minLatency := query.Get("min-latency"); minLatency != "" {
+		d, err := time.ParseDuration(minLatency)
+		if err != nil {
+			return params, fmt.Errorf("invalid min-latency parameter: %s", err)
+		}
+		params.MinLatency = d
+	}
+	if maxRps := query.Get("max-rps"); maxRps != "" {
+		rps, err := strconv.ParseFloat(maxRps, 32)
+		if err != nil {
+			return params, fmt.Errorf("invalid max-rps parameter: %s", err)
+		}
+		params.MaxRps = float32(rps)
+	}
+	return params, nil
+}
+
+// streamTapEvents writes the events of the tap to the WebSocket as JSON
+// messages, until either the tap or the WebSocket is closed.
+func streamTapEvents(ctx context.Context, ws *websocket.Conn, apiClient pb.ApiClient, tapReq *pb.TapByResourceRequest) {
 	go func() {
-		tapClient, err := h.apiClient.TapByResource(req.Context(), tapReq)
+		tapClient, err := apiClient.TapByResource(ctx, tapReq)
 		if err != nil {
 			websocketError(ws, websocket.CloseInternalServerErr, err.Error())
 			return
@@ -3,12 +3,16 @@ package srv
 import (
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/julienschmidt/httprouter"
 	"github.com/linkerd/linkerd2/controller/api/public"
+	"github.com/linkerd/linkerd2/controller/api/util"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 )
 
@@ -53,3 +57,115 @@ func TestHandleApiVersion(t *testing.T) {
 		t.Errorf("Expected to find: %+v", expectedVersionJson)
 	}
 }
+
+// tapStreamClient is a tap stream without gRPC stream, to be closed by the
+// handlers.
+type tapStreamClient struct {
+	*public.MockApi_TapByResourceClient
+}
+
+func (c *tapStreamClient) CloseSend() error {
+	return nil
+}
+
+func TestHandleApiTapStream(t *testing.T) {
+	policy := &public.TapAccessPolicy{
+		Consumers: []public.TapConsumer{{Name: "dashboard", Token: "s3cr3t", Namespaces: []string{"emojivoto"}}},
+	}
+	newServer := func() *httptest.Server {
+		mockApiClient := &public.MockApiClient{
+			Api_TapByResourceClientToReturn: &tapStreamClient{&public.MockApi_TapByResourceClient{
+				TapEventsToReturn: []pb.TapEvent{{ProxyDirection: pb.TapEvent_INBOUND}},
+			}},
+		}
+		handler := &handler{apiClient: mockApiClient, tapPolicy: policy}
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			handler.handleApiTapStream(w, req, httprouter.Params{})
+		}))
+	}
+
+	t.Run("Streams the events of the tap to the consumers", func(t *testing.T) {
+		server := newServer()
+		defer server.Close()
+
+		url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/tap-stream?resource=deploy/web&namespace=emojivoto&status=5xx&access_token=s3cr3t"
+		ws, _, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": []string{"https://dashboard.example.com"}})
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		defer ws.Close()
+
+		_, message, err := ws.ReadMessage()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if !strings.Contains(string(message), `"proxyDirection":"INBOUND"`) {
+			t.Fatalf("Unexpected message: %s", message)
+		}
+	})
+
+	t.Run("Rejects the requests without a valid token", func(t *testing.T) {
+		server := newServer()
+		defer server.Close()
+
+		rsp, err := http.Get(server.URL + "/api/tap-stream?resource=deploy/web&namespace=emojivoto&access_token=wrong")
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if rsp.StatusCode != http.StatusUnauthorized {
+			t.Fatalf("Expected status %d, got %d", http.StatusUnauthorized, rsp.StatusCode)
+		}
+	})
+
+	t.Run("Rejects the taps of the namespaces the consumer isn't allowed", func(t *testing.T) {
+		server := newServer()
+		defer server.Close()
+
+		req, _ := http.NewRequest("GET", server.URL+"/api/tap-stream?resource=deploy/web&namespace=kube-system", nil)
+		req.Header.Set("Authorization", "Bearer s3cr3t")
+		rsp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if rsp.StatusCode != http.StatusForbidden {
+			t.Fatalf("Expected status %d, got %d", http.StatusForbidden, rsp.StatusCode)
+		}
+	})
+
+	t.Run("Is disabled without a tap access policy", func(t *testing.T) {
+		handler := &handler{}
+		recorder := httptest.NewRecorder()
+		handler.handleApiTapStream(recorder, httptest.NewRequest("GET", "/api/tap-stream?resource=deploy/web", nil), httprouter.Params{})
+		if recorder.Code != http.StatusNotFound {
+			t.Fatalf("Expected status %d, got %d", http.StatusNotFound, recorder.Code)
+		}
+	})
+}
+
+func TestTapRequestParamsFromQuery(t *testing.T) {
+	query, _ := url.ParseQuery("resource=deploy/web&method=POST&path=/api&status=4xx,5xx&status=2xx&min-latency=200ms&max-rps=10")
+	params, err := tapRequestParamsFromQuery(query)
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	expected := util.TapRequestParams{
+		Resource:      "deploy/web",
+		Namespace:     "default",
+		Method:        "POST",
+		Path:          "/api",
+		StatusClasses: []string{"4xx", "5xx", "2xx"},
+		MinLatency:    200 * time.Millisecond,
+		MaxRps:        10,
+	}
+	if !reflect.DeepEqual(params, expected) {
+		t.Fatalf("Expected %+v, got %+v", expected, params)
+	}
+
+	for _, invalid := range []string{"namespace=emojivoto", "resource=deploy/web&min-latency=fast", "resource=deploy/web&max-rps=many"} {
+		query, _ := url.ParseQuery(invalid)
+		if _, err := tapRequestParamsFromQuery(query); err == nil {
+			t.Fatalf("Expected an error for %s", invalid)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"regexp"
 
 	"github.com/julienschmidt/httprouter"
+	"github.com/linkerd/linkerd2/controller/api/public"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	profiles "github.com/linkerd/linkerd2/pkg/profiles"
 	log "github.com/sirupsen/logrus"
@@ -24,6 +25,9 @@ type (
 		apiClient           pb.ApiClient
 		uuid                string
 		controllerNamespace string
+		// the consumers of the tap stream endpoint; the endpoint is disabled
+		// when nil
+		tapPolicy *public.TapAccessPolicy
 	}
 )
 
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/julienschmidt/httprouter"
+	"github.com/linkerd/linkerd2/controller/api/public"
 	pb "github.com/linkerd/linkerd2/controller/gen/public"
 	"github.com/linkerd/linkerd2/pkg/filesonly"
 	"github.com/linkerd/linkerd2/pkg/prometheus"
@@ -51,7 +52,9 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 	s.router.ServeHTTP(w, req)
 }
 
-func NewServer(addr, templateDir, staticDir, uuid, controllerNamespace, webpackDevServer string, reload bool, apiClient pb.ApiClient) *http.Server {
+// NewServer returns the server of the dashboard. The tap stream endpoint is
+// only served to the consumers of tapPolicy, if not nil.
+func NewServer(addr, templateDir, staticDir, uuid, controllerNamespace, webpackDevServer string, reload bool, apiClient pb.ApiClient, tapPolicy *public.TapAccessPolicy) *http.Server {
 	server := &Server{
 		templateDir:     templateDir,
 		staticDir:       staticDir,
@@ -72,6 +75,7 @@ func NewServer(addr, templateDir, staticDir, uuid, controllerNamespace, webpackD
 		serveFile:           server.serveFile,
 		uuid:                uuid,
 		controllerNamespace: controllerNamespace,
+		tapPolicy:           tapPolicy,
 	}
 
 	httpServer := &http.Server{
@@ -111,6 +115,7 @@ func NewServer(addr, templateDir, staticDir, uuid, controllerNamespace, webpackD
 	server.router.GET("/api/pods", handler.handleApiPods)
 	server.router.GET("/api/services", handler.handleApiServices)
 	server.router.GET("/api/tap", handler.handleApiTap)
+	server.router.GET("/api/tap-stream", handler.handleApiTapStream)
 	server.router.GET("/api/routes", handler.handleApiTopRoutes)
 
 	return httpServer